@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	routev1 "github.com/openshift/api/route/v1"
 
 	"github.com/argoproj-labs/argocd-operator/common"
@@ -24,6 +25,8 @@ import (
 	autoscaling "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -39,6 +42,7 @@ func init() {
 // ArgoCD is the Schema for the argocds API
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 //+operator-sdk:csv:customresourcedefinitions:resources={{ArgoCD,v1alpha1,""}}
 //+operator-sdk:csv:customresourcedefinitions:resources={{ArgoCDExport,v1alpha1,""}}
 //+operator-sdk:csv:customresourcedefinitions:resources={{ConfigMap,v1,""}}
@@ -76,6 +80,10 @@ type ArgoCDApplicationControllerProcessorsSpec struct {
 
 // ArgoCDApplicationControllerSpec defines the options for the ArgoCD Application Controller component.
 type ArgoCDApplicationControllerSpec struct {
+	// Enabled defines whether the Application Controller is deployed. Defaults to true. Disable it for instances that
+	// only run the UI and API, against Application Controllers deployed elsewhere.
+	Enabled *bool `json:"enabled,omitempty"`
+
 	// Processors contains the options for the Application Controller processors.
 	Processors ArgoCDApplicationControllerProcessorsSpec `json:"processors,omitempty"`
 
@@ -100,11 +108,89 @@ type ArgoCDApplicationControllerSpec struct {
 	// +optional
 	AppSync *metav1.Duration `json:"appSync,omitempty"`
 
+	// SelfHealTimeout is used to control the delay between self heal attempts
+	// of automatically synced Applications, by default the ArgoCD controller
+	// waits 5s.
+	//
+	// Set this to a duration, e.g. 30s or 1m to control the self heal timeout.
+	// +optional
+	SelfHealTimeout *metav1.Duration `json:"selfHealTimeout,omitempty"`
+
+	// ReconciliationTimeout is the interval after which Applications are refreshed, set as `timeout.reconciliation` in
+	// the argocd-cm ConfigMap. Must be a duration, e.g. 180s or 3m. A value of 0s disables the periodic refresh.
+	// AppSync takes precedence when set.
+	// +optional
+	ReconciliationTimeout string `json:"reconciliationTimeout,omitempty"`
+
+	// ServerSideDiff toggles the use of server-side diff for all Applications, set as `controller.diff.server.side` in
+	// the argocd-cm ConfigMap.
+	// +optional
+	ServerSideDiff *bool `json:"serverSideDiff,omitempty"`
+
 	// Sharding contains the options for the Application Controller sharding configuration.
 	Sharding ArgoCDApplicationControllerShardSpec `json:"sharding,omitempty"`
 
 	// Env lets you specify environment for application controller pods
 	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Image is the container image of the Application Controller, to pin it to another image than the other
+	// components, e.g. during a staged upgrade. Defaults to the Image of the ArgoCD.
+	Image string `json:"image,omitempty"`
+
+	// Version is the container image tag of the Application Controller, to pin it to another version than the other
+	// components, e.g. during a staged upgrade. Defaults to the Version of the ArgoCD.
+	Version string `json:"version,omitempty"`
+
+	// Metrics defines the metrics options for the Application Controller component.
+	Metrics ArgoCDApplicationControllerMetricsSpec `json:"metrics,omitempty"`
+
+	// Storage defines a persistent volume to use as the working directory of each Application Controller replica.
+	// When not set, the working directory is kept on the container filesystem.
+	Storage *ArgoCDApplicationControllerStorageSpec `json:"storage,omitempty"`
+
+	// Service defines the IP family and traffic policy options for the metrics Service of the Application Controller component.
+	Service ArgoCDServiceSpec `json:"service,omitempty"`
+
+	// ClusterRole defines customizations of the ClusterRole of the Application Controller component when running cluster-scoped.
+	ClusterRole *ArgoCDClusterRoleSpec `json:"clusterRole,omitempty"`
+
+	// CustomRoleName is the name of an existing ClusterRole to bind the Application Controller to in the managed namespaces.
+	// When set, the operator does not create the default Role for the Application Controller. Takes precedence over the
+	// CONTROLLER_CLUSTER_ROLE environment variable of the operator.
+	CustomRoleName string `json:"customRoleName,omitempty"`
+
+	// ServiceAccountAnnotations are added to the ServiceAccount of the Application Controller component, e.g. to
+	// associate it with a cloud identity through IRSA or GKE Workload Identity.
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+}
+
+// ArgoCDClusterRoleSpec defines customizations of the ClusterRole created for an Argo CD component when running cluster-scoped.
+type ArgoCDClusterRoleSpec struct {
+	// Rules replaces the default policy rules of the ClusterRole, allowing a least-privilege set of permissions to be granted.
+	Rules []rbacv1.PolicyRule `json:"rules,omitempty"`
+
+	// Aggregated enables an aggregation rule on the ClusterRole. The rules of the ClusterRole are then composed by
+	// Kubernetes from all ClusterRoles labeled with argocd.argoproj.io/aggregate-to=<ClusterRole name>, and are no longer
+	// managed by the operator.
+	Aggregated bool `json:"aggregated,omitempty"`
+}
+
+// ArgoCDApplicationControllerMetricsSpec defines the metrics options for the Application Controller component.
+type ArgoCDApplicationControllerMetricsSpec struct {
+	// Port is the port on which the Application Controller exposes metrics. Defaults to 8082.
+	Port int32 `json:"port,omitempty"`
+
+	// ServiceMonitor defines the ServiceMonitor options for the Application Controller metrics.
+	ServiceMonitor ArgoCDServiceMonitorSpec `json:"serviceMonitor,omitempty"`
+}
+
+// ArgoCDApplicationControllerStorageSpec defines the persistent volume options for the Application Controller working directory.
+type ArgoCDApplicationControllerStorageSpec struct {
+	// Size is the size of the persistent volume requested for each Application Controller replica. Defaults to 10Gi.
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// StorageClassName is the name of the StorageClass used to provision the persistent volumes. Uses the cluster default when empty.
+	StorageClassName *string `json:"storageClassName,omitempty"`
 }
 
 // ArgoCDApplicationControllerShardSpec defines the options available for enabling sharding for the Application Controller component.
@@ -115,6 +201,44 @@ type ArgoCDApplicationControllerShardSpec struct {
 
 	// Replicas defines the number of replicas to run in the Application controller shard.
 	Replicas int32 `json:"replicas,omitempty"`
+
+	// DynamicScalingEnabled defines whether the number of Application controller shards should be adjusted
+	// automatically based on the number of clusters managed by Argo CD. When enabled, Replicas is ignored.
+	DynamicScalingEnabled *bool `json:"dynamicScalingEnabled,omitempty"`
+
+	// MinShards defines the minimum number of shards when dynamic scaling is enabled. Defaults to 1.
+	//+kubebuilder:validation:Minimum=1
+	MinShards int32 `json:"minShards,omitempty"`
+
+	// MaxShards defines the maximum number of shards when dynamic scaling is enabled. Defaults to MinShards.
+	MaxShards int32 `json:"maxShards,omitempty"`
+
+	// ClustersPerShard defines the number of clusters managed by each shard when dynamic scaling is enabled. Defaults to 1.
+	//+kubebuilder:validation:Minimum=1
+	ClustersPerShard int32 `json:"clustersPerShard,omitempty"`
+
+	// ClusterAssignment defines how the operator assigns the cluster secrets to the Application controller shards.
+	// When not set, the shards are assigned by the Application controller.
+	ClusterAssignment *ArgoCDClusterShardAssignmentSpec `json:"clusterAssignment,omitempty"`
+}
+
+const (
+	// ClusterShardAssignmentRoundRobin assigns each cluster to the shard with the fewest clusters.
+	ClusterShardAssignmentRoundRobin = "RoundRobin"
+
+	// ClusterShardAssignmentLabel assigns the clusters sharing a label value to the same shard.
+	ClusterShardAssignmentLabel = "Label"
+)
+
+// ArgoCDClusterShardAssignmentSpec defines the options for assigning cluster secrets to Application controller shards.
+type ArgoCDClusterShardAssignmentSpec struct {
+	// Strategy is the strategy used to assign clusters to shards, either RoundRobin or Label. Defaults to RoundRobin.
+	//+kubebuilder:validation:Enum=RoundRobin;Label
+	Strategy string `json:"strategy,omitempty"`
+
+	// LabelKey is the key of the cluster secret label whose value groups clusters onto the same shard when the Label
+	// strategy is used. Clusters without the label are assigned individually.
+	LabelKey string `json:"labelKey,omitempty"`
 }
 
 // ArgoCDApplicationSet defines whether the Argo CD ApplicationSet controller should be installed.
@@ -129,6 +253,10 @@ type ArgoCDApplicationSet struct {
 	// will not overwrite the default command line arguments.
 	ExtraCommandArgs []string `json:"extraCommandArgs,omitempty"`
 
+	// EnableProgressiveSyncs enables the progressive syncs feature of the ApplicationSet controller,
+	// allowing the rollout of generated Applications to be ordered in steps.
+	EnableProgressiveSyncs bool `json:"enableProgressiveSyncs,omitempty"`
+
 	// Image is the Argo CD ApplicationSet image (optional)
 	Image string `json:"image,omitempty"`
 
@@ -141,7 +269,150 @@ type ArgoCDApplicationSet struct {
 	// LogLevel describes the log level that should be used by the ApplicationSet controller. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug,info, error, and warn.
 	LogLevel string `json:"logLevel,omitempty"`
 
+	// Metrics defines the metrics options for the ApplicationSet controller.
+	Metrics ArgoCDApplicationSetMetricsSpec `json:"metrics,omitempty"`
+
+	// Replicas defines the number of replicas for the ApplicationSet controller. Default is nil. Value should be greater than or equal to 0.
+	// Leader election is enabled on the controller when more than one replica is requested.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// SCMProviders defines the credentials used by the SCM Provider and Pull Request generators, either tokens used
+	// when no tokenRef is set on the ApplicationSet, or GitHub App credentials referenced through appSecretName.
+	SCMProviders []ArgoCDApplicationSetSCMProviderSpec `json:"scmProviders,omitempty"`
+
 	WebhookServer WebhookServerSpec `json:"webhookServer,omitempty"`
+
+	// Service defines the IP family and traffic policy options for the Service of the ApplicationSet controller component.
+	Service ArgoCDServiceSpec `json:"service,omitempty"`
+
+	// HostAliases adds entries to the hosts file of the ApplicationSet controller pods, e.g. to resolve Git hosts that
+	// are not in the cluster DNS.
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
+	// DNSPolicy is the DNS policy of the ApplicationSet controller pods. Defaults to ClusterFirst.
+	//+kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig adds name servers, search domains and resolver options to the DNS configuration of the ApplicationSet
+	// controller pods. Required when DNSPolicy is None.
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+}
+
+// ArgoCDApplicationSetMetricsSpec defines the metrics options for the ApplicationSet controller.
+type ArgoCDApplicationSetMetricsSpec struct {
+	// Port is the port on which the ApplicationSet controller exposes metrics. Defaults to 8080.
+	Port int32 `json:"port,omitempty"`
+
+	// ServiceMonitor defines the ServiceMonitor options for the ApplicationSet controller metrics.
+	ServiceMonitor ArgoCDServiceMonitorSpec `json:"serviceMonitor,omitempty"`
+}
+
+// ArgoCDApplicationSetSCMProviderSpec defines the credentials of an SCM provider for the ApplicationSet controller.
+type ArgoCDApplicationSetSCMProviderSpec struct {
+	// Provider is the SCM provider the credentials are used for. Valid options are github, gitlab and gitea.
+	//+kubebuilder:validation:Enum=github;gitlab;gitea
+	Provider string `json:"provider"`
+
+	// TokenSecretRef selects the key of a Secret in the namespace of the Argo CD instance that holds the token.
+	TokenSecretRef *corev1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+
+	// GitHubApp authenticates the github provider as an installation of a GitHub App. The operator stores the
+	// credentials in the <argocd-name>-applicationset-github-app Secret, which the SCM Provider and Pull Request
+	// generators reference as their appSecretName. Only supported for the github provider.
+	GitHubApp *ArgoCDGitHubAppCredentialsSpec `json:"githubApp,omitempty"`
+}
+
+// ArgoCDAgentSpec defines the argocd-agent components of an Argo CD instance, used to manage Applications on workload
+// clusters from a control plane cluster. The principal runs on the control plane cluster, next to the Argo CD of the
+// control plane, and the agents run on the workload clusters, next to the Argo CD of each workload cluster.
+type ArgoCDAgentSpec struct {
+	// Principal defines the argocd-agent principal, which the agents of the workload clusters connect to.
+	Principal *ArgoCDAgentPrincipalSpec `json:"principal,omitempty"`
+
+	// Agent defines the argocd-agent agent, which connects the Argo CD of a workload cluster to a principal.
+	Agent *ArgoCDAgentAgentSpec `json:"agent,omitempty"`
+}
+
+// ArgoCDAgentPrincipalSpec defines the options for the argocd-agent principal.
+type ArgoCDAgentPrincipalSpec struct {
+	// Enabled defines whether the principal should be deployed.
+	Enabled bool `json:"enabled"`
+
+	// Image is the argocd-agent container image. Defaults to quay.io/argoprojlabs/argocd-agent.
+	Image string `json:"image,omitempty"`
+
+	// Version is the argocd-agent container image tag.
+	Version string `json:"version,omitempty"`
+
+	// LogLevel is the log level of the principal. Defaults to ArgoCDDefaultLogLevel if not set. Valid options are
+	// debug, info, error, and warn.
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// AllowedNamespaces are the namespaces, or glob patterns of namespaces, the principal is allowed to manage
+	// Applications in. The principal is only granted access to the namespace of the ArgoCD by the operator.
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+
+	// Auth is the authentication method of the agents. Defaults to mtls:CN=([^,]+), which identifies each agent by the
+	// common name of its client certificate.
+	Auth string `json:"auth,omitempty"`
+
+	// ServiceType is the type of the Service exposing the gRPC endpoint of the principal. Defaults to ClusterIP.
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// Hosts are additional DNS names of the certificate of the principal, e.g. the external host name the agents
+	// connect to.
+	Hosts []string `json:"hosts,omitempty"`
+
+	// Agents are the names of the agents a client certificate is issued for. The certificate of each agent is stored
+	// in the <argocd>-agent-<name>-tls Secret, to be copied to its workload cluster.
+	Agents []string `json:"agents,omitempty"`
+
+	// Resources defines the Compute Resources required by the container of the principal.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// ArgoCDAgentAgentSpec defines the options for the argocd-agent agent.
+type ArgoCDAgentAgentSpec struct {
+	// Enabled defines whether the agent should be deployed.
+	Enabled bool `json:"enabled"`
+
+	// Image is the argocd-agent container image. Defaults to quay.io/argoprojlabs/argocd-agent.
+	Image string `json:"image,omitempty"`
+
+	// Version is the argocd-agent container image tag.
+	Version string `json:"version,omitempty"`
+
+	// LogLevel is the log level of the agent. Defaults to ArgoCDDefaultLogLevel if not set. Valid options are debug,
+	// info, error, and warn.
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// Mode is the mode of the agent. In managed mode, the Applications are defined on the control plane cluster. In
+	// autonomous mode, they are defined on the workload cluster. Defaults to managed.
+	//+kubebuilder:validation:Enum=managed;autonomous
+	Mode string `json:"mode,omitempty"`
+
+	// PrincipalAddress is the host name or IP address of the principal the agent connects to.
+	PrincipalAddress string `json:"principalAddress"`
+
+	// PrincipalPort is the port of the principal the agent connects to. Defaults to 443.
+	PrincipalPort int32 `json:"principalPort,omitempty"`
+
+	// ClientTLSSecretName is the name of the Secret holding the client certificate of the agent, with the tls.crt,
+	// tls.key and ca.crt keys, as issued by the principal.
+	ClientTLSSecretName string `json:"clientTLSSecretName"`
+
+	// Resources defines the Compute Resources required by the container of the agent.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// IsEnabled returns true if the principal should be deployed.
+func (p *ArgoCDAgentPrincipalSpec) IsEnabled() bool {
+	return p != nil && p.Enabled
+}
+
+// IsEnabled returns true if the agent should be deployed.
+func (a *ArgoCDAgentAgentSpec) IsEnabled() bool {
+	return a != nil && a.Enabled
 }
 
 // ArgoCDCASpec defines the CA options for ArgCD.
@@ -176,6 +447,11 @@ type ArgoCDDexSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OpenShift OAuth Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Dex","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	OpenShiftOAuth bool `json:"openShiftOAuth,omitempty"`
 
+	// RedirectURIs overrides the redirect URIs of the OpenShift OAuth client, e.g. when Argo CD is exposed under a
+	// custom domain in front of the Route. The first URI is used by the Dex OpenShift connector. Defaults to the Dex
+	// callback under the URL of the Argo CD Server.
+	RedirectURIs []string `json:"redirectURIs,omitempty"`
+
 	// Resources defines the Compute Resources required by the container for Dex.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Dex","urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
@@ -183,6 +459,31 @@ type ArgoCDDexSpec struct {
 	// Version is the Dex container image tag.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Version",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Dex","urn:alm:descriptor:com.tectonic.ui:text"}
 	Version string `json:"version,omitempty"`
+
+	// Service defines the IP family and traffic policy options for the Service of the Dex component.
+	Service ArgoCDServiceSpec `json:"service,omitempty"`
+
+	// ServiceMonitor defines the ServiceMonitor options for the Dex metrics, e.g. to observe failed SSO logins.
+	ServiceMonitor ArgoCDServiceMonitorSpec `json:"serviceMonitor,omitempty"`
+
+	// Theme mounts a ConfigMap holding a theme of the Dex login page below the web themes of Dex. The theme still needs
+	// to be selected in the frontend section of the Dex configuration.
+	Theme *ArgoCDSSOThemeSpec `json:"theme,omitempty"`
+}
+
+// ArgoCDSSOThemeSpec defines a ConfigMap holding a theme of the login page of an SSO provider, e.g. a logo and a
+// stylesheet.
+type ArgoCDSSOThemeSpec struct {
+	// Name is the name of the theme, which is also the name of the directory the theme is mounted to.
+	//+kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name string `json:"name"`
+
+	// ConfigMap is the name of the ConfigMap, in the namespace of the ArgoCD, holding the files of the theme.
+	ConfigMap string `json:"configMap"`
+
+	// Items maps the keys of the ConfigMap to paths below the theme directory, e.g. login/theme.properties for a
+	// Keycloak theme. All keys are mounted at the root of the theme directory when empty.
+	Items []corev1.KeyToPath `json:"items,omitempty"`
 }
 
 // ArgoCDDexOAuthSpec defines the desired state for the Dex OAuth configuration.
@@ -222,6 +523,25 @@ type ArgoCDGrafanaSpec struct {
 	// Version is the Grafana container image tag.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Version",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:text"}
 	Version string `json:"version,omitempty"`
+
+	// Service defines the IP family and traffic policy options for the Service of the Grafana component.
+	Service ArgoCDServiceSpec `json:"service,omitempty"`
+
+	// DashboardConfigMaps defines the options for providing the Argo CD Grafana dashboards as ConfigMaps, to be loaded
+	// by the dashboard sidecar of an existing Grafana. This does not require Grafana to be enabled.
+	DashboardConfigMaps *ArgoCDGrafanaDashboardConfigMapsSpec `json:"dashboardConfigMaps,omitempty"`
+}
+
+// ArgoCDGrafanaDashboardConfigMapsSpec defines the options for the Grafana dashboard ConfigMaps.
+type ArgoCDGrafanaDashboardConfigMapsSpec struct {
+	// Enabled will toggle the creation of a ConfigMap for each Argo CD Grafana dashboard.
+	Enabled bool `json:"enabled"`
+
+	// Labels is the map of labels the dashboard sidecar selects the ConfigMaps with. Defaults to grafana_dashboard: "1".
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations is the map of annotations for the ConfigMaps, e.g. to place the dashboards in a Grafana folder.
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // ArgoCDHASpec defines the desired state for High Availability support for Argo CD.
@@ -236,8 +556,34 @@ type ArgoCDHASpec struct {
 	// RedisProxyVersion is the Redis HAProxy container image tag.
 	RedisProxyVersion string `json:"redisProxyVersion,omitempty"`
 
+	// RedisImage is the Redis container image used in HA mode. Defaults to the Redis image.
+	RedisImage string `json:"redisImage,omitempty"`
+
+	// RedisVersion is the Redis container image tag used in HA mode. Defaults to the Redis version.
+	RedisVersion string `json:"redisVersion,omitempty"`
+
 	// Resources defines the Compute Resources required by the container for HA.
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Replicas defines the number of replicas for the Redis HA StatefulSet. Defaults to 3.
+	// An odd number is recommended, as the Sentinel quorum is a majority of the replicas.
+	//+kubebuilder:validation:Minimum=3
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// RedisProxyReplicas defines the number of replicas for the Redis HA Proxy Deployment.
+	RedisProxyReplicas *int32 `json:"redisProxyReplicas,omitempty"`
+
+	// RedisProxyConfig overrides settings of the defaults section of the Redis HA Proxy configuration,
+	// e.g. "timeout server".
+	RedisProxyConfig map[string]string `json:"redisProxyConfig,omitempty"`
+
+	// SentinelResources defines the Compute Resources required by the Redis Sentinel container.
+	// Defaults to the Redis resources.
+	SentinelResources *corev1.ResourceRequirements `json:"sentinelResources,omitempty"`
+
+	// SentinelConfig overrides settings of the master monitored by Redis Sentinel,
+	// e.g. "down-after-milliseconds".
+	SentinelConfig map[string]string `json:"sentinelConfig,omitempty"`
 }
 
 // ArgoCDImportSpec defines the desired state for the ArgoCD import/restore process.
@@ -253,9 +599,17 @@ type ArgoCDImportSpec struct {
 
 // ArgoCDIngressSpec defines the desired state for the Ingress resources.
 type ArgoCDIngressSpec struct {
-	// Annotations is the map of annotations to apply to the Ingress.
+	// Annotations is the map of annotations to apply to the Ingress. They are merged with the default annotations,
+	// taking precedence over them.
 	Annotations map[string]string `json:"annotations,omitempty"`
 
+	// DisableDefaultAnnotations disables the default nginx ingress controller annotations added by the operator,
+	// e.g. when another ingress controller is used.
+	DisableDefaultAnnotations bool `json:"disableDefaultAnnotations,omitempty"`
+
+	// Labels is the map of labels to apply to the Ingress.
+	Labels map[string]string `json:"labels,omitempty"`
+
 	// Enabled will toggle the creation of the Ingress.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingress Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
@@ -266,6 +620,30 @@ type ArgoCDIngressSpec struct {
 	// Path used for the Ingress resource.
 	Path string `json:"path,omitempty"`
 
+	// PathType used for the Ingress resource, e.g. Prefix for a wildcard path. Defaults to ImplementationSpecific.
+	//+kubebuilder:validation:Enum=Exact;Prefix;ImplementationSpecific
+	PathType *networkingv1.PathType `json:"pathType,omitempty"`
+
+	// Controller is the flavor of the ingress controller serving the Ingress, used to select the default
+	// annotations. One of nginx, traefik or alb. Defaults to nginx.
+	//+kubebuilder:validation:Enum=nginx;traefik;alb
+	Controller string `json:"controller,omitempty"`
+
+	// BackendProtocol is the protocol used by the ingress controller to talk to the Service. One of HTTP, HTTPS,
+	// GRPC or GRPCS. Defaults to GRPC for the Argo CD Server GRPC Ingress and HTTP otherwise.
+	//+kubebuilder:validation:Enum=HTTP;HTTPS;GRPC;GRPCS
+	BackendProtocol string `json:"backendProtocol,omitempty"`
+
+	// BackendPort is the name of the Argo CD Server Service port targeted by the Ingress, either http or https.
+	// Defaults to https for the GRPC Ingress and for TLS backend protocols, and to http otherwise. Only applies to
+	// the Argo CD Server and GRPC Ingresses.
+	//+kubebuilder:validation:Enum=http;https
+	BackendPort string `json:"backendPort,omitempty"`
+
+	// AdditionalHosts is the list of additional hostnames routed to the same backend, e.g. to expose the Ingress
+	// under several names covered by a SAN certificate.
+	AdditionalHosts []string `json:"additionalHosts,omitempty"`
+
 	// TLS configuration. Currently the Ingress only supports a single TLS
 	// port, 443. If multiple members of this list specify different hosts, they
 	// will be multiplexed on the same port according to the hostname specified
@@ -277,6 +655,11 @@ type ArgoCDIngressSpec struct {
 
 // ArgoCDKeycloakSpec defines the desired state for the Keycloak component.
 type ArgoCDKeycloakSpec struct {
+	// AdminCredentialsSecretRef references a Secret in the namespace of the ArgoCD holding the username and password of
+	// the Keycloak admin user in its username and password keys, e.g. a Secret synced by external-secrets. The default
+	// credentials are used when not set.
+	AdminCredentialsSecretRef *corev1.LocalObjectReference `json:"adminCredentialsSecretRef,omitempty"`
+
 	// Image is the Keycloak container image.
 	Image string `json:"image,omitempty"`
 
@@ -286,6 +669,10 @@ type ArgoCDKeycloakSpec struct {
 	// Custom root CA certificate for communicating with the Keycloak OIDC provider
 	RootCA string `json:"rootCA,omitempty"`
 
+	// Theme mounts a ConfigMap holding a theme of the Keycloak login page below the themes of Keycloak, and selects it
+	// as the login theme of the Argo CD realm when the realm is created.
+	Theme *ArgoCDSSOThemeSpec `json:"theme,omitempty"`
+
 	// Version is the Keycloak container image tag.
 	Version string `json:"version,omitempty"`
 
@@ -325,6 +712,115 @@ type ArgoCDNotifications struct {
 
 	// LogLevel describes the log level that should be used by the argocd-notifications. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug,info, error, and warn.
 	LogLevel string `json:"logLevel,omitempty"`
+
+	// LogFormat describes the log format that should be used by the argocd-notifications. Valid options are text or json.
+	LogFormat string `json:"logFormat,omitempty"`
+
+	// ExtraCommandArgs allows users to pass command line arguments to the notifications controller.
+	// They get added to default command line arguments provided by the operator.
+	// Please note that the command line arguments provided as part of ExtraCommandArgs
+	// will not overwrite the default command line arguments.
+	ExtraCommandArgs []string `json:"extraCommandArgs,omitempty"`
+
+	// NodePlacement defines NodeSelectors and Taints for the notifications controller. It takes precedence over the
+	// NodePlacement of the Argo CD instance.
+	NodePlacement *ArgoCDNodePlacementSpec `json:"nodePlacement,omitempty"`
+
+	// SelfServiceEnabled enables self-service notifications, allowing teams to configure notification services,
+	// templates and triggers in the source namespaces of their Applications.
+	SelfServiceEnabled bool `json:"selfServiceEnabled,omitempty"`
+
+	// Templates defines the notification templates, keyed by template name. They are added to argocd-notifications-cm
+	// as template.<name> entries, in addition to the default templates.
+	Templates map[string]string `json:"templates,omitempty"`
+
+	// Triggers defines the notification triggers, keyed by trigger name. They are added to argocd-notifications-cm
+	// as trigger.<name> entries, in addition to the default triggers.
+	Triggers map[string]string `json:"triggers,omitempty"`
+
+	// Services defines the notification services, keyed by service name. They are added to argocd-notifications-cm
+	// as service.<name> entries.
+	Services map[string]string `json:"services,omitempty"`
+
+	// SecretName is the name of a Secret in the namespace of the Argo CD instance, whose data is copied to
+	// argocd-notifications-secret. Services can reference the keys of the Secret as $<key>.
+	SecretName string `json:"secretName,omitempty"`
+
+	// Integrations defines the common notification services, whose credentials are sourced from Secrets in the
+	// namespace of the Argo CD instance and copied to argocd-notifications-secret.
+	Integrations ArgoCDNotificationsIntegrationsSpec `json:"integrations,omitempty"`
+}
+
+// ArgoCDNotificationsIntegrationsSpec defines the notification services configured by the operator.
+type ArgoCDNotificationsIntegrationsSpec struct {
+	// Slack configures the slack notification service.
+	Slack *ArgoCDNotificationsSlackSpec `json:"slack,omitempty"`
+
+	// Email configures the email notification service.
+	Email *ArgoCDNotificationsEmailSpec `json:"email,omitempty"`
+
+	// Webhooks configures webhook notification services, each added as service.webhook.<name>.
+	Webhooks []ArgoCDNotificationsWebhookSpec `json:"webhooks,omitempty"`
+}
+
+// ArgoCDNotificationsSlackSpec defines the options for the slack notification service.
+type ArgoCDNotificationsSlackSpec struct {
+	// TokenSecretRef selects the key of a Secret in the namespace of the Argo CD instance that holds the bot token.
+	TokenSecretRef corev1.SecretKeySelector `json:"tokenSecretRef"`
+}
+
+// ArgoCDNotificationsEmailSpec defines the options for the email notification service.
+type ArgoCDNotificationsEmailSpec struct {
+	// Host is the hostname of the SMTP server.
+	Host string `json:"host"`
+
+	// Port is the port of the SMTP server.
+	Port int32 `json:"port"`
+
+	// From is the sender address of the notifications.
+	From string `json:"from"`
+
+	// Username is the username used to authenticate with the SMTP server.
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecretRef selects the key of a Secret in the namespace of the Argo CD instance that holds the password
+	// used to authenticate with the SMTP server.
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+}
+
+// ArgoCDNotificationsWebhookSpec defines the options for a webhook notification service.
+type ArgoCDNotificationsWebhookSpec struct {
+	// Name is the name of the webhook service, referenced by subscriptions as webhook.<name>.
+	Name string `json:"name"`
+
+	// URLSecretRef selects the key of a Secret in the namespace of the Argo CD instance that holds the URL of the
+	// webhook, as it usually embeds a token.
+	URLSecretRef corev1.SecretKeySelector `json:"urlSecretRef"`
+}
+
+// ArgoCDServiceMonitorSpec defines the options for a Prometheus Operator ServiceMonitor.
+type ArgoCDServiceMonitorSpec struct {
+	// Enabled will toggle the creation of the ServiceMonitor, even when Prometheus is not enabled for ArgoCD.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Labels is the map of additional labels for the ServiceMonitor, e.g. to match the serviceMonitorSelector of a Prometheus.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Interval at which metrics should be scraped, e.g. 30s. Defaults to the Prometheus global scrape interval.
+	Interval string `json:"interval,omitempty"`
+
+	// Relabelings to apply to the samples before scraping.
+	Relabelings []*monitoringv1.RelabelConfig `json:"relabelings,omitempty"`
+
+	// MetricRelabelings to apply to the samples before ingestion.
+	MetricRelabelings []*monitoringv1.RelabelConfig `json:"metricRelabelings,omitempty"`
+
+	// Scheme is the HTTP scheme used to scrape the metrics, either http or https. Defaults to http.
+	//+kubebuilder:validation:Enum=http;https
+	Scheme string `json:"scheme,omitempty"`
+
+	// TLSConfig is the TLS configuration used to scrape the metrics when the https scheme is used.
+	TLSConfig *monitoringv1.TLSConfig `json:"tlsConfig,omitempty"`
 }
 
 // ArgoCDPrometheusSpec defines the desired state for the Prometheus component.
@@ -346,6 +842,32 @@ type ArgoCDPrometheusSpec struct {
 	// Size is the replica count for the Prometheus StatefulSet.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Size",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:podCount"}
 	Size *int32 `json:"size,omitempty"`
+
+	// Retention is the duration for which Prometheus retains the metrics, e.g. 15d. Defaults to the Prometheus Operator default.
+	//+kubebuilder:validation:Pattern:="^(0|(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retention",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:text"}
+	Retention string `json:"retention,omitempty"`
+
+	// RetentionSize is the maximum size of the metrics retained by Prometheus, e.g. 10GB.
+	//+kubebuilder:validation:Pattern:="^(0|([0-9]*[.])?[0-9]+((K|M|G|T|E|P)i?)?B)$"
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retention Size",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:text"}
+	RetentionSize string `json:"retentionSize,omitempty"`
+
+	// Storage is the PersistentVolumeClaim template for the Prometheus StatefulSet. The metrics are stored in an emptyDir
+	// volume when not set.
+	Storage *corev1.PersistentVolumeClaimSpec `json:"storage,omitempty"`
+
+	// Resources defines the Compute Resources required by the Prometheus container.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ExternalLabels are the labels added to the time series and alerts when communicating with external systems, e.g.
+	// federation or remote storage.
+	ExternalLabels map[string]string `json:"externalLabels,omitempty"`
+
+	// DisableServiceMonitors disables the creation of the component ServiceMonitors when Prometheus is enabled, e.g.
+	// when the metrics are scraped by another monitoring stack. ServiceMonitors enabled for a component are still created.
+	DisableServiceMonitors bool `json:"disableServiceMonitors,omitempty"`
 }
 
 // ArgoCDRBACSpec defines the desired state for the Argo CD RBAC configuration.
@@ -372,11 +894,15 @@ type ArgoCDRBACSpec struct {
 
 	// PolicyMatcherMode configures the matchers function mode for casbin.
 	// There are two options for this, 'glob' for glob matcher or 'regex' for regex matcher.
+	//+kubebuilder:validation:Enum=glob;regex
 	PolicyMatcherMode *string `json:"policyMatcherMode,omitempty"`
 }
 
 // ArgoCDRedisSpec defines the desired state for the Redis server component.
 type ArgoCDRedisSpec struct {
+	// Enabled defines whether the Redis server is deployed. Defaults to true.
+	Enabled *bool `json:"enabled,omitempty"`
+
 	// Image is the Redis container image.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Redis","urn:alm:descriptor:com.tectonic.ui:text"}
 	Image string `json:"image,omitempty"`
@@ -392,14 +918,76 @@ type ArgoCDRedisSpec struct {
 	// DisableTLSVerification defines whether redis server API should be accessed using strict TLS validation
 	DisableTLSVerification bool `json:"disableTLSVerification,omitempty"`
 
+	// EnableAuth enables Redis AUTH, using the password stored in the argocd-redis Secret.
+	// The operator generates the password when the Secret does not exist.
+	EnableAuth bool `json:"enableAuth,omitempty"`
+
+	// Persistence defines a persistent volume to back the Redis data, so that the cache survives restarts.
+	Persistence *ArgoCDRedisPersistenceSpec `json:"persistence,omitempty"`
+
 	// AutoTLS specifies the method to use for automatic TLS configuration for the redis server
 	// The value specified here can currently be:
 	// - openshift - Use the OpenShift service CA to request TLS config
+	// - operator - Use a certificate issued from the CA of the Argo CD instance, which the operator renews before expiry
 	AutoTLS string `json:"autotls,omitempty"`
+
+	// Remote configures Argo CD to use an externally managed Redis server (e.g. ElastiCache or Memorystore).
+	// When set, the operator does not deploy Redis and cannot be combined with HA.
+	Remote *ArgoCDRedisRemoteSpec `json:"remote,omitempty"`
+
+	// TerminationGracePeriodSeconds is the duration in seconds the Redis pods are given to terminate gracefully.
+	// Defaults to 30 seconds, or 60 seconds in HA mode.
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// LivenessProbe overrides the default liveness probe of the Redis container.
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+
+	// ReadinessProbe overrides the default readiness probe of the Redis container.
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+
+	// Service defines the IP family and traffic policy options for the Service of the Redis component.
+	Service ArgoCDServiceSpec `json:"service,omitempty"`
+}
+
+// ArgoCDRedisPersistenceSpec defines the persistence options for Redis.
+type ArgoCDRedisPersistenceSpec struct {
+	// Enabled defines whether Redis persists its data to a persistent volume, using RDB snapshots and the append only file.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Size is the size of the persistent volume requested for each Redis replica. Defaults to 10Gi.
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// StorageClassName is the name of the StorageClass used to provision the persistent volumes. Uses the cluster default when empty.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// ArgoCDRedisRemoteSpec defines the connection settings for an externally managed Redis server.
+type ArgoCDRedisRemoteSpec struct {
+	// Address is the host:port of the remote Redis server. Ignored when Sentinels are set.
+	Address string `json:"address,omitempty"`
+
+	// Sentinels is the list of host:port addresses of the Redis Sentinels monitoring the remote Redis server.
+	Sentinels []string `json:"sentinels,omitempty"`
+
+	// SentinelMaster is the name of the master monitored by the Sentinels. Defaults to "master".
+	SentinelMaster string `json:"sentinelMaster,omitempty"`
+
+	// CredentialsSecret is the name of a Secret holding the "username" (optional) and "password"
+	// keys used to authenticate against the remote Redis server.
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+
+	// UseTLS defines whether the connection to the remote Redis server uses TLS.
+	UseTLS bool `json:"useTLS,omitempty"`
 }
 
 // ArgoCDRepoSpec defines the desired state for the Argo CD repo server component.
 type ArgoCDRepoSpec struct {
+	// Enabled defines whether the repo server is deployed. Defaults to true.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Remote is the host:port address of an externally managed repo server, e.g. the repo server of another Argo CD
+	// instance. When set, the operator does not deploy the repo server, and the other components use this address.
+	Remote string `json:"remote,omitempty"`
 
 	// Extra Command arguments allows users to pass command line arguments to repo server workload. They get added to default command line arguments provided
 	// by the operator.
@@ -422,9 +1010,16 @@ type ArgoCDRepoSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Repo","urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
-	// ServiceAccount defines the ServiceAccount user that you would like the Repo server to use
+	// ServiceAccount defines the ServiceAccount user that you would like the Repo server to use.
+	// When set, the ServiceAccount must already exist and the operator will not create one for the Repo server.
+	// This allows the use of ServiceAccounts carrying cloud identity annotations (e.g. IRSA or Workload Identity).
 	ServiceAccount string `json:"serviceaccount,omitempty"`
 
+	// ServiceAccountAnnotations are added to the ServiceAccount of the Repo server component managed by the operator,
+	// e.g. to associate it with a cloud identity through IRSA or GKE Workload Identity. The operator only creates this
+	// ServiceAccount when annotations are set. Ignored when ServiceAccount is set.
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+
 	// VerifyTLS defines whether repo server API should be accessed using strict TLS validation
 	VerifyTLS bool `json:"verifytls,omitempty"`
 
@@ -456,6 +1051,34 @@ type ArgoCDRepoSpec struct {
 
 	// SidecarContainers defines the list of sidecar containers for the repo server deployment
 	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
+
+	// TerminationGracePeriodSeconds is the duration in seconds the repo server pods are given to terminate gracefully,
+	// allowing long running tool executions to complete during rollouts. Defaults to 30 seconds.
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// LivenessProbe overrides the default liveness probe of the repo server container.
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+
+	// ReadinessProbe overrides the default readiness probe of the repo server container.
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+
+	// Service defines the IP family and traffic policy options for the Service of the Argo CD Repo Server component.
+	Service ArgoCDServiceSpec `json:"service,omitempty"`
+
+	// ServiceMonitor defines the ServiceMonitor options for the Repo Server metrics.
+	ServiceMonitor ArgoCDServiceMonitorSpec `json:"serviceMonitor,omitempty"`
+
+	// HostAliases adds entries to the hosts file of the repo server pods, e.g. to resolve Git hosts that are not in the
+	// cluster DNS.
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
+	// DNSPolicy is the DNS policy of the repo server pods. Defaults to ClusterFirst.
+	//+kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig adds name servers, search domains and resolver options to the DNS configuration of the repo server
+	// pods. Required when DNSPolicy is None.
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
 
 // ArgoCDRouteSpec defines the desired state for an OpenShift Route.
@@ -499,13 +1122,154 @@ type ArgoCDServerGRPCSpec struct {
 	// Ingress defines the desired state for the Argo CD Server GRPC Ingress.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GRPC Ingress Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Ingress ArgoCDIngressSpec `json:"ingress,omitempty"`
+
+	// Web configures the GRPC Ingress for gRPC-web, which tunnels the gRPC calls of the Argo CD CLI over HTTP/1.1 for
+	// load balancers and ingress controllers without gRPC support, e.g. ALB. The backend protocol of the Ingress then
+	// defaults to HTTPS, and the CLI must be used with the --grpc-web flag.
+	Web bool `json:"web,omitempty"`
+}
+
+// ArgoCDCertificateStatus defines the observed state of a TLS certificate used by an Argo CD component.
+type ArgoCDCertificateStatus struct {
+	// SecretName is the name of the Secret holding the certificate.
+	SecretName string `json:"secretName"`
+
+	// NotAfter is the time the certificate expires.
+	NotAfter metav1.Time `json:"notAfter"`
+
+	// Managed is true if the certificate is generated and renewed by the operator.
+	Managed bool `json:"managed,omitempty"`
+}
+
+// ArgoCDSecretKeyRef defines a key of the argocd-secret Secret whose value is sourced from another Secret.
+type ArgoCDSecretKeyRef struct {
+	// Key is the key in argocd-secret, e.g. webhook.github.secret or dex.github.clientSecret.
+	Key string `json:"key"`
+
+	// SecretRef selects the key of a Secret in the namespace of the ArgoCD that holds the value.
+	SecretRef corev1.SecretKeySelector `json:"secretRef"`
+}
+
+// ArgoCDAdminPasswordRotationSpec defines the rotation policy of the admin password.
+type ArgoCDAdminPasswordRotationSpec struct {
+	// Interval is the time after which the operator regenerates the admin password, e.g. 720h. The password is only
+	// rotated on request through the rotate-admin-password annotation when not set.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// ArgoCDServerCustomStylesSpec defines the custom styles of the Argo CD UI.
+type ArgoCDServerCustomStylesSpec struct {
+	// ConfigMap is the name of a ConfigMap in the namespace of the ArgoCD that holds the stylesheet and any assets it
+	// references. The ConfigMap is mounted into the Argo CD Server below the static assets at the `custom` path.
+	ConfigMap string `json:"configMap"`
+
+	// StylesheetKey is the key of the stylesheet in the ConfigMap. Defaults to `custom.styles.css`.
+	StylesheetKey string `json:"stylesheetKey,omitempty"`
+}
+
+// ArgoCDServerExtensionBackendSpec defines the backend of a proxy extension, to which the Argo CD Server forwards the
+// requests of the UI extension.
+type ArgoCDServerExtensionBackendSpec struct {
+	// ConnectionTimeout is the maximum time to wait for a connection to a service. Defaults to 2s.
+	ConnectionTimeout *metav1.Duration `json:"connectionTimeout,omitempty"`
+
+	// KeepAlive is the interval between the keep-alive probes of the connections to the services. Defaults to 15s.
+	KeepAlive *metav1.Duration `json:"keepAlive,omitempty"`
+
+	// IdleConnectionTimeout is the time after which an idle connection to a service is closed. Defaults to 60s.
+	IdleConnectionTimeout *metav1.Duration `json:"idleConnectionTimeout,omitempty"`
+
+	// MaxIdleConnections is the maximum number of idle connections to all services. Defaults to 30.
+	//+kubebuilder:validation:Minimum=1
+	MaxIdleConnections *int32 `json:"maxIdleConnections,omitempty"`
+
+	// Services are the services of the backend. Each service must select a cluster when more than one is set.
+	//+kubebuilder:validation:MinItems=1
+	Services []ArgoCDServerExtensionServiceSpec `json:"services"`
+}
+
+// ArgoCDServerExtensionServiceSpec defines a service of the backend of a proxy extension.
+type ArgoCDServerExtensionServiceSpec struct {
+	// URL is the URL of the service, e.g. http://prometheus-operated.monitoring.svc:9090.
+	URL string `json:"url"`
+
+	// Headers are added to the requests forwarded to the service.
+	Headers []ArgoCDServerExtensionHeaderSpec `json:"headers,omitempty"`
+
+	// Cluster selects the requests forwarded to the service by the destination cluster of their Application.
+	Cluster *ArgoCDServerExtensionClusterSpec `json:"cluster,omitempty"`
+}
+
+// ArgoCDServerExtensionHeaderSpec defines a header added to the requests forwarded to a service of a proxy extension.
+type ArgoCDServerExtensionHeaderSpec struct {
+	// Name is the name of the header.
+	Name string `json:"name"`
+
+	// Value is the value of the header. A value starting with $ references the key of the argocd-secret Secret with
+	// the same name, e.g. $metrics.token.
+	Value string `json:"value"`
+}
+
+// ArgoCDServerExtensionClusterSpec selects the cluster of a service of a proxy extension by name or by server URL.
+type ArgoCDServerExtensionClusterSpec struct {
+	// Name is the name of the cluster in Argo CD.
+	Name string `json:"name,omitempty"`
+
+	// Server is the URL of the API server of the cluster.
+	Server string `json:"server,omitempty"`
+}
+
+// ArgoCDServerExtensionSpec defines a UI extension installed into the Argo CD Server, e.g. the Argo Rollouts extension,
+// and the backend of the extension when it is a proxy extension.
+type ArgoCDServerExtensionSpec struct {
+	// Name is the name of the extension, e.g. rollouts. Proxy extensions are reached under /extensions/<name> of the
+	// Argo CD Server.
+	//+kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name string `json:"name"`
+
+	// URL is the URL of the tar.gz archive of the extension. The extension is not installed when empty, e.g. for the
+	// backend of an extension installed by other means.
+	URL string `json:"url,omitempty"`
+
+	// Backend defines the backend of a proxy extension, set in the extension.config of the argocd-cm ConfigMap.
+	Backend *ArgoCDServerExtensionBackendSpec `json:"backend,omitempty"`
+
+	// ChecksumURL is the URL of a file listing the sha256 checksum of the archive, in the format of the sha256sum
+	// command. The archive is not installed when its checksum does not match.
+	ChecksumURL string `json:"checksumURL,omitempty"`
+
+	// Image is the container image of the argocd-extension-installer installing the extension. Defaults to
+	// quay.io/argoprojlabs/argocd-extension-installer.
+	Image string `json:"image,omitempty"`
 }
 
 // ArgoCDServerSpec defines the options for the ArgoCD Server component.
 type ArgoCDServerSpec struct {
+	// Enabled defines whether the Argo CD Server is deployed. Defaults to true. Disable it for instances that only
+	// run the Application Controller, e.g. in agent namespaces.
+	Enabled *bool `json:"enabled,omitempty"`
+
 	// Autoscale defines the autoscale options for the Argo CD Server component.
 	Autoscale ArgoCDServerAutoscaleSpec `json:"autoscale,omitempty"`
 
+	// BaseHRef is the base href of the Argo CD UI (`--basehref`). Use it when a proxy or Ingress rewrites a sub-path
+	// to the root of the Argo CD Server.
+	BaseHRef string `json:"baseHRef,omitempty"`
+
+	// CustomStyles defines a ConfigMap with a custom stylesheet and assets, such as logos, for the Argo CD UI.
+	CustomStyles *ArgoCDServerCustomStylesSpec `json:"customStyles,omitempty"`
+
+	// Extensions are the UI extensions installed into the Argo CD Server by the argocd-extension-installer, and the
+	// backends of the proxy extensions. The proxy extension feature of the Argo CD Server is enabled when extensions
+	// are set.
+	Extensions []ArgoCDServerExtensionSpec `json:"extensions,omitempty"`
+
+	// ExternalURL is the URL the Argo CD Server is reached under by its users, e.g. https://argocd.example.com. It is
+	// set as the url in the argocd-cm ConfigMap and used for the Dex and Keycloak redirect URIs. Defaults to an https
+	// URL with the host of the server Route or Ingress, or the Host, followed by the RootPath.
+	ExternalURL string `json:"externalURL,omitempty"`
+
 	// GRPC defines the state for the Argo CD Server GRPC options.
 	GRPC ArgoCDServerGRPCSpec `json:"grpc,omitempty"`
 
@@ -516,6 +1280,21 @@ type ArgoCDServerSpec struct {
 	// Ingress defines the desired state for an Ingress for the Argo CD Server component.
 	Ingress ArgoCDIngressSpec `json:"ingress,omitempty"`
 
+	// Istio defines the options for exposing the Argo CD Server component through an Istio service mesh.
+	Istio ArgoCDServerIstioSpec `json:"istio,omitempty"`
+
+	// ClusterRole defines customizations of the ClusterRole of the Argo CD Server component when running cluster-scoped.
+	ClusterRole *ArgoCDClusterRoleSpec `json:"clusterRole,omitempty"`
+
+	// CustomRoleName is the name of an existing ClusterRole to bind the Argo CD Server to in the managed namespaces.
+	// When set, the operator does not create the default Role for the Argo CD Server. Takes precedence over the
+	// SERVER_CLUSTER_ROLE environment variable of the operator.
+	CustomRoleName string `json:"customRoleName,omitempty"`
+
+	// ServiceAccountAnnotations are added to the ServiceAccount of the Argo CD Server component, e.g. to associate it
+	// with a cloud identity through IRSA or GKE Workload Identity.
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+
 	// Insecure toggles the insecure flag.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Insecure",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Insecure bool `json:"insecure,omitempty"`
@@ -533,6 +1312,10 @@ type ArgoCDServerSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// RootPath is the sub-path the Argo CD Server is served under (`--rootpath`), e.g. /argocd. The server Ingress
+	// and Route use it as their path unless a path is set on them.
+	RootPath string `json:"rootPath,omitempty"`
+
 	// Route defines the desired state for an OpenShift Route for the Argo CD Server component.
 	Route ArgoCDRouteSpec `json:"route,omitempty"`
 
@@ -542,10 +1325,277 @@ type ArgoCDServerSpec struct {
 	// Env lets you specify environment for API server pods
 	Env []corev1.EnvVar `json:"env,omitempty"`
 
+	// Image is the container image of the Argo CD Server, to pin it to another image than the other components, e.g.
+	// during a staged upgrade. Defaults to the Image of the ArgoCD.
+	Image string `json:"image,omitempty"`
+
+	// Version is the container image tag of the Argo CD Server, to pin it to another version than the other
+	// components, e.g. during a staged upgrade. Defaults to the Version of the ArgoCD.
+	Version string `json:"version,omitempty"`
+
 	// Extra Command arguments that would append to the Argo CD server command.
 	// ExtraCommandArgs will not be added, if one of these commands is already part of the server command
 	// with same or different value.
 	ExtraCommandArgs []string `json:"extraCommandArgs,omitempty"`
+
+	// ServiceMonitor defines the ServiceMonitor options for the Argo CD Server metrics.
+	ServiceMonitor ArgoCDServiceMonitorSpec `json:"serviceMonitor,omitempty"`
+}
+
+// ArgoCDCmdParamsSpec defines the settings of the argocd-cmd-params-cm ConfigMap, grouped by component.
+type ArgoCDCmdParamsSpec struct {
+	// Controller defines the settings of the Application Controller.
+	Controller ArgoCDControllerCmdParamsSpec `json:"controller,omitempty"`
+
+	// RepoServer defines the settings of the Repo Server.
+	RepoServer ArgoCDRepoServerCmdParamsSpec `json:"repoServer,omitempty"`
+
+	// Server defines the settings of the Argo CD Server.
+	Server ArgoCDServerCmdParamsSpec `json:"server,omitempty"`
+}
+
+// ArgoCDControllerCmdParamsSpec defines the argocd-cmd-params-cm settings of the Application Controller.
+type ArgoCDControllerCmdParamsSpec struct {
+	// OperationProcessors is the number of application operation processors, 'controller.operation.processors'.
+	// Takes precedence over .spec.controller.processors.operation.
+	//+kubebuilder:validation:Minimum=1
+	OperationProcessors *int32 `json:"operationProcessors,omitempty"`
+
+	// RepoServerTimeoutSeconds is the timeout of requests to the Repo Server, 'controller.repo.server.timeout.seconds'.
+	//+kubebuilder:validation:Minimum=1
+	RepoServerTimeoutSeconds *int32 `json:"repoServerTimeoutSeconds,omitempty"`
+
+	// StatusProcessors is the number of application status processors, 'controller.status.processors'. Takes
+	// precedence over .spec.controller.processors.status.
+	//+kubebuilder:validation:Minimum=1
+	StatusProcessors *int32 `json:"statusProcessors,omitempty"`
+}
+
+// ArgoCDRepoServerCmdParamsSpec defines the argocd-cmd-params-cm settings of the Repo Server.
+type ArgoCDRepoServerCmdParamsSpec struct {
+	// ParallelismLimit is the maximum number of concurrent manifest generations, 'reposerver.parallelism.limit'. No
+	// limit is applied when 0.
+	//+kubebuilder:validation:Minimum=0
+	ParallelismLimit *int32 `json:"parallelismLimit,omitempty"`
+}
+
+// ArgoCDServerCmdParamsSpec defines the argocd-cmd-params-cm settings of the Argo CD Server.
+type ArgoCDServerCmdParamsSpec struct {
+	// ContentSecurityPolicy is the value of the Content-Security-Policy header, 'server.content.security.policy'.
+	ContentSecurityPolicy string `json:"contentSecurityPolicy,omitempty"`
+
+	// EnableGZip toggles GZIP compression of responses, 'server.enable.gzip'.
+	EnableGZip *bool `json:"enableGZip,omitempty"`
+
+	// Insecure toggles running the server without TLS, 'server.insecure'. Takes precedence over .spec.server.insecure.
+	Insecure *bool `json:"insecure,omitempty"`
+
+	// XFrameOptions is the value of the X-Frame-Options header, 'server.x.frame.options'.
+	XFrameOptions string `json:"xFrameOptions,omitempty"`
+}
+
+// ArgoCDClusterSpec defines a cluster registered with Argo CD by the operator.
+type ArgoCDClusterSpec struct {
+	// Name is the name of the cluster in Argo CD.
+	Name string `json:"name"`
+
+	// Server is the URL of the API server of the cluster.
+	Server string `json:"server"`
+
+	// Namespaces restricts Argo CD to the given namespaces of the cluster. All namespaces are used when empty.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Project restricts the cluster to the given AppProject.
+	Project string `json:"project,omitempty"`
+
+	// CredentialsSecret is the name of an existing Secret in the namespace of the ArgoCD holding the credentials of the
+	// cluster, as a 'config' key in the format expected by Argo CD.
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+
+	// ExecProvider generates the credentials of the cluster for the workload identity of a cloud provider, using the
+	// identity of the application controller and the server. Ignored when the credentials Secret holds a 'config' key.
+	ExecProvider *ArgoCDClusterExecProviderSpec `json:"execProvider,omitempty"`
+}
+
+// ArgoCDClusterExecProviderSpec defines the workload identity authentication of a cluster registered with Argo CD.
+type ArgoCDClusterExecProviderSpec struct {
+	// Provider is the cloud provider of the cluster. Valid options are aws, gcp and azure.
+	//+kubebuilder:validation:Enum=aws;gcp;azure
+	Provider string `json:"provider"`
+
+	// CAData is the base64 encoded PEM CA certificate of the API server of the cluster.
+	CAData string `json:"caData,omitempty"`
+
+	// ClusterName is the name of the EKS cluster. Required for aws.
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// RoleARN is the ARN of the IAM role assumed to access the EKS cluster, for aws. The role of the workload identity
+	// is used when not set.
+	RoleARN string `json:"roleARN,omitempty"`
+
+	// ClientID is the client ID of the managed identity or application registration, for azure.
+	ClientID string `json:"clientID,omitempty"`
+
+	// TenantID is the ID of the Azure AD tenant of the identity, for azure.
+	TenantID string `json:"tenantID,omitempty"`
+
+	// Command is the exec plugin used for gcp and azure, e.g. a tool added through execProviderTools. Defaults to
+	// argocd-k8s-auth, which is shipped with Argo CD.
+	Command string `json:"command,omitempty"`
+
+	// Args are the arguments of Command. Defaults to the provider when Command is not set.
+	Args []string `json:"args,omitempty"`
+}
+
+// ArgoCDExecProviderToolSpec defines an exec plugin copied from a container image into the application controller and
+// the server.
+type ArgoCDExecProviderToolSpec struct {
+	// Name is the name of the tool, e.g. kubelogin.
+	//+kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name string `json:"name"`
+
+	// Image is the container image providing the tool at Path. The image must contain a `cp` binary.
+	Image string `json:"image"`
+
+	// Path is the path of the tool in the image. The tool is mounted at the same path in the application controller
+	// and the server.
+	Path string `json:"path"`
+}
+
+// ArgoCDRepositorySpec defines a repository registered with Argo CD by the operator.
+type ArgoCDRepositorySpec struct {
+	// Name is the name of the repository in Argo CD.
+	Name string `json:"name"`
+
+	// URL is the URL of the repository.
+	URL string `json:"url"`
+
+	// Type is the type of the repository, either 'git' or 'helm'. Defaults to 'git'.
+	//+kubebuilder:validation:Enum=git;helm
+	Type string `json:"type,omitempty"`
+
+	// Project restricts the repository to the given AppProject.
+	Project string `json:"project,omitempty"`
+
+	// CredentialsSecret is the name of an existing Secret in the namespace of the ArgoCD holding the credentials of the
+	// repository, using the keys expected by Argo CD, e.g. 'username' and 'password' or 'sshPrivateKey'.
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
+// ArgoCDRepositoryCredentialsSpec defines a credential template registered with Argo CD by the operator. Its
+// credentials are used for all repositories with a URL starting with the URL of the template.
+type ArgoCDRepositoryCredentialsSpec struct {
+	// Name is the name of the credential template.
+	Name string `json:"name"`
+
+	// URL is the URL prefix of the repositories the credentials are used for, e.g. https://github.com/example.
+	URL string `json:"url"`
+
+	// Type is the type of the repositories, either 'git' or 'helm'. Defaults to 'git'.
+	//+kubebuilder:validation:Enum=git;helm
+	Type string `json:"type,omitempty"`
+
+	// CredentialsSecret is the name of an existing Secret in the namespace of the ArgoCD holding the credentials of the
+	// repositories, using the keys expected by Argo CD, e.g. 'username' and 'password' or 'sshPrivateKey'.
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+
+	// GitHubApp authenticates to the repositories as an installation of a GitHub App, with short-lived installation
+	// tokens.
+	GitHubApp *ArgoCDGitHubAppCredentialsSpec `json:"githubApp,omitempty"`
+
+	// GoogleCloudSource authenticates to Google Cloud Source repositories as a Google Cloud service account, with
+	// short-lived access tokens.
+	GoogleCloudSource *ArgoCDGoogleCloudSourceCredentialsSpec `json:"googleCloudSource,omitempty"`
+}
+
+// ArgoCDGitHubAppCredentialsSpec defines the GitHub App a credential template authenticates as.
+type ArgoCDGitHubAppCredentialsSpec struct {
+	// AppID is the ID of the GitHub App.
+	//+kubebuilder:validation:Minimum=1
+	AppID int64 `json:"appID"`
+
+	// InstallationID is the ID of the installation of the GitHub App in the organization or user account owning the
+	// repositories.
+	//+kubebuilder:validation:Minimum=1
+	InstallationID int64 `json:"installationID"`
+
+	// PrivateKeySecretRef selects the key of a Secret in the namespace of the ArgoCD that holds the PEM encoded private
+	// key of the GitHub App.
+	PrivateKeySecretRef corev1.SecretKeySelector `json:"privateKeySecretRef"`
+
+	// EnterpriseBaseURL is the API URL of a GitHub Enterprise Server, e.g. https://github.example.com/api/v3. Defaults
+	// to the API of github.com.
+	EnterpriseBaseURL string `json:"enterpriseBaseURL,omitempty"`
+}
+
+// ArgoCDGoogleCloudSourceCredentialsSpec defines the Google Cloud service account a credential template authenticates
+// as.
+type ArgoCDGoogleCloudSourceCredentialsSpec struct {
+	// ServiceAccountKeySecretRef selects the key of a Secret in the namespace of the ArgoCD that holds the JSON key of
+	// the service account.
+	ServiceAccountKeySecretRef corev1.SecretKeySelector `json:"serviceAccountKeySecretRef"`
+}
+
+// ArgoCDProjectSpec defines an AppProject managed by the operator.
+type ArgoCDProjectSpec struct {
+	// Name is the name of the AppProject.
+	Name string `json:"name"`
+
+	// Description is the description of the AppProject.
+	Description string `json:"description,omitempty"`
+
+	// SourceRepos lists the repositories Applications of the AppProject may deploy from, e.g. '*'.
+	SourceRepos []string `json:"sourceRepos,omitempty"`
+
+	// Destinations lists the clusters and namespaces Applications of the AppProject may deploy to.
+	Destinations []ArgoCDProjectDestination `json:"destinations,omitempty"`
+
+	// ClusterResourceWhitelist lists the cluster-scoped resources Applications of the AppProject may deploy.
+	ClusterResourceWhitelist []metav1.GroupKind `json:"clusterResourceWhitelist,omitempty"`
+
+	// Roles defines the project roles of the AppProject.
+	Roles []ArgoCDProjectRole `json:"roles,omitempty"`
+}
+
+// ArgoCDProjectDestination defines a cluster and namespace Applications of an AppProject may deploy to.
+type ArgoCDProjectDestination struct {
+	// Server is the URL of the destination cluster, e.g. https://kubernetes.default.svc.
+	Server string `json:"server,omitempty"`
+
+	// Name is the name of the destination cluster, as an alternative to Server.
+	Name string `json:"name,omitempty"`
+
+	// Namespace is the destination namespace, glob patterns such as 'team-a-*' are supported.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ArgoCDProjectRole defines a project role of an AppProject.
+type ArgoCDProjectRole struct {
+	// Name is the name of the role.
+	Name string `json:"name"`
+
+	// Description is the description of the role.
+	Description string `json:"description,omitempty"`
+
+	// Policies are the RBAC policies of the role, e.g. 'p, proj:team-a:developer, applications, sync, team-a/*, allow'.
+	Policies []string `json:"policies,omitempty"`
+
+	// Groups are the SSO groups that are granted the role.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// ArgoCDServerIstioSpec defines the options for exposing the Argo CD Server component through Istio.
+type ArgoCDServerIstioSpec struct {
+	// Enabled will toggle the creation of an Istio VirtualService and DestinationRule for the Argo CD Server. The
+	// server is run with the insecure flag, leaving TLS to the mesh.
+	Enabled bool `json:"enabled"`
+
+	// Gateways is the list of Istio gateways the VirtualService is bound to, e.g. istio-system/ingressgateway. When
+	// empty, the VirtualService only applies to the sidecars in the mesh.
+	Gateways []string `json:"gateways,omitempty"`
+
+	// Hosts is the list of hosts the VirtualService applies to. Defaults to the Argo CD Server host.
+	Hosts []string `json:"hosts,omitempty"`
 }
 
 // ArgoCDServerServiceSpec defines the Service options for Argo CD Server component.
@@ -553,6 +1603,22 @@ type ArgoCDServerServiceSpec struct {
 	// Type is the ServiceType to use for the Service resource.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Type'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:text"}
 	Type corev1.ServiceType `json:"type"`
+
+	// ArgoCDServiceSpec defines the IP family and traffic policy options for the Argo CD Server Services.
+	ArgoCDServiceSpec `json:",inline"`
+}
+
+// ArgoCDServiceSpec defines the IP family and traffic policy options for the Service of a component.
+// Options that are not set are left to the cluster defaults.
+type ArgoCDServiceSpec struct {
+	// IPFamilies is the list of IP families (e.g. IPv4, IPv6) assigned to the Service.
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+
+	// IPFamilyPolicy represents the dual-stack-ness of the Service.
+	IPFamilyPolicy *corev1.IPFamilyPolicyType `json:"ipFamilyPolicy,omitempty"`
+
+	// InternalTrafficPolicy specifies if the cluster internal traffic should be routed to all endpoints or node-local endpoints only.
+	InternalTrafficPolicy *corev1.ServiceInternalTrafficPolicyType `json:"internalTrafficPolicy,omitempty"`
 }
 
 // Resource Customization for custom health check
@@ -581,6 +1647,21 @@ type IgnoreDifferenceCustomization struct {
 	ManagedFieldsManagers []string `json:"managedFieldsManagers,omitempty"`
 }
 
+// ResourceFilter selects classes of resources by API group, kind and cluster.
+type ResourceFilter struct {
+	// APIGroups are the API groups of the resources, e.g. "apps". Glob patterns are supported. All groups are selected
+	// when empty.
+	APIGroups []string `json:"apiGroups,omitempty"`
+
+	// Kinds are the kinds of the resources, e.g. "Deployment". Glob patterns are supported. All kinds are selected when
+	// empty.
+	Kinds []string `json:"kinds,omitempty"`
+
+	// Clusters are the URLs of the clusters the filter applies to. Glob patterns are supported. All clusters are
+	// selected when empty.
+	Clusters []string `json:"clusters,omitempty"`
+}
+
 // Resource Customization for custom action
 type ResourceAction struct {
 	Group  string `json:"group,omitempty"`
@@ -623,9 +1704,14 @@ type ArgoCDSSOSpec struct {
 // KustomizeVersionSpec is used to specify information about a kustomize version to be used within ArgoCD.
 type KustomizeVersionSpec struct {
 	// Version is a configured kustomize version in the format of vX.Y.Z
+	//+kubebuilder:validation:Pattern:="^v?[0-9A-Za-z.\\-]+$"
 	Version string `json:"version,omitempty"`
 	// Path is the path to a configured kustomize version on the filesystem of your repo server.
 	Path string `json:"path,omitempty"`
+	// Image is an optional container image providing the kustomize binary for this version at Path. When set, an
+	// init container copies the binary from the image and mounts it into the repo server at the same Path.
+	// The image must contain a `cp` binary.
+	Image string `json:"image,omitempty"`
 }
 
 // ArgoCDMonitoringSpec is used to configure workload status monitoring for a given Argo CD instance.
@@ -635,6 +1721,24 @@ type KustomizeVersionSpec struct {
 type ArgoCDMonitoringSpec struct {
 	// Enabled defines whether workload status monitoring is enabled for this instance or not
 	Enabled bool `json:"enabled"`
+
+	// ExternalGrafana defines an existing Grafana instance the Argo CD Grafana dashboards are published to, replacing
+	// the operator-managed Grafana.
+	ExternalGrafana *ArgoCDExternalGrafanaSpec `json:"externalGrafana,omitempty"`
+}
+
+// ArgoCDExternalGrafanaSpec defines the options for publishing the Argo CD Grafana dashboards to an existing Grafana.
+type ArgoCDExternalGrafanaSpec struct {
+	// URL is the base URL of the Grafana instance, e.g. https://grafana.example.com.
+	//+kubebuilder:validation:Pattern:="^https?://"
+	URL string `json:"url"`
+
+	// APIKeySecretRef references the key of a Secret in the namespace of the ArgoCD holding the API key or service
+	// account token used to publish the dashboards. It requires permission to create and update dashboards.
+	APIKeySecretRef corev1.SecretKeySelector `json:"apiKeySecretRef"`
+
+	// FolderUID is the UID of the Grafana folder the dashboards are published to. Defaults to the General folder.
+	FolderUID string `json:"folderUID,omitempty"`
 }
 
 //ArgoCDNodePlacementSpec is used to specify NodeSelector and Tolerations for Argo CD workloads
@@ -645,10 +1749,27 @@ type ArgoCDNodePlacementSpec struct {
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 }
 
+// ArgoCDWorkloadSpec defines the rollout settings of the Deployments and StatefulSets of the Argo CD components.
+type ArgoCDWorkloadSpec struct {
+	// RevisionHistoryLimit is the number of old ReplicaSets of a Deployment, or old revisions of a StatefulSet, kept to
+	// allow a rollback. Default is 3.
+	//+kubebuilder:validation:Minimum=0
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// ProgressDeadlineSeconds is the number of seconds a Deployment may take to make progress before it is reported as
+	// failed. StatefulSets have no progress deadline. Default is the Kubernetes default of 600.
+	//+kubebuilder:validation:Minimum=1
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+}
+
 // ArgoCDSpec defines the desired state of ArgoCD
 // +k8s:openapi-gen=true
 type ArgoCDSpec struct {
 
+	// ArgoCDAgent defines the argocd-agent components, used to manage Applications on workload clusters from a control
+	// plane cluster.
+	ArgoCDAgent *ArgoCDAgentSpec `json:"argoCDAgent,omitempty"`
+
 	// ArgoCDApplicationSet defines whether the Argo CD ApplicationSet controller should be installed.
 	ApplicationSet *ArgoCDApplicationSet `json:"applicationSet,omitempty"`
 
@@ -656,6 +1777,9 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Application Instance Label Key'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ApplicationInstanceLabelKey string `json:"applicationInstanceLabelKey,omitempty"`
 
+	// CmdParams defines the settings the operator manages in the argocd-cmd-params-cm ConfigMap.
+	CmdParams ArgoCDCmdParamsSpec `json:"cmdParams,omitempty"`
+
 	// ConfigManagementPlugins is used to specify additional config management plugins.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Config Management Plugins'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ConfigManagementPlugins string `json:"configManagementPlugins,omitempty"`
@@ -666,17 +1790,39 @@ type ArgoCDSpec struct {
 	// Dex defines the Dex server options for ArgoCD.
 	Dex *ArgoCDDexSpec `json:"dex,omitempty"`
 
+	// DeletionProtection keeps the Secrets holding cluster and repository credentials and the argocd-secret Secret
+	// when the ArgoCD is deleted. Disable it before deleting the ArgoCD to delete them along with it.
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+
 	// DisableAdmin will disable the admin user.
 	DisableAdmin bool `json:"disableAdmin,omitempty"`
 
+	// AdminPasswordSecretRef selects the key of an existing Secret in the namespace of the ArgoCD that holds the
+	// password of the admin user, e.g. a Secret synced by external-secrets. The password is generated when not set.
+	AdminPasswordSecretRef *corev1.SecretKeySelector `json:"adminPasswordSecretRef,omitempty"`
+
+	// SecretKeyRefs defines keys of the argocd-secret Secret whose values are sourced from other Secrets, e.g. Secrets
+	// synced from a vault by external-secrets. The keys can be referenced as $<key> from the Argo CD configuration,
+	// such as the Dex or OIDC configuration.
+	SecretKeyRefs []ArgoCDSecretKeyRef `json:"secretKeyRefs,omitempty"`
+
+	// AdminPasswordRotation defines the policy for regenerating the admin password. Ignored when
+	// AdminPasswordSecretRef is set.
+	AdminPasswordRotation *ArgoCDAdminPasswordRotationSpec `json:"adminPasswordRotation,omitempty"`
+
 	// ExtraConfig can be used to add fields to Argo CD configmap that are not supported by Argo CD CRD.
 	//
 	// Note: ExtraConfig takes precedence over Argo CD CRD.
 	// For example, A user sets `argocd.Spec.DisableAdmin` = true and also
 	// `a.Spec.ExtraConfig["admin.enabled"]` = true. In this case, operator updates
 	// Argo CD Configmap as follows -> argocd-cm.Data["admin.enabled"] = true.
+	// Such conflicts are reported through the ExtraConfigConflict status condition.
 	ExtraConfig map[string]string `json:"extraConfig,omitempty"`
 
+	// ExecProviderTools are the exec plugins copied from container images into the application controller and the
+	// server, for clusters authenticating through an exec provider other than argocd-k8s-auth.
+	ExecProviderTools []ArgoCDExecProviderToolSpec `json:"execProviderTools,omitempty"`
+
 	// GATrackingID is the google analytics tracking ID to use.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Google Analytics Tracking ID'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	GATrackingID string `json:"gaTrackingID,omitempty"`
@@ -706,10 +1852,23 @@ type ArgoCDSpec struct {
 	// Import is the import/restore options for ArgoCD.
 	Import *ArgoCDImportSpec `json:"import,omitempty"`
 
+	// InitialClusters defines the clusters the operator registers with Argo CD by creating cluster secrets.
+	InitialClusters []ArgoCDClusterSpec `json:"initialClusters,omitempty"`
+
+	// InitialProjects defines the AppProjects the operator creates and maintains alongside the Argo CD instance.
+	InitialProjects []ArgoCDProjectSpec `json:"initialProjects,omitempty"`
+
 	// InitialRepositories to configure Argo CD with upon creation of the cluster.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Initial Repositories'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	InitialRepositories string `json:"initialRepositories,omitempty"`
 
+	// InitialRepositorySecrets defines the repositories the operator registers with Argo CD by creating repository secrets.
+	InitialRepositorySecrets []ArgoCDRepositorySpec `json:"initialRepositorySecrets,omitempty"`
+
+	// InitialRepositoryCredentials defines the credential templates the operator registers with Argo CD by creating
+	// repository credential secrets.
+	InitialRepositoryCredentials []ArgoCDRepositoryCredentialsSpec `json:"initialRepositoryCredentials,omitempty"`
+
 	// InitialSSHKnownHosts defines the SSH known hosts data upon creation of the cluster for connecting Git repositories via SSH.
 	InitialSSHKnownHosts SSHHostsSpec `json:"initialSSHKnownHosts,omitempty"`
 
@@ -720,6 +1879,11 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kustomize Build Options'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	KustomizeVersions []KustomizeVersionSpec `json:"kustomizeVersions,omitempty"`
 
+	// ManagedNamespaces defines the namespaces managed by the Argo CD instance, in addition to the namespaces labelled
+	// with argocd.argoproj.io/managed-by. The operator labels these namespaces and reconciles the required Roles and
+	// RoleBindings, and removes them again when a namespace is removed from the list.
+	ManagedNamespaces []string `json:"managedNamespaces,omitempty"`
+
 	// OIDCConfig is the OIDC configuration as an alternative to dex.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OIDC Config'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	OIDCConfig string `json:"oidcConfig,omitempty"`
@@ -764,15 +1928,31 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Action Customizations'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ResourceActions []ResourceAction `json:"resourceActions,omitempty"`
 
-	// ResourceExclusions is used to completely ignore entire classes of resource group/kinds.
+	// ResourceCleanup defines the cleanup of the resources created by the operator for the ArgoCD that are no longer
+	// needed by its spec, e.g. an Ingress left behind after it was disabled.
+	ResourceCleanup ArgoCDResourceCleanupSpec `json:"resourceCleanup,omitempty"`
+
+	// ResourceExclusions is used to completely ignore entire classes of resource group/kinds. Please note that this is
+	// being deprecated in favor of ExcludedResources.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Exclusions'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ResourceExclusions string `json:"resourceExclusions,omitempty"`
 
 	// ResourceInclusions is used to only include specific group/kinds in the
-	// reconciliation process.
+	// reconciliation process. Please note that this is being deprecated in favor of IncludedResources.
 	ResourceInclusions string `json:"resourceInclusions,omitempty"`
 
-	// ResourceTrackingMethod defines how Argo CD should track resources that it manages
+	// ExcludedResources is used to completely ignore entire classes of resource group/kinds. Takes precedence over
+	// ResourceExclusions.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Excluded Resources'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	ExcludedResources []ResourceFilter `json:"excludedResources,omitempty"`
+
+	// IncludedResources is used to only include specific group/kinds in the reconciliation process. Takes precedence
+	// over ResourceInclusions.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Included Resources'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	IncludedResources []ResourceFilter `json:"includedResources,omitempty"`
+
+	// ResourceTrackingMethod defines how Argo CD should track resources that it manages. Valid options are label, annotation and annotation+label.
+	//+kubebuilder:validation:Enum=label;annotation;annotation+label
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Tracking Method'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ResourceTrackingMethod string `json:"resourceTrackingMethod,omitempty"`
 
@@ -792,6 +1972,18 @@ type ArgoCDSpec struct {
 	// TLS defines the TLS options for ArgoCD.
 	TLS ArgoCDTLSSpec `json:"tls,omitempty"`
 
+	// UnmanagedConfigKeys lists the keys of the argocd-cm ConfigMap that the operator must not set, update or remove,
+	// so that settings tuned by hand are not reverted. Entries may be glob patterns, e.g. `resource.customizations.*`.
+	// Unmanaged keys take precedence over the settings of the ArgoCD, including ExtraConfig.
+	UnmanagedConfigKeys []string `json:"unmanagedConfigKeys,omitempty"`
+
+	// UpgradeStrategy defines how the components are rolled out when the Argo CD image changes, e.g. on a change of
+	// Version. With Parallel, the default, all components are updated at once. With Ordered, the components are
+	// updated one after the other, in the order Redis, repo server, application controller, server, Dex and
+	// notifications controller, each waiting for the previous ones to be ready.
+	//+kubebuilder:validation:Enum=Parallel;Ordered
+	UpgradeStrategy ArgoCDUpgradeStrategyType `json:"upgradeStrategy,omitempty"`
+
 	// UsersAnonymousEnabled toggles anonymous user access.
 	// The anonymous users get default role permissions specified argocd-rbac-cm.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Anonymous Users Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
@@ -801,10 +1993,46 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Version",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:ArgoCD","urn:alm:descriptor:com.tectonic.ui:text"}
 	Version string `json:"version,omitempty"`
 
+	// Workloads defines the rollout settings of the Deployments and StatefulSets of the Argo CD components.
+	Workloads *ArgoCDWorkloadSpec `json:"workloads,omitempty"`
+
 	// Banner defines an additional banner to be displayed in Argo CD UI
 	Banner *Banner `json:"banner,omitempty"`
 }
 
+// ArgoCDResourceCleanupSpec defines the cleanup of the resources of an ArgoCD that are no longer needed by its spec.
+type ArgoCDResourceCleanupSpec struct {
+	// Enabled toggles the deletion of the resources created by the operator for the ArgoCD that are no longer needed
+	// by its spec.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// DryRun only reports the resources that are no longer needed in the status of the ArgoCD, without deleting them.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// ArgoCDUpgradeStrategyType defines how the components are rolled out when the Argo CD image changes.
+type ArgoCDUpgradeStrategyType string
+
+const (
+	// ArgoCDUpgradeStrategyParallel updates all components at once.
+	ArgoCDUpgradeStrategyParallel ArgoCDUpgradeStrategyType = "Parallel"
+
+	// ArgoCDUpgradeStrategyOrdered updates the components one after the other, waiting for each to be ready.
+	ArgoCDUpgradeStrategyOrdered ArgoCDUpgradeStrategyType = "Ordered"
+)
+
+// ArgoCDUpgradeStatus reports the progress of an ordered upgrade.
+type ArgoCDUpgradeStatus struct {
+	// Image is the Argo CD image the components are upgraded to.
+	Image string `json:"image,omitempty"`
+
+	// Phase is Progressing while the components are rolled out, and Completed once all of them run the image.
+	Phase string `json:"phase,omitempty"`
+
+	// Stage is the name suffix of the component being rolled out, e.g. repo-server, while the upgrade is Progressing.
+	Stage string `json:"stage,omitempty"`
+}
+
 // ArgoCDStatus defines the observed state of ArgoCD
 // +k8s:openapi-gen=true
 type ArgoCDStatus struct {
@@ -851,6 +2079,12 @@ type ArgoCDStatus struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="SSOConfig",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	SSOConfig string `json:"ssoConfig,omitempty"`
 
+	// RBACConfig defines the status of the RBAC policy configuration.
+	// Success: The RBAC policy CSV in the CR is valid and has been applied.
+	// Failed: The RBAC policy CSV in the CR contains malformed lines and has not been applied.
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="RBACConfig",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	RBACConfig string `json:"rbacConfig,omitempty"`
+
 	// Phase is a simple, high-level summary of where the ArgoCD is in its lifecycle.
 	// There are four possible phase values:
 	// Pending: The ArgoCD has been accepted by the Kubernetes system, but one or more of the required resources have not been created.
@@ -878,6 +2112,11 @@ type ArgoCDStatus struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Repo",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	Repo string `json:"repo,omitempty"`
 
+	// ResourceTrackingMethod is the resource tracking method currently configured for Argo CD.
+	// Falls back to label when an invalid method is requested in the spec.
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="ResourceTrackingMethod",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	ResourceTrackingMethod string `json:"resourceTrackingMethod,omitempty"`
+
 	// Server is a simple, high-level summary of where the Argo CD server component is in its lifecycle.
 	// There are four possible server values:
 	// Pending: The Argo CD server component has been accepted by the Kubernetes system, but one or more of the required resources have not been created.
@@ -893,10 +2132,71 @@ type ArgoCDStatus struct {
 	// RedisTLSChecksum contains the SHA256 checksum of the latest known state of tls.crt and tls.key in the argocd-operator-redis-tls secret.
 	RedisTLSChecksum string `json:"redisTLSChecksum,omitempty"`
 
+	// Certificates lists the expiry of the TLS certificates used by the Argo CD components.
+	// +listType=map
+	// +listMapKey=secretName
+	// +optional
+	Certificates []ArgoCDCertificateStatus `json:"certificates,omitempty"`
+
+	// ServerTLSChecksum contains the SHA256 checksum of the latest known state of tls.crt and tls.key in the argocd-server-tls secret.
+	ServerTLSChecksum string `json:"serverTLSChecksum,omitempty"`
+
+	// CmdParamsChecksum contains the SHA256 checksum of the latest known state of the argocd-cmd-params-cm ConfigMap.
+	CmdParamsChecksum string `json:"cmdParamsChecksum,omitempty"`
+
+	// DexTLSChecksum contains the SHA256 checksum of the latest known state of tls.crt and tls.key in the argocd-dex-server-tls secret.
+	DexTLSChecksum string `json:"dexTLSChecksum,omitempty"`
+
+	// GrafanaDashboardsChecksum is the SHA256 checksum of the Grafana dashboards last published to the external Grafana.
+	GrafanaDashboardsChecksum string `json:"grafanaDashboardsChecksum,omitempty"`
+
 	// Host is the hostname of the Ingress.
 	Host string `json:"host,omitempty"`
+
+	// ManagedNamespaces lists the namespaces, other than the namespace of the ArgoCD, that are currently labelled as
+	// managed by the ArgoCD.
+	ManagedNamespaces []string `json:"managedNamespaces,omitempty"`
+
+	// RejectedNamespaces lists the namespaces that are labelled as managed by the ArgoCD, but are not allowed to be
+	// managed by it according to the managed namespaces allow-list of the operator.
+	RejectedNamespaces []string `json:"rejectedNamespaces,omitempty"`
+
+	// OrphanedResources lists the resources, as Kind/name, created by the operator for the ArgoCD that are no longer
+	// needed by its spec, when the resource cleanup runs in dry-run mode.
+	OrphanedResources []string `json:"orphanedResources,omitempty"`
+
+	// Upgrade reports the progress of the ordered upgrade of the components, when UpgradeStrategy is Ordered.
+	Upgrade *ArgoCDUpgradeStatus `json:"upgrade,omitempty"`
+
+	// Conditions describe the latest observations of the state of the ArgoCD.
+	//+listType=map
+	//+listMapKey=type
+	//+optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// ArgoCDConditionTypeExtraConfigConflict is the type of the condition reporting whether keys of ExtraConfig
+	// override values of argocd-cm that are managed through first-class fields of the ArgoCD.
+	ArgoCDConditionTypeExtraConfigConflict = "ExtraConfigConflict"
+
+	// ArgoCDConditionTypeVersionSkew is the type of the condition reporting whether components pinned to another
+	// version than the ArgoCD run a version outside of the supported skew.
+	ArgoCDConditionTypeVersionSkew = "VersionSkew"
+
+	// ArgoCDConditionTypeReconcilePaused is the type of the condition reporting whether the reconciliation of the
+	// resources of the ArgoCD is paused through the reconcile annotation.
+	ArgoCDConditionTypeReconcilePaused = "ReconcilePaused"
+
+	// ArgoCDConditionTypeResourceQuotaExceeded is the type of the condition reporting whether the resources of the
+	// components exceed a ResourceQuota of the namespace of the ArgoCD, so that their pods would not be admitted.
+	ArgoCDConditionTypeResourceQuotaExceeded = "ResourceQuotaExceeded"
+
+	// ArgoCDConditionTypeComponentReconcileFailed is the type of the condition reporting whether components of the
+	// ArgoCD failed to reconcile, while the other components were reconciled.
+	ArgoCDConditionTypeComponentReconcileFailed = "ComponentReconcileFailed"
+)
+
 // Banner defines an additional banner message to be displayed in Argo CD UI
 // https://argo-cd.readthedocs.io/en/stable/operator-manual/custom-styles/#banners
 type Banner struct {
@@ -913,6 +2213,40 @@ type ArgoCDTLSSpec struct {
 
 	// InitialCerts defines custom TLS certificates upon creation of the cluster for connecting Git repositories via HTTPS.
 	InitialCerts map[string]string `json:"initialCerts,omitempty"`
+
+	// CertManager defines the options for issuing the argocd-server, repo-server and dex certificates through cert-manager.
+	CertManager *ArgoCDCertManagerSpec `json:"certManager,omitempty"`
+
+	// RenewBefore is the remaining validity of the certificates generated by the operator, below which they are
+	// renewed. Defaults to 720h.
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// ArgoCDCertManagerSpec defines the options for issuing Argo CD component certificates through cert-manager.
+type ArgoCDCertManagerSpec struct {
+	// Enabled will toggle the creation of cert-manager Certificates for the Argo CD components.
+	Enabled bool `json:"enabled"`
+
+	// IssuerRef is a reference to the cert-manager issuer that signs the component certificates.
+	IssuerRef ArgoCDCertManagerIssuerRef `json:"issuerRef"`
+
+	// Duration is the requested lifetime of the component certificates. Defaults to the issuer's default.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is how long before expiry cert-manager renews the component certificates. Defaults to the issuer's default.
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// ArgoCDCertManagerIssuerRef references a cert-manager Issuer or ClusterIssuer.
+type ArgoCDCertManagerIssuerRef struct {
+	// Name of the issuer.
+	Name string `json:"name"`
+
+	// Kind of the issuer, either Issuer or ClusterIssuer. Defaults to Issuer.
+	Kind string `json:"kind,omitempty"`
+
+	// Group of the issuer. Defaults to cert-manager.io.
+	Group string `json:"group,omitempty"`
 }
 
 type SSHHostsSpec struct {
@@ -967,6 +2301,56 @@ func (r *ArgoCDRedisSpec) WantsAutoTLS() bool {
 	return r.AutoTLS == "openshift"
 }
 
+// WantsOperatorTLS returns true if the redis server configuration requests a
+// TLS certificate issued by the operator.
+func (r *ArgoCDRedisSpec) WantsOperatorTLS() bool {
+	return r.AutoTLS == "operator"
+}
+
+// IsPersistent returns true if the redis server configuration requests
+// the Redis data to be persisted.
+func (r *ArgoCDRedisSpec) IsPersistent() bool {
+	return r.Persistence != nil && r.Persistence.Enabled
+}
+
+// IsEnabled returns true if the Redis server is deployed by the operator.
+func (r *ArgoCDRedisSpec) IsEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+// IsEnabled returns true if the repo server is deployed by the operator.
+func (r *ArgoCDRepoSpec) IsEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+// IsRemote returns true if the repo server configuration points to an
+// externally managed repo server.
+func (r *ArgoCDRepoSpec) IsRemote() bool {
+	return r.Remote != ""
+}
+
+// IsEnabled returns true if the Argo CD Server is deployed by the operator.
+func (s *ArgoCDServerSpec) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+// IsEnabled returns true if the Application Controller is deployed by the operator.
+func (c *ArgoCDApplicationControllerSpec) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// IsRemote returns true if the redis server configuration points to an
+// externally managed Redis server.
+func (r *ArgoCDRedisSpec) IsRemote() bool {
+	return r.Remote != nil
+}
+
+// WantsCertManager returns true if the TLS configuration requests the
+// component certificates to be issued by cert-manager.
+func (t *ArgoCDTLSSpec) WantsCertManager() bool {
+	return t.CertManager != nil && t.CertManager.Enabled
+}
+
 // ApplicationInstanceLabelKey returns either the custom application instance
 // label key if set, or the default value.
 func (a *ArgoCD) ApplicationInstanceLabelKey() string {
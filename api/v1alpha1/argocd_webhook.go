@@ -0,0 +1,93 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"os"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+//+kubebuilder:webhook:path=/mutate-argoproj-io-v1alpha1-argocd,mutating=true,failurePolicy=fail,sideEffects=None,groups=argoproj.io,resources=argocds,verbs=create;update,versions=v1alpha1,name=margocd.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &ArgoCD{}
+
+// SetupWebhookWithManager registers the webhook that fills in the operator defaults of ArgoCD instances with the given
+// manager.
+func (r *ArgoCD) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// Default fills in the defaults the operator applies to the unset fields of the ArgoCD, so that the stored object
+// shows the effective configuration.
+//
+// Images are only defaulted when neither the image nor the version is set, and the operator does not override the
+// default through its environment, as the operator would otherwise not use the defaults either.
+func (r *ArgoCD) Default() {
+	defaultImage(&r.Spec.Image, &r.Spec.Version,
+		common.ArgoCDDefaultArgoImage, common.ArgoCDDefaultArgoVersion, common.ArgoCDImageEnvName)
+	defaultImage(&r.Spec.Repo.Image, &r.Spec.Repo.Version,
+		common.ArgoCDDefaultArgoImage, common.ArgoCDDefaultArgoVersion, common.ArgoCDImageEnvName)
+
+	// The Redis image is the fallback of the HA image, which has its own defaults.
+	if !r.Spec.HA.Enabled {
+		defaultImage(&r.Spec.Redis.Image, &r.Spec.Redis.Version,
+			common.ArgoCDDefaultRedisImage, common.ArgoCDDefaultRedisVersion, common.ArgoCDRedisImageEnvName)
+	}
+
+	// The deprecated .spec.dex takes precedence over .spec.sso.dex, so only the latter is defaulted when it is in use.
+	if r.Spec.SSO != nil && r.Spec.SSO.Provider == SSOProviderTypeDex && r.Spec.SSO.Dex != nil &&
+		(r.Spec.Dex == nil || (r.Spec.Dex.Image == "" && r.Spec.Dex.Version == "")) {
+		defaultImage(&r.Spec.SSO.Dex.Image, &r.Spec.SSO.Dex.Version,
+			common.ArgoCDDefaultDexImage, common.ArgoCDDefaultDexVersion, common.ArgoCDDexImageEnvName)
+	}
+
+	// In HA mode, the argocd-server and the argocd-repo-server run multiple replicas.
+	replicas := int32(1)
+	if r.Spec.HA.Enabled {
+		replicas = common.ArgoCDDefaultHAReplicas
+	}
+
+	// The replicas of an autoscaled argocd-server are managed by its HorizontalPodAutoscaler.
+	if r.Spec.Server.Replicas == nil && !r.Spec.Server.Autoscale.Enabled {
+		r.Spec.Server.Replicas = int32Ptr(replicas)
+	}
+	if r.Spec.Repo.Replicas == nil {
+		r.Spec.Repo.Replicas = int32Ptr(replicas)
+	}
+
+	// The resources of the components are not defaulted, as the operator picks them from its sizing profile.
+}
+
+// defaultImage sets the given image and version to their defaults, when both are unset and the image is not
+// overridden by the given environment variable.
+func defaultImage(img, tag *string, defaultImg, defaultTag, envName string) {
+	if *img != "" || *tag != "" || os.Getenv(envName) != "" {
+		return
+	}
+	*img = defaultImg
+	*tag = defaultTag
+}
+
+func int32Ptr(val int32) *int32 {
+	return &val
+}
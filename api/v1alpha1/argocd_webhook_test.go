@@ -0,0 +1,82 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestArgoCD_Default(t *testing.T) {
+	cr := &ArgoCD{}
+	cr.Default()
+
+	assert.Equal(t, common.ArgoCDDefaultArgoImage, cr.Spec.Image)
+	assert.Equal(t, common.ArgoCDDefaultArgoVersion, cr.Spec.Version)
+	assert.Equal(t, common.ArgoCDDefaultArgoImage, cr.Spec.Repo.Image)
+	assert.Equal(t, common.ArgoCDDefaultArgoVersion, cr.Spec.Repo.Version)
+	assert.Equal(t, common.ArgoCDDefaultRedisImage, cr.Spec.Redis.Image)
+	assert.Equal(t, common.ArgoCDDefaultRedisVersion, cr.Spec.Redis.Version)
+	assert.Equal(t, int32(1), *cr.Spec.Server.Replicas)
+	assert.Equal(t, int32(1), *cr.Spec.Repo.Replicas)
+	assert.Nil(t, cr.Spec.Server.Resources)
+	assert.Nil(t, cr.Spec.SSO)
+}
+
+func TestArgoCD_DefaultKeepsUserSettings(t *testing.T) {
+	cr := &ArgoCD{}
+	cr.Spec.Version = "v2.5.0"
+	cr.Spec.Server.Replicas = int32Ptr(3)
+	cr.Spec.Repo.Image = "my.registry/argocd"
+	cr.Default()
+
+	assert.Equal(t, "", cr.Spec.Image)
+	assert.Equal(t, "v2.5.0", cr.Spec.Version)
+	assert.Equal(t, "my.registry/argocd", cr.Spec.Repo.Image)
+	assert.Equal(t, "", cr.Spec.Repo.Version)
+	assert.Equal(t, int32(3), *cr.Spec.Server.Replicas)
+}
+
+func TestArgoCD_DefaultImageEnvOverride(t *testing.T) {
+	t.Setenv(common.ArgoCDImageEnvName, "my.registry/argocd:latest")
+	cr := &ArgoCD{}
+	cr.Default()
+
+	assert.Equal(t, "", cr.Spec.Image)
+	assert.Equal(t, "", cr.Spec.Version)
+	assert.Equal(t, common.ArgoCDDefaultRedisImage, cr.Spec.Redis.Image)
+}
+
+func TestArgoCD_DefaultAutoscale(t *testing.T) {
+	cr := &ArgoCD{}
+	cr.Spec.Server.Autoscale.Enabled = true
+	cr.Default()
+
+	// the resources of an autoscaled server are picked by the operator
+	assert.Nil(t, cr.Spec.Server.Replicas)
+	assert.Nil(t, cr.Spec.Server.Resources)
+}
+
+func TestArgoCD_DefaultHA(t *testing.T) {
+	cr := &ArgoCD{}
+	cr.Spec.HA.Enabled = true
+	cr.Default()
+
+	assert.Equal(t, common.ArgoCDDefaultHAReplicas, *cr.Spec.Server.Replicas)
+	assert.Equal(t, common.ArgoCDDefaultHAReplicas, *cr.Spec.Repo.Replicas)
+}
+
+func TestArgoCD_DefaultSSODex(t *testing.T) {
+	cr := &ArgoCD{}
+	cr.Spec.HA.Enabled = true
+	cr.Spec.SSO = &ArgoCDSSOSpec{
+		Provider: SSOProviderTypeDex,
+		Dex:      &ArgoCDDexSpec{OpenShiftOAuth: true},
+	}
+	cr.Default()
+
+	assert.Equal(t, common.ArgoCDDefaultDexImage, cr.Spec.SSO.Dex.Image)
+	assert.Equal(t, common.ArgoCDDefaultDexVersion, cr.Spec.SSO.Dex.Version)
+	assert.Equal(t, "", cr.Spec.Redis.Image)
+}
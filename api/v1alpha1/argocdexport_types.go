@@ -73,6 +73,10 @@ type ArgoCDExportSpec struct {
 	// Image is the container image to use for the export Job.
 	Image string `json:"image,omitempty"`
 
+	// Retention defines which exports are kept when the export is scheduled. All exports are kept if not set, and only
+	// the most recent one if no schedule is set.
+	Retention *ArgoCDExportRetentionSpec `json:"retention,omitempty"`
+
 	// Schedule in Cron format, see https://en.wikipedia.org/wiki/Cron.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Schedule",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	Schedule *string `json:"schedule,omitempty"`
@@ -97,6 +101,26 @@ type ArgoCDExportStatus struct {
 	// Unknown: For some reason the state of the ArgoCDExport could not be obtained.
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Phase",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	Phase string `json:"phase"`
+
+	// LastSuccessfulExportTime is the time the most recent successful export completed.
+	LastSuccessfulExportTime *metav1.Time `json:"lastSuccessfulExportTime,omitempty"`
+
+	// LastExportSize is the size in bytes of the archive of the most recent successful export.
+	LastExportSize *int64 `json:"lastExportSize,omitempty"`
+
+	// LastExportKeyFingerprint is the SHA-256 fingerprint of the passphrase the most recent successful export was
+	// encrypted with, to identify the passphrase required to import it.
+	LastExportKeyFingerprint string `json:"lastExportKeyFingerprint,omitempty"`
+}
+
+// ArgoCDExportRetentionSpec defines the retention policy for the exports of a scheduled ArgoCDExport.
+type ArgoCDExportRetentionSpec struct {
+	// KeepLast is the number of most recent exports to keep. Older exports are pruned.
+	//+kubebuilder:validation:Minimum=1
+	KeepLast *int32 `json:"keepLast,omitempty"`
+
+	// MaxAge is the maximum age of the exports to keep, e.g. "720h". Older exports are pruned.
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
 }
 
 // ArgoCDExportStorageSpec defines the desired state for ArgoCDExport storage options.
@@ -104,6 +128,19 @@ type ArgoCDExportStorageSpec struct {
 	// Backend defines the storage backend to use, must be "local" (the default), "aws", "azure" or "gcp".
 	Backend string `json:"backend,omitempty"`
 
+	// Bucket is the name of the S3 or GCS bucket, or the Azure Blob container, the export archive is uploaded to.
+	// Takes precedence over the bucket or container name in the Secret. Ignored for the "local" backend.
+	Bucket string `json:"bucket,omitempty"`
+
+	// EncryptionKeySecretRef selects the key of an existing Secret in the namespace of the ArgoCDExport that holds the
+	// passphrase the export archive is encrypted with. The passphrase is generated into the export Secret when not set.
+	// Rotating the passphrase only applies to subsequent exports.
+	EncryptionKeySecretRef *corev1.SecretKeySelector `json:"encryptionKeySecretRef,omitempty"`
+
+	// Prefix is prepended to the name of the export archive in the bucket, e.g. "backups/argocd".
+	// Ignored for the "local" backend.
+	Prefix string `json:"prefix,omitempty"`
+
 	// PVC is the desired characteristics for a PersistentVolumeClaim.
 	PVC *corev1.PersistentVolumeClaimSpec `json:"pvc,omitempty"`
 
@@ -22,10 +22,12 @@ limitations under the License.
 package v1alpha1
 
 import (
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	"k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -36,7 +38,7 @@ func (in *ArgoCD) DeepCopyInto(out *ArgoCD) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCD.
@@ -57,6 +59,122 @@ func (in *ArgoCD) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAdminPasswordRotationSpec) DeepCopyInto(out *ArgoCDAdminPasswordRotationSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAdminPasswordRotationSpec.
+func (in *ArgoCDAdminPasswordRotationSpec) DeepCopy() *ArgoCDAdminPasswordRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAdminPasswordRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAgentAgentSpec) DeepCopyInto(out *ArgoCDAgentAgentSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAgentAgentSpec.
+func (in *ArgoCDAgentAgentSpec) DeepCopy() *ArgoCDAgentAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAgentAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAgentPrincipalSpec) DeepCopyInto(out *ArgoCDAgentPrincipalSpec) {
+	*out = *in
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Agents != nil {
+		in, out := &in.Agents, &out.Agents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAgentPrincipalSpec.
+func (in *ArgoCDAgentPrincipalSpec) DeepCopy() *ArgoCDAgentPrincipalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAgentPrincipalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAgentSpec) DeepCopyInto(out *ArgoCDAgentSpec) {
+	*out = *in
+	if in.Principal != nil {
+		in, out := &in.Principal, &out.Principal
+		*out = new(ArgoCDAgentPrincipalSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(ArgoCDAgentAgentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAgentSpec.
+func (in *ArgoCDAgentSpec) DeepCopy() *ArgoCDAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDApplicationControllerMetricsSpec) DeepCopyInto(out *ArgoCDApplicationControllerMetricsSpec) {
+	*out = *in
+	in.ServiceMonitor.DeepCopyInto(&out.ServiceMonitor)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationControllerMetricsSpec.
+func (in *ArgoCDApplicationControllerMetricsSpec) DeepCopy() *ArgoCDApplicationControllerMetricsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDApplicationControllerMetricsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDApplicationControllerProcessorsSpec) DeepCopyInto(out *ArgoCDApplicationControllerProcessorsSpec) {
 	*out = *in
@@ -75,6 +193,16 @@ func (in *ArgoCDApplicationControllerProcessorsSpec) DeepCopy() *ArgoCDApplicati
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDApplicationControllerShardSpec) DeepCopyInto(out *ArgoCDApplicationControllerShardSpec) {
 	*out = *in
+	if in.DynamicScalingEnabled != nil {
+		in, out := &in.DynamicScalingEnabled, &out.DynamicScalingEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ClusterAssignment != nil {
+		in, out := &in.ClusterAssignment, &out.ClusterAssignment
+		*out = new(ArgoCDClusterShardAssignmentSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationControllerShardSpec.
@@ -90,6 +218,11 @@ func (in *ArgoCDApplicationControllerShardSpec) DeepCopy() *ArgoCDApplicationCon
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDApplicationControllerSpec) DeepCopyInto(out *ArgoCDApplicationControllerSpec) {
 	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
 	out.Processors = in.Processors
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
@@ -101,7 +234,17 @@ func (in *ArgoCDApplicationControllerSpec) DeepCopyInto(out *ArgoCDApplicationCo
 		*out = new(metav1.Duration)
 		**out = **in
 	}
-	out.Sharding = in.Sharding
+	if in.SelfHealTimeout != nil {
+		in, out := &in.SelfHealTimeout, &out.SelfHealTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ServerSideDiff != nil {
+		in, out := &in.ServerSideDiff, &out.ServerSideDiff
+		*out = new(bool)
+		**out = **in
+	}
+	in.Sharding.DeepCopyInto(&out.Sharding)
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]v1.EnvVar, len(*in))
@@ -109,6 +252,25 @@ func (in *ArgoCDApplicationControllerSpec) DeepCopyInto(out *ArgoCDApplicationCo
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.Metrics.DeepCopyInto(&out.Metrics)
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(ArgoCDApplicationControllerStorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Service.DeepCopyInto(&out.Service)
+	if in.ClusterRole != nil {
+		in, out := &in.ClusterRole, &out.ClusterRole
+		*out = new(ArgoCDClusterRoleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountAnnotations != nil {
+		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationControllerSpec.
@@ -121,6 +283,31 @@ func (in *ArgoCDApplicationControllerSpec) DeepCopy() *ArgoCDApplicationControll
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDApplicationControllerStorageSpec) DeepCopyInto(out *ArgoCDApplicationControllerStorageSpec) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationControllerStorageSpec.
+func (in *ArgoCDApplicationControllerStorageSpec) DeepCopy() *ArgoCDApplicationControllerStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDApplicationControllerStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDApplicationSet) DeepCopyInto(out *ArgoCDApplicationSet) {
 	*out = *in
@@ -141,7 +328,33 @@ func (in *ArgoCDApplicationSet) DeepCopyInto(out *ArgoCDApplicationSet) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.Metrics.DeepCopyInto(&out.Metrics)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SCMProviders != nil {
+		in, out := &in.SCMProviders, &out.SCMProviders
+		*out = make([]ArgoCDApplicationSetSCMProviderSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.WebhookServer.DeepCopyInto(&out.WebhookServer)
+	in.Service.DeepCopyInto(&out.Service)
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]v1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationSet.
@@ -155,346 +368,383 @@ func (in *ArgoCDApplicationSet) DeepCopy() *ArgoCDApplicationSet {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDCASpec) DeepCopyInto(out *ArgoCDCASpec) {
+func (in *ArgoCDApplicationSetMetricsSpec) DeepCopyInto(out *ArgoCDApplicationSetMetricsSpec) {
 	*out = *in
+	in.ServiceMonitor.DeepCopyInto(&out.ServiceMonitor)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDCASpec.
-func (in *ArgoCDCASpec) DeepCopy() *ArgoCDCASpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationSetMetricsSpec.
+func (in *ArgoCDApplicationSetMetricsSpec) DeepCopy() *ArgoCDApplicationSetMetricsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDCASpec)
+	out := new(ArgoCDApplicationSetMetricsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDCertificateSpec) DeepCopyInto(out *ArgoCDCertificateSpec) {
+func (in *ArgoCDApplicationSetSCMProviderSpec) DeepCopyInto(out *ArgoCDApplicationSetSCMProviderSpec) {
 	*out = *in
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitHubApp != nil {
+		in, out := &in.GitHubApp, &out.GitHubApp
+		*out = new(ArgoCDGitHubAppCredentialsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDCertificateSpec.
-func (in *ArgoCDCertificateSpec) DeepCopy() *ArgoCDCertificateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationSetSCMProviderSpec.
+func (in *ArgoCDApplicationSetSCMProviderSpec) DeepCopy() *ArgoCDApplicationSetSCMProviderSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDCertificateSpec)
+	out := new(ArgoCDApplicationSetSCMProviderSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDDexOAuthSpec) DeepCopyInto(out *ArgoCDDexOAuthSpec) {
+func (in *ArgoCDCASpec) DeepCopyInto(out *ArgoCDCASpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexOAuthSpec.
-func (in *ArgoCDDexOAuthSpec) DeepCopy() *ArgoCDDexOAuthSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDCASpec.
+func (in *ArgoCDCASpec) DeepCopy() *ArgoCDCASpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDDexOAuthSpec)
+	out := new(ArgoCDCASpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDDexSpec) DeepCopyInto(out *ArgoCDDexSpec) {
+func (in *ArgoCDCertManagerIssuerRef) DeepCopyInto(out *ArgoCDCertManagerIssuerRef) {
 	*out = *in
-	if in.Groups != nil {
-		in, out := &in.Groups, &out.Groups
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDCertManagerIssuerRef.
+func (in *ArgoCDCertManagerIssuerRef) DeepCopy() *ArgoCDCertManagerIssuerRef {
+	if in == nil {
+		return nil
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	out := new(ArgoCDCertManagerIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDCertManagerSpec) DeepCopyInto(out *ArgoCDCertManagerSpec) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexSpec.
-func (in *ArgoCDDexSpec) DeepCopy() *ArgoCDDexSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDCertManagerSpec.
+func (in *ArgoCDCertManagerSpec) DeepCopy() *ArgoCDCertManagerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDDexSpec)
+	out := new(ArgoCDCertManagerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDExport) DeepCopyInto(out *ArgoCDExport) {
+func (in *ArgoCDCertificateSpec) DeepCopyInto(out *ArgoCDCertificateSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExport.
-func (in *ArgoCDExport) DeepCopy() *ArgoCDExport {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDCertificateSpec.
+func (in *ArgoCDCertificateSpec) DeepCopy() *ArgoCDCertificateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDExport)
+	out := new(ArgoCDCertificateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ArgoCDExport) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDCertificateStatus) DeepCopyInto(out *ArgoCDCertificateStatus) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDCertificateStatus.
+func (in *ArgoCDCertificateStatus) DeepCopy() *ArgoCDCertificateStatus {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(ArgoCDCertificateStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDExportList) DeepCopyInto(out *ArgoCDExportList) {
+func (in *ArgoCDClusterRoleSpec) DeepCopyInto(out *ArgoCDClusterRoleSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ArgoCDExport, len(*in))
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]rbacv1.PolicyRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportList.
-func (in *ArgoCDExportList) DeepCopy() *ArgoCDExportList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDClusterRoleSpec.
+func (in *ArgoCDClusterRoleSpec) DeepCopy() *ArgoCDClusterRoleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDExportList)
+	out := new(ArgoCDClusterRoleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ArgoCDExportList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDExportSpec) DeepCopyInto(out *ArgoCDExportSpec) {
+func (in *ArgoCDClusterExecProviderSpec) DeepCopyInto(out *ArgoCDClusterExecProviderSpec) {
 	*out = *in
-	if in.Schedule != nil {
-		in, out := &in.Schedule, &out.Schedule
-		*out = new(string)
-		**out = **in
-	}
-	if in.Storage != nil {
-		in, out := &in.Storage, &out.Storage
-		*out = new(ArgoCDExportStorageSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportSpec.
-func (in *ArgoCDExportSpec) DeepCopy() *ArgoCDExportSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDClusterExecProviderSpec.
+func (in *ArgoCDClusterExecProviderSpec) DeepCopy() *ArgoCDClusterExecProviderSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDExportSpec)
+	out := new(ArgoCDClusterExecProviderSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDExportStatus) DeepCopyInto(out *ArgoCDExportStatus) {
+func (in *ArgoCDClusterShardAssignmentSpec) DeepCopyInto(out *ArgoCDClusterShardAssignmentSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportStatus.
-func (in *ArgoCDExportStatus) DeepCopy() *ArgoCDExportStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDClusterShardAssignmentSpec.
+func (in *ArgoCDClusterShardAssignmentSpec) DeepCopy() *ArgoCDClusterShardAssignmentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDExportStatus)
+	out := new(ArgoCDClusterShardAssignmentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDExportStorageSpec) DeepCopyInto(out *ArgoCDExportStorageSpec) {
+func (in *ArgoCDClusterSpec) DeepCopyInto(out *ArgoCDClusterSpec) {
 	*out = *in
-	if in.PVC != nil {
-		in, out := &in.PVC, &out.PVC
-		*out = new(v1.PersistentVolumeClaimSpec)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExecProvider != nil {
+		in, out := &in.ExecProvider, &out.ExecProvider
+		*out = new(ArgoCDClusterExecProviderSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportStorageSpec.
-func (in *ArgoCDExportStorageSpec) DeepCopy() *ArgoCDExportStorageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDClusterSpec.
+func (in *ArgoCDClusterSpec) DeepCopy() *ArgoCDClusterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDExportStorageSpec)
+	out := new(ArgoCDClusterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDGrafanaSpec) DeepCopyInto(out *ArgoCDGrafanaSpec) {
+func (in *ArgoCDCmdParamsSpec) DeepCopyInto(out *ArgoCDCmdParamsSpec) {
 	*out = *in
-	in.Ingress.DeepCopyInto(&out.Ingress)
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	in.Route.DeepCopyInto(&out.Route)
-	if in.Size != nil {
-		in, out := &in.Size, &out.Size
-		*out = new(int32)
-		**out = **in
-	}
+	in.Controller.DeepCopyInto(&out.Controller)
+	in.RepoServer.DeepCopyInto(&out.RepoServer)
+	in.Server.DeepCopyInto(&out.Server)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGrafanaSpec.
-func (in *ArgoCDGrafanaSpec) DeepCopy() *ArgoCDGrafanaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDCmdParamsSpec.
+func (in *ArgoCDCmdParamsSpec) DeepCopy() *ArgoCDCmdParamsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDGrafanaSpec)
+	out := new(ArgoCDCmdParamsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDHASpec) DeepCopyInto(out *ArgoCDHASpec) {
+func (in *ArgoCDControllerCmdParamsSpec) DeepCopyInto(out *ArgoCDControllerCmdParamsSpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.OperationProcessors != nil {
+		in, out := &in.OperationProcessors, &out.OperationProcessors
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RepoServerTimeoutSeconds != nil {
+		in, out := &in.RepoServerTimeoutSeconds, &out.RepoServerTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StatusProcessors != nil {
+		in, out := &in.StatusProcessors, &out.StatusProcessors
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDHASpec.
-func (in *ArgoCDHASpec) DeepCopy() *ArgoCDHASpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDControllerCmdParamsSpec.
+func (in *ArgoCDControllerCmdParamsSpec) DeepCopy() *ArgoCDControllerCmdParamsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDHASpec)
+	out := new(ArgoCDControllerCmdParamsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDImportSpec) DeepCopyInto(out *ArgoCDImportSpec) {
+func (in *ArgoCDDexOAuthSpec) DeepCopyInto(out *ArgoCDDexOAuthSpec) {
 	*out = *in
-	if in.Namespace != nil {
-		in, out := &in.Namespace, &out.Namespace
-		*out = new(string)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDImportSpec.
-func (in *ArgoCDImportSpec) DeepCopy() *ArgoCDImportSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexOAuthSpec.
+func (in *ArgoCDDexOAuthSpec) DeepCopy() *ArgoCDDexOAuthSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDImportSpec)
+	out := new(ArgoCDDexOAuthSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDIngressSpec) DeepCopyInto(out *ArgoCDIngressSpec) {
+func (in *ArgoCDDexSpec) DeepCopyInto(out *ArgoCDDexSpec) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.IngressClassName != nil {
-		in, out := &in.IngressClassName, &out.IngressClassName
-		*out = new(string)
-		**out = **in
+	if in.RedirectURIs != nil {
+		in, out := &in.RedirectURIs, &out.RedirectURIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = make([]networkingv1.IngressTLS, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Service.DeepCopyInto(&out.Service)
+	in.ServiceMonitor.DeepCopyInto(&out.ServiceMonitor)
+	if in.Theme != nil {
+		in, out := &in.Theme, &out.Theme
+		*out = new(ArgoCDSSOThemeSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDIngressSpec.
-func (in *ArgoCDIngressSpec) DeepCopy() *ArgoCDIngressSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexSpec.
+func (in *ArgoCDDexSpec) DeepCopy() *ArgoCDDexSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDIngressSpec)
+	out := new(ArgoCDDexSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDKeycloakSpec) DeepCopyInto(out *ArgoCDKeycloakSpec) {
+func (in *ArgoCDExecProviderToolSpec) DeepCopyInto(out *ArgoCDExecProviderToolSpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VerifyTLS != nil {
-		in, out := &in.VerifyTLS, &out.VerifyTLS
-		*out = new(bool)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExecProviderToolSpec.
+func (in *ArgoCDExecProviderToolSpec) DeepCopy() *ArgoCDExecProviderToolSpec {
+	if in == nil {
+		return nil
 	}
+	out := new(ArgoCDExecProviderToolSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakSpec.
-func (in *ArgoCDKeycloakSpec) DeepCopy() *ArgoCDKeycloakSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDExport) DeepCopyInto(out *ArgoCDExport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExport.
+func (in *ArgoCDExport) DeepCopy() *ArgoCDExport {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDKeycloakSpec)
+	out := new(ArgoCDExport)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDExport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDList) DeepCopyInto(out *ArgoCDList) {
+func (in *ArgoCDExportList) DeepCopyInto(out *ArgoCDExportList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ArgoCD, len(*in))
+		*out = make([]ArgoCDExport, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDList.
-func (in *ArgoCDList) DeepCopy() *ArgoCDList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportList.
+func (in *ArgoCDExportList) DeepCopy() *ArgoCDExportList {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDList)
+	out := new(ArgoCDExportList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ArgoCDList) DeepCopyObject() runtime.Object {
+func (in *ArgoCDExportList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -502,154 +752,866 @@ func (in *ArgoCDList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDMonitoringSpec) DeepCopyInto(out *ArgoCDMonitoringSpec) {
+func (in *ArgoCDExportRetentionSpec) DeepCopyInto(out *ArgoCDExportRetentionSpec) {
 	*out = *in
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMonitoringSpec.
-func (in *ArgoCDMonitoringSpec) DeepCopy() *ArgoCDMonitoringSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportRetentionSpec.
+func (in *ArgoCDExportRetentionSpec) DeepCopy() *ArgoCDExportRetentionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDMonitoringSpec)
+	out := new(ArgoCDExportRetentionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDNodePlacementSpec) DeepCopyInto(out *ArgoCDNodePlacementSpec) {
+func (in *ArgoCDExportSpec) DeepCopyInto(out *ArgoCDExportSpec) {
 	*out = *in
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(ArgoCDExportRetentionSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(string)
+		**out = **in
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(ArgoCDExportStorageSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNodePlacementSpec.
-func (in *ArgoCDNodePlacementSpec) DeepCopy() *ArgoCDNodePlacementSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportSpec.
+func (in *ArgoCDExportSpec) DeepCopy() *ArgoCDExportSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDNodePlacementSpec)
+	out := new(ArgoCDExportSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDNotifications) DeepCopyInto(out *ArgoCDNotifications) {
+func (in *ArgoCDExportStatus) DeepCopyInto(out *ArgoCDExportStatus) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
+	if in.LastSuccessfulExportTime != nil {
+		in, out := &in.LastSuccessfulExportTime, &out.LastSuccessfulExportTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastExportSize != nil {
+		in, out := &in.LastExportSize, &out.LastExportSize
+		*out = new(int64)
 		**out = **in
 	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportStatus.
+func (in *ArgoCDExportStatus) DeepCopy() *ArgoCDExportStatus {
+	if in == nil {
+		return nil
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+	out := new(ArgoCDExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDExportStorageSpec) DeepCopyInto(out *ArgoCDExportStorageSpec) {
+	*out = *in
+	if in.EncryptionKeySecretRef != nil {
+		in, out := &in.EncryptionKeySecretRef, &out.EncryptionKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(v1.PersistentVolumeClaimSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotifications.
-func (in *ArgoCDNotifications) DeepCopy() *ArgoCDNotifications {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportStorageSpec.
+func (in *ArgoCDExportStorageSpec) DeepCopy() *ArgoCDExportStorageSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDNotifications)
+	out := new(ArgoCDExportStorageSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDPrometheusSpec) DeepCopyInto(out *ArgoCDPrometheusSpec) {
+func (in *ArgoCDExternalGrafanaSpec) DeepCopyInto(out *ArgoCDExternalGrafanaSpec) {
 	*out = *in
-	in.Ingress.DeepCopyInto(&out.Ingress)
-	in.Route.DeepCopyInto(&out.Route)
-	if in.Size != nil {
-		in, out := &in.Size, &out.Size
-		*out = new(int32)
-		**out = **in
-	}
+	in.APIKeySecretRef.DeepCopyInto(&out.APIKeySecretRef)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDPrometheusSpec.
-func (in *ArgoCDPrometheusSpec) DeepCopy() *ArgoCDPrometheusSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExternalGrafanaSpec.
+func (in *ArgoCDExternalGrafanaSpec) DeepCopy() *ArgoCDExternalGrafanaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDPrometheusSpec)
+	out := new(ArgoCDExternalGrafanaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDRBACSpec) DeepCopyInto(out *ArgoCDRBACSpec) {
+func (in *ArgoCDGitHubAppCredentialsSpec) DeepCopyInto(out *ArgoCDGitHubAppCredentialsSpec) {
 	*out = *in
-	if in.DefaultPolicy != nil {
-		in, out := &in.DefaultPolicy, &out.DefaultPolicy
-		*out = new(string)
-		**out = **in
-	}
-	if in.Policy != nil {
-		in, out := &in.Policy, &out.Policy
-		*out = new(string)
-		**out = **in
-	}
-	if in.Scopes != nil {
-		in, out := &in.Scopes, &out.Scopes
-		*out = new(string)
-		**out = **in
-	}
-	if in.PolicyMatcherMode != nil {
-		in, out := &in.PolicyMatcherMode, &out.PolicyMatcherMode
-		*out = new(string)
-		**out = **in
-	}
+	in.PrivateKeySecretRef.DeepCopyInto(&out.PrivateKeySecretRef)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACSpec.
-func (in *ArgoCDRBACSpec) DeepCopy() *ArgoCDRBACSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGitHubAppCredentialsSpec.
+func (in *ArgoCDGitHubAppCredentialsSpec) DeepCopy() *ArgoCDGitHubAppCredentialsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDRBACSpec)
+	out := new(ArgoCDGitHubAppCredentialsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDRedisSpec) DeepCopyInto(out *ArgoCDRedisSpec) {
+func (in *ArgoCDGoogleCloudSourceCredentialsSpec) DeepCopyInto(out *ArgoCDGoogleCloudSourceCredentialsSpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
+	in.ServiceAccountKeySecretRef.DeepCopyInto(&out.ServiceAccountKeySecretRef)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisSpec.
-func (in *ArgoCDRedisSpec) DeepCopy() *ArgoCDRedisSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGoogleCloudSourceCredentialsSpec.
+func (in *ArgoCDGoogleCloudSourceCredentialsSpec) DeepCopy() *ArgoCDGoogleCloudSourceCredentialsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDRedisSpec)
+	out := new(ArgoCDGoogleCloudSourceCredentialsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDGrafanaDashboardConfigMapsSpec) DeepCopyInto(out *ArgoCDGrafanaDashboardConfigMapsSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGrafanaDashboardConfigMapsSpec.
+func (in *ArgoCDGrafanaDashboardConfigMapsSpec) DeepCopy() *ArgoCDGrafanaDashboardConfigMapsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDGrafanaDashboardConfigMapsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDGrafanaSpec) DeepCopyInto(out *ArgoCDGrafanaSpec) {
+	*out = *in
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Route.DeepCopyInto(&out.Route)
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(int32)
+		**out = **in
+	}
+	in.Service.DeepCopyInto(&out.Service)
+	if in.DashboardConfigMaps != nil {
+		in, out := &in.DashboardConfigMaps, &out.DashboardConfigMaps
+		*out = new(ArgoCDGrafanaDashboardConfigMapsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGrafanaSpec.
+func (in *ArgoCDGrafanaSpec) DeepCopy() *ArgoCDGrafanaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDGrafanaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDHASpec) DeepCopyInto(out *ArgoCDHASpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RedisProxyReplicas != nil {
+		in, out := &in.RedisProxyReplicas, &out.RedisProxyReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RedisProxyConfig != nil {
+		in, out := &in.RedisProxyConfig, &out.RedisProxyConfig
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SentinelResources != nil {
+		in, out := &in.SentinelResources, &out.SentinelResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SentinelConfig != nil {
+		in, out := &in.SentinelConfig, &out.SentinelConfig
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDHASpec.
+func (in *ArgoCDHASpec) DeepCopy() *ArgoCDHASpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDHASpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDImportSpec) DeepCopyInto(out *ArgoCDImportSpec) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDImportSpec.
+func (in *ArgoCDImportSpec) DeepCopy() *ArgoCDImportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDImportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDIngressSpec) DeepCopyInto(out *ArgoCDIngressSpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.PathType != nil {
+		in, out := &in.PathType, &out.PathType
+		*out = new(networkingv1.PathType)
+		**out = **in
+	}
+	if in.AdditionalHosts != nil {
+		in, out := &in.AdditionalHosts, &out.AdditionalHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = make([]networkingv1.IngressTLS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDIngressSpec.
+func (in *ArgoCDIngressSpec) DeepCopy() *ArgoCDIngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDIngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKeycloakSpec) DeepCopyInto(out *ArgoCDKeycloakSpec) {
+	*out = *in
+	if in.AdminCredentialsSecretRef != nil {
+		in, out := &in.AdminCredentialsSecretRef, &out.AdminCredentialsSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Theme != nil {
+		in, out := &in.Theme, &out.Theme
+		*out = new(ArgoCDSSOThemeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VerifyTLS != nil {
+		in, out := &in.VerifyTLS, &out.VerifyTLS
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakSpec.
+func (in *ArgoCDKeycloakSpec) DeepCopy() *ArgoCDKeycloakSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKeycloakSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDList) DeepCopyInto(out *ArgoCDList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArgoCD, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDList.
+func (in *ArgoCDList) DeepCopy() *ArgoCDList {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDMonitoringSpec) DeepCopyInto(out *ArgoCDMonitoringSpec) {
+	*out = *in
+	if in.ExternalGrafana != nil {
+		in, out := &in.ExternalGrafana, &out.ExternalGrafana
+		*out = new(ArgoCDExternalGrafanaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMonitoringSpec.
+func (in *ArgoCDMonitoringSpec) DeepCopy() *ArgoCDMonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDMonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNodePlacementSpec) DeepCopyInto(out *ArgoCDNodePlacementSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNodePlacementSpec.
+func (in *ArgoCDNodePlacementSpec) DeepCopy() *ArgoCDNodePlacementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNodePlacementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotifications) DeepCopyInto(out *ArgoCDNotifications) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraCommandArgs != nil {
+		in, out := &in.ExtraCommandArgs, &out.ExtraCommandArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodePlacement != nil {
+		in, out := &in.NodePlacement, &out.NodePlacement
+		*out = new(ArgoCDNodePlacementSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Integrations.DeepCopyInto(&out.Integrations)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotifications.
+func (in *ArgoCDNotifications) DeepCopy() *ArgoCDNotifications {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsEmailSpec) DeepCopyInto(out *ArgoCDNotificationsEmailSpec) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsEmailSpec.
+func (in *ArgoCDNotificationsEmailSpec) DeepCopy() *ArgoCDNotificationsEmailSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsEmailSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsIntegrationsSpec) DeepCopyInto(out *ArgoCDNotificationsIntegrationsSpec) {
+	*out = *in
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(ArgoCDNotificationsSlackSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(ArgoCDNotificationsEmailSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]ArgoCDNotificationsWebhookSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsIntegrationsSpec.
+func (in *ArgoCDNotificationsIntegrationsSpec) DeepCopy() *ArgoCDNotificationsIntegrationsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsIntegrationsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsSlackSpec) DeepCopyInto(out *ArgoCDNotificationsSlackSpec) {
+	*out = *in
+	in.TokenSecretRef.DeepCopyInto(&out.TokenSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsSlackSpec.
+func (in *ArgoCDNotificationsSlackSpec) DeepCopy() *ArgoCDNotificationsSlackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsSlackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsWebhookSpec) DeepCopyInto(out *ArgoCDNotificationsWebhookSpec) {
+	*out = *in
+	in.URLSecretRef.DeepCopyInto(&out.URLSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsWebhookSpec.
+func (in *ArgoCDNotificationsWebhookSpec) DeepCopy() *ArgoCDNotificationsWebhookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsWebhookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDProjectDestination) DeepCopyInto(out *ArgoCDProjectDestination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDProjectDestination.
+func (in *ArgoCDProjectDestination) DeepCopy() *ArgoCDProjectDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDProjectDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDProjectRole) DeepCopyInto(out *ArgoCDProjectRole) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDProjectRole.
+func (in *ArgoCDProjectRole) DeepCopy() *ArgoCDProjectRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDProjectRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDProjectSpec) DeepCopyInto(out *ArgoCDProjectSpec) {
+	*out = *in
+	if in.SourceRepos != nil {
+		in, out := &in.SourceRepos, &out.SourceRepos
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]ArgoCDProjectDestination, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterResourceWhitelist != nil {
+		in, out := &in.ClusterResourceWhitelist, &out.ClusterResourceWhitelist
+		*out = make([]metav1.GroupKind, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]ArgoCDProjectRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDProjectSpec.
+func (in *ArgoCDProjectSpec) DeepCopy() *ArgoCDProjectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDProjectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDPrometheusSpec) DeepCopyInto(out *ArgoCDPrometheusSpec) {
+	*out = *in
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	in.Route.DeepCopyInto(&out.Route)
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(v1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalLabels != nil {
+		in, out := &in.ExternalLabels, &out.ExternalLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDPrometheusSpec.
+func (in *ArgoCDPrometheusSpec) DeepCopy() *ArgoCDPrometheusSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDPrometheusSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACSpec) DeepCopyInto(out *ArgoCDRBACSpec) {
+	*out = *in
+	if in.DefaultPolicy != nil {
+		in, out := &in.DefaultPolicy, &out.DefaultPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(string)
+		**out = **in
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = new(string)
+		**out = **in
+	}
+	if in.PolicyMatcherMode != nil {
+		in, out := &in.PolicyMatcherMode, &out.PolicyMatcherMode
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACSpec.
+func (in *ArgoCDRBACSpec) DeepCopy() *ArgoCDRBACSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisPersistenceSpec) DeepCopyInto(out *ArgoCDRedisPersistenceSpec) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisPersistenceSpec.
+func (in *ArgoCDRedisPersistenceSpec) DeepCopy() *ArgoCDRedisPersistenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisPersistenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisRemoteSpec) DeepCopyInto(out *ArgoCDRedisRemoteSpec) {
+	*out = *in
+	if in.Sentinels != nil {
+		in, out := &in.Sentinels, &out.Sentinels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisRemoteSpec.
+func (in *ArgoCDRedisRemoteSpec) DeepCopy() *ArgoCDRedisRemoteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisRemoteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisSpec) DeepCopyInto(out *ArgoCDRedisSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(ArgoCDRedisPersistenceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(ArgoCDRedisRemoteSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Service.DeepCopyInto(&out.Service)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisSpec.
+func (in *ArgoCDRedisSpec) DeepCopy() *ArgoCDRedisSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepoServerCmdParamsSpec) DeepCopyInto(out *ArgoCDRepoServerCmdParamsSpec) {
+	*out = *in
+	if in.ParallelismLimit != nil {
+		in, out := &in.ParallelismLimit, &out.ParallelismLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoServerCmdParamsSpec.
+func (in *ArgoCDRepoServerCmdParamsSpec) DeepCopy() *ArgoCDRepoServerCmdParamsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoServerCmdParamsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -657,6 +1619,11 @@ func (in *ArgoCDRedisSpec) DeepCopy() *ArgoCDRedisSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
 	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ExtraRepoCommandArgs != nil {
 		in, out := &in.ExtraRepoCommandArgs, &out.ExtraRepoCommandArgs
 		*out = make([]string, len(*in))
@@ -672,6 +1639,13 @@ func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ServiceAccountAnnotations != nil {
+		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ExecTimeout != nil {
 		in, out := &in.ExecTimeout, &out.ExecTimeout
 		*out = new(int)
@@ -712,108 +1686,387 @@ func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Service.DeepCopyInto(&out.Service)
+	in.ServiceMonitor.DeepCopyInto(&out.ServiceMonitor)
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]v1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoSpec.
+func (in *ArgoCDRepoSpec) DeepCopy() *ArgoCDRepoSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepositoryCredentialsSpec) DeepCopyInto(out *ArgoCDRepositoryCredentialsSpec) {
+	*out = *in
+	if in.GitHubApp != nil {
+		in, out := &in.GitHubApp, &out.GitHubApp
+		*out = new(ArgoCDGitHubAppCredentialsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GoogleCloudSource != nil {
+		in, out := &in.GoogleCloudSource, &out.GoogleCloudSource
+		*out = new(ArgoCDGoogleCloudSourceCredentialsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepositoryCredentialsSpec.
+func (in *ArgoCDRepositoryCredentialsSpec) DeepCopy() *ArgoCDRepositoryCredentialsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepositoryCredentialsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepositorySpec) DeepCopyInto(out *ArgoCDRepositorySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepositorySpec.
+func (in *ArgoCDRepositorySpec) DeepCopy() *ArgoCDRepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDResourceCleanupSpec) DeepCopyInto(out *ArgoCDResourceCleanupSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDResourceCleanupSpec.
+func (in *ArgoCDResourceCleanupSpec) DeepCopy() *ArgoCDResourceCleanupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDResourceCleanupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRouteSpec) DeepCopyInto(out *ArgoCDRouteSpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(routev1.TLSConfig)
+		**out = **in
+	}
+	if in.WildcardPolicy != nil {
+		in, out := &in.WildcardPolicy, &out.WildcardPolicy
+		*out = new(routev1.WildcardPolicyType)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRouteSpec.
+func (in *ArgoCDRouteSpec) DeepCopy() *ArgoCDRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSSOSpec) DeepCopyInto(out *ArgoCDSSOSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VerifyTLS != nil {
+		in, out := &in.VerifyTLS, &out.VerifyTLS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Dex != nil {
+		in, out := &in.Dex, &out.Dex
+		*out = new(ArgoCDDexSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Keycloak != nil {
+		in, out := &in.Keycloak, &out.Keycloak
+		*out = new(ArgoCDKeycloakSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSSOSpec.
+func (in *ArgoCDSSOSpec) DeepCopy() *ArgoCDSSOSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSSOSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSSOThemeSpec) DeepCopyInto(out *ArgoCDSSOThemeSpec) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1.KeyToPath, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSSOThemeSpec.
+func (in *ArgoCDSSOThemeSpec) DeepCopy() *ArgoCDSSOThemeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSSOThemeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSecretKeyRef) DeepCopyInto(out *ArgoCDSecretKeyRef) {
+	*out = *in
+	in.SecretRef.DeepCopyInto(&out.SecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSecretKeyRef.
+func (in *ArgoCDSecretKeyRef) DeepCopy() *ArgoCDSecretKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSecretKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerAutoscaleSpec) DeepCopyInto(out *ArgoCDServerAutoscaleSpec) {
+	*out = *in
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(autoscalingv1.HorizontalPodAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerAutoscaleSpec.
+func (in *ArgoCDServerAutoscaleSpec) DeepCopy() *ArgoCDServerAutoscaleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerAutoscaleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerCmdParamsSpec) DeepCopyInto(out *ArgoCDServerCmdParamsSpec) {
+	*out = *in
+	if in.EnableGZip != nil {
+		in, out := &in.EnableGZip, &out.EnableGZip
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Insecure != nil {
+		in, out := &in.Insecure, &out.Insecure
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerCmdParamsSpec.
+func (in *ArgoCDServerCmdParamsSpec) DeepCopy() *ArgoCDServerCmdParamsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerCmdParamsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerCustomStylesSpec) DeepCopyInto(out *ArgoCDServerCustomStylesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerCustomStylesSpec.
+func (in *ArgoCDServerCustomStylesSpec) DeepCopy() *ArgoCDServerCustomStylesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerCustomStylesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerExtensionBackendSpec) DeepCopyInto(out *ArgoCDServerExtensionBackendSpec) {
+	*out = *in
+	if in.ConnectionTimeout != nil {
+		in, out := &in.ConnectionTimeout, &out.ConnectionTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.KeepAlive != nil {
+		in, out := &in.KeepAlive, &out.KeepAlive
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.IdleConnectionTimeout != nil {
+		in, out := &in.IdleConnectionTimeout, &out.IdleConnectionTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxIdleConnections != nil {
+		in, out := &in.MaxIdleConnections, &out.MaxIdleConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]ArgoCDServerExtensionServiceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerExtensionBackendSpec.
+func (in *ArgoCDServerExtensionBackendSpec) DeepCopy() *ArgoCDServerExtensionBackendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerExtensionBackendSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoSpec.
-func (in *ArgoCDRepoSpec) DeepCopy() *ArgoCDRepoSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerExtensionClusterSpec) DeepCopyInto(out *ArgoCDServerExtensionClusterSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerExtensionClusterSpec.
+func (in *ArgoCDServerExtensionClusterSpec) DeepCopy() *ArgoCDServerExtensionClusterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDRepoSpec)
+	out := new(ArgoCDServerExtensionClusterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDRouteSpec) DeepCopyInto(out *ArgoCDRouteSpec) {
+func (in *ArgoCDServerExtensionHeaderSpec) DeepCopyInto(out *ArgoCDServerExtensionHeaderSpec) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(routev1.TLSConfig)
-		**out = **in
-	}
-	if in.WildcardPolicy != nil {
-		in, out := &in.WildcardPolicy, &out.WildcardPolicy
-		*out = new(routev1.WildcardPolicyType)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRouteSpec.
-func (in *ArgoCDRouteSpec) DeepCopy() *ArgoCDRouteSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerExtensionHeaderSpec.
+func (in *ArgoCDServerExtensionHeaderSpec) DeepCopy() *ArgoCDServerExtensionHeaderSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDRouteSpec)
+	out := new(ArgoCDServerExtensionHeaderSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDSSOSpec) DeepCopyInto(out *ArgoCDSSOSpec) {
+func (in *ArgoCDServerExtensionServiceSpec) DeepCopyInto(out *ArgoCDServerExtensionServiceSpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]ArgoCDServerExtensionHeaderSpec, len(*in))
+		copy(*out, *in)
 	}
-	if in.VerifyTLS != nil {
-		in, out := &in.VerifyTLS, &out.VerifyTLS
-		*out = new(bool)
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(ArgoCDServerExtensionClusterSpec)
 		**out = **in
 	}
-	if in.Dex != nil {
-		in, out := &in.Dex, &out.Dex
-		*out = new(ArgoCDDexSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Keycloak != nil {
-		in, out := &in.Keycloak, &out.Keycloak
-		*out = new(ArgoCDKeycloakSpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSSOSpec.
-func (in *ArgoCDSSOSpec) DeepCopy() *ArgoCDSSOSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerExtensionServiceSpec.
+func (in *ArgoCDServerExtensionServiceSpec) DeepCopy() *ArgoCDServerExtensionServiceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDSSOSpec)
+	out := new(ArgoCDServerExtensionServiceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDServerAutoscaleSpec) DeepCopyInto(out *ArgoCDServerAutoscaleSpec) {
+func (in *ArgoCDServerExtensionSpec) DeepCopyInto(out *ArgoCDServerExtensionSpec) {
 	*out = *in
-	if in.HPA != nil {
-		in, out := &in.HPA, &out.HPA
-		*out = new(autoscalingv1.HorizontalPodAutoscalerSpec)
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = new(ArgoCDServerExtensionBackendSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerAutoscaleSpec.
-func (in *ArgoCDServerAutoscaleSpec) DeepCopy() *ArgoCDServerAutoscaleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerExtensionSpec.
+func (in *ArgoCDServerExtensionSpec) DeepCopy() *ArgoCDServerExtensionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDServerAutoscaleSpec)
+	out := new(ArgoCDServerExtensionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -834,9 +2087,35 @@ func (in *ArgoCDServerGRPCSpec) DeepCopy() *ArgoCDServerGRPCSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerIstioSpec) DeepCopyInto(out *ArgoCDServerIstioSpec) {
+	*out = *in
+	if in.Gateways != nil {
+		in, out := &in.Gateways, &out.Gateways
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerIstioSpec.
+func (in *ArgoCDServerIstioSpec) DeepCopy() *ArgoCDServerIstioSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerIstioSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDServerServiceSpec) DeepCopyInto(out *ArgoCDServerServiceSpec) {
 	*out = *in
+	in.ArgoCDServiceSpec.DeepCopyInto(&out.ArgoCDServiceSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerServiceSpec.
@@ -852,9 +2131,39 @@ func (in *ArgoCDServerServiceSpec) DeepCopy() *ArgoCDServerServiceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDServerSpec) DeepCopyInto(out *ArgoCDServerSpec) {
 	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
 	in.Autoscale.DeepCopyInto(&out.Autoscale)
+	if in.CustomStyles != nil {
+		in, out := &in.CustomStyles, &out.CustomStyles
+		*out = new(ArgoCDServerCustomStylesSpec)
+		**out = **in
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]ArgoCDServerExtensionSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.GRPC.DeepCopyInto(&out.GRPC)
 	in.Ingress.DeepCopyInto(&out.Ingress)
+	in.Istio.DeepCopyInto(&out.Istio)
+	if in.ClusterRole != nil {
+		in, out := &in.ClusterRole, &out.ClusterRole
+		*out = new(ArgoCDClusterRoleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountAnnotations != nil {
+		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(int32)
@@ -866,7 +2175,7 @@ func (in *ArgoCDServerSpec) DeepCopyInto(out *ArgoCDServerSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Route.DeepCopyInto(&out.Route)
-	out.Service = in.Service
+	in.Service.DeepCopyInto(&out.Service)
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]v1.EnvVar, len(*in))
@@ -879,6 +2188,7 @@ func (in *ArgoCDServerSpec) DeepCopyInto(out *ArgoCDServerSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.ServiceMonitor.DeepCopyInto(&out.ServiceMonitor)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerSpec.
@@ -891,20 +2201,122 @@ func (in *ArgoCDServerSpec) DeepCopy() *ArgoCDServerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServiceMonitorSpec) DeepCopyInto(out *ArgoCDServiceMonitorSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Relabelings != nil {
+		in, out := &in.Relabelings, &out.Relabelings
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.MetricRelabelings != nil {
+		in, out := &in.MetricRelabelings, &out.MetricRelabelings
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(monitoringv1.TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServiceMonitorSpec.
+func (in *ArgoCDServiceMonitorSpec) DeepCopy() *ArgoCDServiceMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServiceMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServiceSpec) DeepCopyInto(out *ArgoCDServiceSpec) {
+	*out = *in
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]v1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(v1.IPFamilyPolicyType)
+		**out = **in
+	}
+	if in.InternalTrafficPolicy != nil {
+		in, out := &in.InternalTrafficPolicy, &out.InternalTrafficPolicy
+		*out = new(v1.ServiceInternalTrafficPolicyType)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServiceSpec.
+func (in *ArgoCDServiceSpec) DeepCopy() *ArgoCDServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 	*out = *in
+	if in.ArgoCDAgent != nil {
+		in, out := &in.ArgoCDAgent, &out.ArgoCDAgent
+		*out = new(ArgoCDAgentSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ApplicationSet != nil {
 		in, out := &in.ApplicationSet, &out.ApplicationSet
 		*out = new(ArgoCDApplicationSet)
 		(*in).DeepCopyInto(*out)
 	}
+	in.CmdParams.DeepCopyInto(&out.CmdParams)
 	in.Controller.DeepCopyInto(&out.Controller)
 	if in.Dex != nil {
 		in, out := &in.Dex, &out.Dex
 		*out = new(ArgoCDDexSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdminPasswordSecretRef != nil {
+		in, out := &in.AdminPasswordSecretRef, &out.AdminPasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRefs != nil {
+		in, out := &in.SecretKeyRefs, &out.SecretKeyRefs
+		*out = make([]ArgoCDSecretKeyRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdminPasswordRotation != nil {
+		in, out := &in.AdminPasswordRotation, &out.AdminPasswordRotation
+		*out = new(ArgoCDAdminPasswordRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExtraConfig != nil {
 		in, out := &in.ExtraConfig, &out.ExtraConfig
 		*out = make(map[string]string, len(*in))
@@ -912,6 +2324,11 @@ func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ExecProviderTools != nil {
+		in, out := &in.ExecProviderTools, &out.ExecProviderTools
+		*out = make([]ArgoCDExecProviderToolSpec, len(*in))
+		copy(*out, *in)
+	}
 	in.Grafana.DeepCopyInto(&out.Grafana)
 	in.HA.DeepCopyInto(&out.HA)
 	if in.Import != nil {
@@ -919,13 +2336,44 @@ func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 		*out = new(ArgoCDImportSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.InitialClusters != nil {
+		in, out := &in.InitialClusters, &out.InitialClusters
+		*out = make([]ArgoCDClusterSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitialProjects != nil {
+		in, out := &in.InitialProjects, &out.InitialProjects
+		*out = make([]ArgoCDProjectSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitialRepositorySecrets != nil {
+		in, out := &in.InitialRepositorySecrets, &out.InitialRepositorySecrets
+		*out = make([]ArgoCDRepositorySpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.InitialRepositoryCredentials != nil {
+		in, out := &in.InitialRepositoryCredentials, &out.InitialRepositoryCredentials
+		*out = make([]ArgoCDRepositoryCredentialsSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.InitialSSHKnownHosts = in.InitialSSHKnownHosts
 	if in.KustomizeVersions != nil {
 		in, out := &in.KustomizeVersions, &out.KustomizeVersions
 		*out = make([]KustomizeVersionSpec, len(*in))
 		copy(*out, *in)
 	}
-	out.Monitoring = in.Monitoring
+	if in.ManagedNamespaces != nil {
+		in, out := &in.ManagedNamespaces, &out.ManagedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Monitoring.DeepCopyInto(&out.Monitoring)
 	if in.NodePlacement != nil {
 		in, out := &in.NodePlacement, &out.NodePlacement
 		*out = new(ArgoCDNodePlacementSpec)
@@ -951,6 +2399,20 @@ func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 		*out = make([]ResourceAction, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExcludedResources != nil {
+		in, out := &in.ExcludedResources, &out.ExcludedResources
+		*out = make([]ResourceFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IncludedResources != nil {
+		in, out := &in.IncludedResources, &out.IncludedResources
+		*out = make([]ResourceFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.Server.DeepCopyInto(&out.Server)
 	if in.SourceNamespaces != nil {
 		in, out := &in.SourceNamespaces, &out.SourceNamespaces
@@ -963,6 +2425,16 @@ func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.TLS.DeepCopyInto(&out.TLS)
+	if in.UnmanagedConfigKeys != nil {
+		in, out := &in.UnmanagedConfigKeys, &out.UnmanagedConfigKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = new(ArgoCDWorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Banner != nil {
 		in, out := &in.Banner, &out.Banner
 		*out = new(Banner)
@@ -983,6 +2455,40 @@ func (in *ArgoCDSpec) DeepCopy() *ArgoCDSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDStatus) DeepCopyInto(out *ArgoCDStatus) {
 	*out = *in
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]ArgoCDCertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManagedNamespaces != nil {
+		in, out := &in.ManagedNamespaces, &out.ManagedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RejectedNamespaces != nil {
+		in, out := &in.RejectedNamespaces, &out.RejectedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OrphanedResources != nil {
+		in, out := &in.OrphanedResources, &out.OrphanedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(ArgoCDUpgradeStatus)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDStatus.
@@ -1006,6 +2512,16 @@ func (in *ArgoCDTLSSpec) DeepCopyInto(out *ArgoCDTLSSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(ArgoCDCertManagerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTLSSpec.
@@ -1018,6 +2534,46 @@ func (in *ArgoCDTLSSpec) DeepCopy() *ArgoCDTLSSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDUpgradeStatus) DeepCopyInto(out *ArgoCDUpgradeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDUpgradeStatus.
+func (in *ArgoCDUpgradeStatus) DeepCopy() *ArgoCDUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDWorkloadSpec) DeepCopyInto(out *ArgoCDWorkloadSpec) {
+	*out = *in
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDWorkloadSpec.
+func (in *ArgoCDWorkloadSpec) DeepCopy() *ArgoCDWorkloadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDWorkloadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Banner) DeepCopyInto(out *Banner) {
 	*out = *in
@@ -1093,6 +2649,36 @@ func (in *ResourceAction) DeepCopy() *ResourceAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceFilter) DeepCopyInto(out *ResourceFilter) {
+	*out = *in
+	if in.APIGroups != nil {
+		in, out := &in.APIGroups, &out.APIGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFilter.
+func (in *ResourceFilter) DeepCopy() *ResourceFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceHealthCheck) DeepCopyInto(out *ResourceHealthCheck) {
 	*out = *in
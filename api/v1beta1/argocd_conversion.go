@@ -0,0 +1,432 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// conversionData holds the deprecated settings of a v1alpha1 ArgoCD that cannot be expressed in v1beta1. They are
+// kept in the conversion data annotation of the v1beta1 ArgoCD, so that converting it back to v1alpha1 restores them.
+type conversionData struct {
+	Dex                    *v1alpha1.ArgoCDDexSpec     `json:"dex,omitempty"`
+	Grafana                *v1alpha1.ArgoCDGrafanaSpec `json:"grafana,omitempty"`
+	ResourceCustomizations string                      `json:"resourceCustomizations,omitempty"`
+
+	// MovedDex is set when the deprecated `.spec.dex` was moved to `.spec.sso.dex`, so that it is moved back.
+	MovedDex *movedDex `json:"movedDex,omitempty"`
+	// MovedResourceCustomizations are the deprecated resource customizations whose entries were moved to the resource
+	// health checks, actions and ignore differences, so that they are moved back.
+	MovedResourceCustomizations string `json:"movedResourceCustomizations,omitempty"`
+}
+
+// movedDex records the SSO configuration of a v1alpha1 ArgoCD before its deprecated `.spec.dex` was moved to
+// `.spec.sso.dex`.
+type movedDex struct {
+	// SSO is true if `.spec.sso` was set.
+	SSO bool `json:"sso,omitempty"`
+	// Provider is the SSO provider that was set.
+	Provider v1alpha1.SSOProviderType `json:"provider,omitempty"`
+}
+
+// legacyResourceCustomization is an entry of the deprecated resourceCustomizations of v1alpha1.
+type legacyResourceCustomization struct {
+	HealthLua         string `json:"health.lua,omitempty"`
+	Actions           string `json:"actions,omitempty"`
+	IgnoreDifferences string `json:"ignoreDifferences,omitempty"`
+}
+
+// ConvertTo converts this ArgoCD to the hub version, v1alpha1.
+func (src *ArgoCD) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ArgoCD)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Status = *src.Status.DeepCopy()
+
+	spec := src.Spec.DeepCopy()
+	dst.Spec = v1alpha1.ArgoCDSpec{
+		ArgoCDAgent:                  spec.ArgoCDAgent,
+		ApplicationSet:               spec.ApplicationSet,
+		ApplicationInstanceLabelKey:  spec.ApplicationInstanceLabelKey,
+		CmdParams:                    spec.CmdParams,
+		ConfigManagementPlugins:      spec.ConfigManagementPlugins,
+		Controller:                   spec.Controller,
+		DeletionProtection:           spec.DeletionProtection,
+		DisableAdmin:                 spec.DisableAdmin,
+		AdminPasswordSecretRef:       spec.AdminPasswordSecretRef,
+		SecretKeyRefs:                spec.SecretKeyRefs,
+		AdminPasswordRotation:        spec.AdminPasswordRotation,
+		ExtraConfig:                  spec.ExtraConfig,
+		ExecProviderTools:            spec.ExecProviderTools,
+		GATrackingID:                 spec.GATrackingID,
+		GAAnonymizeUsers:             spec.GAAnonymizeUsers,
+		Grafana:                      v1alpha1.ArgoCDGrafanaSpec{DashboardConfigMaps: spec.Monitoring.GrafanaDashboards},
+		HA:                           spec.HA,
+		HelpChatURL:                  spec.HelpChatURL,
+		HelpChatText:                 spec.HelpChatText,
+		Image:                        spec.Image,
+		Import:                       spec.Import,
+		InitialClusters:              spec.InitialClusters,
+		InitialProjects:              spec.InitialProjects,
+		InitialRepositories:          spec.InitialRepositories,
+		InitialRepositorySecrets:     spec.InitialRepositorySecrets,
+		InitialRepositoryCredentials: spec.InitialRepositoryCredentials,
+		InitialSSHKnownHosts:         spec.InitialSSHKnownHosts,
+		KustomizeBuildOptions:        spec.KustomizeBuildOptions,
+		KustomizeVersions:            spec.KustomizeVersions,
+		ManagedNamespaces:            spec.ManagedNamespaces,
+		OIDCConfig:                   spec.OIDCConfig,
+		Monitoring:                   v1alpha1.ArgoCDMonitoringSpec{Enabled: spec.Monitoring.Enabled, ExternalGrafana: spec.Monitoring.ExternalGrafana},
+		NodePlacement:                spec.NodePlacement,
+		Notifications:                spec.Notifications,
+		Prometheus:                   spec.Prometheus,
+		RBAC:                         spec.RBAC,
+		Redis:                        spec.Redis,
+		Repo:                         spec.Repo,
+		RepositoryCredentials:        spec.RepositoryCredentials,
+		ResourceHealthChecks:         spec.ResourceHealthChecks,
+		ResourceIgnoreDifferences:    spec.ResourceIgnoreDifferences,
+		ResourceActions:              spec.ResourceActions,
+		ResourceExclusions:           spec.ResourceExclusions,
+		ResourceInclusions:           spec.ResourceInclusions,
+		ExcludedResources:            spec.ExcludedResources,
+		IncludedResources:            spec.IncludedResources,
+		ResourceCleanup:              spec.ResourceCleanup,
+		ResourceTrackingMethod:       spec.ResourceTrackingMethod,
+		Server:                       spec.Server,
+		SourceNamespaces:             spec.SourceNamespaces,
+		SSO:                          spec.SSO,
+		StatusBadgeEnabled:           spec.StatusBadgeEnabled,
+		TLS:                          spec.TLS,
+		UnmanagedConfigKeys:          spec.UnmanagedConfigKeys,
+		UpgradeStrategy:              spec.UpgradeStrategy,
+		UsersAnonymousEnabled:        spec.UsersAnonymousEnabled,
+		Version:                      spec.Version,
+		Workloads:                    spec.Workloads,
+		Banner:                       spec.Banner,
+	}
+
+	value, ok := dst.Annotations[common.AnnotationConversionData]
+	if !ok {
+		return nil
+	}
+	delete(dst.Annotations, common.AnnotationConversionData)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+	data := conversionData{}
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return fmt.Errorf("failed to restore the v1alpha1 settings of ArgoCD %s/%s: %w", src.Namespace, src.Name, err)
+	}
+	dst.Spec.Dex = data.Dex
+	if data.Grafana != nil {
+		dst.Spec.Grafana = *data.Grafana
+		dst.Spec.Grafana.DashboardConfigMaps = spec.Monitoring.GrafanaDashboards
+	}
+	dst.Spec.ResourceCustomizations = data.ResourceCustomizations
+	if data.MovedDex != nil {
+		restoreDex(&dst.Spec, *data.MovedDex)
+	}
+	if data.MovedResourceCustomizations != "" {
+		restoreResourceCustomizations(&dst.Spec, data.MovedResourceCustomizations)
+	}
+	return nil
+}
+
+// ConvertFrom converts the given ArgoCD of the hub version, v1alpha1, to this version. The deprecated `.spec.dex` is
+// moved to `.spec.sso.dex`, `.spec.grafana.dashboardConfigMaps` to `.spec.monitoring.grafanaDashboards`, and the
+// entries of `.spec.resourceCustomizations` to the resource health checks, actions and ignore differences. Deprecated
+// settings that cannot be moved are kept in the conversion data annotation.
+func (dst *ArgoCD) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ArgoCD)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Status = *src.Status.DeepCopy()
+	delete(dst.Annotations, common.AnnotationConversionData)
+
+	spec := src.Spec.DeepCopy()
+	dst.Spec = ArgoCDSpec{
+		ArgoCDAgent:                  spec.ArgoCDAgent,
+		ApplicationSet:               spec.ApplicationSet,
+		ApplicationInstanceLabelKey:  spec.ApplicationInstanceLabelKey,
+		CmdParams:                    spec.CmdParams,
+		ConfigManagementPlugins:      spec.ConfigManagementPlugins,
+		Controller:                   spec.Controller,
+		DeletionProtection:           spec.DeletionProtection,
+		DisableAdmin:                 spec.DisableAdmin,
+		AdminPasswordSecretRef:       spec.AdminPasswordSecretRef,
+		SecretKeyRefs:                spec.SecretKeyRefs,
+		AdminPasswordRotation:        spec.AdminPasswordRotation,
+		ExtraConfig:                  spec.ExtraConfig,
+		ExecProviderTools:            spec.ExecProviderTools,
+		GATrackingID:                 spec.GATrackingID,
+		GAAnonymizeUsers:             spec.GAAnonymizeUsers,
+		HA:                           spec.HA,
+		HelpChatURL:                  spec.HelpChatURL,
+		HelpChatText:                 spec.HelpChatText,
+		Image:                        spec.Image,
+		Import:                       spec.Import,
+		InitialClusters:              spec.InitialClusters,
+		InitialProjects:              spec.InitialProjects,
+		InitialRepositories:          spec.InitialRepositories,
+		InitialRepositorySecrets:     spec.InitialRepositorySecrets,
+		InitialRepositoryCredentials: spec.InitialRepositoryCredentials,
+		InitialSSHKnownHosts:         spec.InitialSSHKnownHosts,
+		KustomizeBuildOptions:        spec.KustomizeBuildOptions,
+		KustomizeVersions:            spec.KustomizeVersions,
+		ManagedNamespaces:            spec.ManagedNamespaces,
+		OIDCConfig:                   spec.OIDCConfig,
+		Monitoring:                   ArgoCDMonitoringSpec{Enabled: spec.Monitoring.Enabled, GrafanaDashboards: spec.Grafana.DashboardConfigMaps, ExternalGrafana: spec.Monitoring.ExternalGrafana},
+		NodePlacement:                spec.NodePlacement,
+		Notifications:                spec.Notifications,
+		Prometheus:                   spec.Prometheus,
+		RBAC:                         spec.RBAC,
+		Redis:                        spec.Redis,
+		Repo:                         spec.Repo,
+		RepositoryCredentials:        spec.RepositoryCredentials,
+		ResourceHealthChecks:         spec.ResourceHealthChecks,
+		ResourceIgnoreDifferences:    spec.ResourceIgnoreDifferences,
+		ResourceActions:              spec.ResourceActions,
+		ResourceExclusions:           spec.ResourceExclusions,
+		ResourceInclusions:           spec.ResourceInclusions,
+		ExcludedResources:            spec.ExcludedResources,
+		IncludedResources:            spec.IncludedResources,
+		ResourceCleanup:              spec.ResourceCleanup,
+		ResourceTrackingMethod:       spec.ResourceTrackingMethod,
+		Server:                       spec.Server,
+		SourceNamespaces:             spec.SourceNamespaces,
+		SSO:                          spec.SSO,
+		StatusBadgeEnabled:           spec.StatusBadgeEnabled,
+		TLS:                          spec.TLS,
+		UnmanagedConfigKeys:          spec.UnmanagedConfigKeys,
+		UpgradeStrategy:              spec.UpgradeStrategy,
+		UsersAnonymousEnabled:        spec.UsersAnonymousEnabled,
+		Version:                      spec.Version,
+		Workloads:                    spec.Workloads,
+		Banner:                       spec.Banner,
+	}
+
+	data := conversionData{}
+	if spec.Dex != nil && !reflect.DeepEqual(spec.Dex, &v1alpha1.ArgoCDDexSpec{}) {
+		moved := &movedDex{SSO: spec.SSO != nil}
+		if spec.SSO != nil {
+			moved.Provider = spec.SSO.Provider
+		}
+		if dst.convertDex(spec.Dex) {
+			data.MovedDex = moved
+		} else {
+			data.Dex = spec.Dex
+		}
+	}
+	if grafana := spec.Grafana; !reflect.DeepEqual(grafana, v1alpha1.ArgoCDGrafanaSpec{DashboardConfigMaps: grafana.DashboardConfigMaps}) {
+		grafana.DashboardConfigMaps = nil
+		data.Grafana = &grafana
+	}
+	if spec.ResourceCustomizations != "" {
+		if dst.convertResourceCustomizations(spec.ResourceCustomizations) {
+			data.MovedResourceCustomizations = spec.ResourceCustomizations
+		} else {
+			data.ResourceCustomizations = spec.ResourceCustomizations
+		}
+	}
+
+	if reflect.DeepEqual(data, conversionData{}) {
+		return nil
+	}
+	value, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to keep the v1alpha1 settings of ArgoCD %s/%s: %w", src.Namespace, src.Name, err)
+	}
+	if dst.Annotations == nil {
+		dst.Annotations = make(map[string]string)
+	}
+	dst.Annotations[common.AnnotationConversionData] = string(value)
+	return nil
+}
+
+// convertDex moves the given deprecated Dex configuration to `.spec.sso.dex`. The returned bool is false if the
+// configuration does not enable Dex, or conflicts with the SSO configuration, and has not been moved.
+func (dst *ArgoCD) convertDex(dex *v1alpha1.ArgoCDDexSpec) bool {
+	if dex.Config == "" && !dex.OpenShiftOAuth {
+		return false
+	}
+	sso := dst.Spec.SSO
+	if sso == nil {
+		sso = &v1alpha1.ArgoCDSSOSpec{}
+	}
+	if sso.Dex != nil || (sso.Provider != "" && sso.Provider != v1alpha1.SSOProviderTypeDex) {
+		return false
+	}
+	sso.Provider = v1alpha1.SSOProviderTypeDex
+	sso.Dex = dex
+	dst.Spec.SSO = sso
+	return true
+}
+
+// restoreDex moves `.spec.sso.dex` of the given v1alpha1 spec back to the deprecated `.spec.dex` it was moved from,
+// and restores the SSO configuration recorded when it was moved. Nothing is restored once the SSO configuration no
+// longer uses Dex.
+func restoreDex(spec *v1alpha1.ArgoCDSpec, moved movedDex) {
+	sso := spec.SSO
+	if spec.Dex != nil || sso == nil || sso.Provider != v1alpha1.SSOProviderTypeDex || sso.Dex == nil {
+		return
+	}
+	spec.Dex = sso.Dex
+	sso.Dex = nil
+	sso.Provider = moved.Provider
+	if !moved.SSO && reflect.DeepEqual(sso, &v1alpha1.ArgoCDSSOSpec{}) {
+		spec.SSO = nil
+	}
+}
+
+// convertResourceCustomizations moves the entries of the given deprecated resource customizations to the resource
+// health checks, actions and ignore differences. The returned bool is false if any of the entries cannot be
+// expressed with these, or if it conflicts with one of them, in which case none of the entries are moved.
+func (dst *ArgoCD) convertResourceCustomizations(value string) bool {
+	customizations := make(map[string]legacyResourceCustomization)
+	if err := yaml.UnmarshalStrict([]byte(value), &customizations); err != nil {
+		return false
+	}
+
+	keys := make([]string, 0, len(customizations))
+	for key := range customizations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	healthChecks := dst.Spec.ResourceHealthChecks
+	actions := dst.Spec.ResourceActions
+	ignoreDifferences := dst.Spec.ResourceIgnoreDifferences.DeepCopy()
+	for _, key := range keys {
+		group, kind := "", key
+		if i := strings.Index(key, "/"); i >= 0 {
+			group, kind = key[:i], key[i+1:]
+		}
+		if kind == "" || strings.Contains(kind, "/") {
+			return false
+		}
+		customization := customizations[key]
+
+		if customization.HealthLua != "" {
+			for _, healthCheck := range healthChecks {
+				if healthCheck.Group == group && healthCheck.Kind == kind {
+					return false
+				}
+			}
+			healthChecks = append(healthChecks, v1alpha1.ResourceHealthCheck{Group: group, Kind: kind, Check: customization.HealthLua})
+		}
+		if customization.Actions != "" {
+			for _, action := range actions {
+				if action.Group == group && action.Kind == kind {
+					return false
+				}
+			}
+			actions = append(actions, v1alpha1.ResourceAction{Group: group, Kind: kind, Action: customization.Actions})
+		}
+		if customization.IgnoreDifferences != "" {
+			ignoreDifference := v1alpha1.IgnoreDifferenceCustomization{}
+			if err := yaml.UnmarshalStrict([]byte(customization.IgnoreDifferences), &ignoreDifference); err != nil {
+				return false
+			}
+			if ignoreDifferences == nil {
+				ignoreDifferences = &v1alpha1.ResourceIgnoreDifference{}
+			}
+			for _, identifier := range ignoreDifferences.ResourceIdentifiers {
+				if identifier.Group == group && identifier.Kind == kind {
+					return false
+				}
+			}
+			ignoreDifferences.ResourceIdentifiers = append(ignoreDifferences.ResourceIdentifiers,
+				v1alpha1.ResourceIdentifiers{Group: group, Kind: kind, Customization: ignoreDifference})
+		}
+	}
+
+	dst.Spec.ResourceHealthChecks = healthChecks
+	dst.Spec.ResourceActions = actions
+	dst.Spec.ResourceIgnoreDifferences = ignoreDifferences
+	return true
+}
+
+// restoreResourceCustomizations moves the entries of the given deprecated resource customizations back from the
+// resource health checks, actions and ignore differences of the given v1alpha1 spec. Nothing is restored once any of
+// the moved entries was changed.
+func restoreResourceCustomizations(spec *v1alpha1.ArgoCDSpec, value string) {
+	moved := &ArgoCD{}
+	if !moved.convertResourceCustomizations(value) {
+		return
+	}
+
+	var identifiers, movedIdentifiers []v1alpha1.ResourceIdentifiers
+	if spec.ResourceIgnoreDifferences != nil {
+		identifiers = spec.ResourceIgnoreDifferences.ResourceIdentifiers
+	}
+	if moved.Spec.ResourceIgnoreDifferences != nil {
+		movedIdentifiers = moved.Spec.ResourceIgnoreDifferences.ResourceIdentifiers
+	}
+
+	// The moved entries were appended to the existing ones.
+	healthChecks := len(spec.ResourceHealthChecks) - len(moved.Spec.ResourceHealthChecks)
+	actions := len(spec.ResourceActions) - len(moved.Spec.ResourceActions)
+	ignoreDifferences := len(identifiers) - len(movedIdentifiers)
+	if healthChecks < 0 || actions < 0 || ignoreDifferences < 0 {
+		return
+	}
+	for i, healthCheck := range moved.Spec.ResourceHealthChecks {
+		if spec.ResourceHealthChecks[healthChecks+i] != healthCheck {
+			return
+		}
+	}
+	for i, action := range moved.Spec.ResourceActions {
+		if spec.ResourceActions[actions+i] != action {
+			return
+		}
+	}
+	for i, identifier := range movedIdentifiers {
+		if !reflect.DeepEqual(identifiers[ignoreDifferences+i], identifier) {
+			return
+		}
+	}
+
+	spec.ResourceCustomizations = value
+	if healthChecks == 0 {
+		spec.ResourceHealthChecks = nil
+	} else {
+		spec.ResourceHealthChecks = spec.ResourceHealthChecks[:healthChecks]
+	}
+	if actions == 0 {
+		spec.ResourceActions = nil
+	} else {
+		spec.ResourceActions = spec.ResourceActions[:actions]
+	}
+	if len(movedIdentifiers) > 0 {
+		if ignoreDifferences == 0 && spec.ResourceIgnoreDifferences.All == nil {
+			spec.ResourceIgnoreDifferences = nil
+		} else if ignoreDifferences == 0 {
+			spec.ResourceIgnoreDifferences.ResourceIdentifiers = nil
+		} else {
+			spec.ResourceIgnoreDifferences.ResourceIdentifiers = identifiers[:ignoreDifferences]
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package v1beta1
+
+import (
+	"reflect"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestV1alpha1ArgoCD(opts ...func(*v1alpha1.ArgoCD)) *v1alpha1.ArgoCD {
+	a := &v1alpha1.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "argocd",
+			Namespace: "argocd",
+		},
+	}
+	for _, o := range opts {
+		o(a)
+	}
+	return a
+}
+
+func TestArgoCD_isConvertible(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1alpha1.AddToScheme(scheme))
+	assert.NoError(t, AddToScheme(scheme))
+
+	ok, err := conversion.IsConvertible(scheme, &ArgoCD{})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestArgoCDSpec_fields(t *testing.T) {
+	// all settings of v1alpha1 must be kept in v1beta1, except for the deprecated ones
+	deprecated := []string{"Dex", "Grafana", "ResourceCustomizations"}
+	alphaSpec := reflect.TypeOf(v1alpha1.ArgoCDSpec{})
+	betaSpec := reflect.TypeOf(ArgoCDSpec{})
+	for i := 0; i < alphaSpec.NumField(); i++ {
+		name := alphaSpec.Field(i).Name
+		_, ok := betaSpec.FieldByName(name)
+		assert.Equal(t, !ok, containsString(deprecated, name), "field %s", name)
+	}
+}
+
+func TestArgoCD_roundTrip(t *testing.T) {
+	f := fuzz.New().NilChance(0.3).NumElements(0, 2)
+	for i := 0; i < 50; i++ {
+		original := &ArgoCD{}
+		f.Fuzz(original)
+		original.TypeMeta = metav1.TypeMeta{}
+
+		hub := &v1alpha1.ArgoCD{}
+		assert.NoError(t, original.ConvertTo(hub))
+		converted := &ArgoCD{}
+		assert.NoError(t, converted.ConvertFrom(hub))
+		assert.Equal(t, original, converted)
+	}
+
+	// the deprecated settings of v1alpha1 are restored when converting back to it. These are kept as JSON, which does
+	// not tell empty collections from unset ones and keeps quantities in their canonical form.
+	f = fuzz.New().NilChance(0.3).NumElements(1, 2).Funcs(func(q *resource.Quantity, c fuzz.Continue) {
+		*q = *resource.NewQuantity(c.Int63n(1000), resource.DecimalSI)
+		_ = q.String()
+	})
+	for i := 0; i < 50; i++ {
+		original := &v1alpha1.ArgoCD{}
+		f.Fuzz(original)
+		original.TypeMeta = metav1.TypeMeta{}
+
+		spoke := &ArgoCD{}
+		assert.NoError(t, spoke.ConvertFrom(original))
+		converted := &v1alpha1.ArgoCD{}
+		assert.NoError(t, spoke.ConvertTo(converted))
+		assert.Equal(t, original, converted)
+	}
+}
+
+func TestArgoCD_ConvertFrom_dex(t *testing.T) {
+	a := makeTestV1alpha1ArgoCD(func(a *v1alpha1.ArgoCD) {
+		a.Spec.Dex = &v1alpha1.ArgoCDDexSpec{OpenShiftOAuth: true, Image: "dex"}
+	})
+
+	converted := &ArgoCD{}
+	assert.NoError(t, converted.ConvertFrom(a))
+	assert.Equal(t, &v1alpha1.ArgoCDSSOSpec{
+		Provider: v1alpha1.SSOProviderTypeDex,
+		Dex:      &v1alpha1.ArgoCDDexSpec{OpenShiftOAuth: true, Image: "dex"},
+	}, converted.Spec.SSO)
+	assert.Equal(t, `{"movedDex":{}}`, converted.Annotations[common.AnnotationConversionData])
+
+	// the Dex configuration is moved back when converting back, so that it is still enabled when DISABLE_DEX is set
+	hub := &v1alpha1.ArgoCD{}
+	assert.NoError(t, converted.ConvertTo(hub))
+	assert.Equal(t, a, hub)
+
+	// unless the SSO configuration no longer uses Dex
+	converted.Spec.SSO = &v1alpha1.ArgoCDSSOSpec{Provider: v1alpha1.SSOProviderTypeKeycloak}
+	hub = &v1alpha1.ArgoCD{}
+	assert.NoError(t, converted.ConvertTo(hub))
+	assert.Nil(t, hub.Spec.Dex)
+	assert.Equal(t, converted.Spec.SSO, hub.Spec.SSO)
+
+	// a Dex configuration that conflicts with the SSO configuration is kept as is
+	a.Spec.SSO = &v1alpha1.ArgoCDSSOSpec{Provider: v1alpha1.SSOProviderTypeKeycloak}
+	converted = &ArgoCD{}
+	assert.NoError(t, converted.ConvertFrom(a))
+	assert.Equal(t, &v1alpha1.ArgoCDSSOSpec{Provider: v1alpha1.SSOProviderTypeKeycloak}, converted.Spec.SSO)
+
+	hub = &v1alpha1.ArgoCD{}
+	assert.NoError(t, converted.ConvertTo(hub))
+	assert.Equal(t, a, hub)
+}
+
+func TestArgoCD_ConvertFrom_grafana(t *testing.T) {
+	dashboards := &v1alpha1.ArgoCDGrafanaDashboardConfigMapsSpec{Enabled: true}
+	a := makeTestV1alpha1ArgoCD(func(a *v1alpha1.ArgoCD) {
+		a.Spec.Grafana.DashboardConfigMaps = dashboards
+	})
+
+	converted := &ArgoCD{}
+	assert.NoError(t, converted.ConvertFrom(a))
+	assert.Equal(t, dashboards, converted.Spec.Monitoring.GrafanaDashboards)
+	assert.NotContains(t, converted.Annotations, common.AnnotationConversionData)
+
+	// the settings of the operator-managed Grafana are kept in the annotation
+	a.Spec.Grafana.Enabled = true
+	a.Spec.Grafana.Host = "grafana.example.com"
+	converted = &ArgoCD{}
+	assert.NoError(t, converted.ConvertFrom(a))
+	assert.Equal(t, dashboards, converted.Spec.Monitoring.GrafanaDashboards)
+	assert.Equal(t, `{"grafana":{"enabled":true,"host":"grafana.example.com","ingress":{"enabled":false},"route":{"enabled":false},"service":{}}}`,
+		converted.Annotations[common.AnnotationConversionData])
+
+	// changes to the dashboards in v1beta1 are kept when converting back
+	converted.Spec.Monitoring.GrafanaDashboards = nil
+	hub := &v1alpha1.ArgoCD{}
+	assert.NoError(t, converted.ConvertTo(hub))
+	assert.Equal(t, v1alpha1.ArgoCDGrafanaSpec{Enabled: true, Host: "grafana.example.com"}, hub.Spec.Grafana)
+	assert.Empty(t, hub.Annotations)
+}
+
+func TestArgoCD_ConvertFrom_resourceCustomizations(t *testing.T) {
+	a := makeTestV1alpha1ArgoCD(func(a *v1alpha1.ArgoCD) {
+		a.Spec.ResourceCustomizations = `
+apps/Deployment:
+  health.lua: |
+    hs = {}
+    return hs
+  actions: |
+    discovery.lua: |
+      actions = {}
+ConfigMap:
+  ignoreDifferences: |
+    jsonPointers:
+    - /data
+`
+		a.Spec.ResourceHealthChecks = []v1alpha1.ResourceHealthCheck{{Group: "apps", Kind: "StatefulSet", Check: "check"}}
+	})
+
+	converted := &ArgoCD{}
+	assert.NoError(t, converted.ConvertFrom(a))
+	assert.Equal(t, []v1alpha1.ResourceHealthCheck{
+		{Group: "apps", Kind: "StatefulSet", Check: "check"},
+		{Group: "apps", Kind: "Deployment", Check: "hs = {}\nreturn hs\n"},
+	}, converted.Spec.ResourceHealthChecks)
+	assert.Equal(t, []v1alpha1.ResourceAction{
+		{Group: "apps", Kind: "Deployment", Action: "discovery.lua: |\n  actions = {}\n"},
+	}, converted.Spec.ResourceActions)
+	assert.Equal(t, &v1alpha1.ResourceIgnoreDifference{
+		ResourceIdentifiers: []v1alpha1.ResourceIdentifiers{{
+			Kind:          "ConfigMap",
+			Customization: v1alpha1.IgnoreDifferenceCustomization{JsonPointers: []string{"/data"}},
+		}},
+	}, converted.Spec.ResourceIgnoreDifferences)
+	assert.Contains(t, converted.Annotations, common.AnnotationConversionData)
+
+	// the customizations are moved back when converting back
+	hub := &v1alpha1.ArgoCD{}
+	assert.NoError(t, converted.ConvertTo(hub))
+	assert.Equal(t, a, hub)
+
+	// unless the moved entries were changed
+	converted.Spec.ResourceActions[0].Action = "other"
+	hub = &v1alpha1.ArgoCD{}
+	assert.NoError(t, converted.ConvertTo(hub))
+	assert.Empty(t, hub.Spec.ResourceCustomizations)
+	assert.Equal(t, converted.Spec.ResourceActions, hub.Spec.ResourceActions)
+}
+
+func TestArgoCD_ConvertFrom_resourceCustomizationsConflict(t *testing.T) {
+	customizations := "apps/Deployment:\n  health.lua: check\n"
+	a := makeTestV1alpha1ArgoCD(func(a *v1alpha1.ArgoCD) {
+		a.Spec.ResourceCustomizations = customizations
+		a.Spec.ResourceHealthChecks = []v1alpha1.ResourceHealthCheck{{Group: "apps", Kind: "Deployment", Check: "other"}}
+	})
+
+	// customizations of a group/kind that is already customized are kept in the annotation
+	converted := &ArgoCD{}
+	assert.NoError(t, converted.ConvertFrom(a))
+	assert.Equal(t, a.Spec.ResourceHealthChecks, converted.Spec.ResourceHealthChecks)
+	assert.Equal(t, `{"resourceCustomizations":"apps/Deployment:\n  health.lua: check\n"}`,
+		converted.Annotations[common.AnnotationConversionData])
+
+	hub := &v1alpha1.ArgoCD{}
+	assert.NoError(t, converted.ConvertTo(hub))
+	assert.Equal(t, a, hub)
+
+	// so are customizations that have no structured replacement
+	a.Spec.ResourceHealthChecks = nil
+	a.Spec.ResourceCustomizations = "apps/Deployment:\n  knownTypeFields:\n  - field: spec\n"
+	converted = &ArgoCD{}
+	assert.NoError(t, converted.ConvertFrom(a))
+	assert.Empty(t, converted.Spec.ResourceHealthChecks)
+	assert.Contains(t, converted.Annotations, common.AnnotationConversionData)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func init() {
+	SchemeBuilder.Register(&ArgoCD{}, &ArgoCDList{})
+}
+
+// The v1beta1 API drops the fields of v1alpha1 that are deprecated in favor of structured replacements, and otherwise
+// shares its types with v1alpha1. v1alpha1 remains the storage version, ArgoCDs are converted by the conversion
+// webhook of the operator.
+
+//+kubebuilder:object:root=true
+
+// ArgoCD is the Schema for the argocds API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:unservedversion
+type ArgoCD struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ArgoCDSpec            `json:"spec,omitempty"`
+	Status v1alpha1.ArgoCDStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ArgoCDList contains a list of ArgoCD
+type ArgoCDList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArgoCD `json:"items"`
+}
+
+// ArgoCDMonitoringSpec is used to configure workload status monitoring for a given Argo CD instance.
+// It triggers creation of serviceMonitor and PrometheusRules that alert users when a given workload
+// status meets a certain criteria. For e.g, it can fire an alert if the application controller is
+// pending for x mins consecutively.
+type ArgoCDMonitoringSpec struct {
+	// Enabled defines whether workload status monitoring is enabled for this instance or not
+	Enabled bool `json:"enabled,omitempty"`
+
+	// GrafanaDashboards defines the options for providing the Argo CD Grafana dashboards as ConfigMaps, to be loaded
+	// by the dashboard sidecar of an existing Grafana. Replaces `.spec.grafana.dashboardConfigMaps` of v1alpha1.
+	GrafanaDashboards *v1alpha1.ArgoCDGrafanaDashboardConfigMapsSpec `json:"grafanaDashboards,omitempty"`
+
+	// ExternalGrafana defines an existing Grafana instance the Argo CD Grafana dashboards are published to, replacing
+	// the operator-managed Grafana.
+	ExternalGrafana *v1alpha1.ArgoCDExternalGrafanaSpec `json:"externalGrafana,omitempty"`
+}
+
+// ArgoCDSpec defines the desired state of ArgoCD
+// +k8s:openapi-gen=true
+type ArgoCDSpec struct {
+
+	// ArgoCDAgent defines the argocd-agent components, used to manage Applications on workload clusters from a control
+	// plane cluster.
+	ArgoCDAgent *v1alpha1.ArgoCDAgentSpec `json:"argoCDAgent,omitempty"`
+
+	// ArgoCDApplicationSet defines whether the Argo CD ApplicationSet controller should be installed.
+	ApplicationSet *v1alpha1.ArgoCDApplicationSet `json:"applicationSet,omitempty"`
+
+	// ApplicationInstanceLabelKey is the key name where Argo CD injects the app name as a tracking label.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Application Instance Label Key'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	ApplicationInstanceLabelKey string `json:"applicationInstanceLabelKey,omitempty"`
+
+	// CmdParams defines the settings the operator manages in the argocd-cmd-params-cm ConfigMap.
+	CmdParams v1alpha1.ArgoCDCmdParamsSpec `json:"cmdParams,omitempty"`
+
+	// ConfigManagementPlugins is used to specify additional config management plugins.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Config Management Plugins'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	ConfigManagementPlugins string `json:"configManagementPlugins,omitempty"`
+
+	// Controller defines the Application Controller options for ArgoCD.
+	Controller v1alpha1.ArgoCDApplicationControllerSpec `json:"controller,omitempty"`
+
+	// DeletionProtection keeps the Secrets holding cluster and repository credentials, the argocd-secret Secret, and
+	// the PersistentVolumeClaims of the exports of the ArgoCD when the ArgoCD is deleted. Disable it before deleting the
+	// ArgoCD to delete them along with it.
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+
+	// DisableAdmin will disable the admin user.
+	DisableAdmin bool `json:"disableAdmin,omitempty"`
+
+	// AdminPasswordSecretRef selects the key of an existing Secret in the namespace of the ArgoCD that holds the
+	// password of the admin user, e.g. a Secret synced by external-secrets. The password is generated when not set.
+	AdminPasswordSecretRef *corev1.SecretKeySelector `json:"adminPasswordSecretRef,omitempty"`
+
+	// SecretKeyRefs defines keys of the argocd-secret Secret whose values are sourced from other Secrets, e.g. Secrets
+	// synced from a vault by external-secrets. The keys can be referenced as $<key> from the Argo CD configuration,
+	// such as the Dex or OIDC configuration.
+	SecretKeyRefs []v1alpha1.ArgoCDSecretKeyRef `json:"secretKeyRefs,omitempty"`
+
+	// AdminPasswordRotation defines the policy for regenerating the admin password. Ignored when
+	// AdminPasswordSecretRef is set.
+	AdminPasswordRotation *v1alpha1.ArgoCDAdminPasswordRotationSpec `json:"adminPasswordRotation,omitempty"`
+
+	// ExtraConfig can be used to add fields to Argo CD configmap that are not supported by Argo CD CRD.
+	//
+	// Note: ExtraConfig takes precedence over Argo CD CRD.
+	// For example, A user sets `argocd.Spec.DisableAdmin` = true and also
+	// `a.Spec.ExtraConfig["admin.enabled"]` = true. In this case, operator updates
+	// Argo CD Configmap as follows -> argocd-cm.Data["admin.enabled"] = true.
+	// Such conflicts are reported through the ExtraConfigConflict status condition.
+	ExtraConfig map[string]string `json:"extraConfig,omitempty"`
+
+	// ExecProviderTools are the exec plugins copied from container images into the application controller and the
+	// server, for clusters authenticating through an exec provider other than argocd-k8s-auth.
+	ExecProviderTools []v1alpha1.ArgoCDExecProviderToolSpec `json:"execProviderTools,omitempty"`
+
+	// GATrackingID is the google analytics tracking ID to use.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Google Analytics Tracking ID'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	GATrackingID string `json:"gaTrackingID,omitempty"`
+
+	// GAAnonymizeUsers toggles user IDs being hashed before sending to google analytics.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Google Analytics Anonymize Users'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	GAAnonymizeUsers bool `json:"gaAnonymizeUsers,omitempty"`
+
+	// HA options for High Availability support for the Redis component.
+	HA v1alpha1.ArgoCDHASpec `json:"ha,omitempty"`
+
+	// HelpChatURL is the URL for getting chat help, this will typically be your Slack channel for support.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Help Chat URL'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	HelpChatURL string `json:"helpChatURL,omitempty"`
+
+	// HelpChatText is the text for getting chat help, defaults to "Chat now!"
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Help Chat Text'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	HelpChatText string `json:"helpChatText,omitempty"`
+
+	// Image is the ArgoCD container image for all ArgoCD components.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:ArgoCD","urn:alm:descriptor:com.tectonic.ui:text"}
+	Image string `json:"image,omitempty"`
+
+	// Import is the import/restore options for ArgoCD.
+	Import *v1alpha1.ArgoCDImportSpec `json:"import,omitempty"`
+
+	// InitialClusters defines the clusters the operator registers with Argo CD by creating cluster secrets.
+	InitialClusters []v1alpha1.ArgoCDClusterSpec `json:"initialClusters,omitempty"`
+
+	// InitialProjects defines the AppProjects the operator creates and maintains alongside the Argo CD instance.
+	InitialProjects []v1alpha1.ArgoCDProjectSpec `json:"initialProjects,omitempty"`
+
+	// InitialRepositories to configure Argo CD with upon creation of the cluster.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Initial Repositories'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	InitialRepositories string `json:"initialRepositories,omitempty"`
+
+	// InitialRepositorySecrets defines the repositories the operator registers with Argo CD by creating repository secrets.
+	InitialRepositorySecrets []v1alpha1.ArgoCDRepositorySpec `json:"initialRepositorySecrets,omitempty"`
+
+	// InitialRepositoryCredentials defines the credential templates the operator registers with Argo CD by creating
+	// repository credential secrets.
+	InitialRepositoryCredentials []v1alpha1.ArgoCDRepositoryCredentialsSpec `json:"initialRepositoryCredentials,omitempty"`
+
+	// InitialSSHKnownHosts defines the SSH known hosts data upon creation of the cluster for connecting Git repositories via SSH.
+	InitialSSHKnownHosts v1alpha1.SSHHostsSpec `json:"initialSSHKnownHosts,omitempty"`
+
+	// KustomizeBuildOptions is used to specify build options/parameters to use with `kustomize build`.
+	KustomizeBuildOptions string `json:"kustomizeBuildOptions,omitempty"`
+
+	// KustomizeVersions is a listing of configured versions of Kustomize to be made available within ArgoCD.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kustomize Build Options'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	KustomizeVersions []v1alpha1.KustomizeVersionSpec `json:"kustomizeVersions,omitempty"`
+
+	// ManagedNamespaces defines the namespaces managed by the Argo CD instance, in addition to the namespaces labelled
+	// with argocd.argoproj.io/managed-by. The operator labels these namespaces and reconciles the required Roles and
+	// RoleBindings, and removes them again when a namespace is removed from the list.
+	ManagedNamespaces []string `json:"managedNamespaces,omitempty"`
+
+	// OIDCConfig is the OIDC configuration as an alternative to dex.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OIDC Config'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	OIDCConfig string `json:"oidcConfig,omitempty"`
+
+	// Monitoring defines whether workload status monitoring configuration for this instance.
+	Monitoring ArgoCDMonitoringSpec `json:"monitoring,omitempty"`
+
+	// NodePlacement defines NodeSelectors and Taints for Argo CD workloads
+	NodePlacement *v1alpha1.ArgoCDNodePlacementSpec `json:"nodePlacement,omitempty"`
+
+	// Notifications defines whether the Argo CD Notifications controller should be installed.
+	Notifications v1alpha1.ArgoCDNotifications `json:"notifications,omitempty"`
+
+	// Prometheus defines the Prometheus server options for ArgoCD.
+	Prometheus v1alpha1.ArgoCDPrometheusSpec `json:"prometheus,omitempty"`
+
+	// RBAC defines the RBAC configuration for Argo CD.
+	RBAC v1alpha1.ArgoCDRBACSpec `json:"rbac,omitempty"`
+
+	// Redis defines the Redis server options for ArgoCD.
+	Redis v1alpha1.ArgoCDRedisSpec `json:"redis,omitempty"`
+
+	// Repo defines the repo server options for Argo CD.
+	Repo v1alpha1.ArgoCDRepoSpec `json:"repo,omitempty"`
+
+	// RepositoryCredentials are the Git pull credentials to configure Argo CD with upon creation of the cluster.
+	RepositoryCredentials string `json:"repositoryCredentials,omitempty"`
+
+	// ResourceHealthChecks customizes resource health check behavior.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Health Check Customizations'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	ResourceHealthChecks []v1alpha1.ResourceHealthCheck `json:"resourceHealthChecks,omitempty"`
+
+	// ResourceIgnoreDifferences customizes resource ignore difference behavior.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Ignore Difference Customizations'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	ResourceIgnoreDifferences *v1alpha1.ResourceIgnoreDifference `json:"resourceIgnoreDifferences,omitempty"`
+
+	// ResourceActions customizes resource action behavior.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Action Customizations'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	ResourceActions []v1alpha1.ResourceAction `json:"resourceActions,omitempty"`
+
+	// ResourceCleanup defines the cleanup of the resources created by the operator for the ArgoCD that are no longer
+	// needed by its spec, e.g. an Ingress left behind after it was disabled.
+	ResourceCleanup v1alpha1.ArgoCDResourceCleanupSpec `json:"resourceCleanup,omitempty"`
+
+	// ResourceExclusions is used to completely ignore entire classes of resource group/kinds. Please note that this is
+	// being deprecated in favor of ExcludedResources.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Exclusions'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	ResourceExclusions string `json:"resourceExclusions,omitempty"`
+
+	// ResourceInclusions is used to only include specific group/kinds in the
+	// reconciliation process. Please note that this is being deprecated in favor of IncludedResources.
+	ResourceInclusions string `json:"resourceInclusions,omitempty"`
+
+	// ExcludedResources is used to completely ignore entire classes of resource group/kinds. Takes precedence over
+	// ResourceExclusions.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Excluded Resources'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	ExcludedResources []v1alpha1.ResourceFilter `json:"excludedResources,omitempty"`
+
+	// IncludedResources is used to only include specific group/kinds in the reconciliation process. Takes precedence
+	// over ResourceInclusions.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Included Resources'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	IncludedResources []v1alpha1.ResourceFilter `json:"includedResources,omitempty"`
+
+	// ResourceTrackingMethod defines how Argo CD should track resources that it manages. Valid options are label, annotation and annotation+label.
+	//+kubebuilder:validation:Enum=label;annotation;annotation+label
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Tracking Method'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	ResourceTrackingMethod string `json:"resourceTrackingMethod,omitempty"`
+
+	// Server defines the options for the ArgoCD Server component.
+	Server v1alpha1.ArgoCDServerSpec `json:"server,omitempty"`
+
+	// SourceNamespaces defines the namespaces application resources are allowed to be created in
+	SourceNamespaces []string `json:"sourceNamespaces,omitempty"`
+
+	// SSO defines the Single Sign-on configuration for Argo CD
+	SSO *v1alpha1.ArgoCDSSOSpec `json:"sso,omitempty"`
+
+	// StatusBadgeEnabled toggles application status badge feature.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Status Badge Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	StatusBadgeEnabled bool `json:"statusBadgeEnabled,omitempty"`
+
+	// TLS defines the TLS options for ArgoCD.
+	TLS v1alpha1.ArgoCDTLSSpec `json:"tls,omitempty"`
+
+	// UnmanagedConfigKeys lists the keys of the argocd-cm ConfigMap that the operator must not set, update or remove,
+	// so that settings tuned by hand are not reverted. Entries may be glob patterns, e.g. `resource.customizations.*`.
+	// Unmanaged keys take precedence over the settings of the ArgoCD, including ExtraConfig.
+	UnmanagedConfigKeys []string `json:"unmanagedConfigKeys,omitempty"`
+
+	// UpgradeStrategy defines how the components are rolled out when the Argo CD image changes, e.g. on a change of
+	// Version. With Parallel, the default, all components are updated at once. With Ordered, the components are
+	// updated one after the other, in the order Redis, repo server, application controller, server, Dex and
+	// notifications controller, each waiting for the previous ones to be ready.
+	//+kubebuilder:validation:Enum=Parallel;Ordered
+	UpgradeStrategy v1alpha1.ArgoCDUpgradeStrategyType `json:"upgradeStrategy,omitempty"`
+
+	// UsersAnonymousEnabled toggles anonymous user access.
+	// The anonymous users get default role permissions specified argocd-rbac-cm.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Anonymous Users Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	UsersAnonymousEnabled bool `json:"usersAnonymousEnabled,omitempty"`
+
+	// Version is the tag to use with the ArgoCD container image for all ArgoCD components.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Version",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:ArgoCD","urn:alm:descriptor:com.tectonic.ui:text"}
+	Version string `json:"version,omitempty"`
+
+	// Workloads defines the rollout settings of the Deployments and StatefulSets of the Argo CD components.
+	Workloads *v1alpha1.ArgoCDWorkloadSpec `json:"workloads,omitempty"`
+
+	// Banner defines an additional banner to be displayed in Argo CD UI
+	Banner *v1alpha1.Banner `json:"banner,omitempty"`
+}
@@ -4,7 +4,7 @@ INDEX="${HOSTNAME##*-}"
 SENTINEL_PORT={{- if eq .UseTLS "false" -}}26379{{- else -}}0{{- end }}
 MASTER=''
 MASTER_GROUP="argocd"
-QUORUM="2"
+QUORUM="{{.Quorum}}"
 REDIS_CONF=/data/conf/redis.conf
 {{- if eq .UseTLS "false"}}
 REDIS_PORT=6379
@@ -133,9 +133,9 @@ setup_defaults() {
 redis_ping() {
 set +e
     if [ "$REDIS_PORT" -eq 0 ]; then
-        redis-cli -h "${MASTER}" -p "${REDIS_TLS_PORT}"  --tls --cacert /app/config/redis/tls/tls.crt ping
+        redis-cli -h "${MASTER}" -p "${REDIS_TLS_PORT}"{{- if eq .UseAuth "true"}} -a "${AUTH}" --no-auth-warning{{- end}}  --tls --cacert /app/config/redis/tls/tls.crt ping
     else
-        redis-cli -h "${MASTER}" -p "${REDIS_PORT}" ping
+        redis-cli -h "${MASTER}" -p "${REDIS_PORT}"{{- if eq .UseAuth "true"}} -a "${AUTH}" --no-auth-warning{{- end}} ping
     fi
 set -e
 }
@@ -11,6 +11,10 @@ tls-replication yes
 tls-auth-clients no
 {{- end}}
 bind 0.0.0.0
+{{- if eq .UseAuth "true"}}
+requirepass replace-default-auth
+masterauth replace-default-auth
+{{- end}}
 maxmemory 0
 maxmemory-policy volatile-lru
 min-replicas-max-lag 5
@@ -18,5 +22,9 @@ min-replicas-to-write 1
 rdbchecksum yes
 rdbcompression yes
 repl-diskless-sync yes
+{{- if eq .Persistence "true"}}
+appendonly yes
+{{- else}}
 save ""
+{{- end}}
 protected-mode no
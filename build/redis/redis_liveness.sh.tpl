@@ -2,6 +2,10 @@ response=$(
   redis-cli \
     -h localhost \
     -p 6379 \
+{{- if eq .UseAuth "true"}}
+    -a "${AUTH}" \
+    --no-auth-warning \
+{{- end}}
 {{- if eq .UseTLS "true"}}
     --tls \
     --cacert /app/config/redis/tls/tls.crt \
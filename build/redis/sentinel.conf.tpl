@@ -11,7 +11,10 @@ tls-replication yes
 tls-auth-clients no
 {{- end}}
 bind 0.0.0.0
-    sentinel down-after-milliseconds argocd 10000
-    sentinel failover-timeout argocd 180000
+{{- range $key, $value := .SentinelConfig}}
+    sentinel {{$key}} argocd {{$value}}
+{{- end}}
+{{- if eq .UseAuth "true"}}
+    sentinel auth-pass argocd replace-default-auth
+{{- end}}
     maxclients 10000
-    sentinel parallel-syncs argocd 5
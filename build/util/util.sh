@@ -18,9 +18,15 @@ BACKUP_SCRIPT=$0
 BACKUP_ACTION=$1
 BACKUP_LOCATION=$2
 BACKUP_FILENAME=argocd-backup.yaml
+# The bucket and prefix of the archive are set from the ArgoCDExport, and default to the values of the secret
+BACKUP_OBJECT_NAME=${BACKUP_PREFIX:+${BACKUP_PREFIX}/}${BACKUP_FILENAME}
 BACKUP_EXPORT_LOCATION=/tmp/${BACKUP_FILENAME}
 BACKUP_ENCRYPT_LOCATION=/backups/${BACKUP_FILENAME}
-BACKUP_KEY_LOCATION=/secrets/backup.key
+# The key is held by the export secret, unless the ArgoCDExport references a secret for it
+BACKUP_KEY_LOCATION=${BACKUP_KEY_LOCATION:-/secrets/backup.key}
+# With a retention policy, every export is also kept as a timestamped archive that is pruned by the policy
+BACKUP_ARCHIVE_NAME=argocd-backup-`date -u +%Y%m%d%H%M%S`.yaml
+BACKUP_ARCHIVE_OBJECT_NAME=${BACKUP_PREFIX:+${BACKUP_PREFIX}/}${BACKUP_ARCHIVE_NAME}
 DEFAULT_BACKUP_BUCKET_REGION="us-east-1"
 
 export_argocd () {
@@ -28,6 +34,8 @@ export_argocd () {
     create_backup
     encrypt_backup
     push_backup
+    prune_backups
+    report_backup
     echo "argo-cd export complete"
 }
 
@@ -38,6 +46,7 @@ create_backup () {
 
 encrypt_backup () {
     echo "encrypting argo-cd backup"
+    BACKUP_KEY_FINGERPRINT=sha256:`sha256sum ${BACKUP_KEY_LOCATION} | awk '{print $1}'`
     openssl enc -aes-256-cbc -pbkdf2 -pass file:${BACKUP_KEY_LOCATION} -in ${BACKUP_EXPORT_LOCATION} -out ${BACKUP_ENCRYPT_LOCATION}
     rm ${BACKUP_EXPORT_LOCATION}
 }
@@ -53,14 +62,80 @@ push_backup () {
         "gcp")
             push_gcp
             ;;
+        "local")
+            push_local
+            ;;
         *)
-        # local and unsupported backends
+        # unsupported backends
+    esac
+}
+
+retention_enabled () {
+    [[ -n "${BACKUP_RETENTION_KEEP_LAST}" || -n "${BACKUP_RETENTION_MAX_AGE}" ]]
+}
+
+# Reads the names of the backups from stdin and prints the timestamped archives to prune.
+expired_backups () {
+    local cutoff=""
+    if [[ -n "${BACKUP_RETENTION_MAX_AGE}" ]]; then
+        cutoff=argocd-backup-`date -u -d @$(( $(date +%s) - BACKUP_RETENTION_MAX_AGE )) +%Y%m%d%H%M%S`.yaml
+    fi
+    local count=0
+    for backup in `grep -E '^argocd-backup-[0-9]{14}\.yaml$' | sort -r`; do
+        count=$((count + 1))
+        if [[ -n "${BACKUP_RETENTION_KEEP_LAST}" && ${count} -gt ${BACKUP_RETENTION_KEEP_LAST} ]] || [[ -n "${cutoff}" && "${backup}" < "${cutoff}" ]]; then
+            echo ${backup}
+        fi
+    done
+}
+
+prune_backups () {
+    if ! retention_enabled; then
+        return
+    fi
+    echo "pruning argo-cd backups"
+    case  ${BACKUP_LOCATION} in
+        "aws")
+            prune_aws
+            ;;
+        "azure")
+            prune_azure
+            ;;
+        "gcp")
+            prune_gcp
+            ;;
+        "local")
+            prune_local
+            ;;
+        *)
+        # unsupported backends
     esac
 }
 
+# Reports the size of the backup and the fingerprint of its key as the termination message of the container, for the
+# status of the ArgoCDExport.
+report_backup () {
+    {
+        echo "size=`stat -c %s ${BACKUP_ENCRYPT_LOCATION}`"
+        echo "keyFingerprint=${BACKUP_KEY_FINGERPRINT}"
+    } > /dev/termination-log || true
+}
+
+push_local () {
+    if retention_enabled; then
+        cp ${BACKUP_ENCRYPT_LOCATION} /backups/${BACKUP_ARCHIVE_NAME}
+    fi
+}
+
+prune_local () {
+    for backup in `ls -1 /backups | expired_backups`; do
+        rm /backups/${backup}
+    done
+}
+
 push_aws () {
     echo "pushing argo-cd backup to aws"
-    BACKUP_BUCKET_NAME=`cat /secrets/aws.bucket.name`
+    BACKUP_BUCKET_NAME=${BACKUP_BUCKET_NAME:-`cat /secrets/aws.bucket.name`}
     BACKUP_BUCKET_REGION=DEFAULT_BACKUP_BUCKET_REGION
     # Set BACKUP_BUCKET_REGION to us-east-1(DEFAULT_BACKUP_BUCKET_REGION) if a user does not provide aws.bucket.region
     # in aws-backup-secret
@@ -75,7 +150,16 @@ push_aws () {
         aws s3 mb ${BACKUP_BUCKET_URI} --region ${BACKUP_BUCKET_REGION}
         aws s3api put-public-access-block --bucket ${BACKUP_BUCKET_NAME} --public-access-block-configuration "BlockPublicAcls=true,IgnorePublicAcls=true,BlockPublicPolicy=true,RestrictPublicBuckets=true"
     fi
-    aws s3 cp ${BACKUP_ENCRYPT_LOCATION} ${BACKUP_BUCKET_URI}/${BACKUP_FILENAME}
+    aws s3 cp ${BACKUP_ENCRYPT_LOCATION} ${BACKUP_BUCKET_URI}/${BACKUP_OBJECT_NAME} --metadata key-fingerprint=${BACKUP_KEY_FINGERPRINT}
+    if retention_enabled; then
+        aws s3 cp ${BACKUP_ENCRYPT_LOCATION} ${BACKUP_BUCKET_URI}/${BACKUP_ARCHIVE_OBJECT_NAME} --metadata key-fingerprint=${BACKUP_KEY_FINGERPRINT}
+    fi
+}
+
+prune_aws () {
+    for backup in `aws s3 ls ${BACKUP_BUCKET_URI}/${BACKUP_PREFIX:+${BACKUP_PREFIX}/} | awk '{print $4}' | expired_backups`; do
+        aws s3 rm ${BACKUP_BUCKET_URI}/${BACKUP_PREFIX:+${BACKUP_PREFIX}/}${backup}
+    done
 }
 
 push_azure () {
@@ -84,21 +168,39 @@ push_azure () {
     BACKUP_SERVICE_ID=`cat /secrets/azure.service.id`
     BACKUP_CERT_PATH="/secrets/azure.service.cert"
     BACKUP_TENANT_ID=`cat /secrets/azure.tenant.id`
-    BACKUP_CONTAINER_NAME=`cat /secrets/azure.container.name`
+    BACKUP_CONTAINER_NAME=${BACKUP_BUCKET_NAME:-`cat /secrets/azure.container.name`}
     az login --service-principal -u ${BACKUP_SERVICE_ID} -p ${BACKUP_CERT_PATH} --tenant ${BACKUP_TENANT_ID}
     az storage container create --auth-mode login --account-name ${BACKUP_STORAGE_ACCOUNT} --name ${BACKUP_CONTAINER_NAME}
-    az storage blob upload --auth-mode login --account-name ${BACKUP_STORAGE_ACCOUNT} --container-name ${BACKUP_CONTAINER_NAME} --file ${BACKUP_ENCRYPT_LOCATION} --name ${BACKUP_FILENAME}
+    az storage blob upload --auth-mode login --account-name ${BACKUP_STORAGE_ACCOUNT} --container-name ${BACKUP_CONTAINER_NAME} --file ${BACKUP_ENCRYPT_LOCATION} --name ${BACKUP_OBJECT_NAME} --metadata key_fingerprint=${BACKUP_KEY_FINGERPRINT}
+    if retention_enabled; then
+        az storage blob upload --auth-mode login --account-name ${BACKUP_STORAGE_ACCOUNT} --container-name ${BACKUP_CONTAINER_NAME} --file ${BACKUP_ENCRYPT_LOCATION} --name ${BACKUP_ARCHIVE_OBJECT_NAME} --metadata key_fingerprint=${BACKUP_KEY_FINGERPRINT}
+    fi
+}
+
+prune_azure () {
+    for backup in `az storage blob list --auth-mode login --account-name ${BACKUP_STORAGE_ACCOUNT} --container-name ${BACKUP_CONTAINER_NAME} --prefix "${BACKUP_PREFIX:+${BACKUP_PREFIX}/}" --query "[].name" -o tsv | xargs -r -n 1 basename | expired_backups`; do
+        az storage blob delete --auth-mode login --account-name ${BACKUP_STORAGE_ACCOUNT} --container-name ${BACKUP_CONTAINER_NAME} --name ${BACKUP_PREFIX:+${BACKUP_PREFIX}/}${backup}
+    done
 }
 
 push_gcp () {
     echo "pushing argo-cd backup to gcp"
     BACKUP_BUCKET_KEY="/secrets/gcp.key.file"
     BACKUP_PROJECT_ID=`cat /secrets/gcp.project.id`
-    BACKUP_BUCKET_NAME=`cat /secrets/gcp.bucket.name`
+    BACKUP_BUCKET_NAME=${BACKUP_BUCKET_NAME:-`cat /secrets/gcp.bucket.name`}
     BACKUP_BUCKET_URI="gs://${BACKUP_BUCKET_NAME}"
     gcloud auth activate-service-account --key-file=${BACKUP_BUCKET_KEY}
     gsutil mb -b on -p ${BACKUP_PROJECT_ID} ${BACKUP_BUCKET_URI} || true
-    gsutil cp ${BACKUP_ENCRYPT_LOCATION} ${BACKUP_BUCKET_URI}/${BACKUP_FILENAME}
+    gsutil -h x-goog-meta-key-fingerprint:${BACKUP_KEY_FINGERPRINT} cp ${BACKUP_ENCRYPT_LOCATION} ${BACKUP_BUCKET_URI}/${BACKUP_OBJECT_NAME}
+    if retention_enabled; then
+        gsutil -h x-goog-meta-key-fingerprint:${BACKUP_KEY_FINGERPRINT} cp ${BACKUP_ENCRYPT_LOCATION} ${BACKUP_BUCKET_URI}/${BACKUP_ARCHIVE_OBJECT_NAME}
+    fi
+}
+
+prune_gcp () {
+    for backup in `gsutil ls ${BACKUP_BUCKET_URI}/${BACKUP_PREFIX:+${BACKUP_PREFIX}/} | xargs -r -n 1 basename | expired_backups`; do
+        gsutil rm ${BACKUP_BUCKET_URI}/${BACKUP_PREFIX:+${BACKUP_PREFIX}/}${backup}
+    done
 }
 
 import_argocd () {
@@ -127,9 +229,9 @@ pull_backup () {
 
 pull_aws () {
     echo "pulling argo-cd backup from aws"
-    BACKUP_BUCKET_NAME=`cat /secrets/aws.bucket.name`
+    BACKUP_BUCKET_NAME=${BACKUP_BUCKET_NAME:-`cat /secrets/aws.bucket.name`}
     BACKUP_BUCKET_URI="s3://${BACKUP_BUCKET_NAME}"
-    aws s3 cp ${BACKUP_BUCKET_URI}/${BACKUP_FILENAME} ${BACKUP_ENCRYPT_LOCATION}
+    aws s3 cp ${BACKUP_BUCKET_URI}/${BACKUP_OBJECT_NAME} ${BACKUP_ENCRYPT_LOCATION}
 }
 
 pull_azure () {
@@ -138,19 +240,19 @@ pull_azure () {
     BACKUP_SERVICE_ID=`cat /secrets/azure.service.id`
     BACKUP_CERT_PATH="/secrets/azure.service.cert"
     BACKUP_TENANT_ID=`cat /secrets/azure.tenant.id`
-    BACKUP_CONTAINER_NAME=`cat /secrets/azure.container.name`
+    BACKUP_CONTAINER_NAME=${BACKUP_BUCKET_NAME:-`cat /secrets/azure.container.name`}
     az login --service-principal -u ${BACKUP_SERVICE_ID} -p ${BACKUP_CERT_PATH} --tenant ${BACKUP_TENANT_ID}
-    az storage blob download --auth-mode login --account-name ${BACKUP_STORAGE_ACCOUNT} --container-name ${BACKUP_CONTAINER_NAME} --file ${BACKUP_ENCRYPT_LOCATION} --name ${BACKUP_FILENAME}
+    az storage blob download --auth-mode login --account-name ${BACKUP_STORAGE_ACCOUNT} --container-name ${BACKUP_CONTAINER_NAME} --file ${BACKUP_ENCRYPT_LOCATION} --name ${BACKUP_OBJECT_NAME}
 }
 
 pull_gcp () {
     echo "pulling argo-cd backup from gcp"
     BACKUP_BUCKET_KEY="/secrets/gcp.key.file"
     BACKUP_PROJECT_ID=`cat /secrets/gcp.project.id`
-    BACKUP_BUCKET_NAME=`cat /secrets/gcp.bucket.name`
+    BACKUP_BUCKET_NAME=${BACKUP_BUCKET_NAME:-`cat /secrets/gcp.bucket.name`}
     BACKUP_BUCKET_URI="gs://${BACKUP_BUCKET_NAME}"
     gcloud auth activate-service-account --key-file=${BACKUP_BUCKET_KEY}
-    gsutil cp ${BACKUP_BUCKET_URI}/${BACKUP_FILENAME} ${BACKUP_ENCRYPT_LOCATION}
+    gsutil cp ${BACKUP_BUCKET_URI}/${BACKUP_OBJECT_NAME} ${BACKUP_ENCRYPT_LOCATION}
 }
 
 decrypt_backup () {
@@ -9,6 +9,51 @@ const (
 	// namespace a specific object is associated with
 	AnnotationNamespace = "argocds.argoproj.io/namespace"
 
+	// AnnotationManagedNamespace is the annotation on namespaces that specifies which ArgoCD instance
+	// namespace labelled the namespace as managed because it is listed in .spec.managedNamespaces
+	AnnotationManagedNamespace = "argocds.argoproj.io/managed-namespace-of"
+
+	// AnnotationRotateAdminPassword is the annotation on ArgoCD instances that requests the rotation
+	// of the admin password when set to "true"
+	AnnotationRotateAdminPassword = "argocds.argoproj.io/rotate-admin-password"
+
+	// AnnotationAdminPasswordRotatedAt is the annotation on the cluster secret that records the time
+	// the admin password was last rotated
+	AnnotationAdminPasswordRotatedAt = "argocds.argoproj.io/admin-password-rotated-at"
+
+	// AnnotationSecretKeyRefs is the annotation on the argocd-secret Secret that records the keys
+	// the operator sourced from the secretKeyRefs of the ArgoCD instance
+	AnnotationSecretKeyRefs = "argocds.argoproj.io/secret-key-refs"
+
+	// AnnotationNotificationsConfigKeys is the annotation on the argocd-notifications-cm ConfigMap that records
+	// the keys the operator sourced from the notifications templates, triggers, integrations and services of the
+	// ArgoCD instance
+	AnnotationNotificationsConfigKeys = "argocds.argoproj.io/notifications-config-keys"
+
+	// AnnotationShardAssigned is the annotation on cluster secrets that marks the shard as assigned
+	// by the operator
+	AnnotationShardAssigned = "argocds.argoproj.io/shard-assigned"
+
+	// AnnotationReconcile is the annotation on ArgoCD instances that pauses the reconciliation of their
+	// resources when set to ReconcilePaused
+	AnnotationReconcile = "argocd.argoproj.io/reconcile"
+
+	// ReconcilePaused is the value of the reconcile annotation that pauses the reconciliation
+	ReconcilePaused = "paused"
+
+	// AnnotationSpecHash is the annotation on the resources applied with Server-Side Apply by the operator,
+	// and on the in-cluster cluster Secret, that records the hash of their desired state, used to skip
+	// updates that would not change them
+	AnnotationSpecHash = "argocds.argoproj.io/spec-hash"
+
+	// AnnotationArgoCDImage is the annotation on the workloads of the Argo CD components that records the
+	// Argo CD image they were last applied with, used to roll out upgrades in order
+	AnnotationArgoCDImage = "argocds.argoproj.io/argocd-image"
+
+	// AnnotationConversionData is the annotation on v1beta1 ArgoCD instances that holds the deprecated v1alpha1
+	// settings without a v1beta1 equivalent, so that they are restored when the instance is converted back
+	AnnotationConversionData = "argocds.argoproj.io/conversion-data"
+
 	// AnnotationOpenShiftServiceCA is the annotation on services used to
 	// request a TLS certificate from OpenShift's Service CA for AutoTLS
 	AnnotationOpenShiftServiceCA = "service.beta.openshift.io/serving-cert-secret-name"
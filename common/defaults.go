@@ -21,6 +21,15 @@ const (
 	// ArgoCDApplicationControllerDefaultShardReplicas is the default number of replicas that the ArgoCD Application Controller Should Use
 	ArgocdApplicationControllerDefaultReplicas = 1
 
+	// ArgoCDApplicationControllerDefaultStorageSize is the default size of the Application Controller working directory volume.
+	ArgoCDApplicationControllerDefaultStorageSize = "10Gi"
+
+	// ArgoCDApplicationControllerWorkDirPath is the path of the Application Controller working directory.
+	ArgoCDApplicationControllerWorkDirPath = "/tmp"
+
+	// ArgoCDApplicationControllerWorkDirVolumeName is the name of the Application Controller working directory volume.
+	ArgoCDApplicationControllerWorkDirVolumeName = "workdir"
+
 	// ArgoCDDefaultLogLevel is the default log level to be used by all ArgoCD components.
 	ArgoCDDefaultLogLevel = "info"
 
@@ -30,6 +39,9 @@ const (
 	// ArgoCDServerComponent is the name of the Dex server control plane component
 	ArgoCDServerComponent = "argocd-server"
 
+	// ArgoCDRepoServerComponent is the name of the Repo server control plane component
+	ArgoCDRepoServerComponent = "argocd-repo-server"
+
 	// ArgoCDRedisComponent is the name of the Redis control plane component
 	ArgoCDRedisComponent = "argocd-redis"
 
@@ -42,6 +54,12 @@ const (
 	// ArgoCDNotificationsControllerComponent is the name of the Notifications controller control plane component
 	ArgoCDNotificationsControllerComponent = "argocd-notifications-controller"
 
+	// ArgoCDAgentPrincipalComponent is the name of the argocd-agent principal control plane component
+	ArgoCDAgentPrincipalComponent = "argocd-agent-principal"
+
+	// ArgoCDAgentAgentComponent is the name of the argocd-agent agent component
+	ArgoCDAgentAgentComponent = "argocd-agent-agent"
+
 	// ArgoCDOperatorGrafanaComponent is the name of the Grafana control plane component
 	ArgoCDOperatorGrafanaComponent = "argocd-grafana"
 
@@ -57,6 +75,25 @@ const (
 	// ArgoCDDefaultApplicationInstanceLabelKey is the default app name as a tracking label.
 	ArgoCDDefaultApplicationInstanceLabelKey = "app.kubernetes.io/instance"
 
+	// ArgoCDDefaultAgentImage is the argocd-agent container image to use when not specified.
+	ArgoCDDefaultAgentImage = "quay.io/argoprojlabs/argocd-agent"
+
+	// ArgoCDDefaultAgentVersion is the argocd-agent container image tag to use when not specified.
+	ArgoCDDefaultAgentVersion = "v0.1.0"
+
+	// ArgoCDDefaultAgentMode is the default mode of the argocd-agent agent.
+	ArgoCDDefaultAgentMode = "managed"
+
+	// ArgoCDDefaultAgentPrincipalAuth is the default authentication method of the argocd-agent principal, which
+	// identifies the agents by the common name of their client certificate.
+	ArgoCDDefaultAgentPrincipalAuth = "mtls:CN=([^,]+)"
+
+	// ArgoCDDefaultAgentPrincipalPort is the default gRPC listen port for the argocd-agent principal.
+	ArgoCDDefaultAgentPrincipalPort = 8443
+
+	// ArgoCDDefaultAgentPrincipalServicePort is the default port of the Service of the argocd-agent principal.
+	ArgoCDDefaultAgentPrincipalServicePort = 443
+
 	// ArgoCDDefaultArgoImage is the ArgoCD container image to use when not specified.
 	ArgoCDDefaultArgoImage = "quay.io/argoproj/argocd"
 
@@ -115,6 +152,24 @@ const (
 	// ArgoCDDefaultDexVersion is the Dex container image tag to use when not specified.
 	ArgoCDDefaultDexVersion = "sha256:d5f887574312f606c61e7e188cfb11ddb33ff3bf4bd9f06e6b1458efca75f604" // v2.30.3
 
+	// ArgoCDDefaultExecProviderCommand is the exec plugin shipped with Argo CD, used for clusters authenticating through
+	// the workload identity of gcp or azure when no command is specified.
+	ArgoCDDefaultExecProviderCommand = "argocd-k8s-auth"
+
+	// ArgoCDDefaultExtensionInstallerImage is the argocd-extension-installer container image to use when not specified.
+	ArgoCDDefaultExtensionInstallerImage = "quay.io/argoprojlabs/argocd-extension-installer"
+
+	// ArgoCDDefaultExtensionInstallerVersion is the argocd-extension-installer container image tag to use when not
+	// specified.
+	ArgoCDDefaultExtensionInstallerVersion = "v0.0.8"
+
+	// ArgoCDDefaultAzureFederatedTokenFile is the path at which the Azure workload identity webhook projects the
+	// federated service account token.
+	ArgoCDDefaultAzureFederatedTokenFile = "/var/run/secrets/azure/tokens/azure-identity-token"
+
+	// ArgoCDDefaultAzureAuthorityHost is the Azure AD authority host of the public cloud.
+	ArgoCDDefaultAzureAuthorityHost = "https://login.microsoftonline.com/"
+
 	// ArgoCDDefaultExportJobImage is the export job container image to use when not specified.
 	ArgoCDDefaultExportJobImage = "quay.io/argoprojlabs/argocd-operator-util"
 
@@ -160,6 +215,13 @@ const (
 	// ArgoCDDefaultGrafanaConfigPath is the default Grafana configuration directory when not specified.
 	ArgoCDDefaultGrafanaConfigPath = "/var/lib/grafana"
 
+	// ArgoCDDefaultGrafanaDashboardLabel is the default label the Grafana dashboard sidecar selects ConfigMaps with.
+	ArgoCDDefaultGrafanaDashboardLabel = "grafana_dashboard"
+
+	// ArgoCDDefaultGrafanaDashboardLabelValue is the default value of the label the Grafana dashboard sidecar selects
+	// ConfigMaps with.
+	ArgoCDDefaultGrafanaDashboardLabelValue = "1"
+
 	// ArgoCDDefaultGrafanaVersion is the Grafana container image tag to use when not specified.
 	ArgoCDDefaultGrafanaVersion = "sha256:afef23a1b4cf159ec3180aac3ad693c10e560657313bfe3ec81f344ace6d2f05" // 6.7.2
 
@@ -172,6 +234,9 @@ const (
 	// ArgoCDDefaultIngressPath is the path to use for the Ingress when not specified.
 	ArgoCDDefaultIngressPath = "/"
 
+	// ArgoCDDefaultApplicationSetWebhookPath is the path to use for the ApplicationSet webhook Ingress when not specified.
+	ArgoCDDefaultApplicationSetWebhookPath = "/api/webhook"
+
 	// ArgoCDDefaultKustomizeBuildOptions is the default kustomize build options.
 	ArgoCDDefaultKustomizeBuildOptions = ""
 
@@ -207,9 +272,23 @@ const (
 	// ArgoCDDefaultRedisConfigPath is the default Redis configuration directory when not specified.
 	ArgoCDDefaultRedisConfigPath = "/var/lib/redis"
 
+	// ArgoCDRedisDefaultStorageSize is the default size of the Redis data volume.
+	ArgoCDRedisDefaultStorageSize = "10Gi"
+
+	// ArgoCDRedisDataVolumeName is the name of the Redis data volume.
+	ArgoCDRedisDataVolumeName = "data"
+
 	// ArgoCDDefaultRedisHAReplicas is the defaul number of replicas for Redis when rinning in HA mode.
 	ArgoCDDefaultRedisHAReplicas = int32(3)
 
+	// ArgoCDDefaultHAReplicas is the default number of replicas for the Argo CD Server and repo server when running
+	// in HA mode.
+	ArgoCDDefaultHAReplicas = int32(2)
+
+	// ArgoCDDefaultRevisionHistoryLimit is the default number of old revisions kept for the Deployments and
+	// StatefulSets of the Argo CD components.
+	ArgoCDDefaultRevisionHistoryLimit = int32(3)
+
 	// ArgoCDDefaultRedisHAProxyImage is the default Redis HAProxy image to use when not specified.
 	ArgoCDDefaultRedisHAProxyImage = "haproxy"
 
@@ -234,6 +313,12 @@ const (
 	// ArgoCDDefaultRedisVersionHA is the Redis container image tag to use when not specified in HA mode.
 	ArgoCDDefaultRedisVersionHA = "sha256:8061ca607db2a0c80010aeb5fc9bed0253448bc68711eaa14253a392f6c48280" // 6.2.4-alpine
 
+	// ArgoCDDefaultApplicationControllerMetricsPort is the default listen port for the Argo CD application controller metrics.
+	ArgoCDDefaultApplicationControllerMetricsPort = 8082
+
+	// ArgoCDDefaultApplicationSetMetricsPort is the default listen port for the Argo CD ApplicationSet controller metrics.
+	ArgoCDDefaultApplicationSetMetricsPort = 8080
+
 	// ArgoCDDefaultRepoMetricsPort is the default listen port for the Argo CD repo server metrics.
 	ArgoCDDefaultRepoMetricsPort = 8084
 
@@ -267,6 +352,13 @@ const (
 	// ArgoCDDefaultControllerParellelismLimit is the default parallelism limit for application controller
 	ArgoCDDefaultControllerParallelismLimit = int32(10)
 
+	// ArgoCDDefaultServerCustomStylesKey is the default key of the custom stylesheet in the custom styles ConfigMap.
+	ArgoCDDefaultServerCustomStylesKey = "custom.styles.css"
+
+	// ArgoCDDefaultServerCustomStylesPath is the path, relative to the static assets, at which the custom styles
+	// ConfigMap is mounted into the Argo CD Server.
+	ArgoCDDefaultServerCustomStylesPath = "custom"
+
 	// ArgoCDDefaultServerResourceLimitCPU is the default CPU limit when not specified for the Argo CD server contianer.
 	ArgoCDDefaultServerResourceLimitCPU = "1000m"
 
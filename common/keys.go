@@ -22,6 +22,9 @@ const (
 	// ArgoCDKeyAdminEnabled is the configuration key for the admin enabled setting..
 	ArgoCDKeyAdminEnabled = "admin.enabled"
 
+	// ArgoCDKeyControllerDiffServerSide is the configuration key for toggling server-side diff.
+	ArgoCDKeyControllerDiffServerSide = "controller.diff.server.side"
+
 	// ArgoCDKeyApplicationInstanceLabelKey is the configuration key for the application instance label.
 	ArgoCDKeyApplicationInstanceLabelKey = "application.instanceLabelKey"
 
@@ -46,6 +49,9 @@ const (
 	// ArgoCDKeyDexConfig is the key for dex configuration.
 	ArgoCDKeyDexConfig = "dex.config"
 
+	// ArgoCDKeyExtensionConfig is the configuration key for the backends of the proxy extensions.
+	ArgoCDKeyExtensionConfig = "extension.config"
+
 	// ArgoCDKeyFailureDomainZone is the failure-domain zone key for labels.
 	ArgoCDKeyFailureDomainZone = "failure-domain.beta.kubernetes.io/zone"
 
@@ -85,12 +91,37 @@ const (
 	// ArgoCDKeyIngressSSLPassthrough is the ssl passthrough key for labels.
 	ArgoCDKeyIngressSSLPassthrough = "nginx.ingress.kubernetes.io/ssl-passthrough"
 
+	// ArgoCDKeyIngressALBBackendProtocol is the backend protocol annotation of the AWS Load Balancer Controller.
+	ArgoCDKeyIngressALBBackendProtocol = "alb.ingress.kubernetes.io/backend-protocol"
+
+	// ArgoCDKeyIngressALBBackendProtocolVersion is the backend protocol version annotation of the AWS Load Balancer Controller.
+	ArgoCDKeyIngressALBBackendProtocolVersion = "alb.ingress.kubernetes.io/backend-protocol-version"
+
+	// ArgoCDKeyIngressALBSSLRedirect is the ssl redirect annotation of the AWS Load Balancer Controller.
+	ArgoCDKeyIngressALBSSLRedirect = "alb.ingress.kubernetes.io/ssl-redirect"
+
+	// ArgoCDKeyIngressTraefikRouterTLS is the router TLS annotation of the Traefik ingress controller.
+	ArgoCDKeyIngressTraefikRouterTLS = "traefik.ingress.kubernetes.io/router.tls"
+
 	// ArgoCDKeyKustomizeBuildOptions is the configuration key for the kustomize build options.
 	ArgoCDKeyKustomizeBuildOptions = "kustomize.buildOptions"
 
 	// ArgoCDKeyMetrics is the resource metrics key for labels.
 	ArgoCDKeyMetrics = "metrics"
 
+	// ArgoCDKeyAzureWorkloadIdentity is the pod label key enabling the Azure workload identity webhook.
+	ArgoCDKeyAzureWorkloadIdentity = "azure.workload.identity/use"
+
+	// ArgoCDKeyAgentName is the label key for the name of the argocd-agent agent a client certificate is issued for.
+	ArgoCDKeyAgentName = "argocd.argoproj.io/agent-name"
+
+	// ArgoCDKeyAgentJWT is the key of the signing key of the argocd-agent principal in its JWT Secret.
+	ArgoCDKeyAgentJWT = "jwt.key"
+
+	// ArgoCDKeyTLSChecksum is the pod template annotation holding the checksum of the certificate mounted by a
+	// workload, which rolls out the workload when the certificate changes.
+	ArgoCDKeyTLSChecksum = "argocd.argoproj.io/tls-checksum"
+
 	// ArgoCDKeyName is the resource name key for labels.
 	ArgoCDKeyName = "app.kubernetes.io/name"
 
@@ -109,6 +140,9 @@ const (
 	// ArgoCDKeyPrometheus is the resource prometheus key for labels.
 	ArgoCDKeyPrometheus = "prometheus"
 
+	// ArgoCDKeyRedisAuth is the password key for the Redis auth Secret.
+	ArgoCDKeyRedisAuth = "auth"
+
 	// ArgoCDKeyRBACPolicyCSV is the configuration key for the Argo CD RBAC policy CSV.
 	ArgoCDKeyRBACPolicyCSV = "policy.csv"
 
@@ -161,6 +195,12 @@ const (
 	// ArgoCDKeyBannerURL is the configuration key for a banner message URL.
 	ArgoCDKeyBannerURL = "ui.bannerurl"
 
+	// ArgoCDKeyUICSSURL is the configuration key for the URL of a custom stylesheet of the Argo CD UI.
+	ArgoCDKeyUICSSURL = "ui.cssurl"
+
+	// ArgoCDKeyTimeoutReconciliation is the configuration key for the Application reconciliation timeout.
+	ArgoCDKeyTimeoutReconciliation = "timeout.reconciliation"
+
 	// ArgoCDKeyTLSCACert is the key for TLS CA certificates.
 	ArgoCDKeyTLSCACert = "ca.crt"
 
@@ -192,6 +232,10 @@ const (
 	// to used for the Keycloak container.
 	ArgoCDKeycloakImageEnvName = "ARGOCD_KEYCLOAK_IMAGE"
 
+	// ArgoCDAgentImageEnvName is the environment variable used to get the image
+	// to used for the argocd-agent containers.
+	ArgoCDAgentImageEnvName = "ARGOCD_AGENT_IMAGE"
+
 	// ArgoCDRedisHAProxyImageEnvName is the environment variable used to get the image
 	// to used for the Redis HA Proxy container.
 	ArgoCDRedisHAProxyImageEnvName = "ARGOCD_REDIS_HA_PROXY_IMAGE"
@@ -217,18 +261,92 @@ const (
 	// ArgoCDSecretTypeLabel is needed for cluster secrets
 	ArgoCDSecretTypeLabel = "argocd.argoproj.io/secret-type"
 
+	// ArgoCDKeyClusterShard is the key in cluster secrets that assigns the cluster to an Application Controller shard
+	ArgoCDKeyClusterShard = "shard"
+
 	// ArgoCDManagedByLabel is needed to identify namespace managed by an instance on ArgoCD
 	ArgoCDManagedByLabel = "argocd.argoproj.io/managed-by"
 
 	// ArgoCDManagedByClusterArgoCDLabel is needed to identify namespace mentioned as sourceNamespace on ArgoCD
 	ArgoCDManagedByClusterArgoCDLabel = "argocd.argoproj.io/managed-by-cluster-argocd"
 
+	// ArgoCDAggregateToClusterRoleLabel is used to select the ClusterRoles aggregated into an Argo CD component ClusterRole
+	ArgoCDAggregateToClusterRoleLabel = "argocd.argoproj.io/aggregate-to"
+
 	// ArgoCDControllerClusterRoleEnvName is an environment variable to specify a custom cluster role for Argo CD application controller
 	ArgoCDControllerClusterRoleEnvName = "CONTROLLER_CLUSTER_ROLE"
 
 	// ArgoCDServerClusterRoleEnvName is an environment variable to specify a custom cluster role for Argo CD server
 	ArgoCDServerClusterRoleEnvName = "SERVER_CLUSTER_ROLE"
 
+	// ArgoCDManagedNamespacesAllowListEnvName is an environment variable to restrict the namespaces each Argo CD instance
+	// is allowed to manage through the managed-by label
+	ArgoCDManagedNamespacesAllowListEnvName = "ARGOCD_MANAGED_NAMESPACES_ALLOWLIST"
+
+	// ArgoCDSyncPeriodEnvName is an environment variable to set the interval at which the operator reconciles all
+	// ArgoCD instances again, e.g. "10h"
+	ArgoCDSyncPeriodEnvName = "SYNC_PERIOD"
+
+	// ArgoCDMaxConcurrentReconcilesEnvName is an environment variable to set the number of ArgoCD instances the
+	// operator reconciles in parallel
+	ArgoCDMaxConcurrentReconcilesEnvName = "MAX_CONCURRENT_RECONCILES"
+
+	// ArgoCDReconcileBaseDelayEnvName is an environment variable to set the initial delay before a failed
+	// reconciliation of an ArgoCD instance is retried, e.g. "5ms"
+	ArgoCDReconcileBaseDelayEnvName = "RECONCILE_BASE_DELAY"
+
+	// ArgoCDReconcileMaxDelayEnvName is an environment variable to set the maximum delay before a failed
+	// reconciliation of an ArgoCD instance is retried, e.g. "1000s"
+	ArgoCDReconcileMaxDelayEnvName = "RECONCILE_MAX_DELAY"
+
+	// ArgoCDLabelSelectorEnvName is an environment variable to restrict the ArgoCD instances reconciled by the operator
+	// to those matching the given label selector, e.g. "operator-shard=a"
+	ArgoCDLabelSelectorEnvName = "ARGOCD_LABEL_SELECTOR"
+
+	// ArgoCDSizingProfileEnvName is an environment variable to set the profile of the resource requests and limits of
+	// the components whose resources are not set by their ArgoCD, one of "small", "medium" or "large"
+	ArgoCDSizingProfileEnvName = "ARGOCD_SIZING_PROFILE"
+
+	// ArgoCDLeaderElectionLeaseDurationEnvName is an environment variable to set the duration standby replicas of the
+	// operator wait before taking over the leadership of a leader that stopped renewing it, e.g. "15s"
+	ArgoCDLeaderElectionLeaseDurationEnvName = "LEADER_ELECTION_LEASE_DURATION"
+
+	// ArgoCDLeaderElectionRenewDeadlineEnvName is an environment variable to set the duration the leader replica of the
+	// operator retries renewing its leadership before giving it up, e.g. "10s"
+	ArgoCDLeaderElectionRenewDeadlineEnvName = "LEADER_ELECTION_RENEW_DEADLINE"
+
+	// ArgoCDLeaderElectionRetryPeriodEnvName is an environment variable to set the interval at which the replicas of the
+	// operator try to acquire or renew the leadership, e.g. "2s"
+	ArgoCDLeaderElectionRetryPeriodEnvName = "LEADER_ELECTION_RETRY_PERIOD"
+
+	// ArgoCDExportBucketEnvName is the environment variable used to set the bucket of the export archive for the
+	// export and import processes
+	ArgoCDExportBucketEnvName = "BACKUP_BUCKET_NAME"
+
+	// ArgoCDExportPrefixEnvName is the environment variable used to set the prefix of the export archive for the
+	// export and import processes
+	ArgoCDExportPrefixEnvName = "BACKUP_PREFIX"
+
+	// ArgoCDExportKeyLocationEnvName is the environment variable used to set the location of the encryption key file
+	// for the export and import processes
+	ArgoCDExportKeyLocationEnvName = "BACKUP_KEY_LOCATION"
+
+	// ArgoCDExportRetentionKeepLastEnvName is the environment variable used to set the number of most recent exports
+	// the export process keeps
+	ArgoCDExportRetentionKeepLastEnvName = "BACKUP_RETENTION_KEEP_LAST"
+
+	// ArgoCDExportRetentionMaxAgeEnvName is the environment variable used to set the maximum age in seconds of the
+	// exports the export process keeps
+	ArgoCDExportRetentionMaxAgeEnvName = "BACKUP_RETENTION_MAX_AGE"
+
+	// ArgoCDEnableConversionWebhookEnvName is an environment variable to enable the webhook that converts ArgoCD
+	// instances between the v1alpha1 and v1beta1 API versions, when set to "true"
+	ArgoCDEnableConversionWebhookEnvName = "ENABLE_CONVERSION_WEBHOOK"
+
+	// ArgoCDEnableDefaultingWebhookEnvName is an environment variable to enable the webhook that fills in the operator
+	// defaults of ArgoCD instances, when set to "true"
+	ArgoCDEnableDefaultingWebhookEnvName = "ENABLE_DEFAULTING_WEBHOOK"
+
 	// ArgoCDDexSecretKey is used to reference Dex secret from Argo CD secret into Argo CD configmap
 	ArgoCDDexSecretKey = "oidc.dex.clientSecret"
 )
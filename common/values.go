@@ -23,6 +23,9 @@ const (
 	// ArgoCDCASuffix is the name suffix for ArgoCD CA resources.
 	ArgoCDCASuffix = "ca"
 
+	// ArgoCDCmdParamsConfigMapName is the upstream hard-coded ArgoCD command parameters ConfigMap name.
+	ArgoCDCmdParamsConfigMapName = "argocd-cmd-params-cm"
+
 	// ArgoCDConfigMapName is the upstream hard-coded ArgoCD ConfigMap name.
 	ArgoCDConfigMapName = "argocd-cm"
 
@@ -32,6 +35,17 @@ const (
 	// ArgoCDDuration365Days is a duration representing 365 days.
 	ArgoCDDuration365Days = time.Hour * 24 * 365
 
+	// ArgoCDDefaultTLSRenewBefore is the default remaining validity of a certificate issued by the operator,
+	// below which the certificate is renewed.
+	ArgoCDDefaultTLSRenewBefore = time.Hour * 24 * 30
+
+	// ArgoCDFieldManager is the field manager the operator applies its managed resources with.
+	ArgoCDFieldManager = "argocd-operator"
+
+	// ArgoCDExportEncryptionKeyMountPath is the path at which the Secret referenced for the encryption key is mounted
+	// in the export and import containers.
+	ArgoCDExportEncryptionKeyMountPath = "/encryption-key"
+
 	// ArgoCDExportName is the export name for labels.
 	ArgoCDExportName = "argocd.export"
 
@@ -53,9 +67,40 @@ const (
 	// ArgoCDGrafanaDashboardConfigMapSuffix is the default suffix for the Grafana dashboards ConfigMap.
 	ArgoCDGrafanaDashboardConfigMapSuffix = "grafana-dashboards"
 
+	// ArgoCDGrafanaSidecarDashboardConfigMapSuffix is the suffix for the ConfigMaps holding a single Grafana dashboard
+	// for the Grafana dashboard sidecar.
+	ArgoCDGrafanaSidecarDashboardConfigMapSuffix = "grafana-dashboard"
+
+	// ArgoCDDexThemesPath is the directory Dex loads the themes of its login page from.
+	ArgoCDDexThemesPath = "/srv/dex/web/themes"
+
+	// ArgoCDExecProviderToolsMountPath is the path at which the exec provider tools volume is mounted in init containers.
+	ArgoCDExecProviderToolsMountPath = "/exec-provider-tools"
+
+	// ArgoCDExecProviderToolsVolumeName is the name of the volume shared between the application controller or the
+	// server and the init containers providing exec provider tools.
+	ArgoCDExecProviderToolsVolumeName = "exec-provider-tools"
+
+	// ArgoCDExtensionsMountPath is the path at which the Argo CD Server loads the UI extensions from.
+	ArgoCDExtensionsMountPath = "/tmp/extensions"
+
+	// ArgoCDExtensionsVolumeName is the name of the volume shared between the Argo CD Server and the init containers
+	// installing the UI extensions.
+	ArgoCDExtensionsVolumeName = "extensions"
+
+	// ArgoCDSSOThemeVolumeName is the name of the volume holding the theme of the login page of the SSO provider.
+	ArgoCDSSOThemeVolumeName = "sso-theme"
+
 	// ArgoCDKnownHostsConfigMapName is the upstream hard-coded SSH known hosts data ConfigMap name.
 	ArgoCDKnownHostsConfigMapName = "argocd-ssh-known-hosts-cm"
 
+	// ArgoCDKustomizeVersionsMountPath is the path at which the kustomize versions volume is mounted in init containers.
+	ArgoCDKustomizeVersionsMountPath = "/kustomize-versions"
+
+	// ArgoCDKustomizeVersionsVolumeName is the name of the volume shared between the repo server and the
+	// init containers providing additional kustomize versions.
+	ArgoCDKustomizeVersionsVolumeName = "kustomize-versions"
+
 	// ArgoCDRedisHAConfigMapName is the upstream ArgoCD Redis HA ConfigMap name.
 	ArgoCDRedisHAConfigMapName = "argocd-redis-ha-configmap"
 
@@ -74,9 +119,21 @@ const (
 	// ArgoCDStatusCompleted is the completed status value.
 	ArgoCDStatusCompleted = "Completed"
 
+	// ArgoCDIngressControllerNginx is the ingress controller flavor for the NGINX ingress controller.
+	ArgoCDIngressControllerNginx = "nginx"
+
+	// ArgoCDIngressControllerTraefik is the ingress controller flavor for Traefik.
+	ArgoCDIngressControllerTraefik = "traefik"
+
+	// ArgoCDIngressControllerALB is the ingress controller flavor for the AWS Load Balancer Controller.
+	ArgoCDIngressControllerALB = "alb"
+
 	// ArgoCDTLSCertsConfigMapName is the upstream hard-coded TLS certificate data ConfigMap name.
 	ArgoCDTLSCertsConfigMapName = "argocd-tls-certs-cm"
 
+	// ArgoCDRedisAuthSecretName is the name of the secret holding the password of the redis-server
+	ArgoCDRedisAuthSecretName = "argocd-redis"
+
 	// ArgoCDRedisServerTLSSecretName is the name of the TLS secret for the redis-server
 	ArgoCDRedisServerTLSSecretName = "argocd-operator-redis-tls"
 
@@ -86,6 +143,13 @@ const (
 	// ArgoCDServerTLSSecretName is the name of the TLS secret for the argocd-server
 	ArgoCDServerTLSSecretName = "argocd-server-tls"
 
+	// ArgoCDDexServerTLSSecretName is the name of the TLS secret for the dex-server
+	ArgoCDDexServerTLSSecretName = "argocd-dex-server-tls"
+
 	//ApplicationSetServiceNameSuffix is the suffix for Apllication Set Controller Service
 	ApplicationSetServiceNameSuffix = "applicationset-controller"
+
+	// ApplicationSetGitHubAppSecretSuffix is the suffix for the Secret holding the GitHub App credentials of the
+	// ApplicationSet SCM providers
+	ApplicationSetGitHubAppSecretSuffix = "applicationset-github-app"
 )
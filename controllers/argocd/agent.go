@@ -0,0 +1,687 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	tlsutil "github.com/operator-framework/operator-sdk/pkg/tls"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// agentPrincipalSuffix is the name suffix of the resources of the argocd-agent principal.
+	agentPrincipalSuffix = "agent-principal"
+
+	// agentSuffix is the name suffix of the resources of the argocd-agent agent.
+	agentSuffix = "agent"
+)
+
+// getAgentPrincipal returns the argocd-agent principal options of the given ArgoCD, or nil if not set.
+func getAgentPrincipal(cr *argoprojv1a1.ArgoCD) *argoprojv1a1.ArgoCDAgentPrincipalSpec {
+	if cr.Spec.ArgoCDAgent == nil {
+		return nil
+	}
+	return cr.Spec.ArgoCDAgent.Principal
+}
+
+// getAgentAgent returns the argocd-agent agent options of the given ArgoCD, or nil if not set.
+func getAgentAgent(cr *argoprojv1a1.ArgoCD) *argoprojv1a1.ArgoCDAgentAgentSpec {
+	if cr.Spec.ArgoCDAgent == nil {
+		return nil
+	}
+	return cr.Spec.ArgoCDAgent.Agent
+}
+
+// getArgoCDAgentContainerImage will return the argocd-agent container image for the given image and version, using
+// the ARGOCD_AGENT_IMAGE environment variable when neither is set, and the defaults otherwise.
+func getArgoCDAgentContainerImage(image, version string) string {
+	defaultImg, defaultTag := false, false
+	if image == "" {
+		image = common.ArgoCDDefaultAgentImage
+		defaultImg = true
+	}
+	if version == "" {
+		version = common.ArgoCDDefaultAgentVersion
+		defaultTag = true
+	}
+	if e := os.Getenv(common.ArgoCDAgentImageEnvName); e != "" && (defaultTag && defaultImg) {
+		return e
+	}
+	return argoutil.CombineImageTag(image, version)
+}
+
+// getAgentPrincipalTLSSecretName returns the name of the Secret holding the server certificate of the principal.
+func getAgentPrincipalTLSSecretName(cr *argoprojv1a1.ArgoCD) string {
+	return nameWithSuffix(agentPrincipalSuffix+"-tls", cr)
+}
+
+// getAgentPrincipalJWTSecretName returns the name of the Secret holding the key the principal signs its tokens with.
+func getAgentPrincipalJWTSecretName(cr *argoprojv1a1.ArgoCD) string {
+	return nameWithSuffix(agentPrincipalSuffix+"-jwt", cr)
+}
+
+// getAgentClientTLSSecretName returns the name of the Secret holding the client certificate of the agent with the
+// given name.
+func getAgentClientTLSSecretName(agent string, cr *argoprojv1a1.ArgoCD) string {
+	return nameWithSuffix(fmt.Sprintf("%s-%s-tls", agentSuffix, agent), cr)
+}
+
+// getAgentPrincipalDNSNames returns the DNS names of the server certificate of the principal.
+func getAgentPrincipalDNSNames(cr *argoprojv1a1.ArgoCD) []string {
+	name := nameWithSuffix(agentPrincipalSuffix, cr)
+	dnsNames := []string{
+		name,
+		fmt.Sprintf("%s.%s.svc", name, cr.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", name, cr.Namespace),
+	}
+	return append(dnsNames, getAgentPrincipal(cr).Hosts...)
+}
+
+// getAgentPrincipalCommand will return the command for the argocd-agent principal.
+func getAgentPrincipalCommand(cr *argoprojv1a1.ArgoCD) []string {
+	principal := getAgentPrincipal(cr)
+
+	auth := common.ArgoCDDefaultAgentPrincipalAuth
+	if principal.Auth != "" {
+		auth = principal.Auth
+	}
+
+	cmd := []string{
+		"argocd-agent",
+		"principal",
+		"--namespace", cr.Namespace,
+		"--listen-port", fmt.Sprint(common.ArgoCDDefaultAgentPrincipalPort),
+		"--tls-cert", "/app/config/tls/tls.crt",
+		"--tls-key", "/app/config/tls/tls.key",
+		"--root-ca-path", "/app/config/ca/ca.crt",
+		"--jwt-key", "/app/config/jwt/" + common.ArgoCDKeyAgentJWT,
+		"--auth", auth,
+		"--log-level", getLogLevel(principal.LogLevel),
+	}
+	if len(principal.AllowedNamespaces) > 0 {
+		cmd = append(cmd, "--allowed-namespaces", strings.Join(principal.AllowedNamespaces, ","))
+	}
+	return cmd
+}
+
+// getAgentAgentCommand will return the command for the argocd-agent agent.
+func getAgentAgentCommand(cr *argoprojv1a1.ArgoCD) []string {
+	agent := getAgentAgent(cr)
+
+	mode := common.ArgoCDDefaultAgentMode
+	if agent.Mode != "" {
+		mode = agent.Mode
+	}
+	port := int32(common.ArgoCDDefaultAgentPrincipalServicePort)
+	if agent.PrincipalPort > 0 {
+		port = agent.PrincipalPort
+	}
+
+	return []string{
+		"argocd-agent",
+		"agent",
+		"--namespace", cr.Namespace,
+		"--agent-mode", mode,
+		"--server-address", agent.PrincipalAddress,
+		"--server-port", fmt.Sprint(port),
+		"--root-ca-path", "/app/config/tls/ca.crt",
+		"--tls-client-cert", "/app/config/tls/tls.crt",
+		"--tls-client-key", "/app/config/tls/tls.key",
+		"--creds", "mtls:any",
+		"--log-level", getLogLevel(agent.LogLevel),
+	}
+}
+
+// getAgentPrincipalResources returns the resources created by the operator for the argocd-agent principal of the given
+// ArgoCD, except for the client certificates of the agents.
+func getAgentPrincipalResources(cr *argoprojv1a1.ArgoCD) []client.Object {
+	return []client.Object{
+		newDeploymentWithSuffix(agentPrincipalSuffix, agentPrincipalSuffix, cr),
+		newServiceWithSuffix(agentPrincipalSuffix, agentPrincipalSuffix, cr),
+		newRoleBindingWithname(common.ArgoCDAgentPrincipalComponent, cr),
+		newRole(common.ArgoCDAgentPrincipalComponent, nil, cr),
+		newServiceAccountWithName(common.ArgoCDAgentPrincipalComponent, cr),
+		argoutil.NewSecretWithName(cr, getAgentPrincipalTLSSecretName(cr)),
+		argoutil.NewSecretWithName(cr, getAgentPrincipalJWTSecretName(cr)),
+	}
+}
+
+// getAgentAgentResources returns the resources created by the operator for the argocd-agent agent of the given ArgoCD.
+func getAgentAgentResources(cr *argoprojv1a1.ArgoCD) []client.Object {
+	return []client.Object{
+		newDeploymentWithSuffix(agentSuffix, agentSuffix, cr),
+		newRoleBindingWithname(common.ArgoCDAgentAgentComponent, cr),
+		newRole(common.ArgoCDAgentAgentComponent, nil, cr),
+		newServiceAccountWithName(common.ArgoCDAgentAgentComponent, cr),
+	}
+}
+
+// reconcileArgoCDAgent will ensure that the argocd-agent principal and agent are deployed as requested by the given
+// ArgoCD, and that their resources are deleted otherwise.
+func (r *ReconcileArgoCD) reconcileArgoCDAgent(cr *argoprojv1a1.ArgoCD) error {
+	if getAgentPrincipal(cr).IsEnabled() {
+		if err := r.reconcileAgentPrincipal(cr); err != nil {
+			return err
+		}
+	} else {
+		if err := r.deleteControlledResources(cr, getAgentPrincipalResources(cr), "the argocd-agent principal is disabled"); err != nil {
+			return err
+		}
+		if err := r.reconcileAgentClientTLSSecrets(cr, nil, nil); err != nil {
+			return err
+		}
+	}
+
+	if getAgentAgent(cr).IsEnabled() {
+		return r.reconcileAgentAgent(cr)
+	}
+	return r.deleteControlledResources(cr, getAgentAgentResources(cr), "the argocd-agent agent is disabled")
+}
+
+// reconcileAgentPrincipal will ensure that the argocd-agent principal of the given ArgoCD is deployed, along with the
+// certificates of the principal and of the agents connecting to it.
+func (r *ReconcileArgoCD) reconcileAgentPrincipal(cr *argoprojv1a1.ArgoCD) error {
+	log.Info("reconciling argocd-agent principal rbac")
+	sa, err := r.reconcileAgentRBAC(common.ArgoCDAgentPrincipalComponent, cr)
+	if err != nil {
+		return err
+	}
+
+	caSecret, err := argoutil.FetchSecret(r.Client, cr.ObjectMeta, argoutil.NewSecretWithSuffix(cr, common.ArgoCDCASuffix).Name)
+	if err != nil {
+		return err
+	}
+	caCert, err := argoutil.ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return err
+	}
+	caKey, err := argoutil.ParsePEMEncodedPrivateKey(caSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return err
+	}
+
+	log.Info("reconciling argocd-agent principal secrets")
+	if err := r.reconcileAgentPrincipalTLSSecret(cr, caCert, caKey); err != nil {
+		return err
+	}
+	if err := r.reconcileAgentPrincipalJWTSecret(cr); err != nil {
+		return err
+	}
+	if err := r.reconcileAgentClientTLSSecrets(cr, caCert, caKey); err != nil {
+		return err
+	}
+
+	log.Info("reconciling argocd-agent principal service")
+	if err := r.reconcileAgentPrincipalService(cr); err != nil {
+		return err
+	}
+
+	log.Info("reconciling argocd-agent principal deployment")
+	return r.reconcileAgentPrincipalDeployment(cr, sa)
+}
+
+// reconcileAgentAgent will ensure that the argocd-agent agent of the given ArgoCD is deployed.
+func (r *ReconcileArgoCD) reconcileAgentAgent(cr *argoprojv1a1.ArgoCD) error {
+	log.Info("reconciling argocd-agent agent rbac")
+	sa, err := r.reconcileAgentRBAC(common.ArgoCDAgentAgentComponent, cr)
+	if err != nil {
+		return err
+	}
+
+	log.Info("reconciling argocd-agent agent deployment")
+	return r.reconcileAgentAgentDeployment(cr, sa)
+}
+
+// reconcileAgentRBAC will ensure that the ServiceAccount, Role and RoleBinding of the given argocd-agent component are
+// present, and returns the ServiceAccount.
+func (r *ReconcileArgoCD) reconcileAgentRBAC(component string, cr *argoprojv1a1.ArgoCD) (*corev1.ServiceAccount, error) {
+	sa := newServiceAccountWithName(component, cr)
+	if err := r.applyResource(cr, sa); err != nil {
+		return nil, err
+	}
+
+	role := newRole(component, policyRuleForArgoCDAgent(), cr)
+	if err := r.applyResource(cr, role); err != nil {
+		return nil, err
+	}
+
+	roleBinding := newRoleBindingWithname(component, cr)
+	roleBinding.RoleRef = rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "Role",
+		Name:     role.Name,
+	}
+	roleBinding.Subjects = []rbacv1.Subject{
+		{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      sa.Name,
+			Namespace: sa.Namespace,
+		},
+	}
+	if err := r.applyResource(cr, roleBinding); err != nil {
+		return nil, err
+	}
+
+	return sa, nil
+}
+
+// newAgentPrincipalCertificateSecret creates a new TLS secret for the argocd-agent principal signed by the given CA,
+// valid for the principal service and the additional hosts of the given ArgoCD.
+func newAgentPrincipalCertificateSecret(caCert *x509.Certificate, caKey *rsa.PrivateKey, cr *argoprojv1a1.ArgoCD) (*corev1.Secret, error) {
+	secret := argoutil.NewSecretWithName(cr, getAgentPrincipalTLSSecretName(cr))
+	secret.Type = corev1.SecretTypeTLS
+
+	key, err := argoutil.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &tlsutil.CertConfig{
+		CertName:     secret.Name,
+		CertType:     tlsutil.ServingCert,
+		CommonName:   nameWithSuffix(agentPrincipalSuffix, cr),
+		Organization: []string{cr.ObjectMeta.Namespace},
+	}
+
+	cert, err := argoutil.NewSignedCertificate(cfg, getAgentPrincipalDNSNames(cr), key, caCert, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:       argoutil.EncodeCertificatePEM(cert),
+		corev1.TLSPrivateKeyKey: argoutil.EncodePrivateKeyPEM(key),
+	}
+
+	return secret, nil
+}
+
+// newAgentClientCertificateSecret creates a new TLS secret holding the client certificate of the argocd-agent agent
+// with the given name, signed by the given CA, along with the CA certificate.
+func newAgentClientCertificateSecret(agent string, caCert *x509.Certificate, caKey *rsa.PrivateKey, cr *argoprojv1a1.ArgoCD) (*corev1.Secret, error) {
+	secret := argoutil.NewSecretWithName(cr, getAgentClientTLSSecretName(agent, cr))
+	secret.Type = corev1.SecretTypeTLS
+	secret.Labels[common.ArgoCDKeyAgentName] = agent
+
+	key, err := argoutil.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// the principal identifies the agent by the common name of its certificate
+	cfg := &tlsutil.CertConfig{
+		CertName:     secret.Name,
+		CertType:     tlsutil.ClientCert,
+		CommonName:   agent,
+		Organization: []string{cr.ObjectMeta.Namespace},
+	}
+
+	cert, err := argoutil.NewSignedCertificate(cfg, nil, key, caCert, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:              argoutil.EncodeCertificatePEM(cert),
+		corev1.TLSPrivateKeyKey:        argoutil.EncodePrivateKeyPEM(key),
+		corev1.ServiceAccountRootCAKey: argoutil.EncodeCertificatePEM(caCert),
+	}
+
+	return secret, nil
+}
+
+// reconcileAgentPrincipalTLSSecret ensures the server certificate of the argocd-agent principal is issued from the CA
+// of the given ArgoCD, and renews it before it expires or when its DNS names change. Secrets not created by the
+// operator are left untouched.
+func (r *ReconcileArgoCD) reconcileAgentPrincipalTLSSecret(cr *argoprojv1a1.ArgoCD, caCert *x509.Certificate, caKey *rsa.PrivateKey) error {
+	existing := &corev1.Secret{}
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, getAgentPrincipalTLSSecretName(cr), existing) {
+		if !metav1.IsControlledBy(existing, cr) {
+			log.Info(fmt.Sprintf("secret %s is not managed by the operator, skipping", existing.Name))
+			return nil
+		}
+		if !certificateNeedsRenewal(existing, caCert, getTLSRenewBefore(cr)) && !agentPrincipalDNSNamesChanged(existing, cr) {
+			return nil
+		}
+
+		secret, err := newAgentPrincipalCertificateSecret(caCert, caKey, cr)
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("renewing certificate in secret %s", existing.Name))
+		existing.Data = secret.Data
+		return r.Client.Update(context.TODO(), existing)
+	}
+
+	secret, err := newAgentPrincipalCertificateSecret(caCert, caKey, cr)
+	if err != nil {
+		return err
+	}
+
+	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("creating secret %s", secret.Name))
+	return r.Client.Create(context.TODO(), secret)
+}
+
+// agentPrincipalDNSNamesChanged returns true if the certificate in the given secret is not valid for the DNS names of
+// the argocd-agent principal of the given ArgoCD.
+func agentPrincipalDNSNamesChanged(secret *corev1.Secret, cr *argoprojv1a1.ArgoCD) bool {
+	cert, err := argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return true
+	}
+	return !reflect.DeepEqual(cert.DNSNames, getAgentPrincipalDNSNames(cr))
+}
+
+// reconcileAgentPrincipalJWTSecret will ensure that the Secret holding the key the argocd-agent principal signs its
+// tokens with is present. The key is generated once, and is never modified.
+func (r *ReconcileArgoCD) reconcileAgentPrincipalJWTSecret(cr *argoprojv1a1.ArgoCD) error {
+	secret := argoutil.NewSecretWithName(cr, getAgentPrincipalJWTSecretName(cr))
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+		return nil // Secret found, do nothing
+	}
+
+	key, err := argoutil.NewPrivateKey()
+	if err != nil {
+		return err
+	}
+	secret.Data = map[string][]byte{
+		common.ArgoCDKeyAgentJWT: argoutil.EncodePrivateKeyPEM(key),
+	}
+
+	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("creating secret %s", secret.Name))
+	return r.Client.Create(context.TODO(), secret)
+}
+
+// reconcileAgentClientTLSSecrets will ensure that a client certificate issued from the given CA is present for each
+// agent listed for the argocd-agent principal of the given ArgoCD, and renews them before they expire. The client
+// certificates of agents that are no longer listed are deleted.
+func (r *ReconcileArgoCD) reconcileAgentClientTLSSecrets(cr *argoprojv1a1.ArgoCD, caCert *x509.Certificate, caKey *rsa.PrivateKey) error {
+	agents := map[string]bool{}
+	if principal := getAgentPrincipal(cr); principal.IsEnabled() {
+		for _, agent := range principal.Agents {
+			agents[agent] = true
+		}
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := r.Client.List(context.TODO(), secrets, client.InNamespace(cr.Namespace), client.HasLabels{common.ArgoCDKeyAgentName}); err != nil {
+		return err
+	}
+
+	existing := map[string]*corev1.Secret{}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if !metav1.IsControlledBy(secret, cr) {
+			continue
+		}
+		agent := secret.Labels[common.ArgoCDKeyAgentName]
+		if agents[agent] && secret.Name == getAgentClientTLSSecretName(agent, cr) {
+			existing[agent] = secret
+			continue
+		}
+		log.Info(fmt.Sprintf("deleting secret %s as agent %s is no longer listed", secret.Name, agent))
+		if err := r.Client.Delete(context.TODO(), secret); err != nil {
+			return err
+		}
+	}
+
+	for agent := range agents {
+		secret, err := newAgentClientCertificateSecret(agent, caCert, caKey, cr)
+		if err != nil {
+			return err
+		}
+
+		if found, ok := existing[agent]; ok {
+			if !certificateNeedsRenewal(found, caCert, getTLSRenewBefore(cr)) {
+				continue
+			}
+			log.Info(fmt.Sprintf("renewing certificate in secret %s", found.Name))
+			found.Data = secret.Data
+			if err := r.Client.Update(context.TODO(), found); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, &corev1.Secret{}) {
+			log.Info(fmt.Sprintf("secret %s is not managed by the operator, skipping", secret.Name))
+			continue
+		}
+		if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("creating secret %s", secret.Name))
+		if err := r.Client.Create(context.TODO(), secret); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileAgentPrincipalService will ensure that the Service exposing the gRPC endpoint of the argocd-agent principal
+// is present.
+func (r *ReconcileArgoCD) reconcileAgentPrincipalService(cr *argoprojv1a1.ArgoCD) error {
+	svc := newServiceWithSuffix(agentPrincipalSuffix, agentPrincipalSuffix, cr)
+
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	if serviceType := getAgentPrincipal(cr).ServiceType; serviceType != "" {
+		svc.Spec.Type = serviceType
+	}
+	svc.Spec.Selector = map[string]string{
+		common.ArgoCDKeyName: nameWithSuffix(agentPrincipalSuffix, cr),
+	}
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "grpc",
+			Port:       common.ArgoCDDefaultAgentPrincipalServicePort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultAgentPrincipalPort),
+		},
+	}
+
+	return r.applyResource(cr, svc)
+}
+
+// agentContainerSecurityContext returns the security context of the argocd-agent containers.
+func agentContainerSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{
+				"ALL",
+			},
+		},
+		AllowPrivilegeEscalation: boolPtr(false),
+		ReadOnlyRootFilesystem:   boolPtr(true),
+		RunAsNonRoot:             boolPtr(true),
+	}
+}
+
+// setAgentTLSChecksum will annotate the pod template of the given Deployment with the checksum of the certificate in
+// the TLS Secret with the given name, so that the Deployment is rolled out when the certificate is renewed.
+func (r *ReconcileArgoCD) setAgentTLSChecksum(deploy *appsv1.Deployment, name string) error {
+	sha256sum, err := r.getTLSSecretChecksum(deploy.Namespace, name)
+	if err != nil {
+		return err
+	}
+	if sha256sum != "" {
+		deploy.Spec.Template.Annotations = map[string]string{
+			common.ArgoCDKeyTLSChecksum: sha256sum,
+		}
+	}
+	return nil
+}
+
+// reconcileAgentPrincipalDeployment will ensure the Deployment resource is present for the argocd-agent principal.
+func (r *ReconcileArgoCD) reconcileAgentPrincipalDeployment(cr *argoprojv1a1.ArgoCD, sa *corev1.ServiceAccount) error {
+	principal := getAgentPrincipal(cr)
+	deploy := newDeploymentWithSuffix(agentPrincipalSuffix, agentPrincipalSuffix, cr)
+
+	if err := r.setAgentTLSChecksum(deploy, getAgentPrincipalTLSSecretName(cr)); err != nil {
+		return err
+	}
+
+	podSpec := &deploy.Spec.Template.Spec
+	podSpec.ServiceAccountName = sa.Name
+	podSpec.Volumes = []corev1.Volume{
+		{
+			Name: "tls",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: getAgentPrincipalTLSSecretName(cr),
+				},
+			},
+		},
+		{
+			// only the CA certificate is mounted, the key of the CA stays with the operator
+			Name: "ca",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: argoutil.NewSecretWithSuffix(cr, common.ArgoCDCASuffix).Name,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  corev1.ServiceAccountRootCAKey,
+							Path: corev1.ServiceAccountRootCAKey,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "jwt",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: getAgentPrincipalJWTSecretName(cr),
+				},
+			},
+		},
+	}
+
+	podSpec.Containers = []corev1.Container{{
+		Command:         getAgentPrincipalCommand(cr),
+		Env:             proxyEnvVars(),
+		Image:           getArgoCDAgentContainerImage(principal.Image, principal.Version),
+		ImagePullPolicy: corev1.PullAlways,
+		Name:            common.ArgoCDAgentPrincipalComponent,
+		Ports: []corev1.ContainerPort{
+			{
+				ContainerPort: common.ArgoCDDefaultAgentPrincipalPort,
+				Name:          "grpc",
+			},
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{
+					Port: intstr.FromInt(common.ArgoCDDefaultAgentPrincipalPort),
+				},
+			},
+		},
+		Resources:       getAgentResources(common.ArgoCDAgentPrincipalComponent, principal.Resources),
+		SecurityContext: agentContainerSecurityContext(),
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "tls",
+				MountPath: "/app/config/tls",
+			},
+			{
+				Name:      "ca",
+				MountPath: "/app/config/ca",
+			},
+			{
+				Name:      "jwt",
+				MountPath: "/app/config/jwt",
+			},
+		},
+	}}
+	AddSeccompProfileForOpenShift(r.Client, podSpec)
+
+	return r.applyResource(cr, deploy)
+}
+
+// reconcileAgentAgentDeployment will ensure the Deployment resource is present for the argocd-agent agent.
+func (r *ReconcileArgoCD) reconcileAgentAgentDeployment(cr *argoprojv1a1.ArgoCD, sa *corev1.ServiceAccount) error {
+	agent := getAgentAgent(cr)
+	deploy := newDeploymentWithSuffix(agentSuffix, agentSuffix, cr)
+
+	if err := r.setAgentTLSChecksum(deploy, agent.ClientTLSSecretName); err != nil {
+		return err
+	}
+
+	podSpec := &deploy.Spec.Template.Spec
+	podSpec.ServiceAccountName = sa.Name
+	podSpec.Volumes = []corev1.Volume{
+		{
+			Name: "tls",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: agent.ClientTLSSecretName,
+				},
+			},
+		},
+	}
+
+	podSpec.Containers = []corev1.Container{{
+		Command:         getAgentAgentCommand(cr),
+		Env:             proxyEnvVars(),
+		Image:           getArgoCDAgentContainerImage(agent.Image, agent.Version),
+		ImagePullPolicy: corev1.PullAlways,
+		Name:            common.ArgoCDAgentAgentComponent,
+		Resources:       getAgentResources(common.ArgoCDAgentAgentComponent, agent.Resources),
+		SecurityContext: agentContainerSecurityContext(),
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "tls",
+				MountPath: "/app/config/tls",
+			},
+		},
+	}}
+	AddSeccompProfileForOpenShift(r.Client, podSpec)
+
+	return r.applyResource(cr, deploy)
+}
+
+// getAgentResources will return the given compute resources of the argocd-agent container of the given component, if
+// set, or the resources of the component in the sizing profile of the operator.
+func getAgentResources(component string, resources *corev1.ResourceRequirements) corev1.ResourceRequirements {
+	if resources != nil {
+		return *resources
+	}
+	return getDefaultResources(component)
+}
@@ -0,0 +1,143 @@
+package argocd
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func TestReconcileArgoCD_reconcileArgoCDAgent_principal(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.UID = "argocd-uid"
+		a.Spec.ArgoCDAgent = &argoprojv1alpha1.ArgoCDAgentSpec{
+			Principal: &argoprojv1alpha1.ArgoCDAgentPrincipalSpec{
+				Enabled:           true,
+				AllowedNamespaces: []string{"agent-*"},
+				ServiceType:       corev1.ServiceTypeLoadBalancer,
+				Hosts:             []string{"principal.example.com"},
+				Agents:            []string{"cluster-1", "cluster-2"},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileClusterCASecret(a))
+
+	assert.NoError(t, r.reconcileArgoCDAgent(a))
+
+	deploy := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-agent-principal", Namespace: a.Namespace}, deploy))
+	container := deploy.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, argoutil.CombineImageTag(common.ArgoCDDefaultAgentImage, common.ArgoCDDefaultAgentVersion), container.Image)
+	assert.Contains(t, container.Command, "--allowed-namespaces")
+	assert.Contains(t, container.Command, "agent-*")
+	assert.Contains(t, container.Command, common.ArgoCDDefaultAgentPrincipalAuth)
+	assert.Equal(t, "argocd-argocd-agent-principal", deploy.Spec.Template.Spec.ServiceAccountName)
+	assert.NotEmpty(t, deploy.Spec.Template.Annotations[common.ArgoCDKeyTLSChecksum])
+
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-agent-principal", Namespace: a.Namespace}, svc))
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, svc.Spec.Type)
+	assert.Equal(t, int32(common.ArgoCDDefaultAgentPrincipalServicePort), svc.Spec.Ports[0].Port)
+
+	roleBinding := &rbacv1.RoleBinding{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-argocd-agent-principal", Namespace: a.Namespace}, roleBinding))
+	assert.Equal(t, "argocd-argocd-agent-principal", roleBinding.RoleRef.Name)
+
+	// the principal certificate is valid for its service and the additional hosts
+	tls := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-agent-principal-tls", Namespace: a.Namespace}, tls))
+	cert, err := argoutil.ParsePEMEncodedCert(tls.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.Contains(t, cert.DNSNames, "argocd-agent-principal.argocd.svc.cluster.local")
+	assert.Contains(t, cert.DNSNames, "principal.example.com")
+
+	jwt := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-agent-principal-jwt", Namespace: a.Namespace}, jwt))
+	assert.NotEmpty(t, jwt.Data[common.ArgoCDKeyAgentJWT])
+
+	// each agent gets a client certificate identifying it by its common name
+	client := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-agent-cluster-1-tls", Namespace: a.Namespace}, client))
+	clientCert, err := argoutil.ParsePEMEncodedCert(client.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.Equal(t, "cluster-1", clientCert.Subject.CommonName)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, clientCert.ExtKeyUsage)
+	assert.NotEmpty(t, client.Data[corev1.ServiceAccountRootCAKey])
+
+	// the principal certificate is renewed when a host is added, and the client certificate of a removed agent is
+	// deleted
+	a.Spec.ArgoCDAgent.Principal.Hosts = append(a.Spec.ArgoCDAgent.Principal.Hosts, "principal.internal.example.com")
+	a.Spec.ArgoCDAgent.Principal.Agents = []string{"cluster-1"}
+	assert.NoError(t, r.reconcileArgoCDAgent(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: tls.Name, Namespace: a.Namespace}, tls))
+	cert, err = argoutil.ParsePEMEncodedCert(tls.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.Contains(t, cert.DNSNames, "principal.internal.example.com")
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-agent-cluster-2-tls", Namespace: a.Namespace}, &corev1.Secret{}))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: client.Name, Namespace: a.Namespace}, &corev1.Secret{}))
+
+	// the resources of the principal are deleted once it is disabled
+	a.Spec.ArgoCDAgent.Principal.Enabled = false
+	assert.NoError(t, r.reconcileArgoCDAgent(a))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: deploy.Name, Namespace: a.Namespace}, &appsv1.Deployment{}))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: svc.Name, Namespace: a.Namespace}, &corev1.Service{}))
+	for _, name := range []string{tls.Name, jwt.Name, client.Name} {
+		assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, &corev1.Secret{}))
+	}
+}
+
+func TestReconcileArgoCD_reconcileArgoCDAgent_agent(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.UID = "argocd-uid"
+		a.Spec.ArgoCDAgent = &argoprojv1alpha1.ArgoCDAgentSpec{
+			Agent: &argoprojv1alpha1.ArgoCDAgentAgentSpec{
+				Enabled:             true,
+				Mode:                "autonomous",
+				PrincipalAddress:    "principal.example.com",
+				ClientTLSSecretName: "agent-client-tls",
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileArgoCDAgent(a))
+
+	deploy := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-agent", Namespace: a.Namespace}, deploy))
+	container := deploy.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, []string{
+		"argocd-agent",
+		"agent",
+		"--namespace", a.Namespace,
+		"--agent-mode", "autonomous",
+		"--server-address", "principal.example.com",
+		"--server-port", "443",
+		"--root-ca-path", "/app/config/tls/ca.crt",
+		"--tls-client-cert", "/app/config/tls/tls.crt",
+		"--tls-client-key", "/app/config/tls/tls.key",
+		"--creds", "mtls:any",
+		"--log-level", "info",
+	}, container.Command)
+	assert.Equal(t, "agent-client-tls", deploy.Spec.Template.Spec.Volumes[0].Secret.SecretName)
+
+	// the principal is not deployed along with the agent
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-agent-principal", Namespace: a.Namespace}, &appsv1.Deployment{}))
+
+	a.Spec.ArgoCDAgent.Agent.Enabled = false
+	assert.NoError(t, r.reconcileArgoCDAgent(a))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: deploy.Name, Namespace: a.Namespace}, &appsv1.Deployment{}))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-argocd-agent-agent", Namespace: a.Namespace}, &corev1.ServiceAccount{}))
+}
@@ -33,6 +33,100 @@ import (
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
+// getArgoCDApplicationSetReplicas will return the size value for the ApplicationSet controller replica count if it
+// has been set in argocd CR. Otherwise, nil is returned if the replicas is not set in the argocd CR or
+// replicas value is < 0.
+func getArgoCDApplicationSetReplicas(cr *argoprojv1a1.ArgoCD) *int32 {
+	if cr.Spec.ApplicationSet.Replicas != nil && *cr.Spec.ApplicationSet.Replicas >= 0 {
+		return cr.Spec.ApplicationSet.Replicas
+	}
+
+	return nil
+}
+
+// getArgoApplicationSetMetricsPort will return the metrics port for the ApplicationSet controller.
+func getArgoApplicationSetMetricsPort(cr *argoprojv1a1.ArgoCD) int32 {
+	port := int32(common.ArgoCDDefaultApplicationSetMetricsPort)
+	if cr.Spec.ApplicationSet.Metrics.Port > 0 {
+		port = cr.Spec.ApplicationSet.Metrics.Port
+	}
+	return port
+}
+
+// applicationSetSCMProviderTokenEnvNames maps the supported SCM providers to the environment variable the
+// ApplicationSet controller reads the token of the provider from.
+var applicationSetSCMProviderTokenEnvNames = map[string]string{
+	"github": "GITHUB_TOKEN",
+	"gitlab": "GITLAB_TOKEN",
+	"gitea":  "GITEA_TOKEN",
+}
+
+// getApplicationSetSCMProviderEnv will return the environment variables exposing the SCM provider tokens referenced
+// in the CR to the ApplicationSet controller, which falls back to them when a generator does not set a tokenRef.
+func getApplicationSetSCMProviderEnv(cr *argoprojv1a1.ArgoCD) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0)
+	for _, scm := range cr.Spec.ApplicationSet.SCMProviders {
+		name, ok := applicationSetSCMProviderTokenEnvNames[scm.Provider]
+		if !ok {
+			log.Info(fmt.Sprintf("unsupported SCM provider %s for the applicationset controller, skipping", scm.Provider))
+			continue
+		}
+		if scm.TokenSecretRef == nil {
+			continue
+		}
+		secretRef := *scm.TokenSecretRef
+		env = append(env, corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &secretRef,
+			},
+		})
+	}
+	return env
+}
+
+// getApplicationSetGitHubApp will return the GitHub App credentials of the first github SCM provider in the CR that
+// sets them, or nil if there are none.
+func getApplicationSetGitHubApp(cr *argoprojv1a1.ArgoCD) *argoprojv1a1.ArgoCDGitHubAppCredentialsSpec {
+	if cr.Spec.ApplicationSet == nil {
+		return nil
+	}
+	for _, scm := range cr.Spec.ApplicationSet.SCMProviders {
+		if scm.Provider == "github" && scm.GitHubApp != nil {
+			return scm.GitHubApp
+		}
+	}
+	return nil
+}
+
+// reconcileApplicationSetGitHubAppSecret will ensure that the Secret holding the GitHub App credentials of the github
+// SCM provider is present and up to date, and that it is removed once the credentials are no longer set. The Secret
+// uses the repo-creds format expected from the appSecretName of the SCM Provider and Pull Request generators, but is
+// not labelled as a credential template, as it is not tied to a repository URL.
+func (r *ReconcileArgoCD) reconcileApplicationSetGitHubAppSecret(cr *argoprojv1a1.ArgoCD) error {
+	secret := argoutil.NewSecretWithSuffix(cr, common.ApplicationSetGitHubAppSecretSuffix)
+
+	app := getApplicationSetGitHubApp(cr)
+	if app == nil {
+		existing := &corev1.Secret{}
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, existing) && metav1.IsControlledBy(existing, cr) {
+			log.Info(fmt.Sprintf("Deleting applicationset github app secret %s as no github app is configured", existing.Name))
+			return r.Client.Delete(context.TODO(), existing)
+		}
+		return nil
+	}
+
+	credentials := make(map[string][]byte)
+	refs := map[string]corev1.SecretKeySelector{"githubAppPrivateKey": app.PrivateKeySecretRef}
+	if err := r.addInitialSecretKeyRefs(refs, credentials, cr); err != nil {
+		log.Info(fmt.Sprintf("private key for the applicationset github app not found, skipping: %v", err))
+		return nil
+	}
+	addGitHubAppSecretData(app, credentials)
+	secret.Data = credentials
+	return r.reconcileInitialSecret(secret, cr)
+}
+
 // getArgoApplicationSetCommand will return the command for the ArgoCD ApplicationSet component.
 func getArgoApplicationSetCommand(cr *argoprojv1a1.ArgoCD) []string {
 	cmd := make([]string, 0)
@@ -46,6 +140,19 @@ func getArgoApplicationSetCommand(cr *argoprojv1a1.ArgoCD) []string {
 	cmd = append(cmd, "--loglevel")
 	cmd = append(cmd, getLogLevel(cr.Spec.ApplicationSet.LogLevel))
 
+	// only one replica reconciles ApplicationSets at a time, the others are on standby
+	if replicas := getArgoCDApplicationSetReplicas(cr); replicas != nil && *replicas > 1 {
+		cmd = append(cmd, "--enable-leader-election")
+	}
+
+	if cr.Spec.ApplicationSet.EnableProgressiveSyncs {
+		cmd = append(cmd, "--enable-progressive-syncs")
+	}
+
+	if port := getArgoApplicationSetMetricsPort(cr); port != common.ArgoCDDefaultApplicationSetMetricsPort {
+		cmd = append(cmd, "--metrics-addr", fmt.Sprintf(":%d", port))
+	}
+
 	// ApplicationSet command arguments provided by the user
 	extraArgs := cr.Spec.ApplicationSet.ExtraCommandArgs
 	err := isMergable(extraArgs, cmd)
@@ -77,6 +184,11 @@ func (r *ReconcileArgoCD) reconcileApplicationSetController(cr *argoprojv1a1.Arg
 		return err
 	}
 
+	log.Info("reconciling applicationset github app secret")
+	if err := r.reconcileApplicationSetGitHubAppSecret(cr); err != nil {
+		return err
+	}
+
 	log.Info("reconciling applicationset deployments")
 	if err := r.reconcileApplicationSetDeployment(cr, sa); err != nil {
 		return err
@@ -145,44 +257,19 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoprojv1a1.Arg
 		},
 	}
 
+	if replicas := getArgoCDApplicationSetReplicas(cr); replicas != nil {
+		deploy.Spec.Replicas = replicas
+	}
+
 	podSpec.Containers = []corev1.Container{
 		applicationSetContainer(cr),
 	}
+	podSpec.HostAliases = cr.Spec.ApplicationSet.HostAliases
+	podSpec.DNSPolicy = cr.Spec.ApplicationSet.DNSPolicy
+	podSpec.DNSConfig = cr.Spec.ApplicationSet.DNSConfig
 	AddSeccompProfileForOpenShift(r.Client, podSpec)
 
-	if existing := newDeploymentWithSuffix("applicationset-controller", "controller", cr); argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-
-		existingSpec := existing.Spec.Template.Spec
-
-		deploymentsDifferent := !reflect.DeepEqual(existingSpec.Containers[0], podSpec.Containers) ||
-			!reflect.DeepEqual(existingSpec.Volumes, podSpec.Volumes) ||
-			existingSpec.ServiceAccountName != podSpec.ServiceAccountName ||
-			!reflect.DeepEqual(existing.Labels, deploy.Labels) ||
-			!reflect.DeepEqual(existing.Spec.Template.Labels, deploy.Spec.Template.Labels) ||
-			!reflect.DeepEqual(existing.Spec.Selector, deploy.Spec.Selector) ||
-			!reflect.DeepEqual(existing.Spec.Template.Spec.NodeSelector, deploy.Spec.Template.Spec.NodeSelector) ||
-			!reflect.DeepEqual(existing.Spec.Template.Spec.Tolerations, deploy.Spec.Template.Spec.Tolerations)
-
-		// If the Deployment already exists, make sure the values we care about are up-to-date
-		if deploymentsDifferent {
-			existing.Spec.Template.Spec.Containers = podSpec.Containers
-			existing.Spec.Template.Spec.Volumes = podSpec.Volumes
-			existing.Spec.Template.Spec.ServiceAccountName = podSpec.ServiceAccountName
-			existing.Labels = deploy.Labels
-			existing.Spec.Template.Labels = deploy.Spec.Template.Labels
-			existing.Spec.Selector = deploy.Spec.Selector
-			existing.Spec.Template.Spec.NodeSelector = deploy.Spec.Template.Spec.NodeSelector
-			existing.Spec.Template.Spec.Tolerations = deploy.Spec.Template.Spec.Tolerations
-			return r.Client.Update(context.TODO(), existing)
-		}
-		return nil // Deployment found with nothing to do, move along...
-	}
-
-	if err := controllerutil.SetControllerReference(cr, deploy, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), deploy)
-
+	return r.applyResource(cr, deploy)
 }
 
 func applicationSetContainer(cr *argoprojv1a1.ArgoCD) corev1.Container {
@@ -199,6 +286,8 @@ func applicationSetContainer(cr *argoprojv1a1.ArgoCD) corev1.Container {
 	// Merge ApplicationSet env vars provided by the user
 	// User should be able to override the default NAMESPACE environmental variable
 	appSetEnv = argoutil.EnvMerge(cr.Spec.ApplicationSet.Env, appSetEnv, true)
+	// Expose the SCM provider tokens referenced in the CR, unless the user set the env vars explicitly
+	appSetEnv = argoutil.EnvMerge(appSetEnv, getApplicationSetSCMProviderEnv(cr), false)
 	// Environment specified in the CR take precedence over everything else
 	appSetEnv = argoutil.EnvMerge(appSetEnv, proxyEnvVars(), false)
 
@@ -237,7 +326,7 @@ func applicationSetContainer(cr *argoprojv1a1.ArgoCD) corev1.Container {
 				Name:          "webhook",
 			},
 			{
-				ContainerPort: 8080,
+				ContainerPort: getArgoApplicationSetMetricsPort(cr),
 				Name:          "metrics",
 			},
 		},
@@ -362,6 +451,19 @@ func (r *ReconcileArgoCD) reconcileApplicationSetRole(cr *argoprojv1a1.ArgoCD) (
 				"watch",
 			},
 		},
+
+		// Leader election
+		{
+			APIGroups: []string{"coordination.k8s.io"},
+			Resources: []string{
+				"leases",
+			},
+			Verbs: []string{
+				"create",
+				"get",
+				"update",
+			},
+		},
 	}
 
 	role := newRole("applicationset-controller", policyRules, cr)
@@ -459,7 +561,7 @@ func getApplicationSetContainerImage(cr *argoprojv1a1.ArgoCD) string {
 
 // getApplicationSetResources will return the ResourceRequirements for the Application Sets container.
 func getApplicationSetResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
-	resources := corev1.ResourceRequirements{}
+	resources := getDefaultResources(sizingApplicationSetComponent)
 
 	// Allow override of resource requirements from CR
 	if cr.Spec.ApplicationSet.Resources != nil {
@@ -475,6 +577,24 @@ func setAppSetLabels(obj *metav1.ObjectMeta) {
 	obj.Labels["app.kubernetes.io/component"] = "controller"
 }
 
+// getApplicationSetServicePorts will return the ports of the Service for the ApplicationSet webhook and metrics.
+func getApplicationSetServicePorts(cr *argoprojv1a1.ArgoCD) []corev1.ServicePort {
+	port := getArgoApplicationSetMetricsPort(cr)
+	return []corev1.ServicePort{
+		{
+			Name:       "webhook",
+			Port:       7000,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(7000),
+		}, {
+			Name:       "metrics",
+			Port:       port,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(int(port)),
+		},
+	}
+}
+
 // reconcileApplicationSetService will ensure that the Service is present for the ApplicationSet webhook and metrics component.
 func (r *ReconcileArgoCD) reconcileApplicationSetService(cr *argoprojv1a1.ArgoCD) error {
 	log.Info("reconciling applicationset service")
@@ -493,24 +613,19 @@ func (r *ReconcileArgoCD) reconcileApplicationSetService(cr *argoprojv1a1.ArgoCD
 				return err
 			}
 		}
-	} else {
-		if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-			return nil // Service found, do nothing
-		}
+		return nil
 	}
-	svc.Spec.Ports = []corev1.ServicePort{
-		{
-			Name:       "webhook",
-			Port:       7000,
-			Protocol:   corev1.ProtocolTCP,
-			TargetPort: intstr.FromInt(7000),
-		}, {
-			Name:       "metrics",
-			Port:       8080,
-			Protocol:   corev1.ProtocolTCP,
-			TargetPort: intstr.FromInt(8080),
-		},
+
+	ports := getApplicationSetServicePorts(cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
+		if reflect.DeepEqual(svc.Spec.Ports, ports) {
+			return r.updateServiceNetworking(svc, cr.Spec.ApplicationSet.Service, false)
+		}
+		svc.Spec.Ports = ports
+		return r.updateServiceNetworking(svc, cr.Spec.ApplicationSet.Service, true)
 	}
+	setServiceNetworking(svc, cr.Spec.ApplicationSet.Service)
+	svc.Spec.Ports = ports
 
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix(common.ApplicationSetServiceNameSuffix, cr),
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
@@ -265,6 +266,98 @@ func TestReconcileApplicationSet_UpdateExistingDeployments(t *testing.T) {
 
 }
 
+func TestReconcileApplicationSet_UpdateExistingDeployments_envAndArgs(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &v1alpha1.ArgoCDApplicationSet{}
+
+	r := makeTestReconciler(t, a)
+	sa := corev1.ServiceAccount{}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	resourceVersion := deployment.ResourceVersion
+
+	// reconciling an unchanged CR does not update the Deployment
+	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, resourceVersion, deployment.ResourceVersion)
+
+	a.Spec.ApplicationSet.ExtraCommandArgs = []string{"--enable-progressive-syncs", "--policy", "create-only"}
+	a.Spec.ApplicationSet.Env = []corev1.EnvVar{{Name: "ARGOCD_APPLICATIONSET_CONTROLLER_REQUEUE_AFTER_SECONDS", Value: "60"}}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Subset(t, container.Command, []string{"--enable-progressive-syncs", "--policy", "create-only"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "ARGOCD_APPLICATIONSET_CONTROLLER_REQUEUE_AFTER_SECONDS", Value: "60"})
+}
+
+func TestReconcileApplicationSet_Deployments_replicas(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &v1alpha1.ArgoCDApplicationSet{}
+
+	r := makeTestReconciler(t, a)
+	sa := corev1.ServiceAccount{}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Nil(t, deployment.Spec.Replicas)
+	assert.NotContains(t, deployment.Spec.Template.Spec.Containers[0].Command, "--enable-leader-election")
+
+	replicas := int32(3)
+	a.Spec.ApplicationSet.Replicas = &replicas
+	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Command, "--enable-leader-election")
+
+	replicas = int32(1)
+	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+	assert.NotContains(t, deployment.Spec.Template.Spec.Containers[0].Command, "--enable-leader-election")
+}
+
+func TestReconcileApplicationSet_Deployments_hostAliasesAndDNS(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &v1alpha1.ArgoCDApplicationSet{
+		HostAliases: []corev1.HostAlias{{IP: "10.0.0.10", Hostnames: []string{"git.example.local"}}},
+		DNSPolicy:   corev1.DNSNone,
+		DNSConfig: &corev1.PodDNSConfig{
+			Nameservers: []string{"10.0.0.2"},
+			Searches:    []string{"example.local"},
+		},
+	}
+
+	r := makeTestReconciler(t, a)
+	sa := corev1.ServiceAccount{}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, a.Spec.ApplicationSet.HostAliases, deployment.Spec.Template.Spec.HostAliases)
+	assert.Equal(t, corev1.DNSNone, deployment.Spec.Template.Spec.DNSPolicy)
+	assert.Equal(t, a.Spec.ApplicationSet.DNSConfig, deployment.Spec.Template.Spec.DNSConfig)
+
+	a.Spec.ApplicationSet = &v1alpha1.ArgoCDApplicationSet{}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Nil(t, deployment.Spec.Template.Spec.HostAliases)
+	assert.Empty(t, deployment.Spec.Template.Spec.DNSPolicy)
+	assert.Nil(t, deployment.Spec.Template.Spec.DNSConfig)
+}
+
 func TestReconcileApplicationSet_Deployments_resourceRequirements(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCDWithResources()
@@ -432,6 +525,7 @@ func TestReconcileApplicationSet_Role(t *testing.T) {
 		"applicationsets",
 		"appprojects",
 		"applicationsets/finalizers",
+		"leases",
 	}
 
 	foundResources := []string{}
@@ -490,12 +584,45 @@ func setProxyEnvVars(t *testing.T) {
 func TestReconcileApplicationSet_Service(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &v1alpha1.ArgoCDApplicationSet{}
 	r := makeTestReconciler(t, a)
 
 	s := newServiceWithSuffix(common.ApplicationSetServiceNameSuffix, common.ApplicationSetServiceNameSuffix, a)
 
 	assert.NoError(t, r.reconcileApplicationSetService(a))
 	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, s))
+
+	// the Service is removed once the ApplicationSet controller is disabled
+	a.Spec.ApplicationSet = nil
+	assert.NoError(t, r.reconcileApplicationSetService(a))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, s))
+}
+
+func TestReconcileApplicationSet_Service_metricsPort(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &v1alpha1.ArgoCDApplicationSet{}
+	r := makeTestReconciler(t, a)
+
+	s := newServiceWithSuffix(common.ApplicationSetServiceNameSuffix, common.ApplicationSetServiceNameSuffix, a)
+	assert.NoError(t, r.reconcileApplicationSetService(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, s))
+	assert.Equal(t, int32(8080), s.Spec.Ports[1].Port)
+
+	// the metrics port is exposed on the container and the existing Service
+	a.Spec.ApplicationSet.Metrics.Port = 9090
+	assert.NoError(t, r.reconcileApplicationSetService(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, s))
+	assert.Equal(t, corev1.ServicePort{
+		Name:       "metrics",
+		Port:       9090,
+		Protocol:   corev1.ProtocolTCP,
+		TargetPort: intstr.FromInt(9090),
+	}, s.Spec.Ports[1])
+
+	container := applicationSetContainer(a)
+	assert.Equal(t, int32(9090), container.Ports[1].ContainerPort)
+	assert.Equal(t, []string{":9090"}, container.Command[len(container.Command)-1:])
 }
 
 func TestArgoCDApplicationSetCommand(t *testing.T) {
@@ -583,6 +710,21 @@ func TestArgoCDApplicationSetCommand(t *testing.T) {
 		deployment))
 
 	assert.Equal(t, baseCommand, deployment.Spec.Template.Spec.Containers[0].Command)
+
+	// When progressive syncs are enabled
+	a.Spec.ApplicationSet.EnableProgressiveSyncs = true
+
+	assert.NoError(t, r.reconcileApplicationSetController(a))
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+
+	cmd = append(append([]string{}, baseCommand...), "--enable-progressive-syncs")
+	assert.Equal(t, cmd, deployment.Spec.Template.Spec.Containers[0].Command)
 }
 
 func TestArgoCDApplicationSetEnv(t *testing.T) {
@@ -639,3 +781,128 @@ func TestArgoCDApplicationSetEnv(t *testing.T) {
 
 	assert.Equal(t, defaultEnv, deployment.Spec.Template.Spec.Containers[0].Env)
 }
+
+func TestArgoCDApplicationSetSCMProviderEnv(t *testing.T) {
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &v1alpha1.ArgoCDApplicationSet{
+		SCMProviders: []v1alpha1.ArgoCDApplicationSetSCMProviderSpec{
+			{
+				Provider: "github",
+				TokenSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "github-token"},
+					Key:                  "token",
+				},
+			},
+			{
+				Provider: "gitlab",
+				TokenSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "gitlab-token"},
+					Key:                  "token",
+				},
+			},
+		},
+	}
+	r := makeTestReconciler(t, a)
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.reconcileApplicationSetController(a))
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+
+	env := deployment.Spec.Template.Spec.Containers[0].Env
+	assert.Contains(t, env, corev1.EnvVar{
+		Name: "GITHUB_TOKEN",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "github-token"},
+				Key:                  "token",
+			},
+		},
+	})
+	assert.Contains(t, env, corev1.EnvVar{
+		Name: "GITLAB_TOKEN",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "gitlab-token"},
+				Key:                  "token",
+			},
+		},
+	})
+
+	// env vars provided by the user take precedence
+	a.Spec.ApplicationSet.Env = []corev1.EnvVar{{Name: "GITHUB_TOKEN", Value: "plain"}}
+	assert.NoError(t, r.reconcileApplicationSetController(a))
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "GITHUB_TOKEN", Value: "plain"})
+}
+
+func TestReconcileApplicationSetGitHubAppSecret(t *testing.T) {
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &v1alpha1.ArgoCDApplicationSet{
+		SCMProviders: []v1alpha1.ArgoCDApplicationSetSCMProviderSpec{
+			{
+				Provider: "github",
+				GitHubApp: &v1alpha1.ArgoCDGitHubAppCredentialsSpec{
+					AppID:          12345,
+					InstallationID: 67890,
+					PrivateKeySecretRef: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "github-app"},
+						Key:                  "private-key",
+					},
+					EnterpriseBaseURL: "https://github.example.com/api/v3",
+				},
+			},
+		},
+	}
+	githubApp := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-app", Namespace: a.Namespace},
+		Data:       map[string][]byte{"private-key": []byte("pem")},
+	}
+	r := makeTestReconciler(t, a, githubApp)
+
+	assert.NoError(t, r.reconcileApplicationSetController(a))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-applicationset-github-app",
+		Namespace: a.Namespace,
+	}, secret))
+	assert.Equal(t, map[string][]byte{
+		"githubAppID":                []byte("12345"),
+		"githubAppInstallationID":    []byte("67890"),
+		"githubAppEnterpriseBaseUrl": []byte("https://github.example.com/api/v3"),
+		"githubAppPrivateKey":        []byte("pem"),
+	}, secret.Data)
+	assert.NotContains(t, secret.Labels, common.ArgoCDSecretTypeLabel)
+	assert.True(t, isSecretReferenced("github-app", a))
+
+	// the private key is kept in sync with the referenced Secret
+	githubApp.Data["private-key"] = []byte("rotated")
+	assert.NoError(t, r.Client.Update(context.TODO(), githubApp))
+	assert.NoError(t, r.reconcileApplicationSetController(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-applicationset-github-app",
+		Namespace: a.Namespace,
+	}, secret))
+	assert.Equal(t, []byte("rotated"), secret.Data["githubAppPrivateKey"])
+
+	// the Secret is removed once the GitHub App is no longer configured
+	a.Spec.ApplicationSet.SCMProviders = nil
+	assert.NoError(t, r.reconcileApplicationSetController(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-applicationset-github-app",
+		Namespace: a.Namespace,
+	}, secret)
+	assertNotFound(t, err)
+}
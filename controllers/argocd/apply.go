@@ -0,0 +1,173 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// applyResource will ensure that the given object, owned by the given ArgoCD, is present with the fields that are set
+// on it, using Server-Side Apply. The operator only owns the fields it sets, so that fields added by users or by
+// mutating webhooks, e.g. annotations or injected sidecars, are kept. Fields that the operator set before and no longer
+// sets are removed, including the fields it set with Create and Update requests before it applied the resource.
+// Resources that were last applied with the same desired state, as recorded by their spec hash, are not applied again.
+func (r *ReconcileArgoCD) applyResource(cr *argoprojv1a1.ArgoCD, obj client.Object) error {
+	if err := controllerutil.SetControllerReference(cr, obj, r.Scheme); err != nil {
+		return err
+	}
+
+	// Applied configurations must hold their type, and must not hold server-side metadata.
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+	if err := setSpecHash(obj); err != nil {
+		return err
+	}
+
+	existing := newEmptyObject(obj)
+	if argoutil.IsObjectFound(r.Client, obj.GetNamespace(), obj.GetName(), existing) {
+		if metav1.IsControlledBy(existing, cr) && isSpecHashUnchanged(existing, obj) {
+			return nil // Resource found with nothing changed, move along...
+		}
+		if err := r.upgradeManagedFields(existing); err != nil {
+			return err
+		}
+	} else {
+		existing = nil
+	}
+
+	ctx := withExistingObject(context.TODO(), existing)
+	return r.Client.Patch(ctx, obj, client.Apply, client.FieldOwner(common.ArgoCDFieldManager), client.ForceOwnership)
+}
+
+// existingObjectKey is the context key of the state of an object before it is patched.
+type existingObjectKey struct{}
+
+// existingObject holds the state of an object before it is patched, or nil if the object does not exist yet.
+type existingObject struct {
+	obj client.Object
+}
+
+// withExistingObject returns a copy of the given context that holds the given state of an object before it is patched,
+// or nil if the object does not exist yet. The clients wrapping the client of the reconciler use it to tell what the
+// patch changed, without retrieving the object again.
+func withExistingObject(ctx context.Context, existing client.Object) context.Context {
+	return context.WithValue(ctx, existingObjectKey{}, existingObject{obj: existing})
+}
+
+// getExistingObject returns the state of an object before it is patched held by the given context, which is nil if the
+// object does not exist yet. The returned bool is false if the context does not hold it.
+func getExistingObject(ctx context.Context) (client.Object, bool) {
+	existing, ok := ctx.Value(existingObjectKey{}).(existingObject)
+	return existing.obj, ok
+}
+
+// getUpdateFieldManager returns the field manager of the Create and Update requests of the operator, which the API
+// server derives from its user agent.
+func getUpdateFieldManager() string {
+	return strings.Split(rest.DefaultKubernetesUserAgent(), "/")[0]
+}
+
+// getUpgradedManagedFields returns the given managed fields with the fields set by the given Create and Update field
+// manager moved to the given apply field manager. The returned bool is false if no field was set by the update field
+// manager, and the managed fields are returned unchanged. The fields of subresources, e.g. the status, are kept.
+func getUpgradedManagedFields(entries []metav1.ManagedFieldsEntry, updateManager, applyManager string) ([]metav1.ManagedFieldsEntry, bool, error) {
+	applied := -1
+	for i, entry := range entries {
+		if entry.Manager == applyManager && entry.Operation == metav1.ManagedFieldsOperationApply && entry.Subresource == "" {
+			applied = i
+		}
+	}
+
+	fields := &fieldpath.Set{}
+	if applied >= 0 && entries[applied].FieldsV1 != nil {
+		if err := fields.FromJSON(bytes.NewReader(entries[applied].FieldsV1.Raw)); err != nil {
+			return nil, false, err
+		}
+	}
+	upgraded := make([]metav1.ManagedFieldsEntry, 0, len(entries))
+	var updated *metav1.ManagedFieldsEntry
+	for i := range entries {
+		entry := entries[i]
+		if entry.Manager != updateManager || entry.Operation != metav1.ManagedFieldsOperationUpdate || entry.Subresource != "" {
+			upgraded = append(upgraded, entry)
+			continue
+		}
+		if entry.FieldsV1 != nil {
+			set := &fieldpath.Set{}
+			if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+				return nil, false, err
+			}
+			fields = fields.Union(set)
+		}
+		updated = &entry
+	}
+	if updated == nil {
+		return entries, false, nil
+	}
+
+	raw, err := fields.ToJSON()
+	if err != nil {
+		return nil, false, err
+	}
+	if applied < 0 {
+		return append(upgraded, metav1.ManagedFieldsEntry{
+			Manager:    applyManager,
+			Operation:  metav1.ManagedFieldsOperationApply,
+			APIVersion: updated.APIVersion,
+			Time:       updated.Time,
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: raw},
+		}), true, nil
+	}
+	for i := range upgraded {
+		if upgraded[i].Manager == applyManager && upgraded[i].Operation == metav1.ManagedFieldsOperationApply && upgraded[i].Subresource == "" {
+			upgraded[i].FieldsType = "FieldsV1"
+			upgraded[i].FieldsV1 = &metav1.FieldsV1{Raw: raw}
+		}
+	}
+	return upgraded, true, nil
+}
+
+// upgradeManagedFields moves the fields of the given existing object that the operator set with Create and Update
+// requests, before it applied the object, to its apply field manager. Otherwise these fields stay owned by the update
+// field manager, and are not removed once the operator no longer sets them.
+func (r *ReconcileArgoCD) upgradeManagedFields(existing client.Object) error {
+	entries, ok, err := getUpgradedManagedFields(existing.GetManagedFields(), getUpdateFieldManager(), common.ArgoCDFieldManager)
+	if err != nil || !ok {
+		return err
+	}
+	base := existing.DeepCopyObject().(client.Object)
+	patch := client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})
+	existing.SetManagedFields(entries)
+	return r.Client.Patch(withExistingObject(context.TODO(), base), existing, patch)
+}
@@ -0,0 +1,239 @@
+package argocd
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// patchRecordingClient is a client.Client that records the options of the patches sent through it.
+type patchRecordingClient struct {
+	client.Client
+	patchTypes []types.PatchType
+	options    []client.PatchOptions
+}
+
+func (c *patchRecordingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.patchTypes = append(c.patchTypes, patch.Type())
+	c.options = append(c.options, *(&client.PatchOptions{}).ApplyOptions(opts))
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func TestReconcileArgoCD_applyResource(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	c := &patchRecordingClient{Client: r.Client}
+	r.Client = c
+
+	deployment := newDeploymentWithSuffix("server", "server", a)
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: "argocd-server", Image: "argocd:v1"}}
+	assert.NoError(t, r.applyResource(a, deployment))
+
+	assert.Equal(t, []types.PatchType{types.ApplyPatchType}, c.patchTypes)
+	assert.Equal(t, common.ArgoCDFieldManager, c.options[0].FieldManager)
+	assert.True(t, *c.options[0].Force)
+
+	existing := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: deployment.Name, Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, existing))
+	assert.True(t, metav1.IsControlledBy(existing, a))
+}
+
+func TestReconcileArgoCD_applyResource_keepsUserFields(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	deployment.Annotations["example.com/owner"] = "team-a"
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+	resourceVersion := deployment.ResourceVersion
+
+	// annotations added by users are kept, and an unchanged Deployment is not updated
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, "team-a", deployment.Annotations["example.com/owner"])
+	assert.Equal(t, resourceVersion, deployment.ResourceVersion)
+
+	// fields owned by the operator are reset
+	deployment.Spec.Template.Spec.Containers[0].Image = "example.com/argocd:latest"
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.NotEqual(t, "example.com/argocd:latest", deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "team-a", deployment.Annotations["example.com/owner"])
+}
+
+func TestGetUpgradedManagedFields(t *testing.T) {
+	updateManager := getUpdateFieldManager()
+	fields := func(raw string) *metav1.FieldsV1 { return &metav1.FieldsV1{Raw: []byte(raw)} }
+	entries := []metav1.ManagedFieldsEntry{
+		{Manager: updateManager, Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "apps/v1", FieldsType: "FieldsV1",
+			FieldsV1: fields(`{"f:spec":{"f:replicas":{}}}`)},
+		{Manager: updateManager, Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "apps/v1", FieldsType: "FieldsV1",
+			FieldsV1: fields(`{"f:status":{"f:replicas":{}}}`), Subresource: "status"},
+		{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "apps/v1", FieldsType: "FieldsV1",
+			FieldsV1: fields(`{"f:metadata":{"f:annotations":{"f:example.com/owner":{}}}}`)},
+		{Manager: common.ArgoCDFieldManager, Operation: metav1.ManagedFieldsOperationApply, APIVersion: "apps/v1", FieldsType: "FieldsV1",
+			FieldsV1: fields(`{"f:spec":{"f:paused":{}}}`)},
+	}
+
+	// the fields set by the update field manager are moved to the apply field manager, apart from the subresources
+	upgraded, ok, err := getUpgradedManagedFields(entries, updateManager, common.ArgoCDFieldManager)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []metav1.ManagedFieldsEntry{
+		entries[1],
+		entries[2],
+		{Manager: common.ArgoCDFieldManager, Operation: metav1.ManagedFieldsOperationApply, APIVersion: "apps/v1", FieldsType: "FieldsV1",
+			FieldsV1: fields(`{"f:spec":{"f:paused":{},"f:replicas":{}}}`)},
+	}, upgraded)
+
+	// an entry of the apply field manager is added if there is none yet
+	upgraded, ok, err = getUpgradedManagedFields(entries[:3], updateManager, common.ArgoCDFieldManager)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []metav1.ManagedFieldsEntry{
+		entries[1],
+		entries[2],
+		{Manager: common.ArgoCDFieldManager, Operation: metav1.ManagedFieldsOperationApply, APIVersion: "apps/v1", FieldsType: "FieldsV1",
+			FieldsV1: fields(`{"f:spec":{"f:replicas":{}}}`)},
+	}, upgraded)
+
+	// managed fields that were already upgraded are left as is
+	_, ok, err = getUpgradedManagedFields(upgraded, updateManager, common.ArgoCDFieldManager)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestReconcileArgoCD_applyResource_upgradesManagedFields(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	existing := newDeploymentWithSuffix("server", "server", a)
+	existing.ManagedFields = []metav1.ManagedFieldsEntry{{
+		Manager:    getUpdateFieldManager(),
+		Operation:  metav1.ManagedFieldsOperationUpdate,
+		APIVersion: "apps/v1",
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{"f:spec":{"f:containers":{}}}}}`)},
+	}}
+	r := makeTestReconciler(t, a, existing)
+
+	// the Deployment created before the operator applied it is owned by the apply field manager once applied, so
+	// that the fields the operator no longer sets are removed
+	deployment := newDeploymentWithSuffix("server", "server", a)
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: "argocd-server", Image: "argocd:v1"}}
+	assert.NoError(t, r.applyResource(a, deployment))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(existing), existing))
+	assert.Len(t, existing.ManagedFields, 1)
+	assert.Equal(t, common.ArgoCDFieldManager, existing.ManagedFields[0].Manager)
+	assert.Equal(t, metav1.ManagedFieldsOperationApply, existing.ManagedFields[0].Operation)
+	assert.Equal(t, `{"f:spec":{"f:template":{"f:spec":{"f:containers":{}}}}}`, string(existing.ManagedFields[0].FieldsV1.Raw))
+}
+
+func TestReconcileArgoCD_applyResource_keepsInjectedFields(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Repo.Env = []corev1.EnvVar{{Name: "EXAMPLE", Value: "value"}}
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	// a sidecar injected by a mutating webhook, and an annotation added by a user
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	deployment.Annotations["example.com/owner"] = "team-a"
+	deployment.Spec.Template.Spec.Containers = append(deployment.Spec.Template.Spec.Containers,
+		corev1.Container{Name: "proxy", Image: "example.com/proxy:v1"})
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+
+	// the env var the operator no longer sets is removed, the fields set by others are kept
+	a.Spec.Repo.Env = nil
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, "team-a", deployment.Annotations["example.com/owner"])
+	containers := deployment.Spec.Template.Spec.Containers
+	assert.Len(t, containers, 2)
+	assert.Equal(t, "argocd-repo-server", containers[0].Name)
+	for _, env := range containers[0].Env {
+		assert.NotEqual(t, "EXAMPLE", env.Name)
+	}
+	assert.Equal(t, corev1.Container{Name: "proxy", Image: "example.com/proxy:v1"}, containers[1])
+}
+
+// startTestEnv starts a local API server, and skips the calling test when its binaries are not installed, see the
+// envtest target of the Makefile.
+func startTestEnv(t *testing.T) *rest.Config {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS is not set")
+	}
+	env := &envtest.Environment{}
+	cfg, err := env.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { assert.NoError(t, env.Stop()) })
+	return cfg
+}
+
+func TestReconcileArgoCD_applyResource_apiServer(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.UID = "argocd-uid"
+	r := makeTestReconciler(t)
+	cl, err := client.New(startTestEnv(t), client.Options{Scheme: r.Scheme})
+	assert.NoError(t, err)
+	r.Client = cl
+	assert.NoError(t, cl.Create(context.TODO(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: a.Namespace}}))
+
+	// a Deployment created before the operator applied it, with a sidecar injected by a mutating webhook
+	deployment := newDeploymentWithSuffix("server", "server", a)
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: "argocd-server", Image: "argocd:v1", Args: []string{"--insecure"}}}
+	assert.NoError(t, cl.Create(context.TODO(), deployment))
+	deployment.Spec.Template.Spec.Containers = append(deployment.Spec.Template.Spec.Containers,
+		corev1.Container{Name: "proxy", Image: "example.com/proxy:v1"})
+	assert.NoError(t, cl.Update(context.TODO(), deployment, client.FieldOwner("sidecar-injector")))
+
+	// the fields the operator no longer sets are removed, the sidecar is kept
+	desired := newDeploymentWithSuffix("server", "server", a)
+	desired.Spec.Template.Spec.Containers = []corev1.Container{{Name: "argocd-server", Image: "argocd:v2"}}
+	assert.NoError(t, r.applyResource(a, desired))
+
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(deployment), deployment))
+	assert.True(t, metav1.IsControlledBy(deployment, a))
+	containers := deployment.Spec.Template.Spec.Containers
+	assert.Len(t, containers, 2)
+	assert.Equal(t, "argocd:v2", containers[0].Image)
+	assert.Empty(t, containers[0].Args)
+	assert.Equal(t, "proxy", containers[1].Name)
+	for _, entry := range deployment.ManagedFields {
+		assert.NotEqual(t, getUpdateFieldManager(), entry.Manager)
+	}
+
+	// applying the same Deployment again does not change it
+	resourceVersion := deployment.ResourceVersion
+	desired = newDeploymentWithSuffix("server", "server", a)
+	desired.Spec.Template.Spec.Containers = []corev1.Container{{Name: "argocd-server", Image: "argocd:v2"}}
+	assert.NoError(t, r.applyResource(a, desired))
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(deployment), deployment))
+	assert.Equal(t, resourceVersion, deployment.ResourceVersion)
+}
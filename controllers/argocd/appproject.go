@@ -0,0 +1,188 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	appProjectGroup   = "argoproj.io"
+	appProjectVersion = "v1alpha1"
+	appProjectKind    = "AppProject"
+)
+
+// newAppProject returns a new AppProject instance with the given name for the given ArgoCD.
+func newAppProject(name string, cr *argoprojv1a1.ArgoCD) *unstructured.Unstructured {
+	project := &unstructured.Unstructured{}
+	project.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   appProjectGroup,
+		Version: appProjectVersion,
+		Kind:    appProjectKind,
+	})
+	project.SetName(name)
+	project.SetNamespace(cr.Namespace)
+	project.SetLabels(argoutil.LabelsForCluster(cr))
+	return project
+}
+
+// toInterfaceSlice converts the given strings to a slice that can be set on an unstructured object.
+func toInterfaceSlice(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+	return result
+}
+
+// getAppProjectSpec returns the desired spec of the AppProject for the given project.
+func getAppProjectSpec(project argoprojv1a1.ArgoCDProjectSpec) map[string]interface{} {
+	spec := map[string]interface{}{}
+	if project.Description != "" {
+		spec["description"] = project.Description
+	}
+	if len(project.SourceRepos) > 0 {
+		spec["sourceRepos"] = toInterfaceSlice(project.SourceRepos)
+	}
+
+	if len(project.Destinations) > 0 {
+		destinations := make([]interface{}, 0, len(project.Destinations))
+		for _, d := range project.Destinations {
+			destination := map[string]interface{}{}
+			if d.Server != "" {
+				destination["server"] = d.Server
+			}
+			if d.Name != "" {
+				destination["name"] = d.Name
+			}
+			if d.Namespace != "" {
+				destination["namespace"] = d.Namespace
+			}
+			destinations = append(destinations, destination)
+		}
+		spec["destinations"] = destinations
+	}
+
+	if len(project.ClusterResourceWhitelist) > 0 {
+		whitelist := make([]interface{}, 0, len(project.ClusterResourceWhitelist))
+		for _, gk := range project.ClusterResourceWhitelist {
+			whitelist = append(whitelist, map[string]interface{}{
+				"group": gk.Group,
+				"kind":  gk.Kind,
+			})
+		}
+		spec["clusterResourceWhitelist"] = whitelist
+	}
+
+	if len(project.Roles) > 0 {
+		roles := make([]interface{}, 0, len(project.Roles))
+		for _, r := range project.Roles {
+			role := map[string]interface{}{
+				"name": r.Name,
+			}
+			if r.Description != "" {
+				role["description"] = r.Description
+			}
+			if len(r.Policies) > 0 {
+				role["policies"] = toInterfaceSlice(r.Policies)
+			}
+			if len(r.Groups) > 0 {
+				role["groups"] = toInterfaceSlice(r.Groups)
+			}
+			roles = append(roles, role)
+		}
+		spec["roles"] = roles
+	}
+	return spec
+}
+
+// reconcileInitialProjects will ensure that the AppProjects listed in the spec of the given ArgoCD are present and up to
+// date, and that AppProjects the operator created for it, but that are no longer listed, are removed.
+func (r *ReconcileArgoCD) reconcileInitialProjects(cr *argoprojv1a1.ArgoCD) error {
+	desired := make(map[string]bool)
+	for _, project := range cr.Spec.InitialProjects {
+		desired[project.Name] = true
+		if err := r.reconcileInitialProject(project, cr); err != nil {
+			return err
+		}
+	}
+
+	projects := &unstructured.UnstructuredList{}
+	projects.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   appProjectGroup,
+		Version: appProjectVersion,
+		Kind:    appProjectKind + "List",
+	})
+	opts := []client.ListOption{
+		client.InNamespace(cr.Namespace),
+		client.MatchingLabels(argoutil.LabelsForCluster(cr)),
+	}
+	if err := r.Client.List(context.TODO(), projects, opts...); err != nil {
+		return err
+	}
+
+	for i := range projects.Items {
+		project := &projects.Items[i]
+		// Only remove AppProjects that were created by the operator.
+		if desired[project.GetName()] || !metav1.IsControlledBy(project, cr) {
+			continue
+		}
+		log.Info(fmt.Sprintf("deleting appproject %s as it is no longer listed in the initial projects", project.GetName()))
+		if err := r.Client.Delete(context.TODO(), project); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileInitialProject will ensure that the AppProject for the given project is present and has the desired spec.
+// AppProjects that exist, but were not created by the operator, are left untouched.
+func (r *ReconcileArgoCD) reconcileInitialProject(project argoprojv1a1.ArgoCDProjectSpec, cr *argoprojv1a1.ArgoCD) error {
+	spec := getAppProjectSpec(project)
+
+	existing := newAppProject(project.Name, cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.GetName(), existing) {
+		if !metav1.IsControlledBy(existing, cr) {
+			log.Info(fmt.Sprintf("appproject %s is not managed by Argo CD instance %s, skipping", existing.GetName(), cr.Name))
+			return nil
+		}
+
+		if !reflect.DeepEqual(existing.Object["spec"], spec) {
+			existing.Object["spec"] = spec
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // AppProject found with nothing to do, move along...
+	}
+
+	appProject := newAppProject(project.Name, cr)
+	appProject.Object["spec"] = spec
+	if err := controllerutil.SetControllerReference(cr, appProject, r.Scheme); err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("creating appproject %s for Argo CD instance %s in namespace %s", appProject.GetName(), cr.Name, cr.Namespace))
+	return r.Client.Create(context.TODO(), appProject)
+}
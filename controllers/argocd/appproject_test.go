@@ -0,0 +1,97 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func TestReconcileArgoCD_reconcileInitialProjects(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.InitialProjects = []argoprojv1alpha1.ArgoCDProjectSpec{
+			{
+				Name:        "team-a",
+				Description: "Team A",
+				SourceRepos: []string{"https://github.com/team-a/*"},
+				Destinations: []argoprojv1alpha1.ArgoCDProjectDestination{
+					{Server: "https://kubernetes.default.svc", Namespace: "team-a-*"},
+				},
+				ClusterResourceWhitelist: []metav1.GroupKind{
+					{Group: "", Kind: "Namespace"},
+				},
+				Roles: []argoprojv1alpha1.ArgoCDProjectRole{
+					{
+						Name:     "developer",
+						Policies: []string{"p, proj:team-a:developer, applications, sync, team-a/*, allow"},
+						Groups:   []string{"team-a"},
+					},
+				},
+			},
+			{
+				Name:        "team-b",
+				SourceRepos: []string{"*"},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileInitialProjects(a))
+
+	project := newAppProject("team-a", a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "team-a", Namespace: a.Namespace}, project))
+	assert.True(t, metav1.IsControlledBy(project, a))
+	description, _, _ := unstructured.NestedString(project.Object, "spec", "description")
+	assert.Equal(t, "Team A", description)
+	sourceRepos, _, _ := unstructured.NestedStringSlice(project.Object, "spec", "sourceRepos")
+	assert.Equal(t, []string{"https://github.com/team-a/*"}, sourceRepos)
+	destinations, _, _ := unstructured.NestedSlice(project.Object, "spec", "destinations")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"server": "https://kubernetes.default.svc", "namespace": "team-a-*"},
+	}, destinations)
+	roles, _, _ := unstructured.NestedSlice(project.Object, "spec", "roles")
+	assert.Len(t, roles, 1)
+
+	// changes made to the AppProject are reverted
+	assert.NoError(t, unstructured.SetNestedStringSlice(project.Object, []string{"*"}, "spec", "sourceRepos"))
+	assert.NoError(t, r.Client.Update(context.TODO(), project))
+	assert.NoError(t, r.reconcileInitialProjects(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "team-a", Namespace: a.Namespace}, project))
+	sourceRepos, _, _ = unstructured.NestedStringSlice(project.Object, "spec", "sourceRepos")
+	assert.Equal(t, []string{"https://github.com/team-a/*"}, sourceRepos)
+
+	// projects removed from the spec are deleted
+	a.Spec.InitialProjects = a.Spec.InitialProjects[:1]
+	assert.NoError(t, r.reconcileInitialProjects(a))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "team-b", Namespace: a.Namespace}, newAppProject("team-b", a)))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "team-a", Namespace: a.Namespace}, project))
+}
+
+func TestReconcileArgoCD_reconcileInitialProjects_existingProject(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.InitialProjects = []argoprojv1alpha1.ArgoCDProjectSpec{
+			{Name: "default", SourceRepos: []string{"https://github.com/team-a/*"}},
+		}
+	})
+	existing := newAppProject("default", a)
+	existing.Object["spec"] = map[string]interface{}{
+		"sourceRepos": []interface{}{"*"},
+	}
+	r := makeTestReconciler(t, a, existing)
+
+	// AppProjects not created by the operator are left untouched
+	assert.NoError(t, r.reconcileInitialProjects(a))
+
+	project := newAppProject("default", a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "default", Namespace: a.Namespace}, project))
+	sourceRepos, _, _ := unstructured.NestedStringSlice(project.Object, "spec", "sourceRepos")
+	assert.Equal(t, []string{"*"}, sourceRepos)
+}
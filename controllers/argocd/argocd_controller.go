@@ -19,6 +19,7 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
 
@@ -43,6 +44,13 @@ type ReconcileArgoCD struct {
 	ManagedNamespaces *corev1.NamespaceList
 	// Stores a list of SourceNamespaces as values
 	ManagedSourceNamespaces map[string]string
+	// Options tune how often and how fast ArgoCD instances are reconciled
+	Options ReconcilerOptions
+
+	// reconciled tracks the ArgoCD instances reconciled successfully, to only reconcile the components that changed
+	reconciled *reconciledObjects
+	// ssoConfigLegalStatus is the result of the validation of the SSO configuration of the ArgoCD being reconciled
+	ssoConfigLegalStatus string
 }
 
 var log = logr.Log.WithName("controller_argocd")
@@ -55,13 +63,17 @@ var log = logr.Log.WithName("controller_argocd")
 //+kubebuilder:rbac:groups=argoproj.io,resources=argocds;argocds/finalizers;argocds/status,verbs=*
 //+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=*
 //+kubebuilder:rbac:groups=batch,resources=cronjobs;jobs,verbs=*
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=*
 //+kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get;list;watch
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=*
+//+kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices;destinationrules,verbs=*
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheuses;servicemonitors,verbs=*
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=*
 //+kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=*
 //+kubebuilder:rbac:groups=argoproj.io,resources=applications;appprojects,verbs=*
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=*,verbs=*
 //+kubebuilder:rbac:groups="",resources=pods;pods/log,verbs=get
+//+kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch
 //+kubebuilder:rbac:groups=template.openshift.io,resources=templates;templateinstances;templateconfigs,verbs=*
 //+kubebuilder:rbac:groups="oauth.openshift.io",resources=oauthclients,verbs=get;list;watch;create;delete;patch;update
 
@@ -74,24 +86,72 @@ var log = logr.Log.WithName("controller_argocd")
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.9.2/pkg/reconcile
 func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	// The managed namespaces and the SSO validation result of an instance are kept on the reconciler while it is
+	// reconciled, use a reconciler per request so that several instances can be reconciled concurrently.
+	return r.forRequest().reconcile(ctx, request)
+}
+
+// forRequest returns a reconciler for a single reconcile request. It shares the client, the options and the
+// reconciled instances of r, and holds its own managed namespaces and SSO validation result. The resources changed
+// by the request are counted on their own.
+func (r *ReconcileArgoCD) forRequest() *ReconcileArgoCD {
+	c := r.Client
+	if m, ok := c.(*metricsClient); ok {
+		c = newMetricsClient(m.Client)
+	}
+	return &ReconcileArgoCD{
+		Client:     c,
+		Scheme:     r.Scheme,
+		Options:    r.Options,
+		reconciled: r.reconciled,
+	}
+}
+
+// reconcile reconciles the ArgoCD of the given request.
+func (r *ReconcileArgoCD) reconcile(ctx context.Context, request ctrl.Request) (result ctrl.Result, err error) {
 	reqLogger := logr.FromContext(ctx, "namespace", request.Namespace, "name", request.Name)
 	reqLogger.Info("Reconciling ArgoCD")
 
+	// Record the operator metrics for the instance, or remove them once the instance is gone
+	start := time.Now()
+	deleted := false
+	defer func() {
+		if deleted {
+			deleteInstanceMetrics(request.NamespacedName)
+			return
+		}
+		recordReconcile(request.NamespacedName, time.Since(start), r.getResourceChangeCount(), err)
+	}()
+
 	argocd := &argoproj.ArgoCD{}
-	err := r.Client.Get(ctx, request.NamespacedName, argocd)
+	err = r.Client.Get(ctx, request.NamespacedName, argocd)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			deleted = true
+			r.reconciled.forget(request.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
 
+	if !r.Options.matchesLabelSelector(argocd) {
+		// The instance is reconciled by another operator, e.g. requested by a watch on one of its resources
+		reqLogger.Info("ArgoCD does not match the label selector of the operator, skipping")
+		deleted = true
+		return reconcile.Result{}, nil
+	}
+
 	if argocd.GetDeletionTimestamp() != nil {
+		deleted = true
 		if argocd.IsDeletionFinalizerPresent() {
+			if err := r.orphanProtectedResources(argocd); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to keep protected resources: %w", err)
+			}
+
 			if err := r.deleteClusterResources(argocd); err != nil {
 				return reconcile.Result{}, fmt.Errorf("failed to delete ClusterResources: %w", err)
 			}
@@ -102,6 +162,14 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 				}
 			}
 
+			if err := r.reconcileManagedNamespacesFromSpec(argocd); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to release managed namespaces, error: %w", err)
+			}
+
+			if err := r.setManagedSourceNamespaces(argocd); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to list source namespaces, error: %w", err)
+			}
+
 			if err := r.removeUnmanagedSourceNamespaceResources(argocd); err != nil {
 				return reconcile.Result{}, fmt.Errorf("failed to remove resources from sourceNamespaces, error: %w", err)
 			}
@@ -112,9 +180,7 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 
 			// remove namespace of deleted Argo CD instance from deprecationEventEmissionTracker (if exists) so that if another instance
 			// is created in the same namespace in the future, that instance is appropriately tracked
-			if _, ok := DeprecationEventEmissionTracker[argocd.Namespace]; ok {
-				delete(DeprecationEventEmissionTracker, argocd.Namespace)
-			}
+			deleteDeprecationEventEmissionStatus(argocd.Namespace)
 		}
 		return reconcile.Result{}, nil
 	}
@@ -130,6 +196,37 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		return reconcile.Result{}, err
 	}
 
+	// The status is changed in memory by the reconcilers, and persisted in a single patch once the pass is done, also
+	// when it failed. A conflicting change to the instance requeues the request to reconcile the latest version.
+	status := argocd.Status.DeepCopy()
+	defer func() {
+		if patchErr := r.patchStatus(argocd, status); patchErr != nil {
+			if errors.IsConflict(patchErr) {
+				reqLogger.Info("ArgoCD was changed during the reconciliation, requeueing to update its status")
+				if err == nil {
+					result = reconcile.Result{Requeue: true}
+				}
+				return
+			}
+			if err == nil {
+				err = fmt.Errorf("failed to patch the status of ArgoCD: %w", patchErr)
+			}
+		}
+	}()
+
+	if isReconcilePaused(argocd) {
+		// Keep reporting the status, but leave the resources of the instance untouched
+		reqLogger.Info("Reconciliation of ArgoCD resources is paused")
+		r.reconciled.forget(request.NamespacedName)
+		err = r.reconcilePausedStatus(argocd)
+		recordComponentPhases(argocd)
+		return reconcile.Result{}, err
+	}
+
+	if err = r.reconcileManagedNamespacesFromSpec(argocd); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if err = r.setManagedNamespaces(argocd); err != nil {
 		return reconcile.Result{}, err
 	}
@@ -138,10 +235,24 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		return reconcile.Result{}, err
 	}
 
-	if err := r.reconcileResources(argocd); err != nil {
+	err = r.reconcileResources(argocd)
+	recordComponentPhases(argocd)
+	if err != nil {
 		// Error reconciling ArgoCD sub-resources - requeue the request.
+		r.reconciled.forget(request.NamespacedName)
 		return reconcile.Result{}, err
 	}
+	r.reconciled.remember(argocd)
+
+	// Requeue to rotate the admin password once the rotation interval has elapsed, or to renew the certificates
+	// generated by the operator before they expire
+	requeueAfter := r.getAdminPasswordRotationRequeueAfter(argocd)
+	if renewAfter := r.getCertificateRenewalRequeueAfter(argocd); renewAfter > 0 && (requeueAfter == 0 || renewAfter < requeueAfter) {
+		requeueAfter = renewAfter
+	}
+	if requeueAfter > 0 {
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
 
 	// Return and don't requeue
 	return reconcile.Result{}, nil
@@ -149,7 +260,11 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ReconcileArgoCD) SetupWithManager(mgr ctrl.Manager) error {
-	bldr := ctrl.NewControllerManagedBy(mgr)
-	r.setResourceWatches(bldr, r.clusterResourceMapper, r.tlsSecretMapper, r.namespaceResourceMapper)
+	// Record events for the resources changed by the reconciler, and count them and the failed API requests
+	r.Client = newMetricsClient(newEventClient(r.Client))
+	r.reconciled = &reconciledObjects{}
+
+	bldr := ctrl.NewControllerManagedBy(mgr).WithOptions(r.Options.controllerOptions())
+	r.setResourceWatches(bldr, r.clusterResourceMapper, r.tlsSecretMapper, r.namespaceResourceMapper, r.clusterSecretResourceMapper, r.resourceQuotaMapper)
 	return bldr.Complete(r)
 }
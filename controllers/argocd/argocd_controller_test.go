@@ -17,6 +17,7 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -102,6 +104,128 @@ func TestReconcileArgoCD_Reconcile(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_Reconcile_concurrent(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	// Emits a deprecation event for each instance
+	t.Setenv("DISABLE_DEX", "false")
+
+	a := makeTestArgoCD(func(a *argov1alpha1.ArgoCD) { a.Namespace = "argocd-a" })
+	b := makeTestArgoCD(func(a *argov1alpha1.ArgoCD) { a.Namespace = "argocd-b" })
+	r := makeTestReconciler(t, a, b)
+	for _, ns := range []string{a.Namespace, b.Namespace} {
+		assert.NoError(t, createNamespace(r, ns, ""))
+	}
+	assert.NoError(t, createNamespace(r, "tenant-a", a.Namespace))
+	assert.NoError(t, createNamespace(r, "tenant-b", b.Namespace))
+
+	// Both instances are reconciled concurrently, as with several max concurrent reconciles, run with -race to detect
+	// the state shared between the reconciliations
+	var wg sync.WaitGroup
+	for _, cr := range []*argov1alpha1.ArgoCD{a, b} {
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 3; i++ {
+				_, err := r.Reconcile(context.TODO(), req)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, cr := range []*argov1alpha1.ArgoCD{a, b} {
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr))
+		assert.Equal(t, ssoLegalUnknown, cr.Status.SSOConfig)
+		status, ok := getDeprecationEventEmissionStatus(cr.Namespace)
+		assert.True(t, ok)
+		assert.True(t, status.DisableDexDeprecationWarningEmitted)
+	}
+	assert.Equal(t, []string{"tenant-a"}, a.Status.ManagedNamespaces)
+	assert.Equal(t, []string{"tenant-b"}, b.Status.ManagedNamespaces)
+}
+
+func TestReconcileArgoCD_Reconcile_labelSelector(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	r.Options = ReconcilerOptions{LabelSelector: "shard=a"}
+	assert.NoError(t, r.Options.Complete())
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      a.Name,
+			Namespace: a.Namespace,
+		},
+	}
+
+	// instances that do not match the label selector are left untouched
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	assert.False(t, a.IsDeletionFinalizerPresent())
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, &appsv1.Deployment{}))
+
+	a.Labels = map[string]string{"shard": "a"}
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, &appsv1.Deployment{}))
+}
+
+func TestReconcileArgoCD_Reconcile_paused(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      a.Name,
+			Namespace: a.Namespace,
+		},
+	}
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	// a manual change to a managed resource is kept while the reconciliation is paused
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, deployment))
+	deployment.Spec.Template.Spec.Containers[0].Image = "redis:patched"
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	a.Annotations = map[string]string{common.AnnotationReconcile: common.ReconcilePaused}
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+
+	res, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Zero(t, res.RequeueAfter)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, deployment))
+	assert.Equal(t, "redis:patched", deployment.Spec.Template.Spec.Containers[0].Image)
+
+	// the status is still reported
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	assert.Equal(t, "Pending", a.Status.Redis)
+	condition := meta.FindStatusCondition(a.Status.Conditions, argov1alpha1.ArgoCDConditionTypeReconcilePaused)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+
+	// resuming the reconciliation reverts the change
+	delete(a.Annotations, common.AnnotationReconcile)
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, deployment))
+	assert.NotEqual(t, "redis:patched", deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	assert.True(t, meta.IsStatusConditionFalse(a.Status.Conditions, argov1alpha1.ArgoCDConditionTypeReconcilePaused))
+}
+
 func TestReconcileArgoCD_Reconcile_RemoveManagedByLabelOnArgocdDeletion(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 
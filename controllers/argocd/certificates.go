@@ -0,0 +1,152 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"crypto/x509"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// getTLSRenewBefore will return the remaining validity of the certificates generated by the operator for the given
+// ArgoCD, below which they are renewed.
+func getTLSRenewBefore(cr *argoprojv1a1.ArgoCD) time.Duration {
+	if cr.Spec.TLS.RenewBefore != nil && cr.Spec.TLS.RenewBefore.Duration > 0 {
+		return cr.Spec.TLS.RenewBefore.Duration
+	}
+	return common.ArgoCDDefaultTLSRenewBefore
+}
+
+// certificateNeedsRenewal returns true if the certificate in the given secret cannot be parsed, is not signed by the
+// given CA or expires within the given duration. The signature is not checked when no CA is given.
+func certificateNeedsRenewal(secret *corev1.Secret, caCert *x509.Certificate, renewBefore time.Duration) bool {
+	cert, err := argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return true
+	}
+	if caCert != nil {
+		if err := cert.CheckSignatureFrom(caCert); err != nil {
+			return true
+		}
+	}
+	return time.Now().Add(renewBefore).After(cert.NotAfter)
+}
+
+// createCertificateRenewedEvent will emit a Normal event for the renewal of the certificate in the Secret with the
+// given name.
+func (r *ReconcileArgoCD) createCertificateRenewedEvent(name string, cr *argoprojv1a1.ArgoCD) error {
+	message := fmt.Sprintf("The certificate in secret %s was renewed.", name)
+	return argoutil.CreateEvent(r.Client, "Normal", "Renewed", message, "CertificateRenewed", cr.ObjectMeta, cr.TypeMeta)
+}
+
+// getCertificateSecretNames will return the names of the Secrets holding the TLS certificates of the given ArgoCD.
+func getCertificateSecretNames(cr *argoprojv1a1.ArgoCD) []string {
+	names := []string{
+		argoutil.NewSecretWithSuffix(cr, common.ArgoCDCASuffix).Name,
+		argoutil.NewSecretWithSuffix(cr, "tls").Name,
+		common.ArgoCDDexServerTLSSecretName,
+		common.ArgoCDRedisServerTLSSecretName,
+		common.ArgoCDRepoServerTLSSecretName,
+		common.ArgoCDServerTLSSecretName,
+	}
+	if principal := getAgentPrincipal(cr); principal.IsEnabled() {
+		names = append(names, getAgentPrincipalTLSSecretName(cr))
+		for _, agent := range principal.Agents {
+			names = append(names, getAgentClientTLSSecretName(agent, cr))
+		}
+	}
+	if agent := getAgentAgent(cr); agent.IsEnabled() && agent.ClientTLSSecretName != "" {
+		names = append(names, agent.ClientTLSSecretName)
+	}
+	return names
+}
+
+// getCertificatesStatus will return the expiry of the TLS certificates of the given ArgoCD that are present, sorted by
+// the name of the Secret holding them.
+func (r *ReconcileArgoCD) getCertificatesStatus(cr *argoprojv1a1.ArgoCD) []argoprojv1a1.ArgoCDCertificateStatus {
+	var certificates []argoprojv1a1.ArgoCDCertificateStatus
+	for _, name := range getCertificateSecretNames(cr) {
+		secret := &corev1.Secret{}
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, name, secret) {
+			continue
+		}
+		cert, err := argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			continue
+		}
+		certificates = append(certificates, argoprojv1a1.ArgoCDCertificateStatus{
+			SecretName: name,
+			NotAfter:   metav1.NewTime(cert.NotAfter.UTC()),
+			Managed:    metav1.IsControlledBy(secret, cr),
+		})
+	}
+	sort.Slice(certificates, func(i, j int) bool {
+		return certificates[i].SecretName < certificates[j].SecretName
+	})
+	return certificates
+}
+
+// reconcileStatusCertificates will ensure that the Certificates status lists the expiry of the TLS certificates of the
+// given ArgoCD. A Warning event is emitted for certificates that are not renewed by the operator and expire soon.
+func (r *ReconcileArgoCD) reconcileStatusCertificates(cr *argoprojv1a1.ArgoCD) error {
+	certificates := r.getCertificatesStatus(cr)
+	if reflect.DeepEqual(cr.Status.Certificates, certificates) {
+		return nil
+	}
+
+	renewBefore := getTLSRenewBefore(cr)
+	for _, certificate := range certificates {
+		if certificate.Managed || time.Now().Add(renewBefore).Before(certificate.NotAfter.Time) {
+			continue
+		}
+		message := fmt.Sprintf("The certificate in secret %s expires at %s and is not renewed by the operator.", certificate.SecretName, certificate.NotAfter.Format(time.RFC3339))
+		if err := argoutil.CreateEvent(r.Client, "Warning", "Expiring", message, "CertificateExpiring", cr.ObjectMeta, cr.TypeMeta); err != nil {
+			log.Error(err, "failed to create event for expiring certificate")
+		}
+	}
+
+	cr.Status.Certificates = certificates
+	return nil
+}
+
+// getCertificateRenewalRequeueAfter will return the time until the first certificate generated by the operator for
+// the given ArgoCD is due for renewal, or zero if no certificate is generated by the operator.
+func (r *ReconcileArgoCD) getCertificateRenewalRequeueAfter(cr *argoprojv1a1.ArgoCD) time.Duration {
+	renewBefore := getTLSRenewBefore(cr)
+
+	var requeueAfter time.Duration
+	for _, certificate := range r.getCertificatesStatus(cr) {
+		if !certificate.Managed {
+			continue
+		}
+		remaining := time.Until(certificate.NotAfter.Add(-renewBefore))
+		if remaining <= 0 {
+			continue
+		}
+		if requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+	return requeueAfter
+}
@@ -0,0 +1,174 @@
+package argocd
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// makeTestCertificateSecret returns a TLS Secret with the given name holding a self-signed certificate that expires at
+// the given time.
+func makeTestCertificateSecret(t *testing.T, name string, notAfter time.Time) *corev1.Secret {
+	t.Helper()
+	key, err := argoutil.NewPrivateKey()
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:              argoutil.EncodeCertificatePEM(cert),
+			corev1.ServiceAccountRootCAKey: argoutil.EncodeCertificatePEM(cert),
+			corev1.TLSPrivateKeyKey:        argoutil.EncodePrivateKeyPEM(key),
+		},
+	}
+}
+
+func TestGetTLSRenewBefore(t *testing.T) {
+	a := makeTestArgoCD()
+	assert.Equal(t, common.ArgoCDDefaultTLSRenewBefore, getTLSRenewBefore(a))
+
+	a.Spec.TLS.RenewBefore = &metav1.Duration{Duration: 48 * time.Hour}
+	assert.Equal(t, 48*time.Hour, getTLSRenewBefore(a))
+}
+
+func TestCertificateNeedsRenewal(t *testing.T) {
+	valid := makeTestCertificateSecret(t, "valid", time.Now().Add(90*24*time.Hour))
+	assert.False(t, certificateNeedsRenewal(valid, nil, 30*24*time.Hour))
+	assert.True(t, certificateNeedsRenewal(valid, nil, 120*24*time.Hour))
+
+	// a certificate not signed by the given CA needs renewal
+	ca := makeTestCertificateSecret(t, "ca", time.Now().Add(90*24*time.Hour))
+	caCert, err := argoutil.ParsePEMEncodedCert(ca.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.True(t, certificateNeedsRenewal(valid, caCert, 30*24*time.Hour))
+
+	invalid := &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: []byte("invalid")}}
+	assert.True(t, certificateNeedsRenewal(invalid, nil, 0))
+}
+
+func TestReconcileArgoCD_reconcileClusterCASecret_renewal(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	expiring := makeTestCertificateSecret(t, "argocd-ca", time.Now().Add(24*time.Hour))
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, controllerutil.SetControllerReference(a, expiring, r.Scheme))
+	assert.NoError(t, r.Client.Create(context.TODO(), expiring))
+
+	assert.NoError(t, r.reconcileClusterCASecret(a))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-ca", Namespace: a.Namespace}, secret))
+	assert.NotEqual(t, expiring.Data[corev1.TLSCertKey], secret.Data[corev1.TLSCertKey])
+	assert.False(t, certificateNeedsRenewal(secret, nil, getTLSRenewBefore(a)))
+
+	// the renewed CA is picked up by the TLS secret and the CA ConfigMap
+	tls := makeTestCertificateSecret(t, "argocd-tls", time.Now().Add(365*24*time.Hour))
+	assert.NoError(t, controllerutil.SetControllerReference(a, tls, r.Scheme))
+	assert.NoError(t, r.Client.Create(context.TODO(), tls))
+	assert.NoError(t, r.reconcileClusterTLSSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-tls", Namespace: a.Namespace}, tls))
+	caCert, err := argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.False(t, certificateNeedsRenewal(tls, caCert, getTLSRenewBefore(a)))
+
+	cm := newConfigMapWithName(getCAConfigMapName(a), a)
+	cm.Data = map[string]string{common.ArgoCDKeyTLSCert: string(expiring.Data[corev1.TLSCertKey])}
+	assert.NoError(t, controllerutil.SetControllerReference(a, cm, r.Scheme))
+	assert.NoError(t, r.Client.Create(context.TODO(), cm))
+	assert.NoError(t, r.reconcileCAConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cm.Name, Namespace: a.Namespace}, cm))
+	assert.Equal(t, string(secret.Data[corev1.TLSCertKey]), cm.Data[common.ArgoCDKeyTLSCert])
+}
+
+func TestReconcileArgoCD_reconcileClusterCASecret_unmanaged(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	expiring := makeTestCertificateSecret(t, "argocd-ca", time.Now().Add(24*time.Hour))
+	r := makeTestReconciler(t, a, expiring)
+
+	assert.NoError(t, r.reconcileClusterCASecret(a))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-ca", Namespace: a.Namespace}, secret))
+	assert.Equal(t, expiring.Data, secret.Data)
+}
+
+func TestReconcileArgoCD_reconcileExistingArgoSecret_tlsRollout(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	server := newDeploymentWithSuffix("server", "server", a)
+	tls := makeTestCertificateSecret(t, "argocd-tls", time.Now().Add(365*24*time.Hour))
+	clusterSecret := argoutil.NewSecretWithSuffix(a, "cluster")
+	clusterSecret.Data = map[string][]byte{common.ArgoCDKeyAdminPassword: []byte("something")}
+	argoSecret := argoutil.NewSecretWithName(a, common.ArgoCDSecretName)
+	argoSecret.Data = map[string][]byte{common.ArgoCDKeyServerSecretKey: []byte("key")}
+	r := makeTestReconciler(t, a, server, tls, clusterSecret, argoSecret)
+
+	assert.NoError(t, r.reconcileExistingArgoSecret(a, argoSecret, clusterSecret, tls))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: server.Name, Namespace: a.Namespace}, deployment))
+	_, ok := deployment.Spec.Template.Labels["tls.cert.changed"]
+	assert.True(t, ok)
+}
+
+func TestReconcileArgoCD_reconcileStatusCertificates(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	caNotAfter := time.Now().Add(365 * 24 * time.Hour).Truncate(time.Second)
+	serverNotAfter := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	ca := makeTestCertificateSecret(t, "argocd-ca", caNotAfter)
+	server := makeTestCertificateSecret(t, common.ArgoCDServerTLSSecretName, serverNotAfter)
+	r := makeTestReconciler(t, a, server)
+	assert.NoError(t, controllerutil.SetControllerReference(a, ca, r.Scheme))
+	assert.NoError(t, r.Client.Create(context.TODO(), ca))
+
+	assert.NoError(t, r.reconcileStatusCertificates(a))
+	assert.Equal(t, []argoprojv1alpha1.ArgoCDCertificateStatus{
+		{SecretName: "argocd-ca", NotAfter: metav1.NewTime(caNotAfter.UTC()), Managed: true},
+		{SecretName: common.ArgoCDServerTLSSecretName, NotAfter: metav1.NewTime(serverNotAfter.UTC())},
+	}, a.Status.Certificates)
+
+	// the expiring server certificate is not renewed by the operator and is reported
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events))
+	assert.Len(t, events.Items, 1)
+	assert.Equal(t, "CertificateExpiring", events.Items[0].Reason)
+
+	// only the certificates generated by the operator are renewed
+	maxRequeueAfter := time.Until(caNotAfter.Add(-common.ArgoCDDefaultTLSRenewBefore))
+	requeueAfter := r.getCertificateRenewalRequeueAfter(a)
+	assert.Greater(t, requeueAfter, time.Duration(0))
+	assert.LessOrEqual(t, requeueAfter, maxRequeueAfter)
+}
@@ -0,0 +1,194 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	certManagerGroup           = "cert-manager.io"
+	certManagerVersion         = "v1"
+	certManagerKindIssuer      = "Issuer"
+	certManagerKindCertificate = "Certificate"
+)
+
+var certManagerAPIFound = false
+
+// IsCertManagerAPIAvailable returns true if the cert-manager Certificate API is present.
+func IsCertManagerAPIAvailable() bool {
+	return certManagerAPIFound
+}
+
+// verifyCertManagerAPI will verify that the cert-manager Certificate API is present.
+func verifyCertManagerAPI() error {
+	found, err := argoutil.VerifyAPI(certManagerGroup, certManagerVersion)
+	if err != nil {
+		return err
+	}
+	certManagerAPIFound = found
+	return nil
+}
+
+// certManagerCertificate describes a cert-manager Certificate issued for an Argo CD component.
+type certManagerCertificate struct {
+	// component is the name suffix of the component service, e.g. "server".
+	component string
+	// secretName is the name of the TLS secret the component reads its certificate from.
+	secretName string
+	// enabled is true if the Certificate should be present in the cluster.
+	enabled bool
+}
+
+// newCertificate returns a new cert-manager Certificate instance with the given name for the given ArgoCD.
+func newCertificate(name string, cr *argoprojv1a1.ArgoCD) *unstructured.Unstructured {
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   certManagerGroup,
+		Version: certManagerVersion,
+		Kind:    certManagerKindCertificate,
+	})
+	cert.SetName(name)
+	cert.SetNamespace(cr.Namespace)
+	cert.SetLabels(argoutil.LabelsForCluster(cr))
+	return cert
+}
+
+// getCertManagerCertificates returns the cert-manager Certificates managed for the given ArgoCD.
+func getCertManagerCertificates(cr *argoprojv1a1.ArgoCD) []certManagerCertificate {
+	enabled := cr.Spec.TLS.WantsCertManager()
+	return []certManagerCertificate{
+		{component: "server", secretName: common.ArgoCDServerTLSSecretName, enabled: enabled},
+		{component: "repo-server", secretName: common.ArgoCDRepoServerTLSSecretName, enabled: enabled},
+		{component: "dex-server", secretName: common.ArgoCDDexServerTLSSecretName, enabled: enabled && UseDex(cr)},
+	}
+}
+
+// getCertManagerCertificateDNSNames returns the DNS names under which the given component is reachable.
+func getCertManagerCertificateDNSNames(component string, cr *argoprojv1a1.ArgoCD) []interface{} {
+	svc := nameWithSuffix(component, cr)
+	dnsNames := []interface{}{
+		"localhost",
+		svc,
+		fmt.Sprintf("%s.%s", svc, cr.Namespace),
+		fmt.Sprintf("%s.%s.svc", svc, cr.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", svc, cr.Namespace),
+	}
+	if component == "server" && cr.Spec.Server.Host != "" {
+		dnsNames = append(dnsNames, cr.Spec.Server.Host)
+	}
+	return dnsNames
+}
+
+// getCertManagerCertificateSpec returns the desired spec of the cert-manager Certificate for the given component.
+func getCertManagerCertificateSpec(cert certManagerCertificate, cr *argoprojv1a1.ArgoCD) map[string]interface{} {
+	certManager := cr.Spec.TLS.CertManager
+
+	issuerRef := map[string]interface{}{
+		"name":  certManager.IssuerRef.Name,
+		"kind":  certManagerKindIssuer,
+		"group": certManagerGroup,
+	}
+	if certManager.IssuerRef.Kind != "" {
+		issuerRef["kind"] = certManager.IssuerRef.Kind
+	}
+	if certManager.IssuerRef.Group != "" {
+		issuerRef["group"] = certManager.IssuerRef.Group
+	}
+
+	spec := map[string]interface{}{
+		"secretName": cert.secretName,
+		"commonName": nameWithSuffix(cert.component, cr),
+		"dnsNames":   getCertManagerCertificateDNSNames(cert.component, cr),
+		"issuerRef":  issuerRef,
+		// The annotation allows the operator to map renewals of the secret back to the ArgoCD instance.
+		"secretTemplate": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				common.AnnotationName: cr.Name,
+			},
+		},
+	}
+	if certManager.Duration != nil {
+		spec["duration"] = certManager.Duration.Duration.String()
+	}
+	if certManager.RenewBefore != nil {
+		spec["renewBefore"] = certManager.RenewBefore.Duration.String()
+	}
+	return spec
+}
+
+// reconcileCertManagerCertificates will ensure that the cert-manager Certificates for the Argo CD components are present
+// when requested, and removed otherwise.
+func (r *ReconcileArgoCD) reconcileCertManagerCertificates(cr *argoprojv1a1.ArgoCD) error {
+	if !IsCertManagerAPIAvailable() {
+		if cr.Spec.TLS.WantsCertManager() {
+			log.Info(fmt.Sprintf("cert-manager API not found, skipping certificates for Argo CD %s in namespace %s", cr.Name, cr.Namespace))
+		}
+		return nil
+	}
+
+	for _, cert := range getCertManagerCertificates(cr) {
+		if err := r.reconcileCertManagerCertificate(cert, cr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileCertManagerCertificate will ensure that the cert-manager Certificate for the given component is up to date.
+func (r *ReconcileArgoCD) reconcileCertManagerCertificate(cert certManagerCertificate, cr *argoprojv1a1.ArgoCD) error {
+	existing := newCertificate(cert.secretName, cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.GetName(), existing) {
+		if !cert.enabled {
+			// Only remove Certificates that were created by the operator.
+			if !metav1.IsControlledBy(existing, cr) {
+				return nil
+			}
+			log.Info(fmt.Sprintf("deleting certificate %s as cert-manager is not requested", existing.GetName()))
+			return r.Client.Delete(context.TODO(), existing)
+		}
+
+		desiredSpec := getCertManagerCertificateSpec(cert, cr)
+		if !reflect.DeepEqual(existing.Object["spec"], desiredSpec) {
+			existing.Object["spec"] = desiredSpec
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // Certificate found with nothing to do, move along...
+	}
+
+	if !cert.enabled {
+		return nil
+	}
+
+	certificate := newCertificate(cert.secretName, cr)
+	certificate.Object["spec"] = getCertManagerCertificateSpec(cert, cr)
+	if err := controllerutil.SetControllerReference(cr, certificate, r.Scheme); err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("creating certificate %s for Argo CD instance %s in namespace %s", certificate.GetName(), cr.Name, cr.Namespace))
+	return r.Client.Create(context.TODO(), certificate)
+}
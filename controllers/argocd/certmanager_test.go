@@ -0,0 +1,172 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestCertManagerSpec() *argoprojv1alpha1.ArgoCDCertManagerSpec {
+	return &argoprojv1alpha1.ArgoCDCertManagerSpec{
+		Enabled: true,
+		IssuerRef: argoprojv1alpha1.ArgoCDCertManagerIssuerRef{
+			Name: "argocd-ca",
+			Kind: "ClusterIssuer",
+		},
+		Duration: &metav1.Duration{Duration: 720 * time.Hour},
+	}
+}
+
+func setCertManagerAPIFound(t *testing.T, found bool) {
+	certManagerAPIFoundTemp := certManagerAPIFound
+	t.Cleanup(func() {
+		certManagerAPIFound = certManagerAPIFoundTemp
+	})
+	certManagerAPIFound = found
+}
+
+func TestReconcileArgoCD_reconcileCertManagerCertificates(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	setCertManagerAPIFound(t, true)
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.TLS.CertManager = makeTestCertManagerSpec()
+		a.Spec.Server.Host = "argocd.example.com"
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileCertManagerCertificates(a))
+
+	cert := newCertificate(common.ArgoCDServerTLSSecretName, a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cert.GetName(), Namespace: a.Namespace}, cert))
+	assert.True(t, metav1.IsControlledBy(cert, a))
+
+	secretName, _, _ := unstructured.NestedString(cert.Object, "spec", "secretName")
+	assert.Equal(t, common.ArgoCDServerTLSSecretName, secretName)
+	issuerRef, _, _ := unstructured.NestedStringMap(cert.Object, "spec", "issuerRef")
+	assert.Equal(t, map[string]string{"name": "argocd-ca", "kind": "ClusterIssuer", "group": "cert-manager.io"}, issuerRef)
+	duration, _, _ := unstructured.NestedString(cert.Object, "spec", "duration")
+	assert.Equal(t, "720h0m0s", duration)
+	dnsNames, _, _ := unstructured.NestedStringSlice(cert.Object, "spec", "dnsNames")
+	assert.Contains(t, dnsNames, "argocd-server.argocd.svc")
+	assert.Contains(t, dnsNames, "argocd.example.com")
+	annotations, _, _ := unstructured.NestedStringMap(cert.Object, "spec", "secretTemplate", "annotations")
+	assert.Equal(t, a.Name, annotations[common.AnnotationName])
+
+	cert = newCertificate(common.ArgoCDRepoServerTLSSecretName, a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cert.GetName(), Namespace: a.Namespace}, cert))
+
+	// dex is not in use, no certificate is issued for it
+	cert = newCertificate(common.ArgoCDDexServerTLSSecretName, a)
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cert.GetName(), Namespace: a.Namespace}, cert))
+
+	// changing the issuer updates the certificates
+	a.Spec.TLS.CertManager.IssuerRef = argoprojv1alpha1.ArgoCDCertManagerIssuerRef{Name: "other"}
+	assert.NoError(t, r.reconcileCertManagerCertificates(a))
+
+	cert = newCertificate(common.ArgoCDRepoServerTLSSecretName, a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cert.GetName(), Namespace: a.Namespace}, cert))
+	issuerRef, _, _ = unstructured.NestedStringMap(cert.Object, "spec", "issuerRef")
+	assert.Equal(t, map[string]string{"name": "other", "kind": "Issuer", "group": "cert-manager.io"}, issuerRef)
+
+	// disabling cert-manager removes the certificates
+	a.Spec.TLS.CertManager.Enabled = false
+	assert.NoError(t, r.reconcileCertManagerCertificates(a))
+
+	cert = newCertificate(common.ArgoCDServerTLSSecretName, a)
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cert.GetName(), Namespace: a.Namespace}, cert))
+	cert = newCertificate(common.ArgoCDRepoServerTLSSecretName, a)
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cert.GetName(), Namespace: a.Namespace}, cert))
+}
+
+func TestReconcileArgoCD_reconcileCertManagerCertificates_noAPI(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	setCertManagerAPIFound(t, false)
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.TLS.CertManager = makeTestCertManagerSpec()
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileCertManagerCertificates(a))
+}
+
+func TestReconcileArgoCD_reconcileServerTLSSecret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.TLS.CertManager = makeTestCertManagerSpec()
+	})
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.ArgoCDServerTLSSecretName,
+			Namespace: a.Namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("foo"),
+			corev1.TLSPrivateKeyKey: []byte("bar"),
+		},
+	}
+	serverDepl := newDeploymentWithSuffix("server", "server", a)
+	r := makeTestReconciler(t, a, secret, serverDepl)
+
+	assert.NoError(t, r.reconcileServerTLSSecret(a))
+	assert.NotEmpty(t, a.Status.ServerTLSChecksum)
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: serverDepl.Name, Namespace: a.Namespace}, deployment))
+	rollout, ok := deployment.Spec.Template.Labels["server.tls.cert.changed"]
+	assert.True(t, ok)
+
+	// renewing the certificate rolls out the server again
+	secret.Data[corev1.TLSCertKey] = []byte("renewed")
+	assert.NoError(t, r.Client.Update(context.TODO(), secret))
+	checksum := a.Status.ServerTLSChecksum
+
+	assert.NoError(t, r.reconcileServerTLSSecret(a))
+	assert.NotEqual(t, checksum, a.Status.ServerTLSChecksum)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: serverDepl.Name, Namespace: a.Namespace}, deployment))
+	assert.NotEqual(t, rollout, deployment.Spec.Template.Labels["server.tls.cert.changed"])
+}
+
+func TestReconcileArgoCD_reconcileDexDeployment_certManager(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.SSO = &argoprojv1alpha1.ArgoCDSSOSpec{
+			Provider: argoprojv1alpha1.SSOProviderTypeDex,
+			Dex: &argoprojv1alpha1.ArgoCDDexSpec{
+				OpenShiftOAuth: true,
+			},
+		}
+		a.Spec.TLS.CertManager = makeTestCertManagerSpec()
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileDexDeployment(a))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-dex-server", Namespace: a.Namespace}, deployment))
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      common.ArgoCDDexServerTLSSecretName,
+		MountPath: "/tls",
+	})
+	assert.Len(t, deployment.Spec.Template.Spec.Volumes, 2)
+
+	// disabling cert-manager removes the volume again
+	a.Spec.TLS.CertManager.Enabled = false
+	assert.NoError(t, r.reconcileDexDeployment(a))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-dex-server", Namespace: a.Namespace}, deployment))
+	assert.Len(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, 1)
+	assert.Len(t, deployment.Spec.Template.Spec.Volumes, 1)
+}
@@ -0,0 +1,126 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	template "github.com/openshift/api/template/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// optionalResource is a resource the operator only creates for some configurations of an ArgoCD.
+type optionalResource struct {
+	obj    client.Object
+	wanted bool
+}
+
+// getOptionalResources returns the resources the operator only creates for some configurations of the given ArgoCD,
+// along with whether they are needed by its current spec.
+func getOptionalResources(cr *argoprojv1a1.ArgoCD) []optionalResource {
+	appSet := cr.Spec.ApplicationSet != nil
+	keycloak := cr.Spec.SSO != nil && cr.Spec.SSO.Provider == argoprojv1a1.SSOProviderTypeKeycloak
+
+	resources := []optionalResource{
+		{newIngressWithSuffix("server", cr), cr.Spec.Server.Ingress.Enabled},
+		{newIngressWithSuffix("grpc", cr), cr.Spec.Server.GRPC.Ingress.Enabled},
+		{newIngressWithSuffix("grafana", cr), cr.Spec.Grafana.Enabled && cr.Spec.Grafana.Ingress.Enabled},
+		{newIngressWithSuffix("prometheus", cr), cr.Spec.Prometheus.Enabled && cr.Spec.Prometheus.Ingress.Enabled},
+		{newIngressWithSuffix(common.ApplicationSetServiceNameSuffix, cr), appSet && cr.Spec.ApplicationSet.WebhookServer.Ingress.Enabled},
+		{newHorizontalPodAutoscalerWithSuffix("server", cr), cr.Spec.Server.Autoscale.Enabled},
+		{newPodDisruptionBudgetWithSuffix("server", cr), cr.Spec.HA.Enabled},
+		{newPodDisruptionBudgetWithSuffix("repo-server", cr), cr.Spec.HA.Enabled},
+		{newDeploymentWithSuffix("dex-server", "dex-server", cr), UseDex(cr)},
+		{newServiceWithSuffix("dex-server", "dex-server", cr), UseDex(cr)},
+		{newDeploymentWithSuffix("notifications-controller", "controller", cr), cr.Spec.Notifications.Enabled},
+		{newDeploymentWithSuffix("applicationset-controller", "controller", cr), appSet},
+		{newDeploymentWithSuffix("redis", "redis", cr), !cr.Spec.HA.Enabled && !cr.Spec.Redis.IsRemote()},
+		{newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr), cr.Spec.HA.Enabled},
+		{newStatefulSetWithSuffix("redis-ha-server", "redis", cr), cr.Spec.HA.Enabled},
+	}
+
+	if IsRouteAPIAvailable() {
+		resources = append(resources,
+			optionalResource{newRouteWithSuffix("server", cr), cr.Spec.Server.Route.Enabled},
+			optionalResource{newRouteWithSuffix("grafana", cr), cr.Spec.Grafana.Enabled && cr.Spec.Grafana.Route.Enabled},
+			optionalResource{newRouteWithSuffix("prometheus", cr), cr.Spec.Prometheus.Enabled && cr.Spec.Prometheus.Route.Enabled},
+			optionalResource{newRouteWithSuffix(fmt.Sprintf("%s-%s", common.ApplicationSetServiceNameSuffix, "webhook"), cr), appSet && cr.Spec.ApplicationSet.WebhookServer.Route.Enabled},
+		)
+	}
+
+	if IsTemplateAPIAvailable() {
+		resources = append(resources, optionalResource{&template.TemplateInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: defaultTemplateIdentifier, Namespace: cr.Namespace},
+		}, keycloak})
+	} else {
+		meta := metav1.ObjectMeta{Name: defaultKeycloakIdentifier, Namespace: cr.Namespace}
+		resources = append(resources,
+			optionalResource{&appsv1.Deployment{ObjectMeta: meta}, keycloak},
+			optionalResource{&corev1.Service{ObjectMeta: meta}, keycloak},
+			optionalResource{&networkingv1.Ingress{ObjectMeta: meta}, keycloak},
+		)
+	}
+	return resources
+}
+
+// reconcileResourceCleanup will ensure that the resources created by the operator for the given ArgoCD that are no
+// longer needed by its spec are deleted when the resource cleanup is enabled. In dry-run mode, the resources are only
+// reported in the status of the ArgoCD. Only resources controlled by the ArgoCD are considered.
+func (r *ReconcileArgoCD) reconcileResourceCleanup(cr *argoprojv1a1.ArgoCD) error {
+	orphaned := []string{}
+	if cr.Spec.ResourceCleanup.Enabled {
+		for _, resource := range getOptionalResources(cr) {
+			if resource.wanted {
+				continue
+			}
+			obj := resource.obj
+			if !argoutil.IsObjectFound(r.Client, cr.Namespace, obj.GetName(), obj) || !metav1.IsControlledBy(obj, cr) {
+				continue
+			}
+
+			gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+			if err != nil {
+				return err
+			}
+			name := fmt.Sprintf("%s/%s", gvk.Kind, obj.GetName())
+			if cr.Spec.ResourceCleanup.DryRun {
+				log.Info(fmt.Sprintf("found orphaned resource %s", name))
+				orphaned = append(orphaned, name)
+				continue
+			}
+
+			log.Info(fmt.Sprintf("deleting orphaned resource %s", name))
+			if err := r.Client.Delete(context.TODO(), obj); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(orphaned) == 0 {
+		orphaned = nil
+	}
+	cr.Status.OrphanedResources = orphaned
+	return nil
+}
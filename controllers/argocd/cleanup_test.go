@@ -0,0 +1,51 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func TestReconcileArgoCD_reconcileResourceCleanup(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.UID = "argocd-uid"
+		a.Spec.Server.GRPC.Ingress.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	// an Ingress left behind after it was disabled, one still needed, and one not created by the operator
+	server := newIngressWithSuffix("server", a)
+	grpc := newIngressWithSuffix("grpc", a)
+	for _, ing := range []*networkingv1.Ingress{server, grpc} {
+		assert.NoError(t, controllerutil.SetControllerReference(a, ing, r.Scheme))
+		assert.NoError(t, r.Client.Create(context.TODO(), ing))
+	}
+	grafana := newIngressWithSuffix("grafana", a)
+	assert.NoError(t, r.Client.Create(context.TODO(), grafana))
+
+	// nothing is reported or deleted while the cleanup is disabled
+	assert.NoError(t, r.reconcileResourceCleanup(a))
+	assert.Empty(t, a.Status.OrphanedResources)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: server.Name, Namespace: a.Namespace}, server))
+
+	// the orphaned resources are only reported in dry-run mode
+	a.Spec.ResourceCleanup = argoprojv1alpha1.ArgoCDResourceCleanupSpec{Enabled: true, DryRun: true}
+	assert.NoError(t, r.reconcileResourceCleanup(a))
+	assert.Equal(t, []string{"Ingress/" + server.Name}, a.Status.OrphanedResources)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: server.Name, Namespace: a.Namespace}, server))
+
+	a.Spec.ResourceCleanup.DryRun = false
+	assert.NoError(t, r.reconcileResourceCleanup(a))
+	assert.Empty(t, a.Status.OrphanedResources)
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: server.Name, Namespace: a.Namespace}, server))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: grpc.Name, Namespace: a.Namespace}, grpc))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: grafana.Name, Namespace: a.Namespace}, grafana))
+}
@@ -0,0 +1,194 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	cmdParamsPrefixController = "controller."
+	cmdParamsPrefixRepoServer = "reposerver."
+	cmdParamsPrefixServer     = "server."
+)
+
+// cmdParamsEnv maps the argocd-cmd-params-cm keys managed by the operator to the environment variables the Argo CD
+// components read them from.
+var cmdParamsEnv = map[string]string{
+	"controller.operation.processors":        "ARGOCD_APPLICATION_CONTROLLER_OPERATION_PROCESSORS",
+	"controller.repo.server.timeout.seconds": "ARGOCD_APPLICATION_CONTROLLER_REPO_SERVER_TIMEOUT_SECONDS",
+	"controller.status.processors":           "ARGOCD_APPLICATION_CONTROLLER_STATUS_PROCESSORS",
+	"reposerver.parallelism.limit":           "ARGOCD_REPO_SERVER_PARALLELISM_LIMIT",
+	"server.content.security.policy":         "ARGOCD_SERVER_CONTENT_SECURITY_POLICY",
+	"server.enable.gzip":                     "ARGOCD_SERVER_ENABLE_GZIP",
+	"server.insecure":                        "ARGOCD_SERVER_INSECURE",
+	"server.x.frame.options":                 "ARGOCD_SERVER_X_FRAME_OPTIONS",
+}
+
+// getCmdParams will return the argocd-cmd-params-cm data for the given ArgoCD. Only the settings that are set in the
+// spec are returned, so that the components fall back to their own defaults otherwise.
+func getCmdParams(cr *argoprojv1a1.ArgoCD) map[string]string {
+	params := make(map[string]string)
+
+	controller := cr.Spec.CmdParams.Controller
+	if controller.OperationProcessors != nil {
+		params["controller.operation.processors"] = fmt.Sprint(*controller.OperationProcessors)
+	}
+	if controller.RepoServerTimeoutSeconds != nil {
+		params["controller.repo.server.timeout.seconds"] = fmt.Sprint(*controller.RepoServerTimeoutSeconds)
+	}
+	if controller.StatusProcessors != nil {
+		params["controller.status.processors"] = fmt.Sprint(*controller.StatusProcessors)
+	}
+
+	if limit := cr.Spec.CmdParams.RepoServer.ParallelismLimit; limit != nil {
+		params["reposerver.parallelism.limit"] = fmt.Sprint(*limit)
+	}
+
+	server := cr.Spec.CmdParams.Server
+	if server.ContentSecurityPolicy != "" {
+		params["server.content.security.policy"] = server.ContentSecurityPolicy
+	}
+	if server.EnableGZip != nil {
+		params["server.enable.gzip"] = fmt.Sprint(*server.EnableGZip)
+	}
+	if server.Insecure != nil {
+		params["server.insecure"] = fmt.Sprint(*server.Insecure)
+	}
+	if server.XFrameOptions != "" {
+		params["server.x.frame.options"] = server.XFrameOptions
+	}
+	return params
+}
+
+// getCmdParamsEnv will return the environment variables that expose the argocd-cmd-params-cm keys with the given
+// component prefix to the component. Only the keys set in the spec of the given ArgoCD are exposed.
+func getCmdParamsEnv(prefix string, cr *argoprojv1a1.ArgoCD) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0)
+	for key := range getCmdParams(cr) {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		env = append(env, corev1.EnvVar{
+			Name: cmdParamsEnv[key],
+			ValueFrom: &corev1.EnvVarSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: common.ArgoCDCmdParamsConfigMapName,
+					},
+					Key:      key,
+					Optional: boolPtr(true),
+				},
+			},
+		})
+	}
+	sort.Slice(env, func(i, j int) bool {
+		return env[i].Name < env[j].Name
+	})
+	return env
+}
+
+// getCmdParamsChecksum will return the SHA256 checksum of the given argocd-cmd-params-cm data, or an empty string if
+// there is no data.
+func getCmdParamsChecksum(data map[string]string) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var sumBytes []byte
+	for _, key := range keys {
+		sumBytes = append(sumBytes, fmt.Sprintf("%s=%s\n", key, data[key])...)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(sumBytes))
+}
+
+// reconcileCmdParamsConfigMap will ensure that the argocd-cmd-params-cm ConfigMap holds the settings of the given
+// ArgoCD, and rolls out the Argo CD components when the settings have changed since the last reconciliation. A
+// ConfigMap that was not created by the operator is left untouched.
+func (r *ReconcileArgoCD) reconcileCmdParamsConfigMap(cr *argoprojv1a1.ArgoCD) error {
+	cm := newConfigMapWithName(common.ArgoCDCmdParamsConfigMapName, cr)
+	cm.Data = getCmdParams(cr)
+
+	existing := newConfigMapWithName(common.ArgoCDCmdParamsConfigMapName, cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+		// A ConfigMap created before the operator managed it may be in use through the environment of the components.
+		if !metav1.IsControlledBy(existing, cr) {
+			log.Info(fmt.Sprintf("configmap %s is not managed by Argo CD instance %s, skipping", existing.Name, cr.Name))
+			return nil
+		}
+
+		if !reflect.DeepEqual(existing.Data, cm.Data) && !(len(existing.Data) == 0 && len(cm.Data) == 0) {
+			existing.Data = cm.Data
+			if err := r.Client.Update(context.TODO(), existing); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Client.Create(context.TODO(), cm); err != nil {
+			return err
+		}
+	}
+
+	checksum := getCmdParamsChecksum(cm.Data)
+	if cr.Status.CmdParamsChecksum == checksum {
+		return nil
+	}
+
+	// Adding or removing a setting changes the environment of the components, which rolls them out already. Only
+	// changed values of settings that were present before require an explicit rollout.
+	rollout := cr.Status.CmdParamsChecksum != "" && checksum != ""
+
+	// We store the value early to prevent a possible restart loop, for the cost of a possibly missed restart when we
+	// cannot update the status field of the resource.
+	cr.Status.CmdParamsChecksum = checksum
+
+	if !rollout {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("argocd-cmd-params-cm of Argo CD instance %s changed, rolling out components", cr.Name))
+	for _, obj := range []interface{}{
+		newStatefulSetWithSuffix("application-controller", "application-controller", cr),
+		newDeploymentWithSuffix("repo-server", "repo-server", cr),
+		newDeploymentWithSuffix("server", "server", cr),
+	} {
+		if err := r.triggerRollout(obj, "cmd.params.changed"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func int32Ptr(val int32) *int32 {
+	return &val
+}
+
+func TestReconcileArgoCD_reconcileCmdParamsConfigMap(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.CmdParams.Controller.StatusProcessors = int32Ptr(30)
+		a.Spec.CmdParams.RepoServer.ParallelismLimit = int32Ptr(10)
+		a.Spec.CmdParams.Server.EnableGZip = boolPtr(true)
+		a.Spec.CmdParams.Server.XFrameOptions = "deny"
+	})
+	serverDepl := newDeploymentWithSuffix("server", "server", a)
+	repoDepl := newDeploymentWithSuffix("repo-server", "repo-server", a)
+	r := makeTestReconciler(t, a, serverDepl, repoDepl)
+
+	assert.NoError(t, r.reconcileCmdParamsConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDCmdParamsConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, map[string]string{
+		"controller.status.processors": "30",
+		"reposerver.parallelism.limit": "10",
+		"server.enable.gzip":           "true",
+		"server.x.frame.options":       "deny",
+	}, cm.Data)
+	assert.NotEmpty(t, a.Status.CmdParamsChecksum)
+
+	// the components are not rolled out when the ConfigMap is created
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: serverDepl.Name, Namespace: a.Namespace}, deployment))
+	_, ok := deployment.Spec.Template.Labels["cmd.params.changed"]
+	assert.False(t, ok)
+
+	// changing a value updates the ConfigMap and rolls out the components
+	checksum := a.Status.CmdParamsChecksum
+	a.Spec.CmdParams.RepoServer.ParallelismLimit = int32Ptr(5)
+	assert.NoError(t, r.reconcileCmdParamsConfigMap(a))
+	assert.NotEqual(t, checksum, a.Status.CmdParamsChecksum)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDCmdParamsConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, "5", cm.Data["reposerver.parallelism.limit"])
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: repoDepl.Name, Namespace: a.Namespace}, deployment))
+	_, ok = deployment.Spec.Template.Labels["cmd.params.changed"]
+	assert.True(t, ok)
+
+	// removing all settings empties the ConfigMap
+	a.Spec.CmdParams = argoprojv1alpha1.ArgoCDCmdParamsSpec{}
+	assert.NoError(t, r.reconcileCmdParamsConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDCmdParamsConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Empty(t, cm.Data)
+	assert.Empty(t, a.Status.CmdParamsChecksum)
+}
+
+func TestGetCmdParamsEnv(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.CmdParams.Controller.StatusProcessors = int32Ptr(30)
+		a.Spec.CmdParams.Controller.RepoServerTimeoutSeconds = int32Ptr(120)
+		a.Spec.CmdParams.Server.EnableGZip = boolPtr(true)
+	})
+
+	env := getCmdParamsEnv(cmdParamsPrefixController, a)
+	assert.Len(t, env, 2)
+	assert.Equal(t, "ARGOCD_APPLICATION_CONTROLLER_REPO_SERVER_TIMEOUT_SECONDS", env[0].Name)
+	assert.Equal(t, "ARGOCD_APPLICATION_CONTROLLER_STATUS_PROCESSORS", env[1].Name)
+	assert.Equal(t, common.ArgoCDCmdParamsConfigMapName, env[1].ValueFrom.ConfigMapKeyRef.Name)
+	assert.Equal(t, "controller.status.processors", env[1].ValueFrom.ConfigMapKeyRef.Key)
+
+	assert.Empty(t, getCmdParamsEnv(cmdParamsPrefixRepoServer, a))
+	assert.Len(t, getCmdParamsEnv(cmdParamsPrefixServer, a), 1)
+}
+
+func TestGetArgoApplicationControllerCommand_cmdParams(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Controller.Processors.Status = 50
+		a.Spec.CmdParams.Controller.StatusProcessors = int32Ptr(30)
+	})
+
+	cmd := getArgoApplicationControllerCommand(a, false)
+	assert.Contains(t, cmd, "30")
+	assert.NotContains(t, cmd, "50")
+}
+
+func TestGetArgoServerCommand_cmdParamsInsecure(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Insecure = true
+		a.Spec.CmdParams.Server.Insecure = boolPtr(false)
+	})
+	assert.NotContains(t, getArgoServerCommand(a, false), "--insecure")
+
+	a.Spec.CmdParams.Server.Insecure = boolPtr(true)
+	a.Spec.Server.Insecure = false
+	assert.Contains(t, getArgoServerCommand(a, false), "--insecure")
+}
+
+func TestReconcileArgoCD_reconcileCmdParamsConfigMap_existingConfigMap(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.CmdParams.Server.EnableGZip = boolPtr(true)
+	})
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDCmdParamsConfigMapName, Namespace: a.Namespace},
+		Data:       map[string]string{"server.disable.auth": "false"},
+	}
+	r := makeTestReconciler(t, a, existing)
+
+	assert.NoError(t, r.reconcileCmdParamsConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDCmdParamsConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, map[string]string{"server.disable.auth": "false"}, cm.Data)
+}
@@ -0,0 +1,330 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+// componentGroup reconciles the workloads and networking resources of a component, which do not depend on the
+// resources of the other components. The shared secrets and config maps are reconciled before any group.
+type componentGroup struct {
+	name      string
+	reconcile func() error
+}
+
+// getComponentGroups returns the component groups of the given ArgoCD. The resources of a group are reconciled in
+// order, but groups may be reconciled concurrently.
+func (r *ReconcileArgoCD) getComponentGroups(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) []componentGroup {
+	groups := []componentGroup{
+		{"dex", func() error { return r.reconcileDexWorkload(cr) }},
+		{"redis", func() error { return r.reconcileRedisWorkload(cr, useTLSForRedis) }},
+		{"repo-server", func() error { return r.reconcileRepoServerWorkload(cr, useTLSForRedis) }},
+		{"server", func() error { return r.reconcileServerWorkload(cr, useTLSForRedis) }},
+		{"application-controller", func() error { return r.reconcileApplicationControllerWorkload(cr, useTLSForRedis) }},
+		{"applicationset", func() error { return r.reconcileApplicationSetComponent(cr) }},
+		{"notifications", func() error { return r.reconcileNotificationsComponent(cr) }},
+		{"monitoring", func() error { return r.reconcileMonitoring(cr) }},
+		{"argocd-agent", func() error { return r.reconcileArgoCDAgent(cr) }},
+		{"initial projects", func() error { return r.reconcileInitialProjects(cr) }},
+	}
+	return groups
+}
+
+// componentError is the error of a component of an ArgoCD that failed to reconcile.
+type componentError struct {
+	component string
+	err       error
+}
+
+func (e *componentError) Error() string {
+	return fmt.Sprintf("failed to reconcile %s: %v", e.component, e.err)
+}
+
+func (e *componentError) Unwrap() error {
+	return e.err
+}
+
+// componentErrors aggregates the errors of the components of an ArgoCD that failed to reconcile, so that a failing
+// component does not prevent the other components from being reconciled.
+type componentErrors []*componentError
+
+func (e componentErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// components returns the names of the failed components, without duplicates.
+func (e componentErrors) components() []string {
+	components := []string{}
+	for _, err := range e {
+		if !containsString(components, err.component) {
+			components = append(components, err.component)
+		}
+	}
+	return components
+}
+
+// reconcileComponentGroups will reconcile the component groups of the given ArgoCD concurrently, and return the errors
+// of the groups that failed once all groups are done. The groups are reconciled one after the other during an ordered
+// upgrade, so that the stage of the upgrade is determined in the upgrade order.
+func (r *ReconcileArgoCD) reconcileComponentGroups(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) componentErrors {
+	groups := r.getComponentGroups(cr, useTLSForRedis)
+	errs := make([]error, len(groups))
+	reconcileGroup := func(i int) {
+		log.Info(fmt.Sprintf("reconciling %s", groups[i].name))
+		errs[i] = groups[i].reconcile()
+	}
+
+	if isOrderedUpgrade(cr) {
+		for i := range groups {
+			reconcileGroup(i)
+		}
+	} else {
+		g := errgroup.Group{}
+		for i := range groups {
+			i := i
+			g.Go(func() error {
+				reconcileGroup(i)
+				return nil
+			})
+		}
+		_ = g.Wait()
+	}
+
+	var failed componentErrors
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, &componentError{component: groups[i].name, err: err})
+		}
+	}
+	return failed
+}
+
+// reconcileStatusComponentErrors will ensure that the ComponentReconcileFailed condition of the given ArgoCD reports
+// the components that failed to reconcile. A warning event is emitted when the failures change. The condition is only
+// maintained once a component failed.
+func (r *ReconcileArgoCD) reconcileStatusComponentErrors(cr *argoprojv1a1.ArgoCD, errs componentErrors) {
+	if len(errs) == 0 && meta.FindStatusCondition(cr.Status.Conditions, argoprojv1a1.ArgoCDConditionTypeComponentReconcileFailed) == nil {
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:    argoprojv1a1.ArgoCDConditionTypeComponentReconcileFailed,
+		Status:  metav1.ConditionFalse,
+		Reason:  "Reconciled",
+		Message: "all components were reconciled",
+	}
+	if len(errs) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ReconcileFailed"
+		condition.Message = fmt.Sprintf("components %s failed to reconcile: %s", strings.Join(errs.components(), ", "), errs.Error())
+	}
+	condition.ObservedGeneration = cr.Generation
+
+	r.setConditionWithEvent(cr, condition, condition.Reason)
+}
+
+// reconcileDexWorkload will reconcile the Service, Deployment and metrics of Dex for the given ArgoCD. Errors are only
+// logged, as the SSO configuration is validated and reported before.
+func (r *ReconcileArgoCD) reconcileDexWorkload(cr *argoprojv1a1.ArgoCD) error {
+	if err := r.reconcileDexService(cr); err != nil {
+		log.Error(err, "error reconciling dex service")
+	}
+
+	if err := r.reconcileDexDeployment(cr); err != nil {
+		log.Error(err, "error reconciling dex deployment")
+	}
+
+	if IsPrometheusAPIAvailable() {
+		return r.reconcileDexServiceMonitor(cr)
+	}
+	return nil
+}
+
+// reconcileRedisWorkload will reconcile the Services, Deployments and StatefulSet of Redis for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileRedisWorkload(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) error {
+	if !cr.Spec.Redis.IsEnabled() {
+		return nil
+	}
+
+	if err := r.reconcileRedisHAServices(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileRedisService(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileRedisDeployment(cr, useTLSForRedis); err != nil {
+		return err
+	}
+
+	if err := r.reconcileRedisHAProxyDeployment(cr); err != nil {
+		return err
+	}
+
+	return r.reconcileRedisStatefulSet(cr)
+}
+
+// reconcileRepoServerWorkload will reconcile the Service, Deployment, PodDisruptionBudget and metrics of the repo
+// server for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileRepoServerWorkload(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) error {
+	if !isRepoServerDeployed(cr) {
+		return nil
+	}
+
+	if err := r.reconcileRepoService(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileRepoDeployment(cr, useTLSForRedis); err != nil {
+		return err
+	}
+
+	if err := r.reconcilePodDisruptionBudget("repo-server", cr); err != nil {
+		return err
+	}
+
+	if IsPrometheusAPIAvailable() {
+		return r.reconcileRepoServerServiceMonitor(cr)
+	}
+	return nil
+}
+
+// reconcileServerWorkload will reconcile the Services, Deployment, autoscaling, disruption budget, exposure and metrics
+// of the Argo CD server for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileServerWorkload(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) error {
+	// The Istio objects are reconciled regardless of the server, so that they are removed once Istio is disabled.
+	if IsIstioAPIAvailable() {
+		if err := r.reconcileIstio(cr); err != nil {
+			return err
+		}
+	}
+
+	if !cr.Spec.Server.IsEnabled() {
+		return nil
+	}
+
+	if err := r.reconcileServerMetricsService(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileServerService(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileServerDeployment(cr, useTLSForRedis); err != nil {
+		return err
+	}
+
+	if err := r.reconcileServerHPA(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcilePodDisruptionBudget("server", cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileArgoServerIngress(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileArgoServerGRPCIngress(cr); err != nil {
+		return err
+	}
+
+	if IsRouteAPIAvailable() {
+		if err := r.reconcileServerRoute(cr); err != nil {
+			return err
+		}
+	}
+
+	if IsPrometheusAPIAvailable() {
+		return r.reconcileServerMetricsServiceMonitor(cr)
+	}
+	return nil
+}
+
+// reconcileApplicationControllerWorkload will reconcile the metrics Service, StatefulSet and metrics of the application
+// controller for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileApplicationControllerWorkload(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) error {
+	if !cr.Spec.Controller.IsEnabled() {
+		return nil
+	}
+
+	if err := r.reconcileMetricsService(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileApplicationControllerStatefulSet(cr, useTLSForRedis); err != nil {
+		return err
+	}
+
+	if IsPrometheusAPIAvailable() {
+		return r.reconcileMetricsServiceMonitor(cr)
+	}
+	return nil
+}
+
+// reconcileMonitoring will reconcile the Grafana and Prometheus resources of the given ArgoCD, along with the rules
+// that alert on the state of its workloads.
+func (r *ReconcileArgoCD) reconcileMonitoring(cr *argoprojv1a1.ArgoCD) error {
+	if err := r.reconcileGrafanaService(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileGrafanaDeployment(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileGrafanaIngress(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcilePrometheusIngress(cr); err != nil {
+		return err
+	}
+
+	if IsRouteAPIAvailable() {
+		if err := r.reconcileGrafanaRoute(cr); err != nil {
+			return err
+		}
+
+		if err := r.reconcilePrometheusRoute(cr); err != nil {
+			return err
+		}
+	}
+
+	if IsPrometheusAPIAvailable() {
+		if err := r.reconcilePrometheus(cr); err != nil {
+			return err
+		}
+
+		// Reconciles prometheusRule created to alert based on argo-cd workload status
+		return r.reconcilePrometheusRule(cr)
+	}
+	return nil
+}
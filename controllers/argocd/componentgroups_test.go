@@ -0,0 +1,96 @@
+package argocd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+// failingPatchClient is a client.Client that fails to patch the Deployment with the given name.
+type failingPatchClient struct {
+	client.Client
+	name string
+}
+
+func (c *failingPatchClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if _, ok := obj.(*appsv1.Deployment); ok && obj.GetName() == c.name {
+		return errors.New("patch failed")
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func TestReconcileArgoCD_reconcileComponentGroups(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	assert.Empty(t, r.reconcileComponentGroups(a, false))
+
+	for _, name := range []string{"argocd-redis", "argocd-repo-server", "argocd-server"} {
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, &appsv1.Deployment{}))
+	}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, &appsv1.StatefulSet{}))
+}
+
+func TestReconcileArgoCD_reconcileComponentGroups_error(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	r.Client = &failingPatchClient{Client: r.Client, name: "argocd-repo-server"}
+
+	errs := r.reconcileComponentGroups(a, false)
+	assert.Equal(t, []string{"repo-server"}, errs.components())
+	assert.EqualError(t, errs, "failed to reconcile repo-server: patch failed")
+
+	// the failure of a group does not prevent the other groups from being reconciled
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, &appsv1.Deployment{}))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, &appsv1.StatefulSet{}))
+}
+
+func TestReconcileArgoCD_Reconcile_componentFailure(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Notifications.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	r.Client = &failingPatchClient{Client: r.Client, name: "argocd-repo-server"}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.ErrorContains(t, err, "failed to reconcile repo-server: patch failed")
+
+	// the components after the failing one still converge
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-notifications-controller", Namespace: a.Namespace}, &appsv1.Deployment{}))
+
+	// the failed components are reported in the status
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionTypeComponentReconcileFailed)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "components repo-server failed to reconcile: failed to reconcile repo-server: patch failed", condition.Message)
+
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events))
+	assert.Len(t, events.Items, 1)
+	assert.Equal(t, "ComponentReconcileFailed", events.Items[0].Reason)
+
+	// the condition is cleared once the components reconcile
+	r.Client = r.Client.(*failingPatchClient).Client
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	assert.True(t, meta.IsStatusConditionFalse(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionTypeComponentReconcileFailed))
+}
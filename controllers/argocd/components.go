@@ -0,0 +1,126 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// isRepoServerDeployed returns true if the operator deploys the repo server of the given ArgoCD, i.e. the repo server
+// is enabled and no remote repo server is used.
+func isRepoServerDeployed(cr *argoprojv1a1.ArgoCD) bool {
+	return cr.Spec.Repo.IsEnabled() && !cr.Spec.Repo.IsRemote()
+}
+
+// isRedisDeployed returns true if the operator deploys the Redis server of the given ArgoCD, i.e. Redis is enabled
+// and no remote Redis server is used.
+func isRedisDeployed(cr *argoprojv1a1.ArgoCD) bool {
+	return cr.Spec.Redis.IsEnabled() && !cr.Spec.Redis.IsRemote()
+}
+
+// getDisabledComponentResources returns the resources created by the operator for the components of the given ArgoCD
+// that are disabled. The reconcilers of these resources are skipped, and the resources are deleted instead.
+func getDisabledComponentResources(cr *argoprojv1a1.ArgoCD) []client.Object {
+	resources := []client.Object{}
+
+	if !cr.Spec.Controller.IsEnabled() {
+		resources = append(resources,
+			newStatefulSetWithSuffix("application-controller", "application-controller", cr),
+			newServiceWithSuffix(common.ArgoCDKeyMetrics, common.ArgoCDKeyMetrics, cr),
+		)
+		if IsPrometheusAPIAvailable() {
+			resources = append(resources, newServiceMonitorWithSuffix(common.ArgoCDKeyMetrics, cr))
+		}
+	}
+
+	if !cr.Spec.Redis.IsEnabled() {
+		resources = append(resources,
+			newDeploymentWithSuffix("redis", "redis", cr),
+			newServiceWithSuffix("redis", "redis", cr),
+			newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr),
+			newStatefulSetWithSuffix("redis-ha-server", "redis", cr),
+			newServiceWithSuffix("redis-ha", "redis", cr),
+			newServiceWithSuffix("redis-ha-haproxy", "redis", cr),
+		)
+		for i := int32(0); i < *getRedisHAReplicas(cr); i++ {
+			resources = append(resources, newServiceWithSuffix(fmt.Sprintf("redis-ha-announce-%d", i), "redis", cr))
+		}
+	}
+
+	if !isRepoServerDeployed(cr) {
+		resources = append(resources,
+			newDeploymentWithSuffix("repo-server", "repo-server", cr),
+			newServiceWithSuffix("repo-server", "repo-server", cr),
+			newPodDisruptionBudgetWithSuffix("repo-server", cr),
+		)
+		if IsPrometheusAPIAvailable() {
+			resources = append(resources, newServiceMonitorWithSuffix("repo-server-metrics", cr))
+		}
+	}
+
+	if !cr.Spec.Server.IsEnabled() {
+		resources = append(resources,
+			newDeploymentWithSuffix("server", "server", cr),
+			newServiceWithSuffix("server", "server", cr),
+			newServiceWithSuffix("server-metrics", "server", cr),
+			newHorizontalPodAutoscalerWithSuffix("server", cr),
+			newPodDisruptionBudgetWithSuffix("server", cr),
+			newIngressWithSuffix("server", cr),
+			newIngressWithSuffix("grpc", cr),
+		)
+		if IsRouteAPIAvailable() {
+			resources = append(resources, newRouteWithSuffix("server", cr))
+		}
+		if IsPrometheusAPIAvailable() {
+			resources = append(resources, newServiceMonitorWithSuffix("server-metrics", cr))
+		}
+	}
+
+	return resources
+}
+
+// reconcileDisabledComponents will ensure that the resources of the components disabled for the given ArgoCD are
+// deleted. Only resources controlled by the ArgoCD are considered.
+func (r *ReconcileArgoCD) reconcileDisabledComponents(cr *argoprojv1a1.ArgoCD) error {
+	return r.deleteControlledResources(cr, getDisabledComponentResources(cr), "its component is disabled")
+}
+
+// deleteControlledResources will delete the given resources that exist and are controlled by the given ArgoCD, logging
+// the given reason for the deletion.
+func (r *ReconcileArgoCD) deleteControlledResources(cr *argoprojv1a1.ArgoCD, resources []client.Object, reason string) error {
+	for _, obj := range resources {
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, obj.GetName(), obj) || !metav1.IsControlledBy(obj, cr) {
+			continue
+		}
+		gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("deleting %s/%s as %s", gvk.Kind, obj.GetName(), reason))
+		if err := r.Client.Delete(context.TODO(), obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func TestReconcileArgoCD_reconcileDisabledComponents(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.UID = "argocd-uid"
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileRedisWorkload(a, false))
+	assert.NoError(t, r.reconcileRepoServerWorkload(a, false))
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+
+	// a Deployment with the name of a component that was not created by the operator is kept
+	repo := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-repo-server", Namespace: a.Namespace}, repo))
+	repo.OwnerReferences = nil
+	assert.NoError(t, r.Client.Update(context.TODO(), repo))
+
+	a.Spec.Server.Enabled = boolPtr(false)
+	a.Spec.Repo.Enabled = boolPtr(false)
+	assert.NoError(t, r.reconcileDisabledComponents(a))
+	assert.NoError(t, r.reconcileRepoServerWorkload(a, false))
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-server", Namespace: a.Namespace}, &appsv1.Deployment{}))
+	for _, name := range []string{a.Name + "-server", a.Name + "-server-metrics", a.Name + "-repo-server"} {
+		assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, &corev1.Service{}))
+	}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: repo.Name, Namespace: a.Namespace}, repo))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-redis", Namespace: a.Namespace}, &appsv1.Deployment{}))
+
+	// the component is deployed again once it is enabled
+	a.Spec.Server.Enabled = boolPtr(true)
+	assert.NoError(t, r.reconcileDisabledComponents(a))
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-server", Namespace: a.Namespace}, &appsv1.Deployment{}))
+}
+
+func TestReconcileArgoCD_reconcileStatusPhase_disabledComponents(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Enabled = boolPtr(false)
+		a.Spec.Repo.Enabled = boolPtr(false)
+		a.Spec.Redis.Enabled = boolPtr(false)
+	})
+	controller := newStatefulSetWithSuffix("application-controller", "application-controller", a)
+	controller.Spec.Replicas = int32Ptr(1)
+	controller.Status.ReadyReplicas = 1
+	r := makeTestReconciler(t, a, controller)
+
+	assert.NoError(t, r.reconcileStatus(a))
+	assert.Equal(t, "Running", a.Status.ApplicationController)
+	assert.Empty(t, a.Status.Server)
+	assert.Empty(t, a.Status.Repo)
+	assert.Empty(t, a.Status.Redis)
+	assert.Equal(t, "Available", a.Status.Phase)
+}
+
+func TestReconcileArgoCD_remoteRepoServer(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Repo.Remote = "argocd-repo-server.hub.svc.cluster.local:8081"
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileRepoServerWorkload(a, false))
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+
+	// the repo server is not deployed, the other components use the remote address
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-repo-server", Namespace: a.Namespace}, &appsv1.Deployment{}))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-repo-server", Namespace: a.Namespace}, &corev1.Service{}))
+
+	server := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-server", Namespace: a.Namespace}, server))
+	assert.Contains(t, server.Spec.Template.Spec.Containers[0].Command, "argocd-repo-server.hub.svc.cluster.local:8081")
+	assert.Contains(t, getArgoApplicationControllerCommand(a, false), "argocd-repo-server.hub.svc.cluster.local:8081")
+
+	assert.NoError(t, r.reconcileStatus(a))
+	assert.Empty(t, a.Status.Repo)
+}
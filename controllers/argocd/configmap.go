@@ -16,15 +16,21 @@ package argocd
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
-	"io/ioutil"
+	"io"
+	"path"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
+	"unicode"
 
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
@@ -34,11 +40,18 @@ import (
 )
 
 // createRBACConfigMap will create the Argo CD RBAC ConfigMap resource.
-func (r *ReconcileArgoCD) createRBACConfigMap(cm *corev1.ConfigMap, cr *argoprojv1a1.ArgoCD) error {
+// The policy CSV of the given ArgoCD is only applied if it is valid, the default policy is used otherwise.
+func (r *ReconcileArgoCD) createRBACConfigMap(cm *corev1.ConfigMap, cr *argoprojv1a1.ArgoCD, validPolicy bool) error {
 	data := make(map[string]string)
-	data[common.ArgoCDKeyRBACPolicyCSV] = getRBACPolicy(cr)
+	data[common.ArgoCDKeyRBACPolicyCSV] = common.ArgoCDDefaultRBACPolicy
+	if validPolicy {
+		data[common.ArgoCDKeyRBACPolicyCSV] = getRBACPolicy(cr)
+	}
 	data[common.ArgoCDKeyRBACPolicyDefault] = getRBACDefaultPolicy(cr)
 	data[common.ArgoCDKeyRBACScopes] = getRBACScopes(cr)
+	if cr.Spec.RBAC.PolicyMatcherMode != nil {
+		data[common.ArgoCDPolicyMatcherMode] = *cr.Spec.RBAC.PolicyMatcherMode
+	}
 	cm.Data = data
 
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
@@ -56,6 +69,16 @@ func getApplicationInstanceLabelKey(cr *argoprojv1a1.ArgoCD) string {
 	return key
 }
 
+// getValidApplicationInstanceLabelKey will return the application instance label key for the given ArgoCD, or an
+// error if it is not a valid label key.
+func getValidApplicationInstanceLabelKey(cr *argoprojv1a1.ArgoCD) (string, error) {
+	key := getApplicationInstanceLabelKey(cr)
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return "", fmt.Errorf("invalid application instance label key %q: %s", key, strings.Join(errs, "; "))
+	}
+	return key, nil
+}
+
 // getCAConfigMapName will return the CA ConfigMap name for the given ArgoCD.
 func getCAConfigMapName(cr *argoprojv1a1.ArgoCD) string {
 	if len(cr.Spec.TLS.CA.ConfigMapName) > 0 {
@@ -196,6 +219,21 @@ func getResourceIgnoreDifferences(cr *argoprojv1a1.ArgoCD) (map[string]string, e
 	return ignoreDiff, nil
 }
 
+// isResourceCustomizationKey returns true if the given argocd-cm key holds a health check, action or ignore differences
+// customization.
+func isResourceCustomizationKey(key string) bool {
+	for _, prefix := range []string{
+		common.ArgoCDKeyResourceCustomizations + ".health.",
+		common.ArgoCDKeyResourceCustomizations + ".actions.",
+		common.ArgoCDKeyResourceCustomizations + ".ignoreDifferences.",
+	} {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // getResourceActions loads custom actions to `resource.customizations.actions` from argocd-cm ConfigMap
 func getResourceActions(cr *argoprojv1a1.ArgoCD) map[string]string {
 	action := make(map[string]string)
@@ -210,22 +248,304 @@ func getResourceActions(cr *argoprojv1a1.ArgoCD) map[string]string {
 	return action
 }
 
-// getResourceExclusions will return the resource exclusions for the given ArgoCD.
-func getResourceExclusions(cr *argoprojv1a1.ArgoCD) string {
+// validateResourceCustomizations will return an error if any of the resource health checks, actions or ignore
+// differences customizations of the given ArgoCD is malformed, or if the same group/kind is customized twice, which
+// would silently discard one of the entries.
+func validateResourceCustomizations(cr *argoprojv1a1.ArgoCD) error {
+	healthChecks := make(map[string]bool)
+	for i, healthCheck := range cr.Spec.ResourceHealthChecks {
+		if err := validateCustomizationGroupKind(healthCheck.Group, healthCheck.Kind, healthChecks); err != nil {
+			return fmt.Errorf("illegal resource health check %d: %w", i, err)
+		}
+		if strings.TrimSpace(healthCheck.Check) == "" {
+			return fmt.Errorf("illegal resource health check %d: check must not be empty", i)
+		}
+	}
+
+	actions := make(map[string]bool)
+	for i, action := range cr.Spec.ResourceActions {
+		if err := validateCustomizationGroupKind(action.Group, action.Kind, actions); err != nil {
+			return fmt.Errorf("illegal resource action %d: %w", i, err)
+		}
+		if strings.TrimSpace(action.Action) == "" {
+			return fmt.Errorf("illegal resource action %d: action must not be empty", i)
+		}
+	}
+
+	if cr.Spec.ResourceIgnoreDifferences != nil {
+		if all := cr.Spec.ResourceIgnoreDifferences.All; all != nil {
+			if err := validateIgnoreDifferenceCustomization(*all); err != nil {
+				return fmt.Errorf("illegal resource ignore differences for all resources: %w", err)
+			}
+		}
+
+		ignoreDifferences := make(map[string]bool)
+		for i, identifier := range cr.Spec.ResourceIgnoreDifferences.ResourceIdentifiers {
+			if err := validateCustomizationGroupKind(identifier.Group, identifier.Kind, ignoreDifferences); err != nil {
+				return fmt.Errorf("illegal resource ignore differences %d: %w", i, err)
+			}
+			if err := validateIgnoreDifferenceCustomization(identifier.Customization); err != nil {
+				return fmt.Errorf("illegal resource ignore differences %d: %w", i, err)
+			}
+			if reflect.DeepEqual(identifier.Customization, v1alpha1.IgnoreDifferenceCustomization{}) {
+				return fmt.Errorf("illegal resource ignore differences %d: customization must not be empty", i)
+			}
+		}
+	}
+	return nil
+}
+
+// validateCustomizationGroupKind will return an error if the given group and kind are malformed, or have already been
+// recorded in the given set of seen group/kinds.
+func validateCustomizationGroupKind(group string, kind string, seen map[string]bool) error {
+	if err := validateAPIGroup(group); err != nil {
+		return err
+	}
+	if err := validateKind(kind); err != nil {
+		return err
+	}
+
+	key := group + "_" + kind
+	if seen[key] {
+		return fmt.Errorf("duplicate customization for group %q and kind %q", group, kind)
+	}
+	seen[key] = true
+	return nil
+}
+
+// validateIgnoreDifferenceCustomization will return an error if any of the JSON pointers of the given customization
+// is malformed.
+func validateIgnoreDifferenceCustomization(customization v1alpha1.IgnoreDifferenceCustomization) error {
+	for _, pointer := range customization.JsonPointers {
+		if !strings.HasPrefix(pointer, "/") {
+			return fmt.Errorf("jsonPointer %q must start with '/'", pointer)
+		}
+	}
+	for _, expression := range customization.JqPathExpressions {
+		if strings.TrimSpace(expression) == "" {
+			return fmt.Errorf("jqPathExpression must not be empty")
+		}
+	}
+	return nil
+}
+
+// getExtraConfigConflicts will return the sorted keys of the ExtraConfig of the given ArgoCD that override a different
+// value the operator rendered into argocd-cm from the first-class fields of the ArgoCD.
+func getExtraConfigConflicts(data map[string]string, cr *argoprojv1a1.ArgoCD) []string {
+	var conflicts []string
+	for k, v := range cr.Spec.ExtraConfig {
+		if current, ok := data[k]; ok && current != v {
+			conflicts = append(conflicts, k)
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// getResourceExclusions will return the resource exclusions for the given ArgoCD. The structured ExcludedResources
+// take precedence over the free-form ResourceExclusions.
+func getResourceExclusions(cr *argoprojv1a1.ArgoCD) (string, error) {
+	if len(cr.Spec.ExcludedResources) > 0 {
+		return marshalResourceFilters(cr.Spec.ExcludedResources)
+	}
+
 	re := common.ArgoCDDefaultResourceExclusions
 	if cr.Spec.ResourceExclusions != "" {
 		re = cr.Spec.ResourceExclusions
 	}
-	return re
+	return re, nil
 }
 
-// getResourceInclusions will return the resource inclusions for the given ArgoCD.
-func getResourceInclusions(cr *argoprojv1a1.ArgoCD) string {
+// getResourceInclusions will return the resource inclusions for the given ArgoCD. The structured IncludedResources
+// take precedence over the free-form ResourceInclusions.
+func getResourceInclusions(cr *argoprojv1a1.ArgoCD) (string, error) {
+	if len(cr.Spec.IncludedResources) > 0 {
+		return marshalResourceFilters(cr.Spec.IncludedResources)
+	}
+
 	re := common.ArgoCDDefaultResourceInclusions
 	if cr.Spec.ResourceInclusions != "" {
 		re = cr.Spec.ResourceInclusions
 	}
-	return re
+	return re, nil
+}
+
+// marshalResourceFilters will validate the given resource filters and return them in the YAML format expected by the
+// resource.exclusions and resource.inclusions keys of argocd-cm.
+func marshalResourceFilters(filters []argoprojv1a1.ResourceFilter) (string, error) {
+	if err := validateResourceFilters(filters); err != nil {
+		return "", err
+	}
+
+	result := make([]map[string][]string, 0, len(filters))
+	for _, filter := range filters {
+		entry := map[string][]string{}
+		if len(filter.APIGroups) > 0 {
+			entry["apiGroups"] = filter.APIGroups
+		}
+		if len(filter.Kinds) > 0 {
+			entry["kinds"] = filter.Kinds
+		}
+		if len(filter.Clusters) > 0 {
+			entry["clusters"] = filter.Clusters
+		}
+		result = append(result, entry)
+	}
+
+	bytes, err := yaml.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// validateResourceFilters will return an error if any of the given resource filters is malformed. A filter must select
+// at least one API group or kind, so that a forgotten field does not match every resource, and API groups and kinds
+// must either be glob patterns or well formed names.
+func validateResourceFilters(filters []argoprojv1a1.ResourceFilter) error {
+	for i, filter := range filters {
+		if len(filter.APIGroups) == 0 && len(filter.Kinds) == 0 {
+			return fmt.Errorf("illegal resource filter %d: at least one of apiGroups or kinds must be set", i)
+		}
+
+		for _, group := range filter.APIGroups {
+			if err := validateAPIGroup(group); err != nil {
+				return fmt.Errorf("illegal resource filter %d: %w", i, err)
+			}
+		}
+
+		for _, kind := range filter.Kinds {
+			if err := validateKind(kind); err != nil {
+				return fmt.Errorf("illegal resource filter %d: %w", i, err)
+			}
+		}
+
+		for _, cluster := range filter.Clusters {
+			if cluster == "" {
+				return fmt.Errorf("illegal resource filter %d: cluster must not be empty", i)
+			}
+			if err := validateResourceFilterPattern(cluster); err != nil {
+				return fmt.Errorf("illegal resource filter %d: cluster %q: %w", i, cluster, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateAPIGroup will return an error if the given API group is neither a valid glob pattern nor a well formed API
+// group. The empty string denotes the core group.
+func validateAPIGroup(group string) error {
+	if err := validateResourceFilterPattern(group); err != nil {
+		return fmt.Errorf("apiGroup %q: %w", group, err)
+	}
+	if group != "" && !isGlobPattern(group) {
+		if errs := validation.IsDNS1123Subdomain(group); len(errs) > 0 {
+			return fmt.Errorf("apiGroup %q: %s", group, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// validateKind will return an error if the given kind is neither a valid glob pattern nor starts with an upper case
+// letter.
+func validateKind(kind string) error {
+	if kind == "" {
+		return fmt.Errorf("kind must not be empty")
+	}
+	if err := validateResourceFilterPattern(kind); err != nil {
+		return fmt.Errorf("kind %q: %w", kind, err)
+	}
+	if !isGlobPattern(kind) && !unicode.IsUpper([]rune(kind)[0]) {
+		return fmt.Errorf("kind %q must start with an upper case letter", kind)
+	}
+	return nil
+}
+
+// validateResourceFilterPattern will return an error if the given value is not a valid glob pattern.
+func validateResourceFilterPattern(pattern string) error {
+	_, err := path.Match(pattern, "")
+	return err
+}
+
+// isGlobPattern returns true if the given value contains glob meta characters.
+func isGlobPattern(value string) bool {
+	return strings.ContainsAny(value, "*?[")
+}
+
+// reconcileValidatedValue will return the value of the given key of argocd-cm. An invalid value is reported through
+// an event with the given reason and the given existing value is kept, so that a typo does not break Argo CD.
+func (r *ReconcileArgoCD) reconcileValidatedValue(key string, reason string, get func(*argoprojv1a1.ArgoCD) (string, error), existing string, cr *argoprojv1a1.ArgoCD) string {
+	value, err := get(cr)
+	if err == nil {
+		return value
+	}
+
+	log.Error(err, fmt.Sprintf("invalid %s for Argo CD instance %s in namespace %s, keeping the current value", key, cr.Name, cr.Namespace))
+	if err := argoutil.CreateEvent(r.Client, "Warning", "Invalid", err.Error(), reason, cr.ObjectMeta, cr.TypeMeta); err != nil {
+		log.Error(err, fmt.Sprintf("failed to create event for invalid %s", key))
+	}
+	return existing
+}
+
+// isUnmanagedConfigKey returns true if the given key of argocd-cm matches one of the unmanaged config keys of the given
+// ArgoCD.
+func isUnmanagedConfigKey(key string, cr *argoprojv1a1.ArgoCD) bool {
+	for _, pattern := range cr.Spec.UnmanagedConfigKeys {
+		if pattern == key {
+			return true
+		}
+		if matched, err := path.Match(pattern, key); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// keepUnmanagedConfigKeys will replace the values of the unmanaged keys in the given desired argocd-cm data with their
+// values in the given existing data, and remove the unmanaged keys that are not present in the existing data.
+func keepUnmanagedConfigKeys(desired map[string]string, existing map[string]string, cr *argoprojv1a1.ArgoCD) {
+	for key := range desired {
+		if isUnmanagedConfigKey(key, cr) {
+			delete(desired, key)
+		}
+	}
+	for key, value := range existing {
+		if isUnmanagedConfigKey(key, cr) {
+			desired[key] = value
+		}
+	}
+}
+
+// getArgoServerCustomStylesURL will return the URL of the custom stylesheet of the Argo CD UI, relative to the static
+// assets of the Argo CD Server, or an empty string if no custom styles are configured.
+func getArgoServerCustomStylesURL(cr *argoprojv1a1.ArgoCD) string {
+	if cr.Spec.Server.CustomStyles == nil || cr.Spec.Server.CustomStyles.ConfigMap == "" {
+		return ""
+	}
+
+	key := common.ArgoCDDefaultServerCustomStylesKey
+	if cr.Spec.Server.CustomStyles.StylesheetKey != "" {
+		key = cr.Spec.Server.CustomStyles.StylesheetKey
+	}
+	return fmt.Sprintf("./%s/%s", common.ArgoCDDefaultServerCustomStylesPath, key)
+}
+
+// getReconciliationTimeout will return the Application reconciliation timeout for the given ArgoCD, or an error if it
+// is not a valid, non-negative duration.
+func getReconciliationTimeout(cr *argoprojv1a1.ArgoCD) (string, error) {
+	timeout := cr.Spec.Controller.ReconciliationTimeout
+	if timeout == "" {
+		return "", nil
+	}
+
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return "", fmt.Errorf("invalid reconciliation timeout %q: %v", timeout, err)
+	}
+	if d < 0 {
+		return "", fmt.Errorf("invalid reconciliation timeout %q: must not be negative", timeout)
+	}
+	return timeout, nil
 }
 
 // getResourceTrackingMethod will return the resource tracking method for the given ArgoCD.
@@ -315,6 +635,10 @@ func (r *ReconcileArgoCD) reconcileConfigMaps(cr *argoprojv1a1.ArgoCD, useTLSFor
 		return err
 	}
 
+	if err := r.reconcileCmdParamsConfigMap(cr); err != nil {
+		return err
+	}
+
 	if err := r.reconcileRedisConfiguration(cr, useTLSForRedis); err != nil {
 		return err
 	}
@@ -339,6 +663,10 @@ func (r *ReconcileArgoCD) reconcileConfigMaps(cr *argoprojv1a1.ArgoCD, useTLSFor
 		return err
 	}
 
+	if err := r.reconcileGrafanaDashboardConfigMaps(cr); err != nil {
+		return err
+	}
+
 	return r.reconcileGPGKeysConfigMap(cr)
 }
 
@@ -346,9 +674,6 @@ func (r *ReconcileArgoCD) reconcileConfigMaps(cr *argoprojv1a1.ArgoCD, useTLSFor
 // This ConfigMap holds the CA Certificate data for client use.
 func (r *ReconcileArgoCD) reconcileCAConfigMap(cr *argoprojv1a1.ArgoCD) error {
 	cm := newConfigMapWithName(getCAConfigMapName(cr), cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
-		return nil // ConfigMap found, do nothing
-	}
 
 	caSecret := argoutil.NewSecretWithSuffix(cr, common.ArgoCDCASuffix)
 	if !argoutil.IsObjectFound(r.Client, cr.Namespace, caSecret.Name, caSecret) {
@@ -356,10 +681,21 @@ func (r *ReconcileArgoCD) reconcileCAConfigMap(cr *argoprojv1a1.ArgoCD) error {
 		return nil
 	}
 
-	cm.Data = map[string]string{
+	data := map[string]string{
 		common.ArgoCDKeyTLSCert: string(caSecret.Data[common.ArgoCDKeyTLSCert]),
 	}
 
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
+		// Keep the ConfigMap in sync with a renewed CA, but only if it was created by the operator.
+		if !metav1.IsControlledBy(cm, cr) || reflect.DeepEqual(cm.Data, data) {
+			return nil
+		}
+		cm.Data = data
+		return r.Client.Update(context.TODO(), cm)
+	}
+
+	cm.Data = data
+
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
 		return err
 	}
@@ -372,7 +708,6 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoprojv1a1.ArgoCD) error
 
 	cm.Data = make(map[string]string)
 
-	cm.Data[common.ArgoCDKeyApplicationInstanceLabelKey] = getApplicationInstanceLabelKey(cr)
 	cm.Data[common.ArgoCDKeyConfigManagementPlugins] = getConfigManagementPlugins(cr)
 	cm.Data[common.ArgoCDKeyAdminEnabled] = fmt.Sprintf("%t", !cr.Spec.DisableAdmin)
 	cm.Data[common.ArgoCDKeyGATrackingID] = getGATrackingID(cr)
@@ -389,23 +724,39 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoprojv1a1.ArgoCD) error
 
 	cm.Data[common.ArgoCDKeyOIDCConfig] = getOIDCConfig(cr)
 
-	if c := getResourceHealthChecks(cr); c != nil {
-		for k, v := range c {
-			cm.Data[k] = v
-		}
-	}
+	existingCM := &corev1.ConfigMap{}
+	cmExists := argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, existingCM)
 
-	if c, err := getResourceIgnoreDifferences(cr); c != nil && err == nil {
-		for k, v := range c {
-			cm.Data[k] = v
+	if err := validateResourceCustomizations(cr); err != nil {
+		// keep the current customizations, so that a malformed entry does not drop the valid ones
+		log.Error(err, fmt.Sprintf("invalid resource customizations for Argo CD instance %s in namespace %s, keeping the current values", cr.Name, cr.Namespace))
+		if err := argoutil.CreateEvent(r.Client, "Warning", "Invalid", err.Error(), "InvalidResourceCustomization", cr.ObjectMeta, cr.TypeMeta); err != nil {
+			log.Error(err, "failed to create event for invalid resource customizations")
+		}
+		for k, v := range existingCM.Data {
+			if isResourceCustomizationKey(k) {
+				cm.Data[k] = v
+			}
 		}
 	} else {
-		return err
-	}
+		if c := getResourceHealthChecks(cr); c != nil {
+			for k, v := range c {
+				cm.Data[k] = v
+			}
+		}
 
-	if c := getResourceActions(cr); c != nil {
-		for k, v := range c {
-			cm.Data[k] = v
+		if c, err := getResourceIgnoreDifferences(cr); c != nil && err == nil {
+			for k, v := range c {
+				cm.Data[k] = v
+			}
+		} else {
+			return err
+		}
+
+		if c := getResourceActions(cr); c != nil {
+			for k, v := range c {
+				cm.Data[k] = v
+			}
 		}
 	}
 
@@ -413,7 +764,7 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoprojv1a1.ArgoCD) error
 	if c := getResourceCustomizations(cr); c != "" {
 
 		// Emit event providing users with deprecation notice for ResourceCustomization if not emitted already
-		if currentInstanceEventEmissionStatus, ok := DeprecationEventEmissionTracker[cr.Namespace]; !ok || !currentInstanceEventEmissionStatus.ResourceCustomizationsDeprecationWarningEmitted {
+		if currentInstanceEventEmissionStatus, ok := getDeprecationEventEmissionStatus(cr.Namespace); !ok || !currentInstanceEventEmissionStatus.ResourceCustomizationsDeprecationWarningEmitted {
 			err := argoutil.CreateEvent(r.Client, "Warning", "Deprecated", "ResourceCustomizations is deprecated, please use the new formats `ResourceHealthChecks`, `ResourceIgnoreDifferences`, and `ResourceActions` instead.", "DeprecationNotice", cr.ObjectMeta, cr.TypeMeta)
 			if err != nil {
 				return err
@@ -424,19 +775,48 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoprojv1a1.ArgoCD) error
 			} else {
 				currentInstanceEventEmissionStatus.ResourceCustomizationsDeprecationWarningEmitted = true
 			}
-			DeprecationEventEmissionTracker[cr.Namespace] = currentInstanceEventEmissionStatus
+			setDeprecationEventEmissionStatus(cr.Namespace, currentInstanceEventEmissionStatus)
 		}
 		cm.Data[common.ArgoCDKeyResourceCustomizations] = c
 	}
 
-	cm.Data[common.ArgoCDKeyResourceExclusions] = getResourceExclusions(cr)
-	cm.Data[common.ArgoCDKeyResourceInclusions] = getResourceInclusions(cr)
+	// old format of ResourceExclusions and ResourceInclusions
+	if (cr.Spec.ResourceExclusions != "" && len(cr.Spec.ExcludedResources) == 0) || (cr.Spec.ResourceInclusions != "" && len(cr.Spec.IncludedResources) == 0) {
+
+		// Emit event providing users with deprecation notice for ResourceExclusions and ResourceInclusions if not emitted already
+		if currentInstanceEventEmissionStatus, ok := getDeprecationEventEmissionStatus(cr.Namespace); !ok || !currentInstanceEventEmissionStatus.ResourceFiltersDeprecationWarningEmitted {
+			err := argoutil.CreateEvent(r.Client, "Warning", "Deprecated", "ResourceExclusions and ResourceInclusions are deprecated, please use the new formats `ExcludedResources` and `IncludedResources` instead.", "DeprecationNotice", cr.ObjectMeta, cr.TypeMeta)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				currentInstanceEventEmissionStatus = DeprecationEventEmissionStatus{ResourceFiltersDeprecationWarningEmitted: true}
+			} else {
+				currentInstanceEventEmissionStatus.ResourceFiltersDeprecationWarningEmitted = true
+			}
+			setDeprecationEventEmissionStatus(cr.Namespace, currentInstanceEventEmissionStatus)
+		}
+	}
+
+	cm.Data[common.ArgoCDKeyApplicationInstanceLabelKey] = r.reconcileValidatedValue(common.ArgoCDKeyApplicationInstanceLabelKey, "InvalidApplicationInstanceLabelKey", getValidApplicationInstanceLabelKey, existingCM.Data[common.ArgoCDKeyApplicationInstanceLabelKey], cr)
+	if timeout := r.reconcileValidatedValue(common.ArgoCDKeyTimeoutReconciliation, "InvalidReconciliationTimeout", getReconciliationTimeout, existingCM.Data[common.ArgoCDKeyTimeoutReconciliation], cr); timeout != "" {
+		cm.Data[common.ArgoCDKeyTimeoutReconciliation] = timeout
+	}
+	if cr.Spec.Controller.ServerSideDiff != nil {
+		cm.Data[common.ArgoCDKeyControllerDiffServerSide] = fmt.Sprint(*cr.Spec.Controller.ServerSideDiff)
+	}
+	cm.Data[common.ArgoCDKeyResourceExclusions] = r.reconcileValidatedValue(common.ArgoCDKeyResourceExclusions, "InvalidResourceFilter", getResourceExclusions, existingCM.Data[common.ArgoCDKeyResourceExclusions], cr)
+	cm.Data[common.ArgoCDKeyResourceInclusions] = r.reconcileValidatedValue(common.ArgoCDKeyResourceInclusions, "InvalidResourceFilter", getResourceInclusions, existingCM.Data[common.ArgoCDKeyResourceInclusions], cr)
 	cm.Data[common.ArgoCDKeyResourceTrackingMethod] = getResourceTrackingMethod(cr)
 	cm.Data[common.ArgoCDKeyRepositories] = getInitialRepositories(cr)
 	cm.Data[common.ArgoCDKeyRepositoryCredentials] = getRepositoryCredentials(cr)
 	cm.Data[common.ArgoCDKeyStatusBadgeEnabled] = fmt.Sprint(cr.Spec.StatusBadgeEnabled)
 	cm.Data[common.ArgoCDKeyServerURL] = r.getArgoServerURI(cr)
 	cm.Data[common.ArgoCDKeyUsersAnonymousEnabled] = fmt.Sprint(cr.Spec.UsersAnonymousEnabled)
+	if cssURL := getArgoServerCustomStylesURL(cr); cssURL != "" {
+		cm.Data[common.ArgoCDKeyUICSSURL] = cssURL
+	}
 
 	// create dex config if dex is enabled either through DISABLE_DEX or through `.spec.sso`
 	if UseDex(cr) {
@@ -455,6 +835,14 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoprojv1a1.ArgoCD) error
 		cm.Data[common.ArgoCDKeyDexConfig] = dexConfig
 	}
 
+	extensionConfig, err := getServerExtensionConfig(cr)
+	if err != nil {
+		return err
+	}
+	if extensionConfig != "" {
+		cm.Data[common.ArgoCDKeyExtensionConfig] = extensionConfig
+	}
+
 	if cr.Spec.Banner != nil {
 		if cr.Spec.Banner.Content != "" {
 			cm.Data[common.ArgoCDKeyBannerContent] = cr.Spec.Banner.Content
@@ -464,18 +852,23 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoprojv1a1.ArgoCD) error
 		}
 	}
 
+	if err := r.reconcileStatusExtraConfigConflicts(cr, getExtraConfigConflicts(cm.Data, cr)); err != nil {
+		return err
+	}
+
 	if len(cr.Spec.ExtraConfig) > 0 {
 		for k, v := range cr.Spec.ExtraConfig {
 			cm.Data[k] = v
 		}
 	}
 
+	keepUnmanagedConfigKeys(cm.Data, existingCM.Data, cr)
+
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
 		return err
 	}
 
-	existingCM := &corev1.ConfigMap{}
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, existingCM) {
+	if cmExists {
 
 		// reconcile dex configuration if dex is enabled either through `DISABLE_DEX` or `.spec.sso.dex.provider` or there is
 		// existing dex configuration
@@ -489,8 +882,18 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoprojv1a1.ArgoCD) error
 		}
 
 		if !reflect.DeepEqual(cm.Data, existingCM.Data) {
+			// The application controller only reads these settings on startup.
+			rollout := existingCM.Data[common.ArgoCDKeyTimeoutReconciliation] != cm.Data[common.ArgoCDKeyTimeoutReconciliation] ||
+				existingCM.Data[common.ArgoCDKeyControllerDiffServerSide] != cm.Data[common.ArgoCDKeyControllerDiffServerSide]
+
 			existingCM.Data = cm.Data
-			return r.Client.Update(context.TODO(), existingCM)
+			if err := r.Client.Update(context.TODO(), existingCM); err != nil {
+				return err
+			}
+			if rollout {
+				log.Info(fmt.Sprintf("application controller settings of Argo CD instance %s changed, rolling out the application controller", cr.Name))
+				return r.triggerRollout(newStatefulSetWithSuffix("application-controller", "application-controller", cr), "controller.settings.changed")
+			}
 		}
 		return nil // Do nothing as there is no change in the configmap.
 	}
@@ -555,23 +958,10 @@ func (r *ReconcileArgoCD) reconcileGrafanaDashboards(cr *argoprojv1a1.ArgoCD) er
 		return nil // ConfigMap found, do nothing
 	}
 
-	pattern := filepath.Join(getGrafanaConfigPath(), "dashboards/*.json")
-	dashboards, err := filepath.Glob(pattern)
+	data, err := loadGrafanaDashboards()
 	if err != nil {
 		return err
 	}
-
-	data := make(map[string]string)
-	for _, f := range dashboards {
-		dashboard, err := ioutil.ReadFile(f)
-		if err != nil {
-			return err
-		}
-
-		parts := strings.Split(f, "/")
-		filename := parts[len(parts)-1]
-		data[filename] = string(dashboard)
-	}
 	cm.Data = data
 
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
@@ -580,20 +970,151 @@ func (r *ReconcileArgoCD) reconcileGrafanaDashboards(cr *argoprojv1a1.ArgoCD) er
 	return r.Client.Create(context.TODO(), cm)
 }
 
+// isGrafanaDashboardConfigMapsEnabled returns true if the Grafana dashboards are provided as ConfigMaps for the
+// Grafana dashboard sidecar.
+func isGrafanaDashboardConfigMapsEnabled(cr *argoprojv1a1.ArgoCD) bool {
+	return cr.Spec.Grafana.DashboardConfigMaps != nil && cr.Spec.Grafana.DashboardConfigMaps.Enabled
+}
+
+// newGrafanaDashboardConfigMap returns a new ConfigMap for the Grafana dashboard sidecar, holding the dashboard with
+// the given file name.
+func newGrafanaDashboardConfigMap(filename string, cr *argoprojv1a1.ArgoCD) *corev1.ConfigMap {
+	name := strings.TrimSuffix(filename, filepath.Ext(filename))
+	cm := newConfigMapWithSuffix(fmt.Sprintf("%s-%s", common.ArgoCDGrafanaSidecarDashboardConfigMapSuffix, name), cr)
+
+	labels := map[string]string{
+		common.ArgoCDDefaultGrafanaDashboardLabel: common.ArgoCDDefaultGrafanaDashboardLabelValue,
+	}
+	if spec := cr.Spec.Grafana.DashboardConfigMaps; spec != nil {
+		if len(spec.Labels) > 0 {
+			labels = spec.Labels
+		}
+		if len(spec.Annotations) > 0 {
+			cm.Annotations = make(map[string]string)
+			for k, v := range spec.Annotations {
+				cm.Annotations[k] = v
+			}
+		}
+	}
+	for k, v := range labels {
+		cm.Labels[k] = v
+	}
+	return cm
+}
+
+// reconcileGrafanaDashboardConfigMaps will ensure that a ConfigMap is present for each Grafana dashboard when the
+// dashboards are provided for the Grafana dashboard sidecar, and removed otherwise.
+func (r *ReconcileArgoCD) reconcileGrafanaDashboardConfigMaps(cr *argoprojv1a1.ArgoCD) error {
+	dashboards, err := loadGrafanaDashboards()
+	if err != nil {
+		return err
+	}
+
+	for filename, dashboard := range dashboards {
+		cm := newGrafanaDashboardConfigMap(filename, cr)
+		cm.Data = map[string]string{filename: dashboard}
+
+		existing := &corev1.ConfigMap{}
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, existing) {
+			// Only modify ConfigMaps that were created by the operator.
+			if !metav1.IsControlledBy(existing, cr) {
+				continue
+			}
+			if !isGrafanaDashboardConfigMapsEnabled(cr) {
+				log.Info(fmt.Sprintf("deleting grafana dashboard configmap %s", existing.Name))
+				if err := r.Client.Delete(context.TODO(), existing); err != nil {
+					return err
+				}
+				continue
+			}
+			if !reflect.DeepEqual(existing.Data, cm.Data) || !reflect.DeepEqual(existing.Labels, cm.Labels) ||
+				!reflect.DeepEqual(existing.Annotations, cm.Annotations) {
+				existing.Data = cm.Data
+				existing.Labels = cm.Labels
+				existing.Annotations = cm.Annotations
+				if err := r.Client.Update(context.TODO(), existing); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if !isGrafanaDashboardConfigMapsEnabled(cr) {
+			continue
+		}
+
+		if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("creating grafana dashboard configmap %s", cm.Name))
+		if err := r.Client.Create(context.TODO(), cm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // reconcileRBAC will ensure that the ArgoCD RBAC ConfigMap is present.
 func (r *ReconcileArgoCD) reconcileRBAC(cr *argoprojv1a1.ArgoCD) error {
+	validPolicy := true
+	if err := validateRBACPolicy(getRBACPolicy(cr)); err != nil {
+		log.Error(err, fmt.Sprintf("invalid RBAC policy for Argo CD %s in namespace %s, the policy will not be applied", cr.Name, cr.Namespace))
+		validPolicy = false
+	}
+	if err := r.reconcileStatusRBACConfig(cr, validPolicy); err != nil {
+		return err
+	}
+
 	cm := newConfigMapWithName(common.ArgoCDRBACConfigMapName, cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
-		return r.reconcileRBACConfigMap(cm, cr)
+		return r.reconcileRBACConfigMap(cm, cr, validPolicy)
+	}
+	return r.createRBACConfigMap(cm, cr, validPolicy)
+}
+
+// validateRBACPolicy will return an error if the given RBAC policy CSV contains malformed lines. Each line must either
+// be a policy (p, subject, resource, action, object, effect) or a group assignment (g, subject, role).
+func validateRBACPolicy(policy string) error {
+	reader := csv.NewReader(strings.NewReader(policy))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("illegal RBAC policy: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		switch strings.TrimSpace(record[0]) {
+		case "":
+			if len(record) == 1 {
+				continue // blank line
+			}
+			return fmt.Errorf("illegal RBAC policy: line %d: missing policy type", line)
+		case "p":
+			if len(record) != 6 {
+				return fmt.Errorf("illegal RBAC policy: line %d: policy must have 6 fields, got %d", line, len(record))
+			}
+		case "g":
+			if len(record) != 3 {
+				return fmt.Errorf("illegal RBAC policy: line %d: group assignment must have 3 fields, got %d", line, len(record))
+			}
+		default:
+			return fmt.Errorf("illegal RBAC policy: line %d: unknown policy type %q", line, record[0])
+		}
 	}
-	return r.createRBACConfigMap(cm, cr)
 }
 
 // reconcileRBACConfigMap will ensure that the RBAC ConfigMap is syncronized with the given ArgoCD.
-func (r *ReconcileArgoCD) reconcileRBACConfigMap(cm *corev1.ConfigMap, cr *argoprojv1a1.ArgoCD) error {
+func (r *ReconcileArgoCD) reconcileRBACConfigMap(cm *corev1.ConfigMap, cr *argoprojv1a1.ArgoCD, validPolicy bool) error {
 	changed := false
 	// Policy CSV
-	if cr.Spec.RBAC.Policy != nil && cm.Data[common.ArgoCDKeyRBACPolicyCSV] != *cr.Spec.RBAC.Policy {
+	if validPolicy && cr.Spec.RBAC.Policy != nil && cm.Data[common.ArgoCDKeyRBACPolicyCSV] != *cr.Spec.RBAC.Policy {
 		cm.Data[common.ArgoCDKeyRBACPolicyCSV] = *cr.Spec.RBAC.Policy
 		changed = true
 	}
@@ -637,11 +1158,22 @@ func (r *ReconcileArgoCD) reconcileRedisConfiguration(cr *argoprojv1a1.ArgoCD, u
 // reconcileRedisHAConfigMap will ensure that the Redis HA Health ConfigMap is present for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileRedisHAHealthConfigMap(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) error {
 	cm := newConfigMapWithName(common.ArgoCDRedisHAHealthConfigMapName, cr)
+	desiredData := map[string]string{
+		"redis_liveness.sh":    getRedisLivenessScript(cr, useTLSForRedis),
+		"redis_readiness.sh":   getRedisReadinessScript(cr, useTLSForRedis),
+		"sentinel_liveness.sh": getSentinelLivenessScript(useTLSForRedis),
+	}
+
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
 		if !cr.Spec.HA.Enabled {
 			// ConfigMap exists but HA enabled flag has been set to false, delete the ConfigMap
 			return r.Client.Delete(context.TODO(), cm)
 		}
+		if !reflect.DeepEqual(cm.Data, desiredData) {
+			// The health scripts are read on every probe, no restart required
+			cm.Data = desiredData
+			return r.Client.Update(context.TODO(), cm)
+		}
 		return nil // ConfigMap found with nothing changed, move along...
 	}
 
@@ -649,11 +1181,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAHealthConfigMap(cr *argoprojv1a1.ArgoC
 		return nil // HA not enabled, do nothing.
 	}
 
-	cm.Data = map[string]string{
-		"redis_liveness.sh":    getRedisLivenessScript(useTLSForRedis),
-		"redis_readiness.sh":   getRedisReadinessScript(useTLSForRedis),
-		"sentinel_liveness.sh": getSentinelLivenessScript(useTLSForRedis),
-	}
+	cm.Data = desiredData
 
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
 		return err
@@ -664,25 +1192,39 @@ func (r *ReconcileArgoCD) reconcileRedisHAHealthConfigMap(cr *argoprojv1a1.ArgoC
 // reconcileRedisHAConfigMap will ensure that the Redis HA ConfigMap is present for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileRedisHAConfigMap(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) error {
 	cm := newConfigMapWithName(common.ArgoCDRedisHAConfigMapName, cr)
+	desiredData := map[string]string{
+		"haproxy.cfg":     getRedisHAProxyConfig(cr, useTLSForRedis),
+		"haproxy_init.sh": getRedisHAProxyScript(cr),
+		"init.sh":         getRedisInitScript(cr, useTLSForRedis),
+		"redis.conf":      getRedisConf(cr, useTLSForRedis),
+		"sentinel.conf":   getRedisSentinelConf(cr, useTLSForRedis),
+	}
+
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
 		if !cr.Spec.HA.Enabled {
 			// ConfigMap exists but HA enabled flag has been set to false, delete the ConfigMap
 			return r.Client.Delete(context.TODO(), cm)
 		}
-		return nil // ConfigMap found with nothing changed, move along...
+		if reflect.DeepEqual(cm.Data, desiredData) {
+			return nil // ConfigMap found with nothing changed, move along...
+		}
+
+		// The configuration is only read on startup, restart Redis HA to pick up the changes
+		cm.Data = desiredData
+		if err := r.Client.Update(context.TODO(), cm); err != nil {
+			return err
+		}
+		if err := r.triggerRollout(newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr), "redis.ha.config.changed"); err != nil {
+			return err
+		}
+		return r.triggerRollout(newStatefulSetWithSuffix("redis-ha-server", "redis", cr), "redis.ha.config.changed")
 	}
 
 	if !cr.Spec.HA.Enabled {
 		return nil // HA not enabled, do nothing.
 	}
 
-	cm.Data = map[string]string{
-		"haproxy.cfg":     getRedisHAProxyConfig(cr, useTLSForRedis),
-		"haproxy_init.sh": getRedisHAProxyScript(cr),
-		"init.sh":         getRedisInitScript(cr, useTLSForRedis),
-		"redis.conf":      getRedisConf(useTLSForRedis),
-		"sentinel.conf":   getRedisSentinelConf(useTLSForRedis),
-	}
+	cm.Data = desiredData
 
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
 		return err
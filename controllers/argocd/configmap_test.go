@@ -19,11 +19,13 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -624,6 +626,219 @@ func TestReconcileArgoCD_reconcileArgoConfigMap_withKustomizeVersions(t *testing
 	}
 }
 
+func TestReconcileArgoCD_reconcileArgoConfigMap_withCustomStyles(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.CustomStyles = &argoprojv1alpha1.ArgoCDServerCustomStylesSpec{
+			ConfigMap: "argocd-styles",
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "./custom/custom.styles.css", cm.Data[common.ArgoCDKeyUICSSURL])
+
+	a.Spec.Server.CustomStyles.StylesheetKey = "branding.css"
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "./custom/branding.css", cm.Data[common.ArgoCDKeyUICSSURL])
+
+	a.Spec.Server.CustomStyles = nil
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	_, ok := cm.Data[common.ArgoCDKeyUICSSURL]
+	assert.False(t, ok)
+}
+
+func TestReconcileArgoCD_reconcileArgoConfigMap_withExtensionConfig(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	maxIdleConnections := int32(10)
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Extensions = []argoprojv1alpha1.ArgoCDServerExtensionSpec{
+			{
+				Name: "rollouts",
+				URL:  "https://github.com/argoproj-labs/rollout-extension/releases/download/v0.3.4/extension.tar",
+			},
+			{
+				Name: "metrics",
+				Backend: &argoprojv1alpha1.ArgoCDServerExtensionBackendSpec{
+					ConnectionTimeout:  &metav1.Duration{Duration: 5 * time.Second},
+					MaxIdleConnections: &maxIdleConnections,
+					Services: []argoprojv1alpha1.ArgoCDServerExtensionServiceSpec{
+						{
+							URL: "http://prometheus-operated.monitoring.svc:9090",
+							Headers: []argoprojv1alpha1.ArgoCDServerExtensionHeaderSpec{
+								{Name: "Authorization", Value: "$metrics.token"},
+							},
+							Cluster: &argoprojv1alpha1.ArgoCDServerExtensionClusterSpec{Name: "in-cluster"},
+						},
+					},
+				},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	want := `extensions:
+- name: metrics
+  backend:
+    connectionTimeout: 5s
+    maxIdleConnections: 10
+    services:
+    - url: http://prometheus-operated.monitoring.svc:9090
+      headers:
+      - name: Authorization
+        value: $metrics.token
+      cluster:
+        name: in-cluster
+`
+	assert.Equal(t, want, cm.Data[common.ArgoCDKeyExtensionConfig])
+
+	a.Spec.Server.Extensions = a.Spec.Server.Extensions[:1]
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	_, ok := cm.Data[common.ArgoCDKeyExtensionConfig]
+	assert.False(t, ok)
+}
+
+func TestReconcileArgoCD_reconcileArgoConfigMap_withControllerSettings(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Controller.ReconciliationTimeout = "5m"
+		a.Spec.Controller.ServerSideDiff = boolPtr(true)
+	})
+	ss := newStatefulSetWithSuffix("application-controller", "application-controller", a)
+	r := makeTestReconciler(t, a, ss)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "5m", cm.Data[common.ArgoCDKeyTimeoutReconciliation])
+	assert.Equal(t, "true", cm.Data[common.ArgoCDKeyControllerDiffServerSide])
+
+	// changing a setting rolls out the application controller
+	a.Spec.Controller.ReconciliationTimeout = "0s"
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "0s", cm.Data[common.ArgoCDKeyTimeoutReconciliation])
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ss.Name, Namespace: testNamespace}, ss))
+	_, ok := ss.Spec.Template.Labels["controller.settings.changed"]
+	assert.True(t, ok)
+}
+
+func TestReconcileArgoCD_reconcileArgoConfigMap_withInvalidControllerSettings(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Controller.ReconciliationTimeout = "3m"
+		a.Spec.ApplicationInstanceLabelKey = "mycompany.com/appname"
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	tests := []struct {
+		name   string
+		update func(*argoprojv1alpha1.ArgoCD)
+		reason string
+	}{
+		{
+			name:   "invalid duration",
+			update: func(a *argoprojv1alpha1.ArgoCD) { a.Spec.Controller.ReconciliationTimeout = "3 minutes" },
+			reason: "InvalidReconciliationTimeout",
+		},
+		{
+			name:   "negative duration",
+			update: func(a *argoprojv1alpha1.ArgoCD) { a.Spec.Controller.ReconciliationTimeout = "-1m" },
+			reason: "InvalidReconciliationTimeout",
+		},
+		{
+			name:   "invalid label key",
+			update: func(a *argoprojv1alpha1.ArgoCD) { a.Spec.ApplicationInstanceLabelKey = "my company/app name" },
+			reason: "InvalidApplicationInstanceLabelKey",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			invalid := a.DeepCopy()
+			test.update(invalid)
+			assert.NoError(t, r.reconcileArgoConfigMap(invalid))
+
+			// the last valid values are kept
+			cm := &corev1.ConfigMap{}
+			assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+			assert.Equal(t, "3m", cm.Data[common.ArgoCDKeyTimeoutReconciliation])
+			assert.Equal(t, "mycompany.com/appname", cm.Data[common.ArgoCDKeyApplicationInstanceLabelKey])
+
+			events := &corev1.EventList{}
+			assert.NoError(t, r.Client.List(context.TODO(), events))
+			found := false
+			for _, event := range events.Items {
+				found = found || event.Reason == test.reason
+			}
+			assert.True(t, found)
+		})
+	}
+}
+
+func TestReconcileArgoCD_reconcileArgoConfigMap_withUnmanagedConfigKeys(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.UnmanagedConfigKeys = []string{common.ArgoCDKeyAdminEnabled, "resource.customizations.*", common.ArgoCDKeyGATrackingID}
+		a.Spec.DisableAdmin = true
+		a.Spec.ExtraConfig = map[string]string{
+			"resource.customizations.health.example.com_Foo": "managed",
+		}
+	})
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDConfigMapName, Namespace: testNamespace},
+		Data: map[string]string{
+			common.ArgoCDKeyAdminEnabled:                      "true",
+			"resource.customizations.health.example.com_Foo":  "hand-tuned",
+			"resource.customizations.actions.example.com_Bar": "hand-tuned",
+			common.ArgoCDKeyUsersAnonymousEnabled:             "true",
+		},
+	}
+	r := makeTestReconciler(t, a, existing)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+
+	// unmanaged keys keep the values set by the user
+	assert.Equal(t, "true", cm.Data[common.ArgoCDKeyAdminEnabled])
+	assert.Equal(t, "hand-tuned", cm.Data["resource.customizations.health.example.com_Foo"])
+	assert.Equal(t, "hand-tuned", cm.Data["resource.customizations.actions.example.com_Bar"])
+
+	// unmanaged keys that are not set are not added
+	_, ok := cm.Data[common.ArgoCDKeyGATrackingID]
+	assert.False(t, ok)
+
+	// managed keys are still reconciled
+	assert.Equal(t, "false", cm.Data[common.ArgoCDKeyUsersAnonymousEnabled])
+}
+
+func TestIsUnmanagedConfigKey(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.UnmanagedConfigKeys = []string{"url", "resource.customizations.*", "["}
+	})
+
+	assert.True(t, isUnmanagedConfigKey("url", a))
+	assert.True(t, isUnmanagedConfigKey("resource.customizations.health.apps_Deployment", a))
+	assert.True(t, isUnmanagedConfigKey("[", a))
+	assert.False(t, isUnmanagedConfigKey("admin.enabled", a))
+	assert.False(t, isUnmanagedConfigKey("resource.exclusions", a))
+}
+
 func TestReconcileArgoCD_reconcileGPGKeysConfigMap(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
@@ -768,6 +983,112 @@ func TestReconcileArgoCD_reconcileArgoConfigMap_withResourceInclusions(t *testin
 
 }
 
+func TestReconcileArgoCD_reconcileArgoConfigMap_withExcludedResources(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.ResourceExclusions = "ignored: true"
+		a.Spec.ExcludedResources = []argoprojv1alpha1.ResourceFilter{
+			{
+				APIGroups: []string{"", "discovery.k8s.io"},
+				Kinds:     []string{"Endpoints", "EndpointSlice"},
+			},
+			{
+				APIGroups: []string{"cilium.io"},
+				Kinds:     []string{"*"},
+				Clusters:  []string{"https://*.example.com"},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	want := `- apiGroups:
+  - ""
+  - discovery.k8s.io
+  kinds:
+  - Endpoints
+  - EndpointSlice
+- apiGroups:
+  - cilium.io
+  clusters:
+  - https://*.example.com
+  kinds:
+  - '*'
+`
+	assert.Equal(t, want, cm.Data[common.ArgoCDKeyResourceExclusions])
+
+	// an invalid filter keeps the current value
+	a.Spec.ExcludedResources[0].Kinds = []string{"endpoints"}
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, want, cm.Data[common.ArgoCDKeyResourceExclusions])
+
+	// removing the structured filters falls back to the free-form value
+	a.Spec.ExcludedResources = nil
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "ignored: true", cm.Data[common.ArgoCDKeyResourceExclusions])
+}
+
+func Test_validateResourceFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters []argoprojv1alpha1.ResourceFilter
+		wantErr bool
+	}{
+		{
+			name:    "core group",
+			filters: []argoprojv1alpha1.ResourceFilter{{APIGroups: []string{""}, Kinds: []string{"ConfigMap"}}},
+		},
+		{
+			name:    "glob patterns",
+			filters: []argoprojv1alpha1.ResourceFilter{{APIGroups: []string{"*.k8s.io"}, Kinds: []string{"*"}, Clusters: []string{"*"}}},
+		},
+		{
+			name:    "only kinds",
+			filters: []argoprojv1alpha1.ResourceFilter{{Kinds: []string{"Secret"}}},
+		},
+		{
+			name:    "empty filter",
+			filters: []argoprojv1alpha1.ResourceFilter{{Clusters: []string{"https://kubernetes.default.svc"}}},
+			wantErr: true,
+		},
+		{
+			name:    "lower case kind",
+			filters: []argoprojv1alpha1.ResourceFilter{{Kinds: []string{"deployments"}}},
+			wantErr: true,
+		},
+		{
+			name:    "malformed api group",
+			filters: []argoprojv1alpha1.ResourceFilter{{APIGroups: []string{"Apps"}}},
+			wantErr: true,
+		},
+		{
+			name:    "malformed pattern",
+			filters: []argoprojv1alpha1.ResourceFilter{{Kinds: []string{"Deploy[ment"}}},
+			wantErr: true,
+		},
+		{
+			name:    "empty cluster",
+			filters: []argoprojv1alpha1.ResourceFilter{{Kinds: []string{"Secret"}, Clusters: []string{""}}},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateResourceFilters(test.filters)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestReconcileArgoCD_reconcileArgoConfigMap_withResourceCustomizations(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	customizations := "testing: testing"
@@ -799,8 +1120,8 @@ func TestReconcileArgoCD_reconcileArgoConfigMap_withNewResourceCustomizations(t
 - a
 - b
 jsonpointers:
-- a
-- b
+- /a
+- /b
 managedfieldsmanagers:
 - a
 - b
@@ -808,41 +1129,41 @@ managedfieldsmanagers:
 
 	health := []argoprojv1alpha1.ResourceHealthCheck{
 		{
-			Group: "healthFoo",
-			Kind:  "healthFoo",
+			Group: "healthfoo.example.com",
+			Kind:  "HealthFoo",
 			Check: "healthFoo",
 		},
 		{
-			Group: "healthBar",
-			Kind:  "healthBar",
+			Group: "healthbar.example.com",
+			Kind:  "HealthBar",
 			Check: "healthBar",
 		},
 	}
 	actions := []argoprojv1alpha1.ResourceAction{
 		{
-			Group:  "actionsFoo",
-			Kind:   "actionsFoo",
+			Group:  "actionsfoo.example.com",
+			Kind:   "ActionsFoo",
 			Action: "actionsFoo",
 		},
 		{
-			Group:  "actionsBar",
-			Kind:   "actionsBar",
+			Group:  "actionsbar.example.com",
+			Kind:   "ActionsBar",
 			Action: "actionsBar",
 		},
 	}
 	ignoreDifferences := argoprojv1alpha1.ResourceIgnoreDifference{
 		All: &v1alpha1.IgnoreDifferenceCustomization{
 			JqPathExpressions:     []string{"a", "b"},
-			JsonPointers:          []string{"a", "b"},
+			JsonPointers:          []string{"/a", "/b"},
 			ManagedFieldsManagers: []string{"a", "b"},
 		},
 		ResourceIdentifiers: []argoprojv1alpha1.ResourceIdentifiers{
 			{
-				Group: "ignoreDiffBar",
-				Kind:  "ignoreDiffBar",
+				Group: "ignorediffbar.example.com",
+				Kind:  "IgnoreDiffBar",
 				Customization: v1alpha1.IgnoreDifferenceCustomization{
 					JqPathExpressions:     []string{"a", "b"},
-					JsonPointers:          []string{"a", "b"},
+					JsonPointers:          []string{"/a", "/b"},
 					ManagedFieldsManagers: []string{"a", "b"},
 				},
 			},
@@ -867,12 +1188,12 @@ managedfieldsmanagers:
 	assert.NoError(t, err)
 
 	desiredCM := make(map[string]string)
-	desiredCM["resource.customizations.health.healthFoo_healthFoo"] = "healthFoo"
-	desiredCM["resource.customizations.health.healthBar_healthBar"] = "healthBar"
-	desiredCM["resource.customizations.actions.actionsFoo_actionsFoo"] = "actionsFoo"
-	desiredCM["resource.customizations.actions.actionsBar_actionsBar"] = "actionsBar"
+	desiredCM["resource.customizations.health.healthfoo.example.com_HealthFoo"] = "healthFoo"
+	desiredCM["resource.customizations.health.healthbar.example.com_HealthBar"] = "healthBar"
+	desiredCM["resource.customizations.actions.actionsfoo.example.com_ActionsFoo"] = "actionsFoo"
+	desiredCM["resource.customizations.actions.actionsbar.example.com_ActionsBar"] = "actionsBar"
 	desiredCM["resource.customizations.ignoreDifferences.all"] = desiredIgnoreDifferenceCustomization
-	desiredCM["resource.customizations.ignoreDifferences.ignoreDiffBar_ignoreDiffBar"] = desiredIgnoreDifferenceCustomization
+	desiredCM["resource.customizations.ignoreDifferences.ignorediffbar.example.com_IgnoreDiffBar"] = desiredIgnoreDifferenceCustomization
 
 	for k, v := range desiredCM {
 		if value, ok := cm.Data[k]; !ok || value != v {
@@ -881,6 +1202,120 @@ managedfieldsmanagers:
 	}
 }
 
+func TestReconcileArgoCD_reconcileArgoConfigMap_withInvalidResourceCustomizations(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.ResourceHealthChecks = []argoprojv1alpha1.ResourceHealthCheck{
+			{Group: "certmanager.k8s.io", Kind: "Certificate", Check: "return {}"},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	// a malformed entry keeps the current customizations
+	a.Spec.ResourceHealthChecks = append(a.Spec.ResourceHealthChecks, argoprojv1alpha1.ResourceHealthCheck{
+		Group: "apps", Kind: "deployment", Check: "return {}",
+	})
+	a.Spec.ResourceActions = []argoprojv1alpha1.ResourceAction{
+		{Group: "apps", Kind: "Deployment", Action: "discovery.lua: return {}"},
+	}
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "return {}", cm.Data["resource.customizations.health.certmanager.k8s.io_Certificate"])
+	assert.NotContains(t, cm.Data, "resource.customizations.health.apps_deployment")
+	assert.NotContains(t, cm.Data, "resource.customizations.actions.apps_Deployment")
+
+	// fixing the entry applies all customizations
+	a.Spec.ResourceHealthChecks[1].Kind = "Deployment"
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Contains(t, cm.Data, "resource.customizations.health.apps_Deployment")
+	assert.Contains(t, cm.Data, "resource.customizations.actions.apps_Deployment")
+}
+
+func Test_validateResourceCustomizations(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    func(*argoprojv1alpha1.ArgoCD)
+		wantErr bool
+	}{
+		{
+			name: "valid customizations",
+			opts: func(a *argoprojv1alpha1.ArgoCD) {
+				a.Spec.ResourceHealthChecks = []argoprojv1alpha1.ResourceHealthCheck{
+					{Group: "", Kind: "Service", Check: "return {}"},
+					{Group: "*.example.com", Kind: "*", Check: "return {}"},
+				}
+				a.Spec.ResourceActions = []argoprojv1alpha1.ResourceAction{
+					{Group: "apps", Kind: "Deployment", Action: "discovery.lua: return {}"},
+				}
+				a.Spec.ResourceIgnoreDifferences = &argoprojv1alpha1.ResourceIgnoreDifference{
+					All: &argoprojv1alpha1.IgnoreDifferenceCustomization{JsonPointers: []string{"/spec/replicas"}},
+					ResourceIdentifiers: []argoprojv1alpha1.ResourceIdentifiers{
+						{Group: "apps", Kind: "Deployment", Customization: argoprojv1alpha1.IgnoreDifferenceCustomization{JqPathExpressions: []string{".spec.replicas"}}},
+					},
+				}
+			},
+		},
+		{
+			name: "duplicate health check",
+			opts: func(a *argoprojv1alpha1.ArgoCD) {
+				a.Spec.ResourceHealthChecks = []argoprojv1alpha1.ResourceHealthCheck{
+					{Group: "apps", Kind: "Deployment", Check: "return {}"},
+					{Group: "apps", Kind: "Deployment", Check: "return {}"},
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty health check",
+			opts: func(a *argoprojv1alpha1.ArgoCD) {
+				a.Spec.ResourceHealthChecks = []argoprojv1alpha1.ResourceHealthCheck{{Group: "apps", Kind: "Deployment"}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing action kind",
+			opts: func(a *argoprojv1alpha1.ArgoCD) {
+				a.Spec.ResourceActions = []argoprojv1alpha1.ResourceAction{{Group: "apps", Action: "discovery.lua: return {}"}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "relative json pointer",
+			opts: func(a *argoprojv1alpha1.ArgoCD) {
+				a.Spec.ResourceIgnoreDifferences = &argoprojv1alpha1.ResourceIgnoreDifference{
+					All: &argoprojv1alpha1.IgnoreDifferenceCustomization{JsonPointers: []string{"spec/replicas"}},
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty ignore differences customization",
+			opts: func(a *argoprojv1alpha1.ArgoCD) {
+				a.Spec.ResourceIgnoreDifferences = &argoprojv1alpha1.ResourceIgnoreDifference{
+					ResourceIdentifiers: []argoprojv1alpha1.ResourceIdentifiers{{Group: "apps", Kind: "Deployment"}},
+				}
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateResourceCustomizations(makeTestArgoCD(test.opts))
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestReconcile_emitEventOnDeprecatedResourceCustomizations(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 
@@ -996,6 +1431,40 @@ func TestReconcileArgoCD_reconcileArgoConfigMap_withExtraConfig(t *testing.T) {
 
 }
 
+func TestReconcileArgoCD_reconcileArgoConfigMap_extraConfigConflicts(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.ExtraConfig = map[string]string{
+			"foo": "bar",
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	// keys that are not managed by the spec do not conflict
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionTypeExtraConfigConflict)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+
+	// overriding the value of a first-class field is reported
+	a.Spec.DisableAdmin = true
+	a.Spec.ExtraConfig["admin.enabled"] = "true"
+	a.Spec.ExtraConfig["users.anonymous.enabled"] = "true"
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionTypeExtraConfigConflict)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "ConflictingKeys", condition.Reason)
+	assert.Contains(t, condition.Message, "admin.enabled, users.anonymous.enabled")
+
+	// setting the same value as the spec is not a conflict
+	a.Spec.ExtraConfig = map[string]string{
+		"admin.enabled": "false",
+	}
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionTypeExtraConfigConflict)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+}
+
 func Test_reconcileRBAC(t *testing.T) {
 	a := makeTestArgoCD()
 	r := makeTestReconciler(t, a)
@@ -1019,3 +1488,107 @@ func Test_reconcileRBAC(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, cm.Data["policy.matchMode"], matcherMode)
 }
+
+func Test_validateRBACPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr bool
+	}{
+		{
+			name:   "empty policy",
+			policy: "",
+		},
+		{
+			name:   "valid policy with comments and blank lines",
+			policy: "# admins\np, role:org-admin, applications, *, */*, allow\n\ng, my-org:team-alpha, role:org-admin\n",
+		},
+		{
+			name:   "quoted fields",
+			policy: `p, role:test, applications, get, "default/*", allow`,
+		},
+		{
+			name:    "policy with missing effect",
+			policy:  "p, role:org-admin, applications, *, */*",
+			wantErr: true,
+		},
+		{
+			name:    "group assignment with extra fields",
+			policy:  "g, my-org:team-alpha, role:org-admin, allow",
+			wantErr: true,
+		},
+		{
+			name:    "unknown policy type",
+			policy:  "x, role:org-admin, applications, *, */*, allow",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateRBACPolicy(test.policy)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_reconcileRBAC_invalidPolicy(t *testing.T) {
+	validPolicy := "g, my-org:team-alpha, role:admin"
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.RBAC.Policy = &validPolicy
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileRBAC(a))
+	assert.Equal(t, "Success", a.Status.RBACConfig)
+
+	// a malformed policy is not applied, the last valid policy is kept
+	invalidPolicy := "g, my-org:team-alpha"
+	a.Spec.RBAC.Policy = &invalidPolicy
+	assert.NoError(t, r.reconcileRBAC(a))
+	assert.Equal(t, "Failed", a.Status.RBACConfig)
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      common.ArgoCDRBACConfigMapName,
+		Namespace: testNamespace,
+	}, cm))
+	assert.Equal(t, validPolicy, cm.Data[common.ArgoCDKeyRBACPolicyCSV])
+}
+
+func TestReconcileArgoCD_reconcileGrafanaDashboardConfigMaps(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	t.Setenv("GRAFANA_CONFIG_PATH", "../../grafana")
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Grafana.DashboardConfigMaps = &argoprojv1alpha1.ArgoCDGrafanaDashboardConfigMapsSpec{
+			Enabled:     true,
+			Annotations: map[string]string{"grafana_folder": "Argo CD"},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileGrafanaDashboardConfigMaps(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-grafana-dashboard-argocd", Namespace: a.Namespace}, cm))
+	assert.Equal(t, "1", cm.Labels[common.ArgoCDDefaultGrafanaDashboardLabel])
+	assert.Equal(t, map[string]string{"grafana_folder": "Argo CD"}, cm.Annotations)
+	assert.Contains(t, cm.Data, "argocd.json")
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-grafana-dashboard-go", Namespace: a.Namespace}, cm))
+
+	// the sidecar labels can be customized
+	a.Spec.Grafana.DashboardConfigMaps.Labels = map[string]string{"dashboards": "argocd"}
+	assert.NoError(t, r.reconcileGrafanaDashboardConfigMaps(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-grafana-dashboard-argocd", Namespace: a.Namespace}, cm))
+	assert.Equal(t, "argocd", cm.Labels["dashboards"])
+	_, ok := cm.Labels[common.ArgoCDDefaultGrafanaDashboardLabel]
+	assert.False(t, ok)
+
+	// the ConfigMaps are removed once disabled
+	a.Spec.Grafana.DashboardConfigMaps.Enabled = false
+	assert.NoError(t, r.reconcileGrafanaDashboardConfigMaps(a))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-grafana-dashboard-argocd", Namespace: a.Namespace}, cm))
+}
@@ -44,6 +44,9 @@ func isSecretOfInterest(o client.Object) bool {
 	if o.GetName() == common.ArgoCDRedisServerTLSSecretName {
 		return true
 	}
+	if o.GetName() == common.ArgoCDServerTLSSecretName || o.GetName() == common.ArgoCDDexServerTLSSecretName {
+		return true
+	}
 	return false
 }
 
@@ -149,6 +152,76 @@ func (r *ReconcileArgoCD) namespaceResourceMapper(o client.Object) []reconcile.R
 		result = []reconcile.Request{
 			{NamespacedName: namespacedName},
 		}
+		return result
+	}
+
+	// the namespace is not labelled yet, enqueue the ArgoCD instances that list it in
+	// .spec.sourceNamespaces or .spec.managedNamespaces, e.g. when it is created after the instance.
+	argocds := &argoprojv1alpha1.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds); err != nil {
+		return result
+	}
+
+	for _, argocd := range argocds.Items {
+		if !containsString(argocd.Spec.SourceNamespaces, o.GetName()) && !containsString(argocd.Spec.ManagedNamespaces, o.GetName()) {
+			continue
+		}
+		namespacedName := client.ObjectKey{
+			Name:      argocd.Name,
+			Namespace: argocd.Namespace,
+		}
+		result = append(result, reconcile.Request{NamespacedName: namespacedName})
+	}
+
+	return result
+}
+
+// clusterSecretResourceMapper maps a watch event on a cluster secret back to the ArgoCD object
+// in the same namespace that uses dynamic scaling of the Application controller shards or assigns
+// the clusters to the shards, and a watch event on a secret referenced as the source of secret
+// material, such as the admin password, back to the ArgoCD object in the same namespace that
+// references it.
+func (r *ReconcileArgoCD) clusterSecretResourceMapper(o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	isClusterSecret := o.GetLabels()[common.ArgoCDSecretTypeLabel] == "cluster"
+
+	argocds := &argoprojv1alpha1.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		return result
+	}
+
+	for _, argocd := range argocds.Items {
+		usesClusterSecrets := isControllerDynamicScalingEnabled(&argocd) || isClusterShardAssignmentEnabled(&argocd)
+		if !(isClusterSecret && usesClusterSecrets) && !isSecretReferenced(o.GetName(), &argocd) {
+			continue
+		}
+		namespacedName := client.ObjectKey{
+			Name:      argocd.Name,
+			Namespace: argocd.Namespace,
+		}
+		result = append(result, reconcile.Request{NamespacedName: namespacedName})
+	}
+
+	return result
+}
+
+// resourceQuotaMapper maps a watch event on a ResourceQuota back to the ArgoCD objects in the same namespace, whose
+// components are validated against it.
+func (r *ReconcileArgoCD) resourceQuotaMapper(o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	argocds := &argoprojv1alpha1.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		return result
+	}
+
+	for _, argocd := range argocds.Items {
+		namespacedName := client.ObjectKey{
+			Name:      argocd.Name,
+			Namespace: argocd.Namespace,
+		}
+		result = append(result, reconcile.Request{NamespacedName: namespacedName})
 	}
 
 	return result
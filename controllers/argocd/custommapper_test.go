@@ -582,8 +582,32 @@ func TestReconcileArgoCD_namespaceResourceMapper(t *testing.T) {
 			},
 			want: []reconcile.Request{},
 		},
+		{
+			name: "test when namespace is listed as source namespace",
+			o: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "sourceNamespace",
+				},
+			},
+			want: []reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Name:      "source-argocd",
+						Namespace: "sourceArgoCDNamespace",
+					},
+				},
+			},
+		},
 	}
 
+	sourceArgoCD := makeTestArgoCD(func(cr *v1alpha1.ArgoCD) {
+		cr.Name = "source-argocd"
+		cr.Namespace = "sourceArgoCDNamespace"
+		cr.ResourceVersion = ""
+		cr.Spec.SourceNamespaces = []string{"sourceNamespace"}
+	})
+	assert.NoError(t, r.Client.Create(context.TODO(), sourceArgoCD))
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if got := r.namespaceResourceMapper(tt.o); !reflect.DeepEqual(got, tt.want) {
@@ -592,3 +616,153 @@ func TestReconcileArgoCD_namespaceResourceMapper(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileArgoCD_clusterSecretResourceMapper(t *testing.T) {
+	a := makeTestArgoCD(func(a *v1alpha1.ArgoCD) {
+		a.Spec.Controller.Sharding.DynamicScalingEnabled = boolPtr(true)
+	})
+	r := makeTestReconciler(t, a)
+
+	type test struct {
+		name string
+		o    client.Object
+		want []reconcile.Request
+	}
+
+	tests := []test{
+		{
+			name: "test when secret is a cluster secret",
+			o: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster-secret",
+					Namespace: a.Namespace,
+					Labels: map[string]string{
+						common.ArgoCDSecretTypeLabel: "cluster",
+					},
+				},
+			},
+			want: []reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Name:      a.Name,
+						Namespace: a.Namespace,
+					},
+				},
+			},
+		},
+		{
+			name: "test when secret is not a cluster secret",
+			o: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "other-secret",
+					Namespace: a.Namespace,
+				},
+			},
+			want: []reconcile.Request{},
+		},
+		{
+			name: "test when cluster secret is in another namespace",
+			o: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster-secret",
+					Namespace: "other-namespace",
+					Labels: map[string]string{
+						common.ArgoCDSecretTypeLabel: "cluster",
+					},
+				},
+			},
+			want: []reconcile.Request{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.clusterSecretResourceMapper(tt.o); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReconcileArgoCD.clusterSecretResourceMapper(), got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileArgoCD_clusterSecretResourceMapper_shardAssignment(t *testing.T) {
+	a := makeTestArgoCD(func(a *v1alpha1.ArgoCD) {
+		a.Spec.Controller.Sharding.Enabled = true
+		a.Spec.Controller.Sharding.ClusterAssignment = &v1alpha1.ArgoCDClusterShardAssignmentSpec{}
+	})
+	r := makeTestReconciler(t, a)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-secret",
+			Namespace: a.Namespace,
+			Labels: map[string]string{
+				common.ArgoCDSecretTypeLabel: "cluster",
+			},
+		},
+	}
+	want := []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      a.Name,
+				Namespace: a.Namespace,
+			},
+		},
+	}
+	if got := r.clusterSecretResourceMapper(secret); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReconcileArgoCD.clusterSecretResourceMapper(), got = %v, want = %v", got, want)
+	}
+}
+
+func TestReconcileArgoCD_clusterSecretResourceMapper_adminPasswordSecret(t *testing.T) {
+	a := makeTestArgoCD(func(a *v1alpha1.ArgoCD) {
+		a.Spec.AdminPasswordSecretRef = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "admin-credentials"},
+			Key:                  "password",
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	type test struct {
+		name string
+		o    client.Object
+		want []reconcile.Request
+	}
+
+	tests := []test{
+		{
+			name: "test when secret is the admin password secret",
+			o: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "admin-credentials",
+					Namespace: a.Namespace,
+				},
+			},
+			want: []reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Name:      a.Name,
+						Namespace: a.Namespace,
+					},
+				},
+			},
+		},
+		{
+			name: "test when secret is not the admin password secret",
+			o: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "other-secret",
+					Namespace: a.Namespace,
+				},
+			},
+			want: []reconcile.Request{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.clusterSecretResourceMapper(tt.o); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReconcileArgoCD.clusterSecretResourceMapper(), got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
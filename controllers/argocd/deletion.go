@@ -0,0 +1,83 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// protectedSecretTypes are the values of the secret type label of the Secrets that hold credentials, and are kept
+// when an ArgoCD with deletion protection is deleted.
+var protectedSecretTypes = map[string]bool{
+	"cluster":    true,
+	"repository": true,
+	"repo-creds": true,
+}
+
+// isProtectedSecret returns true when the given Secret is kept when an ArgoCD with deletion protection is deleted.
+func isProtectedSecret(secret *corev1.Secret) bool {
+	return secret.Name == common.ArgoCDSecretName || protectedSecretTypes[secret.Labels[common.ArgoCDSecretTypeLabel]]
+}
+
+// removeOwnerReference removes the owner reference to the owner with the given UID from the given object, so that
+// the object is not garbage collected along with the owner. It returns false when the object is not owned by it.
+func removeOwnerReference(obj client.Object, uid types.UID) bool {
+	refs := []metav1.OwnerReference{}
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID != uid {
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) == len(obj.GetOwnerReferences()) {
+		return false
+	}
+	obj.SetOwnerReferences(refs)
+	return true
+}
+
+// orphanProtectedResources will ensure that the Secrets holding credentials of the given ArgoCD are not deleted along
+// with it when deletion protection is enabled. The Secrets are orphaned by removing their owner references to the
+// ArgoCD, so that the garbage collector leaves them in place once the ArgoCD is removed. Owner references to other
+// resources are kept, e.g. the PersistentVolumeClaims of the exports are still deleted along with their ArgoCDExport.
+func (r *ReconcileArgoCD) orphanProtectedResources(cr *argoprojv1a1.ArgoCD) error {
+	if !cr.Spec.DeletionProtection {
+		return nil
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := r.Client.List(context.TODO(), secrets, client.InNamespace(cr.Namespace)); err != nil {
+		return fmt.Errorf("failed to list secrets in namespace %s: %w", cr.Namespace, err)
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if !isProtectedSecret(secret) || !removeOwnerReference(secret, cr.UID) {
+			continue
+		}
+		log.Info(fmt.Sprintf("keeping secret %s as deletion protection is enabled", secret.Name))
+		if err := r.Client.Update(context.TODO(), secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcileArgoCD_orphanProtectedResources(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.UID = "argocd-uid"
+		a.Spec.DeletionProtection = true
+	})
+	owner := func(uid types.UID, kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: "argoproj.io/v1alpha1", Kind: kind, Name: name, UID: uid, Controller: boolPtr(true)}}
+	}
+	cluster := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name: "cluster", Namespace: a.Namespace, OwnerReferences: owner(a.UID, "ArgoCD", a.Name),
+		Labels: map[string]string{common.ArgoCDSecretTypeLabel: "cluster"},
+	}}
+	argoSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name: common.ArgoCDSecretName, Namespace: a.Namespace, OwnerReferences: owner(a.UID, "ArgoCD", a.Name),
+	}}
+	tls := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name: "tls", Namespace: a.Namespace, OwnerReferences: owner(a.UID, "ArgoCD", a.Name),
+	}}
+	r := makeTestReconciler(t, a, cluster, argoSecret, tls)
+
+	assert.NoError(t, r.orphanProtectedResources(a))
+
+	// the credentials are kept, the other resources are deleted along with the ArgoCD
+	for _, name := range []string{"cluster", common.ArgoCDSecretName} {
+		secret := &corev1.Secret{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, secret))
+		assert.Empty(t, secret.OwnerReferences)
+	}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: tls.Name, Namespace: a.Namespace}, tls))
+	assert.Len(t, tls.OwnerReferences, 1)
+}
+
+func TestReconcileArgoCD_orphanProtectedResources_exportPVC(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.UID = "argocd-uid"
+		a.Spec.DeletionProtection = true
+	})
+	export := &argoprojv1alpha1.ArgoCDExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "export", Namespace: a.Namespace, UID: "export-uid"},
+		Spec:       argoprojv1alpha1.ArgoCDExportSpec{Argocd: a.Name},
+	}
+	owners := []metav1.OwnerReference{{APIVersion: "argoproj.io/v1alpha1", Kind: "ArgoCDExport", Name: export.Name, UID: export.UID, Controller: boolPtr(true)}}
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+		Name: "export", Namespace: a.Namespace, OwnerReferences: owners,
+	}}
+	r := makeTestReconciler(t, a, export, pvc)
+
+	assert.NoError(t, r.orphanProtectedResources(a))
+
+	// the export data is still garbage collected along with the export
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: pvc.Name, Namespace: a.Namespace}, pvc))
+	assert.Equal(t, owners, pvc.OwnerReferences)
+}
+
+func TestReconcileArgoCD_orphanProtectedResources_disabled(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.UID = "argocd-uid"
+	})
+	cluster := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name: "cluster", Namespace: a.Namespace,
+		OwnerReferences: []metav1.OwnerReference{{APIVersion: "argoproj.io/v1alpha1", Kind: "ArgoCD", Name: a.Name, UID: a.UID}},
+		Labels:          map[string]string{common.ArgoCDSecretTypeLabel: "cluster"},
+	}}
+	r := makeTestReconciler(t, a, cluster)
+
+	assert.NoError(t, r.orphanProtectedResources(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cluster.Name, Namespace: a.Namespace}, cluster))
+	assert.Len(t, cluster.OwnerReferences, 1)
+}
@@ -19,9 +19,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"reflect"
+	"path"
+	"regexp"
 	"strings"
-	"time"
 
 	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
 	"github.com/argoproj-labs/argocd-operator/common"
@@ -36,27 +36,69 @@ import (
 )
 
 // getArgoCDRepoServerReplicas will return the size value for the argocd-repo-server replica count if it
-// has been set in argocd CR. Otherwise, nil is returned if the replicas is not set in the argocd CR or
-// replicas value is < 0.
+// has been set in argocd CR. Otherwise, the HA replica count is returned when HA is enabled, and nil is
+// returned if the replicas is not set in the argocd CR or replicas value is < 0.
 func getArgoCDRepoServerReplicas(cr *argoprojv1a1.ArgoCD) *int32 {
 	if cr.Spec.Repo.Replicas != nil && *cr.Spec.Repo.Replicas >= 0 {
 		return cr.Spec.Repo.Replicas
 	}
 
+	if cr.Spec.HA.Enabled {
+		replicas := common.ArgoCDDefaultHAReplicas
+		return &replicas
+	}
+
 	return nil
 }
 
 // getArgoCDServerReplicas will return the size value for the argocd-server replica count if it
-// has been set in argocd CR. Otherwise, nil is returned if the replicas is not set in the argocd CR or
-// replicas value is < 0. If Autoscale is enabled, the value for replicas in the argocd CR will be ignored.
+// has been set in argocd CR. Otherwise, the HA replica count is returned when HA is enabled, and nil is
+// returned if the replicas is not set in the argocd CR or replicas value is < 0. If Autoscale is enabled,
+// the value for replicas in the argocd CR will be ignored.
 func getArgoCDServerReplicas(cr *argoprojv1a1.ArgoCD) *int32 {
-	if !cr.Spec.Server.Autoscale.Enabled && cr.Spec.Server.Replicas != nil && *cr.Spec.Server.Replicas >= 0 {
+	if cr.Spec.Server.Autoscale.Enabled {
+		return nil
+	}
+
+	if cr.Spec.Server.Replicas != nil && *cr.Spec.Server.Replicas >= 0 {
 		return cr.Spec.Server.Replicas
 	}
 
+	if cr.Spec.HA.Enabled {
+		replicas := common.ArgoCDDefaultHAReplicas
+		return &replicas
+	}
+
 	return nil
 }
 
+// getArgoCDHAAffinity will return the Affinity that spreads the Pods of the component with the given name across
+// nodes when HA is enabled, or nil otherwise. The anti-affinity is preferred, so that the Pods are still scheduled
+// on clusters with fewer nodes than replicas.
+func getArgoCDHAAffinity(name string, cr *argoprojv1a1.ArgoCD) *corev1.Affinity {
+	if !cr.Spec.HA.Enabled {
+		return nil
+	}
+
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								common.ArgoCDKeyName: name,
+							},
+						},
+						TopologyKey: common.ArgoCDKeyHostname,
+					},
+					Weight: int32(100),
+				},
+			},
+		},
+	}
+}
+
 func (r *ReconcileArgoCD) getArgoCDExport(cr *argoprojv1a1.ArgoCD) *argoprojv1a1.ArgoCDExport {
 	if cr.Spec.Import == nil {
 		return nil
@@ -138,6 +180,9 @@ func getArgoImportContainerEnv(cr *argoprojv1a1.ArgoCDExport) []corev1.EnvVar {
 		})
 	}
 
+	env = append(env, argoutil.FetchStorageLocationEnv(cr)...)
+	env = append(env, argoutil.FetchEncryptionKeyEnv(cr)...)
+
 	return env
 }
 
@@ -157,7 +202,7 @@ func getArgoImportContainerImage(cr *argoprojv1a1.ArgoCDExport) string {
 }
 
 // getArgoImportVolumeMounts will return the VolumneMounts for the given ArgoCDExport.
-func getArgoImportVolumeMounts() []corev1.VolumeMount {
+func getArgoImportVolumeMounts(cr *argoprojv1a1.ArgoCDExport) []corev1.VolumeMount {
 	mounts := make([]corev1.VolumeMount, 0)
 
 	mounts = append(mounts, corev1.VolumeMount{
@@ -170,6 +215,10 @@ func getArgoImportVolumeMounts() []corev1.VolumeMount {
 		MountPath: "/secrets",
 	})
 
+	if _, mount := argoutil.FetchEncryptionKeyVolume(cr); mount != nil {
+		mounts = append(mounts, *mount)
+	}
+
 	return mounts
 }
 
@@ -204,14 +253,24 @@ func getArgoImportVolumes(cr *argoprojv1a1.ArgoCDExport) []corev1.Volume {
 		},
 	})
 
+	if volume, _ := argoutil.FetchEncryptionKeyVolume(cr); volume != nil {
+		volumes = append(volumes, *volume)
+	}
+
 	return volumes
 }
 
-func getArgoRedisArgs(useTLS bool) []string {
+func getArgoRedisArgs(cr *argoprojv1a1.ArgoCD, useTLS bool) []string {
 	args := make([]string, 0)
 
-	args = append(args, "--save", "")
-	args = append(args, "--appendonly", "no")
+	if cr.Spec.Redis.IsPersistent() {
+		// Keep the default RDB snapshots and additionally log every write to the append only file
+		args = append(args, "--dir", "/data")
+		args = append(args, "--appendonly", "yes")
+	} else {
+		args = append(args, "--save", "")
+		args = append(args, "--appendonly", "no")
+	}
 
 	if useTLS {
 		args = append(args, "--tls-port", "6379")
@@ -232,8 +291,7 @@ func getArgoRepoCommand(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) []string {
 	cmd = append(cmd, "uid_entrypoint.sh")
 	cmd = append(cmd, "argocd-repo-server")
 
-	cmd = append(cmd, "--redis")
-	cmd = append(cmd, getRedisServerAddress(cr))
+	cmd = append(cmd, getRedisServerArgs(cr)...)
 
 	if useTLSForRedis {
 		cmd = append(cmd, "--redis-use-tls")
@@ -272,6 +330,79 @@ func getArgoCmpServerInitCommand() []string {
 	return cmd
 }
 
+// getKustomizeVersionInitContainerName will return the name of the init container providing the given kustomize version.
+func getKustomizeVersionInitContainerName(version string) string {
+	name := strings.ToLower(version)
+	name = strings.NewReplacer(".", "-", "+", "-", "_", "-").Replace(name)
+	return fmt.Sprintf("kustomize-%s", name)
+}
+
+// kustomizeVersionPattern matches the kustomize versions that can be used as the name of a file in the kustomize
+// versions volume, as validated by the CRD.
+var kustomizeVersionPattern = regexp.MustCompile(`^v?[0-9A-Za-z.\-]+$`)
+
+// getImageKustomizeVersions will return the image based kustomize versions of the given ArgoCD. Versions that do not
+// name a file in the kustomize versions volume, e.g. "..", are skipped.
+func getImageKustomizeVersions(cr *argoprojv1a1.ArgoCD) []argoprojv1a1.KustomizeVersionSpec {
+	versions := make([]argoprojv1a1.KustomizeVersionSpec, 0)
+	for _, kv := range cr.Spec.KustomizeVersions {
+		if kv.Image == "" || kv.Path == "" {
+			continue
+		}
+		if !kustomizeVersionPattern.MatchString(kv.Version) || kv.Version == "." || strings.Contains(kv.Version, "..") {
+			log.Info(fmt.Sprintf("invalid kustomize version %q for image %s, skipping", kv.Version, kv.Image))
+			continue
+		}
+		versions = append(versions, kv)
+	}
+	return versions
+}
+
+// getKustomizeVersionsInitContainers will return the init containers that copy the kustomize binaries for
+// the image based kustomize versions of the given ArgoCD into the shared kustomize versions volume.
+func getKustomizeVersionsInitContainers(cr *argoprojv1a1.ArgoCD) []corev1.Container {
+	containers := make([]corev1.Container, 0)
+	for _, kv := range getImageKustomizeVersions(cr) {
+		containers = append(containers, corev1.Container{
+			Name:            getKustomizeVersionInitContainerName(kv.Version),
+			Image:           kv.Image,
+			Command:         []string{"cp", kv.Path, path.Join(common.ArgoCDKustomizeVersionsMountPath, kv.Version)},
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Resources:       getArgoRepoResources(cr),
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: boolPtr(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{
+						"ALL",
+					},
+				},
+				RunAsNonRoot: boolPtr(true),
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      common.ArgoCDKustomizeVersionsVolumeName,
+					MountPath: common.ArgoCDKustomizeVersionsMountPath,
+				},
+			},
+		})
+	}
+	return containers
+}
+
+// getKustomizeVersionsVolumeMounts will return the repo server VolumeMounts that expose the image based
+// kustomize versions of the given ArgoCD at their configured path.
+func getKustomizeVersionsVolumeMounts(cr *argoprojv1a1.ArgoCD) []corev1.VolumeMount {
+	mounts := make([]corev1.VolumeMount, 0)
+	for _, kv := range getImageKustomizeVersions(cr) {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      common.ArgoCDKustomizeVersionsVolumeName,
+			MountPath: kv.Path,
+			SubPath:   kv.Version,
+		})
+	}
+	return mounts
+}
+
 // getArgoServerCommand will return the command for the ArgoCD server component.
 func getArgoServerCommand(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) []string {
 	cmd := make([]string, 0)
@@ -294,8 +425,7 @@ func getArgoServerCommand(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) []string
 	cmd = append(cmd, "--repo-server")
 	cmd = append(cmd, getRepoServerAddress(cr))
 
-	cmd = append(cmd, "--redis")
-	cmd = append(cmd, getRedisServerAddress(cr))
+	cmd = append(cmd, getRedisServerArgs(cr)...)
 
 	if useTLSForRedis {
 		cmd = append(cmd, "--redis-use-tls")
@@ -312,6 +442,16 @@ func getArgoServerCommand(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) []string
 	cmd = append(cmd, "--logformat")
 	cmd = append(cmd, getLogFormat(cr.Spec.Server.LogFormat))
 
+	if cr.Spec.Server.RootPath != "" {
+		cmd = append(cmd, "--rootpath")
+		cmd = append(cmd, cr.Spec.Server.RootPath)
+	}
+
+	if cr.Spec.Server.BaseHRef != "" {
+		cmd = append(cmd, "--basehref")
+		cmd = append(cmd, cr.Spec.Server.BaseHRef)
+	}
+
 	extraArgs := cr.Spec.Server.ExtraCommandArgs
 	err := isMergable(extraArgs, cmd)
 	if err != nil {
@@ -346,8 +486,12 @@ func getDexServerAddress(cr *argoprojv1a1.ArgoCD) string {
 	return fmt.Sprintf("https://%s", fqdnServiceRef("dex-server", common.ArgoCDDefaultDexHTTPPort, cr))
 }
 
-// getRepoServerAddress will return the Argo CD repo server address.
+// getRepoServerAddress will return the Argo CD repo server address, which is the address of the remote repo server
+// when one is used.
 func getRepoServerAddress(cr *argoprojv1a1.ArgoCD) string {
+	if cr.Spec.Repo.IsRemote() {
+		return cr.Spec.Repo.Remote
+	}
 	return fqdnServiceRef("repo-server", common.ArgoCDDefaultRepoServerPort, cr)
 }
 
@@ -390,6 +534,9 @@ func newDeploymentWithName(name string, component string, cr *argoprojv1a1.ArgoC
 		},
 	}
 
+	deploy.Spec.RevisionHistoryLimit = getRevisionHistoryLimit(cr)
+	deploy.Spec.ProgressDeadlineSeconds = getProgressDeadlineSeconds(cr)
+
 	if cr.Spec.NodePlacement != nil {
 		deploy.Spec.Template.Spec.NodeSelector = argoutil.AppendStringMap(deploy.Spec.Template.Spec.NodeSelector, cr.Spec.NodePlacement.NodeSelector)
 		deploy.Spec.Template.Spec.Tolerations = cr.Spec.NodePlacement.Tolerations
@@ -397,44 +544,29 @@ func newDeploymentWithName(name string, component string, cr *argoprojv1a1.ArgoC
 	return deploy
 }
 
-// newDeploymentWithSuffix returns a new Deployment instance for the given ArgoCD using the given suffix.
-func newDeploymentWithSuffix(suffix string, component string, cr *argoprojv1a1.ArgoCD) *appsv1.Deployment {
-	return newDeploymentWithName(fmt.Sprintf("%s-%s", cr.Name, suffix), component, cr)
-}
-
-// reconcileDeployments will ensure that all Deployment resources are present for the given ArgoCD.
-func (r *ReconcileArgoCD) reconcileDeployments(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) error {
-
-	if err := r.reconcileDexDeployment(cr); err != nil {
-		log.Error(err, "error reconciling dex deployment")
-	}
-
-	err := r.reconcileRedisDeployment(cr, useTLSForRedis)
-	if err != nil {
-		return err
-	}
-
-	err = r.reconcileRedisHAProxyDeployment(cr)
-	if err != nil {
-		return err
-	}
-
-	err = r.reconcileRepoDeployment(cr, useTLSForRedis)
-	if err != nil {
-		return err
-	}
-
-	err = r.reconcileServerDeployment(cr, useTLSForRedis)
-	if err != nil {
-		return err
+// getRevisionHistoryLimit returns the number of old revisions kept for the Deployments and StatefulSets of the given
+// ArgoCD.
+func getRevisionHistoryLimit(cr *argoprojv1a1.ArgoCD) *int32 {
+	limit := common.ArgoCDDefaultRevisionHistoryLimit
+	if cr.Spec.Workloads != nil && cr.Spec.Workloads.RevisionHistoryLimit != nil {
+		limit = *cr.Spec.Workloads.RevisionHistoryLimit
 	}
+	return &limit
+}
 
-	err = r.reconcileGrafanaDeployment(cr)
-	if err != nil {
-		return err
+// getProgressDeadlineSeconds returns the progress deadline of the Deployments of the given ArgoCD, or nil to keep the
+// Kubernetes default.
+func getProgressDeadlineSeconds(cr *argoprojv1a1.ArgoCD) *int32 {
+	if cr.Spec.Workloads == nil || cr.Spec.Workloads.ProgressDeadlineSeconds == nil {
+		return nil
 	}
+	deadline := *cr.Spec.Workloads.ProgressDeadlineSeconds
+	return &deadline
+}
 
-	return nil
+// newDeploymentWithSuffix returns a new Deployment instance for the given ArgoCD using the given suffix.
+func newDeploymentWithSuffix(suffix string, component string, cr *argoprojv1a1.ArgoCD) *appsv1.Deployment {
+	return newDeploymentWithName(fmt.Sprintf("%s-%s", cr.Name, suffix), component, cr)
 }
 
 // reconcileGrafanaDeployment will ensure the Deployment resource is present for the ArgoCD Grafana component.
@@ -533,40 +665,15 @@ func (r *ReconcileArgoCD) reconcileGrafanaDeployment(cr *argoprojv1a1.ArgoCD) er
 		},
 	}
 
-	existing := newDeploymentWithSuffix("grafana", "grafana", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		if !cr.Spec.Grafana.Enabled {
-			// Deployment exists but enabled flag has been set to false, delete the Deployment
+	if !cr.Spec.Grafana.Enabled {
+		// Grafana is not enabled, delete the Deployment if it exists
+		existing := newDeploymentWithSuffix("grafana", "grafana", cr)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 			return r.Client.Delete(context.TODO(), existing)
 		}
-		changed := false
-		if hasGrafanaSpecChanged(existing, cr) {
-			existing.Spec.Replicas = cr.Spec.Grafana.Size
-			changed = true
-		}
-		updateNodePlacement(existing, deploy, &changed)
-		if !reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].Env,
-			deploy.Spec.Template.Spec.Containers[0].Env) {
-			existing.Spec.Template.Spec.Containers[0].Env = deploy.Spec.Template.Spec.Containers[0].Env
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Resources, existing.Spec.Template.Spec.Containers[0].Resources) {
-			existing.Spec.Template.Spec.Containers[0].Resources = deploy.Spec.Template.Spec.Containers[0].Resources
-			changed = true
-		}
-		if changed {
-			return r.Client.Update(context.TODO(), existing)
-		}
-		return nil // Deployment found, do nothing
-	}
-
-	if !cr.Spec.Grafana.Enabled {
-		return nil // Grafana not enabled, do nothing.
-	}
-	if err := controllerutil.SetControllerReference(cr, deploy, r.Scheme); err != nil {
-		return err
+		return nil
 	}
-	return r.Client.Create(context.TODO(), deploy)
+	return r.applyResource(cr, deploy)
 }
 
 // reconcileRedisDeployment will ensure the Deployment resource is present for the ArgoCD Redis component.
@@ -575,18 +682,25 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoprojv1a1.ArgoCD, useT
 
 	AddSeccompProfileForOpenShift(r.Client, &deploy.Spec.Template.Spec)
 
+	args := getArgoRedisArgs(cr, useTLS)
+	if isRedisAuthEnabled(cr) {
+		args = append(args, "--requirepass", "$(REDIS_PASSWORD)")
+	}
+
 	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
-		Args:            getArgoRedisArgs(useTLS),
+		Args:            args,
 		Image:           getRedisContainerImage(cr),
 		ImagePullPolicy: corev1.PullAlways,
+		LivenessProbe:   getRedisProbe(cr.Spec.Redis.LivenessProbe, nil),
 		Name:            "redis",
 		Ports: []corev1.ContainerPort{
 			{
 				ContainerPort: common.ArgoCDDefaultRedisPort,
 			},
 		},
-		Resources: getRedisResources(cr),
-		Env:       proxyEnvVars(),
+		ReadinessProbe: getRedisProbe(cr.Spec.Redis.ReadinessProbe, nil),
+		Resources:      getRedisResources(cr),
+		Env:            proxyEnvVars(getRedisAuthEnv(cr, "REDIS_PASSWORD")...),
 		SecurityContext: &corev1.SecurityContext{
 			AllowPrivilegeEscalation: boolPtr(false),
 			Capabilities: &corev1.Capabilities{
@@ -606,6 +720,7 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoprojv1a1.ArgoCD, useT
 	}}
 
 	deploy.Spec.Template.Spec.ServiceAccountName = fmt.Sprintf("%s-%s", cr.Name, "argocd-redis")
+	deploy.Spec.Template.Spec.TerminationGracePeriodSeconds = getRedisTerminationGracePeriodSeconds(cr, corev1.DefaultTerminationGracePeriodSeconds)
 	deploy.Spec.Template.Spec.Volumes = []corev1.Volume{
 		{
 			Name: common.ArgoCDRedisServerTLSSecretName,
@@ -618,86 +733,100 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoprojv1a1.ArgoCD, useT
 		},
 	}
 
-	if err := applyReconcilerHook(cr, deploy, ""); err != nil {
-		return err
-	}
-
-	existing := newDeploymentWithSuffix("redis", "redis", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		if cr.Spec.HA.Enabled {
-			// Deployment exists but HA enabled flag has been set to true, delete the Deployment
-			return r.Client.Delete(context.TODO(), deploy)
+	if cr.Spec.Redis.IsPersistent() {
+		deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      common.ArgoCDRedisDataVolumeName,
+			MountPath: "/data",
+		})
+		deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: common.ArgoCDRedisDataVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: nameWithSuffix("redis-data", cr),
+				},
+			},
+		})
+		if deploy.Spec.Template.Spec.SecurityContext == nil {
+			deploy.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{}
 		}
-		changed := false
-		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getRedisContainerImage(cr)
-		if actualImage != desiredImage {
-			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
-			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
-			changed = true
+		deploy.Spec.Template.Spec.SecurityContext.FSGroup = int64Ptr(999)
+		// The volume can only be attached to a single node, old pods must be gone before new ones start
+		deploy.Spec.Strategy = appsv1.DeploymentStrategy{
+			Type: appsv1.RecreateDeploymentStrategyType,
 		}
-		updateNodePlacement(existing, deploy, &changed)
+	}
 
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Args, existing.Spec.Template.Spec.Containers[0].Args) {
-			existing.Spec.Template.Spec.Containers[0].Args = deploy.Spec.Template.Spec.Containers[0].Args
-			changed = true
-		}
+	if err := r.reconcileRedisPersistentVolumeClaim(cr); err != nil {
+		return err
+	}
 
-		if !reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].Env,
-			deploy.Spec.Template.Spec.Containers[0].Env) {
-			existing.Spec.Template.Spec.Containers[0].Env = deploy.Spec.Template.Spec.Containers[0].Env
-			changed = true
-		}
+	if err := applyReconcilerHook(cr, deploy, ""); err != nil {
+		return err
+	}
 
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Resources, existing.Spec.Template.Spec.Containers[0].Resources) {
-			existing.Spec.Template.Spec.Containers[0].Resources = deploy.Spec.Template.Spec.Containers[0].Resources
-			changed = true
+	if cr.Spec.HA.Enabled || cr.Spec.Redis.IsRemote() {
+		// HA is enabled or a remote Redis is used, delete the Deployment if it exists
+		existing := newDeploymentWithSuffix("redis", "redis", cr)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+			return r.Client.Delete(context.TODO(), existing)
 		}
+		return nil
+	}
+	return r.applyResource(cr, deploy)
+}
 
-		if changed {
-			return r.Client.Update(context.TODO(), existing)
+// reconcileRedisPersistentVolumeClaim will ensure the PersistentVolumeClaim backing the data of the non-HA Redis
+// Deployment is present when persistence is enabled for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileRedisPersistentVolumeClaim(cr *argoprojv1a1.ArgoCD) error {
+	pvc := argoutil.NewPersistentVolumeClaimWithName(nameWithSuffix("redis-data", cr), cr.ObjectMeta)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, pvc.Name, pvc)
+
+	if !cr.Spec.Redis.IsPersistent() || cr.Spec.HA.Enabled || cr.Spec.Redis.IsRemote() {
+		// Only remove the claim if it was created by the operator
+		if found && metav1.IsControlledBy(pvc, cr) {
+			log.Info(fmt.Sprintf("deleting redis persistent volume claim %s as it is no longer required", pvc.Name))
+			return r.Client.Delete(context.TODO(), pvc)
 		}
-		return nil // Deployment found with nothing to do, move along...
+		return nil
 	}
 
-	if cr.Spec.HA.Enabled {
-		return nil // HA enabled, do nothing.
+	desired := getRedisPersistentVolumeClaimSpec(cr)
+	if found {
+		// Claims can only be expanded, a smaller size is ignored
+		existingSize := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		desiredSize := desired.Resources.Requests[corev1.ResourceStorage]
+		if desiredSize.Cmp(existingSize) > 0 {
+			if pvc.Spec.Resources.Requests == nil {
+				pvc.Spec.Resources.Requests = corev1.ResourceList{}
+			}
+			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = desiredSize
+			return r.Client.Update(context.TODO(), pvc)
+		}
+		return nil // PersistentVolumeClaim found, do nothing
 	}
-	if err := controllerutil.SetControllerReference(cr, deploy, r.Scheme); err != nil {
+
+	pvc.Spec = desired
+	if err := controllerutil.SetControllerReference(cr, pvc, r.Scheme); err != nil {
 		return err
 	}
-	return r.Client.Create(context.TODO(), deploy)
+	log.Info(fmt.Sprintf("creating redis persistent volume claim %s", pvc.Name))
+	return r.Client.Create(context.TODO(), pvc)
 }
 
 // reconcileRedisHAProxyDeployment will ensure the Deployment resource is present for the Redis HA Proxy component.
 func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoprojv1a1.ArgoCD) error {
 	deploy := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
 
-	existing := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		if !cr.Spec.HA.Enabled {
-			// Deployment exists but HA enabled flag has been set to false, delete the Deployment
+	if !cr.Spec.HA.Enabled {
+		// HA is not enabled, delete the Deployment if it exists
+		existing := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 			return r.Client.Delete(context.TODO(), existing)
 		}
-		changed := false
-		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getRedisHAProxyContainerImage(cr)
-
-		if actualImage != desiredImage {
-			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
-			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
-			changed = true
-		}
-		updateNodePlacement(existing, deploy, &changed)
-		if changed {
-			return r.Client.Update(context.TODO(), existing)
-		}
-		return nil // Deployment found, do nothing
+		return nil
 	}
 
-	if !cr.Spec.HA.Enabled {
-		return nil // HA not enabled, do nothing.
-	}
+	deploy.Spec.Replicas = cr.Spec.HA.RedisProxyReplicas
 
 	deploy.Spec.Template.Spec.Affinity = &corev1.Affinity{
 		PodAntiAffinity: &corev1.PodAntiAffinity{
@@ -784,7 +913,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoprojv1a1.ArgoC
 		Image:           getRedisHAProxyContainerImage(cr),
 		ImagePullPolicy: corev1.PullIfNotPresent,
 		Name:            "config-init",
-		Env:             proxyEnvVars(),
+		Env:             proxyEnvVars(getRedisAuthEnv(cr, "AUTH")...),
 		Resources:       getRedisHAProxyResources(cr),
 		SecurityContext: &corev1.SecurityContext{
 			AllowPrivilegeEscalation: boolPtr(false),
@@ -858,10 +987,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoprojv1a1.ArgoC
 		return err
 	}
 
-	if err := controllerutil.SetControllerReference(cr, deploy, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), deploy)
+	return r.applyResource(cr, deploy)
 }
 
 // reconcileRepoDeployment will ensure the Deployment resource is present for the ArgoCD Repo component.
@@ -874,23 +1000,25 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoprojv1a1.ArgoCD, useTL
 
 	deploy.Spec.Template.Spec.AutomountServiceAccountToken = &automountToken
 
-	if cr.Spec.Repo.ServiceAccount != "" {
-		deploy.Spec.Template.Spec.ServiceAccountName = cr.Spec.Repo.ServiceAccount
-	}
+	deploy.Spec.Template.Spec.ServiceAccountName = getRepoServerServiceAccountName(cr)
 
 	// Global proxy env vars go first
 	repoEnv := cr.Spec.Repo.Env
 	// Environment specified in the CR take precedence over everything else
 	repoEnv = argoutil.EnvMerge(repoEnv, proxyEnvVars(), false)
+	repoEnv = argoutil.EnvMerge(repoEnv, getRedisCredentialsEnv(cr), false)
+	repoEnv = argoutil.EnvMerge(repoEnv, getCmdParamsEnv(cmdParamsPrefixRepoServer, cr), false)
 	if cr.Spec.Repo.ExecTimeout != nil {
 		repoEnv = argoutil.EnvMerge(repoEnv, []corev1.EnvVar{{Name: "ARGOCD_EXEC_TIMEOUT", Value: fmt.Sprintf("%d", *cr.Spec.Repo.ExecTimeout)}}, true)
 	}
 
 	AddSeccompProfileForOpenShift(r.Client, &deploy.Spec.Template.Spec)
 
+	image := r.getArgoContainerImageForStage(cr, "repo-server")
+	setWorkloadArgoImage(deploy, image)
 	deploy.Spec.Template.Spec.InitContainers = []corev1.Container{{
 		Name:            "copyutil",
-		Image:           getArgoContainerImage(cr),
+		Image:           image,
 		Command:         getArgoCmpServerInitCommand(),
 		ImagePullPolicy: corev1.PullAlways,
 		Resources:       getArgoRepoResources(cr),
@@ -912,6 +1040,8 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoprojv1a1.ArgoCD, useTL
 		},
 	}}
 
+	deploy.Spec.Template.Spec.InitContainers = append(deploy.Spec.Template.Spec.InitContainers, getKustomizeVersionsInitContainers(cr)...)
+
 	if cr.Spec.Repo.InitContainers != nil {
 		deploy.Spec.Template.Spec.InitContainers = append(deploy.Spec.Template.Spec.InitContainers, cr.Spec.Repo.InitContainers...)
 	}
@@ -951,25 +1081,19 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoprojv1a1.ArgoCD, useTL
 		},
 	}
 
+	repoServerVolumeMounts = append(repoServerVolumeMounts, getKustomizeVersionsVolumeMounts(cr)...)
+
 	if cr.Spec.Repo.VolumeMounts != nil {
 		repoServerVolumeMounts = append(repoServerVolumeMounts, cr.Spec.Repo.VolumeMounts...)
 	}
 
 	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
 		Command:         getArgoRepoCommand(cr, useTLSForRedis),
-		Image:           getRepoServerContainerImage(cr),
+		Image:           image,
 		ImagePullPolicy: corev1.PullAlways,
-		LivenessProbe: &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
-				TCPSocket: &corev1.TCPSocketAction{
-					Port: intstr.FromInt(common.ArgoCDDefaultRepoServerPort),
-				},
-			},
-			InitialDelaySeconds: 5,
-			PeriodSeconds:       10,
-		},
-		Env:  repoEnv,
-		Name: "argocd-repo-server",
+		LivenessProbe:   getArgoRepoLivenessProbe(cr),
+		Env:             repoEnv,
+		Name:            "argocd-repo-server",
 		Ports: []corev1.ContainerPort{
 			{
 				ContainerPort: common.ArgoCDDefaultRepoServerPort,
@@ -979,16 +1103,8 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoprojv1a1.ArgoCD, useTL
 				Name:          "metrics",
 			},
 		},
-		ReadinessProbe: &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
-				TCPSocket: &corev1.TCPSocketAction{
-					Port: intstr.FromInt(common.ArgoCDDefaultRepoServerPort),
-				},
-			},
-			InitialDelaySeconds: 5,
-			PeriodSeconds:       10,
-		},
-		Resources: getArgoRepoResources(cr),
+		ReadinessProbe: getArgoRepoReadinessProbe(cr),
+		Resources:      getArgoRepoResources(cr),
 		SecurityContext: &corev1.SecurityContext{
 			AllowPrivilegeEscalation: boolPtr(false),
 			Capabilities: &corev1.Capabilities{
@@ -1080,90 +1196,31 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoprojv1a1.ArgoCD, useTL
 		},
 	}
 
+	if len(getKustomizeVersionsVolumeMounts(cr)) > 0 {
+		repoServerVolumes = append(repoServerVolumes, corev1.Volume{
+			Name: common.ArgoCDKustomizeVersionsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
 	if cr.Spec.Repo.Volumes != nil {
 		repoServerVolumes = append(repoServerVolumes, cr.Spec.Repo.Volumes...)
 	}
 
 	deploy.Spec.Template.Spec.Volumes = repoServerVolumes
+	deploy.Spec.Template.Spec.TerminationGracePeriodSeconds = getArgoRepoTerminationGracePeriodSeconds(cr)
+	deploy.Spec.Template.Spec.Affinity = getArgoCDHAAffinity(deploy.Name, cr)
+	deploy.Spec.Template.Spec.HostAliases = cr.Spec.Repo.HostAliases
+	deploy.Spec.Template.Spec.DNSPolicy = cr.Spec.Repo.DNSPolicy
+	deploy.Spec.Template.Spec.DNSConfig = cr.Spec.Repo.DNSConfig
 
 	if replicas := getArgoCDRepoServerReplicas(cr); replicas != nil {
 		deploy.Spec.Replicas = replicas
 	}
 
-	existing := newDeploymentWithSuffix("repo-server", "repo-server", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		changed := false
-		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getRepoServerContainerImage(cr)
-		if actualImage != desiredImage {
-			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
-			if existing.Spec.Template.ObjectMeta.Labels == nil {
-				existing.Spec.Template.ObjectMeta.Labels = map[string]string{
-					"image.upgraded": time.Now().UTC().Format("01022006-150406-MST"),
-				}
-			}
-			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
-			changed = true
-		}
-		updateNodePlacement(existing, deploy, &changed)
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Volumes, existing.Spec.Template.Spec.Volumes) {
-			existing.Spec.Template.Spec.Volumes = deploy.Spec.Template.Spec.Volumes
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].VolumeMounts,
-			existing.Spec.Template.Spec.Containers[0].VolumeMounts) {
-			existing.Spec.Template.Spec.Containers[0].VolumeMounts = deploy.Spec.Template.Spec.Containers[0].VolumeMounts
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Env,
-			existing.Spec.Template.Spec.Containers[0].Env) {
-			existing.Spec.Template.Spec.Containers[0].Env = deploy.Spec.Template.Spec.Containers[0].Env
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Resources, existing.Spec.Template.Spec.Containers[0].Resources) {
-			existing.Spec.Template.Spec.Containers[0].Resources = deploy.Spec.Template.Spec.Containers[0].Resources
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Command, existing.Spec.Template.Spec.Containers[0].Command) {
-			existing.Spec.Template.Spec.Containers[0].Command = deploy.Spec.Template.Spec.Containers[0].Command
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[1:],
-			existing.Spec.Template.Spec.Containers[1:]) {
-			existing.Spec.Template.Spec.Containers = append(existing.Spec.Template.Spec.Containers[0:1],
-				deploy.Spec.Template.Spec.Containers[1:]...)
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.InitContainers, existing.Spec.Template.Spec.InitContainers) {
-			existing.Spec.Template.Spec.InitContainers = deploy.Spec.Template.Spec.InitContainers
-			changed = true
-		}
-
-		if !reflect.DeepEqual(deploy.Spec.Replicas, existing.Spec.Replicas) {
-			existing.Spec.Replicas = deploy.Spec.Replicas
-			changed = true
-		}
-
-		if deploy.Spec.Template.Spec.AutomountServiceAccountToken != existing.Spec.Template.Spec.AutomountServiceAccountToken {
-			existing.Spec.Template.Spec.AutomountServiceAccountToken = deploy.Spec.Template.Spec.AutomountServiceAccountToken
-			changed = true
-		}
-
-		if deploy.Spec.Template.Spec.ServiceAccountName != existing.Spec.Template.Spec.ServiceAccountName {
-			existing.Spec.Template.Spec.ServiceAccountName = deploy.Spec.Template.Spec.ServiceAccountName
-			changed = true
-		}
-
-		if changed {
-			return r.Client.Update(context.TODO(), existing)
-		}
-		return nil // Deployment found with nothing to do, move along...
-	}
-
-	if err := controllerutil.SetControllerReference(cr, deploy, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), deploy)
+	return r.applyResource(cr, deploy)
 }
 
 // reconcileServerDeployment will ensure the Deployment resource is present for the ArgoCD Server component.
@@ -1171,10 +1228,15 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoprojv1a1.ArgoCD, use
 	deploy := newDeploymentWithSuffix("server", "server", cr)
 	serverEnv := cr.Spec.Server.Env
 	serverEnv = argoutil.EnvMerge(serverEnv, proxyEnvVars(), false)
+	serverEnv = argoutil.EnvMerge(serverEnv, getRedisCredentialsEnv(cr), false)
+	serverEnv = argoutil.EnvMerge(serverEnv, getCmdParamsEnv(cmdParamsPrefixServer, cr), false)
+	serverEnv = argoutil.EnvMerge(serverEnv, getServerExtensionsEnv(cr), false)
 	AddSeccompProfileForOpenShift(r.Client, &deploy.Spec.Template.Spec)
+	image := r.getArgoContainerImageForStage(cr, "server")
+	setWorkloadArgoImage(deploy, image)
 	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
 		Command:         getArgoServerCommand(cr, useTLSForRedis),
-		Image:           getArgoContainerImage(cr),
+		Image:           image,
 		ImagePullPolicy: corev1.PullAlways,
 		Env:             serverEnv,
 		LivenessProbe: &corev1.Probe{
@@ -1275,59 +1337,34 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoprojv1a1.ArgoCD, use
 		},
 	}
 
-	if replicas := getArgoCDServerReplicas(cr); replicas != nil {
-		deploy.Spec.Replicas = replicas
+	if getArgoServerCustomStylesURL(cr) != "" {
+		deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "custom-styles",
+			MountPath: fmt.Sprintf("/shared/app/%s", common.ArgoCDDefaultServerCustomStylesPath),
+		})
+		deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: "custom-styles",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: cr.Spec.Server.CustomStyles.ConfigMap,
+					},
+					Optional: boolPtr(true),
+				},
+			},
+		})
 	}
 
-	existing := newDeploymentWithSuffix("server", "server", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getArgoContainerImage(cr)
-		changed := false
-		if actualImage != desiredImage {
-			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
-			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
-			changed = true
-		}
-		updateNodePlacement(existing, deploy, &changed)
-		if !reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].Env,
-			deploy.Spec.Template.Spec.Containers[0].Env) {
-			existing.Spec.Template.Spec.Containers[0].Env = deploy.Spec.Template.Spec.Containers[0].Env
-			changed = true
-		}
-		if !reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].Command,
-			deploy.Spec.Template.Spec.Containers[0].Command) {
-			existing.Spec.Template.Spec.Containers[0].Command = deploy.Spec.Template.Spec.Containers[0].Command
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Volumes, existing.Spec.Template.Spec.Volumes) {
-			existing.Spec.Template.Spec.Volumes = deploy.Spec.Template.Spec.Volumes
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].VolumeMounts,
-			existing.Spec.Template.Spec.Containers[0].VolumeMounts) {
-			existing.Spec.Template.Spec.Containers[0].VolumeMounts = deploy.Spec.Template.Spec.Containers[0].VolumeMounts
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Resources,
-			existing.Spec.Template.Spec.Containers[0].Resources) {
-			existing.Spec.Template.Spec.Containers[0].Resources = deploy.Spec.Template.Spec.Containers[0].Resources
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Replicas, existing.Spec.Replicas) {
-			existing.Spec.Replicas = deploy.Spec.Replicas
-			changed = true
-		}
-		if changed {
-			return r.Client.Update(context.TODO(), existing)
-		}
-		return nil // Deployment found with nothing to do, move along...
-	}
+	addExecProviderTools(cr, &deploy.Spec.Template, getArgoServerResources(cr))
+	addServerExtensions(cr, &deploy.Spec.Template, getArgoServerResources(cr))
 
-	if err := controllerutil.SetControllerReference(cr, deploy, r.Scheme); err != nil {
-		return err
+	deploy.Spec.Template.Spec.Affinity = getArgoCDHAAffinity(deploy.Name, cr)
+
+	if replicas := getArgoCDServerReplicas(cr); replicas != nil {
+		deploy.Spec.Replicas = replicas
 	}
-	return r.Client.Create(context.TODO(), deploy)
+
+	return r.applyResource(cr, deploy)
 }
 
 // triggerDeploymentRollout will update the label with the given key to trigger a new rollout of the Deployment.
@@ -1370,15 +1407,3 @@ func isRemoveManagedByLabelOnArgoCDDeletion() bool {
 	}
 	return false
 }
-
-// to update nodeSelector and tolerations in reconciler
-func updateNodePlacement(existing *appsv1.Deployment, deploy *appsv1.Deployment, changed *bool) {
-	if !reflect.DeepEqual(existing.Spec.Template.Spec.NodeSelector, deploy.Spec.Template.Spec.NodeSelector) {
-		existing.Spec.Template.Spec.NodeSelector = deploy.Spec.Template.Spec.NodeSelector
-		*changed = true
-	}
-	if !reflect.DeepEqual(existing.Spec.Template.Spec.Tolerations, deploy.Spec.Template.Spec.Tolerations) {
-		existing.Spec.Template.Spec.Tolerations = deploy.Spec.Template.Spec.Tolerations
-		*changed = true
-	}
-}
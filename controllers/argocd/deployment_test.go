@@ -135,6 +135,42 @@ func TestReconcileArgoCD_reconcile_ServerDeployment_replicas(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_reconcile_ServerDeployment_customStyles(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.CustomStyles = &argoprojv1alpha1.ArgoCDServerCustomStylesSpec{
+			ConfigMap: "argocd-styles",
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "custom-styles",
+		MountPath: "/shared/app/custom",
+	})
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "custom-styles",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "argocd-styles"},
+				Optional:             boolPtr(true),
+			},
+		},
+	})
+
+	// removing the custom styles removes the volume
+	a.Spec.Server.CustomStyles = nil
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	for _, volume := range deployment.Spec.Template.Spec.Volumes {
+		assert.NotEqual(t, "custom-styles", volume.Name)
+	}
+}
+
 func TestReconcileArgoCD_reconcileRepoDeployment_loglevel(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 
@@ -427,6 +463,186 @@ func TestReconcileArgoCD_reconcileRepoDeployment_initContainers(t *testing.T) {
 	assert.Equal(t, deployment.Spec.Template.Spec.InitContainers[1].Name, "test-init-container")
 }
 
+func TestReconcileArgoCD_reconcileRepoDeployment_kustomizeVersions(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.KustomizeVersions = []argoprojv1alpha1.KustomizeVersionSpec{
+			{
+				Version: "v4.1.0",
+				Path:    "/custom-tools/kustomize_4_1",
+				Image:   "quay.io/example/kustomize:v4.1.0",
+			},
+			{
+				Version: "v3.5.4",
+				Path:    "/custom-tools/kustomize_3_5",
+			},
+		}
+		a.Spec.Repo.InitContainers = []corev1.Container{{
+			Name:  "test-init-container",
+			Image: "test-image",
+		}}
+	})
+	r := makeTestReconciler(t, a)
+
+	err := r.reconcileRepoDeployment(a, false)
+	assert.NoError(t, err)
+
+	deployment := &appsv1.Deployment{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-repo-server",
+		Namespace: testNamespace,
+	}, deployment)
+	assert.NoError(t, err)
+
+	initContainers := deployment.Spec.Template.Spec.InitContainers
+	assert.Len(t, initContainers, 3)
+	assert.Equal(t, "copyutil", initContainers[0].Name)
+	assert.Equal(t, "kustomize-v4-1-0", initContainers[1].Name)
+	assert.Equal(t, "quay.io/example/kustomize:v4.1.0", initContainers[1].Image)
+	assert.Equal(t, []string{"cp", "/custom-tools/kustomize_4_1", "/kustomize-versions/v4.1.0"}, initContainers[1].Command)
+	assert.Equal(t, "test-init-container", initContainers[2].Name)
+
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "kustomize-versions",
+		MountPath: "/custom-tools/kustomize_4_1",
+		SubPath:   "v4.1.0",
+	})
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "kustomize-versions",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+
+	// removing the image based version should remove the init container, mount and volume
+	a.Spec.KustomizeVersions = a.Spec.KustomizeVersions[1:]
+	err = r.reconcileRepoDeployment(a, false)
+	assert.NoError(t, err)
+
+	err = r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-repo-server",
+		Namespace: testNamespace,
+	}, deployment)
+	assert.NoError(t, err)
+	assert.Len(t, deployment.Spec.Template.Spec.InitContainers, 2)
+	assert.Equal(t, repoServerDefaultVolumes(), deployment.Spec.Template.Spec.Volumes)
+	for _, vm := range deployment.Spec.Template.Spec.Containers[0].VolumeMounts {
+		assert.NotEqual(t, "kustomize-versions", vm.Name)
+	}
+}
+
+func TestGetImageKustomizeVersions(t *testing.T) {
+	tests := []struct {
+		version string
+		valid   bool
+	}{
+		{"v4.1.0", true},
+		{"4.1.0-rc.1", true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"v4..1", false},
+		{"../../bin", false},
+		{"v4/1", false},
+	}
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+				a.Spec.KustomizeVersions = []argoprojv1alpha1.KustomizeVersionSpec{{
+					Version: test.version,
+					Path:    "/custom-tools/kustomize",
+					Image:   "quay.io/example/kustomize:v4.1.0",
+				}}
+			})
+			assert.Equal(t, test.valid, len(getImageKustomizeVersions(a)) == 1)
+			assert.Equal(t, test.valid, len(getKustomizeVersionsInitContainers(a)) == 1)
+			assert.Equal(t, test.valid, len(getKustomizeVersionsVolumeMounts(a)) == 1)
+		})
+	}
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_probesAndGracePeriod(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	err := r.reconcileRepoDeployment(a, false)
+	assert.NoError(t, err)
+
+	deployment := &appsv1.Deployment{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-repo-server",
+		Namespace: testNamespace,
+	}, deployment)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), *deployment.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	assert.Equal(t, int32(5), deployment.Spec.Template.Spec.Containers[0].LivenessProbe.InitialDelaySeconds)
+	assert.Equal(t, int32(1), deployment.Spec.Template.Spec.Containers[0].LivenessProbe.TimeoutSeconds)
+
+	var gracePeriod int64 = 300
+	a.Spec.Repo.TerminationGracePeriodSeconds = &gracePeriod
+	a.Spec.Repo.LivenessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(common.ArgoCDDefaultRepoServerPort),
+			},
+		},
+		InitialDelaySeconds: 30,
+		TimeoutSeconds:      10,
+		FailureThreshold:    10,
+	}
+	a.Spec.Repo.ReadinessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: "/healthz",
+				Port: intstr.FromInt(common.ArgoCDDefaultRepoMetricsPort),
+			},
+		},
+		PeriodSeconds: 20,
+	}
+
+	err = r.reconcileRepoDeployment(a, false)
+	assert.NoError(t, err)
+
+	err = r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-repo-server",
+		Namespace: testNamespace,
+	}, deployment)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(300), *deployment.Spec.Template.Spec.TerminationGracePeriodSeconds)
+
+	livenessProbe := deployment.Spec.Template.Spec.Containers[0].LivenessProbe
+	assert.Equal(t, int32(30), livenessProbe.InitialDelaySeconds)
+	assert.Equal(t, int32(10), livenessProbe.TimeoutSeconds)
+	assert.Equal(t, int32(10), livenessProbe.FailureThreshold)
+	assert.Equal(t, int32(10), livenessProbe.PeriodSeconds)
+
+	readinessProbe := deployment.Spec.Template.Spec.Containers[0].ReadinessProbe
+	assert.Equal(t, "/healthz", readinessProbe.HTTPGet.Path)
+	assert.Equal(t, int32(20), readinessProbe.PeriodSeconds)
+	assert.Equal(t, int32(3), readinessProbe.FailureThreshold)
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_hostAliasesAndDNS(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	ndots := "2"
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Repo.HostAliases = []corev1.HostAlias{{IP: "10.0.0.10", Hostnames: []string{"git.example.local"}}}
+		a.Spec.Repo.DNSPolicy = corev1.DNSDefault
+		a.Spec.Repo.DNSConfig = &corev1.PodDNSConfig{
+			Options: []corev1.PodDNSConfigOption{{Name: "ndots", Value: &ndots}},
+		}
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Equal(t, a.Spec.Repo.HostAliases, deployment.Spec.Template.Spec.HostAliases)
+	assert.Equal(t, corev1.DNSDefault, deployment.Spec.Template.Spec.DNSPolicy)
+	assert.Equal(t, a.Spec.Repo.DNSConfig, deployment.Spec.Template.Spec.DNSConfig)
+}
+
 func TestReconcileArgoCD_reconcileRepoDeployment_missingInitContainers(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
@@ -542,9 +758,11 @@ func TestReconcileArgoCD_reconcileDeployments_proxy(t *testing.T) {
 	})
 	r := makeTestReconciler(t, a)
 
-	err := r.reconcileDeployments(a, false)
-	assert.NoError(t, err)
-	err = r.reconcileDexDeployment(a)
+	assert.NoError(t, r.reconcileRedisWorkload(a, false))
+	assert.NoError(t, r.reconcileRepoServerWorkload(a, false))
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+	assert.NoError(t, r.reconcileMonitoring(a))
+	err := r.reconcileDexDeployment(a)
 	assert.NoError(t, err)
 
 	for _, v := range deploymentNames {
@@ -567,10 +785,12 @@ func TestReconcileArgoCD_reconcileDeployments_proxy_update_existing(t *testing.T
 		}
 	})
 	r := makeTestReconciler(t, a)
-	err := r.reconcileDeployments(a, false)
-	assert.NoError(t, err)
+	assert.NoError(t, r.reconcileRedisWorkload(a, false))
+	assert.NoError(t, r.reconcileRepoServerWorkload(a, false))
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+	assert.NoError(t, r.reconcileMonitoring(a))
 
-	err = r.reconcileDexDeployment(a)
+	err := r.reconcileDexDeployment(a)
 	assert.NoError(t, err)
 
 	for _, v := range deploymentNames {
@@ -583,8 +803,10 @@ func TestReconcileArgoCD_reconcileDeployments_proxy_update_existing(t *testing.T
 
 	logf.SetLogger(ZapLogger(true))
 
-	err = r.reconcileDeployments(a, false)
-	assert.NoError(t, err)
+	assert.NoError(t, r.reconcileRedisWorkload(a, false))
+	assert.NoError(t, r.reconcileRepoServerWorkload(a, false))
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+	assert.NoError(t, r.reconcileMonitoring(a))
 	err = r.reconcileDexDeployment(a)
 	assert.NoError(t, err)
 
@@ -605,12 +827,53 @@ func TestReconcileArgoCD_reconcileDeployments_HA_proxy(t *testing.T) {
 	})
 	r := makeTestReconciler(t, a)
 
-	err := r.reconcileDeployments(a, false)
-	assert.NoError(t, err)
+	assert.NoError(t, r.reconcileRedisWorkload(a, false))
 
 	assertDeploymentHasProxyVars(t, r.Client, "argocd-redis-ha-haproxy")
 }
 
+func TestReconcileArgoCD_reconcileDeployments_HA_server_and_repo(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileRepoServerWorkload(a, false))
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+
+	for _, name := range []string{"argocd-server", "argocd-repo-server"} {
+		deployment := &appsv1.Deployment{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, deployment))
+		assert.Equal(t, common.ArgoCDDefaultHAReplicas, *deployment.Spec.Replicas)
+		assert.Equal(t, []corev1.WeightedPodAffinityTerm{
+			{
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{common.ArgoCDKeyName: name},
+					},
+					TopologyKey: common.ArgoCDKeyHostname,
+				},
+				Weight: int32(100),
+			},
+		}, deployment.Spec.Template.Spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+	}
+
+	// the replicas set for a component take precedence, and the anti-affinity is removed with HA
+	a.Spec.Server.Replicas = int32Ptr(3)
+	assert.NoError(t, r.reconcileRepoServerWorkload(a, false))
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, deployment))
+	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+
+	a.Spec.HA.Enabled = false
+	assert.NoError(t, r.reconcileRepoServerWorkload(a, false))
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}, deployment))
+	assert.Nil(t, deployment.Spec.Template.Spec.Affinity)
+}
+
 func TestReconcileArgoCD_reconcileDeployments_HA_proxy_with_resources(t *testing.T) {
 	t.Setenv("HTTP_PROXY", testHTTPProxy)
 	t.Setenv("HTTPS_PROXY", testHTTPSProxy)
@@ -754,6 +1017,28 @@ func TestReconcileArgoCD_reconcileDeployment_nodePlacement(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_reconcileDeployment_workloads(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Equal(t, int32(3), *deployment.Spec.RevisionHistoryLimit)
+	assert.Nil(t, deployment.Spec.ProgressDeadlineSeconds)
+
+	a.Spec.Workloads = &argoprojv1alpha1.ArgoCDWorkloadSpec{
+		RevisionHistoryLimit:    int32Ptr(1),
+		ProgressDeadlineSeconds: int32Ptr(300),
+	}
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Equal(t, int32(1), *deployment.Spec.RevisionHistoryLimit)
+	assert.Equal(t, int32(300), *deployment.Spec.ProgressDeadlineSeconds)
+}
+
 func deploymentDefaultNodeSelector() map[string]string {
 	nodeSelector := map[string]string{
 		"test_key1": "test_value1",
@@ -944,6 +1229,69 @@ func TestReconcileArgoCD_reconcileServerDeployment(t *testing.T) {
 	assert.Equal(t, wantCmd, deployment.Spec.Template.Spec.Containers[0].Command)
 }
 
+func TestReconcileArgoCD_reconcileServerDeployment_extensions(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Extensions = []argoprojv1alpha1.ArgoCDServerExtensionSpec{
+			{
+				Name:        "rollouts",
+				URL:         "https://github.com/argoproj-labs/rollout-extension/releases/download/v0.3.4/extension.tar",
+				ChecksumURL: "https://github.com/argoproj-labs/rollout-extension/releases/download/v0.3.4/extension_checksums.txt",
+			},
+			{
+				Name:  "metrics",
+				URL:   "https://example.com/metrics-extension.tar.gz",
+				Image: "registry.example.com/argocd-extension-installer:v0.0.8",
+			},
+			{
+				// proxy extensions without a URL are not installed
+				Name: "httpbin",
+				Backend: &argoprojv1alpha1.ArgoCDServerExtensionBackendSpec{
+					Services: []argoprojv1alpha1.ArgoCDServerExtensionServiceSpec{{URL: "http://httpbin.org"}},
+				},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, deployment))
+	initContainers := deployment.Spec.Template.Spec.InitContainers
+	assert.Len(t, initContainers, 2)
+	assert.Equal(t, "extension-rollouts", initContainers[0].Name)
+	assert.Equal(t, "quay.io/argoprojlabs/argocd-extension-installer:v0.0.8", initContainers[0].Image)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "EXTENSION_NAME", Value: "rollouts"},
+		{Name: "EXTENSION_URL", Value: "https://github.com/argoproj-labs/rollout-extension/releases/download/v0.3.4/extension.tar"},
+		{Name: "EXTENSION_CHECKSUM_URL", Value: "https://github.com/argoproj-labs/rollout-extension/releases/download/v0.3.4/extension_checksums.txt"},
+	}, initContainers[0].Env)
+	assert.Equal(t, "extension-metrics", initContainers[1].Name)
+	assert.Equal(t, "registry.example.com/argocd-extension-installer:v0.0.8", initContainers[1].Image)
+	assert.Len(t, initContainers[1].Env, 2)
+
+	extensionsMount := corev1.VolumeMount{Name: "extensions", MountPath: "/tmp/extensions"}
+	assert.Contains(t, initContainers[0].VolumeMounts, extensionsMount)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, extensionsMount)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "ARGOCD_SERVER_ENABLE_PROXY_EXTENSION", Value: "true"})
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "extensions",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+
+	// removing the extensions should remove the init containers and disable the proxy extension
+	a.Spec.Server.Extensions = nil
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: deployment.Name, Namespace: a.Namespace}, deployment))
+	assert.Empty(t, deployment.Spec.Template.Spec.InitContainers)
+	assert.NotContains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, extensionsMount)
+	for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+		assert.NotEqual(t, "ARGOCD_SERVER_ENABLE_PROXY_EXTENSION", env.Name)
+	}
+}
+
 func TestArgoCDServerDeploymentCommand(t *testing.T) {
 	a := makeTestArgoCD()
 	r := makeTestReconciler(t, a)
@@ -1037,6 +1385,20 @@ func TestArgoCDServerDeploymentCommand(t *testing.T) {
 	assert.Equal(t, baseCommand, deployment.Spec.Template.Spec.Containers[0].Command)
 }
 
+func TestArgoCDServerCommand_rootPath(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.RootPath = "/argocd"
+		a.Spec.Server.BaseHRef = "/argocd/"
+	})
+
+	cmd := getArgoServerCommand(a, false)
+	assert.Equal(t, []string{"--rootpath", "/argocd", "--basehref", "/argocd/"}, cmd[len(cmd)-4:])
+
+	// Extra arguments duplicating the root path are not added
+	a.Spec.Server.ExtraCommandArgs = []string{"--rootpath", "/other"}
+	assert.Equal(t, cmd, getArgoServerCommand(a, false))
+}
+
 func TestArgoCDServerCommand_isMergable(t *testing.T) {
 	cmd := []string{"--server", "foo.svc.cluster.local", "--path", "/bar"}
 	extraCMDArgs := []string{"--extra-path", "/"}
@@ -1258,6 +1620,34 @@ func TestReconcileArgoCD_reconcileRedisDeploymentWithTLS(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_reconcileRedisDeploymentWithAuth(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Redis.EnableAuth = true
+	})
+	r := makeTestReconciler(t, cr)
+
+	want := []string{
+		"--save", "",
+		"--appendonly", "no",
+		"--requirepass", "$(REDIS_PASSWORD)",
+	}
+
+	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+	d := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-redis", Namespace: cr.Namespace}, d))
+	assert.Equal(t, want, d.Spec.Template.Spec.Containers[0].Args)
+	assert.Equal(t, getRedisAuthEnv(cr, "REDIS_PASSWORD"), d.Spec.Template.Spec.Containers[0].Env)
+
+	// Argo CD components read the password from the environment
+	assert.NoError(t, r.reconcileServerDeployment(cr, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-server", Namespace: cr.Namespace}, d))
+	env := d.Spec.Template.Spec.Containers[0].Env
+	assert.Len(t, env, 1)
+	assert.Equal(t, "REDIS_PASSWORD", env[0].Name)
+	assert.Equal(t, common.ArgoCDRedisAuthSecretName, env[0].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, common.ArgoCDKeyRedisAuth, env[0].ValueFrom.SecretKeyRef.Key)
+}
+
 func TestReconcileArgoCD_reconcileRedisDeployment(t *testing.T) {
 	// tests reconciler hook for redis deployment
 	cr := makeTestArgoCD()
@@ -1305,6 +1695,118 @@ func TestReconcileArgoCD_reconcileRedisDeployment_with_error(t *testing.T) {
 	assert.Error(t, r.reconcileRedisDeployment(cr, false), "this is a test error")
 }
 
+func TestReconcileArgoCD_reconcileRedisDeployment_remote(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	cr := makeTestArgoCD()
+	r := makeTestReconciler(t, cr)
+
+	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+	assert.NoError(t, r.reconcileRedisService(cr))
+
+	// Switching to a remote Redis removes the Redis deployed by the operator
+	cr.Spec.Redis.Remote = &argoprojv1alpha1.ArgoCDRedisRemoteSpec{
+		Address:           "redis.example.com:6379",
+		CredentialsSecret: "redis-credentials",
+	}
+	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+	assert.NoError(t, r.reconcileRedisService(cr))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-redis", Namespace: cr.Namespace}, &appsv1.Deployment{}))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-redis", Namespace: cr.Namespace}, &corev1.Service{}))
+
+	assert.NoError(t, r.reconcileRepoDeployment(cr, false))
+	repo := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-repo-server", Namespace: cr.Namespace}, repo))
+	assert.Contains(t, repo.Spec.Template.Spec.Containers[0].Command, "redis.example.com:6379")
+
+	env := repo.Spec.Template.Spec.Containers[0].Env
+	assert.Len(t, env, 2)
+	assert.Equal(t, "REDIS_PASSWORD", env[0].Name)
+	assert.Equal(t, "redis-credentials", env[0].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "password", env[0].ValueFrom.SecretKeyRef.Key)
+	assert.Equal(t, "REDIS_USERNAME", env[1].Name)
+	assert.Equal(t, "username", env[1].ValueFrom.SecretKeyRef.Key)
+}
+
+func TestReconcileArgoCD_reconcileRedisDeployment_persistence(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	size := resourcev1.MustParse("5Gi")
+	storageClass := "fast"
+	cr := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Redis.Persistence = &argoprojv1alpha1.ArgoCDRedisPersistenceSpec{
+			Enabled:          true,
+			Size:             &size,
+			StorageClassName: &storageClass,
+		}
+	})
+	r := makeTestReconciler(t, cr)
+
+	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-redis-data", Namespace: cr.Namespace}, pvc))
+	assert.Equal(t, size, pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+	assert.Equal(t, &storageClass, pvc.Spec.StorageClassName)
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-redis", Namespace: cr.Namespace}, deployment))
+	assert.Equal(t, []string{"--dir", "/data", "--appendonly", "yes"}, deployment.Spec.Template.Spec.Containers[0].Args)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "data", MountPath: "/data"})
+	assert.Equal(t, cr.Name+"-redis-data", deployment.Spec.Template.Spec.Volumes[1].PersistentVolumeClaim.ClaimName)
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, deployment.Spec.Strategy.Type)
+
+	// The claim is expanded but never shrunk
+	size = resourcev1.MustParse("20Gi")
+	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: pvc.Name, Namespace: cr.Namespace}, pvc))
+	assert.Equal(t, size, pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+
+	size = resourcev1.MustParse("1Gi")
+	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: pvc.Name, Namespace: cr.Namespace}, pvc))
+	assert.Equal(t, resourcev1.MustParse("20Gi"), pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+
+	// Disabling persistence removes the claim and the volume
+	cr.Spec.Redis.Persistence.Enabled = false
+	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: pvc.Name, Namespace: cr.Namespace}, pvc))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-redis", Namespace: cr.Namespace}, deployment))
+	assert.Len(t, deployment.Spec.Template.Spec.Volumes, 1)
+	assert.Equal(t, []string{"--save", "", "--appendonly", "no"}, deployment.Spec.Template.Spec.Containers[0].Args)
+}
+
+func TestReconcileArgoCD_reconcileRedisDeployment_probes(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	cr := makeTestArgoCD()
+	r := makeTestReconciler(t, cr)
+
+	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-redis", Namespace: cr.Namespace}, deployment))
+	assert.Nil(t, deployment.Spec.Template.Spec.Containers[0].LivenessProbe)
+	assert.Nil(t, deployment.Spec.Template.Spec.Containers[0].ReadinessProbe)
+	assert.Equal(t, int64(30), *deployment.Spec.Template.Spec.TerminationGracePeriodSeconds)
+
+	gracePeriod := int64(10)
+	cr.Spec.Redis.TerminationGracePeriodSeconds = &gracePeriod
+	cr.Spec.Redis.ReadinessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: []string{"redis-cli", "ping"}},
+		},
+	}
+	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-redis", Namespace: cr.Namespace}, deployment))
+	assert.Equal(t, int64(10), *deployment.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	assert.Equal(t, &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: []string{"redis-cli", "ping"}},
+		},
+		TimeoutSeconds:   1,
+		PeriodSeconds:    10,
+		SuccessThreshold: 1,
+		FailureThreshold: 3,
+	}, deployment.Spec.Template.Spec.Containers[0].ReadinessProbe)
+}
+
 func operationProcessors(n int32) argoCDOpt {
 	return func(a *argoprojv1alpha1.ArgoCD) {
 		a.Spec.Controller.Processors.Operation = n
@@ -1316,63 +1818,56 @@ func appSync(d time.Duration) argoCDOpt {
 		a.Spec.Controller.AppSync = &metav1.Duration{Duration: d}
 	}
 }
+
+func selfHealTimeout(d time.Duration) argoCDOpt {
+	return func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Controller.SelfHealTimeout = &metav1.Duration{Duration: d}
+	}
+}
+
 func Test_UpdateNodePlacement(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
 
-	deployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "argocd-sample-server",
-			Namespace: testNamespace,
-		},
-		Spec: appsv1.DeploymentSpec{
-			Template: corev1.PodTemplateSpec{
-				Spec: corev1.PodSpec{
-					NodeSelector: map[string]string{
-						"test_key1": "test_value1",
-						"test_key2": "test_value2",
-					},
-					Tolerations: []corev1.Toleration{
-						{
-							Key:    "test_key1",
-							Value:  "test_value1",
-							Effect: corev1.TaintEffectNoSchedule,
-						},
-					},
-				},
-			},
-		},
+	deployment := newDeploymentWithSuffix("server", "server", a)
+	deployment.Spec.Template.Spec.NodeSelector = map[string]string{
+		"test_key1": "test_value1",
+		"test_key2": "test_value2",
 	}
-	deployment2 := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "argocd-sample-server",
-			Namespace: testNamespace,
-		},
-		Spec: appsv1.DeploymentSpec{
-			Template: corev1.PodTemplateSpec{
-				Spec: corev1.PodSpec{
-					NodeSelector: map[string]string{
-						"test_key1": "test_value1",
-					},
-					Tolerations: []corev1.Toleration{
-						{
-							Key:    "test_key1",
-							Value:  "test_value1",
-							Effect: corev1.TaintEffectNoExecute,
-						},
-					},
-				},
-			},
+	deployment.Spec.Template.Spec.Tolerations = []corev1.Toleration{
+		{
+			Key:    "test_key1",
+			Value:  "test_value1",
+			Effect: corev1.TaintEffectNoSchedule,
 		},
 	}
-	expectedChange := false
-	actualChange := false
-	updateNodePlacement(deployment, deployment, &actualChange)
-	if actualChange != expectedChange {
-		t.Fatalf("updateNodePlacement failed, value of changed: %t", actualChange)
+	assert.NoError(t, r.applyResource(a, deployment.DeepCopy()))
+	existing := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: deployment.Name, Namespace: a.Namespace}, existing))
+	resourceVersion := existing.ResourceVersion
+
+	// an unchanged node placement is not applied again
+	assert.NoError(t, r.applyResource(a, deployment.DeepCopy()))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: deployment.Name, Namespace: a.Namespace}, existing))
+	assert.Equal(t, resourceVersion, existing.ResourceVersion)
+
+	// a changed node placement is applied, and the node selectors no longer set are removed
+	deployment.Spec.Template.Spec.NodeSelector = map[string]string{
+		"test_key1": "test_value1",
 	}
-	updateNodePlacement(deployment, deployment2, &actualChange)
-	if actualChange == expectedChange {
-		t.Fatalf("updateNodePlacement failed, value of changed: %t", actualChange)
+	deployment.Spec.Template.Spec.Tolerations = []corev1.Toleration{
+		{
+			Key:    "test_key1",
+			Value:  "test_value1",
+			Effect: corev1.TaintEffectNoExecute,
+		},
 	}
+	assert.NoError(t, r.applyResource(a, deployment.DeepCopy()))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: deployment.Name, Namespace: a.Namespace}, existing))
+	assert.NotEqual(t, resourceVersion, existing.ResourceVersion)
+	assert.Equal(t, deployment.Spec.Template.Spec.NodeSelector, existing.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, deployment.Spec.Template.Spec.Tolerations, existing.Spec.Template.Spec.Tolerations)
 }
 
 func parallelismLimit(n int32) argoCDOpt {
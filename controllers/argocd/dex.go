@@ -107,6 +107,10 @@ func (r *ReconcileArgoCD) getDexOAuthClientSecret(cr *argoprojv1a1.ArgoCD) (*str
 
 // reconcileDexConfiguration will ensure that Dex is configured properly.
 func (r *ReconcileArgoCD) reconcileDexConfiguration(cm *corev1.ConfigMap, cr *argoprojv1a1.ArgoCD) error {
+	if isUnmanagedConfigKey(common.ArgoCDKeyDexConfig, cr) {
+		return nil
+	}
+
 	actual := cm.Data[common.ArgoCDKeyDexConfig]
 	desired := getDexConfig(cr)
 
@@ -161,7 +165,7 @@ func (r *ReconcileArgoCD) getOpenShiftDexConfig(cr *argoprojv1a1.ArgoCD) (string
 			"issuer":       "https://kubernetes.default.svc", // TODO: Should this be hard-coded?
 			"clientID":     getDexOAuthClientID(cr),
 			"clientSecret": "$oidc.dex.clientSecret",
-			"redirectURI":  r.getDexOAuthRedirectURI(cr),
+			"redirectURI":  r.getDexOAuthRedirectURIs(cr)[0],
 			"insecureCA":   true, // TODO: Configure for openshift CA,
 			"groups":       groups,
 		},
@@ -192,23 +196,39 @@ func (r *ReconcileArgoCD) reconcileDexServiceAccount(cr *argoprojv1a1.ArgoCD) er
 		return err
 	}
 
-	// Get the OAuth redirect URI that should be used.
-	uri := r.getDexOAuthRedirectURI(cr)
-	log.Info(fmt.Sprintf("URI: %s", uri))
+	// Get the OAuth redirect URIs that should be used. The first one is set with the default annotation, the others
+	// with an indexed suffix, as each annotation holds a single URI.
+	desired := map[string]string{}
+	for i, uri := range r.getDexOAuthRedirectURIs(cr) {
+		key := common.ArgoCDKeyDexOAuthRedirectURI
+		if i > 0 {
+			key = fmt.Sprintf("%s-%d", common.ArgoCDKeyDexOAuthRedirectURI, i)
+		}
+		desired[key] = uri
+	}
 
-	// Get the current redirect URI
-	ann := sa.ObjectMeta.Annotations
-	currentURI, found := ann[common.ArgoCDKeyDexOAuthRedirectURI]
-	if found && currentURI == uri {
-		return nil // Redirect URI annotation found and correct, move along...
+	// Get the current redirect URIs
+	current := map[string]string{}
+	for k, v := range sa.ObjectMeta.Annotations {
+		if k == common.ArgoCDKeyDexOAuthRedirectURI || strings.HasPrefix(k, common.ArgoCDKeyDexOAuthRedirectURI+"-") {
+			current[k] = v
+		}
+	}
+	if reflect.DeepEqual(current, desired) {
+		return nil // Redirect URI annotations found and correct, move along...
 	}
 
-	log.Info(fmt.Sprintf("current URI: %s is not correct, should be: %s", currentURI, uri))
+	log.Info(fmt.Sprintf("current URIs: %v are not correct, should be: %v", current, desired))
+	ann := sa.ObjectMeta.Annotations
 	if len(ann) <= 0 {
 		ann = make(map[string]string)
 	}
-
-	ann[common.ArgoCDKeyDexOAuthRedirectURI] = uri
+	for k := range current {
+		delete(ann, k)
+	}
+	for k, v := range desired {
+		ann[k] = v
+	}
 	sa.ObjectMeta.Annotations = ann
 
 	return r.Client.Update(context.TODO(), sa)
@@ -266,6 +286,8 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoprojv1a1.ArgoCD) error
 		}},
 	}}
 
+	image := r.getArgoContainerImageForStage(cr, "dex-server")
+	setWorkloadArgoImage(deploy, image)
 	deploy.Spec.Template.Spec.InitContainers = []corev1.Container{{
 		Command: []string{
 			"cp",
@@ -274,7 +296,7 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoprojv1a1.ArgoCD) error
 			"/shared/argocd-dex",
 		},
 		Env:             proxyEnvVars(),
-		Image:           getArgoContainerImage(cr),
+		Image:           image,
 		ImagePullPolicy: corev1.PullAlways,
 		Name:            "copyutil",
 		Resources:       getDexResources(cr),
@@ -301,70 +323,59 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoprojv1a1.ArgoCD) error
 		},
 	}}
 
-	existing := newDeploymentWithSuffix("dex-server", "dex-server", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+	// Mount the certificate issued by cert-manager, dex serves it instead of a self-signed one.
+	if cr.Spec.TLS.WantsCertManager() {
+		deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      common.ArgoCDDexServerTLSSecretName,
+			MountPath: "/tls",
+		})
+		deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: common.ArgoCDDexServerTLSSecretName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: common.ArgoCDDexServerTLSSecretName,
+					Optional:   boolPtr(true),
+				},
+			},
+		})
+	}
 
-		// dex uninstallation requested
-		if !UseDex(cr) {
+	setSSOTheme(&deploy.Spec.Template.Spec, getDexTheme(cr), common.ArgoCDDexThemesPath)
+
+	// if Dex installation has not been requested, delete the deployment if it exists
+	if !UseDex(cr) {
+		existing := newDeploymentWithSuffix("dex-server", "dex-server", cr)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 			log.Info("deleting the existing dex deployment because dex uninstallation has been requested")
 			return r.Client.Delete(context.TODO(), existing)
 		}
-		changed := false
-
-		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getDexContainerImage(cr)
-		if actualImage != desiredImage {
-			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
-			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
-			changed = true
-		}
-
-		actualImage = existing.Spec.Template.Spec.InitContainers[0].Image
-		desiredImage = getArgoContainerImage(cr)
-		if actualImage != desiredImage {
-			existing.Spec.Template.Spec.InitContainers[0].Image = desiredImage
-			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
-			changed = true
-		}
-		updateNodePlacement(existing, deploy, &changed)
-		if !reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].Env,
-			deploy.Spec.Template.Spec.Containers[0].Env) {
-			existing.Spec.Template.Spec.Containers[0].Env = deploy.Spec.Template.Spec.Containers[0].Env
-			changed = true
-		}
-
-		if !reflect.DeepEqual(existing.Spec.Template.Spec.InitContainers[0].Env,
-			deploy.Spec.Template.Spec.InitContainers[0].Env) {
-			existing.Spec.Template.Spec.InitContainers[0].Env = deploy.Spec.Template.Spec.InitContainers[0].Env
-			changed = true
-		}
-
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Resources, existing.Spec.Template.Spec.Containers[0].Resources) {
-			existing.Spec.Template.Spec.Containers[0].Resources = deploy.Spec.Template.Spec.Containers[0].Resources
-			changed = true
-		}
-
-		if changed {
-			return r.Client.Update(context.TODO(), existing)
-		}
-		return nil // Deployment found with nothing to do, move along...
-	}
-
-	// if Dex installation has not been requested, do nothing
-	if !UseDex(cr) {
 		return nil
 	}
 
-	if err := controllerutil.SetControllerReference(cr, deploy, r.Scheme); err != nil {
-		return err
-	}
-
-	log.Info(fmt.Sprintf("creating deployment %s for Argo CD instance %s in namespace %s", deploy.Name, cr.Name, cr.Namespace))
-	return r.Client.Create(context.TODO(), deploy)
+	return r.applyResource(cr, deploy)
 }
 
 // reconcileDexService will ensure that the Service for Dex is present.
 func (r *ReconcileArgoCD) reconcileDexService(cr *argoprojv1a1.ArgoCD) error {
+	ports := []corev1.ServicePort{
+		{
+			Name:       "http",
+			Port:       common.ArgoCDDefaultDexHTTPPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexHTTPPort),
+		}, {
+			Name:       "grpc",
+			Port:       common.ArgoCDDefaultDexGRPCPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexGRPCPort),
+		}, {
+			Name:       common.ArgoCDKeyMetrics,
+			Port:       common.ArgoCDDefaultDexMetricsPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexMetricsPort),
+		},
+	}
+
 	svc := newServiceWithSuffix("dex-server", "dex-server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
 
@@ -373,7 +384,14 @@ func (r *ReconcileArgoCD) reconcileDexService(cr *argoprojv1a1.ArgoCD) error {
 			log.Info("deleting the existing Dex service because dex uninstallation has been requested")
 			return r.Client.Delete(context.TODO(), svc)
 		}
-		return nil
+
+		// Services created by earlier versions of the operator do not expose the metrics port.
+		changed := false
+		if !reflect.DeepEqual(svc.Spec.Ports, ports) {
+			svc.Spec.Ports = ports
+			changed = true
+		}
+		return r.updateServiceNetworking(svc, getDexServiceSpec(cr), changed)
 	}
 
 	// if Dex installation has not been requested, do nothing
@@ -381,23 +399,13 @@ func (r *ReconcileArgoCD) reconcileDexService(cr *argoprojv1a1.ArgoCD) error {
 		return nil // Dex is disabled, do nothing
 	}
 
+	setServiceNetworking(svc, getDexServiceSpec(cr))
+
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix("dex-server", cr),
 	}
 
-	svc.Spec.Ports = []corev1.ServicePort{
-		{
-			Name:       "http",
-			Port:       common.ArgoCDDefaultDexHTTPPort,
-			Protocol:   corev1.ProtocolTCP,
-			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexHTTPPort),
-		}, {
-			Name:       "grpc",
-			Port:       common.ArgoCDDefaultDexGRPCPort,
-			Protocol:   corev1.ProtocolTCP,
-			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexGRPCPort),
-		},
-	}
+	svc.Spec.Ports = ports
 
 	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
 		return err
@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
-	v1 "k8s.io/api/core/v1"
 
 	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
 	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
@@ -69,6 +68,18 @@ func (r *ReconcileArgoCD) getDexOAuthRedirectURI(cr *argoprojv1a1.ArgoCD) string
 	return uri + common.ArgoCDDefaultDexOAuthRedirectPath
 }
 
+// getDexOAuthRedirectURIs will return the redirect URIs of the OpenShift OAuth client used by Dex, which default to the
+// OAuth redirect URI for the Dex server.
+func (r *ReconcileArgoCD) getDexOAuthRedirectURIs(cr *argoprojv1a1.ArgoCD) []string {
+	// Allow override of redirect URIs from CR
+	if cr.Spec.Dex != nil && !reflect.DeepEqual(cr.Spec.Dex, &v1alpha1.ArgoCDDexSpec{}) && len(cr.Spec.Dex.RedirectURIs) > 0 {
+		return cr.Spec.Dex.RedirectURIs
+	} else if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil && len(cr.Spec.SSO.Dex.RedirectURIs) > 0 {
+		return cr.Spec.SSO.Dex.RedirectURIs
+	}
+	return []string{r.getDexOAuthRedirectURI(cr)}
+}
+
 // getDexOAuthClientID will return the OAuth client ID for the given ArgoCD.
 func getDexOAuthClientID(cr *argoprojv1a1.ArgoCD) string {
 	return fmt.Sprintf("system:serviceaccount:%s:%s", cr.Namespace, fmt.Sprintf("%s-%s", cr.Name, common.ArgoCDDefaultDexServiceAccountName))
@@ -77,7 +88,7 @@ func getDexOAuthClientID(cr *argoprojv1a1.ArgoCD) string {
 // getDexResources will return the ResourceRequirements for the Dex container.
 func getDexResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
 
-	resources := v1.ResourceRequirements{}
+	resources := getDefaultResources(common.ArgoCDDexServerComponent)
 
 	// Allow override of resource requirements from CR
 	if cr.Spec.Dex != nil && !reflect.DeepEqual(cr.Spec.Dex, &v1alpha1.ArgoCDDexSpec{}) && cr.Spec.Dex.Resources != nil {
@@ -89,6 +100,36 @@ func getDexResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
 	return resources
 }
 
+// getDexServiceSpec returns the IP family and traffic policy options for the Dex Service.
+func getDexServiceSpec(cr *argoprojv1a1.ArgoCD) argoprojv1a1.ArgoCDServiceSpec {
+	if cr.Spec.Dex != nil && !reflect.DeepEqual(cr.Spec.Dex, &v1alpha1.ArgoCDDexSpec{}) && !reflect.DeepEqual(cr.Spec.Dex.Service, v1alpha1.ArgoCDServiceSpec{}) {
+		return cr.Spec.Dex.Service
+	} else if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil {
+		return cr.Spec.SSO.Dex.Service
+	}
+	return argoprojv1a1.ArgoCDServiceSpec{}
+}
+
+// getDexServiceMonitorSpec returns the ServiceMonitor options for the Dex metrics.
+func getDexServiceMonitorSpec(cr *argoprojv1a1.ArgoCD) argoprojv1a1.ArgoCDServiceMonitorSpec {
+	if cr.Spec.Dex != nil && !reflect.DeepEqual(cr.Spec.Dex.ServiceMonitor, v1alpha1.ArgoCDServiceMonitorSpec{}) {
+		return cr.Spec.Dex.ServiceMonitor
+	} else if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil {
+		return cr.Spec.SSO.Dex.ServiceMonitor
+	}
+	return argoprojv1a1.ArgoCDServiceMonitorSpec{}
+}
+
+// getDexTheme returns the theme of the Dex login page, or nil if none is set.
+func getDexTheme(cr *argoprojv1a1.ArgoCD) *argoprojv1a1.ArgoCDSSOThemeSpec {
+	if cr.Spec.Dex != nil && cr.Spec.Dex.Theme != nil {
+		return cr.Spec.Dex.Theme
+	} else if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil {
+		return cr.Spec.SSO.Dex.Theme
+	}
+	return nil
+}
+
 func getDexConfig(cr *argoprojv1a1.ArgoCD) string {
 	config := common.ArgoCDDefaultDexConfig
 
@@ -429,6 +429,53 @@ func TestReconcileArgoCD_reconcileDexDeployment(t *testing.T) {
 	assert.Equal(t, want, deployment.Spec.Template.Spec)
 }
 
+func TestReconcileArgoCD_reconcileDexDeployment_withTheme(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.SSO = &v1alpha1.ArgoCDSSOSpec{
+		Provider: argoprojv1alpha1.SSOProviderTypeDex,
+		Dex: &v1alpha1.ArgoCDDexSpec{
+			Config: "test-config",
+			Theme: &v1alpha1.ArgoCDSSOThemeSpec{
+				Name:      "corporate",
+				ConfigMap: "dex-theme",
+			},
+		},
+	}
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileDexDeployment(a))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-dex-server", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	var defaultMode int32 = 420
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: common.ArgoCDSSOThemeVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "dex-theme"},
+				DefaultMode:          &defaultMode,
+			},
+		},
+	})
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      common.ArgoCDSSOThemeVolumeName,
+		MountPath: "/srv/dex/web/themes/corporate",
+		ReadOnly:  true,
+	})
+
+	// Removing the theme unmounts it.
+	a.Spec.SSO.Dex.Theme = nil
+	assert.NoError(t, r.reconcileDexDeployment(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	for _, volume := range deployment.Spec.Template.Spec.Volumes {
+		assert.NotEqual(t, common.ArgoCDSSOThemeVolumeName, volume.Name)
+	}
+	for _, mount := range deployment.Spec.Template.Spec.Containers[0].VolumeMounts {
+		assert.NotEqual(t, common.ArgoCDSSOThemeVolumeName, mount.Name)
+	}
+}
+
 func TestReconcileArgoCD_reconcileDexDeployment_withUpdate(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 
@@ -1085,3 +1132,64 @@ func TestReconcileArgoCD_reconcileRoleBinding_dex_disabled(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileArgoCD_getDexOAuthRedirectURI(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Host = "argocd.example.com"
+		a.Spec.Server.RootPath = "/argocd"
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.Equal(t, "https://argocd.example.com/argocd/api/dex/callback", r.getDexOAuthRedirectURI(a))
+
+	// the external URL of the server takes precedence
+	a.Spec.Server.ExternalURL = "https://cd.example.com"
+	assert.Equal(t, "https://cd.example.com/api/dex/callback", r.getDexOAuthRedirectURI(a))
+}
+
+func TestReconcileArgoCD_reconcileDexServiceAccount_redirectURIs(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Host = "argocd.example.com"
+		a.Spec.SSO = &v1alpha1.ArgoCDSSOSpec{
+			Provider: argoprojv1alpha1.SSOProviderTypeDex,
+			Dex: &v1alpha1.ArgoCDDexSpec{
+				OpenShiftOAuth: true,
+			},
+		}
+	})
+	sa := newServiceAccountWithName(common.ArgoCDDefaultDexServiceAccountName, a)
+	r := makeTestReconciler(t, a, sa)
+
+	// the redirect URI is derived from the URL of the server by default
+	assert.NoError(t, r.reconcileDexServiceAccount(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: sa.Name, Namespace: a.Namespace}, sa))
+	assert.Equal(t, map[string]string{
+		common.ArgoCDKeyDexOAuthRedirectURI: "https://argocd.example.com/api/dex/callback",
+	}, sa.Annotations)
+
+	// the redirect URIs can be overridden, each is set in its own annotation
+	a.Spec.SSO.Dex.RedirectURIs = []string{
+		"https://cd.example.com/api/dex/callback",
+		"https://argocd.example.com/api/dex/callback",
+	}
+	assert.NoError(t, r.reconcileDexServiceAccount(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: sa.Name, Namespace: a.Namespace}, sa))
+	assert.Equal(t, map[string]string{
+		common.ArgoCDKeyDexOAuthRedirectURI:        "https://cd.example.com/api/dex/callback",
+		common.ArgoCDKeyDexOAuthRedirectURI + "-1": "https://argocd.example.com/api/dex/callback",
+	}, sa.Annotations)
+
+	config, err := r.getOpenShiftDexConfig(a)
+	assert.NoError(t, err)
+	assert.Contains(t, config, "redirectURI: https://cd.example.com/api/dex/callback")
+
+	// the annotations of redirect URIs that were removed are cleaned up
+	a.Spec.SSO.Dex.RedirectURIs = []string{"https://cd.example.com/api/dex/callback"}
+	assert.NoError(t, r.reconcileDexServiceAccount(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: sa.Name, Namespace: a.Namespace}, sa))
+	assert.Equal(t, map[string]string{
+		common.ArgoCDKeyDexOAuthRedirectURI: "https://cd.example.com/api/dex/callback",
+	}, sa.Annotations)
+}
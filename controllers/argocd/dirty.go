@@ -0,0 +1,259 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+// componentReconciler reconciles the resources of a component that only depend on a few sections of the ArgoCD spec,
+// so that a change to these sections alone does not run the whole reconcile chain.
+type componentReconciler struct {
+	name string
+	// clear zeroes the sections of the given spec the component depends on.
+	clear func(spec *argoprojv1a1.ArgoCDSpec)
+	// reconcile reconciles the resources of the component of the given ArgoCD.
+	reconcile func(r *ReconcileArgoCD, cr *argoprojv1a1.ArgoCD) error
+}
+
+// componentReconcilers are the components that are reconciled on their own when only their sections of the spec
+// change.
+var componentReconcilers = []componentReconciler{
+	{
+		name: "dex",
+		clear: func(spec *argoprojv1a1.ArgoCDSpec) {
+			spec.SSO = nil
+			spec.Dex = nil
+		},
+		reconcile: (*ReconcileArgoCD).reconcileDexComponent,
+	},
+	{
+		name: "applicationset",
+		clear: func(spec *argoprojv1a1.ArgoCDSpec) {
+			spec.ApplicationSet = nil
+		},
+		reconcile: (*ReconcileArgoCD).reconcileApplicationSetComponent,
+	},
+	{
+		name: "notifications",
+		clear: func(spec *argoprojv1a1.ArgoCDSpec) {
+			spec.Notifications = argoprojv1a1.ArgoCDNotifications{}
+		},
+		reconcile: (*ReconcileArgoCD).reconcileNotificationsComponent,
+	},
+}
+
+// reconciledObject is the part of an ArgoCD that was last reconciled successfully.
+type reconciledObject struct {
+	generation  int64
+	labels      map[string]string
+	annotations map[string]string
+	spec        *argoprojv1a1.ArgoCDSpec
+}
+
+// reconciledObjects tracks the ArgoCD instances that were last reconciled successfully, keyed by their namespaced
+// name. A nil reconciledObjects does not track any instance, so that all instances are fully reconciled.
+type reconciledObjects struct {
+	mu      sync.Mutex
+	objects map[types.NamespacedName]reconciledObject
+}
+
+// remember records that the given ArgoCD was reconciled successfully.
+func (o *reconciledObjects) remember(cr *argoprojv1a1.ArgoCD) {
+	if o == nil {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.objects == nil {
+		o.objects = map[types.NamespacedName]reconciledObject{}
+	}
+	o.objects[types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}] = reconciledObject{
+		generation:  cr.Generation,
+		labels:      copyMap(cr.Labels),
+		annotations: copyMap(cr.Annotations),
+		spec:        cr.Spec.DeepCopy(),
+	}
+}
+
+// forget drops the ArgoCD with the given name, so that it is fully reconciled again.
+func (o *reconciledObjects) forget(name types.NamespacedName) {
+	if o == nil {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.objects, name)
+}
+
+// getDirtyComponents returns the components of the given ArgoCD that can be reconciled on their own, because only their
+// sections of the spec changed since the ArgoCD was last reconciled successfully. Nil is returned if the whole reconcile
+// chain must run, i.e. if the ArgoCD was not reconciled yet, if anything else changed, or if nothing changed at all, as
+// the reconciliation is then requested to repair the resources of the ArgoCD.
+func (o *reconciledObjects) getDirtyComponents(cr *argoprojv1a1.ArgoCD) []componentReconciler {
+	if o == nil {
+		return nil
+	}
+	o.mu.Lock()
+	last, ok := o.objects[types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}]
+	o.mu.Unlock()
+	if !ok || last.generation == cr.Generation || !reflect.DeepEqual(last.labels, copyMap(cr.Labels)) ||
+		!reflect.DeepEqual(last.annotations, copyMap(cr.Annotations)) {
+		return nil
+	}
+
+	// Any change outside of the sections of the components requires the whole reconcile chain.
+	if !reflect.DeepEqual(clearComponentSections(last.spec, ""), clearComponentSections(&cr.Spec, "")) {
+		return nil
+	}
+
+	var dirty []componentReconciler
+	for _, component := range componentReconcilers {
+		if !reflect.DeepEqual(clearComponentSections(last.spec, component.name), clearComponentSections(&cr.Spec, component.name)) {
+			dirty = append(dirty, component)
+		}
+	}
+	return dirty
+}
+
+// clearComponentSections returns a copy of the given spec without the sections of the components, except for the
+// sections of the component with the given name.
+func clearComponentSections(spec *argoprojv1a1.ArgoCDSpec, except string) *argoprojv1a1.ArgoCDSpec {
+	spec = spec.DeepCopy()
+	for _, component := range componentReconcilers {
+		if component.name != except {
+			component.clear(spec)
+		}
+	}
+	return spec
+}
+
+// copyMap returns a copy of the given map, or an empty map if it is nil, so that nil and empty maps compare as equal.
+func copyMap(m map[string]string) map[string]string {
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// reconcileComponents will reconcile the resources of the given components of the given ArgoCD only, followed by its
+// status and the cleanup of its resources. The cleanup is skipped when a component failed.
+func (r *ReconcileArgoCD) reconcileComponents(cr *argoprojv1a1.ArgoCD, components []componentReconciler) error {
+	names := make([]string, 0, len(components))
+	for _, component := range components {
+		names = append(names, component.name)
+	}
+	log.Info(fmt.Sprintf("reconciling the changed components %s only", strings.Join(names, ", ")))
+
+	var errs componentErrors
+	for _, component := range components {
+		if err := component.reconcile(r, cr); err != nil {
+			errs = append(errs, &componentError{component: component.name, err: err})
+		}
+	}
+
+	log.Info("reconciling status")
+	if err := r.reconcileStatus(cr); err != nil {
+		return err
+	}
+
+	r.reconcileStatusComponentErrors(cr, errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return r.reconcileResourceCleanup(cr)
+}
+
+// reconcileDexComponent will reconcile the resources of the SSO provider of the given ArgoCD, including the dex
+// client secret, certificate and metrics.
+func (r *ReconcileArgoCD) reconcileDexComponent(cr *argoprojv1a1.ArgoCD) error {
+	log.Info("reconciling SSO")
+	if err := r.reconcileSSO(cr); err != nil {
+		return err
+	}
+
+	log.Info("reconciling secrets")
+	if err := r.reconcileSecrets(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileCertManagerCertificates(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileDexTLSSecret(cr); err != nil {
+		return err
+	}
+
+	if IsPrometheusAPIAvailable() {
+		if err := r.reconcileDexServiceMonitor(cr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileApplicationSetComponent will reconcile the resources of the ApplicationSet controller of the given ArgoCD,
+// including its webhook ingress, route and metrics.
+func (r *ReconcileArgoCD) reconcileApplicationSetComponent(cr *argoprojv1a1.ArgoCD) error {
+	if cr.Spec.ApplicationSet != nil {
+		log.Info("reconciling ApplicationSet controller")
+		if err := r.reconcileApplicationSetController(cr); err != nil {
+			return err
+		}
+	} else {
+		if err := r.reconcileApplicationSetService(cr); err != nil {
+			return err
+		}
+		if err := r.reconcileApplicationSetGitHubAppSecret(cr); err != nil {
+			return err
+		}
+	}
+
+	if err := r.reconcileApplicationSetControllerIngress(cr); err != nil {
+		return err
+	}
+
+	if IsRouteAPIAvailable() {
+		if err := r.reconcileApplicationSetControllerWebhookRoute(cr); err != nil {
+			return err
+		}
+	}
+
+	if IsPrometheusAPIAvailable() {
+		if err := r.reconcileApplicationSetServiceMonitor(cr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileNotificationsComponent will reconcile the resources of the notifications controller of the given ArgoCD.
+// The resources of a disabled notifications controller are deleted by the watch on the ArgoCD.
+func (r *ReconcileArgoCD) reconcileNotificationsComponent(cr *argoprojv1a1.ArgoCD) error {
+	if !cr.Spec.Notifications.Enabled {
+		return nil
+	}
+	log.Info("reconciling Notifications controller")
+	return r.reconcileNotificationsController(cr)
+}
@@ -0,0 +1,92 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func getComponentNames(components []componentReconciler) []string {
+	var names []string
+	for _, component := range components {
+		names = append(names, component.name)
+	}
+	return names
+}
+
+func TestReconciledObjects_getDirtyComponents(t *testing.T) {
+	a := makeTestArgoCD()
+	a.Generation = 1
+	o := &reconciledObjects{}
+
+	// instances that were not reconciled yet are fully reconciled
+	assert.Nil(t, o.getDirtyComponents(a))
+
+	// so are instances whose spec did not change, as their resources may need to be repaired
+	o.remember(a)
+	assert.Nil(t, o.getDirtyComponents(a))
+
+	a.Generation = 2
+	a.Spec.SSO = &argoprojv1alpha1.ArgoCDSSOSpec{
+		Provider: argoprojv1alpha1.SSOProviderTypeDex,
+		Dex:      &argoprojv1alpha1.ArgoCDDexSpec{Config: "test-config"},
+	}
+	assert.Equal(t, []string{"dex"}, getComponentNames(o.getDirtyComponents(a)))
+
+	a.Spec.Notifications.Enabled = true
+	a.Spec.ApplicationSet = &argoprojv1alpha1.ArgoCDApplicationSet{}
+	assert.Equal(t, []string{"dex", "applicationset", "notifications"}, getComponentNames(o.getDirtyComponents(a)))
+
+	// any other change requires the whole reconcile chain
+	a.Spec.Server.Replicas = int32Ptr(2)
+	assert.Nil(t, o.getDirtyComponents(a))
+
+	a.Spec.Server.Replicas = nil
+	a.Annotations = map[string]string{"example": "true"}
+	assert.Nil(t, o.getDirtyComponents(a))
+
+	// the instance is fully reconciled once it is forgotten
+	a.Annotations = nil
+	assert.Len(t, o.getDirtyComponents(a), 3)
+	o.forget(types.NamespacedName{Name: a.Name, Namespace: a.Namespace})
+	assert.Nil(t, o.getDirtyComponents(a))
+}
+
+func TestReconcileArgoCD_Reconcile_dirtyComponents(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Generation = 1
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	server := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, server))
+	assert.NoError(t, r.Client.Delete(context.TODO(), server))
+
+	// enabling notifications only reconciles the notifications controller
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	a.Generation = 2
+	a.Spec.Notifications.Enabled = true
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-notifications-controller", Namespace: a.Namespace}, &appsv1.Deployment{}))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: server.Name, Namespace: a.Namespace}, &appsv1.Deployment{}))
+
+	// the next reconciliation of an unchanged spec runs the whole reconcile chain again
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: server.Name, Namespace: a.Namespace}, &appsv1.Deployment{}))
+}
@@ -0,0 +1,274 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// eventClient is a client.Client that records an Event on the owning ArgoCD for every resource created, updated or
+// deleted through it.
+type eventClient struct {
+	client.Client
+}
+
+// newEventClient returns a new eventClient that wraps the given client.
+func newEventClient(c client.Client) *eventClient {
+	return &eventClient{Client: c}
+}
+
+// getOwner returns the ArgoCD that controls the given object. The returned bool is false if the object is not
+// controlled by an ArgoCD.
+func getOwner(obj client.Object) (metav1.ObjectMeta, metav1.TypeMeta, bool) {
+	ref := metav1.GetControllerOf(obj)
+	if ref == nil || ref.Kind != "ArgoCD" || ref.APIVersion != argoprojv1a1.GroupVersion.String() || obj.GetNamespace() == "" {
+		return metav1.ObjectMeta{}, metav1.TypeMeta{}, false
+	}
+	return metav1.ObjectMeta{Name: ref.Name, Namespace: obj.GetNamespace(), UID: ref.UID},
+		metav1.TypeMeta{Kind: ref.Kind, APIVersion: ref.APIVersion}, true
+}
+
+// newEmptyObject returns a new empty object of the same type as the given object.
+func newEmptyObject(obj client.Object) client.Object {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		empty := &unstructured.Unstructured{}
+		empty.SetGroupVersionKind(u.GroupVersionKind())
+		return empty
+	}
+	return reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+}
+
+// getExisting returns the current state of the given object, or nil if it cannot be retrieved.
+func (c *eventClient) getExisting(ctx context.Context, obj client.Object) client.Object {
+	existing := newEmptyObject(obj)
+	if err := c.Client.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		return nil
+	}
+	return existing
+}
+
+// recordEvent records an Event with the given reason and action on the ArgoCD that controls the given object.
+// Events are informational, failures to record them are logged and otherwise ignored.
+func (c *eventClient) recordEvent(obj client.Object, reason, action, details string) {
+	if _, ok := obj.(*corev1.Event); ok {
+		return
+	}
+	meta, typeMeta, ok := getOwner(obj)
+	if !ok {
+		return
+	}
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		kind = gvk.Kind
+	}
+	message := fmt.Sprintf("%s %s %s", action, kind, obj.GetName())
+	if details != "" {
+		message = fmt.Sprintf("%s: %s", message, details)
+	}
+	if err := argoutil.CreateEvent(c.Client, "Normal", action, message, reason, meta, typeMeta); err != nil {
+		log.Error(err, "failed to record event", "message", message)
+	}
+}
+
+// Create creates the given object and records an Event for it.
+func (c *eventClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := c.Client.Create(ctx, obj, opts...)
+	if err == nil {
+		c.recordEvent(obj, "ResourceCreated", "Created", "")
+	}
+	return err
+}
+
+// Update updates the given object and records an Event that summarizes what changed. Updates that do not change the
+// object are not recorded.
+func (c *eventClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	var existing client.Object
+	if _, ok := obj.(*corev1.Event); !ok {
+		existing = c.getExisting(ctx, obj)
+	}
+	err := c.Client.Update(ctx, obj, opts...)
+	if err == nil && existing != nil {
+		if changes := getResourceChanges(existing, obj); len(changes) > 0 {
+			c.recordEvent(obj, "ResourceUpdated", "Updated", strings.Join(changes, ", ")+" changed")
+		}
+	}
+	return err
+}
+
+// Patch patches the given object and records an Event for it. Objects created by an apply are recorded as created,
+// and patches that do not change the object are not recorded. The object is only retrieved before it is patched if
+// the given context does not hold its existing state, see withExistingObject.
+func (c *eventClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	existing, found := getExistingObject(ctx)
+	if _, ok := obj.(*corev1.Event); !ok && !found {
+		existing = c.getExisting(ctx, obj)
+	}
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		if patch.Type() == types.ApplyPatchType {
+			c.recordEvent(obj, "ResourceCreated", "Created", "")
+		}
+	} else if obj.GetResourceVersion() != existing.GetResourceVersion() {
+		if changes := getResourceChanges(existing, obj); len(changes) > 0 {
+			c.recordEvent(obj, "ResourceUpdated", "Updated", strings.Join(changes, ", ")+" changed")
+		}
+	}
+	return nil
+}
+
+// Delete deletes the given object and records an Event for it.
+func (c *eventClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	owned := obj
+	if _, _, ok := getOwner(obj); !ok {
+		// Objects are often deleted using a new object that only holds the name.
+		if existing := c.getExisting(ctx, obj); existing != nil {
+			owned = existing
+		}
+	}
+	err := c.Client.Delete(ctx, obj, opts...)
+	if err == nil {
+		c.recordEvent(owned, "ResourceDeleted", "Deleted", "")
+	}
+	return err
+}
+
+// getContainerImages returns the images of the containers of the given pod spec.
+func getContainerImages(spec corev1.PodSpec) []string {
+	var images []string
+	for _, container := range append(spec.InitContainers, spec.Containers...) {
+		images = append(images, container.Image)
+	}
+	return images
+}
+
+// getWorkloadChanges returns the changes between the given Deployments or StatefulSets that are worth calling out on
+// their own, and copies of the objects without these changes.
+func getWorkloadChanges(existing, updated client.Object) ([]string, client.Object, client.Object) {
+	var changes []string
+	existing = existing.DeepCopyObject().(client.Object)
+	updated = updated.DeepCopyObject().(client.Object)
+
+	var existingSpec, updatedSpec *corev1.PodSpec
+	var existingReplicas, updatedReplicas **int32
+	switch e := existing.(type) {
+	case *appsv1.Deployment:
+		u, ok := updated.(*appsv1.Deployment)
+		if !ok {
+			return nil, existing, updated
+		}
+		existingSpec, updatedSpec = &e.Spec.Template.Spec, &u.Spec.Template.Spec
+		existingReplicas, updatedReplicas = &e.Spec.Replicas, &u.Spec.Replicas
+	case *appsv1.StatefulSet:
+		u, ok := updated.(*appsv1.StatefulSet)
+		if !ok {
+			return nil, existing, updated
+		}
+		existingSpec, updatedSpec = &e.Spec.Template.Spec, &u.Spec.Template.Spec
+		existingReplicas, updatedReplicas = &e.Spec.Replicas, &u.Spec.Replicas
+	default:
+		return nil, existing, updated
+	}
+
+	if !reflect.DeepEqual(getContainerImages(*existingSpec), getContainerImages(*updatedSpec)) {
+		changes = append(changes, "image")
+		if len(existingSpec.Containers) == len(updatedSpec.Containers) && len(existingSpec.InitContainers) == len(updatedSpec.InitContainers) {
+			for i := range existingSpec.Containers {
+				existingSpec.Containers[i].Image = updatedSpec.Containers[i].Image
+			}
+			for i := range existingSpec.InitContainers {
+				existingSpec.InitContainers[i].Image = updatedSpec.InitContainers[i].Image
+			}
+		}
+	}
+	if !reflect.DeepEqual(*existingReplicas, *updatedReplicas) {
+		changes = append(changes, "replicas")
+		*existingReplicas = *updatedReplicas
+	}
+	return changes, existing, updated
+}
+
+// getResourceChanges returns the names of the parts of the given object that differ between its existing and updated
+// state, e.g. "spec", "data" or "labels". The values themselves are never returned, as they may hold secrets.
+func getResourceChanges(existing, updated client.Object) []string {
+	changes, existing, updated := getWorkloadChanges(existing, updated)
+
+	existingFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(existing)
+	if err != nil {
+		return changes
+	}
+	updatedFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(updated)
+	if err != nil {
+		return changes
+	}
+
+	var fields []string
+	for _, fieldMap := range []map[string]interface{}{existingFields, updatedFields} {
+		for field := range fieldMap {
+			switch field {
+			case "apiVersion", "kind", "metadata", "status":
+				continue
+			}
+			if !reflect.DeepEqual(existingFields[field], updatedFields[field]) && !containsString(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+	}
+	sort.Strings(fields)
+	changes = append(changes, fields...)
+
+	if !reflect.DeepEqual(existing.GetLabels(), updated.GetLabels()) && (len(existing.GetLabels()) > 0 || len(updated.GetLabels()) > 0) {
+		changes = append(changes, "labels")
+	}
+	// The spec hash changes with every other change, and is not worth calling out.
+	existingAnnotations, updatedAnnotations := withoutSpecHash(existing.GetAnnotations()), withoutSpecHash(updated.GetAnnotations())
+	if !reflect.DeepEqual(existingAnnotations, updatedAnnotations) && (len(existingAnnotations) > 0 || len(updatedAnnotations) > 0) {
+		changes = append(changes, "annotations")
+	}
+	return changes
+}
+
+// withoutSpecHash returns a copy of the given annotations without the spec hash annotation.
+func withoutSpecHash(annotations map[string]string) map[string]string {
+	if _, ok := annotations[common.AnnotationSpecHash]; !ok {
+		return annotations
+	}
+	result := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		if k != common.AnnotationSpecHash {
+			result[k] = v
+		}
+	}
+	return result
+}
@@ -0,0 +1,153 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// getTestEventMessages returns the messages of the Events with the given reason.
+func getTestEventMessages(t *testing.T, c client.Client, reason string) []string {
+	t.Helper()
+	events := &corev1.EventList{}
+	assert.NoError(t, c.List(context.TODO(), events))
+	var messages []string
+	for _, event := range events.Items {
+		if event.Reason == reason {
+			messages = append(messages, event.Message)
+		}
+	}
+	return messages
+}
+
+// getCountingClient is a client.Client that counts the Deployments retrieved through it.
+type getCountingClient struct {
+	client.Client
+	gets int
+}
+
+func (c *getCountingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if _, ok := obj.(*appsv1.Deployment); ok {
+		c.gets++
+	}
+	return c.Client.Get(ctx, key, obj)
+}
+
+func TestEventClient_deployment(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	r.Client = newEventClient(r.Client)
+
+	deployment := newDeploymentWithSuffix("repo-server", "repo-server", a)
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: "repo", Image: "argocd:v1"}}
+	assert.NoError(t, controllerutil.SetControllerReference(a, deployment, r.Scheme))
+	assert.NoError(t, r.Client.Create(context.TODO(), deployment))
+	assert.Equal(t, []string{"Created Deployment argocd-repo-server"}, getTestEventMessages(t, r.Client, "ResourceCreated"))
+
+	deployment.Spec.Template.Spec.Containers[0].Image = "argocd:v2"
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+	assert.Equal(t, []string{"Updated Deployment argocd-repo-server: image changed"}, getTestEventMessages(t, r.Client, "ResourceUpdated"))
+
+	// updates that do not change the object are not recorded
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+	assert.Len(t, getTestEventMessages(t, r.Client, "ResourceUpdated"), 1)
+
+	// objects deleted by name are recorded on their owner
+	assert.NoError(t, r.Client.Delete(context.TODO(), newDeploymentWithSuffix("repo-server", "repo-server", a)))
+	assert.Equal(t, []string{"Deleted Deployment argocd-repo-server"}, getTestEventMessages(t, r.Client, "ResourceDeleted"))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: deployment.Name, Namespace: a.Namespace}, &appsv1.Deployment{}))
+}
+
+func TestEventClient_secret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	r.Client = newEventClient(r.Client)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-secret", Namespace: a.Namespace},
+		Data:       map[string][]byte{"password": []byte("old")},
+	}
+	assert.NoError(t, controllerutil.SetControllerReference(a, secret, r.Scheme))
+	assert.NoError(t, r.Client.Create(context.TODO(), secret))
+
+	// the values of the changed fields are never included
+	secret.Data["password"] = []byte("new")
+	secret.Labels = map[string]string{"key": "value"}
+	assert.NoError(t, r.Client.Update(context.TODO(), secret))
+	assert.Equal(t, []string{"Updated Secret argocd-secret: data, labels changed"}, getTestEventMessages(t, r.Client, "ResourceUpdated"))
+
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events))
+	for _, event := range events.Items {
+		assert.Equal(t, a.Name, event.InvolvedObject.Name)
+		assert.Equal(t, "ArgoCD", event.InvolvedObject.Kind)
+	}
+}
+
+func TestEventClient_unowned(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	r.Client = newEventClient(r.Client)
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unowned", Namespace: a.Namespace}}
+	assert.NoError(t, r.Client.Create(context.TODO(), cm))
+	cm.Data = map[string]string{"key": "value"}
+	assert.NoError(t, r.Client.Update(context.TODO(), cm))
+	assert.NoError(t, r.Client.Delete(context.TODO(), cm))
+
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events))
+	assert.Empty(t, events.Items)
+}
+
+func TestEventClient_apply(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	r.Client = newEventClient(r.Client)
+
+	deployment := newDeploymentWithSuffix("repo-server", "repo-server", a)
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: "repo", Image: "argocd:v1"}}
+	assert.NoError(t, r.applyResource(a, deployment))
+	assert.Equal(t, []string{"Created Deployment argocd-repo-server"}, getTestEventMessages(t, r.Client, "ResourceCreated"))
+
+	// applies that do not change the object are not recorded
+	assert.NoError(t, r.applyResource(a, deployment))
+	assert.Empty(t, getTestEventMessages(t, r.Client, "ResourceUpdated"))
+
+	deployment.Spec.Template.Spec.Containers[0].Image = "argocd:v2"
+	assert.NoError(t, r.applyResource(a, deployment))
+	assert.Equal(t, []string{"Updated Deployment argocd-repo-server: image changed"}, getTestEventMessages(t, r.Client, "ResourceUpdated"))
+}
+
+func TestEventClient_applyDoesNotRetrieveExisting(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	counting := &getCountingClient{Client: r.Client}
+	r.Client = newMetricsClient(newEventClient(counting))
+
+	deployment := newDeploymentWithSuffix("repo-server", "repo-server", a)
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: "repo", Image: "argocd:v1"}}
+	assert.NoError(t, r.applyResource(a, deployment))
+	assert.Equal(t, []string{"Created Deployment argocd-repo-server"}, getTestEventMessages(t, r.Client, "ResourceCreated"))
+
+	// the Deployment is only retrieved by applyResource itself
+	counting.gets = 0
+	deployment.Spec.Template.Spec.Containers[0].Image = "argocd:v2"
+	assert.NoError(t, r.applyResource(a, deployment))
+	assert.Equal(t, 1, counting.gets)
+	assert.Equal(t, []string{"Updated Deployment argocd-repo-server: image changed"}, getTestEventMessages(t, r.Client, "ResourceUpdated"))
+	assert.Equal(t, int64(2), r.getResourceChangeCount())
+}
@@ -0,0 +1,184 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const (
+	execProviderAWS   = "aws"
+	execProviderAzure = "azure"
+
+	execProviderAPIVersion = "client.authentication.k8s.io/v1beta1"
+)
+
+// getClusterConfig returns the Argo CD cluster config of the given cluster. Clusters with an exec provider
+// authenticate through the workload identity of the application controller and the server.
+func getClusterConfig(cluster argoprojv1a1.ArgoCDClusterSpec) map[string]interface{} {
+	tlsClientConfig := map[string]interface{}{
+		"insecure": false,
+	}
+	config := map[string]interface{}{
+		"tlsClientConfig": tlsClientConfig,
+	}
+
+	provider := cluster.ExecProvider
+	if provider == nil {
+		return config
+	}
+	if provider.CAData != "" {
+		tlsClientConfig["caData"] = provider.CAData
+	}
+
+	// Argo CD authenticates to EKS clusters through its own awsAuthConfig.
+	if provider.Provider == execProviderAWS {
+		awsAuthConfig := map[string]interface{}{
+			"clusterName": provider.ClusterName,
+		}
+		if provider.RoleARN != "" {
+			awsAuthConfig["roleARN"] = provider.RoleARN
+		}
+		config["awsAuthConfig"] = awsAuthConfig
+		return config
+	}
+
+	command, args := common.ArgoCDDefaultExecProviderCommand, []string{provider.Provider}
+	if provider.Command != "" {
+		command, args = provider.Command, provider.Args
+	}
+	execProviderConfig := map[string]interface{}{
+		"command":    command,
+		"apiVersion": execProviderAPIVersion,
+	}
+	if len(args) > 0 {
+		execProviderConfig["args"] = args
+	}
+	if provider.Provider == execProviderAzure {
+		env := map[string]string{
+			"AAD_ENVIRONMENT_NAME":       "AzurePublicCloud",
+			"AAD_LOGIN_METHOD":           "workloadidentity",
+			"AZURE_AUTHORITY_HOST":       common.ArgoCDDefaultAzureAuthorityHost,
+			"AZURE_FEDERATED_TOKEN_FILE": common.ArgoCDDefaultAzureFederatedTokenFile,
+		}
+		if provider.ClientID != "" {
+			env["AZURE_CLIENT_ID"] = provider.ClientID
+		}
+		if provider.TenantID != "" {
+			env["AZURE_TENANT_ID"] = provider.TenantID
+		}
+		execProviderConfig["env"] = env
+	}
+	config["execProviderConfig"] = execProviderConfig
+	return config
+}
+
+// validateClusterExecProvider returns an error if the exec provider of the given cluster lacks a setting required by
+// its cloud provider.
+func validateClusterExecProvider(cluster argoprojv1a1.ArgoCDClusterSpec) error {
+	if cluster.ExecProvider == nil {
+		return nil
+	}
+	if cluster.ExecProvider.Provider == execProviderAWS && cluster.ExecProvider.ClusterName == "" {
+		return fmt.Errorf("the %s exec provider requires a cluster name", execProviderAWS)
+	}
+	return nil
+}
+
+// usesAzureWorkloadIdentity returns true if a cluster of the given ArgoCD authenticates through the workload identity
+// of azure.
+func usesAzureWorkloadIdentity(cr *argoprojv1a1.ArgoCD) bool {
+	for _, cluster := range cr.Spec.InitialClusters {
+		if cluster.ExecProvider != nil && cluster.ExecProvider.Provider == execProviderAzure {
+			return true
+		}
+	}
+	return false
+}
+
+// getExecProviderToolsInitContainers will return the init containers that copy the exec provider tools of the given
+// ArgoCD into the shared exec provider tools volume.
+func getExecProviderToolsInitContainers(cr *argoprojv1a1.ArgoCD, resources corev1.ResourceRequirements) []corev1.Container {
+	containers := make([]corev1.Container, 0)
+	for _, tool := range cr.Spec.ExecProviderTools {
+		containers = append(containers, corev1.Container{
+			Name:            fmt.Sprintf("exec-provider-%s", tool.Name),
+			Image:           tool.Image,
+			Command:         []string{"cp", tool.Path, path.Join(common.ArgoCDExecProviderToolsMountPath, tool.Name)},
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Resources:       resources,
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: boolPtr(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{
+						"ALL",
+					},
+				},
+				RunAsNonRoot: boolPtr(true),
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      common.ArgoCDExecProviderToolsVolumeName,
+					MountPath: common.ArgoCDExecProviderToolsMountPath,
+				},
+			},
+		})
+	}
+	return containers
+}
+
+// getExecProviderToolsVolumeMounts will return the VolumeMounts that expose the exec provider tools of the given
+// ArgoCD at their configured path.
+func getExecProviderToolsVolumeMounts(cr *argoprojv1a1.ArgoCD) []corev1.VolumeMount {
+	mounts := make([]corev1.VolumeMount, 0)
+	for _, tool := range cr.Spec.ExecProviderTools {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      common.ArgoCDExecProviderToolsVolumeName,
+			MountPath: tool.Path,
+			SubPath:   tool.Name,
+		})
+	}
+	return mounts
+}
+
+// addExecProviderTools will add the exec provider tools of the given ArgoCD to the given pod template of the
+// application controller or the server, and enable the Azure workload identity webhook for it when needed.
+func addExecProviderTools(cr *argoprojv1a1.ArgoCD, template *corev1.PodTemplateSpec, resources corev1.ResourceRequirements) {
+	if usesAzureWorkloadIdentity(cr) {
+		if template.Labels == nil {
+			template.Labels = map[string]string{}
+		}
+		template.Labels[common.ArgoCDKeyAzureWorkloadIdentity] = "true"
+	}
+
+	if len(cr.Spec.ExecProviderTools) == 0 {
+		return
+	}
+	podSpec := &template.Spec
+	podSpec.InitContainers = append(podSpec.InitContainers, getExecProviderToolsInitContainers(cr, resources)...)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, getExecProviderToolsVolumeMounts(cr)...)
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: common.ArgoCDExecProviderToolsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// proxyExtensionConfig is a proxy extension in the extension.config of the argocd-cm ConfigMap.
+type proxyExtensionConfig struct {
+	Name    string             `yaml:"name"`
+	Backend proxyBackendConfig `yaml:"backend"`
+}
+
+// proxyBackendConfig is the backend of a proxy extension in the extension.config of the argocd-cm ConfigMap.
+type proxyBackendConfig struct {
+	ConnectionTimeout     string               `yaml:"connectionTimeout,omitempty"`
+	KeepAlive             string               `yaml:"keepAlive,omitempty"`
+	IdleConnectionTimeout string               `yaml:"idleConnectionTimeout,omitempty"`
+	MaxIdleConnections    int32                `yaml:"maxIdleConnections,omitempty"`
+	Services              []proxyServiceConfig `yaml:"services"`
+}
+
+// proxyServiceConfig is a service of the backend of a proxy extension in the extension.config of the argocd-cm
+// ConfigMap.
+type proxyServiceConfig struct {
+	URL     string              `yaml:"url"`
+	Headers []proxyHeaderConfig `yaml:"headers,omitempty"`
+	Cluster *proxyClusterConfig `yaml:"cluster,omitempty"`
+}
+
+// proxyHeaderConfig is a header added to the requests forwarded to a service of a proxy extension.
+type proxyHeaderConfig struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// proxyClusterConfig selects the cluster of a service of a proxy extension.
+type proxyClusterConfig struct {
+	Name   string `yaml:"name,omitempty"`
+	Server string `yaml:"server,omitempty"`
+}
+
+// getServerExtensionInstallerImage will return the container image of the argocd-extension-installer installing the
+// given extension.
+func getServerExtensionInstallerImage(extension argoprojv1a1.ArgoCDServerExtensionSpec) string {
+	if extension.Image != "" {
+		return extension.Image
+	}
+	return argoutil.CombineImageTag(common.ArgoCDDefaultExtensionInstallerImage, common.ArgoCDDefaultExtensionInstallerVersion)
+}
+
+// getServerExtensionsInitContainers will return the init containers that install the UI extensions of the given
+// ArgoCD into the shared extensions volume.
+func getServerExtensionsInitContainers(cr *argoprojv1a1.ArgoCD, resources corev1.ResourceRequirements) []corev1.Container {
+	containers := make([]corev1.Container, 0)
+	for _, extension := range cr.Spec.Server.Extensions {
+		if extension.URL == "" {
+			continue
+		}
+		env := []corev1.EnvVar{
+			{Name: "EXTENSION_NAME", Value: extension.Name},
+			{Name: "EXTENSION_URL", Value: extension.URL},
+		}
+		if extension.ChecksumURL != "" {
+			env = append(env, corev1.EnvVar{Name: "EXTENSION_CHECKSUM_URL", Value: extension.ChecksumURL})
+		}
+		containers = append(containers, corev1.Container{
+			Name:            fmt.Sprintf("extension-%s", extension.Name),
+			Image:           getServerExtensionInstallerImage(extension),
+			Env:             env,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Resources:       resources,
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: boolPtr(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{
+						"ALL",
+					},
+				},
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      common.ArgoCDExtensionsVolumeName,
+					MountPath: common.ArgoCDExtensionsMountPath,
+				},
+			},
+		})
+	}
+	return containers
+}
+
+// getServerExtensionsEnv will return the environment variables enabling the proxy extension feature of the Argo CD
+// Server when the given ArgoCD installs UI extensions.
+func getServerExtensionsEnv(cr *argoprojv1a1.ArgoCD) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0)
+	if len(cr.Spec.Server.Extensions) > 0 {
+		env = append(env, corev1.EnvVar{Name: "ARGOCD_SERVER_ENABLE_PROXY_EXTENSION", Value: "true"})
+	}
+	return env
+}
+
+// addServerExtensions will add the init containers installing the UI extensions of the given ArgoCD to the given pod
+// template of the Argo CD Server, and share the installed extensions with the server.
+func addServerExtensions(cr *argoprojv1a1.ArgoCD, template *corev1.PodTemplateSpec, resources corev1.ResourceRequirements) {
+	initContainers := getServerExtensionsInitContainers(cr, resources)
+	if len(initContainers) == 0 {
+		return
+	}
+	podSpec := &template.Spec
+	podSpec.InitContainers = append(podSpec.InitContainers, initContainers...)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      common.ArgoCDExtensionsVolumeName,
+		MountPath: common.ArgoCDExtensionsMountPath,
+	})
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: common.ArgoCDExtensionsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+}
+
+// durationString will return the given duration in the format of the extension.config, or an empty string if unset.
+func durationString(d *metav1.Duration) string {
+	if d == nil {
+		return ""
+	}
+	return d.Duration.String()
+}
+
+// getServerExtensionConfig will return the extension.config of the argocd-cm ConfigMap, holding the backends of the
+// proxy extensions of the given ArgoCD, or an empty string if no extension has a backend.
+func getServerExtensionConfig(cr *argoprojv1a1.ArgoCD) (string, error) {
+	extensions := make([]proxyExtensionConfig, 0)
+	for _, extension := range cr.Spec.Server.Extensions {
+		backend := extension.Backend
+		if backend == nil {
+			continue
+		}
+		config := proxyExtensionConfig{
+			Name: extension.Name,
+			Backend: proxyBackendConfig{
+				ConnectionTimeout:     durationString(backend.ConnectionTimeout),
+				KeepAlive:             durationString(backend.KeepAlive),
+				IdleConnectionTimeout: durationString(backend.IdleConnectionTimeout),
+				Services:              make([]proxyServiceConfig, 0, len(backend.Services)),
+			},
+		}
+		if backend.MaxIdleConnections != nil {
+			config.Backend.MaxIdleConnections = *backend.MaxIdleConnections
+		}
+		for _, service := range backend.Services {
+			serviceConfig := proxyServiceConfig{URL: service.URL}
+			for _, header := range service.Headers {
+				serviceConfig.Headers = append(serviceConfig.Headers, proxyHeaderConfig{Name: header.Name, Value: header.Value})
+			}
+			if service.Cluster != nil {
+				serviceConfig.Cluster = &proxyClusterConfig{Name: service.Cluster.Name, Server: service.Cluster.Server}
+			}
+			config.Backend.Services = append(config.Backend.Services, serviceConfig)
+		}
+		extensions = append(extensions, config)
+	}
+	if len(extensions) == 0 {
+		return "", nil
+	}
+
+	bytes, err := yaml.Marshal(map[string][]proxyExtensionConfig{"extensions": extensions})
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
@@ -16,18 +16,24 @@ package argocd
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/sethvargo/go-password/password"
-	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 
 	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
 	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
 // GrafanaConfig represents the Grafana configuration options.
@@ -88,21 +94,6 @@ func getGrafanaConfigPath() string {
 	return common.ArgoCDDefaultGrafanaConfigPath
 }
 
-// hasGrafanaSpecChanged will return true if the supported properties differs in the actual versus the desired state.
-func hasGrafanaSpecChanged(actual *appsv1.Deployment, desired *argoprojv1a1.ArgoCD) bool {
-	// Replica count
-	if desired.Spec.Grafana.Size != nil { // Replica count specified in desired state
-		if *desired.Spec.Grafana.Size >= 0 && *actual.Spec.Replicas != *desired.Spec.Grafana.Size {
-			return true
-		}
-	} else { // Replica count NOT specified in desired state
-		if *actual.Spec.Replicas != common.ArgoCDDefaultGrafanaReplicas {
-			return true
-		}
-	}
-	return false
-}
-
 // loadGrafanaConfigs will scan the config directory and read any files ending with '.yaml'
 func loadGrafanaConfigs() (map[string]string, error) {
 	data := make(map[string]string)
@@ -127,6 +118,30 @@ func loadGrafanaConfigs() (map[string]string, error) {
 	return data, nil
 }
 
+// loadGrafanaDashboards will scan the dashboards directory and read any files ending with '.json'
+func loadGrafanaDashboards() (map[string]string, error) {
+	data := make(map[string]string)
+
+	pattern := filepath.Join(getGrafanaConfigPath(), "dashboards/*.json")
+	dashboards, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range dashboards {
+		dashboard, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+
+		parts := strings.Split(f, "/")
+		filename := parts[len(parts)-1]
+		data[filename] = string(dashboard)
+	}
+
+	return data, nil
+}
+
 // loadGrafanaTemplates will scan the template directory and parse/execute any files ending with '.tmpl'
 func loadGrafanaTemplates(c *GrafanaConfig) (map[string]string, error) {
 	data := make(map[string]string)
@@ -166,3 +181,103 @@ func loadGrafanaTemplates(c *GrafanaConfig) (map[string]string, error) {
 
 	return data, nil
 }
+
+// getExternalGrafanaDashboardsChecksum will return the SHA256 checksum of the given dashboards as published to the
+// given external Grafana with the given API key.
+func getExternalGrafanaDashboardsChecksum(spec *argoprojv1a1.ArgoCDExternalGrafanaSpec, apiKey []byte, dashboards map[string]string) string {
+	filenames := make([]string, 0, len(dashboards))
+	for filename := range dashboards {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	sumBytes := []byte(fmt.Sprintf("url=%s\nfolderUID=%s\napiKey=%s\n", spec.URL, spec.FolderUID, apiKey))
+	for _, filename := range filenames {
+		sumBytes = append(sumBytes, fmt.Sprintf("%s=%s\n", filename, dashboards[filename])...)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(sumBytes))
+}
+
+// publishGrafanaDashboard will create or update the given dashboard in the given external Grafana through its
+// dashboard API.
+func publishGrafanaDashboard(spec *argoprojv1a1.ArgoCDExternalGrafanaSpec, apiKey []byte, dashboard string) error {
+	model := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(dashboard), &model); err != nil {
+		return err
+	}
+	// Dashboards are matched by their uid, the id is specific to the Grafana they were exported from.
+	model["id"] = nil
+
+	body, err := json.Marshal(map[string]interface{}{
+		"dashboard": model,
+		"folderUid": spec.FolderUID,
+		"overwrite": true,
+		"message":   "Published by the Argo CD Operator",
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/dashboards/db", strings.TrimSuffix(spec.URL, "/")), bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		msg, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("grafana responded with status %d: %s", res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// reconcileExternalGrafanaDashboards will ensure that the Grafana dashboards are published to the external Grafana of
+// the given ArgoCD, when set. The dashboards are only published again when they, the Grafana or the API key change.
+func (r *ReconcileArgoCD) reconcileExternalGrafanaDashboards(cr *argoprojv1a1.ArgoCD) error {
+	spec := cr.Spec.Monitoring.ExternalGrafana
+	if spec == nil {
+		// Dashboards published before are left in the Grafana, but published again when it is set again.
+		cr.Status.GrafanaDashboardsChecksum = ""
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, spec.APIKeySecretRef.Name, secret) {
+		log.Info(fmt.Sprintf("grafana api key secret %s not found, waiting to publish grafana dashboards", spec.APIKeySecretRef.Name))
+		return nil
+	}
+	apiKey := secret.Data[spec.APIKeySecretRef.Key]
+	if len(apiKey) == 0 {
+		log.Info(fmt.Sprintf("grafana api key %s of secret %s not found, waiting to publish grafana dashboards",
+			spec.APIKeySecretRef.Key, spec.APIKeySecretRef.Name))
+		return nil
+	}
+
+	dashboards, err := loadGrafanaDashboards()
+	if err != nil {
+		return err
+	}
+
+	checksum := getExternalGrafanaDashboardsChecksum(spec, apiKey, dashboards)
+	if cr.Status.GrafanaDashboardsChecksum == checksum {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("publishing grafana dashboards of Argo CD instance %s to %s", cr.Name, spec.URL))
+	for filename, dashboard := range dashboards {
+		if err := publishGrafanaDashboard(spec, apiKey, dashboard); err != nil {
+			return fmt.Errorf("failed to publish grafana dashboard %s to %s: %w", filename, spec.URL, err)
+		}
+	}
+
+	cr.Status.GrafanaDashboardsChecksum = checksum
+	return nil
+}
@@ -0,0 +1,108 @@
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func TestReconcileArgoCD_reconcileExternalGrafanaDashboards(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	t.Setenv("GRAFANA_CONFIG_PATH", "../../grafana")
+
+	var mu sync.Mutex
+	published := map[string]map[string]interface{}{}
+	authorization := ""
+	grafana := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/dashboards/db" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body := map[string]interface{}{}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		authorization = req.Header.Get("Authorization")
+		dashboard := body["dashboard"].(map[string]interface{})
+		published[dashboard["uid"].(string)] = body
+	}))
+	defer grafana.Close()
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Monitoring.ExternalGrafana = &argoprojv1alpha1.ArgoCDExternalGrafanaSpec{
+			URL: grafana.URL + "/",
+			APIKeySecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "grafana-api-key"},
+				Key:                  "token",
+			},
+			FolderUID: "argocd",
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	// the dashboards are not published until the API key exists
+	assert.NoError(t, r.reconcileExternalGrafanaDashboards(a))
+	assert.Empty(t, published)
+	assert.Empty(t, a.Status.GrafanaDashboardsChecksum)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "grafana-api-key", Namespace: a.Namespace},
+		Data:       map[string][]byte{"token": []byte("secret-token")},
+	}
+	assert.NoError(t, r.Client.Create(context.TODO(), secret))
+	assert.NoError(t, r.reconcileExternalGrafanaDashboards(a))
+	assert.Len(t, published, 3)
+	assert.Equal(t, "Bearer secret-token", authorization)
+	assert.Equal(t, "argocd", published["BjWwX3jik"]["folderUid"])
+	assert.Equal(t, true, published["BjWwX3jik"]["overwrite"])
+	assert.Nil(t, published["xnxhtXhWk"]["dashboard"].(map[string]interface{})["id"])
+	checksum := a.Status.GrafanaDashboardsChecksum
+	assert.NotEmpty(t, checksum)
+
+	// the dashboards are not published again while nothing changed
+	published = map[string]map[string]interface{}{}
+	assert.NoError(t, r.reconcileExternalGrafanaDashboards(a))
+	assert.Empty(t, published)
+
+	// a new API key publishes the dashboards again
+	secret.Data["token"] = []byte("rotated-token")
+	assert.NoError(t, r.Client.Update(context.TODO(), secret))
+	assert.NoError(t, r.reconcileExternalGrafanaDashboards(a))
+	assert.Len(t, published, 3)
+	assert.Equal(t, "Bearer rotated-token", authorization)
+	assert.NotEqual(t, checksum, a.Status.GrafanaDashboardsChecksum)
+
+	// the checksum is reset once the external Grafana is removed
+	a.Spec.Monitoring.ExternalGrafana = nil
+	assert.NoError(t, r.reconcileExternalGrafanaDashboards(a))
+	assert.Empty(t, a.Status.GrafanaDashboardsChecksum)
+	stored := &argoprojv1alpha1.ArgoCD{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, stored))
+	assert.Empty(t, stored.Status.GrafanaDashboardsChecksum)
+}
+
+func TestPublishGrafanaDashboard_error(t *testing.T) {
+	grafana := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Permission denied"}`))
+	}))
+	defer grafana.Close()
+
+	spec := &argoprojv1alpha1.ArgoCDExternalGrafanaSpec{URL: grafana.URL}
+	err := publishGrafanaDashboard(spec, []byte("token"), `{"uid":"argocd","title":"ArgoCD"}`)
+	assert.EqualError(t, err, `grafana responded with status 403: {"message":"Permission denied"}`)
+}
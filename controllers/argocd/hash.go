@@ -0,0 +1,137 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// getSpecHash returns the SHA256 hash of the desired state of the given object. The hash covers all fields of the
+// object apart from its metadata and status, and its labels and annotations, except for the spec hash annotation.
+// The data of other objects the object refers to, e.g. the ConfigMaps mounted by a workload, is not covered.
+func getSpecHash(obj client.Object) (string, error) {
+	fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", err
+	}
+	delete(fields, "apiVersion")
+	delete(fields, "kind")
+	delete(fields, "metadata")
+	delete(fields, "status")
+
+	annotations := map[string]string{}
+	for k, v := range obj.GetAnnotations() {
+		if k != common.AnnotationSpecHash {
+			annotations[k] = v
+		}
+	}
+	fields["labels"] = obj.GetLabels()
+	fields["annotations"] = annotations
+
+	// Maps are marshalled with sorted keys, so that equal objects have equal hashes.
+	sumBytes, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(sumBytes)), nil
+}
+
+// setSpecHash records the hash of the desired state of the given object in its spec hash annotation.
+func setSpecHash(obj client.Object) error {
+	hash, err := getSpecHash(obj)
+	if err != nil {
+		return err
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[common.AnnotationSpecHash] = hash
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// isSpecHashUnchanged returns true if the existing object was last written with the desired state of the given
+// object, whose spec hash must have been set with setSpecHash, and still holds all fields of the desired state.
+// Fields added to the existing object, e.g. defaults set by the API server, are ignored, while changes to the fields
+// of the desired state, e.g. manual edits, are not.
+func isSpecHashUnchanged(existing, desired client.Object) bool {
+	hash, ok := existing.GetAnnotations()[common.AnnotationSpecHash]
+	if !ok || hash != desired.GetAnnotations()[common.AnnotationSpecHash] {
+		return false
+	}
+
+	existingFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(existing)
+	if err != nil {
+		return false
+	}
+	desiredFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return false
+	}
+	for _, fields := range []map[string]interface{}{existingFields, desiredFields} {
+		delete(fields, "apiVersion")
+		delete(fields, "kind")
+		delete(fields, "status")
+		if metadata, ok := fields["metadata"].(map[string]interface{}); ok {
+			fields["metadata"] = map[string]interface{}{
+				"labels":      metadata["labels"],
+				"annotations": metadata["annotations"],
+			}
+		}
+	}
+	return containsFields(existingFields, desiredFields)
+}
+
+// containsFields returns true if the given unstructured value holds all fields of the desired value. Maps may hold
+// additional keys, while lists must hold the same number of items.
+func containsFields(value, desired interface{}) bool {
+	switch d := desired.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, field := range d {
+			if !containsFields(v[key], field) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		v, ok := value.([]interface{})
+		if !ok || len(v) != len(d) {
+			return false
+		}
+		for i := range d {
+			if !containsFields(v[i], d[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(value, desired)
+	}
+}
@@ -0,0 +1,78 @@
+package argocd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestGetSpecHash(t *testing.T) {
+	a := makeTestArgoCD()
+	cm := newConfigMapWithName("test", a)
+	cm.Data = map[string]string{"key": "value"}
+
+	hash, err := getSpecHash(cm)
+	assert.NoError(t, err)
+
+	// the hash does not depend on the server-side metadata or on the spec hash annotation
+	cm.ResourceVersion = "42"
+	assert.NoError(t, setSpecHash(cm))
+	assert.Equal(t, hash, cm.Annotations[common.AnnotationSpecHash])
+	assert.NoError(t, setSpecHash(cm))
+	assert.Equal(t, hash, cm.Annotations[common.AnnotationSpecHash])
+
+	cm.Labels["example.com/team"] = "a"
+	changed, err := getSpecHash(cm)
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, changed)
+}
+
+func TestIsSpecHashUnchanged(t *testing.T) {
+	a := makeTestArgoCD()
+	desired := newConfigMapWithName("test", a)
+	desired.Data = map[string]string{"key": "value"}
+	assert.NoError(t, setSpecHash(desired))
+
+	existing := desired.DeepCopy()
+	assert.True(t, isSpecHashUnchanged(existing, desired))
+
+	// fields added to the existing object are ignored
+	existing.Annotations["example.com/owner"] = "team-a"
+	existing.Data["extra"] = "value"
+	assert.True(t, isSpecHashUnchanged(existing, desired))
+
+	// changes to the desired fields are detected
+	existing.Data["key"] = "changed"
+	assert.False(t, isSpecHashUnchanged(existing, desired))
+
+	// objects without a spec hash are always updated
+	assert.False(t, isSpecHashUnchanged(&corev1.ConfigMap{}, desired))
+
+	changed := desired.DeepCopy()
+	changed.Data["key"] = "changed"
+	assert.NoError(t, setSpecHash(changed))
+	assert.False(t, isSpecHashUnchanged(desired, changed))
+}
+
+func TestContainsFields(t *testing.T) {
+	value := map[string]interface{}{
+		"replicas": int64(1),
+		"containers": []interface{}{
+			map[string]interface{}{"name": "server", "image": "argocd:v1", "imagePullPolicy": "Always"},
+		},
+	}
+
+	assert.True(t, containsFields(value, map[string]interface{}{"replicas": int64(1)}))
+	assert.True(t, containsFields(value, map[string]interface{}{
+		"containers": []interface{}{map[string]interface{}{"name": "server", "image": "argocd:v1"}},
+	}))
+	assert.False(t, containsFields(value, map[string]interface{}{
+		"containers": []interface{}{map[string]interface{}{"name": "server", "image": "argocd:v2"}},
+	}))
+	// lists must hold the same number of items
+	assert.False(t, containsFields(value, map[string]interface{}{"containers": []interface{}{}}))
+	assert.False(t, containsFields(value, map[string]interface{}{"replicas": map[string]interface{}{}}))
+}
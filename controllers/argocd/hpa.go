@@ -84,11 +84,3 @@ func (r *ReconcileArgoCD) reconcileServerHPA(cr *argoprojv1a1.ArgoCD) error {
 
 	return r.Client.Create(context.TODO(), hpa)
 }
-
-// reconcileAutoscalers will ensure that all HorizontalPodAutoscalers are present for the given ArgoCD.
-func (r *ReconcileArgoCD) reconcileAutoscalers(cr *argoprojv1a1.ArgoCD) error {
-	if err := r.reconcileServerHPA(cr); err != nil {
-		return err
-	}
-	return nil
-}
@@ -17,6 +17,7 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,7 +28,7 @@ import (
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
-// getArgoServerPath will return the Ingress Path for the Argo CD component.
+// getPathOrDefault will return the given Ingress Path, or the default path if it is not set.
 func getPathOrDefault(path string) string {
 	result := common.ArgoCDDefaultIngressPath
 	if len(path) > 0 {
@@ -36,6 +37,24 @@ func getPathOrDefault(path string) string {
 	return result
 }
 
+// getArgoServerIngressPath will return the Ingress Path for the Argo CD Server, which defaults to the root path
+// the server is served under.
+func getArgoServerIngressPath(cr *argoprojv1a1.ArgoCD) string {
+	if len(cr.Spec.Server.Ingress.Path) == 0 && len(cr.Spec.Server.RootPath) > 0 {
+		return cr.Spec.Server.RootPath
+	}
+	return getPathOrDefault(cr.Spec.Server.Ingress.Path)
+}
+
+// getApplicationSetWebhookIngressPath will return the Ingress Path for the ApplicationSet webhook, which defaults to
+// the path the webhook is served under.
+func getApplicationSetWebhookIngressPath(cr *argoprojv1a1.ArgoCD) string {
+	if len(cr.Spec.ApplicationSet.WebhookServer.Ingress.Path) > 0 {
+		return cr.Spec.ApplicationSet.WebhookServer.Ingress.Path
+	}
+	return common.ArgoCDDefaultApplicationSetWebhookPath
+}
+
 // newIngress returns a new Ingress instance for the given ArgoCD.
 func newIngress(cr *argoprojv1a1.ArgoCD) *networkingv1.Ingress {
 	return &networkingv1.Ingress{
@@ -64,92 +83,213 @@ func newIngressWithSuffix(suffix string, cr *argoprojv1a1.ArgoCD) *networkingv1.
 	return newIngressWithName(fmt.Sprintf("%s-%s", cr.Name, suffix), cr)
 }
 
-// reconcileIngresses will ensure that all ArgoCD Ingress resources are present.
-func (r *ReconcileArgoCD) reconcileIngresses(cr *argoprojv1a1.ArgoCD) error {
-	if err := r.reconcileArgoServerIngress(cr); err != nil {
-		return err
+// setIngressMetadata will set the annotations and labels of the given Ingress from the ingress spec. The default
+// annotations are merged with the user defined annotations, which take precedence, unless they are disabled.
+// User defined labels never override the labels set by the operator.
+func setIngressMetadata(ingress *networkingv1.Ingress, spec argoprojv1a1.ArgoCDIngressSpec, defaults map[string]string) {
+	atns := make(map[string]string)
+	if !spec.DisableDefaultAnnotations {
+		for k, v := range defaults {
+			atns[k] = v
+		}
 	}
-
-	if err := r.reconcileArgoServerGRPCIngress(cr); err != nil {
-		return err
-	}
-
-	if err := r.reconcileGrafanaIngress(cr); err != nil {
-		return err
+	for k, v := range spec.Annotations {
+		atns[k] = v
 	}
+	ingress.ObjectMeta.Annotations = atns
 
-	if err := r.reconcilePrometheusIngress(cr); err != nil {
-		return err
+	for k, v := range spec.Labels {
+		if _, ok := ingress.ObjectMeta.Labels[k]; !ok {
+			ingress.ObjectMeta.Labels[k] = v
+		}
 	}
+}
 
-	if err := r.reconcileApplicationSetControllerIngress(cr); err != nil {
-		return err
+// getIngressBackendProtocol will return the backend protocol of the ingress spec, or the given default protocol.
+func getIngressBackendProtocol(spec argoprojv1a1.ArgoCDIngressSpec, protocol string) string {
+	if len(spec.BackendProtocol) > 0 {
+		return spec.BackendProtocol
 	}
+	return protocol
+}
 
-	return nil
+// isTLSBackendProtocol returns true if the given backend protocol is encrypted with TLS.
+func isTLSBackendProtocol(protocol string) bool {
+	return protocol == "HTTPS" || protocol == "GRPCS"
 }
 
-// reconcileArgoServerIngress will ensure that the ArgoCD Server Ingress is present.
-func (r *ReconcileArgoCD) reconcileArgoServerIngress(cr *argoprojv1a1.ArgoCD) error {
-	ingress := newIngressWithSuffix("server", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if !cr.Spec.Server.Ingress.Enabled {
-			// Ingress exists but enabled flag has been set to false, delete the Ingress
-			return r.Client.Delete(context.TODO(), ingress)
+// getIngressDefaultAnnotations will return the default annotations for the ingress controller flavor of the ingress
+// spec. They configure the backend protocol and, when sslRedirect is true, the redirect of HTTP traffic to HTTPS.
+func getIngressDefaultAnnotations(spec argoprojv1a1.ArgoCDIngressSpec, protocol string, sslRedirect bool) map[string]string {
+	protocol = getIngressBackendProtocol(spec, protocol)
+	atns := make(map[string]string)
+
+	switch spec.Controller {
+	case common.ArgoCDIngressControllerTraefik:
+		// Traefik derives the backend protocol from the name of the targeted Service port.
+		atns[common.ArgoCDKeyIngressTraefikRouterTLS] = "true"
+	case common.ArgoCDIngressControllerALB:
+		atns[common.ArgoCDKeyIngressALBBackendProtocol] = "HTTP"
+		if isTLSBackendProtocol(protocol) {
+			atns[common.ArgoCDKeyIngressALBBackendProtocol] = "HTTPS"
+		}
+		if protocol == "GRPC" || protocol == "GRPCS" {
+			atns[common.ArgoCDKeyIngressALBBackendProtocolVersion] = "GRPC"
+		}
+		if sslRedirect {
+			atns[common.ArgoCDKeyIngressALBSSLRedirect] = "443"
+		}
+	default:
+		atns[common.ArgoCDKeyIngressBackendProtocol] = protocol
+		if sslRedirect {
+			atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
 		}
-		return nil // Ingress found and enabled, do nothing
 	}
+	return atns
+}
 
-	if !cr.Spec.Server.Ingress.Enabled {
-		return nil // Ingress not enabled, move along...
+// getArgoServerIngressBackendPort will return the name of the Argo CD Server Service port targeted by the ingress
+// spec. Ingresses with a TLS backend protocol target the https port unless a port is set.
+func getArgoServerIngressBackendPort(spec argoprojv1a1.ArgoCDIngressSpec, port string, protocol string) string {
+	if len(spec.BackendPort) > 0 {
+		return spec.BackendPort
 	}
-
-	// Add default annotations
-	atns := make(map[string]string)
-	atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
-	atns[common.ArgoCDKeyIngressBackendProtocol] = "HTTP"
-
-	// Override default annotations if specified
-	if len(cr.Spec.Server.Ingress.Annotations) > 0 {
-		atns = cr.Spec.Server.Ingress.Annotations
+	if isTLSBackendProtocol(getIngressBackendProtocol(spec, protocol)) {
+		return "https"
 	}
+	return port
+}
 
-	ingress.ObjectMeta.Annotations = atns
+// getArgoServerGRPCIngressProtocol will return the default backend protocol of the Argo CD Server GRPC Ingress. With
+// gRPC-web, the CLI talks HTTP/1.1 to the server, so that the ingress controller does not need gRPC support.
+func getArgoServerGRPCIngressProtocol(cr *argoprojv1a1.ArgoCD) string {
+	if cr.Spec.Server.GRPC.Web {
+		return "HTTPS"
+	}
+	return "GRPC"
+}
 
-	ingress.Spec.IngressClassName = cr.Spec.Server.Ingress.IngressClassName
+// getIngressHosts will return the given host followed by the additional hosts of the ingress spec.
+func getIngressHosts(host string, spec argoprojv1a1.ArgoCDIngressSpec) []string {
+	return append([]string{host}, spec.AdditionalHosts...)
+}
 
+// getIngressPathType will return the path type of the ingress spec, ImplementationSpecific by default.
+func getIngressPathType(spec argoprojv1a1.ArgoCDIngressSpec) *networkingv1.PathType {
 	pathType := networkingv1.PathTypeImplementationSpecific
-	// Add rules
-	ingress.Spec.Rules = []networkingv1.IngressRule{
-		{
-			Host: getArgoServerHost(cr),
+	if spec.PathType != nil {
+		pathType = *spec.PathType
+	}
+	return &pathType
+}
+
+// getIngressRules will return a rule routing the path to the given backend for each of the hosts.
+func getIngressRules(hosts []string, path string, pathType *networkingv1.PathType, backend networkingv1.IngressBackend) []networkingv1.IngressRule {
+	rules := make([]networkingv1.IngressRule, 0, len(hosts))
+	for _, host := range hosts {
+		rules = append(rules, networkingv1.IngressRule{
+			Host: host,
 			IngressRuleValue: networkingv1.IngressRuleValue{
 				HTTP: &networkingv1.HTTPIngressRuleValue{
 					Paths: []networkingv1.HTTPIngressPath{
 						{
-							Path: getPathOrDefault(cr.Spec.Server.Ingress.Path),
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: nameWithSuffix("server", cr),
-									Port: networkingv1.ServiceBackendPort{
-										Name: "http",
-									},
-								},
-							},
-							PathType: &pathType,
+							Path:     path,
+							Backend:  backend,
+							PathType: pathType,
 						},
 					},
 				},
 			},
+		})
+	}
+	return rules
+}
+
+// getIngressServiceBackend will return the backend for the given Service name and port name.
+func getIngressServiceBackend(name string, port string) networkingv1.IngressBackend {
+	return networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: name,
+			Port: networkingv1.ServiceBackendPort{
+				Name: port,
+			},
 		},
 	}
+}
+
+// updateIngress will update the existing Ingress with the desired rules, TLS options, ingress class, annotations
+// and labels. Annotations and labels added by others, e.g. an ingress controller, are preserved.
+func (r *ReconcileArgoCD) updateIngress(existing, desired *networkingv1.Ingress) error {
+	changed := false
+	if existing.ObjectMeta.Annotations == nil {
+		existing.ObjectMeta.Annotations = make(map[string]string)
+	}
+	for k, v := range desired.ObjectMeta.Annotations {
+		if existing.ObjectMeta.Annotations[k] != v {
+			existing.ObjectMeta.Annotations[k] = v
+			changed = true
+		}
+	}
+	if existing.ObjectMeta.Labels == nil {
+		existing.ObjectMeta.Labels = make(map[string]string)
+	}
+	for k, v := range desired.ObjectMeta.Labels {
+		if existing.ObjectMeta.Labels[k] != v {
+			existing.ObjectMeta.Labels[k] = v
+			changed = true
+		}
+	}
+
+	if !reflect.DeepEqual(existing.Spec.IngressClassName, desired.Spec.IngressClassName) {
+		existing.Spec.IngressClassName = desired.Spec.IngressClassName
+		changed = true
+	}
+	if !reflect.DeepEqual(existing.Spec.Rules, desired.Spec.Rules) {
+		existing.Spec.Rules = desired.Spec.Rules
+		changed = true
+	}
+	if !reflect.DeepEqual(existing.Spec.TLS, desired.Spec.TLS) {
+		existing.Spec.TLS = desired.Spec.TLS
+		changed = true
+	}
+
+	if changed {
+		return r.Client.Update(context.TODO(), existing)
+	}
+	return nil // Ingress found with nothing to do, move along...
+}
+
+// reconcileArgoServerIngress will ensure that the ArgoCD Server Ingress is present.
+func (r *ReconcileArgoCD) reconcileArgoServerIngress(cr *argoprojv1a1.ArgoCD) error {
+	ingress := newIngressWithSuffix("server", cr)
+	existing := newIngressWithSuffix("server", cr)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
+
+	if !cr.Spec.Server.Ingress.Enabled {
+		if found {
+			// Ingress exists but enabled flag has been set to false, delete the Ingress
+			return r.Client.Delete(context.TODO(), existing)
+		}
+		return nil // Ingress not enabled, move along...
+	}
+
+	// Add default annotations, merged with the user defined annotations and labels
+	setIngressMetadata(ingress, cr.Spec.Server.Ingress, getIngressDefaultAnnotations(cr.Spec.Server.Ingress, "HTTP", true))
+
+	ingress.Spec.IngressClassName = cr.Spec.Server.Ingress.IngressClassName
+
+	// Add rules
+	ingress.Spec.Rules = getIngressRules(
+		getIngressHosts(getArgoServerHost(cr), cr.Spec.Server.Ingress),
+		getArgoServerIngressPath(cr),
+		getIngressPathType(cr.Spec.Server.Ingress),
+		getIngressServiceBackend(nameWithSuffix("server", cr), getArgoServerIngressBackendPort(cr.Spec.Server.Ingress, "http", "HTTP")),
+	)
 
 	// Add default TLS options
 	ingress.Spec.TLS = []networkingv1.IngressTLS{
 		{
-			Hosts: []string{
-				getArgoServerHost(cr),
-			},
+			Hosts:      getIngressHosts(getArgoServerHost(cr), cr.Spec.Server.Ingress),
 			SecretName: common.ArgoCDSecretName,
 		},
 	}
@@ -159,6 +299,10 @@ func (r *ReconcileArgoCD) reconcileArgoServerIngress(cr *argoprojv1a1.ArgoCD) er
 		ingress.Spec.TLS = cr.Spec.Server.Ingress.TLS
 	}
 
+	if found {
+		return r.updateIngress(existing, ingress)
+	}
+
 	if err := controllerutil.SetControllerReference(cr, ingress, r.Scheme); err != nil {
 		return err
 	}
@@ -168,63 +312,34 @@ func (r *ReconcileArgoCD) reconcileArgoServerIngress(cr *argoprojv1a1.ArgoCD) er
 // reconcileArgoServerGRPCIngress will ensure that the ArgoCD Server GRPC Ingress is present.
 func (r *ReconcileArgoCD) reconcileArgoServerGRPCIngress(cr *argoprojv1a1.ArgoCD) error {
 	ingress := newIngressWithSuffix("grpc", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if !cr.Spec.Server.GRPC.Ingress.Enabled {
-			// Ingress exists but enabled flag has been set to false, delete the Ingress
-			return r.Client.Delete(context.TODO(), ingress)
-		}
-		return nil // Ingress found and enabled, do nothing
-	}
+	existing := newIngressWithSuffix("grpc", cr)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
 
 	if !cr.Spec.Server.GRPC.Ingress.Enabled {
+		if found {
+			// Ingress exists but enabled flag has been set to false, delete the Ingress
+			return r.Client.Delete(context.TODO(), existing)
+		}
 		return nil // Ingress not enabled, move along...
 	}
 
-	// Add default annotations
-	atns := make(map[string]string)
-	atns[common.ArgoCDKeyIngressBackendProtocol] = "GRPC"
-
-	// Override default annotations if specified
-	if len(cr.Spec.Server.GRPC.Ingress.Annotations) > 0 {
-		atns = cr.Spec.Server.GRPC.Ingress.Annotations
-	}
-
-	ingress.ObjectMeta.Annotations = atns
+	// Add default annotations, merged with the user defined annotations and labels
+	setIngressMetadata(ingress, cr.Spec.Server.GRPC.Ingress, getIngressDefaultAnnotations(cr.Spec.Server.GRPC.Ingress, getArgoServerGRPCIngressProtocol(cr), false))
 
 	ingress.Spec.IngressClassName = cr.Spec.Server.GRPC.Ingress.IngressClassName
 
-	pathType := networkingv1.PathTypeImplementationSpecific
 	// Add rules
-	ingress.Spec.Rules = []networkingv1.IngressRule{
-		{
-			Host: getArgoServerGRPCHost(cr),
-			IngressRuleValue: networkingv1.IngressRuleValue{
-				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: []networkingv1.HTTPIngressPath{
-						{
-							Path: getPathOrDefault(cr.Spec.Server.GRPC.Ingress.Path),
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: nameWithSuffix("server", cr),
-									Port: networkingv1.ServiceBackendPort{
-										Name: "https",
-									},
-								},
-							},
-							PathType: &pathType,
-						},
-					},
-				},
-			},
-		},
-	}
+	ingress.Spec.Rules = getIngressRules(
+		getIngressHosts(getArgoServerGRPCHost(cr), cr.Spec.Server.GRPC.Ingress),
+		getPathOrDefault(cr.Spec.Server.GRPC.Ingress.Path),
+		getIngressPathType(cr.Spec.Server.GRPC.Ingress),
+		getIngressServiceBackend(nameWithSuffix("server", cr), getArgoServerIngressBackendPort(cr.Spec.Server.GRPC.Ingress, "https", getArgoServerGRPCIngressProtocol(cr))),
+	)
 
 	// Add TLS options
 	ingress.Spec.TLS = []networkingv1.IngressTLS{
 		{
-			Hosts: []string{
-				getArgoServerGRPCHost(cr),
-			},
+			Hosts:      getIngressHosts(getArgoServerGRPCHost(cr), cr.Spec.Server.GRPC.Ingress),
 			SecretName: common.ArgoCDSecretName,
 		},
 	}
@@ -234,6 +349,14 @@ func (r *ReconcileArgoCD) reconcileArgoServerGRPCIngress(cr *argoprojv1a1.ArgoCD
 		ingress.Spec.TLS = cr.Spec.Server.GRPC.Ingress.TLS
 	}
 
+	if found {
+		// The gRPC protocol version is no longer wanted once switched to gRPC-web
+		if _, ok := ingress.Annotations[common.ArgoCDKeyIngressALBBackendProtocolVersion]; !ok {
+			delete(existing.Annotations, common.ArgoCDKeyIngressALBBackendProtocolVersion)
+		}
+		return r.updateIngress(existing, ingress)
+	}
+
 	if err := controllerutil.SetControllerReference(cr, ingress, r.Scheme); err != nil {
 		return err
 	}
@@ -243,65 +366,34 @@ func (r *ReconcileArgoCD) reconcileArgoServerGRPCIngress(cr *argoprojv1a1.ArgoCD
 // reconcileGrafanaIngress will ensure that the ArgoCD Server GRPC Ingress is present.
 func (r *ReconcileArgoCD) reconcileGrafanaIngress(cr *argoprojv1a1.ArgoCD) error {
 	ingress := newIngressWithSuffix("grafana", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if !cr.Spec.Grafana.Enabled || !cr.Spec.Grafana.Ingress.Enabled {
-			// Ingress exists but enabled flag has been set to false, delete the Ingress
-			return r.Client.Delete(context.TODO(), ingress)
-		}
-		return nil // Ingress found and enabled, do nothing
-	}
+	existing := newIngressWithSuffix("grafana", cr)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
 
 	if !cr.Spec.Grafana.Enabled || !cr.Spec.Grafana.Ingress.Enabled {
+		if found {
+			// Ingress exists but enabled flag has been set to false, delete the Ingress
+			return r.Client.Delete(context.TODO(), existing)
+		}
 		return nil // Grafana itself or Ingress not enabled, move along...
 	}
 
-	// Add default annotations
-	atns := make(map[string]string)
-	atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
-	atns[common.ArgoCDKeyIngressBackendProtocol] = "HTTP"
-
-	// Override default annotations if specified
-	if len(cr.Spec.Grafana.Ingress.Annotations) > 0 {
-		atns = cr.Spec.Grafana.Ingress.Annotations
-	}
-
-	ingress.ObjectMeta.Annotations = atns
+	// Add default annotations, merged with the user defined annotations and labels
+	setIngressMetadata(ingress, cr.Spec.Grafana.Ingress, getIngressDefaultAnnotations(cr.Spec.Grafana.Ingress, "HTTP", true))
 
 	ingress.Spec.IngressClassName = cr.Spec.Grafana.Ingress.IngressClassName
 
-	pathType := networkingv1.PathTypeImplementationSpecific
 	// Add rules
-	ingress.Spec.Rules = []networkingv1.IngressRule{
-		{
-			Host: getGrafanaHost(cr),
-			IngressRuleValue: networkingv1.IngressRuleValue{
-				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: []networkingv1.HTTPIngressPath{
-						{
-							Path: getPathOrDefault(cr.Spec.Grafana.Ingress.Path),
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: nameWithSuffix("grafana", cr),
-									Port: networkingv1.ServiceBackendPort{
-										Name: "http",
-									},
-								},
-							},
-							PathType: &pathType,
-						},
-					},
-				},
-			},
-		},
-	}
+	ingress.Spec.Rules = getIngressRules(
+		getIngressHosts(getGrafanaHost(cr), cr.Spec.Grafana.Ingress),
+		getPathOrDefault(cr.Spec.Grafana.Ingress.Path),
+		getIngressPathType(cr.Spec.Grafana.Ingress),
+		getIngressServiceBackend(nameWithSuffix("grafana", cr), "http"),
+	)
 
 	// Add TLS options
 	ingress.Spec.TLS = []networkingv1.IngressTLS{
 		{
-			Hosts: []string{
-				cr.Name,
-				getGrafanaHost(cr),
-			},
+			Hosts:      append([]string{cr.Name}, getIngressHosts(getGrafanaHost(cr), cr.Spec.Grafana.Ingress)...),
 			SecretName: common.ArgoCDSecretName,
 		},
 	}
@@ -311,6 +403,10 @@ func (r *ReconcileArgoCD) reconcileGrafanaIngress(cr *argoprojv1a1.ArgoCD) error
 		ingress.Spec.TLS = cr.Spec.Grafana.Ingress.TLS
 	}
 
+	if found {
+		return r.updateIngress(existing, ingress)
+	}
+
 	if err := controllerutil.SetControllerReference(cr, ingress, r.Scheme); err != nil {
 		return err
 	}
@@ -320,57 +416,29 @@ func (r *ReconcileArgoCD) reconcileGrafanaIngress(cr *argoprojv1a1.ArgoCD) error
 // reconcilePrometheusIngress will ensure that the Prometheus Ingress is present.
 func (r *ReconcileArgoCD) reconcilePrometheusIngress(cr *argoprojv1a1.ArgoCD) error {
 	ingress := newIngressWithSuffix("prometheus", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if !cr.Spec.Prometheus.Enabled || !cr.Spec.Prometheus.Ingress.Enabled {
-			// Ingress exists but enabled flag has been set to false, delete the Ingress
-			return r.Client.Delete(context.TODO(), ingress)
-		}
-		return nil // Ingress found and enabled, do nothing
-	}
+	existing := newIngressWithSuffix("prometheus", cr)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
 
 	if !cr.Spec.Prometheus.Enabled || !cr.Spec.Prometheus.Ingress.Enabled {
+		if found {
+			// Ingress exists but enabled flag has been set to false, delete the Ingress
+			return r.Client.Delete(context.TODO(), existing)
+		}
 		return nil // Prometheus itself or Ingress not enabled, move along...
 	}
 
-	// Add default annotations
-	atns := make(map[string]string)
-	atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
-	atns[common.ArgoCDKeyIngressBackendProtocol] = "HTTP"
-
-	// Override default annotations if specified
-	if len(cr.Spec.Prometheus.Ingress.Annotations) > 0 {
-		atns = cr.Spec.Prometheus.Ingress.Annotations
-	}
-
-	ingress.ObjectMeta.Annotations = atns
+	// Add default annotations, merged with the user defined annotations and labels
+	setIngressMetadata(ingress, cr.Spec.Prometheus.Ingress, getIngressDefaultAnnotations(cr.Spec.Prometheus.Ingress, "HTTP", true))
 
 	ingress.Spec.IngressClassName = cr.Spec.Prometheus.Ingress.IngressClassName
 
-	pathType := networkingv1.PathTypeImplementationSpecific
 	// Add rules
-	ingress.Spec.Rules = []networkingv1.IngressRule{
-		{
-			Host: getPrometheusHost(cr),
-			IngressRuleValue: networkingv1.IngressRuleValue{
-				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: []networkingv1.HTTPIngressPath{
-						{
-							Path: getPathOrDefault(cr.Spec.Prometheus.Ingress.Path),
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: "prometheus-operated",
-									Port: networkingv1.ServiceBackendPort{
-										Name: "web",
-									},
-								},
-							},
-							PathType: &pathType,
-						},
-					},
-				},
-			},
-		},
-	}
+	ingress.Spec.Rules = getIngressRules(
+		getIngressHosts(getPrometheusHost(cr), cr.Spec.Prometheus.Ingress),
+		getPathOrDefault(cr.Spec.Prometheus.Ingress.Path),
+		getIngressPathType(cr.Spec.Prometheus.Ingress),
+		getIngressServiceBackend("prometheus-operated", "web"),
+	)
 
 	// Add TLS options
 	ingress.Spec.TLS = []networkingv1.IngressTLS{
@@ -385,6 +453,10 @@ func (r *ReconcileArgoCD) reconcilePrometheusIngress(cr *argoprojv1a1.ArgoCD) er
 		ingress.Spec.TLS = cr.Spec.Prometheus.Ingress.TLS
 	}
 
+	if found {
+		return r.updateIngress(existing, ingress)
+	}
+
 	if err := controllerutil.SetControllerReference(cr, ingress, r.Scheme); err != nil {
 		return err
 	}
@@ -394,53 +466,35 @@ func (r *ReconcileArgoCD) reconcilePrometheusIngress(cr *argoprojv1a1.ArgoCD) er
 // reconcileApplicationSetControllerIngress will ensure that the ApplicationSetController Ingress is present.
 func (r *ReconcileArgoCD) reconcileApplicationSetControllerIngress(cr *argoprojv1a1.ArgoCD) error {
 	ingress := newIngressWithSuffix(common.ApplicationSetServiceNameSuffix, cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.WebhookServer.Ingress.Enabled {
-			return r.Client.Delete(context.TODO(), ingress)
-		}
-		return nil // Ingress found and enabled, do nothing
-	}
+	existing := newIngressWithSuffix(common.ApplicationSetServiceNameSuffix, cr)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
 
 	if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.WebhookServer.Ingress.Enabled {
+		if found {
+			return r.Client.Delete(context.TODO(), existing)
+		}
 		log.Info("not enabled")
 		return nil // Ingress not enabled, move along...
 	}
 
-	// Add annotations
-	atns := make(map[string]string)
-	atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
-	atns[common.ArgoCDKeyIngressBackendProtocol] = "HTTP"
+	// Add default annotations, merged with the user defined annotations and labels
+	setIngressMetadata(ingress, cr.Spec.ApplicationSet.WebhookServer.Ingress, getIngressDefaultAnnotations(cr.Spec.ApplicationSet.WebhookServer.Ingress, "HTTP", true))
 
-	// Override default annotations if specified
-	if len(cr.Spec.ApplicationSet.WebhookServer.Ingress.Annotations) > 0 {
-		atns = cr.Spec.ApplicationSet.WebhookServer.Ingress.Annotations
-	}
+	ingress.Spec.IngressClassName = cr.Spec.ApplicationSet.WebhookServer.Ingress.IngressClassName
 
-	ingress.ObjectMeta.Annotations = atns
-
-	pathType := networkingv1.PathTypeImplementationSpecific
 	// Add rules
-	ingress.Spec.Rules = []networkingv1.IngressRule{
+	ingress.Spec.Rules = getIngressRules(
+		getIngressHosts(getApplicationSetHTTPServerHost(cr), cr.Spec.ApplicationSet.WebhookServer.Ingress),
+		getApplicationSetWebhookIngressPath(cr),
+		getIngressPathType(cr.Spec.ApplicationSet.WebhookServer.Ingress),
+		getIngressServiceBackend(nameWithSuffix(common.ApplicationSetServiceNameSuffix, cr), "webhook"),
+	)
+
+	// Add default TLS options
+	ingress.Spec.TLS = []networkingv1.IngressTLS{
 		{
-			Host: getApplicationSetHTTPServerHost(cr),
-			IngressRuleValue: networkingv1.IngressRuleValue{
-				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: []networkingv1.HTTPIngressPath{
-						{
-							Path: "/api/webhook",
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: nameWithSuffix(common.ApplicationSetServiceNameSuffix, cr),
-									Port: networkingv1.ServiceBackendPort{
-										Name: "webhook",
-									},
-								},
-							},
-							PathType: &pathType,
-						},
-					},
-				},
-			},
+			Hosts:      getIngressHosts(getApplicationSetHTTPServerHost(cr), cr.Spec.ApplicationSet.WebhookServer.Ingress),
+			SecretName: common.ArgoCDSecretName,
 		},
 	}
 
@@ -449,6 +503,10 @@ func (r *ReconcileArgoCD) reconcileApplicationSetControllerIngress(cr *argoprojv
 		ingress.Spec.TLS = cr.Spec.ApplicationSet.WebhookServer.Ingress.TLS
 	}
 
+	if found {
+		return r.updateIngress(existing, ingress)
+	}
+
 	if err := controllerutil.SetControllerReference(cr, ingress, r.Scheme); err != nil {
 		return err
 	}
@@ -200,3 +200,211 @@ func TestReconcileApplicationSetService_Ingress(t *testing.T) {
 	assert.NoError(t, r.reconcileApplicationSetControllerIngress(a))
 	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}, ingress))
 }
+
+func TestReconcileApplicationSetService_Ingress_options(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	ingressClassName := "nginx"
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.ApplicationSet = &argoprojv1alpha1.ArgoCDApplicationSet{
+			WebhookServer: argoprojv1alpha1.WebhookServerSpec{
+				Host: "webhook.example.com",
+				Ingress: argoprojv1alpha1.ArgoCDIngressSpec{
+					Enabled:          true,
+					IngressClassName: &ingressClassName,
+					AdditionalHosts:  []string{"webhook.example.org"},
+					Annotations: map[string]string{
+						"cert-manager.io/cluster-issuer": "letsencrypt",
+					},
+				},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileApplicationSetControllerIngress(a))
+
+	ingress := &networkingv1.Ingress{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-" + common.ApplicationSetServiceNameSuffix, Namespace: testNamespace}, ingress))
+	assert.Equal(t, map[string]string{
+		common.ArgoCDKeyIngressSSLRedirect:     "true",
+		common.ArgoCDKeyIngressBackendProtocol: "HTTP",
+		"cert-manager.io/cluster-issuer":       "letsencrypt",
+	}, ingress.Annotations)
+	assert.Equal(t, &ingressClassName, ingress.Spec.IngressClassName)
+	assert.Len(t, ingress.Spec.Rules, 2)
+	assert.Equal(t, "webhook.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "/api/webhook", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, []networkingv1.IngressTLS{{
+		Hosts:      []string{"webhook.example.com", "webhook.example.org"},
+		SecretName: common.ArgoCDSecretName,
+	}}, ingress.Spec.TLS)
+
+	// the TLS options and the path can be overridden
+	a.Spec.ApplicationSet.WebhookServer.Ingress.Path = "/webhook"
+	a.Spec.ApplicationSet.WebhookServer.Ingress.TLS = []networkingv1.IngressTLS{{
+		Hosts:      []string{"webhook.example.com"},
+		SecretName: "webhook-tls",
+	}}
+	assert.NoError(t, r.reconcileApplicationSetControllerIngress(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-" + common.ApplicationSetServiceNameSuffix, Namespace: testNamespace}, ingress))
+	assert.Equal(t, "/webhook", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, a.Spec.ApplicationSet.WebhookServer.Ingress.TLS, ingress.Spec.TLS)
+}
+
+func TestReconcileArgoCD_reconcile_ServerIngress_metadata(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Ingress.Enabled = true
+		a.Spec.Server.Ingress.Annotations = map[string]string{
+			common.ArgoCDKeyIngressBackendProtocol:      "HTTPS",
+			"external-dns.alpha.kubernetes.io/hostname": "argocd.example.com",
+		}
+		a.Spec.Server.Ingress.Labels = map[string]string{
+			"team":               "platform",
+			common.ArgoCDKeyName: "overridden",
+		}
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+
+	ingress := &networkingv1.Ingress{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, ingress))
+	assert.Equal(t, map[string]string{
+		common.ArgoCDKeyIngressSSLRedirect:          "true",
+		common.ArgoCDKeyIngressBackendProtocol:      "HTTPS",
+		"external-dns.alpha.kubernetes.io/hostname": "argocd.example.com",
+	}, ingress.Annotations)
+	assert.Equal(t, "platform", ingress.Labels["team"])
+	assert.Equal(t, "argocd-server", ingress.Labels[common.ArgoCDKeyName])
+
+	// Annotations added by others are preserved, user defined annotations are reconciled
+	ingress.Annotations["alb.ingress.kubernetes.io/target-type"] = "ip"
+	ingress.Annotations["external-dns.alpha.kubernetes.io/hostname"] = "changed.example.com"
+	assert.NoError(t, r.Client.Update(context.TODO(), ingress))
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, ingress))
+	assert.Equal(t, "ip", ingress.Annotations["alb.ingress.kubernetes.io/target-type"])
+	assert.Equal(t, "argocd.example.com", ingress.Annotations["external-dns.alpha.kubernetes.io/hostname"])
+}
+
+func TestReconcileArgoCD_reconcile_GrafanaIngress_disableDefaultAnnotations(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Grafana.Enabled = true
+		a.Spec.Grafana.Ingress.Enabled = true
+		a.Spec.Grafana.Ingress.DisableDefaultAnnotations = true
+		a.Spec.Grafana.Ingress.Annotations = map[string]string{
+			"traefik.ingress.kubernetes.io/router.tls": "true",
+		}
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileGrafanaIngress(a))
+
+	ingress := &networkingv1.Ingress{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-grafana", Namespace: testNamespace}, ingress))
+	assert.Equal(t, map[string]string{
+		"traefik.ingress.kubernetes.io/router.tls": "true",
+	}, ingress.Annotations)
+}
+
+func TestReconcileArgoCD_reconcile_ServerIngress_multipleHosts(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	prefix := networkingv1.PathTypePrefix
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Host = "argocd.example.com"
+		a.Spec.Server.Ingress.Enabled = true
+		a.Spec.Server.Ingress.AdditionalHosts = []string{"argocd.example.org"}
+		a.Spec.Server.Ingress.PathType = &prefix
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+
+	ingress := &networkingv1.Ingress{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, ingress))
+	assert.Len(t, ingress.Spec.Rules, 2)
+	assert.Equal(t, "argocd.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "argocd.example.org", ingress.Spec.Rules[1].Host)
+	assert.Equal(t, &prefix, ingress.Spec.Rules[1].HTTP.Paths[0].PathType)
+	assert.Equal(t, "argocd-server", ingress.Spec.Rules[1].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, []string{"argocd.example.com", "argocd.example.org"}, ingress.Spec.TLS[0].Hosts)
+
+	// Changes to the hosts and TLS options are reconciled
+	a.Spec.Server.Ingress.AdditionalHosts = nil
+	a.Spec.Server.Ingress.TLS = []networkingv1.IngressTLS{
+		{Hosts: []string{"argocd.example.com"}, SecretName: "argocd-example-com"},
+		{Hosts: []string{"argocd.internal"}, SecretName: "argocd-internal"},
+	}
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, ingress))
+	assert.Len(t, ingress.Spec.Rules, 1)
+	assert.Equal(t, a.Spec.Server.Ingress.TLS, ingress.Spec.TLS)
+}
+
+func TestReconcileArgoCD_reconcile_ServerIngress_rootPath(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Ingress.Enabled = true
+		a.Spec.Server.RootPath = "/argocd"
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+
+	ingress := &networkingv1.Ingress{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, ingress))
+	assert.Equal(t, "/argocd", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+
+	// An explicit Ingress path takes precedence over the root path
+	prefix := networkingv1.PathTypePrefix
+	a.Spec.Server.Ingress.Path = "/argocd(/|$)(.*)"
+	a.Spec.Server.Ingress.PathType = &prefix
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, ingress))
+	assert.Equal(t, "/argocd(/|$)(.*)", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, &prefix, ingress.Spec.Rules[0].HTTP.Paths[0].PathType)
+}
+
+func TestReconcileArgoCD_reconcile_ServerIngress_backendProtocol(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Ingress.Enabled = true
+		a.Spec.Server.Ingress.BackendProtocol = "HTTPS"
+		a.Spec.Server.GRPC.Ingress.Enabled = true
+		a.Spec.Server.GRPC.Ingress.Controller = common.ArgoCDIngressControllerALB
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+	assert.NoError(t, r.reconcileArgoServerGRPCIngress(a))
+
+	ingress := &networkingv1.Ingress{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, ingress))
+	assert.Equal(t, "HTTPS", ingress.Annotations[common.ArgoCDKeyIngressBackendProtocol])
+	assert.Equal(t, "https", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-grpc", Namespace: testNamespace}, ingress))
+	assert.Equal(t, map[string]string{
+		common.ArgoCDKeyIngressALBBackendProtocol:        "HTTP",
+		common.ArgoCDKeyIngressALBBackendProtocolVersion: "GRPC",
+	}, ingress.Annotations)
+	assert.Equal(t, "https", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+
+	// With gRPC-web, the GRPC Ingress is served over HTTPS
+	a.Spec.Server.GRPC.Web = true
+	assert.NoError(t, r.reconcileArgoServerGRPCIngress(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-grpc", Namespace: testNamespace}, ingress))
+	assert.Equal(t, map[string]string{
+		common.ArgoCDKeyIngressALBBackendProtocol: "HTTPS",
+	}, ingress.Annotations)
+	assert.Equal(t, "https", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+
+	// The targeted port can be selected explicitly
+	a.Spec.Server.Ingress.Controller = common.ArgoCDIngressControllerTraefik
+	a.Spec.Server.Ingress.BackendPort = "http"
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, ingress))
+	assert.Equal(t, "true", ingress.Annotations[common.ArgoCDKeyIngressTraefikRouterTLS])
+	assert.Equal(t, "http", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+}
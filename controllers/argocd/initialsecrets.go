@@ -0,0 +1,289 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	initialSecretTypeRepository            = "repository"
+	initialSecretTypeRepositoryCredentials = "repo-creds"
+	initialSecretTypeCluster               = "cluster"
+)
+
+// newInitialSecret returns a new Secret of the given Argo CD secret type for the entry with the given name.
+func newInitialSecret(secretType string, name string, cr *argoprojv1a1.ArgoCD) *corev1.Secret {
+	secret := argoutil.NewSecretWithSuffix(cr, fmt.Sprintf("%s-%s", secretType, name))
+	secret.Labels[common.ArgoCDSecretTypeLabel] = secretType
+	return secret
+}
+
+// getInitialSecretCredentials returns the data of the credentials Secret with the given name in the namespace of the
+// given ArgoCD. The returned bool is false if the Secret does not exist.
+func (r *ReconcileArgoCD) getInitialSecretCredentials(name string, cr *argoprojv1a1.ArgoCD) (map[string][]byte, bool) {
+	data := map[string][]byte{}
+	if name == "" {
+		return data, true
+	}
+
+	credentials := &corev1.Secret{}
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, name, credentials) {
+		return nil, false
+	}
+	for key, value := range credentials.Data {
+		data[key] = value
+	}
+	return data, true
+}
+
+// getRepositorySecretData returns the desired data of the repository secret for the given repository.
+func getRepositorySecretData(repo argoprojv1a1.ArgoCDRepositorySpec, credentials map[string][]byte) map[string][]byte {
+	data := credentials
+	data["name"] = []byte(repo.Name)
+	data["url"] = []byte(repo.URL)
+	data["type"] = []byte("git")
+	if repo.Type != "" {
+		data["type"] = []byte(repo.Type)
+	}
+	if repo.Project != "" {
+		data["project"] = []byte(repo.Project)
+	}
+	return data
+}
+
+// getRepositoryCredentialsSecretRefs returns the keys of the repository credentials secret for the given credential
+// template that are sourced from secret key refs, mapped to the Secret keys they are sourced from.
+func getRepositoryCredentialsSecretRefs(creds argoprojv1a1.ArgoCDRepositoryCredentialsSpec) map[string]corev1.SecretKeySelector {
+	refs := make(map[string]corev1.SecretKeySelector)
+	if creds.GitHubApp != nil {
+		refs["githubAppPrivateKey"] = creds.GitHubApp.PrivateKeySecretRef
+	}
+	if creds.GoogleCloudSource != nil {
+		refs["gcpServiceAccountKey"] = creds.GoogleCloudSource.ServiceAccountKeySecretRef
+	}
+	return refs
+}
+
+// validateRepositoryCredentials returns an error if the given credential template authenticates with more than one
+// provider, or with a provider that does not support its type.
+func validateRepositoryCredentials(creds argoprojv1a1.ArgoCDRepositoryCredentialsSpec) error {
+	if creds.GitHubApp != nil && creds.GoogleCloudSource != nil {
+		return fmt.Errorf("githubApp and googleCloudSource are mutually exclusive")
+	}
+	if (creds.GitHubApp != nil || creds.GoogleCloudSource != nil) && creds.Type == "helm" {
+		return fmt.Errorf("githubApp and googleCloudSource are only supported for git repositories")
+	}
+	return nil
+}
+
+// getRepositoryCredentialsSecretData returns the desired data of the repository credentials secret for the given
+// credential template.
+func getRepositoryCredentialsSecretData(creds argoprojv1a1.ArgoCDRepositoryCredentialsSpec, credentials map[string][]byte) map[string][]byte {
+	data := credentials
+	data["url"] = []byte(creds.URL)
+	data["type"] = []byte("git")
+	if creds.Type != "" {
+		data["type"] = []byte(creds.Type)
+	}
+	if creds.GitHubApp != nil {
+		addGitHubAppSecretData(creds.GitHubApp, data)
+	}
+	return data
+}
+
+// addGitHubAppSecretData adds the keys identifying the given GitHub App and its installation to the given data of a
+// Secret in the repo-creds format.
+func addGitHubAppSecretData(app *argoprojv1a1.ArgoCDGitHubAppCredentialsSpec, data map[string][]byte) {
+	data["githubAppID"] = []byte(strconv.FormatInt(app.AppID, 10))
+	data["githubAppInstallationID"] = []byte(strconv.FormatInt(app.InstallationID, 10))
+	if app.EnterpriseBaseURL != "" {
+		data["githubAppEnterpriseBaseUrl"] = []byte(app.EnterpriseBaseURL)
+	}
+}
+
+// addInitialSecretKeyRefs adds the values of the given secret key refs to the given credentials, by the keys they are
+// mapped to. The returned error names the first Secret or key that does not exist.
+func (r *ReconcileArgoCD) addInitialSecretKeyRefs(refs map[string]corev1.SecretKeySelector, credentials map[string][]byte, cr *argoprojv1a1.ArgoCD) error {
+	for key, ref := range refs {
+		source := &corev1.Secret{}
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, ref.Name, source) {
+			return fmt.Errorf("secret %s not found", ref.Name)
+		}
+		value, ok := source.Data[ref.Key]
+		if !ok {
+			return fmt.Errorf("key %s of secret %s not found", ref.Key, ref.Name)
+		}
+		credentials[key] = value
+	}
+	return nil
+}
+
+// getClusterSecretData returns the desired data of the cluster secret for the given cluster.
+func getClusterSecretData(cluster argoprojv1a1.ArgoCDClusterSpec, credentials map[string][]byte) map[string][]byte {
+	data := credentials
+	data["name"] = []byte(cluster.Name)
+	data["server"] = []byte(cluster.Server)
+	if len(cluster.Namespaces) > 0 {
+		data["namespaces"] = []byte(strings.Join(cluster.Namespaces, ","))
+	}
+	if cluster.Project != "" {
+		data["project"] = []byte(cluster.Project)
+	}
+	if _, ok := data["config"]; !ok {
+		data["config"], _ = json.Marshal(getClusterConfig(cluster))
+	}
+	return data
+}
+
+// reconcileInitialSecrets will ensure that the repository, repository credentials and cluster secrets for the
+// repositories, credential templates and clusters listed in the spec of the given ArgoCD are present and up to date,
+// and that secrets the operator created for it, but that are no longer listed, are removed.
+func (r *ReconcileArgoCD) reconcileInitialSecrets(cr *argoprojv1a1.ArgoCD) error {
+	desired := make(map[string]bool)
+
+	for _, repo := range cr.Spec.InitialRepositorySecrets {
+		secret := newInitialSecret(initialSecretTypeRepository, repo.Name, cr)
+		desired[secret.Name] = true
+
+		credentials, found := r.getInitialSecretCredentials(repo.CredentialsSecret, cr)
+		if !found {
+			log.Info(fmt.Sprintf("credentials secret %s for repository %s not found, skipping", repo.CredentialsSecret, repo.Name))
+			continue
+		}
+		secret.Data = getRepositorySecretData(repo, credentials)
+		if err := r.reconcileInitialSecret(secret, cr); err != nil {
+			return err
+		}
+	}
+
+	for _, creds := range cr.Spec.InitialRepositoryCredentials {
+		secret := newInitialSecret(initialSecretTypeRepositoryCredentials, creds.Name, cr)
+		desired[secret.Name] = true
+
+		if err := validateRepositoryCredentials(creds); err != nil {
+			log.Info(fmt.Sprintf("invalid repository credentials %s, skipping: %v", creds.Name, err))
+			continue
+		}
+		credentials, found := r.getInitialSecretCredentials(creds.CredentialsSecret, cr)
+		if !found {
+			log.Info(fmt.Sprintf("credentials secret %s for repository credentials %s not found, skipping", creds.CredentialsSecret, creds.Name))
+			continue
+		}
+		if err := r.addInitialSecretKeyRefs(getRepositoryCredentialsSecretRefs(creds), credentials, cr); err != nil {
+			log.Info(fmt.Sprintf("credentials for repository credentials %s not found, skipping: %v", creds.Name, err))
+			continue
+		}
+		secret.Data = getRepositoryCredentialsSecretData(creds, credentials)
+		if err := r.reconcileInitialSecret(secret, cr); err != nil {
+			return err
+		}
+	}
+
+	for _, cluster := range cr.Spec.InitialClusters {
+		// The in-cluster secret is managed by reconcileClusterPermissionsSecret.
+		if cluster.Server == common.ArgoCDDefaultServer {
+			log.Info(fmt.Sprintf("cluster %s uses the in-cluster server address, skipping", cluster.Name))
+			continue
+		}
+
+		secret := newInitialSecret(initialSecretTypeCluster, cluster.Name, cr)
+		desired[secret.Name] = true
+
+		credentials, found := r.getInitialSecretCredentials(cluster.CredentialsSecret, cr)
+		if !found {
+			log.Info(fmt.Sprintf("credentials secret %s for cluster %s not found, skipping", cluster.CredentialsSecret, cluster.Name))
+			continue
+		}
+		if err := validateClusterExecProvider(cluster); err != nil {
+			log.Info(fmt.Sprintf("invalid exec provider for cluster %s, skipping: %v", cluster.Name, err))
+			continue
+		}
+		secret.Data = getClusterSecretData(cluster, credentials)
+		if err := r.reconcileInitialSecret(secret, cr); err != nil {
+			return err
+		}
+	}
+
+	for _, secretType := range []string{initialSecretTypeRepository, initialSecretTypeRepositoryCredentials, initialSecretTypeCluster} {
+		secrets := &corev1.SecretList{}
+		opts := []client.ListOption{
+			client.InNamespace(cr.Namespace),
+			client.MatchingLabels{common.ArgoCDSecretTypeLabel: secretType},
+		}
+		if err := r.Client.List(context.TODO(), secrets, opts...); err != nil {
+			return err
+		}
+
+		for i := range secrets.Items {
+			secret := &secrets.Items[i]
+			// Only remove secrets that were created for the initial repositories and clusters.
+			if desired[secret.Name] || !metav1.IsControlledBy(secret, cr) ||
+				!strings.HasPrefix(secret.Name, fmt.Sprintf("%s-%s-", cr.Name, secretType)) {
+				continue
+			}
+			log.Info(fmt.Sprintf("deleting %s secret %s as it is no longer listed in the spec", secretType, secret.Name))
+			if err := r.Client.Delete(context.TODO(), secret); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// reconcileInitialSecret will ensure that the given repository or cluster secret is present with the desired data.
+// Secrets that exist, but were not created by the operator, are left untouched.
+func (r *ReconcileArgoCD) reconcileInitialSecret(secret *corev1.Secret, cr *argoprojv1a1.ArgoCD) error {
+	existing := &corev1.Secret{}
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, existing) {
+		if !metav1.IsControlledBy(existing, cr) {
+			log.Info(fmt.Sprintf("secret %s is not managed by Argo CD instance %s, skipping", existing.Name, cr.Name))
+			return nil
+		}
+
+		// The shard is assigned separately, see reconcileClusterShardAssignment.
+		if shard, ok := existing.Data[common.ArgoCDKeyClusterShard]; ok {
+			secret.Data[common.ArgoCDKeyClusterShard] = shard
+		}
+
+		if !reflect.DeepEqual(existing.Data, secret.Data) || !reflect.DeepEqual(existing.Labels, secret.Labels) {
+			existing.Data = secret.Data
+			existing.Labels = secret.Labels
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // Secret found with nothing to do, move along...
+	}
+
+	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("creating secret %s for Argo CD instance %s in namespace %s", secret.Name, cr.Name, cr.Namespace))
+	return r.Client.Create(context.TODO(), secret)
+}
@@ -0,0 +1,297 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcileArgoCD_reconcileInitialSecrets(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.InitialRepositorySecrets = []argoprojv1alpha1.ArgoCDRepositorySpec{
+			{Name: "private", URL: "https://github.com/example/private", CredentialsSecret: "repo-credentials"},
+			{Name: "charts", URL: "https://charts.example.com", Type: "helm", Project: "team-a"},
+		}
+		a.Spec.InitialClusters = []argoprojv1alpha1.ArgoCDClusterSpec{
+			{Name: "staging", Server: "https://staging.example.com", Namespaces: []string{"team-a", "team-b"}, CredentialsSecret: "cluster-credentials"},
+			{Name: "local", Server: common.ArgoCDDefaultServer},
+		}
+	})
+	repoCredentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "repo-credentials", Namespace: a.Namespace},
+		Data: map[string][]byte{
+			"username": []byte("user"),
+			"password": []byte("pass"),
+		},
+	}
+	clusterCredentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-credentials", Namespace: a.Namespace},
+		Data: map[string][]byte{
+			"config": []byte(`{"bearerToken":"token"}`),
+		},
+	}
+	r := makeTestReconciler(t, a, repoCredentials, clusterCredentials)
+
+	assert.NoError(t, r.reconcileInitialSecrets(a))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repository-private", Namespace: a.Namespace}, secret))
+	assert.True(t, metav1.IsControlledBy(secret, a))
+	assert.Equal(t, "repository", secret.Labels[common.ArgoCDSecretTypeLabel])
+	assert.Equal(t, map[string][]byte{
+		"name":     []byte("private"),
+		"url":      []byte("https://github.com/example/private"),
+		"type":     []byte("git"),
+		"username": []byte("user"),
+		"password": []byte("pass"),
+	}, secret.Data)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repository-charts", Namespace: a.Namespace}, secret))
+	assert.Equal(t, "helm", string(secret.Data["type"]))
+	assert.Equal(t, "team-a", string(secret.Data["project"]))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster-staging", Namespace: a.Namespace}, secret))
+	assert.Equal(t, "cluster", secret.Labels[common.ArgoCDSecretTypeLabel])
+	assert.Equal(t, map[string][]byte{
+		"name":       []byte("staging"),
+		"server":     []byte("https://staging.example.com"),
+		"namespaces": []byte("team-a,team-b"),
+		"config":     []byte(`{"bearerToken":"token"}`),
+	}, secret.Data)
+
+	// the in-cluster server is managed by the cluster permissions secret
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster-local", Namespace: a.Namespace}, secret))
+
+	// changes to the credentials are picked up
+	repoCredentials.Data["password"] = []byte("changed")
+	assert.NoError(t, r.Client.Update(context.TODO(), repoCredentials))
+	assert.NoError(t, r.reconcileInitialSecrets(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repository-private", Namespace: a.Namespace}, secret))
+	assert.Equal(t, "changed", string(secret.Data["password"]))
+
+	// entries removed from the spec are deleted
+	a.Spec.InitialRepositorySecrets = a.Spec.InitialRepositorySecrets[:1]
+	a.Spec.InitialClusters = nil
+	assert.NoError(t, r.reconcileInitialSecrets(a))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repository-charts", Namespace: a.Namespace}, secret))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster-staging", Namespace: a.Namespace}, secret))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repository-private", Namespace: a.Namespace}, secret))
+}
+
+func TestReconcileArgoCD_reconcileInitialSecrets_defaultClusterConfig(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.InitialClusters = []argoprojv1alpha1.ArgoCDClusterSpec{
+			{Name: "staging", Server: "https://staging.example.com"},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileInitialSecrets(a))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster-staging", Namespace: a.Namespace}, secret))
+	assert.JSONEq(t, `{"tlsClientConfig":{"insecure":false}}`, string(secret.Data["config"]))
+	_, ok := secret.Data["namespaces"]
+	assert.False(t, ok)
+}
+
+func TestReconcileArgoCD_reconcileInitialSecrets_missingCredentials(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.InitialRepositorySecrets = []argoprojv1alpha1.ArgoCDRepositorySpec{
+			{Name: "private", URL: "https://github.com/example/private", CredentialsSecret: "missing"},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileInitialSecrets(a))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repository-private", Namespace: a.Namespace}, &corev1.Secret{}))
+}
+
+func TestReconcileArgoCD_reconcileInitialSecrets_execProvider(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.InitialClusters = []argoprojv1alpha1.ArgoCDClusterSpec{
+			{
+				Name:   "eks",
+				Server: "https://eks.example.com",
+				ExecProvider: &argoprojv1alpha1.ArgoCDClusterExecProviderSpec{
+					Provider:    "aws",
+					CAData:      "Y2EK",
+					ClusterName: "production",
+					RoleARN:     "arn:aws:iam::123456789012:role/argocd",
+				},
+			},
+			{
+				Name:   "aks",
+				Server: "https://aks.example.com",
+				ExecProvider: &argoprojv1alpha1.ArgoCDClusterExecProviderSpec{
+					Provider: "azure",
+					ClientID: "client-id",
+					TenantID: "tenant-id",
+				},
+			},
+			{
+				Name:   "gke",
+				Server: "https://gke.example.com",
+				ExecProvider: &argoprojv1alpha1.ArgoCDClusterExecProviderSpec{
+					Provider: "gcp",
+					Command:  "gke-gcloud-auth-plugin",
+				},
+			},
+			{
+				Name:         "invalid",
+				Server:       "https://invalid.example.com",
+				ExecProvider: &argoprojv1alpha1.ArgoCDClusterExecProviderSpec{Provider: "aws"},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileInitialSecrets(a))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster-eks", Namespace: a.Namespace}, secret))
+	assert.JSONEq(t, `{
+		"awsAuthConfig":{"clusterName":"production","roleARN":"arn:aws:iam::123456789012:role/argocd"},
+		"tlsClientConfig":{"insecure":false,"caData":"Y2EK"}
+	}`, string(secret.Data["config"]))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster-aks", Namespace: a.Namespace}, secret))
+	assert.JSONEq(t, `{
+		"execProviderConfig":{
+			"command":"argocd-k8s-auth",
+			"args":["azure"],
+			"apiVersion":"client.authentication.k8s.io/v1beta1",
+			"env":{
+				"AAD_ENVIRONMENT_NAME":"AzurePublicCloud",
+				"AAD_LOGIN_METHOD":"workloadidentity",
+				"AZURE_AUTHORITY_HOST":"https://login.microsoftonline.com/",
+				"AZURE_CLIENT_ID":"client-id",
+				"AZURE_FEDERATED_TOKEN_FILE":"/var/run/secrets/azure/tokens/azure-identity-token",
+				"AZURE_TENANT_ID":"tenant-id"
+			}
+		},
+		"tlsClientConfig":{"insecure":false}
+	}`, string(secret.Data["config"]))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster-gke", Namespace: a.Namespace}, secret))
+	assert.JSONEq(t, `{
+		"execProviderConfig":{"command":"gke-gcloud-auth-plugin","apiVersion":"client.authentication.k8s.io/v1beta1"},
+		"tlsClientConfig":{"insecure":false}
+	}`, string(secret.Data["config"]))
+
+	// the aws exec provider requires the name of the cluster
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster-invalid", Namespace: a.Namespace}, &corev1.Secret{}))
+}
+
+func TestReconcileArgoCD_reconcileInitialSecrets_repositoryCredentials(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.InitialRepositoryCredentials = []argoprojv1alpha1.ArgoCDRepositoryCredentialsSpec{
+			{
+				Name: "github",
+				URL:  "https://github.com/example",
+				GitHubApp: &argoprojv1alpha1.ArgoCDGitHubAppCredentialsSpec{
+					AppID:          12345,
+					InstallationID: 67890,
+					PrivateKeySecretRef: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "github-app"},
+						Key:                  "private-key",
+					},
+					EnterpriseBaseURL: "https://github.example.com/api/v3",
+				},
+			},
+			{
+				Name: "gcp",
+				URL:  "https://source.developers.google.com/p/example/r/",
+				GoogleCloudSource: &argoprojv1alpha1.ArgoCDGoogleCloudSourceCredentialsSpec{
+					ServiceAccountKeySecretRef: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "gcp-service-account"},
+						Key:                  "key.json",
+					},
+				},
+			},
+			{Name: "gitlab", URL: "https://gitlab.com/example", CredentialsSecret: "gitlab-credentials"},
+			{Name: "missing", URL: "https://github.com/missing", GitHubApp: &argoprojv1alpha1.ArgoCDGitHubAppCredentialsSpec{
+				AppID:          1,
+				InstallationID: 1,
+				PrivateKeySecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+					Key:                  "private-key",
+				},
+			}},
+			{Name: "helm", URL: "https://charts.example.com", Type: "helm", GoogleCloudSource: &argoprojv1alpha1.ArgoCDGoogleCloudSourceCredentialsSpec{
+				ServiceAccountKeySecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "gcp-service-account"},
+					Key:                  "key.json",
+				},
+			}},
+		}
+	})
+	githubApp := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-app", Namespace: a.Namespace},
+		Data:       map[string][]byte{"private-key": []byte("pem")},
+	}
+	gcpServiceAccount := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gcp-service-account", Namespace: a.Namespace},
+		Data:       map[string][]byte{"key.json": []byte(`{"type":"service_account"}`)},
+	}
+	gitlabCredentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gitlab-credentials", Namespace: a.Namespace},
+		Data:       map[string][]byte{"username": []byte("user"), "password": []byte("token")},
+	}
+	r := makeTestReconciler(t, a, githubApp, gcpServiceAccount, gitlabCredentials)
+
+	assert.NoError(t, r.reconcileInitialSecrets(a))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-creds-github", Namespace: a.Namespace}, secret))
+	assert.True(t, metav1.IsControlledBy(secret, a))
+	assert.Equal(t, "repo-creds", secret.Labels[common.ArgoCDSecretTypeLabel])
+	assert.Equal(t, map[string][]byte{
+		"url":                        []byte("https://github.com/example"),
+		"type":                       []byte("git"),
+		"githubAppID":                []byte("12345"),
+		"githubAppInstallationID":    []byte("67890"),
+		"githubAppEnterpriseBaseUrl": []byte("https://github.example.com/api/v3"),
+		"githubAppPrivateKey":        []byte("pem"),
+	}, secret.Data)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-creds-gcp", Namespace: a.Namespace}, secret))
+	assert.Equal(t, map[string][]byte{
+		"url":                  []byte("https://source.developers.google.com/p/example/r/"),
+		"type":                 []byte("git"),
+		"gcpServiceAccountKey": []byte(`{"type":"service_account"}`),
+	}, secret.Data)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-creds-gitlab", Namespace: a.Namespace}, secret))
+	assert.Equal(t, "token", string(secret.Data["password"]))
+
+	// templates with missing or invalid credentials are skipped
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-creds-missing", Namespace: a.Namespace}, secret))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-creds-helm", Namespace: a.Namespace}, secret))
+
+	// a rotated private key is picked up
+	githubApp.Data["private-key"] = []byte("rotated")
+	assert.NoError(t, r.Client.Update(context.TODO(), githubApp))
+	assert.NoError(t, r.reconcileInitialSecrets(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-creds-github", Namespace: a.Namespace}, secret))
+	assert.Equal(t, "rotated", string(secret.Data["githubAppPrivateKey"]))
+
+	// templates removed from the spec are deleted
+	a.Spec.InitialRepositoryCredentials = a.Spec.InitialRepositoryCredentials[:1]
+	assert.NoError(t, r.reconcileInitialSecrets(a))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-creds-gcp", Namespace: a.Namespace}, secret))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-creds-github", Namespace: a.Namespace}, secret))
+}
@@ -0,0 +1,192 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	istioNetworkingGroup      = "networking.istio.io"
+	istioNetworkingVersion    = "v1beta1"
+	istioKindVirtualService   = "VirtualService"
+	istioKindDestinationRule  = "DestinationRule"
+	istioGRPCContentTypeMatch = "application/grpc"
+)
+
+var istioAPIFound = false
+
+// IsIstioAPIAvailable returns true if the Istio networking API is present.
+func IsIstioAPIAvailable() bool {
+	return istioAPIFound
+}
+
+// verifyIstioAPI will verify that the Istio networking API is present.
+func verifyIstioAPI() error {
+	found, err := argoutil.VerifyAPI(istioNetworkingGroup, istioNetworkingVersion)
+	if err != nil {
+		return err
+	}
+	istioAPIFound = found
+	return nil
+}
+
+// newIstioObjectWithSuffix returns a new Istio networking object of the given kind for the given ArgoCD, using the
+// given name suffix.
+func newIstioObjectWithSuffix(kind string, suffix string, cr *argoprojv1a1.ArgoCD) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   istioNetworkingGroup,
+		Version: istioNetworkingVersion,
+		Kind:    kind,
+	})
+	obj.SetName(nameWithSuffix(suffix, cr))
+	obj.SetNamespace(cr.Namespace)
+	obj.SetLabels(argoutil.LabelsForCluster(cr))
+	return obj
+}
+
+// getArgoServerIstioHosts will return the hosts of the Argo CD Server VirtualService.
+func getArgoServerIstioHosts(cr *argoprojv1a1.ArgoCD) []interface{} {
+	hosts := cr.Spec.Server.Istio.Hosts
+	if len(hosts) == 0 {
+		hosts = []string{getArgoServerHost(cr)}
+	}
+
+	result := make([]interface{}, 0, len(hosts))
+	for _, host := range hosts {
+		result = append(result, host)
+	}
+	return result
+}
+
+// getArgoServerIstioDestination will return the destination of the Argo CD Server VirtualService routes. As the
+// server runs insecure, both HTTP and gRPC traffic is sent to the plain text http port of the Service.
+func getArgoServerIstioDestination(cr *argoprojv1a1.ArgoCD) map[string]interface{} {
+	return map[string]interface{}{
+		"host": fmt.Sprintf("%s.%s.svc.cluster.local", nameWithSuffix("server", cr), cr.Namespace),
+		"port": map[string]interface{}{
+			"number": int64(80),
+		},
+	}
+}
+
+// getArgoServerVirtualServiceSpec will return the desired spec of the Argo CD Server VirtualService.
+func getArgoServerVirtualServiceSpec(cr *argoprojv1a1.ArgoCD) map[string]interface{} {
+	spec := map[string]interface{}{
+		"hosts": getArgoServerIstioHosts(cr),
+		"http": []interface{}{
+			map[string]interface{}{
+				"name": "grpc",
+				"match": []interface{}{
+					map[string]interface{}{
+						"headers": map[string]interface{}{
+							"content-type": map[string]interface{}{
+								"prefix": istioGRPCContentTypeMatch,
+							},
+						},
+					},
+				},
+				"route": []interface{}{
+					map[string]interface{}{"destination": getArgoServerIstioDestination(cr)},
+				},
+			},
+			map[string]interface{}{
+				"name": "http",
+				"route": []interface{}{
+					map[string]interface{}{"destination": getArgoServerIstioDestination(cr)},
+				},
+			},
+		},
+	}
+
+	if len(cr.Spec.Server.Istio.Gateways) > 0 {
+		gateways := make([]interface{}, 0, len(cr.Spec.Server.Istio.Gateways))
+		for _, gateway := range cr.Spec.Server.Istio.Gateways {
+			gateways = append(gateways, gateway)
+		}
+		spec["gateways"] = gateways
+	}
+	return spec
+}
+
+// getArgoServerDestinationRuleSpec will return the desired spec of the Argo CD Server DestinationRule. HTTP/1.1
+// connections to the server are upgraded to HTTP/2, which is required for gRPC.
+func getArgoServerDestinationRuleSpec(cr *argoprojv1a1.ArgoCD) map[string]interface{} {
+	return map[string]interface{}{
+		"host": fmt.Sprintf("%s.%s.svc.cluster.local", nameWithSuffix("server", cr), cr.Namespace),
+		"trafficPolicy": map[string]interface{}{
+			"connectionPool": map[string]interface{}{
+				"http": map[string]interface{}{
+					"h2UpgradePolicy": "UPGRADE",
+				},
+			},
+		},
+	}
+}
+
+// reconcileIstio will ensure that the Istio resources for the Argo CD Server are present when requested, and
+// removed otherwise.
+func (r *ReconcileArgoCD) reconcileIstio(cr *argoprojv1a1.ArgoCD) error {
+	if err := r.reconcileIstioObject(istioKindVirtualService, getArgoServerVirtualServiceSpec(cr), cr); err != nil {
+		return err
+	}
+	return r.reconcileIstioObject(istioKindDestinationRule, getArgoServerDestinationRuleSpec(cr), cr)
+}
+
+// reconcileIstioObject will ensure that the Istio object of the given kind for the Argo CD Server has the desired
+// spec.
+func (r *ReconcileArgoCD) reconcileIstioObject(kind string, spec map[string]interface{}, cr *argoprojv1a1.ArgoCD) error {
+	existing := newIstioObjectWithSuffix(kind, "server", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.GetName(), existing) {
+		if !cr.Spec.Server.Istio.Enabled {
+			// Only remove objects that were created by the operator.
+			if !metav1.IsControlledBy(existing, cr) {
+				return nil
+			}
+			log.Info(fmt.Sprintf("deleting %s %s as istio is disabled", kind, existing.GetName()))
+			return r.Client.Delete(context.TODO(), existing)
+		}
+
+		if !reflect.DeepEqual(existing.Object["spec"], spec) {
+			existing.Object["spec"] = spec
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // Object found with nothing to do, move along...
+	}
+
+	if !cr.Spec.Server.Istio.Enabled {
+		return nil // Istio not enabled, do nothing.
+	}
+
+	obj := newIstioObjectWithSuffix(kind, "server", cr)
+	obj.Object["spec"] = spec
+	if err := controllerutil.SetControllerReference(cr, obj, r.Scheme); err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("creating %s %s for Argo CD instance %s in namespace %s", kind, obj.GetName(), cr.Name, cr.Namespace))
+	return r.Client.Create(context.TODO(), obj)
+}
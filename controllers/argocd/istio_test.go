@@ -0,0 +1,61 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func TestReconcileArgoCD_reconcileIstio(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Host = "argocd.example.com"
+		a.Spec.Server.Istio = argoprojv1alpha1.ArgoCDServerIstioSpec{
+			Enabled:  true,
+			Gateways: []string{"istio-system/ingressgateway"},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileIstio(a))
+
+	vs := newIstioObjectWithSuffix(istioKindVirtualService, "server", a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, vs))
+	assert.True(t, metav1.IsControlledBy(vs, a))
+
+	hosts, _, _ := unstructured.NestedStringSlice(vs.Object, "spec", "hosts")
+	assert.Equal(t, []string{"argocd.example.com"}, hosts)
+	gateways, _, _ := unstructured.NestedStringSlice(vs.Object, "spec", "gateways")
+	assert.Equal(t, []string{"istio-system/ingressgateway"}, gateways)
+	routes, _, _ := unstructured.NestedSlice(vs.Object, "spec", "http")
+	assert.Len(t, routes, 2)
+
+	dr := newIstioObjectWithSuffix(istioKindDestinationRule, "server", a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, dr))
+	host, _, _ := unstructured.NestedString(dr.Object, "spec", "host")
+	assert.Equal(t, "argocd-server.argocd.svc.cluster.local", host)
+
+	// the server runs insecure behind the mesh
+	assert.Contains(t, getArgoServerCommand(a, false), "--insecure")
+
+	// changes to the hosts are reconciled
+	a.Spec.Server.Istio.Hosts = []string{"argocd.example.org"}
+	assert.NoError(t, r.reconcileIstio(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, vs))
+	hosts, _, _ = unstructured.NestedStringSlice(vs.Object, "spec", "hosts")
+	assert.Equal(t, []string{"argocd.example.org"}, hosts)
+
+	// disabling istio removes the objects
+	a.Spec.Server.Istio.Enabled = false
+	assert.NoError(t, r.reconcileIstio(a))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, vs))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, dr))
+	assert.NotContains(t, getArgoServerCommand(a, false), "--insecure")
+}
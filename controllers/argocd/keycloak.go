@@ -21,6 +21,7 @@ import (
 	json "encoding/json"
 	"fmt"
 	"os"
+	"reflect"
 
 	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
 	"github.com/argoproj-labs/argocd-operator/common"
@@ -78,6 +79,10 @@ const (
 	defaultKeycloakAdminPassword = "admin"
 	// Default Hostname for Keycloak Ingress.
 	keycloakIngressHost = "keycloak-ingress"
+	// Themes directory of Keycloak on OpenShift.
+	keycloakThemesDirForOpenShift = "/opt/eap/themes"
+	// Themes directory of Keycloak on Kubernetes.
+	keycloakThemesDir = "/opt/jboss/keycloak/themes"
 )
 
 var (
@@ -99,6 +104,7 @@ type keycloakConfig struct {
 	ArgoCDURL          string
 	KeycloakServerCert []byte
 	VerifyTLS          bool
+	LoginTheme         string
 }
 
 type oidcConfig struct {
@@ -139,6 +145,9 @@ type CustomKeycloakAPIRealm struct {
 	// Require SSL
 	// +optional
 	SslRequired string `json:"sslRequired,omitempty"`
+	// Login theme
+	// +optional
+	LoginTheme string `json:"loginTheme,omitempty"`
 	// A set of Keycloak Clients.
 	// +optional
 	Clients []*keycloakv1alpha1.KeycloakAPIClient `json:"clients,omitempty"`
@@ -203,6 +212,48 @@ func getKeycloakContainerImage(cr *argoprojv1a1.ArgoCD) string {
 	return argoutil.CombineImageTag(img, tag)
 }
 
+// getKeycloakTheme returns the theme of the Keycloak login page, or nil if none is set.
+func getKeycloakTheme(cr *argoprojv1a1.ArgoCD) *argoprojv1a1.ArgoCDSSOThemeSpec {
+	if cr.Spec.SSO != nil && cr.Spec.SSO.Keycloak != nil {
+		return cr.Spec.SSO.Keycloak.Theme
+	}
+	return nil
+}
+
+// getKeycloakAdminCredentialsSecretRef returns the reference to the Secret holding the credentials of the Keycloak admin
+// user, or nil if none is set.
+func getKeycloakAdminCredentialsSecretRef(cr *argoprojv1a1.ArgoCD) *corev1.LocalObjectReference {
+	if cr.Spec.SSO != nil && cr.Spec.SSO.Keycloak != nil {
+		return cr.Spec.SSO.Keycloak.AdminCredentialsSecretRef
+	}
+	return nil
+}
+
+// getKeycloakAdminEnv returns the environment variables with the given names holding the username and password of the
+// Keycloak admin user. They are sourced from the Secret referenced by the given ArgoCD, or set to the given values.
+func getKeycloakAdminEnv(cr *argoprojv1a1.ArgoCD, usernameEnv, passwordEnv, username, password string) []corev1.EnvVar {
+	ref := getKeycloakAdminCredentialsSecretRef(cr)
+	if ref == nil {
+		return []corev1.EnvVar{
+			{Name: usernameEnv, Value: username},
+			{Name: passwordEnv, Value: password},
+		}
+	}
+
+	secretKeyRef := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: *ref,
+				Key:                  key,
+			},
+		}
+	}
+	return []corev1.EnvVar{
+		{Name: usernameEnv, ValueFrom: secretKeyRef(corev1.BasicAuthUsernameKey)},
+		{Name: passwordEnv, ValueFrom: secretKeyRef(corev1.BasicAuthPasswordKey)},
+	}
+}
+
 func getKeycloakConfigMapTemplate(ns string) *corev1.ConfigMap {
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -254,8 +305,11 @@ func defaultKeycloakResources() corev1.ResourceRequirements {
 // getKeycloakResources will return the ResourceRequirements for the Keycloak container.
 func getKeycloakResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
 
-	// Default values for Keycloak resources requirements.
-	resources := defaultKeycloakResources()
+	// Default values for Keycloak resources requirements, unless the sizing profile of the operator sets them.
+	resources := getDefaultResources(sizingKeycloakComponent)
+	if resources.Requests == nil {
+		resources = defaultKeycloakResources()
+	}
 
 	// Allow override of resource requirements from CR
 	if cr.Spec.SSO != nil && cr.Spec.SSO.Resources != nil {
@@ -276,12 +330,13 @@ func getKeycloakContainer(cr *argoprojv1a1.ArgoCD) corev1.Container {
 		{Name: "OPENSHIFT_DNS_PING_SERVICE_NAME", Value: "${APPLICATION_NAME}-ping"},
 		{Name: "OPENSHIFT_DNS_PING_SERVICE_PORT", Value: "8888"},
 		{Name: "X509_CA_BUNDLE", Value: "/var/run/configmaps/service-ca/service-ca.crt /var/run/secrets/kubernetes.io/serviceaccount/*.crt"},
-		{Name: "SSO_ADMIN_USERNAME", Value: "${SSO_ADMIN_USERNAME}"},
-		{Name: "SSO_ADMIN_PASSWORD", Value: "${SSO_ADMIN_PASSWORD}"},
-		{Name: "SSO_REALM", Value: "${SSO_REALM}"},
-		{Name: "SSO_SERVICE_USERNAME", Value: "${SSO_SERVICE_USERNAME}"},
-		{Name: "SSO_SERVICE_PASSWORD", Value: "${SSO_SERVICE_PASSWORD}"},
 	}
+	envVars = append(envVars, getKeycloakAdminEnv(cr, "SSO_ADMIN_USERNAME", "SSO_ADMIN_PASSWORD", "${SSO_ADMIN_USERNAME}", "${SSO_ADMIN_PASSWORD}")...)
+	envVars = append(envVars,
+		corev1.EnvVar{Name: "SSO_REALM", Value: "${SSO_REALM}"},
+		corev1.EnvVar{Name: "SSO_SERVICE_USERNAME", Value: "${SSO_SERVICE_USERNAME}"},
+		corev1.EnvVar{Name: "SSO_SERVICE_PASSWORD", Value: "${SSO_SERVICE_PASSWORD}"},
+	)
 
 	return corev1.Container{
 		Env:             proxyEnvVars(envVars...),
@@ -360,8 +415,9 @@ func getKeycloakDeploymentConfigTemplate(cr *argoprojv1a1.ArgoCD) *appsv1.Deploy
 		},
 		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "DeploymentConfig"},
 		Spec: appsv1.DeploymentConfigSpec{
-			Replicas: 1,
-			Selector: map[string]string{"deploymentConfig": "${APPLICATION_NAME}"},
+			Replicas:             1,
+			RevisionHistoryLimit: getRevisionHistoryLimit(cr),
+			Selector:             map[string]string{"deploymentConfig": "${APPLICATION_NAME}"},
 			Strategy: appsv1.DeploymentStrategy{
 				Type: "Recreate",
 				Resources: corev1.ResourceRequirements{
@@ -424,6 +480,8 @@ func getKeycloakDeploymentConfigTemplate(cr *argoprojv1a1.ArgoCD) *appsv1.Deploy
 		dc.Spec.Template.Spec.Tolerations = cr.Spec.NodePlacement.Tolerations
 	}
 
+	setSSOTheme(&dc.Spec.Template.Spec, getKeycloakTheme(cr), keycloakThemesDirForOpenShift)
+
 	return dc
 
 }
@@ -638,18 +696,15 @@ func newKeycloakService(cr *argoprojv1a1.ArgoCD) *corev1.Service {
 	}
 }
 
-func getKeycloakContainerEnv() []corev1.EnvVar {
-	return []corev1.EnvVar{
-		{Name: "KEYCLOAK_USER", Value: defaultKeycloakAdminUser},
-		{Name: "KEYCLOAK_PASSWORD", Value: defaultKeycloakAdminPassword},
-		{Name: "PROXY_ADDRESS_FORWARDING", Value: "true"},
-	}
+func getKeycloakContainerEnv(cr *argoprojv1a1.ArgoCD) []corev1.EnvVar {
+	env := getKeycloakAdminEnv(cr, "KEYCLOAK_USER", "KEYCLOAK_PASSWORD", defaultKeycloakAdminUser, defaultKeycloakAdminPassword)
+	return append(env, corev1.EnvVar{Name: "PROXY_ADDRESS_FORWARDING", Value: "true"})
 }
 
 func newKeycloakDeployment(cr *argoprojv1a1.ArgoCD) *k8sappsv1.Deployment {
 
 	var replicas int32 = 1
-	return &k8sappsv1.Deployment{
+	dep := &k8sappsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      defaultKeycloakIdentifier,
 			Namespace: cr.Namespace,
@@ -661,7 +716,9 @@ func newKeycloakDeployment(cr *argoprojv1a1.ArgoCD) *k8sappsv1.Deployment {
 			},
 		},
 		Spec: k8sappsv1.DeploymentSpec{
-			Replicas: &replicas,
+			Replicas:                &replicas,
+			RevisionHistoryLimit:    getRevisionHistoryLimit(cr),
+			ProgressDeadlineSeconds: getProgressDeadlineSeconds(cr),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{
 					"app": defaultKeycloakIdentifier,
@@ -676,9 +733,10 @@ func newKeycloakDeployment(cr *argoprojv1a1.ArgoCD) *k8sappsv1.Deployment {
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
 						{
-							Name:  defaultKeycloakIdentifier,
-							Image: getKeycloakContainerImage(cr),
-							Env:   proxyEnvVars(getKeycloakContainerEnv()...),
+							Name:      defaultKeycloakIdentifier,
+							Image:     getKeycloakContainerImage(cr),
+							Env:       proxyEnvVars(getKeycloakContainerEnv(cr)...),
+							Resources: getKeycloakResources(cr),
 							Ports: []corev1.ContainerPort{
 								{Name: "http", ContainerPort: httpPort},
 								{Name: "https", ContainerPort: portTLS},
@@ -697,6 +755,10 @@ func newKeycloakDeployment(cr *argoprojv1a1.ArgoCD) *k8sappsv1.Deployment {
 			},
 		},
 	}
+
+	setSSOTheme(&dep.Spec.Template.Spec, getKeycloakTheme(cr), keycloakThemesDir)
+
+	return dep
 }
 
 func (r *ReconcileArgoCD) newKeycloakInstance(cr *argoprojv1a1.ArgoCD) error {
@@ -793,7 +855,7 @@ func (r *ReconcileArgoCD) prepareKeycloakConfig(cr *argoprojv1a1.ArgoCD) (*keycl
 	if err != nil {
 		return nil, err
 	}
-	aRouteURL := fmt.Sprintf("https://%s", existingArgoCDRoute.Spec.Host)
+	aRouteURL := getArgoServerExternalURL(cr, existingArgoCDRoute.Spec.Host)
 
 	// Get keycloak Secret for credentials. credentials are required to authenticate with keycloak.
 	existingSecret := &corev1.Secret{
@@ -802,14 +864,19 @@ func (r *ReconcileArgoCD) prepareKeycloakConfig(cr *argoprojv1a1.ArgoCD) (*keycl
 			Namespace: cr.Namespace,
 		},
 	}
+	usernameKey, passwordKey := "SSO_USERNAME", "SSO_PASSWORD"
+	if ref := getKeycloakAdminCredentialsSecretRef(cr); ref != nil {
+		existingSecret.Name = ref.Name
+		usernameKey, passwordKey = corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey
+	}
 	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: existingSecret.Name,
 		Namespace: existingSecret.Namespace}, existingSecret)
 	if err != nil {
 		return nil, err
 	}
 
-	userEnc := b64.URLEncoding.EncodeToString(existingSecret.Data["SSO_USERNAME"])
-	passEnc := b64.URLEncoding.EncodeToString(existingSecret.Data["SSO_PASSWORD"])
+	userEnc := b64.URLEncoding.EncodeToString(existingSecret.Data[usernameKey])
+	passEnc := b64.URLEncoding.EncodeToString(existingSecret.Data[passwordKey])
 
 	username, _ := b64.URLEncoding.DecodeString(userEnc)
 	password, _ := b64.URLEncoding.DecodeString(passEnc)
@@ -835,6 +902,9 @@ func (r *ReconcileArgoCD) prepareKeycloakConfig(cr *argoprojv1a1.ArgoCD) (*keycl
 		KeycloakServerCert: serverCert,
 		VerifyTLS:          tlsVerification,
 	}
+	if theme := getKeycloakTheme(cr); theme != nil {
+		cfg.LoginTheme = theme.Name
+	}
 
 	return cfg, nil
 }
@@ -870,17 +940,31 @@ func (r *ReconcileArgoCD) prepareKeycloakConfigForK8s(cr *argoprojv1a1.ArgoCD) (
 	if err != nil {
 		return nil, err
 	}
-	aIngURL := fmt.Sprintf("https://%s", existingArgoCDIng.Spec.Rules[0].Host)
+	aIngURL := getArgoServerExternalURL(cr, existingArgoCDIng.Spec.Rules[0].Host)
+
+	// Get the credentials from the referenced Secret, if any. credentials are required to authenticate with keycloak.
+	username, password := defaultKeycloakAdminUser, defaultKeycloakAdminPassword
+	if ref := getKeycloakAdminCredentialsSecretRef(cr); ref != nil {
+		secret := &corev1.Secret{}
+		err = r.Client.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: cr.Namespace}, secret)
+		if err != nil {
+			return nil, err
+		}
+		username, password = string(secret.Data[corev1.BasicAuthUsernameKey]), string(secret.Data[corev1.BasicAuthPasswordKey])
+	}
 
 	cfg := &keycloakConfig{
 		ArgoName:      cr.Name,
 		ArgoNamespace: cr.Namespace,
-		Username:      defaultKeycloakAdminUser,
-		Password:      defaultKeycloakAdminPassword,
+		Username:      username,
+		Password:      password,
 		KeycloakURL:   kIngURL,
 		ArgoCDURL:     aIngURL,
 		VerifyTLS:     false,
 	}
+	if theme := getKeycloakTheme(cr); theme != nil {
+		cfg.LoginTheme = theme.Name
+	}
 
 	return cfg, nil
 }
@@ -892,6 +976,7 @@ func createRealmConfig(cfg *keycloakConfig) ([]byte, error) {
 		Realm:       keycloakRealm,
 		Enabled:     true,
 		SslRequired: "external",
+		LoginTheme:  cfg.LoginTheme,
 		Clients: []*keycloakv1alpha1.KeycloakAPIClient{
 			{
 				ClientID:                keycloakClient,
@@ -1122,12 +1207,14 @@ func (r *ReconcileArgoCD) updateArgoCDConfiguration(cr *argoprojv1a1.ArgoCD, kRo
 		return err
 	}
 
-	argoCDCM.Data[common.ArgoCDKeyOIDCConfig] = string(o)
-	err = r.Client.Update(context.TODO(), argoCDCM)
-	if err != nil {
-		log.Error(err, fmt.Sprintf("Error updating OIDC Configuration for ArgoCD %s in namespace %s",
-			cr.Name, cr.Namespace))
-		return err
+	if !isUnmanagedConfigKey(common.ArgoCDKeyOIDCConfig, cr) {
+		argoCDCM.Data[common.ArgoCDKeyOIDCConfig] = string(o)
+		err = r.Client.Update(context.TODO(), argoCDCM)
+		if err != nil {
+			log.Error(err, fmt.Sprintf("Error updating OIDC Configuration for ArgoCD %s in namespace %s",
+				cr.Name, cr.Namespace))
+			return err
+		}
 	}
 
 	// Update RBAC for ArgoCD Instance.
@@ -1385,10 +1472,35 @@ func (r *ReconcileArgoCD) reconcileKeycloakForOpenShift(cr *argoprojv1a1.ArgoCD)
 			cr.Name, cr.Namespace))
 	} else {
 		// Handle Image upgrades
+		changed := false
 		desiredImage := getKeycloakContainerImage(cr)
 		if existingDC.Spec.Template.Spec.Containers[0].Image != desiredImage {
 			existingDC.Spec.Template.Spec.Containers[0].Image = desiredImage
+			changed = true
+		}
 
+		// Handle theme changes
+		if setSSOTheme(&existingDC.Spec.Template.Spec, getKeycloakTheme(cr), keycloakThemesDirForOpenShift) {
+			changed = true
+		}
+
+		// Handle changes of the number of old revisions kept
+		if !reflect.DeepEqual(existingDC.Spec.RevisionHistoryLimit, getRevisionHistoryLimit(cr)) {
+			existingDC.Spec.RevisionHistoryLimit = getRevisionHistoryLimit(cr)
+			changed = true
+		}
+
+		// Handle references to the admin credentials, the generated credentials are set by the template.
+		if getKeycloakAdminCredentialsSecretRef(cr) != nil {
+			desiredEnv := argoutil.EnvMerge(existingDC.Spec.Template.Spec.Containers[0].Env,
+				getKeycloakAdminEnv(cr, "SSO_ADMIN_USERNAME", "SSO_ADMIN_PASSWORD", "", ""), true)
+			if !reflect.DeepEqual(existingDC.Spec.Template.Spec.Containers[0].Env, desiredEnv) {
+				existingDC.Spec.Template.Spec.Containers[0].Env = desiredEnv
+				changed = true
+			}
+		}
+
+		if changed {
 			err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 				return r.Client.Update(context.TODO(), existingDC)
 			})
@@ -1488,10 +1600,32 @@ func (r *ReconcileArgoCD) reconcileKeycloak(cr *argoprojv1a1.ArgoCD) error {
 			cr.Name, cr.Namespace))
 	} else {
 		// Handle Image upgrades
+		changed := false
 		desiredImage := getKeycloakContainerImage(cr)
 		if existingDeployment.Spec.Template.Spec.Containers[0].Image != desiredImage {
 			existingDeployment.Spec.Template.Spec.Containers[0].Image = desiredImage
+			changed = true
+		}
+
+		// Handle theme changes
+		if setSSOTheme(&existingDeployment.Spec.Template.Spec, getKeycloakTheme(cr), keycloakThemesDir) {
+			changed = true
+		}
+
+		// Handle changes of the number of old revisions kept
+		if !reflect.DeepEqual(existingDeployment.Spec.RevisionHistoryLimit, getRevisionHistoryLimit(cr)) {
+			existingDeployment.Spec.RevisionHistoryLimit = getRevisionHistoryLimit(cr)
+			changed = true
+		}
+
+		// Handle changes of the reference to the admin credentials
+		desiredEnv := proxyEnvVars(getKeycloakContainerEnv(cr)...)
+		if !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[0].Env, desiredEnv) {
+			existingDeployment.Spec.Template.Spec.Containers[0].Env = desiredEnv
+			changed = true
+		}
 
+		if changed {
 			err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 				return r.Client.Update(context.TODO(), existingDeployment)
 			})
@@ -22,9 +22,12 @@ import (
 	appsv1 "github.com/openshift/api/apps/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/assert"
+	k8sappsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	resourcev1 "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
 	argoappv1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
@@ -529,6 +532,149 @@ func TestKeycloak_NodeLabelSelector(t *testing.T) {
 	assert.Equal(t, dc.Spec.Template.Spec.Tolerations, a.Spec.NodePlacement.Tolerations)
 }
 
+func TestKeycloak_theme(t *testing.T) {
+	a := makeTestArgoCDForKeycloak()
+	a.Spec.SSO.Keycloak = &argoappv1.ArgoCDKeycloakSpec{
+		Theme: &argoappv1.ArgoCDSSOThemeSpec{
+			Name:      "corporate",
+			ConfigMap: "keycloak-theme",
+			Items: []corev1.KeyToPath{
+				{Key: "theme.properties", Path: "login/theme.properties"},
+				{Key: "login.css", Path: "login/resources/css/login.css"},
+			},
+		},
+	}
+	var defaultMode int32 = 420
+	wantVolume := corev1.Volume{
+		Name: common.ArgoCDSSOThemeVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "keycloak-theme"},
+				Items:                a.Spec.SSO.Keycloak.Theme.Items,
+				DefaultMode:          &defaultMode,
+			},
+		},
+	}
+
+	dep := newKeycloakDeployment(a)
+	assert.Contains(t, dep.Spec.Template.Spec.Volumes, wantVolume)
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      common.ArgoCDSSOThemeVolumeName,
+		MountPath: "/opt/jboss/keycloak/themes/corporate",
+		ReadOnly:  true,
+	})
+
+	dc := getKeycloakDeploymentConfigTemplate(a)
+	assert.Equal(t, append(fakeVolumes, wantVolume), dc.Spec.Template.Spec.Volumes)
+	assert.Contains(t, dc.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      common.ArgoCDSSOThemeVolumeName,
+		MountPath: "/opt/eap/themes/corporate",
+		ReadOnly:  true,
+	})
+
+	// An unchanged theme does not change the pod spec, a removed one does.
+	assert.False(t, setSSOTheme(&dep.Spec.Template.Spec, a.Spec.SSO.Keycloak.Theme, keycloakThemesDir))
+	assert.True(t, setSSOTheme(&dep.Spec.Template.Spec, nil, keycloakThemesDir))
+	assert.Empty(t, dep.Spec.Template.Spec.Volumes)
+	assert.Empty(t, dep.Spec.Template.Spec.Containers[0].VolumeMounts)
+
+	realm, err := createRealmConfig(&keycloakConfig{ArgoCDURL: "https://bar.argocd.com", LoginTheme: "corporate"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(realm), `"loginTheme":"corporate"`)
+}
+
+func TestKeycloak_adminCredentialsSecretRef(t *testing.T) {
+	a := makeTestArgoCDForKeycloak()
+	r := makeFakeReconciler(t, a)
+	for _, name := range []string{defaultKeycloakIdentifier, a.Name + "-server"} {
+		assert.NoError(t, r.Client.Create(context.TODO(), &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: a.Namespace},
+			Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: name + ".example.com"}}},
+		}))
+	}
+	assert.NoError(t, r.reconcileKeycloak(a))
+
+	// the default credentials are used without a reference
+	cfg, err := r.prepareKeycloakConfigForK8s(a)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultKeycloakAdminUser, cfg.Username)
+	assert.Equal(t, defaultKeycloakAdminPassword, cfg.Password)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keycloak-admin", Namespace: a.Namespace},
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("vault-admin"),
+			corev1.BasicAuthPasswordKey: []byte("vault-password"),
+		},
+	}
+	assert.NoError(t, r.Client.Create(context.TODO(), secret))
+	a.Spec.SSO.Keycloak = &argoappv1.ArgoCDKeycloakSpec{
+		AdminCredentialsSecretRef: &corev1.LocalObjectReference{Name: secret.Name},
+	}
+	assert.True(t, isSecretReferenced(secret.Name, a))
+
+	// the existing Deployment is updated to source the credentials from the referenced secret
+	assert.NoError(t, r.reconcileKeycloak(a))
+	dep := &k8sappsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: defaultKeycloakIdentifier, Namespace: a.Namespace}, dep))
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+		Name: "KEYCLOAK_PASSWORD",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+				Key:                  corev1.BasicAuthPasswordKey,
+			},
+		},
+	})
+	assert.Contains(t, getKeycloakContainer(a).Env, corev1.EnvVar{
+		Name: "SSO_ADMIN_USERNAME",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+				Key:                  corev1.BasicAuthUsernameKey,
+			},
+		},
+	})
+
+	cfg, err = r.prepareKeycloakConfigForK8s(a)
+	assert.NoError(t, err)
+	assert.Equal(t, "vault-admin", cfg.Username)
+	assert.Equal(t, "vault-password", cfg.Password)
+
+	// a change to the referenced secret is picked up
+	secret.Data[corev1.BasicAuthPasswordKey] = []byte("rotated-password")
+	assert.NoError(t, r.Client.Update(context.TODO(), secret))
+	cfg, err = r.prepareKeycloakConfigForK8s(a)
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated-password", cfg.Password)
+}
+
+func TestKeycloak_revisionHistoryLimit(t *testing.T) {
+	a := makeTestArgoCDForKeycloak()
+	assert.Equal(t, int32(3), *newKeycloakDeployment(a).Spec.RevisionHistoryLimit)
+	assert.Equal(t, int32(3), *getKeycloakDeploymentConfigTemplate(a).Spec.RevisionHistoryLimit)
+
+	r := makeFakeReconciler(t, a)
+	for _, name := range []string{defaultKeycloakIdentifier, a.Name + "-server"} {
+		assert.NoError(t, r.Client.Create(context.TODO(), &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: a.Namespace},
+			Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: name + ".example.com"}}},
+		}))
+	}
+	assert.NoError(t, r.reconcileKeycloak(a))
+
+	a.Spec.Workloads = &argoprojv1alpha1.ArgoCDWorkloadSpec{
+		RevisionHistoryLimit: int32Ptr(1),
+	}
+	assert.Equal(t, int32(1), *getKeycloakDeploymentConfigTemplate(a).Spec.RevisionHistoryLimit)
+
+	// the existing Deployment is updated with the new limit
+	assert.NoError(t, r.reconcileKeycloak(a))
+	dep := &k8sappsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: defaultKeycloakIdentifier, Namespace: a.Namespace}, dep))
+	assert.Equal(t, int32(1), *dep.Spec.RevisionHistoryLimit)
+}
+
 func removeTemplateAPI() {
 	templateAPIFound = false
 }
@@ -0,0 +1,105 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/client-go/tools/leaderelection"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const (
+	// defaultLeaseDuration, defaultRenewDeadline and defaultRetryPeriod are the default durations of the leader
+	// election of the operator, the same as the controller-runtime defaults.
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+)
+
+// LeaderElectionOptions are the operator options that tune how fast a standby replica of the operator takes over when
+// the leader replica stops, when the operator runs with several replicas.
+type LeaderElectionOptions struct {
+	// Enabled makes the replicas of the operator elect a leader, the only replica that reconciles resources.
+	Enabled bool
+	// LeaseDuration is the duration standby replicas wait before taking over the leadership of a leader that stopped
+	// renewing it.
+	LeaseDuration time.Duration
+	// RenewDeadline is the duration the leader retries renewing its leadership before giving it up and stopping.
+	RenewDeadline time.Duration
+	// RetryPeriod is the interval at which the replicas try to acquire or renew the leadership.
+	RetryPeriod time.Duration
+}
+
+// Complete sets the durations that are not set, e.g. by command line flags, from their environment variables or to
+// their defaults, and validates the result.
+func (o *LeaderElectionOptions) Complete() error {
+	var err error
+	if o.LeaseDuration == 0 {
+		if o.LeaseDuration, err = getEnvDuration(common.ArgoCDLeaderElectionLeaseDurationEnvName, defaultLeaseDuration); err != nil {
+			return err
+		}
+	}
+	if o.RenewDeadline == 0 {
+		if o.RenewDeadline, err = getEnvDuration(common.ArgoCDLeaderElectionRenewDeadlineEnvName, defaultRenewDeadline); err != nil {
+			return err
+		}
+	}
+	if o.RetryPeriod == 0 {
+		if o.RetryPeriod, err = getEnvDuration(common.ArgoCDLeaderElectionRetryPeriodEnvName, defaultRetryPeriod); err != nil {
+			return err
+		}
+	}
+
+	// The leader must give up its leadership before a standby replica can take it over, and must be able to retry the
+	// renewal at least once within its deadline.
+	if o.RetryPeriod <= 0 || o.LeaseDuration <= o.RenewDeadline ||
+		o.RenewDeadline <= time.Duration(leaderelection.JitterFactor*float64(o.RetryPeriod)) {
+		return fmt.Errorf("leader election durations must be positive with the lease duration above the renew deadline, "+
+			"and the renew deadline above %.1f times the retry period, got %s, %s and %s",
+			leaderelection.JitterFactor, o.LeaseDuration, o.RenewDeadline, o.RetryPeriod)
+	}
+	return nil
+}
+
+// Apply sets the leader election of the given manager options. The leader gives up its leadership as soon as it
+// stops, so that a standby replica takes over without waiting for the lease to expire.
+func (o LeaderElectionOptions) Apply(opts *manager.Options) {
+	opts.LeaderElection = o.Enabled
+	opts.LeaseDuration = &o.LeaseDuration
+	opts.RenewDeadline = &o.RenewDeadline
+	opts.RetryPeriod = &o.RetryPeriod
+	opts.LeaderElectionReleaseOnCancel = true
+}
+
+// LeaderReadyCheck returns a readiness check that only passes once the given channel is closed, i.e. once the replica
+// of the operator is elected as the leader. Standby replicas are reported as not ready. The channel of a manager
+// without leader election is closed as soon as it starts.
+func LeaderReadyCheck(elected <-chan struct{}) healthz.Checker {
+	return func(_ *http.Request) error {
+		select {
+		case <-elected:
+			return nil
+		default:
+			return errors.New("waiting to be elected as the leader")
+		}
+	}
+}
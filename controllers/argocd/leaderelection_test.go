@@ -0,0 +1,61 @@
+package argocd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestLeaderElectionOptions_Complete(t *testing.T) {
+	opts := LeaderElectionOptions{Enabled: true}
+	assert.NoError(t, opts.Complete())
+	assert.Equal(t, LeaderElectionOptions{
+		Enabled:       true,
+		LeaseDuration: defaultLeaseDuration,
+		RenewDeadline: defaultRenewDeadline,
+		RetryPeriod:   defaultRetryPeriod,
+	}, opts)
+
+	// durations that are set, e.g. by flags, take precedence over the environment
+	t.Setenv(common.ArgoCDLeaderElectionLeaseDurationEnvName, "60s")
+	t.Setenv(common.ArgoCDLeaderElectionRenewDeadlineEnvName, "40s")
+	opts = LeaderElectionOptions{RetryPeriod: 5 * time.Second}
+	assert.NoError(t, opts.Complete())
+	assert.Equal(t, LeaderElectionOptions{
+		LeaseDuration: time.Minute,
+		RenewDeadline: 40 * time.Second,
+		RetryPeriod:   5 * time.Second,
+	}, opts)
+}
+
+func TestLeaderElectionOptions_Complete_invalid(t *testing.T) {
+	t.Setenv(common.ArgoCDLeaderElectionLeaseDurationEnvName, "soon")
+	assert.Error(t, (&LeaderElectionOptions{}).Complete())
+
+	t.Setenv(common.ArgoCDLeaderElectionLeaseDurationEnvName, "")
+	assert.Error(t, (&LeaderElectionOptions{LeaseDuration: 10 * time.Second, RenewDeadline: 10 * time.Second}).Complete())
+	assert.Error(t, (&LeaderElectionOptions{RenewDeadline: 2 * time.Second, RetryPeriod: 2 * time.Second}).Complete())
+}
+
+func TestLeaderElectionOptions_Apply(t *testing.T) {
+	opts := manager.Options{}
+	LeaderElectionOptions{Enabled: true, LeaseDuration: time.Minute, RenewDeadline: 40 * time.Second, RetryPeriod: 5 * time.Second}.Apply(&opts)
+	assert.True(t, opts.LeaderElection)
+	assert.True(t, opts.LeaderElectionReleaseOnCancel)
+	assert.Equal(t, time.Minute, *opts.LeaseDuration)
+	assert.Equal(t, 40*time.Second, *opts.RenewDeadline)
+	assert.Equal(t, 5*time.Second, *opts.RetryPeriod)
+}
+
+func TestLeaderReadyCheck(t *testing.T) {
+	elected := make(chan struct{})
+	check := LeaderReadyCheck(elected)
+	assert.Error(t, check(nil))
+
+	close(elected)
+	assert.NoError(t, check(nil))
+}
@@ -0,0 +1,204 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+var (
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "argocd_operator_reconcile_duration_seconds",
+		Help:    "Duration of the reconciliation of an ArgoCD instance in seconds.",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"namespace", "name"})
+
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "argocd_operator_reconcile_errors_total",
+		Help: "Number of failed reconciliations of an ArgoCD instance.",
+	}, []string{"namespace", "name"})
+
+	reconcileLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "argocd_operator_reconcile_last_success_timestamp_seconds",
+		Help: "Time of the last successful reconciliation of an ArgoCD instance as a Unix timestamp.",
+	}, []string{"namespace", "name"})
+
+	reconcileResourcesChanged = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "argocd_operator_reconcile_resources_changed",
+		Help: "Number of resources created, updated or deleted by the last reconciliation of an ArgoCD instance.",
+	}, []string{"namespace", "name"})
+
+	componentPhase = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "argocd_operator_component_phase",
+		Help: "Phase of the components of an ArgoCD instance, set to 1 for the current phase of a component.",
+	}, []string{"namespace", "name", "component", "phase"})
+
+	apiRequestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "argocd_operator_api_errors_total",
+		Help: "Number of failed requests of the operator to the Kubernetes API, by verb.",
+	}, []string{"verb"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		reconcileDuration,
+		reconcileErrors,
+		reconcileLastSuccess,
+		reconcileResourcesChanged,
+		componentPhase,
+		apiRequestErrors,
+	)
+}
+
+// metricsClient is a client.Client that counts failed API requests and the resources changed through it.
+type metricsClient struct {
+	client.Client
+	changes int64
+}
+
+// newMetricsClient returns a new metricsClient that wraps the given client.
+func newMetricsClient(c client.Client) *metricsClient {
+	return &metricsClient{Client: c}
+}
+
+// observe records the result of a request with the given verb for the given object. Not found and conflict errors
+// are part of the normal reconciliation and are not counted.
+func (c *metricsClient) observe(verb string, obj client.Object, err error, write bool) {
+	if err != nil {
+		if !errors.IsNotFound(err) && !errors.IsConflict(err) && !errors.IsAlreadyExists(err) {
+			apiRequestErrors.WithLabelValues(verb).Inc()
+		}
+		return
+	}
+	if _, ok := obj.(*corev1.Event); write && !ok {
+		atomic.AddInt64(&c.changes, 1)
+	}
+}
+
+// Get retrieves the given object and records the result.
+func (c *metricsClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	err := c.Client.Get(ctx, key, obj)
+	c.observe("get", obj, err, false)
+	return err
+}
+
+// List retrieves the given list of objects and records the result.
+func (c *metricsClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	err := c.Client.List(ctx, list, opts...)
+	if err != nil && !errors.IsNotFound(err) {
+		apiRequestErrors.WithLabelValues("list").Inc()
+	}
+	return err
+}
+
+// Create creates the given object and records the result.
+func (c *metricsClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := c.Client.Create(ctx, obj, opts...)
+	c.observe("create", obj, err, true)
+	return err
+}
+
+// Update updates the given object and records the result.
+func (c *metricsClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	err := c.Client.Update(ctx, obj, opts...)
+	c.observe("update", obj, err, true)
+	return err
+}
+
+// Patch patches the given object and records the result. Patches that leave the resource unchanged are not counted,
+// if the given context holds the existing state of the resource, see withExistingObject.
+func (c *metricsClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	resourceVersion := ""
+	if existing, _ := getExistingObject(ctx); existing != nil {
+		resourceVersion = existing.GetResourceVersion()
+	}
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	c.observe("patch", obj, err, resourceVersion == "" || obj.GetResourceVersion() != resourceVersion)
+	return err
+}
+
+// Delete deletes the given object and records the result.
+func (c *metricsClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := c.Client.Delete(ctx, obj, opts...)
+	c.observe("delete", obj, err, true)
+	return err
+}
+
+// getResourceChangeCount returns the number of resources changed through the client of the reconciler, or zero if
+// the client does not record them.
+func (r *ReconcileArgoCD) getResourceChangeCount() int64 {
+	if c, ok := r.Client.(*metricsClient); ok {
+		return atomic.LoadInt64(&c.changes)
+	}
+	return 0
+}
+
+// getComponentPhases returns the phase of each component of the given ArgoCD, keyed by the component name.
+func getComponentPhases(cr *argoprojv1a1.ArgoCD) map[string]string {
+	return map[string]string{
+		"argocd":                   cr.Status.Phase,
+		"applicationController":    cr.Status.ApplicationController,
+		"applicationSetController": cr.Status.ApplicationSetController,
+		"dex":                      cr.Status.Dex,
+		"notificationsController":  cr.Status.NotificationsController,
+		"redis":                    cr.Status.Redis,
+		"repo":                     cr.Status.Repo,
+		"server":                   cr.Status.Server,
+	}
+}
+
+// recordComponentPhases sets the component phase gauges of the given ArgoCD to its current status. Components without
+// a phase are not reported.
+func recordComponentPhases(cr *argoprojv1a1.ArgoCD) {
+	for component, phase := range getComponentPhases(cr) {
+		componentPhase.DeletePartialMatch(prometheus.Labels{"namespace": cr.Namespace, "name": cr.Name, "component": component})
+		if phase == "" {
+			continue
+		}
+		componentPhase.WithLabelValues(cr.Namespace, cr.Name, component, phase).Set(1)
+	}
+}
+
+// recordReconcile records the duration and outcome of a reconciliation of the ArgoCD instance with the given name.
+func recordReconcile(name types.NamespacedName, duration time.Duration, changes int64, err error) {
+	reconcileDuration.WithLabelValues(name.Namespace, name.Name).Observe(duration.Seconds())
+	reconcileResourcesChanged.WithLabelValues(name.Namespace, name.Name).Set(float64(changes))
+	if err != nil {
+		reconcileErrors.WithLabelValues(name.Namespace, name.Name).Inc()
+		return
+	}
+	reconcileLastSuccess.WithLabelValues(name.Namespace, name.Name).SetToCurrentTime()
+}
+
+// deleteInstanceMetrics removes the metrics of the ArgoCD instance with the given name, once it has been deleted.
+func deleteInstanceMetrics(name types.NamespacedName) {
+	labels := prometheus.Labels{"namespace": name.Namespace, "name": name.Name}
+	reconcileDuration.DeletePartialMatch(labels)
+	reconcileErrors.DeletePartialMatch(labels)
+	reconcileLastSuccess.DeletePartialMatch(labels)
+	reconcileResourcesChanged.DeletePartialMatch(labels)
+	componentPhase.DeletePartialMatch(labels)
+}
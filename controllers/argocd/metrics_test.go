@@ -0,0 +1,129 @@
+package argocd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func TestMetricsClient_resourceChanges(t *testing.T) {
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	r.Client = newMetricsClient(r.Client)
+
+	cm := newConfigMapWithName("test", a)
+	assert.NoError(t, r.Client.Create(context.TODO(), cm))
+	cm.Data = map[string]string{"key": "value"}
+	assert.NoError(t, r.Client.Update(context.TODO(), cm))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}, cm))
+
+	// events are not counted as changed resources
+	assert.NoError(t, r.Client.Create(context.TODO(), &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "event", Namespace: a.Namespace},
+	}))
+	assert.Equal(t, int64(2), r.getResourceChangeCount())
+
+	// failed requests do not change resources, and not found errors are not counted as API errors
+	errorsBefore := testutil.ToFloat64(apiRequestErrors.WithLabelValues("get"))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "missing", Namespace: a.Namespace}, cm))
+	assert.Equal(t, errorsBefore, testutil.ToFloat64(apiRequestErrors.WithLabelValues("get")))
+	assert.Equal(t, int64(2), r.getResourceChangeCount())
+}
+
+func TestRecordReconcile(t *testing.T) {
+	name := types.NamespacedName{Name: "metrics", Namespace: "metrics-test"}
+
+	recordReconcile(name, 0, 3, nil)
+	assert.Equal(t, float64(3), testutil.ToFloat64(reconcileResourcesChanged.WithLabelValues(name.Namespace, name.Name)))
+	assert.NotZero(t, testutil.ToFloat64(reconcileLastSuccess.WithLabelValues(name.Namespace, name.Name)))
+
+	recordReconcile(name, 0, 0, errors.New("failed"))
+	assert.Equal(t, float64(1), testutil.ToFloat64(reconcileErrors.WithLabelValues(name.Namespace, name.Name)))
+
+	deleteInstanceMetrics(name)
+	assert.Zero(t, reconcileErrors.DeletePartialMatch(prometheus.Labels{"namespace": name.Namespace}))
+}
+
+func TestRecordComponentPhases(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Namespace = "phases-test"
+		a.Status.Phase = "Pending"
+		a.Status.Server = "Running"
+	})
+
+	recordComponentPhases(a)
+	assert.Equal(t, float64(1), testutil.ToFloat64(componentPhase.WithLabelValues(a.Namespace, a.Name, "argocd", "Pending")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(componentPhase.WithLabelValues(a.Namespace, a.Name, "server", "Running")))
+
+	// the gauge of the previous phase is removed
+	a.Status.Phase = "Available"
+	recordComponentPhases(a)
+	assert.Zero(t, componentPhase.DeletePartialMatch(prometheus.Labels{"namespace": a.Namespace, "component": "argocd", "phase": "Pending"}))
+
+	// all gauges of the instance are removed once it is deleted
+	deleteInstanceMetrics(types.NamespacedName{Name: a.Name, Namespace: a.Namespace})
+	assert.Zero(t, componentPhase.DeletePartialMatch(prometheus.Labels{"namespace": a.Namespace}))
+}
+
+func TestReconcile_resourcesChangedPerRequest(t *testing.T) {
+	// getReconciler returns a reconciler counting the resources it changes, for the given instances
+	getReconciler := func(instances ...*argoprojv1alpha1.ArgoCD) *ReconcileArgoCD {
+		objs := make([]runtime.Object, 0)
+		for _, cr := range instances {
+			objs = append(objs, cr)
+		}
+		r := makeTestReconciler(t, objs...)
+		for _, cr := range instances {
+			assert.NoError(t, createNamespace(r, cr.Namespace, ""))
+		}
+		r.Client = newMetricsClient(r.Client)
+		return r
+	}
+	getChanges := func(cr *argoprojv1alpha1.ArgoCD) float64 {
+		return testutil.ToFloat64(reconcileResourcesChanged.WithLabelValues(cr.Namespace, cr.Name))
+	}
+	newRequest := func(cr *argoprojv1alpha1.ArgoCD) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}}
+	}
+
+	// the number of resources created by the first reconciliation of an instance on its own
+	c := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) { a.Namespace = "changes-c" })
+	_, err := getReconciler(c).Reconcile(context.TODO(), newRequest(c))
+	assert.NoError(t, err)
+	want := getChanges(c)
+	assert.NotZero(t, want)
+
+	// instances reconciled at the same time only count the resources they changed themselves
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) { a.Namespace = "changes-a" })
+	b := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) { a.Namespace = "changes-b" })
+	r := getReconciler(a, b)
+	var wg sync.WaitGroup
+	for _, cr := range []*argoprojv1alpha1.ArgoCD{a, b} {
+		req := newRequest(cr)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := r.Reconcile(context.TODO(), req)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, want, getChanges(a))
+	assert.Equal(t, want, getChanges(b))
+
+	for _, cr := range []*argoprojv1alpha1.ArgoCD{a, b, c} {
+		deleteInstanceMetrics(types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace})
+	}
+}
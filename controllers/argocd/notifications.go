@@ -4,12 +4,13 @@ import (
 	"context"
 	"fmt"
 	"reflect"
-	"time"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -38,6 +39,11 @@ func (r *ReconcileArgoCD) reconcileNotificationsController(cr *argoprojv1a1.Argo
 		return err
 	}
 
+	log.Info("reconciling notifications rbac for source namespaces")
+	if err := r.reconcileNotificationsSourceNamespacesRBAC(cr, sa); err != nil {
+		return err
+	}
+
 	log.Info("reconciling notifications configmap")
 	if err := r.reconcileNotificationsConfigMap(cr); err != nil {
 		return err
@@ -255,6 +261,114 @@ func (r *ReconcileArgoCD) reconcileNotificationsRoleBinding(cr *argoprojv1a1.Arg
 	return nil
 }
 
+// getNotificationsRoleNameForSourceNamespaces returns the name of the Role and RoleBinding of the notifications controller
+// in the source namespaces of the given ArgoCD.
+func getNotificationsRoleNameForSourceNamespaces(cr *argoprojv1a1.ArgoCD) string {
+	return fmt.Sprintf("%s_%s_%s", cr.Name, cr.Namespace, common.ArgoCDNotificationsControllerComponent)
+}
+
+// isNotificationsSelfServiceEnabled returns true if the notifications controller should process the notifications
+// configuration in the source namespaces of the given ArgoCD.
+func isNotificationsSelfServiceEnabled(cr *argoprojv1a1.ArgoCD) bool {
+	return cr.Spec.Notifications.Enabled && cr.Spec.Notifications.SelfServiceEnabled
+}
+
+// reconcileNotificationsSourceNamespacesRBAC creates the Role and RoleBinding allowing the notifications controller to read
+// the notifications configuration and Applications in each source namespace when self-service notifications are enabled,
+// and deletes them otherwise. Resources in namespaces removed from the source namespaces are deleted during their cleanup.
+func (r *ReconcileArgoCD) reconcileNotificationsSourceNamespacesRBAC(cr *argoprojv1a1.ArgoCD, sa *corev1.ServiceAccount) error {
+	name := getNotificationsRoleNameForSourceNamespaces(cr)
+
+	for _, sourceNamespace := range cr.Spec.SourceNamespaces {
+		if !r.Options.isNamespaceWatched(sourceNamespace) {
+			continue
+		}
+		namespace := &corev1.Namespace{}
+		if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: sourceNamespace}, namespace); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		desiredRole := &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: sourceNamespace,
+				Labels:    argoutil.LabelsForCluster(cr),
+			},
+			Rules: policyRuleForNotificationsController(),
+		}
+
+		desiredRoleBinding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: sourceNamespace,
+				Labels:    argoutil.LabelsForCluster(cr),
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     name,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      getServiceAccountName(cr.Name, common.ArgoCDNotificationsControllerComponent),
+					Namespace: cr.Namespace,
+				},
+			},
+		}
+
+		existingRole := &rbacv1.Role{}
+		roleExists := argoutil.IsObjectFound(r.Client, sourceNamespace, name, existingRole)
+		existingRoleBinding := &rbacv1.RoleBinding{}
+		roleBindingExists := argoutil.IsObjectFound(r.Client, sourceNamespace, name, existingRoleBinding)
+
+		if !isNotificationsSelfServiceEnabled(cr) {
+			if roleBindingExists {
+				log.Info(fmt.Sprintf("Deleting roleBinding %s in namespace %s as self-service notifications are disabled", name, sourceNamespace))
+				if err := r.Client.Delete(context.TODO(), existingRoleBinding); err != nil {
+					return err
+				}
+			}
+			if roleExists {
+				log.Info(fmt.Sprintf("Deleting role %s in namespace %s as self-service notifications are disabled", name, sourceNamespace))
+				if err := r.Client.Delete(context.TODO(), existingRole); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if !roleExists {
+			log.Info(fmt.Sprintf("Creating role %s in namespace %s", name, sourceNamespace))
+			if err := r.Client.Create(context.TODO(), desiredRole); err != nil {
+				return err
+			}
+		} else if !reflect.DeepEqual(existingRole.Rules, desiredRole.Rules) {
+			existingRole.Rules = desiredRole.Rules
+			if err := r.Client.Update(context.TODO(), existingRole); err != nil {
+				return err
+			}
+		}
+
+		if !roleBindingExists {
+			log.Info(fmt.Sprintf("Creating roleBinding %s in namespace %s", name, sourceNamespace))
+			if err := r.Client.Create(context.TODO(), desiredRoleBinding); err != nil {
+				return err
+			}
+		} else if !reflect.DeepEqual(existingRoleBinding.Subjects, desiredRoleBinding.Subjects) {
+			existingRoleBinding.Subjects = desiredRoleBinding.Subjects
+			if err := r.Client.Update(context.TODO(), existingRoleBinding); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoprojv1a1.ArgoCD, sa *corev1.ServiceAccount) error {
 
 	desiredDeployment := newDeploymentWithSuffix("notifications-controller", "controller", cr)
@@ -267,6 +381,11 @@ func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoprojv1a1.Argo
 		desiredDeployment.Spec.Replicas = replicas
 	}
 
+	if cr.Spec.Notifications.NodePlacement != nil {
+		desiredDeployment.Spec.Template.Spec.NodeSelector = argoutil.AppendStringMap(common.DefaultNodeSelector(), cr.Spec.Notifications.NodePlacement.NodeSelector)
+		desiredDeployment.Spec.Template.Spec.Tolerations = cr.Spec.Notifications.NodePlacement.Tolerations
+	}
+
 	notificationEnv := cr.Spec.Notifications.Env
 	// Let user specify their own environment first
 	notificationEnv = argoutil.EnvMerge(notificationEnv, proxyEnvVars(), false)
@@ -299,9 +418,11 @@ func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoprojv1a1.Argo
 		},
 	}
 
+	image := r.getArgoContainerImageForStage(cr, "notifications-controller")
+	setWorkloadArgoImage(desiredDeployment, image)
 	podSpec.Containers = []corev1.Container{{
 		Command:         getNotificationsCommand(cr),
-		Image:           getArgoContainerImage(cr),
+		Image:           image,
 		ImagePullPolicy: corev1.PullAlways,
 		Name:            common.ArgoCDNotificationsControllerComponent,
 		Env:             notificationEnv,
@@ -336,108 +457,26 @@ func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoprojv1a1.Argo
 		WorkingDir: "/app",
 	}}
 
-	// fetch existing deployment by name
-	deploymentChanged := false
-	existingDeployment := &appsv1.Deployment{}
-	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: desiredDeployment.Name, Namespace: cr.Namespace}, existingDeployment); err != nil {
-		if !errors.IsNotFound(err) {
-			return fmt.Errorf("failed to get the deployment associated with %s : %s", existingDeployment.Name, err)
-		}
-
-		// deployment does not exist and shouldn't, nothing to do here
-		if !cr.Spec.Notifications.Enabled {
-			return nil
-		}
-
-		// deployment does not exist but should, so it should be created
-		if err := controllerutil.SetControllerReference(cr, desiredDeployment, r.Scheme); err != nil {
-			return err
-		}
-
-		log.Info(fmt.Sprintf("Creating deployment %s", desiredDeployment.Name))
-		return r.Client.Create(context.TODO(), desiredDeployment)
-	}
-
-	// deployment exists but shouldn't, so it should be deleted
 	if !cr.Spec.Notifications.Enabled {
-		log.Info(fmt.Sprintf("Deleting deployment %s as notifications is disabled", existingDeployment.Name))
-		return r.Client.Delete(context.TODO(), existingDeployment)
-	}
-
-	// deployment exists and should. Reconcile deployment if changed
-	updateNodePlacement(existingDeployment, desiredDeployment, &deploymentChanged)
-
-	if existingDeployment.Spec.Template.Spec.Containers[0].Image != desiredDeployment.Spec.Template.Spec.Containers[0].Image {
-		existingDeployment.Spec.Template.Spec.Containers[0].Image = desiredDeployment.Spec.Template.Spec.Containers[0].Image
-		existingDeployment.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
-		deploymentChanged = true
-	}
-
-	if !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[0].Command, desiredDeployment.Spec.Template.Spec.Containers[0].Command) {
-		existingDeployment.Spec.Template.Spec.Containers[0].Command = desiredDeployment.Spec.Template.Spec.Containers[0].Command
-		deploymentChanged = true
-	}
-
-	if !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[0].Env,
-		desiredDeployment.Spec.Template.Spec.Containers[0].Env) {
-		existingDeployment.Spec.Template.Spec.Containers[0].Env = desiredDeployment.Spec.Template.Spec.Containers[0].Env
-		deploymentChanged = true
-	}
-
-	if !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Volumes, desiredDeployment.Spec.Template.Spec.Volumes) {
-		existingDeployment.Spec.Template.Spec.Volumes = desiredDeployment.Spec.Template.Spec.Volumes
-		deploymentChanged = true
-	}
-
-	if !reflect.DeepEqual(existingDeployment.Spec.Replicas, desiredDeployment.Spec.Replicas) {
-		existingDeployment.Spec.Replicas = desiredDeployment.Spec.Replicas
-		deploymentChanged = true
-	}
-
-	if !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[0].VolumeMounts, desiredDeployment.Spec.Template.Spec.Containers[0].VolumeMounts) {
-		existingDeployment.Spec.Template.Spec.Containers[0].VolumeMounts = desiredDeployment.Spec.Template.Spec.Containers[0].VolumeMounts
-		deploymentChanged = true
-	}
-
-	if !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[0].Resources, desiredDeployment.Spec.Template.Spec.Containers[0].Resources) {
-		existingDeployment.Spec.Template.Spec.Containers[0].Resources = desiredDeployment.Spec.Template.Spec.Containers[0].Resources
-		deploymentChanged = true
-	}
-
-	if !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.ServiceAccountName, desiredDeployment.Spec.Template.Spec.ServiceAccountName) {
-		existingDeployment.Spec.Template.Spec.ServiceAccountName = desiredDeployment.Spec.Template.Spec.ServiceAccountName
-		deploymentChanged = true
-	}
-
-	if !reflect.DeepEqual(existingDeployment.Labels, desiredDeployment.Labels) {
-		existingDeployment.Labels = desiredDeployment.Labels
-		deploymentChanged = true
-	}
-
-	if !reflect.DeepEqual(existingDeployment.Spec.Template.Labels, desiredDeployment.Spec.Template.Labels) {
-		existingDeployment.Spec.Template.Labels = desiredDeployment.Spec.Template.Labels
-		deploymentChanged = true
-	}
-
-	if !reflect.DeepEqual(existingDeployment.Spec.Selector, desiredDeployment.Spec.Selector) {
-		existingDeployment.Spec.Selector = desiredDeployment.Spec.Selector
-		deploymentChanged = true
-	}
-
-	if deploymentChanged {
-		return r.Client.Update(context.TODO(), existingDeployment)
+		// notifications is disabled, delete the deployment if it exists
+		existingDeployment := newDeploymentWithSuffix("notifications-controller", "controller", cr)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, existingDeployment.Name, existingDeployment) {
+			log.Info(fmt.Sprintf("Deleting deployment %s as notifications is disabled", existingDeployment.Name))
+			return r.Client.Delete(context.TODO(), existingDeployment)
+		}
+		return nil
 	}
 
-	return nil
-
+	return r.applyResource(cr, desiredDeployment)
 }
 
-// reconcileNotificationsConfigMap only creates/deletes the argocd-notifications-cm based on whether notifications is enabled/disabled in the CR
-// It does not reconcile/overwrite any fields or information in the configmap itself
+// reconcileNotificationsConfigMap creates/deletes the argocd-notifications-cm based on whether notifications is enabled/disabled in the CR
+// It only reconciles/overwrites the information in the configmap itself if templates, triggers or services are declared in the CR,
+// and removes the keys it sourced from the CR once they are no longer declared
 func (r *ReconcileArgoCD) reconcileNotificationsConfigMap(cr *argoprojv1a1.ArgoCD) error {
 
 	desiredConfigMap := newConfigMapWithName("argocd-notifications-cm", cr)
-	desiredConfigMap.Data = getDefaultNotificationsConfig()
+	desiredConfigMap.Data = getNotificationsConfig(cr)
 
 	cmExists := true
 	existingConfigMap := &corev1.ConfigMap{}
@@ -455,8 +494,41 @@ func (r *ReconcileArgoCD) reconcileNotificationsConfigMap(cr *argoprojv1a1.ArgoC
 			return r.Client.Delete(context.TODO(), existingConfigMap)
 		}
 
-		// CM exists and should, nothing to do here
-		return nil
+		// CM exists and should, make sure it matches the configuration declared in the CR. Once nothing is declared
+		// anymore, the keys that were sourced from the CR are removed, or reset to their defaults.
+		data := existingConfigMap.Data
+		if isNotificationsConfigManaged(cr) {
+			data = desiredConfigMap.Data
+		} else if managed := getManagedNotificationsConfigKeys(existingConfigMap); len(managed) > 0 {
+			data = make(map[string]string, len(existingConfigMap.Data))
+			for key, value := range existingConfigMap.Data {
+				data[key] = value
+			}
+			defaults := getDefaultNotificationsConfig()
+			for _, key := range managed {
+				if value, ok := defaults[key]; ok {
+					data[key] = value
+				} else {
+					delete(data, key)
+				}
+			}
+		}
+
+		keys := strings.Join(getNotificationsConfigKeys(cr), ",")
+		if reflect.DeepEqual(existingConfigMap.Data, data) && keys == existingConfigMap.Annotations[common.AnnotationNotificationsConfigKeys] {
+			return nil
+		}
+		existingConfigMap.Data = data
+		if keys == "" {
+			delete(existingConfigMap.Annotations, common.AnnotationNotificationsConfigKeys)
+		} else {
+			if existingConfigMap.Annotations == nil {
+				existingConfigMap.Annotations = make(map[string]string)
+			}
+			existingConfigMap.Annotations[common.AnnotationNotificationsConfigKeys] = keys
+		}
+		log.Info(fmt.Sprintf("Updating configmap %s", existingConfigMap.Name))
+		return r.Client.Update(context.TODO(), existingConfigMap)
 	}
 
 	// CM doesn't exist and shouldn't, nothing to do here
@@ -468,6 +540,12 @@ func (r *ReconcileArgoCD) reconcileNotificationsConfigMap(cr *argoprojv1a1.ArgoC
 	if err := controllerutil.SetControllerReference(cr, desiredConfigMap, r.Scheme); err != nil {
 		return err
 	}
+	if keys := getNotificationsConfigKeys(cr); len(keys) > 0 {
+		if desiredConfigMap.Annotations == nil {
+			desiredConfigMap.Annotations = make(map[string]string)
+		}
+		desiredConfigMap.Annotations[common.AnnotationNotificationsConfigKeys] = strings.Join(keys, ",")
+	}
 
 	log.Info(fmt.Sprintf("Creating configmap %s", desiredConfigMap.Name))
 	err := r.Client.Create(context.TODO(), desiredConfigMap)
@@ -478,12 +556,40 @@ func (r *ReconcileArgoCD) reconcileNotificationsConfigMap(cr *argoprojv1a1.ArgoC
 	return nil
 }
 
-// reconcileNotificationsSecret only creates/deletes the argocd-notifications-secret based on whether notifications is enabled/disabled in the CR
-// It does not reconcile/overwrite any fields or information in the secret itself
+// reconcileNotificationsSecret creates/deletes the argocd-notifications-secret based on whether notifications is enabled/disabled in the CR
+// It only reconciles/overwrites the information in the secret itself if a source secret is referenced in the CR
 func (r *ReconcileArgoCD) reconcileNotificationsSecret(cr *argoprojv1a1.ArgoCD) error {
 
 	desiredSecret := argoutil.NewSecretWithName(cr, "argocd-notifications-secret")
 
+	if cr.Spec.Notifications.Enabled && cr.Spec.Notifications.SecretName != "" {
+		sourceSecret := &corev1.Secret{}
+		if err := argoutil.FetchObject(r.Client, cr.Namespace, cr.Spec.Notifications.SecretName, sourceSecret); err != nil {
+			return fmt.Errorf("failed to get the notifications secret %s : %s", cr.Spec.Notifications.SecretName, err)
+		}
+		desiredSecret.Data = sourceSecret.Data
+	}
+
+	integrationRefs := getNotificationsIntegrationsSecretRefs(cr)
+	if cr.Spec.Notifications.Enabled && len(integrationRefs) > 0 {
+		data := make(map[string][]byte)
+		for key, value := range desiredSecret.Data {
+			data[key] = value
+		}
+		for key, ref := range integrationRefs {
+			sourceSecret := &corev1.Secret{}
+			if err := argoutil.FetchObject(r.Client, cr.Namespace, ref.Name, sourceSecret); err != nil {
+				return fmt.Errorf("failed to get the secret %s of the notifications integrations : %s", ref.Name, err)
+			}
+			value, ok := sourceSecret.Data[ref.Key]
+			if !ok {
+				return fmt.Errorf("key %s of the secret %s of the notifications integrations not found", ref.Key, ref.Name)
+			}
+			data[key] = value
+		}
+		desiredSecret.Data = data
+	}
+
 	secretExists := true
 	existingSecret := &corev1.Secret{}
 	if err := argoutil.FetchObject(r.Client, cr.Namespace, desiredSecret.Name, existingSecret); err != nil {
@@ -500,7 +606,13 @@ func (r *ReconcileArgoCD) reconcileNotificationsSecret(cr *argoprojv1a1.ArgoCD)
 			return r.Client.Delete(context.TODO(), existingSecret)
 		}
 
-		// secret exists and should, nothing to do here
+		// secret exists and should, make sure it matches the secrets referenced in the CR
+		secretManaged := cr.Spec.Notifications.SecretName != "" || len(integrationRefs) > 0
+		if secretManaged && !reflect.DeepEqual(existingSecret.Data, desiredSecret.Data) {
+			existingSecret.Data = desiredSecret.Data
+			log.Info(fmt.Sprintf("Updating secret %s", existingSecret.Name))
+			return r.Client.Update(context.TODO(), existingSecret)
+		}
 		return nil
 	}
 
@@ -531,12 +643,34 @@ func getNotificationsCommand(cr *argoprojv1a1.ArgoCD) []string {
 	cmd = append(cmd, "--loglevel")
 	cmd = append(cmd, getLogLevel(cr.Spec.Notifications.LogLevel))
 
+	if len(cr.Spec.SourceNamespaces) > 0 {
+		cmd = append(cmd, "--application-namespaces", strings.Join(cr.Spec.SourceNamespaces, ","))
+	}
+
+	if cr.Spec.Notifications.SelfServiceEnabled {
+		cmd = append(cmd, "--self-service-notification-enabled")
+	}
+
+	if cr.Spec.Notifications.LogFormat != "" {
+		cmd = append(cmd, "--logformat")
+		cmd = append(cmd, getLogFormat(cr.Spec.Notifications.LogFormat))
+	}
+
+	// Notifications command arguments provided by the user
+	extraArgs := cr.Spec.Notifications.ExtraCommandArgs
+	err := isMergable(extraArgs, cmd)
+	if err != nil {
+		return cmd
+	}
+
+	cmd = append(cmd, extraArgs...)
+
 	return cmd
 }
 
 // getNotificationsResources will return the ResourceRequirements for the Notifications container.
 func getNotificationsResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
-	resources := corev1.ResourceRequirements{}
+	resources := getDefaultResources(common.ArgoCDNotificationsControllerComponent)
 
 	// Allow override of resource requirements from CR
 	if cr.Spec.Notifications.Resources != nil {
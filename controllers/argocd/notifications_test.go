@@ -282,6 +282,234 @@ func TestReconcileNotifications_CreateConfigMap(t *testing.T) {
 	assertNotFound(t, err)
 }
 
+func TestReconcileNotifications_DeclarativeConfigMap(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Notifications.Enabled = true
+	})
+
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileNotificationsConfigMap(a))
+
+	a.Spec.Notifications.Templates = map[string]string{
+		"my-template": "message: Application {{.app.metadata.name}} has a custom notification.",
+	}
+	a.Spec.Notifications.Triggers = map[string]string{
+		"on-custom": "- send: [my-template]\n  when: app.status.sync.status == 'Unknown'",
+	}
+	a.Spec.Notifications.Services = map[string]string{
+		"slack": "token: $slack-token",
+	}
+	assert.NoError(t, r.reconcileNotificationsConfigMap(a))
+
+	testCm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-notifications-cm",
+		Namespace: a.Namespace,
+	}, testCm))
+	assert.Equal(t, a.Spec.Notifications.Templates["my-template"], testCm.Data["template.my-template"])
+	assert.Equal(t, a.Spec.Notifications.Triggers["on-custom"], testCm.Data["trigger.on-custom"])
+	assert.Equal(t, "token: $slack-token", testCm.Data["service.slack"])
+	assert.Contains(t, testCm.Data, "template.app-created")
+
+	// manual changes are reverted
+	testCm.Data["service.slack"] = "token: changed"
+	assert.NoError(t, r.Client.Update(context.TODO(), testCm))
+	assert.NoError(t, r.reconcileNotificationsConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-notifications-cm",
+		Namespace: a.Namespace,
+	}, testCm))
+	assert.Equal(t, "token: $slack-token", testCm.Data["service.slack"])
+}
+
+func TestReconcileNotifications_RemovedConfigMapKeys(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Notifications.Enabled = true
+		a.Spec.Notifications.Templates = map[string]string{
+			"my-template": "message: Application {{.app.metadata.name}} has a custom notification.",
+			"app-created": "message: Application {{.app.metadata.name}} was created.",
+		}
+		a.Spec.Notifications.Triggers = map[string]string{
+			"on-custom": "- send: [my-template]\n  when: app.status.sync.status == 'Unknown'",
+		}
+		a.Spec.Notifications.Services = map[string]string{
+			"slack": "token: $slack-token",
+		}
+	})
+
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileNotificationsConfigMap(a))
+
+	testCm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-notifications-cm",
+		Namespace: a.Namespace,
+	}, testCm))
+	assert.Equal(t, "service.slack,template.app-created,template.my-template,trigger.on-custom",
+		testCm.Annotations[common.AnnotationNotificationsConfigKeys])
+
+	// removing a single entry removes its key
+	delete(a.Spec.Notifications.Triggers, "on-custom")
+	assert.NoError(t, r.reconcileNotificationsConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-notifications-cm",
+		Namespace: a.Namespace,
+	}, testCm))
+	assert.NotContains(t, testCm.Data, "trigger.on-custom")
+	assert.Equal(t, "service.slack,template.app-created,template.my-template",
+		testCm.Annotations[common.AnnotationNotificationsConfigKeys])
+
+	// emptying the maps removes the remaining keys and restores the overridden defaults
+	a.Spec.Notifications.Templates = nil
+	a.Spec.Notifications.Triggers = nil
+	a.Spec.Notifications.Services = nil
+	assert.NoError(t, r.reconcileNotificationsConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-notifications-cm",
+		Namespace: a.Namespace,
+	}, testCm))
+	assert.NotContains(t, testCm.Data, "template.my-template")
+	assert.NotContains(t, testCm.Data, "service.slack")
+	assert.Equal(t, getDefaultNotificationsConfig()["template.app-created"], testCm.Data["template.app-created"])
+	assert.NotContains(t, testCm.Annotations, common.AnnotationNotificationsConfigKeys)
+
+	// manual changes are kept once nothing is declared in the CR
+	testCm.Data["service.slack"] = "token: $my-token"
+	assert.NoError(t, r.Client.Update(context.TODO(), testCm))
+	assert.NoError(t, r.reconcileNotificationsConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-notifications-cm",
+		Namespace: a.Namespace,
+	}, testCm))
+	assert.Equal(t, "token: $my-token", testCm.Data["service.slack"])
+}
+
+func TestReconcileNotifications_SecretFromSecretName(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Notifications.Enabled = true
+		a.Spec.Notifications.SecretName = "my-notifications-secret"
+	})
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-notifications-secret",
+			Namespace: a.Namespace,
+		},
+		Data: map[string][]byte{
+			"slack-token": []byte("token"),
+		},
+	}
+
+	r := makeTestReconciler(t, a, source)
+	assert.NoError(t, r.reconcileNotificationsSecret(a))
+
+	testSecret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-notifications-secret",
+		Namespace: a.Namespace,
+	}, testSecret))
+	assert.Equal(t, []byte("token"), testSecret.Data["slack-token"])
+
+	source.Data["slack-token"] = []byte("rotated")
+	assert.NoError(t, r.Client.Update(context.TODO(), source))
+	assert.NoError(t, r.reconcileNotificationsSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-notifications-secret",
+		Namespace: a.Namespace,
+	}, testSecret))
+	assert.Equal(t, []byte("rotated"), testSecret.Data["slack-token"])
+}
+
+func TestReconcileNotifications_Integrations(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Notifications.Enabled = true
+		a.Spec.Notifications.SecretName = "my-notifications-secret"
+		a.Spec.Notifications.Integrations = argoprojv1alpha1.ArgoCDNotificationsIntegrationsSpec{
+			Slack: &argoprojv1alpha1.ArgoCDNotificationsSlackSpec{
+				TokenSecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "chat-credentials"},
+					Key:                  "slack",
+				},
+			},
+			Email: &argoprojv1alpha1.ArgoCDNotificationsEmailSpec{
+				Host:     "smtp.example.com",
+				Port:     587,
+				From:     "argocd@example.com",
+				Username: "argocd",
+				PasswordSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "smtp-credentials"},
+					Key:                  "password",
+				},
+			},
+			Webhooks: []argoprojv1alpha1.ArgoCDNotificationsWebhookSpec{{
+				Name: "teams",
+				URLSecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "chat-credentials"},
+					Key:                  "teams",
+				},
+			}},
+		}
+	})
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-notifications-secret", Namespace: a.Namespace},
+		Data:       map[string][]byte{"github-token": []byte("github")},
+	}
+	chat := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "chat-credentials", Namespace: a.Namespace},
+		Data: map[string][]byte{
+			"slack": []byte("xoxb-token"),
+			"teams": []byte("https://example.webhook.office.com/token"),
+		},
+	}
+	smtp := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "smtp-credentials", Namespace: a.Namespace},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+
+	r := makeTestReconciler(t, a, source, chat, smtp)
+	assert.NoError(t, r.reconcileNotificationsSecret(a))
+	assert.NoError(t, r.reconcileNotificationsConfigMap(a))
+
+	testSecret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-notifications-secret",
+		Namespace: a.Namespace,
+	}, testSecret))
+	assert.Equal(t, map[string][]byte{
+		"github-token":      []byte("github"),
+		"slack-token":       []byte("xoxb-token"),
+		"email-password":    []byte("secret"),
+		"webhook-teams-url": []byte("https://example.webhook.office.com/token"),
+	}, testSecret.Data)
+
+	testCm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-notifications-cm",
+		Namespace: a.Namespace,
+	}, testCm))
+	assert.Equal(t, "token: $slack-token", testCm.Data["service.slack"])
+	assert.Equal(t, "host: smtp.example.com\nport: 587\nfrom: argocd@example.com\nusername: argocd\npassword: $email-password", testCm.Data["service.email"])
+	assert.Equal(t, "url: $webhook-teams-url", testCm.Data["service.webhook.teams"])
+
+	// rotating the token only requires an update of the referenced secret
+	chat.Data["slack"] = []byte("rotated")
+	assert.NoError(t, r.Client.Update(context.TODO(), chat))
+	assert.NoError(t, r.reconcileNotificationsSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-notifications-secret",
+		Namespace: a.Namespace,
+	}, testSecret))
+	assert.Equal(t, []byte("rotated"), testSecret.Data["slack-token"])
+
+	// a missing key is reported
+	delete(smtp.Data, "password")
+	assert.NoError(t, r.Client.Update(context.TODO(), smtp))
+	assert.Error(t, r.reconcileNotificationsSecret(a))
+}
+
 func TestReconcileNotifications_testEnvVars(t *testing.T) {
 
 	envMap := []corev1.EnvVar{
@@ -313,11 +541,12 @@ func TestReconcileNotifications_testEnvVars(t *testing.T) {
 		t.Fatalf("failed to reconcile notifications-controller deployment env:\n%s", diff)
 	}
 
-	// Verify any manual updates to the env vars should be overridden by the operator.
+	// Verify manual updates to the env vars set by the operator are overridden, while the env vars added by others
+	// are kept, as the Deployment is applied with server-side apply.
 	unwantedEnv := []corev1.EnvVar{
 		{
 			Name:  "foo",
-			Value: "bar",
+			Value: "baz",
 		},
 		{
 			Name:  "ping",
@@ -340,7 +569,7 @@ func TestReconcileNotifications_testEnvVars(t *testing.T) {
 		},
 		deployment))
 
-	if diff := cmp.Diff(envMap, deployment.Spec.Template.Spec.Containers[0].Env); diff != "" {
+	if diff := cmp.Diff(append(envMap, unwantedEnv[1]), deployment.Spec.Template.Spec.Containers[0].Env); diff != "" {
 		t.Fatalf("operator failed to override the manual changes to notification controller:\n%s", diff)
 	}
 }
@@ -403,3 +632,122 @@ func TestReconcileNotifications_testLogLevel(t *testing.T) {
 		t.Fatalf("operator failed to override the manual changes to notification controller:\n%s", diff)
 	}
 }
+
+func TestReconcileNotifications_testLogFormatAndExtraArgs(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Notifications.Enabled = true
+		a.Spec.Notifications.LogFormat = "json"
+		a.Spec.Notifications.ExtraCommandArgs = []string{"--self-service-notification-enabled"}
+	})
+
+	r := makeTestReconciler(t, a)
+
+	sa := corev1.ServiceAccount{}
+	assert.NoError(t, r.reconcileNotificationsDeployment(a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      a.Name + "-notifications-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+
+	expectedCMD := []string{
+		"argocd-notifications",
+		"--loglevel",
+		"info",
+		"--logformat",
+		"json",
+		"--self-service-notification-enabled",
+	}
+
+	if diff := cmp.Diff(expectedCMD, deployment.Spec.Template.Spec.Containers[0].Command); diff != "" {
+		t.Fatalf("failed to reconcile notifications-controller deployment command:\n%s", diff)
+	}
+
+	// ExtraCommandArgs must not override the default command arguments
+	a.Spec.Notifications.ExtraCommandArgs = []string{"--loglevel", "error"}
+	assert.NoError(t, r.reconcileNotificationsDeployment(a, &sa))
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      a.Name + "-notifications-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+
+	if diff := cmp.Diff(expectedCMD[:5], deployment.Spec.Template.Spec.Containers[0].Command); diff != "" {
+		t.Fatalf("failed to reconcile notifications-controller deployment command:\n%s", diff)
+	}
+}
+
+func TestReconcileNotifications_testNodePlacement(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Notifications.Enabled = true
+		a.Spec.NodePlacement = &argoprojv1alpha1.ArgoCDNodePlacementSpec{
+			NodeSelector: map[string]string{"global": "true"},
+		}
+	})
+
+	r := makeTestReconciler(t, a)
+
+	sa := corev1.ServiceAccount{}
+	assert.NoError(t, r.reconcileNotificationsDeployment(a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: a.Name + "-notifications-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, "true", deployment.Spec.Template.Spec.NodeSelector["global"])
+
+	// NodePlacement of the notifications controller takes precedence
+	tolerations := []corev1.Toleration{{Key: "notifications", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}}
+	a.Spec.Notifications.NodePlacement = &argoprojv1alpha1.ArgoCDNodePlacementSpec{
+		NodeSelector: map[string]string{"notifications": "true"},
+		Tolerations:  tolerations,
+	}
+	assert.NoError(t, r.reconcileNotificationsDeployment(a, &sa))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, argoutil.AppendStringMap(common.DefaultNodeSelector(), map[string]string{"notifications": "true"}), deployment.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, tolerations, deployment.Spec.Template.Spec.Tolerations)
+}
+
+func TestReconcileNotifications_SelfServiceSourceNamespaces(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	sourceNamespace := "team-a"
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Notifications.Enabled = true
+		a.Spec.Notifications.SelfServiceEnabled = true
+		a.Spec.SourceNamespaces = []string{sourceNamespace}
+	})
+
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, createNamespaceManagedByClusterArgoCDLabel(r, sourceNamespace, a.Namespace))
+
+	sa, err := r.reconcileNotificationsServiceAccount(a)
+	assert.NoError(t, err)
+	assert.NoError(t, r.reconcileNotificationsSourceNamespacesRBAC(a, sa))
+
+	name := getNotificationsRoleNameForSourceNamespaces(a)
+	role := &rbacv1.Role{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: sourceNamespace}, role))
+	assert.Equal(t, policyRuleForNotificationsController(), role.Rules)
+
+	roleBinding := &rbacv1.RoleBinding{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: sourceNamespace}, roleBinding))
+	assert.Equal(t, sa.Name, roleBinding.Subjects[0].Name)
+	assert.Equal(t, a.Namespace, roleBinding.Subjects[0].Namespace)
+
+	assert.NoError(t, r.reconcileNotificationsDeployment(a, sa))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-notifications-controller", Namespace: a.Namespace}, deployment))
+	assert.Subset(t, deployment.Spec.Template.Spec.Containers[0].Command, []string{"--application-namespaces", sourceNamespace, "--self-service-notification-enabled"})
+
+	// disabling self-service notifications removes the resources again
+	a.Spec.Notifications.SelfServiceEnabled = false
+	assert.NoError(t, r.reconcileNotificationsSourceNamespacesRBAC(a, sa))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: sourceNamespace}, role))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: sourceNamespace}, roleBinding))
+}
@@ -1,6 +1,15 @@
 package argocd
 
-import argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
 
 // getDefaultNotificationsConfig returns a map that contains default triggers and template configurations for argocd-notifications-cm
 func getDefaultNotificationsConfig() map[string]string {
@@ -543,6 +552,121 @@ teams:
 	return notificationsConfig
 }
 
+// isNotificationsConfigManaged returns true if the notifications templates, triggers, services or integrations are
+// declared in the CR, in which case the operator owns the content of argocd-notifications-cm.
+func isNotificationsConfigManaged(cr *argoprojv1a1.ArgoCD) bool {
+	return len(cr.Spec.Notifications.Templates) > 0 ||
+		len(cr.Spec.Notifications.Triggers) > 0 ||
+		len(cr.Spec.Notifications.Services) > 0 ||
+		len(getNotificationsIntegrationsSecretRefs(cr)) > 0
+}
+
+// getNotificationsIntegrationsSecretRefs returns the keys of argocd-notifications-secret holding the credentials of the
+// notification services configured through the integrations of the given ArgoCD, mapped to the Secret keys they are
+// sourced from.
+func getNotificationsIntegrationsSecretRefs(cr *argoprojv1a1.ArgoCD) map[string]corev1.SecretKeySelector {
+	integrations := cr.Spec.Notifications.Integrations
+	refs := make(map[string]corev1.SecretKeySelector)
+	if integrations.Slack != nil {
+		refs["slack-token"] = integrations.Slack.TokenSecretRef
+	}
+	if integrations.Email != nil && integrations.Email.PasswordSecretRef != nil {
+		refs["email-password"] = *integrations.Email.PasswordSecretRef
+	}
+	for _, webhook := range integrations.Webhooks {
+		refs[getNotificationsWebhookURLKey(webhook.Name)] = webhook.URLSecretRef
+	}
+	return refs
+}
+
+// getNotificationsWebhookURLKey returns the key of argocd-notifications-secret holding the URL of the webhook
+// integration with the given name.
+func getNotificationsWebhookURLKey(name string) string {
+	return fmt.Sprintf("webhook-%s-url", name)
+}
+
+// getNotificationsIntegrationsConfig returns the service.<name> entries of argocd-notifications-cm for the
+// integrations of the given ArgoCD. The credentials are referenced as keys of argocd-notifications-secret.
+func getNotificationsIntegrationsConfig(cr *argoprojv1a1.ArgoCD) map[string]string {
+	integrations := cr.Spec.Notifications.Integrations
+	services := make(map[string]string)
+	if integrations.Slack != nil {
+		services["service.slack"] = "token: $slack-token"
+	}
+	if email := integrations.Email; email != nil {
+		service := []string{
+			fmt.Sprintf("host: %s", email.Host),
+			fmt.Sprintf("port: %d", email.Port),
+			fmt.Sprintf("from: %s", email.From),
+		}
+		if email.Username != "" {
+			service = append(service, fmt.Sprintf("username: %s", email.Username))
+		}
+		if email.PasswordSecretRef != nil {
+			service = append(service, "password: $email-password")
+		}
+		services["service.email"] = strings.Join(service, "\n")
+	}
+	for _, webhook := range integrations.Webhooks {
+		services["service.webhook."+webhook.Name] = fmt.Sprintf("url: $%s", getNotificationsWebhookURLKey(webhook.Name))
+	}
+	return services
+}
+
+// getNotificationsConfig returns the content of argocd-notifications-cm, which consists of the default configuration
+// and the templates, triggers, integrations and services declared in the CR. The services take precedence over the
+// integrations of the same name.
+func getNotificationsConfig(cr *argoprojv1a1.ArgoCD) map[string]string {
+	notificationsConfig := getDefaultNotificationsConfig()
+
+	for name, template := range cr.Spec.Notifications.Templates {
+		notificationsConfig["template."+name] = template
+	}
+	for name, trigger := range cr.Spec.Notifications.Triggers {
+		notificationsConfig["trigger."+name] = trigger
+	}
+	for key, service := range getNotificationsIntegrationsConfig(cr) {
+		notificationsConfig[key] = service
+	}
+	for name, service := range cr.Spec.Notifications.Services {
+		notificationsConfig["service."+name] = service
+	}
+
+	return notificationsConfig
+}
+
+// getNotificationsConfigKeys returns the sorted keys of argocd-notifications-cm that are sourced from the templates,
+// triggers, integrations and services declared in the given ArgoCD.
+func getNotificationsConfigKeys(cr *argoprojv1a1.ArgoCD) []string {
+	keys := make([]string, 0)
+	for name := range cr.Spec.Notifications.Templates {
+		keys = append(keys, "template."+name)
+	}
+	for name := range cr.Spec.Notifications.Triggers {
+		keys = append(keys, "trigger."+name)
+	}
+	for key := range getNotificationsIntegrationsConfig(cr) {
+		if _, ok := cr.Spec.Notifications.Services[strings.TrimPrefix(key, "service.")]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	for name := range cr.Spec.Notifications.Services {
+		keys = append(keys, "service."+name)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getManagedNotificationsConfigKeys returns the keys of the given argocd-notifications-cm that were sourced from the
+// ArgoCD the last time it was reconciled.
+func getManagedNotificationsConfigKeys(cm *corev1.ConfigMap) []string {
+	keys := cm.Annotations[common.AnnotationNotificationsConfigKeys]
+	if keys == "" {
+		return nil
+	}
+	return strings.Split(keys, ",")
+}
+
 // getArgoCDNotificationsControllerReplicas will return the size value for the argocd-notifications-controller replica count if it
 // has been set in argocd CR. Otherwise, nil is returned if the replicas is not set in the argocd CR or
 // replicas value is < 0.
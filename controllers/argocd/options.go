@@ -0,0 +1,185 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const (
+	// defaultSyncPeriod is the default interval at which all ArgoCD instances are reconciled again.
+	defaultSyncPeriod = 10 * time.Hour
+
+	// defaultMaxConcurrentReconciles is the default number of ArgoCD instances reconciled in parallel.
+	defaultMaxConcurrentReconciles = 1
+
+	// defaultReconcileBaseDelay and defaultReconcileMaxDelay are the default bounds of the backoff of failed
+	// reconciliations, the same as the controller-runtime defaults.
+	defaultReconcileBaseDelay = 5 * time.Millisecond
+	defaultReconcileMaxDelay  = 1000 * time.Second
+)
+
+// ReconcilerOptions are the operator options that tune how often and how fast ArgoCD instances are reconciled.
+type ReconcilerOptions struct {
+	// SyncPeriod is the interval at which all ArgoCD instances are reconciled again.
+	SyncPeriod time.Duration
+	// MaxConcurrentReconciles is the number of ArgoCD instances reconciled in parallel.
+	MaxConcurrentReconciles int
+	// ReconcileBaseDelay is the initial delay before a failed reconciliation is retried. The delay doubles with every
+	// failure, up to ReconcileMaxDelay.
+	ReconcileBaseDelay time.Duration
+	// ReconcileMaxDelay is the maximum delay before a failed reconciliation is retried.
+	ReconcileMaxDelay time.Duration
+	// WatchNamespaces are the namespaces watched by the operator. All namespaces are watched if it is empty.
+	WatchNamespaces []string
+	// LabelSelector restricts the ArgoCD instances reconciled by the operator to those whose labels match it, e.g.
+	// "operator-shard=a". All instances are reconciled if it is empty.
+	LabelSelector string
+
+	labelSelector labels.Selector
+}
+
+// ParseWatchNamespaces returns the namespaces in the given comma separated list of namespaces to watch, e.g. the value
+// of the WATCH_NAMESPACE environment variable, sorted and without duplicates. An empty list, or a list containing "*",
+// watches all namespaces and results in no namespaces.
+func ParseWatchNamespaces(value string) []string {
+	var namespaces []string
+	for _, ns := range strings.Split(value, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "*" {
+			return nil
+		}
+		if ns != "" && !containsString(namespaces, ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// isNamespaceWatched returns true if the given namespace is watched by the operator. Resources in namespaces that are
+// not watched cannot be reconciled, as they are missing from the cache of the operator.
+func (o ReconcilerOptions) isNamespaceWatched(namespace string) bool {
+	return len(o.WatchNamespaces) == 0 || containsString(o.WatchNamespaces, namespace)
+}
+
+// getEnvDuration returns the duration held by the given environment variable, or the given fallback if it is not set.
+func getEnvDuration(name string, fallback time.Duration) (time.Duration, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q of %s: %w", v, name, err)
+	}
+	return d, nil
+}
+
+// getEnvInt returns the integer held by the given environment variable, or the given fallback if it is not set.
+func getEnvInt(name string, fallback int) (int, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return fallback, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q of %s: %w", v, name, err)
+	}
+	return i, nil
+}
+
+// Complete sets the options that are not set, e.g. by command line flags, from their environment variables or to
+// their defaults, and validates the result. The label selector is only applied once the options are complete.
+func (o *ReconcilerOptions) Complete() error {
+	var err error
+	if o.SyncPeriod == 0 {
+		if o.SyncPeriod, err = getEnvDuration(common.ArgoCDSyncPeriodEnvName, defaultSyncPeriod); err != nil {
+			return err
+		}
+	}
+	if o.MaxConcurrentReconciles == 0 {
+		if o.MaxConcurrentReconciles, err = getEnvInt(common.ArgoCDMaxConcurrentReconcilesEnvName, defaultMaxConcurrentReconciles); err != nil {
+			return err
+		}
+	}
+	if o.ReconcileBaseDelay == 0 {
+		if o.ReconcileBaseDelay, err = getEnvDuration(common.ArgoCDReconcileBaseDelayEnvName, defaultReconcileBaseDelay); err != nil {
+			return err
+		}
+	}
+	if o.ReconcileMaxDelay == 0 {
+		if o.ReconcileMaxDelay, err = getEnvDuration(common.ArgoCDReconcileMaxDelayEnvName, defaultReconcileMaxDelay); err != nil {
+			return err
+		}
+	}
+	if o.LabelSelector == "" {
+		o.LabelSelector = os.Getenv(common.ArgoCDLabelSelectorEnvName)
+	}
+
+	if o.SyncPeriod <= 0 {
+		return fmt.Errorf("sync period must be positive, got %s", o.SyncPeriod)
+	}
+	if o.MaxConcurrentReconciles <= 0 {
+		return fmt.Errorf("max concurrent reconciles must be positive, got %d", o.MaxConcurrentReconciles)
+	}
+	if o.ReconcileBaseDelay <= 0 || o.ReconcileMaxDelay < o.ReconcileBaseDelay {
+		return fmt.Errorf("reconcile delays must be positive with the base delay not above the max delay, got %s and %s",
+			o.ReconcileBaseDelay, o.ReconcileMaxDelay)
+	}
+	if o.LabelSelector != "" {
+		if o.labelSelector, err = labels.Parse(o.LabelSelector); err != nil {
+			return fmt.Errorf("invalid label selector %q: %w", o.LabelSelector, err)
+		}
+	}
+	// The sizing profile is read from the environment of the operator whenever the resources of a component are built.
+	return validateSizingProfile(getSizingProfile())
+}
+
+// matchesLabelSelector returns true if the given ArgoCD is reconciled by the operator, i.e. if its labels match the
+// label selector of the options.
+func (o ReconcilerOptions) matchesLabelSelector(obj client.Object) bool {
+	if o.labelSelector == nil {
+		return true
+	}
+	return o.labelSelector.Matches(labels.Set(obj.GetLabels()))
+}
+
+// controllerOptions returns the options of the ArgoCD controller. Options that are not set keep the controller-runtime
+// defaults.
+func (o ReconcilerOptions) controllerOptions() controller.Options {
+	opts := controller.Options{MaxConcurrentReconciles: o.MaxConcurrentReconciles}
+	if o.ReconcileBaseDelay > 0 && o.ReconcileMaxDelay > 0 {
+		// Keep the overall rate limit of the default controller rate limiter.
+		opts.RateLimiter = workqueue.NewMaxOfRateLimiter(
+			workqueue.NewItemExponentialFailureRateLimiter(o.ReconcileBaseDelay, o.ReconcileMaxDelay),
+			&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+		)
+	}
+	return opts
+}
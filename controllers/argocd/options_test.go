@@ -0,0 +1,98 @@
+package argocd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcilerOptions_Complete(t *testing.T) {
+	opts := ReconcilerOptions{}
+	assert.NoError(t, opts.Complete())
+	assert.Equal(t, ReconcilerOptions{
+		SyncPeriod:              defaultSyncPeriod,
+		MaxConcurrentReconciles: defaultMaxConcurrentReconciles,
+		ReconcileBaseDelay:      defaultReconcileBaseDelay,
+		ReconcileMaxDelay:       defaultReconcileMaxDelay,
+	}, opts)
+
+	// options that are set, e.g. by flags, take precedence over the environment
+	t.Setenv(common.ArgoCDSyncPeriodEnvName, "30m")
+	t.Setenv(common.ArgoCDMaxConcurrentReconcilesEnvName, "4")
+	t.Setenv(common.ArgoCDReconcileMaxDelayEnvName, "5m")
+	opts = ReconcilerOptions{ReconcileBaseDelay: time.Second}
+	assert.NoError(t, opts.Complete())
+	assert.Equal(t, ReconcilerOptions{
+		SyncPeriod:              30 * time.Minute,
+		MaxConcurrentReconciles: 4,
+		ReconcileBaseDelay:      time.Second,
+		ReconcileMaxDelay:       5 * time.Minute,
+	}, opts)
+}
+
+func TestReconcilerOptions_Complete_invalid(t *testing.T) {
+	t.Setenv(common.ArgoCDSyncPeriodEnvName, "often")
+	assert.Error(t, (&ReconcilerOptions{}).Complete())
+
+	t.Setenv(common.ArgoCDSyncPeriodEnvName, "")
+	t.Setenv(common.ArgoCDMaxConcurrentReconcilesEnvName, "-1")
+	assert.Error(t, (&ReconcilerOptions{}).Complete())
+
+	t.Setenv(common.ArgoCDMaxConcurrentReconcilesEnvName, "")
+	assert.Error(t, (&ReconcilerOptions{ReconcileBaseDelay: time.Minute, ReconcileMaxDelay: time.Second}).Complete())
+
+	t.Setenv(common.ArgoCDSizingProfileEnvName, "huge")
+	assert.EqualError(t, (&ReconcilerOptions{}).Complete(), `invalid value "huge" of ARGOCD_SIZING_PROFILE, must be one of large, medium, small`)
+}
+
+func TestReconcilerOptions_controllerOptions(t *testing.T) {
+	// options that are not set keep the controller-runtime defaults
+	opts := ReconcilerOptions{}.controllerOptions()
+	assert.Zero(t, opts.MaxConcurrentReconciles)
+	assert.Nil(t, opts.RateLimiter)
+
+	opts = ReconcilerOptions{MaxConcurrentReconciles: 4, ReconcileBaseDelay: time.Second, ReconcileMaxDelay: 4 * time.Second}.controllerOptions()
+	assert.Equal(t, 4, opts.MaxConcurrentReconciles)
+	assert.Equal(t, time.Second, opts.RateLimiter.When("argocd"))
+	assert.Equal(t, 2*time.Second, opts.RateLimiter.When("argocd"))
+	assert.Equal(t, 4*time.Second, opts.RateLimiter.When("argocd"))
+	assert.Equal(t, 4*time.Second, opts.RateLimiter.When("argocd"))
+}
+
+func TestReconcilerOptions_matchesLabelSelector(t *testing.T) {
+	a := makeTestArgoCD()
+
+	// all instances match options that are not complete, or without a label selector
+	assert.True(t, ReconcilerOptions{LabelSelector: "shard=a"}.matchesLabelSelector(a))
+	opts := ReconcilerOptions{}
+	assert.NoError(t, opts.Complete())
+	assert.True(t, opts.matchesLabelSelector(a))
+
+	t.Setenv(common.ArgoCDLabelSelectorEnvName, "shard in (a, b)")
+	opts = ReconcilerOptions{}
+	assert.NoError(t, opts.Complete())
+	assert.False(t, opts.matchesLabelSelector(a))
+	a.Labels = map[string]string{"shard": "b"}
+	assert.True(t, opts.matchesLabelSelector(a))
+
+	t.Setenv(common.ArgoCDLabelSelectorEnvName, "shard in (a")
+	assert.Error(t, (&ReconcilerOptions{}).Complete())
+}
+
+func TestParseWatchNamespaces(t *testing.T) {
+	assert.Nil(t, ParseWatchNamespaces(""))
+	assert.Nil(t, ParseWatchNamespaces("tenant-a,*"))
+	assert.Equal(t, []string{"argocd"}, ParseWatchNamespaces("argocd"))
+	assert.Equal(t, []string{"argocd", "tenant-a", "tenant-b"}, ParseWatchNamespaces(" tenant-b, argocd,,tenant-a,argocd "))
+}
+
+func TestReconcilerOptions_isNamespaceWatched(t *testing.T) {
+	assert.True(t, ReconcilerOptions{}.isNamespaceWatched("tenant-a"))
+
+	opts := ReconcilerOptions{WatchNamespaces: []string{"argocd", "tenant-a"}}
+	assert.True(t, opts.isNamespaceWatched("tenant-a"))
+	assert.False(t, opts.isNamespaceWatched("tenant-b"))
+}
@@ -0,0 +1,135 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// getAdminPasswordRotationInterval will return the admin password rotation interval for the given ArgoCD, or zero if
+// the admin password is not rotated on an interval.
+func getAdminPasswordRotationInterval(cr *argoprojv1a1.ArgoCD) time.Duration {
+	if cr.Spec.AdminPasswordSecretRef != nil || cr.Spec.AdminPasswordRotation == nil || cr.Spec.AdminPasswordRotation.Interval == nil {
+		return 0
+	}
+	return cr.Spec.AdminPasswordRotation.Interval.Duration
+}
+
+// isAdminPasswordRotationRequested returns true if the rotation of the admin password was requested through the
+// rotate-admin-password annotation of the given ArgoCD.
+func isAdminPasswordRotationRequested(cr *argoprojv1a1.ArgoCD) bool {
+	return cr.Annotations[common.AnnotationRotateAdminPassword] == "true"
+}
+
+// getAdminPasswordRotatedAt returns the time the admin password in the given cluster secret was last rotated, falling
+// back to the creation time of the secret.
+func getAdminPasswordRotatedAt(secret *corev1.Secret) time.Time {
+	if rotatedAt, err := time.Parse(time.RFC3339, secret.Annotations[common.AnnotationAdminPasswordRotatedAt]); err == nil {
+		return rotatedAt
+	}
+	return secret.CreationTimestamp.Time
+}
+
+// getAdminPasswordRotationRequeueAfter returns the time until the admin password of the given ArgoCD is due for
+// rotation, or zero if the admin password is not rotated on an interval.
+func (r *ReconcileArgoCD) getAdminPasswordRotationRequeueAfter(cr *argoprojv1a1.ArgoCD) time.Duration {
+	interval := getAdminPasswordRotationInterval(cr)
+	if interval <= 0 {
+		return 0
+	}
+
+	secret := argoutil.NewSecretWithSuffix(cr, "cluster")
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+		return 0
+	}
+
+	remaining := time.Until(getAdminPasswordRotatedAt(secret).Add(interval))
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// reconcileAdminPasswordRotation will regenerate the admin password in the cluster secret when the rotation interval
+// has elapsed, or when rotation was requested through the rotate-admin-password annotation of the given ArgoCD. The
+// Argo CD and Grafana secrets pick up the new password from the cluster secret. The annotation is removed before the
+// password is rotated, so that a retried reconciliation does not rotate the password twice for a request.
+func (r *ReconcileArgoCD) reconcileAdminPasswordRotation(cr *argoprojv1a1.ArgoCD) error {
+	requested := isAdminPasswordRotationRequested(cr)
+	interval := getAdminPasswordRotationInterval(cr)
+	if !requested && interval <= 0 {
+		return nil // Rotation not requested, do nothing.
+	}
+
+	if cr.Spec.AdminPasswordSecretRef != nil {
+		log.Info(fmt.Sprintf("admin password of Argo CD instance %s is sourced from secret %s, skipping rotation", cr.Name, cr.Spec.AdminPasswordSecretRef.Name))
+		return nil
+	}
+
+	secret := argoutil.NewSecretWithSuffix(cr, "cluster")
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+		log.Info(fmt.Sprintf("cluster secret [%s] not found, waiting to rotate the admin password", secret.Name))
+		return nil
+	}
+
+	if !requested && time.Since(getAdminPasswordRotatedAt(secret)) < interval {
+		return nil // Rotation not due yet, move along...
+	}
+
+	if requested {
+		// The patch does not conflict with changes made to the ArgoCD in the meantime. Only the metadata is taken
+		// from the patched ArgoCD, so that the status changed during the reconciliation is kept.
+		patched := cr.DeepCopy()
+		delete(patched.Annotations, common.AnnotationRotateAdminPassword)
+		if err := r.Client.Patch(context.TODO(), patched, client.MergeFrom(cr)); err != nil {
+			return err
+		}
+		cr.ObjectMeta = patched.ObjectMeta
+	}
+
+	adminPassword, err := generateArgoAdminPassword()
+	if err != nil {
+		return err
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[common.ArgoCDKeyAdminPassword] = adminPassword
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[common.AnnotationAdminPasswordRotatedAt] = time.Now().UTC().Format(time.RFC3339)
+
+	log.Info(fmt.Sprintf("rotating the admin password of Argo CD instance %s in namespace %s", cr.Name, cr.Namespace))
+	if err := r.Client.Update(context.TODO(), secret); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("The admin password was rotated, the new password is stored in secret %s.", secret.Name)
+	if err := argoutil.CreateEvent(r.Client, "Normal", "Rotated", message, "AdminPasswordRotated", cr.ObjectMeta, cr.TypeMeta); err != nil {
+		log.Error(err, "failed to create event for admin password rotation")
+	}
+	return nil
+}
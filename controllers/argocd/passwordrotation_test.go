@@ -0,0 +1,171 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func makeTestClusterSecret(a *argoprojv1alpha1.ArgoCD, rotatedAt time.Time) *corev1.Secret {
+	secret := argoutil.NewSecretWithSuffix(a, "cluster")
+	secret.Annotations = map[string]string{
+		common.AnnotationAdminPasswordRotatedAt: rotatedAt.UTC().Format(time.RFC3339),
+	}
+	secret.Data = map[string][]byte{
+		common.ArgoCDKeyAdminPassword: []byte("initial"),
+	}
+	return secret
+}
+
+func TestReconcileArgoCD_reconcileAdminPasswordRotation_requested(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Annotations = map[string]string{common.AnnotationRotateAdminPassword: "true"}
+	})
+	r := makeTestReconciler(t, a, makeTestClusterSecret(a, time.Now()))
+
+	assert.NoError(t, r.reconcileAdminPasswordRotation(a))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster", Namespace: a.Namespace}, secret))
+	assert.NotEqual(t, "initial", string(secret.Data[common.ArgoCDKeyAdminPassword]))
+
+	// the annotation is removed once the password is rotated
+	cr := &argoprojv1alpha1.ArgoCD{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, cr))
+	_, ok := cr.Annotations[common.AnnotationRotateAdminPassword]
+	assert.False(t, ok)
+
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events))
+	assert.Len(t, events.Items, 1)
+	assert.Equal(t, "AdminPasswordRotated", events.Items[0].Reason)
+}
+
+// conflictingClient fails the given number of writes to ArgoCDs with a conflict.
+type conflictingClient struct {
+	client.Client
+	conflicts int
+}
+
+func (c *conflictingClient) conflict(obj client.Object) error {
+	if _, ok := obj.(*argoprojv1alpha1.ArgoCD); !ok || c.conflicts == 0 {
+		return nil
+	}
+	c.conflicts--
+	return errors.NewConflict(schema.GroupResource{Group: "argoproj.io", Resource: "argocds"}, obj.GetName(), nil)
+}
+
+func (c *conflictingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.conflict(obj); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *conflictingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.conflict(obj); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func TestReconcileArgoCD_reconcileAdminPasswordRotation_conflict(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Annotations = map[string]string{common.AnnotationRotateAdminPassword: "true"}
+	})
+	r := makeTestReconciler(t, a, makeTestClusterSecret(a, time.Now()))
+	r.Client = &conflictingClient{Client: r.Client, conflicts: 1}
+	getPassword := func() string {
+		secret := &corev1.Secret{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster", Namespace: a.Namespace}, secret))
+		return string(secret.Data[common.ArgoCDKeyAdminPassword])
+	}
+
+	// the password is not rotated when the request cannot be removed
+	assert.Error(t, r.reconcileAdminPasswordRotation(a))
+	assert.Equal(t, "initial", getPassword())
+
+	// the request is removed from a stale ArgoCD, and the password is rotated once
+	cr := &argoprojv1alpha1.ArgoCD{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, cr))
+	cr.Labels = map[string]string{"example": "conflict"}
+	assert.NoError(t, r.Client.Update(context.TODO(), cr))
+
+	assert.NoError(t, r.reconcileAdminPasswordRotation(a))
+	rotated := getPassword()
+	assert.NotEqual(t, "initial", rotated)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, cr))
+	_, ok := cr.Annotations[common.AnnotationRotateAdminPassword]
+	assert.False(t, ok)
+	assert.Equal(t, "conflict", cr.Labels["example"])
+
+	// a retried reconciliation does not rotate the password again
+	assert.NoError(t, r.reconcileAdminPasswordRotation(cr))
+	assert.Equal(t, rotated, getPassword())
+}
+
+func TestReconcileArgoCD_reconcileAdminPasswordRotation_interval(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.AdminPasswordRotation = &argoprojv1alpha1.ArgoCDAdminPasswordRotationSpec{
+			Interval: &metav1.Duration{Duration: 24 * time.Hour},
+		}
+	})
+	r := makeTestReconciler(t, a, makeTestClusterSecret(a, time.Now().Add(-time.Hour)))
+
+	// the password is not rotated before the interval elapses
+	assert.NoError(t, r.reconcileAdminPasswordRotation(a))
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster", Namespace: a.Namespace}, secret))
+	assert.Equal(t, "initial", string(secret.Data[common.ArgoCDKeyAdminPassword]))
+
+	requeueAfter := r.getAdminPasswordRotationRequeueAfter(a)
+	assert.True(t, requeueAfter > 22*time.Hour && requeueAfter <= 23*time.Hour)
+
+	// the password is rotated once the interval elapsed
+	secret.Annotations[common.AnnotationAdminPasswordRotatedAt] = time.Now().Add(-25 * time.Hour).UTC().Format(time.RFC3339)
+	assert.NoError(t, r.Client.Update(context.TODO(), secret))
+
+	assert.NoError(t, r.reconcileAdminPasswordRotation(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster", Namespace: a.Namespace}, secret))
+	assert.NotEqual(t, "initial", string(secret.Data[common.ArgoCDKeyAdminPassword]))
+	assert.True(t, r.getAdminPasswordRotationRequeueAfter(a) > 23*time.Hour)
+}
+
+func TestReconcileArgoCD_reconcileAdminPasswordRotation_adminPasswordSecretRef(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Annotations = map[string]string{common.AnnotationRotateAdminPassword: "true"}
+		a.Spec.AdminPasswordSecretRef = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "admin-credentials"},
+			Key:                  "password",
+		}
+		a.Spec.AdminPasswordRotation = &argoprojv1alpha1.ArgoCDAdminPasswordRotationSpec{
+			Interval: &metav1.Duration{Duration: time.Hour},
+		}
+	})
+	r := makeTestReconciler(t, a, makeTestClusterSecret(a, time.Now().Add(-48*time.Hour)))
+
+	assert.NoError(t, r.reconcileAdminPasswordRotation(a))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster", Namespace: a.Namespace}, secret))
+	assert.Equal(t, "initial", string(secret.Data[common.ArgoCDKeyAdminPassword]))
+	assert.Equal(t, time.Duration(0), r.getAdminPasswordRotationRequeueAfter(a))
+}
@@ -0,0 +1,91 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// newPodDisruptionBudgetWithSuffix returns a new PodDisruptionBudget instance for the given ArgoCD using the given
+// suffix. The PodDisruptionBudget selects the Pods of the Deployment with the same name.
+func newPodDisruptionBudgetWithSuffix(suffix string, cr *argoprojv1a1.ArgoCD) *policyv1.PodDisruptionBudget {
+	name := nameWithSuffix(suffix, cr)
+
+	lbls := argoutil.LabelsForCluster(cr)
+	lbls[common.ArgoCDKeyName] = name
+
+	maxUnavailable := intstr.FromInt(1)
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    lbls,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			// Unlike minAvailable, maxUnavailable does not block the eviction of the Pods of a Deployment scaled down
+			// to a single replica.
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					common.ArgoCDKeyName: name,
+				},
+			},
+		},
+	}
+}
+
+// reconcilePodDisruptionBudget will ensure that the PodDisruptionBudget with the given suffix is present for the
+// Deployment with the same suffix when HA is enabled, and removed otherwise.
+func (r *ReconcileArgoCD) reconcilePodDisruptionBudget(suffix string, cr *argoprojv1a1.ArgoCD) error {
+	pdb := newPodDisruptionBudgetWithSuffix(suffix, cr)
+
+	existing := &policyv1.PodDisruptionBudget{}
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, pdb.Name, existing) {
+		// Only modify PodDisruptionBudgets that were created by the operator.
+		if !metav1.IsControlledBy(existing, cr) {
+			return nil
+		}
+		if !cr.Spec.HA.Enabled {
+			log.Info(fmt.Sprintf("deleting pod disruption budget %s", existing.Name))
+			return r.Client.Delete(context.TODO(), existing)
+		}
+		if !equality.Semantic.DeepEqual(existing.Spec, pdb.Spec) {
+			existing.Spec = pdb.Spec
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // PodDisruptionBudget found with nothing to do, move along...
+	}
+
+	if !cr.Spec.HA.Enabled {
+		return nil // HA not enabled, do nothing.
+	}
+
+	if err := controllerutil.SetControllerReference(cr, pdb, r.Scheme); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("creating pod disruption budget %s", pdb.Name))
+	return r.Client.Create(context.TODO(), pdb)
+}
@@ -0,0 +1,86 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcileArgoCD_reconcilePodDisruptionBudget(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	names := []string{"argocd-server", "argocd-repo-server"}
+
+	// no PodDisruptionBudgets without HA
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+	assert.NoError(t, r.reconcileRepoServerWorkload(a, false))
+	for _, name := range names {
+		assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, &policyv1.PodDisruptionBudget{}))
+	}
+
+	a.Spec.HA.Enabled = true
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+	assert.NoError(t, r.reconcileRepoServerWorkload(a, false))
+	for _, name := range names {
+		pdb := &policyv1.PodDisruptionBudget{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, pdb))
+		assert.Equal(t, intstr.FromInt(1), *pdb.Spec.MaxUnavailable)
+		assert.Nil(t, pdb.Spec.MinAvailable)
+		assert.Equal(t, map[string]string{common.ArgoCDKeyName: name}, pdb.Spec.Selector.MatchLabels)
+	}
+
+	// changes to the PodDisruptionBudgets are reverted
+	pdb := &policyv1.PodDisruptionBudget{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: names[0], Namespace: a.Namespace}, pdb))
+	minAvailable := intstr.FromInt(2)
+	pdb.Spec.MaxUnavailable = nil
+	pdb.Spec.MinAvailable = &minAvailable
+	assert.NoError(t, r.Client.Update(context.TODO(), pdb))
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+	assert.NoError(t, r.reconcileRepoServerWorkload(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: names[0], Namespace: a.Namespace}, pdb))
+	assert.Equal(t, intstr.FromInt(1), *pdb.Spec.MaxUnavailable)
+	assert.Nil(t, pdb.Spec.MinAvailable)
+
+	// the PodDisruptionBudgets are removed with HA
+	a.Spec.HA.Enabled = false
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+	assert.NoError(t, r.reconcileRepoServerWorkload(a, false))
+	for _, name := range names {
+		assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, &policyv1.PodDisruptionBudget{}))
+	}
+}
+
+func TestReconcileArgoCD_reconcilePodDisruptionBudget_unmanaged(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	minAvailable := intstr.FromInt(2)
+	existing := newPodDisruptionBudgetWithSuffix("server", a)
+	existing.Spec.MaxUnavailable = nil
+	existing.Spec.MinAvailable = &minAvailable
+	r := makeTestReconciler(t, a, existing)
+
+	// a PodDisruptionBudget not created by the operator is left untouched
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+	assert.NoError(t, r.reconcileRepoServerWorkload(a, false))
+	pdb := &policyv1.PodDisruptionBudget{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: existing.Name, Namespace: a.Namespace}, pdb))
+	assert.Equal(t, minAvailable, *pdb.Spec.MinAvailable)
+
+	a.Spec.HA.Enabled = false
+	assert.NoError(t, r.reconcileServerWorkload(a, false))
+	assert.NoError(t, r.reconcileRepoServerWorkload(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: existing.Name, Namespace: a.Namespace}, pdb))
+}
@@ -146,6 +146,74 @@ func policyRuleForServer() []v1.PolicyRule {
 	}
 }
 
+// policyRuleForArgoCDAgent returns the rules of the argocd-agent principal and agent, which synchronize the Argo CD
+// resources between the control plane and the workload clusters.
+func policyRuleForArgoCDAgent() []v1.PolicyRule {
+	return []v1.PolicyRule{
+		{
+			APIGroups: []string{
+				"argoproj.io",
+			},
+			Resources: []string{
+				"applications",
+				"appprojects",
+				"applicationsets",
+			},
+			Verbs: []string{
+				"create",
+				"delete",
+				"get",
+				"list",
+				"patch",
+				"update",
+				"watch",
+			},
+		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"secrets",
+			},
+			Verbs: []string{
+				"create",
+				"delete",
+				"get",
+				"list",
+				"patch",
+				"update",
+				"watch",
+			},
+		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"configmaps",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+				"watch",
+			},
+		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"events",
+			},
+			Verbs: []string{
+				"create",
+				"patch",
+			},
+		},
+	}
+}
+
 func policyRuleForNotificationsController() []v1.PolicyRule {
 	return []v1.PolicyRule{
 
@@ -17,8 +17,11 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -71,7 +74,53 @@ func hasPrometheusSpecChanged(actual *monitoringv1.Prometheus, desired *argoproj
 			return true
 		}
 	}
-	return false
+
+	if actual.Spec.Retention != desired.Spec.Prometheus.Retention ||
+		actual.Spec.RetentionSize != desired.Spec.Prometheus.RetentionSize {
+		return true
+	}
+	if !equality.Semantic.DeepEqual(actual.Spec.ExternalLabels, desired.Spec.Prometheus.ExternalLabels) {
+		return true
+	}
+	if !equality.Semantic.DeepEqual(actual.Spec.Resources, getPrometheusResources(desired)) {
+		return true
+	}
+	return !equality.Semantic.DeepEqual(actual.Spec.Storage, getPrometheusStorage(desired))
+}
+
+// getPrometheusResources will return the ResourceRequirements for the Prometheus container.
+func getPrometheusResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
+	resources := corev1.ResourceRequirements{}
+
+	// Allow override of resource requirements from CR
+	if cr.Spec.Prometheus.Resources != nil {
+		resources = *cr.Spec.Prometheus.Resources
+	}
+
+	return resources
+}
+
+// getPrometheusStorage will return the storage for the Prometheus StatefulSet, or nil when no PersistentVolumeClaim
+// template is set.
+func getPrometheusStorage(cr *argoprojv1a1.ArgoCD) *monitoringv1.StorageSpec {
+	if cr.Spec.Prometheus.Storage == nil {
+		return nil
+	}
+	return &monitoringv1.StorageSpec{
+		VolumeClaimTemplate: monitoringv1.EmbeddedPersistentVolumeClaim{
+			Spec: *cr.Spec.Prometheus.Storage,
+		},
+	}
+}
+
+// setPrometheusSpec will set the properties of the given Prometheus that are managed through the given ArgoCD.
+func setPrometheusSpec(prometheus *monitoringv1.Prometheus, cr *argoprojv1a1.ArgoCD) {
+	prometheus.Spec.Replicas = getPrometheusReplicas(cr)
+	prometheus.Spec.Retention = cr.Spec.Prometheus.Retention
+	prometheus.Spec.RetentionSize = cr.Spec.Prometheus.RetentionSize
+	prometheus.Spec.ExternalLabels = cr.Spec.Prometheus.ExternalLabels
+	prometheus.Spec.Resources = getPrometheusResources(cr)
+	prometheus.Spec.Storage = getPrometheusStorage(cr)
 }
 
 // verifyPrometheusAPI will verify that the Prometheus API is present.
@@ -126,28 +175,58 @@ func newServiceMonitorWithSuffix(suffix string, cr *argoprojv1a1.ArgoCD) *monito
 
 // reconcileMetricsServiceMonitor will ensure that the ServiceMonitor is present for the ArgoCD metrics Service.
 func (r *ReconcileArgoCD) reconcileMetricsServiceMonitor(cr *argoprojv1a1.ArgoCD) error {
-	sm := newServiceMonitorWithSuffix(common.ArgoCDKeyMetrics, cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
-		if !cr.Spec.Prometheus.Enabled {
-			// ServiceMonitor exists but enabled flag has been set to false, delete the ServiceMonitor
-			return r.Client.Delete(context.TODO(), sm)
-		}
-		return nil // ServiceMonitor found, do nothing
-	}
+	return r.reconcileServiceMonitor(common.ArgoCDKeyMetrics, common.ArgoCDKeyMetrics, cr.Spec.Controller.Metrics.ServiceMonitor, cr)
+}
 
-	if !cr.Spec.Prometheus.Enabled {
-		return nil // Prometheus not enabled, do nothing.
-	}
+// isServiceMonitorEnabled returns true if the ServiceMonitor with the given options should be present for the given
+// ArgoCD.
+func isServiceMonitorEnabled(smSpec argoprojv1a1.ArgoCDServiceMonitorSpec, cr *argoprojv1a1.ArgoCD) bool {
+	return smSpec.Enabled || (cr.Spec.Prometheus.Enabled && !cr.Spec.Prometheus.DisableServiceMonitors)
+}
+
+// reconcileServiceMonitor will ensure that the ServiceMonitor with the given name suffix is present for the Service
+// with the given name suffix, using the given ServiceMonitor options.
+func (r *ReconcileArgoCD) reconcileServiceMonitor(suffix string, serviceSuffix string, smSpec argoprojv1a1.ArgoCDServiceMonitorSpec, cr *argoprojv1a1.ArgoCD) error {
+	enabled := isServiceMonitorEnabled(smSpec, cr)
 
+	sm := newServiceMonitorWithSuffix(suffix, cr)
+	for k, v := range smSpec.Labels {
+		sm.Labels[k] = v
+	}
 	sm.Spec.Selector = metav1.LabelSelector{
 		MatchLabels: map[string]string{
-			common.ArgoCDKeyName: nameWithSuffix(common.ArgoCDKeyMetrics, cr),
+			common.ArgoCDKeyName: nameWithSuffix(serviceSuffix, cr),
 		},
 	}
 	sm.Spec.Endpoints = []monitoringv1.Endpoint{
-		{
-			Port: common.ArgoCDKeyMetrics,
-		},
+		getServiceMonitorEndpoint(common.ArgoCDKeyMetrics, smSpec),
+	}
+
+	existing := newServiceMonitorWithSuffix(suffix, cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+		if !enabled {
+			// ServiceMonitor exists but enabled flag has been set to false, delete the ServiceMonitor
+			return r.Client.Delete(context.TODO(), existing)
+		}
+		changed := false
+		for k, v := range sm.Labels {
+			if existing.Labels[k] != v {
+				existing.Labels[k] = v
+				changed = true
+			}
+		}
+		if !reflect.DeepEqual(existing.Spec.Endpoints, sm.Spec.Endpoints) {
+			existing.Spec.Endpoints = sm.Spec.Endpoints
+			changed = true
+		}
+		if changed {
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // ServiceMonitor found with nothing to do, move along...
+	}
+
+	if !enabled {
+		return nil // Prometheus and ServiceMonitor not enabled, do nothing.
 	}
 
 	if err := controllerutil.SetControllerReference(cr, sm, r.Scheme); err != nil {
@@ -156,6 +235,18 @@ func (r *ReconcileArgoCD) reconcileMetricsServiceMonitor(cr *argoprojv1a1.ArgoCD
 	return r.Client.Create(context.TODO(), sm)
 }
 
+// getServiceMonitorEndpoint will return the ServiceMonitor Endpoint for the given Service port name and ServiceMonitor options.
+func getServiceMonitorEndpoint(port string, smSpec argoprojv1a1.ArgoCDServiceMonitorSpec) monitoringv1.Endpoint {
+	return monitoringv1.Endpoint{
+		Port:                 port,
+		Interval:             smSpec.Interval,
+		RelabelConfigs:       smSpec.Relabelings,
+		MetricRelabelConfigs: smSpec.MetricRelabelings,
+		Scheme:               smSpec.Scheme,
+		TLSConfig:            smSpec.TLSConfig,
+	}
+}
+
 // reconcilePrometheus will ensure that Prometheus is present for ArgoCD metrics.
 func (r *ReconcileArgoCD) reconcilePrometheus(cr *argoprojv1a1.ArgoCD) error {
 	prometheus := newPrometheus(cr)
@@ -165,7 +256,7 @@ func (r *ReconcileArgoCD) reconcilePrometheus(cr *argoprojv1a1.ArgoCD) error {
 			return r.Client.Delete(context.TODO(), prometheus)
 		}
 		if hasPrometheusSpecChanged(prometheus, cr) {
-			prometheus.Spec.Replicas = cr.Spec.Prometheus.Size
+			setPrometheusSpec(prometheus, cr)
 			return r.Client.Update(context.TODO(), prometheus)
 		}
 		return nil // Prometheus found, do nothing
@@ -175,7 +266,7 @@ func (r *ReconcileArgoCD) reconcilePrometheus(cr *argoprojv1a1.ArgoCD) error {
 		return nil // Prometheus not enabled, do nothing.
 	}
 
-	prometheus.Spec.Replicas = getPrometheusReplicas(cr)
+	setPrometheusSpec(prometheus, cr)
 	prometheus.Spec.ServiceAccountName = "prometheus-k8s"
 	prometheus.Spec.ServiceMonitorSelector = &metav1.LabelSelector{}
 
@@ -187,66 +278,39 @@ func (r *ReconcileArgoCD) reconcilePrometheus(cr *argoprojv1a1.ArgoCD) error {
 
 // reconcileRepoServerServiceMonitor will ensure that the ServiceMonitor is present for the Repo Server metrics Service.
 func (r *ReconcileArgoCD) reconcileRepoServerServiceMonitor(cr *argoprojv1a1.ArgoCD) error {
-	sm := newServiceMonitorWithSuffix("repo-server-metrics", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
-		if !cr.Spec.Prometheus.Enabled {
-			// ServiceMonitor exists but enabled flag has been set to false, delete the ServiceMonitor
-			return r.Client.Delete(context.TODO(), sm)
-		}
-		return nil // ServiceMonitor found, do nothing
-	}
-
-	if !cr.Spec.Prometheus.Enabled {
-		return nil // Prometheus not enabled, do nothing.
-	}
-
-	sm.Spec.Selector = metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			common.ArgoCDKeyName: nameWithSuffix("repo-server", cr),
-		},
-	}
-	sm.Spec.Endpoints = []monitoringv1.Endpoint{
-		{
-			Port: common.ArgoCDKeyMetrics,
-		},
-	}
-
-	if err := controllerutil.SetControllerReference(cr, sm, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), sm)
+	return r.reconcileServiceMonitor("repo-server-metrics", "repo-server", cr.Spec.Repo.ServiceMonitor, cr)
 }
 
 // reconcileServerMetricsServiceMonitor will ensure that the ServiceMonitor is present for the ArgoCD Server metrics Service.
 func (r *ReconcileArgoCD) reconcileServerMetricsServiceMonitor(cr *argoprojv1a1.ArgoCD) error {
-	sm := newServiceMonitorWithSuffix("server-metrics", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
-		if !cr.Spec.Prometheus.Enabled {
-			// ServiceMonitor exists but enabled flag has been set to false, delete the ServiceMonitor
+	return r.reconcileServiceMonitor("server-metrics", "server-metrics", cr.Spec.Server.ServiceMonitor, cr)
+}
+
+// reconcileDexServiceMonitor will ensure that the ServiceMonitor is present for the Dex metrics when Dex is used.
+func (r *ReconcileArgoCD) reconcileDexServiceMonitor(cr *argoprojv1a1.ArgoCD) error {
+	if !UseDex(cr) {
+		sm := newServiceMonitorWithSuffix("dex-server-metrics", cr)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
+			// ServiceMonitor exists but Dex has been disabled, delete the ServiceMonitor
 			return r.Client.Delete(context.TODO(), sm)
 		}
-		return nil // ServiceMonitor found, do nothing
-	}
-
-	if !cr.Spec.Prometheus.Enabled {
-		return nil // Prometheus not enabled, do nothing.
-	}
-
-	sm.Spec.Selector = metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			common.ArgoCDKeyName: nameWithSuffix("server-metrics", cr),
-		},
-	}
-	sm.Spec.Endpoints = []monitoringv1.Endpoint{
-		{
-			Port: common.ArgoCDKeyMetrics,
-		},
+		return nil
 	}
+	return r.reconcileServiceMonitor("dex-server-metrics", "dex-server", getDexServiceMonitorSpec(cr), cr)
+}
 
-	if err := controllerutil.SetControllerReference(cr, sm, r.Scheme); err != nil {
-		return err
+// reconcileApplicationSetServiceMonitor will ensure that the ServiceMonitor is present for the ApplicationSet
+// controller metrics Service when the ApplicationSet controller is installed.
+func (r *ReconcileArgoCD) reconcileApplicationSetServiceMonitor(cr *argoprojv1a1.ArgoCD) error {
+	if cr.Spec.ApplicationSet == nil {
+		sm := newServiceMonitorWithSuffix("applicationset-controller-metrics", cr)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
+			// ServiceMonitor exists but the ApplicationSet controller has been disabled, delete the ServiceMonitor
+			return r.Client.Delete(context.TODO(), sm)
+		}
+		return nil
 	}
-	return r.Client.Create(context.TODO(), sm)
+	return r.reconcileServiceMonitor("applicationset-controller-metrics", common.ApplicationSetServiceNameSuffix, cr.Spec.ApplicationSet.Metrics.ServiceMonitor, cr)
 }
 
 // reconcilePrometheusRule reconciles the PrometheusRule that triggers alerts based on workload statuses
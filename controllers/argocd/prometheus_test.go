@@ -6,8 +6,11 @@ import (
 	"testing"
 
 	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -199,3 +202,271 @@ func TestReconcileWorkloadStatusAlertRule(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileArgoCD_reconcileMetricsServiceMonitor(t *testing.T) {
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, monitoringv1.AddToScheme(r.Scheme))
+
+	key := types.NamespacedName{
+		Name:      fmt.Sprintf("%s-%s", a.Name, "metrics"),
+		Namespace: a.Namespace,
+	}
+
+	// neither Prometheus nor the ServiceMonitor are enabled
+	assert.NoError(t, r.reconcileMetricsServiceMonitor(a))
+	sm := &monitoringv1.ServiceMonitor{}
+	assert.Error(t, r.Client.Get(context.TODO(), key, sm))
+
+	// the ServiceMonitor is created without enabling Prometheus
+	a.Spec.Controller.Metrics.ServiceMonitor = argoprojv1alpha1.ArgoCDServiceMonitorSpec{
+		Enabled:  true,
+		Labels:   map[string]string{"team": "platform"},
+		Interval: "30s",
+		Relabelings: []*monitoringv1.RelabelConfig{
+			{
+				SourceLabels: []string{"__meta_kubernetes_pod_node_name"},
+				TargetLabel:  "node",
+			},
+		},
+	}
+	assert.NoError(t, r.reconcileMetricsServiceMonitor(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, sm))
+	assert.Equal(t, "platform", sm.Labels["team"])
+	assert.Equal(t, []monitoringv1.Endpoint{
+		{
+			Port:     "metrics",
+			Interval: "30s",
+			RelabelConfigs: []*monitoringv1.RelabelConfig{
+				{
+					SourceLabels: []string{"__meta_kubernetes_pod_node_name"},
+					TargetLabel:  "node",
+				},
+			},
+		},
+	}, sm.Spec.Endpoints)
+
+	// changes to the ServiceMonitor options are applied to the existing ServiceMonitor
+	a.Spec.Controller.Metrics.ServiceMonitor.Interval = "1m"
+	a.Spec.Controller.Metrics.ServiceMonitor.Relabelings = nil
+	assert.NoError(t, r.reconcileMetricsServiceMonitor(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, sm))
+	assert.Equal(t, []monitoringv1.Endpoint{{Port: "metrics", Interval: "1m"}}, sm.Spec.Endpoints)
+
+	// the ServiceMonitor is deleted once disabled
+	a.Spec.Controller.Metrics.ServiceMonitor.Enabled = false
+	assert.NoError(t, r.reconcileMetricsServiceMonitor(a))
+	assert.Error(t, r.Client.Get(context.TODO(), key, sm))
+}
+
+func TestReconcileArgoCD_reconcileApplicationSetServiceMonitor(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.ApplicationSet = &argoprojv1alpha1.ArgoCDApplicationSet{
+			Metrics: argoprojv1alpha1.ArgoCDApplicationSetMetricsSpec{
+				ServiceMonitor: argoprojv1alpha1.ArgoCDServiceMonitorSpec{
+					Enabled:  true,
+					Interval: "30s",
+				},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, monitoringv1.AddToScheme(r.Scheme))
+
+	key := types.NamespacedName{
+		Name:      fmt.Sprintf("%s-%s", a.Name, "applicationset-controller-metrics"),
+		Namespace: a.Namespace,
+	}
+
+	sm := &monitoringv1.ServiceMonitor{}
+	assert.NoError(t, r.reconcileApplicationSetServiceMonitor(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, sm))
+	assert.Equal(t, "argocd-applicationset-controller", sm.Spec.Selector.MatchLabels[common.ArgoCDKeyName])
+	assert.Equal(t, []monitoringv1.Endpoint{{Port: "metrics", Interval: "30s"}}, sm.Spec.Endpoints)
+
+	// the ServiceMonitor is deleted along with the ApplicationSet controller, even with Prometheus enabled
+	a.Spec.Prometheus.Enabled = true
+	a.Spec.ApplicationSet = nil
+	assert.NoError(t, r.reconcileApplicationSetServiceMonitor(a))
+	assert.Error(t, r.Client.Get(context.TODO(), key, sm))
+}
+
+func TestReconcileArgoCD_reconcileDexServiceMonitor(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.SSO = &argoprojv1alpha1.ArgoCDSSOSpec{
+			Provider: argoprojv1alpha1.SSOProviderTypeDex,
+			Dex: &argoprojv1alpha1.ArgoCDDexSpec{
+				OpenShiftOAuth: true,
+				ServiceMonitor: argoprojv1alpha1.ArgoCDServiceMonitorSpec{
+					Enabled:  true,
+					Labels:   map[string]string{"release": "kube-prometheus-stack"},
+					Interval: "30s",
+				},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, monitoringv1.AddToScheme(r.Scheme))
+
+	key := types.NamespacedName{
+		Name:      fmt.Sprintf("%s-%s", a.Name, "dex-server-metrics"),
+		Namespace: a.Namespace,
+	}
+
+	sm := &monitoringv1.ServiceMonitor{}
+	assert.NoError(t, r.reconcileDexServiceMonitor(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, sm))
+	assert.Equal(t, "kube-prometheus-stack", sm.Labels["release"])
+	assert.Equal(t, "argocd-dex-server", sm.Spec.Selector.MatchLabels[common.ArgoCDKeyName])
+	assert.Equal(t, []monitoringv1.Endpoint{{Port: "metrics", Interval: "30s"}}, sm.Spec.Endpoints)
+
+	// the Dex Service exposes the metrics port scraped by the ServiceMonitor
+	assert.NoError(t, r.reconcileDexService(a))
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-dex-server", Namespace: a.Namespace}, svc))
+	assert.Contains(t, svc.Spec.Ports, corev1.ServicePort{
+		Name:       "metrics",
+		Port:       common.ArgoCDDefaultDexMetricsPort,
+		Protocol:   corev1.ProtocolTCP,
+		TargetPort: intstr.FromInt(common.ArgoCDDefaultDexMetricsPort),
+	})
+
+	// the ServiceMonitor is deleted along with Dex
+	a.Spec.SSO = nil
+	assert.NoError(t, r.reconcileDexServiceMonitor(a))
+	assert.Error(t, r.Client.Get(context.TODO(), key, sm))
+}
+
+func TestReconcileArgoCD_reconcileServiceMonitors_disabled(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Prometheus.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, monitoringv1.AddToScheme(r.Scheme))
+
+	names := []string{"argocd-metrics", "argocd-repo-server-metrics", "argocd-server-metrics"}
+	reconcileServiceMonitors := func() {
+		assert.NoError(t, r.reconcileMetricsServiceMonitor(a))
+		assert.NoError(t, r.reconcileRepoServerServiceMonitor(a))
+		assert.NoError(t, r.reconcileServerMetricsServiceMonitor(a))
+	}
+
+	// the ServiceMonitors are created together with Prometheus
+	reconcileServiceMonitors()
+	for _, name := range names {
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, &monitoringv1.ServiceMonitor{}))
+	}
+
+	// disabling the ServiceMonitors removes them, unless enabled for the component
+	a.Spec.Prometheus.DisableServiceMonitors = true
+	a.Spec.Server.ServiceMonitor.Enabled = true
+	reconcileServiceMonitors()
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: names[0], Namespace: a.Namespace}, &monitoringv1.ServiceMonitor{}))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: names[1], Namespace: a.Namespace}, &monitoringv1.ServiceMonitor{}))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: names[2], Namespace: a.Namespace}, &monitoringv1.ServiceMonitor{}))
+}
+
+func TestReconcileArgoCD_reconcileRepoServerServiceMonitor_tls(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Repo.ServiceMonitor = argoprojv1alpha1.ArgoCDServiceMonitorSpec{
+			Enabled:  true,
+			Interval: "15s",
+			Scheme:   "https",
+			TLSConfig: &monitoringv1.TLSConfig{
+				CAFile:     "/etc/prometheus/configmaps/argocd-ca/ca.crt",
+				ServerName: "argocd-repo-server",
+			},
+			MetricRelabelings: []*monitoringv1.RelabelConfig{
+				{
+					SourceLabels: []string{"__name__"},
+					Regex:        "go_.*",
+					Action:       "drop",
+				},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, monitoringv1.AddToScheme(r.Scheme))
+
+	assert.NoError(t, r.reconcileRepoServerServiceMonitor(a))
+
+	sm := &monitoringv1.ServiceMonitor{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server-metrics", Namespace: a.Namespace}, sm))
+	assert.Equal(t, "argocd-repo-server", sm.Spec.Selector.MatchLabels[common.ArgoCDKeyName])
+	assert.Equal(t, []monitoringv1.Endpoint{
+		{
+			Port:     "metrics",
+			Interval: "15s",
+			Scheme:   "https",
+			TLSConfig: &monitoringv1.TLSConfig{
+				CAFile:     "/etc/prometheus/configmaps/argocd-ca/ca.crt",
+				ServerName: "argocd-repo-server",
+			},
+			MetricRelabelConfigs: []*monitoringv1.RelabelConfig{
+				{
+					SourceLabels: []string{"__name__"},
+					Regex:        "go_.*",
+					Action:       "drop",
+				},
+			},
+		},
+	}, sm.Spec.Endpoints)
+}
+
+func TestReconcileArgoCD_reconcilePrometheus(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Prometheus.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, monitoringv1.AddToScheme(r.Scheme))
+
+	key := types.NamespacedName{Name: a.Name, Namespace: a.Namespace}
+
+	// the Prometheus defaults to a single replica and the Prometheus Operator defaults
+	assert.NoError(t, r.reconcilePrometheus(a))
+	prometheus := &monitoringv1.Prometheus{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, prometheus))
+	assert.Equal(t, common.ArgoCDDefaultPrometheusReplicas, *prometheus.Spec.Replicas)
+	assert.Empty(t, prometheus.Spec.Retention)
+	assert.Nil(t, prometheus.Spec.Storage)
+
+	// changes to the Prometheus options are applied to the existing Prometheus
+	size := int32(2)
+	storage := corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("50Gi"),
+			},
+		},
+	}
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+		},
+	}
+	a.Spec.Prometheus.Size = &size
+	a.Spec.Prometheus.Retention = "15d"
+	a.Spec.Prometheus.RetentionSize = "40GB"
+	a.Spec.Prometheus.Storage = &storage
+	a.Spec.Prometheus.Resources = &resources
+	a.Spec.Prometheus.ExternalLabels = map[string]string{"cluster": "production"}
+	assert.NoError(t, r.reconcilePrometheus(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, prometheus))
+	assert.Equal(t, size, *prometheus.Spec.Replicas)
+	assert.Equal(t, "15d", prometheus.Spec.Retention)
+	assert.Equal(t, "40GB", prometheus.Spec.RetentionSize)
+	assert.Equal(t, storage, prometheus.Spec.Storage.VolumeClaimTemplate.Spec)
+	assert.Equal(t, resources, prometheus.Spec.Resources)
+	assert.Equal(t, map[string]string{"cluster": "production"}, prometheus.Spec.ExternalLabels)
+	assert.False(t, hasPrometheusSpecChanged(prometheus, a))
+
+	// removing the options resets the Prometheus to the defaults
+	a.Spec.Prometheus = argoprojv1alpha1.ArgoCDPrometheusSpec{Enabled: true}
+	assert.NoError(t, r.reconcilePrometheus(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, prometheus))
+	assert.Equal(t, common.ArgoCDDefaultPrometheusReplicas, *prometheus.Spec.Replicas)
+	assert.Empty(t, prometheus.Spec.Retention)
+	assert.Empty(t, prometheus.Spec.ExternalLabels)
+	assert.Nil(t, prometheus.Spec.Storage)
+}
@@ -0,0 +1,190 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// componentPods describes the pods the operator runs for a component, with the resources of each of their containers.
+type componentPods struct {
+	name       string
+	replicas   int32
+	containers []corev1.ResourceRequirements
+}
+
+// quotaComputeResources are the compute resources of a ResourceQuota the operator validates, along with the container
+// resource they account for and whether they account for its limit rather than its request.
+var quotaComputeResources = []struct {
+	name     corev1.ResourceName
+	resource corev1.ResourceName
+	limit    bool
+}{
+	{corev1.ResourceCPU, corev1.ResourceCPU, false},
+	{corev1.ResourceMemory, corev1.ResourceMemory, false},
+	{corev1.ResourceRequestsCPU, corev1.ResourceCPU, false},
+	{corev1.ResourceRequestsMemory, corev1.ResourceMemory, false},
+	{corev1.ResourceLimitsCPU, corev1.ResourceCPU, true},
+	{corev1.ResourceLimitsMemory, corev1.ResourceMemory, true},
+}
+
+// replicasOrDefault returns the given number of replicas, or the default of a single replica when not set.
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// getComponentPods returns the pods the operator runs for the enabled components of the given ArgoCD.
+func (r *ReconcileArgoCD) getComponentPods(cr *argoprojv1a1.ArgoCD) []componentPods {
+	pods := []componentPods{}
+
+	if cr.Spec.Controller.IsEnabled() {
+		pods = append(pods, componentPods{common.ArgoCDApplicationControllerComponent, r.getApplicationControllerReplicaCount(cr),
+			[]corev1.ResourceRequirements{getArgoApplicationControllerResources(cr)}})
+	}
+	if cr.Spec.Server.IsEnabled() {
+		pods = append(pods, componentPods{common.ArgoCDServerComponent, replicasOrDefault(getArgoCDServerReplicas(cr)),
+			[]corev1.ResourceRequirements{getArgoServerResources(cr)}})
+	}
+	if isRepoServerDeployed(cr) {
+		pods = append(pods, componentPods{common.ArgoCDRepoServerComponent, replicasOrDefault(getArgoCDRepoServerReplicas(cr)),
+			[]corev1.ResourceRequirements{getArgoRepoResources(cr)}})
+	}
+	if isRedisDeployed(cr) {
+		if cr.Spec.HA.Enabled {
+			pods = append(pods,
+				componentPods{common.ArgoCDRedisHAComponent, *getRedisHAReplicas(cr),
+					[]corev1.ResourceRequirements{getRedisResources(cr), getRedisSentinelResources(cr)}},
+				componentPods{common.ArgoCDRedisHAComponent + "-haproxy", replicasOrDefault(cr.Spec.HA.RedisProxyReplicas),
+					[]corev1.ResourceRequirements{getRedisHAProxyResources(cr)}},
+			)
+		} else {
+			pods = append(pods, componentPods{common.ArgoCDRedisComponent, 1,
+				[]corev1.ResourceRequirements{getRedisResources(cr)}})
+		}
+	}
+	if UseDex(cr) {
+		pods = append(pods, componentPods{common.ArgoCDDexServerComponent, 1,
+			[]corev1.ResourceRequirements{getDexResources(cr)}})
+	}
+	if cr.Spec.ApplicationSet != nil {
+		pods = append(pods, componentPods{"argocd-" + common.ApplicationSetServiceNameSuffix, replicasOrDefault(getArgoCDApplicationSetReplicas(cr)),
+			[]corev1.ResourceRequirements{getApplicationSetResources(cr)}})
+	}
+	if cr.Spec.Notifications.Enabled {
+		pods = append(pods, componentPods{common.ArgoCDNotificationsControllerComponent, replicasOrDefault(getArgoCDNotificationsControllerReplicas(cr)),
+			[]corev1.ResourceRequirements{getNotificationsResources(cr)}})
+	}
+	return pods
+}
+
+// getPodQuantity returns the amount of the given resource requested, or limited, by the given containers of a pod.
+func getPodQuantity(containers []corev1.ResourceRequirements, name corev1.ResourceName, limit bool) resource.Quantity {
+	total := resource.Quantity{}
+	for _, container := range containers {
+		list := container.Requests
+		if limit {
+			list = container.Limits
+		}
+		if quantity, ok := list[name]; ok {
+			total.Add(quantity)
+		}
+	}
+	return total
+}
+
+// getResourceQuotaViolations returns a description of each compute resource of the given ResourceQuotas that is
+// exceeded by the total resources of the given component pods alone. Quotas restricted to a scope are ignored, as
+// they do not necessarily apply to the pods of the components.
+func getResourceQuotaViolations(quotas []corev1.ResourceQuota, pods []componentPods) []string {
+	violations := []string{}
+	for _, quota := range quotas {
+		if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			continue
+		}
+		for _, compute := range quotaComputeResources {
+			hard, ok := quota.Spec.Hard[compute.name]
+			if !ok {
+				continue
+			}
+
+			total := resource.Quantity{}
+			components := []string{}
+			for _, pod := range pods {
+				quantity := getPodQuantity(pod.containers, compute.resource, compute.limit)
+				if quantity.IsZero() || pod.replicas <= 0 {
+					continue
+				}
+				for i := int32(0); i < pod.replicas; i++ {
+					total.Add(quantity)
+				}
+				components = append(components, pod.name)
+			}
+
+			if total.Cmp(hard) > 0 {
+				violations = append(violations, fmt.Sprintf("%s of %s requested by %s exceeds %s of ResourceQuota %s",
+					compute.name, total.String(), strings.Join(components, ", "), hard.String(), quota.Name))
+			}
+		}
+	}
+	return violations
+}
+
+// reconcileStatusResourceQuota will ensure that the ResourceQuotaExceeded condition of the given ArgoCD reports the
+// compute resources of the ResourceQuotas in its namespace that the components cannot fit in, so that the pods of the
+// components would not be admitted. A warning event is emitted when the exceeded resources change. The condition is
+// only maintained once a ResourceQuota exists in the namespace.
+func (r *ReconcileArgoCD) reconcileStatusResourceQuota(cr *argoprojv1a1.ArgoCD) error {
+	quotas := &corev1.ResourceQuotaList{}
+	if err := r.Client.List(context.TODO(), quotas, client.InNamespace(cr.Namespace)); err != nil {
+		return err
+	}
+	if len(quotas.Items) == 0 && meta.FindStatusCondition(cr.Status.Conditions, argoprojv1a1.ArgoCDConditionTypeResourceQuotaExceeded) == nil {
+		return nil
+	}
+	sort.Slice(quotas.Items, func(i, j int) bool {
+		return quotas.Items[i].Name < quotas.Items[j].Name
+	})
+
+	condition := metav1.Condition{
+		Type:    argoprojv1a1.ArgoCDConditionTypeResourceQuotaExceeded,
+		Status:  metav1.ConditionFalse,
+		Reason:  "WithinQuota",
+		Message: "the resources of the components fit in the resource quotas of the namespace",
+	}
+	if violations := getResourceQuotaViolations(quotas.Items, r.getComponentPods(cr)); len(violations) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "QuotaExceeded"
+		condition.Message = fmt.Sprintf("the pods of the components will not be admitted: %s", strings.Join(violations, "; "))
+	}
+	condition.ObservedGeneration = cr.Generation
+
+	r.setConditionWithEvent(cr, condition, "QuotaExceeded")
+	return nil
+}
@@ -0,0 +1,82 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func TestReconcileArgoCD_reconcileStatusResourceQuota(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Controller.Resources = &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		}
+		a.Spec.SSO = &argoprojv1alpha1.ArgoCDSSOSpec{
+			Provider: argoprojv1alpha1.SSOProviderTypeDex,
+			Dex: &argoprojv1alpha1.ArgoCDDexSpec{
+				OpenShiftOAuth: true,
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+				},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	// the condition is not reported while the namespace has no quota
+	assert.NoError(t, r.reconcileStatusResourceQuota(a))
+	assert.Nil(t, meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionTypeResourceQuotaExceeded))
+
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: a.Namespace},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{
+				corev1.ResourceRequestsMemory: resource.MustParse("1Gi"),
+				corev1.ResourceLimitsMemory:   resource.MustParse("1Gi"),
+			},
+		},
+	}
+	scoped := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "best-effort", Namespace: a.Namespace},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard:   corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("1Mi")},
+			Scopes: []corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeBestEffort},
+		},
+	}
+	assert.NoError(t, r.Client.Create(context.TODO(), quota))
+	assert.NoError(t, r.Client.Create(context.TODO(), scoped))
+
+	// the requests of the controller and Dex exceed the quota, the scoped quota is ignored
+	assert.NoError(t, r.reconcileStatusResourceQuota(a))
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionTypeResourceQuotaExceeded)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "QuotaExceeded", condition.Reason)
+	assert.Equal(t, "the pods of the components will not be admitted: requests.memory of 1536Mi requested by argocd-application-controller, argocd-dex-server exceeds 1Gi of ResourceQuota compute", condition.Message)
+
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events))
+	assert.Len(t, events.Items, 1)
+	assert.Equal(t, "ResourceQuotaExceeded", events.Items[0].Reason)
+
+	// no further event is emitted while the exceeded resources are unchanged
+	assert.NoError(t, r.reconcileStatusResourceQuota(a))
+	assert.NoError(t, r.Client.List(context.TODO(), events))
+	assert.Len(t, events.Items, 1)
+
+	// the condition is cleared once the components fit in the quota
+	a.Spec.Controller.Resources.Requests[corev1.ResourceMemory] = resource.MustParse("256Mi")
+	assert.NoError(t, r.reconcileStatusResourceQuota(a))
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionTypeResourceQuotaExceeded)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "WithinQuota", condition.Reason)
+}
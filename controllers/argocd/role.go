@@ -62,6 +62,43 @@ func newClusterRole(name string, rules []v1.PolicyRule, cr *argoprojv1a1.ArgoCD)
 	}
 }
 
+// getClusterRoleSpec returns the ClusterRole customizations for the given component of the given ArgoCD, if any.
+func getClusterRoleSpec(name string, cr *argoprojv1a1.ArgoCD) *argoprojv1a1.ArgoCDClusterRoleSpec {
+	switch name {
+	case common.ArgoCDApplicationControllerComponent:
+		return cr.Spec.Controller.ClusterRole
+	case common.ArgoCDServerComponent:
+		return cr.Spec.Server.ClusterRole
+	}
+	return nil
+}
+
+// applyClusterRoleSpec will apply the ClusterRole customizations for the given component to the given ClusterRole.
+// Aggregated ClusterRoles select the ClusterRoles labeled for aggregation into them, and carry no rules of their own.
+func applyClusterRoleSpec(name string, clusterRole *v1.ClusterRole, cr *argoprojv1a1.ArgoCD) {
+	spec := getClusterRoleSpec(name, cr)
+	if spec == nil {
+		return
+	}
+
+	if len(spec.Rules) > 0 {
+		clusterRole.Rules = spec.Rules
+	}
+
+	if spec.Aggregated {
+		clusterRole.Rules = nil
+		clusterRole.AggregationRule = &v1.AggregationRule{
+			ClusterRoleSelectors: []metav1.LabelSelector{
+				{
+					MatchLabels: map[string]string{
+						common.ArgoCDAggregateToClusterRoleLabel: clusterRole.Name,
+					},
+				},
+			},
+		}
+	}
+}
+
 // reconcileRoles will ensure that all ArgoCD Service Accounts are configured.
 func (r *ReconcileArgoCD) reconcileRoles(cr *argoprojv1a1.ArgoCD) error {
 	params := getPolicyRuleList(r.Client)
@@ -93,6 +130,11 @@ func (r *ReconcileArgoCD) reconcileRoles(cr *argoprojv1a1.ArgoCD) error {
 		return err
 	}
 
+	log.Info("performing cleanup for managed namespaces")
+	if err := r.removeStaleManagedNamespaceRBACs(cr); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -121,7 +163,7 @@ func (r *ReconcileArgoCD) reconcileRole(name string, policyRules []v1.PolicyRule
 				continue
 			}
 		}
-		customRole := getCustomRoleName(name)
+		customRole := getCustomRoleName(name, cr)
 		role := newRole(name, policyRules, cr)
 		if err := applyReconcilerHook(cr, role, ""); err != nil {
 			return nil, err
@@ -186,9 +228,18 @@ func (r *ReconcileArgoCD) reconcileRoleForApplicationSourceNamespaces(name strin
 
 	// create policy rules for each source namespace for ArgoCD Server
 	for _, sourceNamespace := range cr.Spec.SourceNamespaces {
+		if !r.Options.isNamespaceWatched(sourceNamespace) {
+			log.Info(fmt.Sprintf("Skipping reconciling resources for source namespace %s as it is not watched by the operator.", sourceNamespace))
+			continue
+		}
 
 		namespace := &corev1.Namespace{}
 		if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: sourceNamespace}, namespace); err != nil {
+			if errors.IsNotFound(err) {
+				// the namespace may be created later on, which triggers a new reconciliation
+				log.Info(fmt.Sprintf("Skipping reconciling resources for source namespace %s as it does not exist.", sourceNamespace))
+				continue
+			}
 			return nil, err
 		}
 
@@ -273,6 +324,7 @@ func (r *ReconcileArgoCD) reconcileClusterRole(name string, policyRules []v1.Pol
 		allowed = true
 	}
 	clusterRole := newClusterRole(name, policyRules, cr)
+	applyClusterRoleSpec(name, clusterRole, cr)
 	if err := applyReconcilerHook(cr, clusterRole, ""); err != nil {
 		return nil, err
 	}
@@ -294,9 +346,19 @@ func (r *ReconcileArgoCD) reconcileClusterRole(name string, policyRules []v1.Pol
 		return nil, r.Client.Delete(context.TODO(), existingClusterRole)
 	}
 
-	// if the Rules differ, update the Role
-	if !reflect.DeepEqual(existingClusterRole.Rules, clusterRole.Rules) {
+	changed := false
+	if !reflect.DeepEqual(existingClusterRole.AggregationRule, clusterRole.AggregationRule) {
+		existingClusterRole.AggregationRule = clusterRole.AggregationRule
+		changed = true
+	}
+
+	// if the Rules differ, update the Role. The rules of aggregated ClusterRoles are managed by Kubernetes.
+	if clusterRole.AggregationRule == nil && !reflect.DeepEqual(existingClusterRole.Rules, clusterRole.Rules) {
 		existingClusterRole.Rules = clusterRole.Rules
+		changed = true
+	}
+
+	if changed {
 		if err := r.Client.Update(context.TODO(), existingClusterRole); err != nil {
 			return nil, err
 		}
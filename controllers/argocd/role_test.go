@@ -149,6 +149,24 @@ func TestReconcileArgoCD_reconcileRoleForApplicationSourceNamespaces(t *testing.
 
 }
 
+func TestReconcileArgoCD_reconcileRoleForApplicationSourceNamespaces_missingNamespace(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec = v1alpha1.ArgoCDSpec{
+		SourceNamespaces: []string{
+			"missing-namespace",
+		},
+	}
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	// namespaces which do not exist yet are skipped instead of failing the reconciliation
+	roles, err := r.reconcileRoleForApplicationSourceNamespaces(common.ArgoCDServerComponent, policyRuleForServerApplicationSourceNamespaces(), a)
+	assert.NoError(t, err)
+	assert.Empty(t, roles)
+	assert.NoError(t, r.reconcileRoleBinding(common.ArgoCDServerComponent, policyRuleForServerApplicationSourceNamespaces(), a))
+}
+
 func TestReconcileArgoCD_RoleHooks(t *testing.T) {
 	defer resetHooks()()
 	a := makeTestArgoCD()
@@ -271,3 +289,85 @@ func TestReconcileRoles_ManagedTerminatingNamespace(t *testing.T) {
 	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: "managedNS2"}, reconciledRole))
 	assert.Equal(t, expectedRules, reconciledRole.Rules)
 }
+
+func TestReconcileArgoCD_reconcileClusterRole_customRules(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	customRules := []v1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"configmaps"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+	}
+	a := makeTestArgoCD(func(a *v1alpha1.ArgoCD) {
+		a.Spec.Controller.ClusterRole = &v1alpha1.ArgoCDClusterRoleSpec{
+			Rules: customRules,
+		}
+	})
+	r := makeTestReconciler(t, a)
+	t.Setenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES", a.Namespace)
+
+	workloadIdentifier := common.ArgoCDApplicationControllerComponent
+	clusterRoleName := GenerateUniqueResourceName(workloadIdentifier, a)
+	_, err := r.reconcileClusterRole(workloadIdentifier, policyRuleForApplicationController(), a)
+	assert.NoError(t, err)
+
+	reconciledClusterRole := &v1.ClusterRole{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRoleName}, reconciledClusterRole))
+	assert.Equal(t, customRules, reconciledClusterRole.Rules)
+
+	// the server ClusterRole keeps its default rules
+	_, err = r.reconcileClusterRole(common.ArgoCDServerComponent, policyRuleForServerClusterRole(), a)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: GenerateUniqueResourceName(common.ArgoCDServerComponent, a)}, reconciledClusterRole))
+	assert.Equal(t, policyRuleForServerClusterRole(), reconciledClusterRole.Rules)
+}
+
+func TestReconcileArgoCD_reconcileClusterRole_aggregated(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	t.Setenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES", a.Namespace)
+
+	workloadIdentifier := common.ArgoCDServerComponent
+	clusterRoleName := GenerateUniqueResourceName(workloadIdentifier, a)
+	_, err := r.reconcileClusterRole(workloadIdentifier, policyRuleForServerClusterRole(), a)
+	assert.NoError(t, err)
+
+	// switching to an aggregated ClusterRole adds the aggregation rule
+	a.Spec.Server.ClusterRole = &v1alpha1.ArgoCDClusterRoleSpec{Aggregated: true}
+	_, err = r.reconcileClusterRole(workloadIdentifier, policyRuleForServerClusterRole(), a)
+	assert.NoError(t, err)
+
+	reconciledClusterRole := &v1.ClusterRole{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRoleName}, reconciledClusterRole))
+	assert.Equal(t, &v1.AggregationRule{
+		ClusterRoleSelectors: []metav1.LabelSelector{
+			{MatchLabels: map[string]string{common.ArgoCDAggregateToClusterRoleLabel: clusterRoleName}},
+		},
+	}, reconciledClusterRole.AggregationRule)
+
+	// rules filled in by the aggregation controller are left untouched
+	aggregatedRules := []v1.PolicyRule{
+		{
+			APIGroups: []string{"argoproj.io"},
+			Resources: []string{"applications"},
+			Verbs:     []string{"get"},
+		},
+	}
+	reconciledClusterRole.Rules = aggregatedRules
+	assert.NoError(t, r.Client.Update(context.TODO(), reconciledClusterRole))
+
+	_, err = r.reconcileClusterRole(workloadIdentifier, policyRuleForServerClusterRole(), a)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRoleName}, reconciledClusterRole))
+	assert.Equal(t, aggregatedRules, reconciledClusterRole.Rules)
+
+	// disabling aggregation restores the default rules
+	a.Spec.Server.ClusterRole = nil
+	_, err = r.reconcileClusterRole(workloadIdentifier, policyRuleForServerClusterRole(), a)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRoleName}, reconciledClusterRole))
+	assert.Nil(t, reconciledClusterRole.AggregationRule)
+	assert.Equal(t, policyRuleForServerClusterRole(), reconciledClusterRole.Rules)
+}
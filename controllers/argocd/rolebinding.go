@@ -156,7 +156,7 @@ func (r *ReconcileArgoCD) reconcileRoleBinding(name string, rules []v1.PolicyRul
 			},
 		}
 
-		customRoleName := getCustomRoleName(name)
+		customRoleName := getCustomRoleName(name, cr)
 		if customRoleName != "" {
 			roleBinding.RoleRef = v1.RoleRef{
 				APIGroup: v1.GroupName,
@@ -216,8 +216,16 @@ func (r *ReconcileArgoCD) reconcileRoleBinding(name string, rules []v1.PolicyRul
 
 		// reconcile rolebindings for all source namespaces for argocd-server
 		for _, sourceNamespace := range cr.Spec.SourceNamespaces {
+			if !r.Options.isNamespaceWatched(sourceNamespace) {
+				log.Info(fmt.Sprintf("Skipping reconciling resources for source namespace %s as it is not watched by the operator.", sourceNamespace))
+				continue
+			}
 			namespace := &corev1.Namespace{}
 			if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: sourceNamespace}, namespace); err != nil {
+				if errors.IsNotFound(err) {
+					log.Info(fmt.Sprintf("Skipping reconciling resources for source namespace %s as it does not exist.", sourceNamespace))
+					continue
+				}
 				return err
 			}
 
@@ -303,11 +311,19 @@ func (r *ReconcileArgoCD) reconcileRoleBinding(name string, rules []v1.PolicyRul
 	return nil
 }
 
-func getCustomRoleName(name string) string {
+// getCustomRoleName returns the name of the user-provided ClusterRole the given component should be bound to, if any.
+// The role set in the ArgoCD spec takes precedence over the one set in the environment of the operator.
+func getCustomRoleName(name string, cr *argoprojv1a1.ArgoCD) string {
 	if name == common.ArgoCDApplicationControllerComponent {
+		if cr.Spec.Controller.CustomRoleName != "" {
+			return cr.Spec.Controller.CustomRoleName
+		}
 		return os.Getenv(common.ArgoCDControllerClusterRoleEnvName)
 	}
 	if name == common.ArgoCDServerComponent {
+		if cr.Spec.Server.CustomRoleName != "" {
+			return cr.Spec.Server.CustomRoleName
+		}
 		return os.Getenv(common.ArgoCDServerClusterRoleEnvName)
 	}
 	return ""
@@ -199,6 +199,95 @@ func TestReconcileArgoCD_reconcileRoleBinding_custom_role(t *testing.T) {
 	checkForUpdatedRoleRef(t, "custom-server-role", expectedName)
 }
 
+func TestReconcileArgoCD_reconcileRoleBinding_customRoleName(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	p := policyRuleForApplicationController()
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	workloadIdentifier := common.ArgoCDApplicationControllerComponent
+	expectedName := fmt.Sprintf("%s-%s", a.Name, workloadIdentifier)
+	assert.NoError(t, r.reconcileRoleBinding(workloadIdentifier, p, a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: a.Namespace}, &rbacv1.Role{}))
+
+	// the role set in the spec takes precedence over the environment
+	t.Setenv(common.ArgoCDControllerClusterRoleEnvName, "custom-env-role")
+	a.Spec.Controller.CustomRoleName = "custom-spec-role"
+	assert.NoError(t, r.reconcileRoleBinding(workloadIdentifier, p, a))
+
+	roleBinding := &rbacv1.RoleBinding{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: a.Namespace}, roleBinding))
+	assert.Equal(t, rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     "custom-spec-role",
+	}, roleBinding.RoleRef)
+
+	// the default role is removed
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: a.Namespace}, &rbacv1.Role{}))
+
+	// the server is not affected by the controller custom role
+	assert.Equal(t, "", getCustomRoleName(common.ArgoCDServerComponent, a))
+}
+
+func TestReconcileArgoCD_removeStaleManagedNamespaceRBACs(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	p := policyRuleForApplicationController()
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	assert.NoError(t, createNamespace(r, "managed", a.Namespace))
+	assert.NoError(t, createNamespace(r, "stale", a.Namespace))
+	assert.NoError(t, r.reconcileRoleBinding(common.ArgoCDApplicationControllerComponent, p, a))
+
+	// the label is removed from the namespace without the operator noticing
+	stale := &corev1.Namespace{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "stale"}, stale))
+	stale.Labels = nil
+	assert.NoError(t, r.Client.Update(context.TODO(), stale))
+
+	assert.NoError(t, r.removeStaleManagedNamespaceRBACs(a))
+
+	expectedName := fmt.Sprintf("%s-%s", a.Name, common.ArgoCDApplicationControllerComponent)
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: "stale"}, &rbacv1.Role{}))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: "stale"}, &rbacv1.RoleBinding{}))
+
+	// RBACs in managed namespaces and in the namespace of the instance are kept
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: "managed"}, &rbacv1.Role{}))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: "managed"}, &rbacv1.RoleBinding{}))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: a.Namespace}, &rbacv1.Role{}))
+
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events))
+	assert.Len(t, events.Items, 2)
+	for _, event := range events.Items {
+		assert.Equal(t, "StaleRBACRemoved", event.Reason)
+	}
+}
+
+func TestReconcileArgoCD_removeStaleManagedNamespaceRBACs_otherInstance(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	other := makeTestArgoCD(func(o *v1alpha1.ArgoCD) {
+		o.Namespace = "other"
+	})
+	r := makeTestReconciler(t, a, other)
+	p := policyRuleForApplicationController()
+
+	// an instance with the same name in an unlabelled namespace owns its own RBACs
+	assert.NoError(t, createNamespace(r, other.Namespace, ""))
+	assert.NoError(t, r.reconcileRoleBinding(common.ArgoCDApplicationControllerComponent, p, other))
+
+	assert.NoError(t, r.removeStaleManagedNamespaceRBACs(a))
+
+	expectedName := fmt.Sprintf("%s-%s", a.Name, common.ArgoCDApplicationControllerComponent)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: other.Namespace}, &rbacv1.Role{}))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: other.Namespace}, &rbacv1.RoleBinding{}))
+}
+
 func TestReconcileArgoCD_reconcileRoleBinding_forSourceNamespaces(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	sourceNamespace := "newNamespaceTest"
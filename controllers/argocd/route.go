@@ -73,55 +73,79 @@ func newRouteWithSuffix(suffix string, cr *argoprojv1a1.ArgoCD) *routev1.Route {
 	return newRouteWithName(fmt.Sprintf("%s-%s", cr.Name, suffix), cr)
 }
 
-// reconcileRoutes will ensure that all ArgoCD Routes are present.
-func (r *ReconcileArgoCD) reconcileRoutes(cr *argoprojv1a1.ArgoCD) error {
-	if err := r.reconcileGrafanaRoute(cr); err != nil {
-		return err
+// setRouteMetadata will merge the annotations and labels of the route spec into the given Route. Annotations and
+// labels added by others, e.g. external-dns or cert-utils, are preserved.
+func setRouteMetadata(route *routev1.Route, spec argoprojv1a1.ArgoCDRouteSpec) {
+	if len(spec.Annotations) > 0 {
+		if route.Annotations == nil {
+			route.Annotations = make(map[string]string)
+		}
+		for key, val := range spec.Annotations {
+			route.Annotations[key] = val
+		}
 	}
 
-	if err := r.reconcilePrometheusRoute(cr); err != nil {
-		return err
+	if len(spec.Labels) > 0 {
+		if route.Labels == nil {
+			route.Labels = make(map[string]string)
+		}
+		for key, val := range spec.Labels {
+			route.Labels[key] = val
+		}
 	}
+}
 
-	if err := r.reconcileServerRoute(cr); err != nil {
-		return err
+// getRouteWildcardPolicy will return the wildcard policy of the route spec, None by default.
+func getRouteWildcardPolicy(spec argoprojv1a1.ArgoCDRouteSpec) routev1.WildcardPolicyType {
+	if spec.WildcardPolicy != nil && len(*spec.WildcardPolicy) > 0 {
+		return *spec.WildcardPolicy
 	}
+	return routev1.WildcardPolicyNone
+}
 
-	if err := r.reconcileApplicationSetControllerWebhookRoute(cr); err != nil {
-		return err
+// deleteRouteIfWildcardPolicyChanged will delete the given Route if its wildcard policy differs from the one of the
+// route spec, as the wildcard policy of a Route cannot be changed. Returns true if the Route was deleted.
+func (r *ReconcileArgoCD) deleteRouteIfWildcardPolicyChanged(route *routev1.Route, spec argoprojv1a1.ArgoCDRouteSpec) (bool, error) {
+	existing := route.Spec.WildcardPolicy
+	if len(existing) == 0 {
+		existing = routev1.WildcardPolicyNone
+	}
+	if existing == getRouteWildcardPolicy(spec) {
+		return false, nil
 	}
 
-	return nil
+	log.Info(fmt.Sprintf("wildcard policy of route %s changed, recreating it", route.Name))
+	if err := r.Client.Delete(context.TODO(), route); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // reconcileGrafanaRoute will ensure that the ArgoCD Grafana Route is present.
 func (r *ReconcileArgoCD) reconcileGrafanaRoute(cr *argoprojv1a1.ArgoCD) error {
 	route := newRouteWithSuffix("grafana", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, route.Name, route) {
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, route.Name, route)
+	if found {
 		if !cr.Spec.Grafana.Enabled || !cr.Spec.Grafana.Route.Enabled {
 			// Route exists but enabled flag has been set to false, delete the Route
 			return r.Client.Delete(context.TODO(), route)
 		}
-		return nil // Route found, do nothing
+		deleted, err := r.deleteRouteIfWildcardPolicyChanged(route, cr.Spec.Grafana.Route)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			route = newRouteWithSuffix("grafana", cr)
+			found = false
+		}
 	}
 
 	if !cr.Spec.Grafana.Enabled || !cr.Spec.Grafana.Route.Enabled {
 		return nil // Grafana itself or Route not enabled, do nothing.
 	}
 
-	// Allow override of the Annotations for the Route.
-	if len(cr.Spec.Grafana.Route.Annotations) > 0 {
-		route.Annotations = cr.Spec.Grafana.Route.Annotations
-	}
-
-	// Allow override of the Labels for the Route.
-	if len(cr.Spec.Grafana.Route.Labels) > 0 {
-		labels := route.Labels
-		for key, val := range cr.Spec.Grafana.Route.Labels {
-			labels[key] = val
-		}
-		route.Labels = labels
-	}
+	// Allow override of the Annotations and Labels for the Route.
+	setRouteMetadata(route, cr.Spec.Grafana.Route)
 
 	// Allow override of the Host for the Route.
 	if len(cr.Spec.Grafana.Host) > 0 {
@@ -146,50 +170,53 @@ func (r *ReconcileArgoCD) reconcileGrafanaRoute(cr *argoprojv1a1.ArgoCD) error {
 	route.Spec.To.Name = nameWithSuffix("grafana", cr)
 
 	// Allow override of the WildcardPolicy for the Route
-	if cr.Spec.Grafana.Route.WildcardPolicy != nil && len(*cr.Spec.Grafana.Route.WildcardPolicy) > 0 {
-		route.Spec.WildcardPolicy = *cr.Spec.Grafana.Route.WildcardPolicy
-	}
+	route.Spec.WildcardPolicy = getRouteWildcardPolicy(cr.Spec.Grafana.Route)
 
 	if err := controllerutil.SetControllerReference(cr, route, r.Scheme); err != nil {
 		return err
 	}
-	return r.Client.Create(context.TODO(), route)
+	if !found {
+		return r.Client.Create(context.TODO(), route)
+	}
+	return r.Client.Update(context.TODO(), route)
 }
 
 // reconcilePrometheusRoute will ensure that the ArgoCD Prometheus Route is present.
 func (r *ReconcileArgoCD) reconcilePrometheusRoute(cr *argoprojv1a1.ArgoCD) error {
 	route := newRouteWithSuffix("prometheus", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, route.Name, route) {
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, route.Name, route)
+	if found {
 		if !cr.Spec.Prometheus.Enabled || !cr.Spec.Prometheus.Route.Enabled {
 			// Route exists but enabled flag has been set to false, delete the Route
 			return r.Client.Delete(context.TODO(), route)
 		}
-		return nil // Route found, do nothing
+		deleted, err := r.deleteRouteIfWildcardPolicyChanged(route, cr.Spec.Prometheus.Route)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			route = newRouteWithSuffix("prometheus", cr)
+			found = false
+		}
 	}
 
 	if !cr.Spec.Prometheus.Enabled || !cr.Spec.Prometheus.Route.Enabled {
 		return nil // Prometheus itself or Route not enabled, do nothing.
 	}
 
-	// Allow override of the Annotations for the Route.
-	if len(cr.Spec.Prometheus.Route.Annotations) > 0 {
-		route.Annotations = cr.Spec.Prometheus.Route.Annotations
-	}
-
-	// Allow override of the Labels for the Route.
-	if len(cr.Spec.Prometheus.Route.Labels) > 0 {
-		labels := route.Labels
-		for key, val := range cr.Spec.Prometheus.Route.Labels {
-			labels[key] = val
-		}
-		route.Labels = labels
-	}
+	// Allow override of the Annotations and Labels for the Route.
+	setRouteMetadata(route, cr.Spec.Prometheus.Route)
 
 	// Allow override of the Host for the Route.
 	if len(cr.Spec.Prometheus.Host) > 0 {
 		route.Spec.Host = cr.Spec.Prometheus.Host // TODO: What additional role needed for this?
 	}
 
+	// Allow override of the Path for the Route
+	if len(cr.Spec.Prometheus.Route.Path) > 0 {
+		route.Spec.Path = cr.Spec.Prometheus.Route.Path
+	}
+
 	route.Spec.Port = &routev1.RoutePort{
 		TargetPort: intstr.FromString("web"),
 	}
@@ -203,14 +230,15 @@ func (r *ReconcileArgoCD) reconcilePrometheusRoute(cr *argoprojv1a1.ArgoCD) erro
 	route.Spec.To.Name = "prometheus-operated"
 
 	// Allow override of the WildcardPolicy for the Route
-	if cr.Spec.Prometheus.Route.WildcardPolicy != nil && len(*cr.Spec.Prometheus.Route.WildcardPolicy) > 0 {
-		route.Spec.WildcardPolicy = *cr.Spec.Prometheus.Route.WildcardPolicy
-	}
+	route.Spec.WildcardPolicy = getRouteWildcardPolicy(cr.Spec.Prometheus.Route)
 
 	if err := controllerutil.SetControllerReference(cr, route, r.Scheme); err != nil {
 		return err
 	}
-	return r.Client.Create(context.TODO(), route)
+	if !found {
+		return r.Client.Create(context.TODO(), route)
+	}
+	return r.Client.Update(context.TODO(), route)
 }
 
 // reconcileServerRoute will ensure that the ArgoCD Server Route is present.
@@ -223,32 +251,36 @@ func (r *ReconcileArgoCD) reconcileServerRoute(cr *argoprojv1a1.ArgoCD) error {
 			// Route exists but enabled flag has been set to false, delete the Route
 			return r.Client.Delete(context.TODO(), route)
 		}
+		deleted, err := r.deleteRouteIfWildcardPolicyChanged(route, cr.Spec.Server.Route)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			route = newRouteWithSuffix("server", cr)
+			found = false
+		}
 	}
 
 	if !cr.Spec.Server.Route.Enabled {
 		return nil // Route not enabled, move along...
 	}
 
-	// Allow override of the Annotations for the Route.
-	if len(cr.Spec.Server.Route.Annotations) > 0 {
-		route.Annotations = cr.Spec.Server.Route.Annotations
-	}
-
-	// Allow override of the Labels for the Route.
-	if len(cr.Spec.Server.Route.Labels) > 0 {
-		labels := route.Labels
-		for key, val := range cr.Spec.Server.Route.Labels {
-			labels[key] = val
-		}
-		route.Labels = labels
-	}
+	// Allow override of the Annotations and Labels for the Route.
+	setRouteMetadata(route, cr.Spec.Server.Route)
 
 	// Allow override of the Host for the Route.
 	if len(cr.Spec.Server.Host) > 0 {
 		route.Spec.Host = cr.Spec.Server.Host // TODO: What additional role needed for this?
 	}
 
-	if cr.Spec.Server.Insecure {
+	// Allow override of the Path for the Route, the server root path is used by default
+	if len(cr.Spec.Server.Route.Path) > 0 {
+		route.Spec.Path = cr.Spec.Server.Route.Path
+	} else if len(cr.Spec.Server.RootPath) > 0 {
+		route.Spec.Path = cr.Spec.Server.RootPath
+	}
+
+	if getArgoServerInsecure(cr) {
 		// Disable TLS and rely on the cluster certificate.
 		route.Spec.Port = &routev1.RoutePort{
 			TargetPort: intstr.FromString("http"),
@@ -277,9 +309,7 @@ func (r *ReconcileArgoCD) reconcileServerRoute(cr *argoprojv1a1.ArgoCD) error {
 	route.Spec.To.Name = nameWithSuffix("server", cr)
 
 	// Allow override of the WildcardPolicy for the Route
-	if cr.Spec.Server.Route.WildcardPolicy != nil && len(*cr.Spec.Server.Route.WildcardPolicy) > 0 {
-		route.Spec.WildcardPolicy = *cr.Spec.Server.Route.WildcardPolicy
-	}
+	route.Spec.WildcardPolicy = getRouteWildcardPolicy(cr.Spec.Server.Route)
 
 	if err := controllerutil.SetControllerReference(cr, route, r.Scheme); err != nil {
 		return err
@@ -300,31 +330,33 @@ func (r *ReconcileArgoCD) reconcileApplicationSetControllerWebhookRoute(cr *argo
 			// Route exists but enabled flag has been set to false, delete the Route
 			return r.Client.Delete(context.TODO(), route)
 		}
+		deleted, err := r.deleteRouteIfWildcardPolicyChanged(route, cr.Spec.ApplicationSet.WebhookServer.Route)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			route = newRouteWithSuffix(name, cr)
+			found = false
+		}
 	}
 
 	if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.WebhookServer.Route.Enabled {
 		return nil // Route not enabled, move along...
 	}
 
-	// Allow override of the Annotations for the Route.
-	if len(cr.Spec.ApplicationSet.WebhookServer.Route.Annotations) > 0 {
-		route.Annotations = cr.Spec.Server.Route.Annotations
-	}
-
-	// Allow override of the Labels for the Route.
-	if len(cr.Spec.ApplicationSet.WebhookServer.Route.Labels) > 0 {
-		labels := route.Labels
-		for key, val := range cr.Spec.Server.Route.Labels {
-			labels[key] = val
-		}
-		route.Labels = labels
-	}
+	// Allow override of the Annotations and Labels for the Route.
+	setRouteMetadata(route, cr.Spec.ApplicationSet.WebhookServer.Route)
 
 	// Allow override of the Host for the Route.
-	if len(cr.Spec.Server.Host) > 0 {
+	if len(cr.Spec.ApplicationSet.WebhookServer.Host) > 0 {
 		route.Spec.Host = cr.Spec.ApplicationSet.WebhookServer.Host
 	}
 
+	// Allow override of the Path for the Route
+	if len(cr.Spec.ApplicationSet.WebhookServer.Route.Path) > 0 {
+		route.Spec.Path = cr.Spec.ApplicationSet.WebhookServer.Route.Path
+	}
+
 	if cr.Spec.Server.Insecure {
 		// Disable TLS and rely on the cluster certificate.
 		route.Spec.Port = &routev1.RoutePort{
@@ -346,17 +378,15 @@ func (r *ReconcileArgoCD) reconcileApplicationSetControllerWebhookRoute(cr *argo
 	}
 
 	// Allow override of TLS options for the Route
-	if cr.Spec.Server.Route.TLS != nil {
-		route.Spec.TLS = cr.Spec.Server.Route.TLS
+	if cr.Spec.ApplicationSet.WebhookServer.Route.TLS != nil {
+		route.Spec.TLS = cr.Spec.ApplicationSet.WebhookServer.Route.TLS
 	}
 
 	route.Spec.To.Kind = "Service"
 	route.Spec.To.Name = nameWithSuffix(common.ApplicationSetServiceNameSuffix, cr)
 
 	// Allow override of the WildcardPolicy for the Route
-	if cr.Spec.Server.Route.WildcardPolicy != nil && len(*cr.Spec.Server.Route.WildcardPolicy) > 0 {
-		route.Spec.WildcardPolicy = *cr.Spec.Server.Route.WildcardPolicy
-	}
+	route.Spec.WildcardPolicy = getRouteWildcardPolicy(cr.Spec.ApplicationSet.WebhookServer.Route)
 
 	if err := controllerutil.SetControllerReference(cr, route, r.Scheme); err != nil {
 		return err
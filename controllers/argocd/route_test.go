@@ -201,13 +201,80 @@ func TestReconcileRouteUnsetsInsecure(t *testing.T) {
 	}
 }
 
+func TestReconcileGrafanaRoute_metadata(t *testing.T) {
+	ctx := context.Background()
+	logf.SetLogger(ZapLogger(true))
+	argoCD := makeArgoCD(func(a *argov1alpha1.ArgoCD) {
+		a.Spec.Grafana.Enabled = true
+		a.Spec.Grafana.Route.Enabled = true
+	})
+	r := makeReconciler(t, argoCD, argoCD)
+	assert.NoError(t, r.reconcileGrafanaRoute(argoCD))
+
+	loaded := &routev1.Route{}
+	assert.NoError(t, r.Client.Get(ctx, types.NamespacedName{Name: testArgoCDName + "-grafana", Namespace: testNamespace}, loaded))
+	assert.Equal(t, routev1.WildcardPolicyNone, loaded.Spec.WildcardPolicy)
+
+	// Annotations added by others survive, changes to the spec are applied to the existing Route
+	loaded.Annotations = map[string]string{"cert-utils-operator.redhat-cop.io/certs-from-secret": "grafana-tls"}
+	assert.NoError(t, r.Client.Update(ctx, loaded))
+
+	argoCD.Spec.Grafana.Host = "grafana.apps.example.com"
+	argoCD.Spec.Grafana.Route.Annotations = map[string]string{"external-dns.alpha.kubernetes.io/target": "router.example.com"}
+	argoCD.Spec.Grafana.Route.Labels = map[string]string{"team": "platform"}
+	assert.NoError(t, r.reconcileGrafanaRoute(argoCD))
+	assert.NoError(t, r.Client.Get(ctx, types.NamespacedName{Name: testArgoCDName + "-grafana", Namespace: testNamespace}, loaded))
+	assert.Equal(t, "grafana.apps.example.com", loaded.Spec.Host)
+	assert.Equal(t, map[string]string{
+		"cert-utils-operator.redhat-cop.io/certs-from-secret": "grafana-tls",
+		"external-dns.alpha.kubernetes.io/target":             "router.example.com",
+	}, loaded.Annotations)
+	assert.Equal(t, "platform", loaded.Labels["team"])
+
+	// The wildcard policy of a Route is immutable, the Route is recreated
+	subdomain := routev1.WildcardPolicySubdomain
+	argoCD.Spec.Grafana.Route.WildcardPolicy = &subdomain
+	assert.NoError(t, r.reconcileGrafanaRoute(argoCD))
+	assert.NoError(t, r.Client.Get(ctx, types.NamespacedName{Name: testArgoCDName + "-grafana", Namespace: testNamespace}, loaded))
+	assert.Equal(t, routev1.WildcardPolicySubdomain, loaded.Spec.WildcardPolicy)
+	assert.Equal(t, "router.example.com", loaded.Annotations["external-dns.alpha.kubernetes.io/target"])
+}
+
+func TestReconcileApplicationSetControllerWebhookRoute_spec(t *testing.T) {
+	ctx := context.Background()
+	logf.SetLogger(ZapLogger(true))
+	argoCD := makeArgoCD(func(a *argov1alpha1.ArgoCD) {
+		a.Spec.Server.Route.Annotations = map[string]string{"server": "annotation"}
+		a.Spec.ApplicationSet = &argov1alpha1.ArgoCDApplicationSet{
+			WebhookServer: argov1alpha1.WebhookServerSpec{
+				Host: "webhook.apps.example.com",
+				Route: argov1alpha1.ArgoCDRouteSpec{
+					Enabled:     true,
+					Annotations: map[string]string{"webhook": "annotation"},
+					TLS: &routev1.TLSConfig{
+						Termination: routev1.TLSTerminationReencrypt,
+					},
+				},
+			},
+		}
+	})
+	r := makeReconciler(t, argoCD, argoCD)
+	assert.NoError(t, r.reconcileApplicationSetControllerWebhookRoute(argoCD))
+
+	loaded := &routev1.Route{}
+	assert.NoError(t, r.Client.Get(ctx, types.NamespacedName{Name: testArgoCDName + "-applicationset-controller-webhook", Namespace: testNamespace}, loaded))
+	assert.Equal(t, map[string]string{"webhook": "annotation"}, loaded.Annotations)
+	assert.Equal(t, "webhook.apps.example.com", loaded.Spec.Host)
+	assert.Equal(t, routev1.TLSTerminationReencrypt, loaded.Spec.TLS.Termination)
+}
+
 func makeReconciler(t *testing.T, acd *argov1alpha1.ArgoCD, objs ...runtime.Object) *ReconcileArgoCD {
 	t.Helper()
 	s := scheme.Scheme
 	s.AddKnownTypes(argov1alpha1.GroupVersion, acd)
 	routev1.Install(s)
 	configv1.Install(s)
-	cl := fake.NewFakeClient(objs...)
+	cl := newTestApplyClient(fake.NewFakeClient(objs...))
 	return &ReconcileArgoCD{
 		Client: cl,
 		Scheme: s,
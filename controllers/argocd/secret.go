@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
 	"sort"
 	"strings"
 	"time"
@@ -36,6 +37,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -183,6 +185,7 @@ func (r *ReconcileArgoCD) reconcileArgoSecret(cr *argoprojv1a1.ArgoCD) error {
 		common.ArgoCDKeyTLSCert:            tlsSecret.Data[common.ArgoCDKeyTLSCert],
 		common.ArgoCDKeyTLSPrivateKey:      tlsSecret.Data[common.ArgoCDKeyTLSPrivateKey],
 	}
+	r.reconcileArgoSecretKeyRefs(cr, secret)
 
 	if cr.Spec.SSO != nil && cr.Spec.SSO.Provider == v1alpha1.SSOProviderTypeDex {
 		dexOIDCClientSecret, err := r.getDexOAuthClientSecret(cr)
@@ -198,20 +201,64 @@ func (r *ReconcileArgoCD) reconcileArgoSecret(cr *argoprojv1a1.ArgoCD) error {
 	return r.Client.Create(context.TODO(), secret)
 }
 
-// reconcileClusterMainSecret will ensure that the main Secret is present for the Argo CD cluster.
-func (r *ReconcileArgoCD) reconcileClusterMainSecret(cr *argoprojv1a1.ArgoCD) error {
-	secret := argoutil.NewSecretWithSuffix(cr, "cluster")
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
-		return nil // Secret found, do nothing
+// getAdminPasswordFromSecretRef returns the admin password from the Secret referenced by the AdminPasswordSecretRef
+// of the given ArgoCD. The returned bool is false if the Secret or the key does not exist.
+func (r *ReconcileArgoCD) getAdminPasswordFromSecretRef(cr *argoprojv1a1.ArgoCD) ([]byte, bool) {
+	ref := cr.Spec.AdminPasswordSecretRef
+	secret := &corev1.Secret{}
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, ref.Name, secret) {
+		return nil, false
 	}
 
-	adminPassword, err := generateArgoAdminPassword()
-	if err != nil {
-		return err
+	password, ok := secret.Data[ref.Key]
+	if !ok || len(password) == 0 {
+		return nil, false
 	}
+	return password, true
+}
 
-	secret.Data = map[string][]byte{
-		common.ArgoCDKeyAdminPassword: adminPassword,
+// reconcileClusterMainSecret will ensure that the main Secret is present for the Argo CD cluster. When the ArgoCD
+// references an admin password Secret, the admin password is kept in sync with it.
+func (r *ReconcileArgoCD) reconcileClusterMainSecret(cr *argoprojv1a1.ArgoCD) error {
+	secret := argoutil.NewSecretWithSuffix(cr, "cluster")
+	exists := argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret)
+
+	if cr.Spec.AdminPasswordSecretRef != nil {
+		adminPassword, found := r.getAdminPasswordFromSecretRef(cr)
+		if !found {
+			log.Info(fmt.Sprintf("admin password key %s of secret %s not found, waiting to reconcile cluster secret %s",
+				cr.Spec.AdminPasswordSecretRef.Key, cr.Spec.AdminPasswordSecretRef.Name, secret.Name))
+			return nil
+		}
+
+		if exists {
+			if string(secret.Data[common.ArgoCDKeyAdminPassword]) == string(adminPassword) {
+				return nil // Secret found with nothing to do, move along...
+			}
+			log.Info(fmt.Sprintf("admin password secret %s changed, updating cluster secret %s", cr.Spec.AdminPasswordSecretRef.Name, secret.Name))
+			if secret.Data == nil {
+				secret.Data = make(map[string][]byte)
+			}
+			secret.Data[common.ArgoCDKeyAdminPassword] = adminPassword
+			return r.Client.Update(context.TODO(), secret)
+		}
+
+		secret.Data = map[string][]byte{
+			common.ArgoCDKeyAdminPassword: adminPassword,
+		}
+	} else {
+		if exists {
+			return nil // Secret found, do nothing
+		}
+
+		adminPassword, err := generateArgoAdminPassword()
+		if err != nil {
+			return err
+		}
+
+		secret.Data = map[string][]byte{
+			common.ArgoCDKeyAdminPassword: adminPassword,
+		}
 	}
 
 	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
@@ -220,11 +267,14 @@ func (r *ReconcileArgoCD) reconcileClusterMainSecret(cr *argoprojv1a1.ArgoCD) er
 	return r.Client.Create(context.TODO(), secret)
 }
 
-// reconcileClusterTLSSecret ensures the TLS Secret is created for the ArgoCD cluster.
+// reconcileClusterTLSSecret ensures the TLS Secret is created for the ArgoCD cluster, and renews the certificate
+// before it expires or when the CA changed. Secrets not created by the operator are left untouched.
 func (r *ReconcileArgoCD) reconcileClusterTLSSecret(cr *argoprojv1a1.ArgoCD) error {
 	secret := argoutil.NewTLSSecret(cr, "tls")
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
-		return nil // Secret found, do nothing
+	existing := &corev1.Secret{}
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, existing)
+	if found && !metav1.IsControlledBy(existing, cr) {
+		return nil // Secret not managed by the operator, do nothing
 	}
 
 	caSecret := argoutil.NewSecretWithSuffix(cr, "ca")
@@ -243,11 +293,24 @@ func (r *ReconcileArgoCD) reconcileClusterTLSSecret(cr *argoprojv1a1.ArgoCD) err
 		return err
 	}
 
+	if found && !certificateNeedsRenewal(existing, caCert, getTLSRenewBefore(cr)) {
+		return nil // Certificate still valid, do nothing
+	}
+
 	secret, err = newCertificateSecret("tls", caCert, caKey, cr)
 	if err != nil {
 		return err
 	}
 
+	if found {
+		log.Info(fmt.Sprintf("renewing certificate in secret %s", existing.Name))
+		existing.Data = secret.Data
+		if err := r.Client.Update(context.TODO(), existing); err != nil {
+			return err
+		}
+		return r.createCertificateRenewedEvent(existing.Name, cr)
+	}
+
 	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
 		return err
 	}
@@ -255,11 +318,25 @@ func (r *ReconcileArgoCD) reconcileClusterTLSSecret(cr *argoprojv1a1.ArgoCD) err
 	return r.Client.Create(context.TODO(), secret)
 }
 
-// reconcileClusterCASecret ensures the CA Secret is created for the ArgoCD cluster.
+// reconcileClusterCASecret ensures the CA Secret is created for the ArgoCD cluster, and renews the CA certificate
+// before it expires. Secrets not created by the operator are left untouched.
 func (r *ReconcileArgoCD) reconcileClusterCASecret(cr *argoprojv1a1.ArgoCD) error {
-	secret := argoutil.NewSecretWithSuffix(cr, "ca")
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
-		return nil // Secret found, do nothing
+	existing := argoutil.NewSecretWithSuffix(cr, "ca")
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+		if !metav1.IsControlledBy(existing, cr) || !certificateNeedsRenewal(existing, nil, getTLSRenewBefore(cr)) {
+			return nil // Secret found, do nothing
+		}
+
+		secret, err := newCASecret(cr)
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("renewing certificate in secret %s", existing.Name))
+		existing.Data = secret.Data
+		if err := r.Client.Update(context.TODO(), existing); err != nil {
+			return err
+		}
+		return r.createCertificateRenewedEvent(existing.Name, cr)
 	}
 
 	secret, err := newCASecret(cr)
@@ -279,6 +356,10 @@ func (r *ReconcileArgoCD) reconcileClusterSecrets(cr *argoprojv1a1.ArgoCD) error
 		return err
 	}
 
+	if err := r.reconcileAdminPasswordRotation(cr); err != nil {
+		return err
+	}
+
 	if err := r.reconcileClusterCASecret(cr); err != nil {
 		return err
 	}
@@ -328,10 +409,12 @@ func (r *ReconcileArgoCD) reconcileExistingArgoSecret(cr *argoprojv1a1.ArgoCD, s
 		}
 	}
 
+	tlsChanged := false
 	if hasArgoTLSChanged(secret, tlsSecret) {
 		secret.Data[common.ArgoCDKeyTLSCert] = tlsSecret.Data[common.ArgoCDKeyTLSCert]
 		secret.Data[common.ArgoCDKeyTLSPrivateKey] = tlsSecret.Data[common.ArgoCDKeyTLSPrivateKey]
 		changed = true
+		tlsChanged = len(secret.Data[common.ArgoCDKeyTLSCert]) > 0
 	}
 
 	if cr.Spec.SSO != nil && cr.Spec.SSO.Provider == v1alpha1.SSOProviderTypeDex {
@@ -345,6 +428,10 @@ func (r *ReconcileArgoCD) reconcileExistingArgoSecret(cr *argoprojv1a1.ArgoCD, s
 		}
 	}
 
+	if r.reconcileArgoSecretKeyRefs(cr, secret) {
+		changed = true
+	}
+
 	if changed {
 		log.Info("updating argo secret")
 		if err := r.Client.Update(context.TODO(), secret); err != nil {
@@ -352,6 +439,10 @@ func (r *ReconcileArgoCD) reconcileExistingArgoSecret(cr *argoprojv1a1.ArgoCD, s
 		}
 	}
 
+	if tlsChanged {
+		// The server only reads its certificate on startup.
+		return r.triggerRollout(newDeploymentWithSuffix("server", "server", cr), "tls.cert.changed")
+	}
 	return nil
 }
 
@@ -438,7 +529,9 @@ func (r *ReconcileArgoCD) reconcileClusterPermissionsSecret(cr *argoprojv1a1.Arg
 
 	var namespaces []string
 	for _, namespace := range namespaceList.Items {
-		namespaces = append(namespaces, namespace.Name)
+		if isManagedNamespaceAllowed(cr.Namespace, namespace.Name) {
+			namespaces = append(namespaces, namespace.Name)
+		}
 	}
 
 	if !containsString(namespaces, cr.Namespace) {
@@ -471,6 +564,7 @@ func (r *ReconcileArgoCD) reconcileClusterPermissionsSecret(cr *argoprojv1a1.Arg
 	for _, s := range clusterSecrets.Items {
 		// check if cluster secret with default server address exists
 		if string(s.Data["server"]) == common.ArgoCDDefaultServer {
+			existing := s.DeepCopy()
 			// if the cluster belongs to cluster config namespace,
 			// remove all namespaces from cluster secret,
 			// else update the list of namespaces if value differs.
@@ -486,6 +580,12 @@ func (r *ReconcileArgoCD) reconcileClusterPermissionsSecret(cr *argoprojv1a1.Arg
 				sort.Strings(ns)
 				s.Data["namespaces"] = []byte(strings.Join(ns, ","))
 			}
+			if err := setSpecHash(&s); err != nil {
+				return err
+			}
+			if isSpecHashUnchanged(existing, &s) && reflect.DeepEqual(existing.Data, s.Data) {
+				return nil // Secret found with nothing changed, move along...
+			}
 			return r.Client.Update(context.TODO(), &s)
 		}
 	}
@@ -539,10 +639,6 @@ func (r *ReconcileArgoCD) reconcileRepoServerTLSSecret(cr *argoprojv1a1.ArgoCD)
 		// cost of a possibly missed restart when we cannot update the status
 		// field of the resource.
 		cr.Status.RepoTLSChecksum = sha256sum
-		err = r.Client.Status().Update(context.TODO(), cr)
-		if err != nil {
-			return err
-		}
 
 		// Trigger rollout of API server
 		apiDepl := newDeploymentWithSuffix("server", "server", cr)
@@ -569,6 +665,94 @@ func (r *ReconcileArgoCD) reconcileRepoServerTLSSecret(cr *argoprojv1a1.ArgoCD)
 	return nil
 }
 
+// getTLSSecretChecksum returns the SHA256 checksum of tls.crt and tls.key in
+// the TLS secret with the given name, or an empty string if the secret does
+// not exist or is not of type kubernetes.io/tls.
+func (r *ReconcileArgoCD) getTLSSecretChecksum(namespace string, name string) (string, error) {
+	var tlsSecretObj corev1.Secret
+
+	tlsSecretName := types.NamespacedName{Namespace: namespace, Name: name}
+	if err := r.Client.Get(context.TODO(), tlsSecretName, &tlsSecretObj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", err
+		}
+		return "", nil
+	}
+	if tlsSecretObj.Type != corev1.SecretTypeTLS {
+		return "", nil
+	}
+
+	crt, crtOk := tlsSecretObj.Data[corev1.TLSCertKey]
+	key, keyOk := tlsSecretObj.Data[corev1.TLSPrivateKeyKey]
+	if !crtOk || !keyOk {
+		return "", nil
+	}
+	var sumBytes []byte
+	sumBytes = append(sumBytes, crt...)
+	sumBytes = append(sumBytes, key...)
+	return fmt.Sprintf("%x", sha256.Sum256(sumBytes)), nil
+}
+
+// reconcileServerTLSSecret checks whether the argocd-server-tls secret issued
+// by cert-manager has changed since our last reconciliation loop, and rolls
+// out the API server when it has been renewed.
+func (r *ReconcileArgoCD) reconcileServerTLSSecret(cr *argoprojv1a1.ArgoCD) error {
+	if !cr.Spec.TLS.WantsCertManager() {
+		return nil
+	}
+
+	log.Info("reconciling server TLS secret")
+
+	sha256sum, err := r.getTLSSecretChecksum(cr.Namespace, common.ArgoCDServerTLSSecretName)
+	if err != nil {
+		return err
+	}
+
+	if cr.Status.ServerTLSChecksum != sha256sum {
+		// We store the value early to prevent a possible restart loop, for the
+		// cost of a possibly missed restart when we cannot update the status
+		// field of the resource.
+		cr.Status.ServerTLSChecksum = sha256sum
+
+		apiDepl := newDeploymentWithSuffix("server", "server", cr)
+		if err := r.triggerRollout(apiDepl, "server.tls.cert.changed"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileDexTLSSecret checks whether the argocd-dex-server-tls secret issued
+// by cert-manager has changed since our last reconciliation loop, and rolls
+// out the dex server when it has been renewed.
+func (r *ReconcileArgoCD) reconcileDexTLSSecret(cr *argoprojv1a1.ArgoCD) error {
+	if !cr.Spec.TLS.WantsCertManager() || !UseDex(cr) {
+		return nil
+	}
+
+	log.Info("reconciling dex-server TLS secret")
+
+	sha256sum, err := r.getTLSSecretChecksum(cr.Namespace, common.ArgoCDDexServerTLSSecretName)
+	if err != nil {
+		return err
+	}
+
+	if cr.Status.DexTLSChecksum != sha256sum {
+		// We store the value early to prevent a possible restart loop, for the
+		// cost of a possibly missed restart when we cannot update the status
+		// field of the resource.
+		cr.Status.DexTLSChecksum = sha256sum
+
+		dexDepl := newDeploymentWithSuffix("dex-server", "dex-server", cr)
+		if err := r.triggerRollout(dexDepl, "dex.tls.cert.changed"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // reconcileRedisTLSSecret checks whether the argocd-operator-redis-tls secret
 // has changed since our last reconciliation loop. It does so by comparing the
 // checksum of tls.crt and tls.key in the status of the ArgoCD CR against the
@@ -607,10 +791,6 @@ func (r *ReconcileArgoCD) reconcileRedisTLSSecret(cr *argoprojv1a1.ArgoCD, useTL
 		// cost of a possibly missed restart when we cannot update the status
 		// field of the resource.
 		cr.Status.RedisTLSChecksum = sha256sum
-		err = r.Client.Status().Update(context.TODO(), cr)
-		if err != nil {
-			return err
-		}
 
 		// Trigger rollout of redis
 		if cr.Spec.HA.Enabled {
@@ -671,15 +851,163 @@ func (r *ReconcileArgoCD) reconcileRedisTLSSecret(cr *argoprojv1a1.ArgoCD, useTL
 	return nil
 }
 
+// newRedisCertificateSecret creates a new TLS secret for the redis server signed by the given CA, valid for the
+// redis and redis HA services of the given ArgoCD.
+func newRedisCertificateSecret(caCert *x509.Certificate, caKey *rsa.PrivateKey, cr *argoprojv1a1.ArgoCD) (*corev1.Secret, error) {
+	secret := argoutil.NewSecretWithName(cr, common.ArgoCDRedisServerTLSSecretName)
+	secret.Type = corev1.SecretTypeTLS
+
+	key, err := argoutil.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &tlsutil.CertConfig{
+		CertName:     secret.Name,
+		CertType:     tlsutil.ServingCert,
+		CommonName:   nameWithSuffix(common.ArgoCDDefaultRedisSuffix, cr),
+		Organization: []string{cr.ObjectMeta.Namespace},
+	}
+
+	dnsNames := []string{}
+	for _, suffix := range []string{common.ArgoCDDefaultRedisSuffix, "redis-ha", "redis-ha-haproxy"} {
+		name := nameWithSuffix(suffix, cr)
+		dnsNames = append(dnsNames,
+			name,
+			fmt.Sprintf("%s.%s.svc", name, cr.Namespace),
+			fmt.Sprintf("%s.%s.svc.cluster.local", name, cr.Namespace),
+		)
+	}
+	// the redis HA server pods are addressed through the headless service
+	dnsNames = append(dnsNames, fmt.Sprintf("*.%s.%s.svc.cluster.local", nameWithSuffix("redis-ha", cr), cr.Namespace))
+
+	cert, err := argoutil.NewSignedCertificate(cfg, dnsNames, key, caCert, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:       argoutil.EncodeCertificatePEM(cert),
+		corev1.TLSPrivateKeyKey: argoutil.EncodePrivateKeyPEM(key),
+	}
+
+	return secret, nil
+}
+
+// reconcileRedisOperatorTLSSecret ensures the redis server TLS secret is issued from the CA of the ArgoCD cluster when
+// requested by .spec.redis.autotls, and renews the certificate before it expires. Secrets not created by the operator
+// are left untouched. The redis workloads pick up the renewed certificate through reconcileRedisTLSSecret.
+func (r *ReconcileArgoCD) reconcileRedisOperatorTLSSecret(cr *argoprojv1a1.ArgoCD) error {
+	if !cr.Spec.Redis.WantsOperatorTLS() || cr.Spec.Redis.IsRemote() {
+		return nil
+	}
+
+	caSecret, err := argoutil.FetchSecret(r.Client, cr.ObjectMeta, argoutil.NewSecretWithSuffix(cr, "ca").Name)
+	if err != nil {
+		return err
+	}
+
+	caCert, err := argoutil.ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return err
+	}
+
+	caKey, err := argoutil.ParsePEMEncodedPrivateKey(caSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return err
+	}
+
+	existing := &corev1.Secret{}
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, common.ArgoCDRedisServerTLSSecretName, existing) {
+		if !metav1.IsControlledBy(existing, cr) {
+			log.Info(fmt.Sprintf("secret %s is not managed by the operator, skipping", existing.Name))
+			return nil
+		}
+		if !certificateNeedsRenewal(existing, caCert, getTLSRenewBefore(cr)) {
+			return nil
+		}
+
+		secret, err := newRedisCertificateSecret(caCert, caKey, cr)
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("renewing certificate in secret %s", existing.Name))
+		existing.Data = secret.Data
+		return r.Client.Update(context.TODO(), existing)
+	}
+
+	secret, err := newRedisCertificateSecret(caCert, caKey, cr)
+	if err != nil {
+		return err
+	}
+
+	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("creating secret %s", secret.Name))
+	return r.Client.Create(context.TODO(), secret)
+}
+
 // reconcileSecrets will reconcile all ArgoCD Secret resources.
 func (r *ReconcileArgoCD) reconcileSecrets(cr *argoprojv1a1.ArgoCD) error {
 	if err := r.reconcileClusterSecrets(cr); err != nil {
 		return err
 	}
 
+	if err := r.reconcileRedisOperatorTLSSecret(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileRedisAuthSecret(cr); err != nil {
+		return err
+	}
+
 	if err := r.reconcileArgoSecret(cr); err != nil {
 		return err
 	}
 
+	if err := r.reconcileInitialSecrets(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileClusterShardAssignment(cr); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+// reconcileRedisAuthSecret will ensure that the Secret holding the Redis password is present when Redis AUTH is
+// enabled for the given ArgoCD. A Secret provided by the user is never modified.
+func (r *ReconcileArgoCD) reconcileRedisAuthSecret(cr *argoprojv1a1.ArgoCD) error {
+	secret := argoutil.NewSecretWithName(cr, common.ArgoCDRedisAuthSecretName)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret)
+
+	if !isRedisAuthEnabled(cr) {
+		// Only remove the Secret if it was generated by the operator
+		if found && metav1.IsControlledBy(secret, cr) {
+			log.Info(fmt.Sprintf("deleting redis auth secret %s as redis auth is disabled", secret.Name))
+			return r.Client.Delete(context.TODO(), secret)
+		}
+		return nil
+	}
+
+	if found {
+		if _, ok := secret.Data[common.ArgoCDKeyRedisAuth]; !ok {
+			log.Info(fmt.Sprintf("redis auth secret %s has no %s key", secret.Name, common.ArgoCDKeyRedisAuth))
+		}
+		return nil // Secret found, do nothing
+	}
+
+	secret.Data = map[string][]byte{
+		common.ArgoCDKeyRedisAuth: []byte(generateRandomString(24)),
+	}
+
+	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("creating redis auth secret %s", secret.Name))
+	return r.Client.Create(context.TODO(), secret)
+}
@@ -12,6 +12,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -248,6 +249,45 @@ func Test_ReconcileArgoCD_ReconcileExistingArgoSecret(t *testing.T) {
 
 }
 
+func Test_ReconcileArgoCD_ReconcileClusterMainSecret_adminPasswordSecretRef(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.AdminPasswordSecretRef = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "admin-credentials"},
+			Key:                  "password",
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	// the cluster secret is not created until the referenced secret exists
+	assert.NoError(t, r.reconcileClusterMainSecret(a))
+	clusterSecret := &corev1.Secret{}
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster", Namespace: a.Namespace}, clusterSecret))
+
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "admin-credentials", Namespace: a.Namespace},
+		Data:       map[string][]byte{"password": []byte("s3cr3t")},
+	}
+	assert.NoError(t, r.Client.Create(context.TODO(), credentials))
+
+	assert.NoError(t, r.reconcileClusterMainSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster", Namespace: a.Namespace}, clusterSecret))
+	assert.Equal(t, "s3cr3t", string(clusterSecret.Data[common.ArgoCDKeyAdminPassword]))
+
+	// rotating the referenced secret updates the cluster secret
+	credentials.Data["password"] = []byte("rotated")
+	assert.NoError(t, r.Client.Update(context.TODO(), credentials))
+	assert.NoError(t, r.reconcileClusterMainSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster", Namespace: a.Namespace}, clusterSecret))
+	assert.Equal(t, "rotated", string(clusterSecret.Data[common.ArgoCDKeyAdminPassword]))
+
+	// without a reference, the existing password is kept
+	a.Spec.AdminPasswordSecretRef = nil
+	assert.NoError(t, r.reconcileClusterMainSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster", Namespace: a.Namespace}, clusterSecret))
+	assert.Equal(t, "rotated", string(clusterSecret.Data[common.ArgoCDKeyAdminPassword]))
+}
+
 func Test_ReconcileArgoCD_ReconcileRedisTLSSecret(t *testing.T) {
 	argocd := &v1alpha1.ArgoCD{
 		ObjectMeta: metav1.ObjectMeta{
@@ -419,6 +459,123 @@ func Test_ReconcileArgoCD_ReconcileRedisTLSSecret(t *testing.T) {
 	})
 }
 
+func Test_ReconcileArgoCD_ReconcileRedisOperatorTLSSecret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Redis.AutoTLS = "operator"
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileClusterCASecret(a))
+
+	assert.NoError(t, r.reconcileRedisOperatorTLSSecret(a))
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Name: common.ArgoCDRedisServerTLSSecretName, Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+	assert.Equal(t, corev1.SecretTypeTLS, secret.Type)
+	assert.True(t, metav1.IsControlledBy(secret, a))
+	assert.True(t, r.redisShouldUseTLS(a))
+
+	cert, err := argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.Contains(t, cert.DNSNames, "argocd-redis.argocd.svc.cluster.local")
+	assert.Contains(t, cert.DNSNames, "argocd-redis-ha-haproxy.argocd.svc.cluster.local")
+
+	// a valid certificate is not renewed
+	assert.NoError(t, r.reconcileRedisOperatorTLSSecret(a))
+	unchanged := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, unchanged))
+	assert.Equal(t, secret.Data, unchanged.Data)
+
+	// an invalid certificate is renewed
+	unchanged.Data[corev1.TLSCertKey] = []byte("invalid")
+	assert.NoError(t, r.Client.Update(context.TODO(), unchanged))
+	assert.NoError(t, r.reconcileRedisOperatorTLSSecret(a))
+	renewed := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, renewed))
+	_, err = argoutil.ParsePEMEncodedCert(renewed.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.NotEqual(t, secret.Data[corev1.TLSCertKey], renewed.Data[corev1.TLSCertKey])
+}
+
+func Test_ReconcileArgoCD_ReconcileRedisOperatorTLSSecret_userProvided(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Redis.AutoTLS = "operator"
+	})
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.ArgoCDRedisServerTLSSecretName,
+			Namespace: a.Namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("cert"),
+			corev1.TLSPrivateKeyKey: []byte("key"),
+		},
+	}
+	r := makeTestReconciler(t, a, userSecret)
+	assert.NoError(t, r.reconcileClusterCASecret(a))
+
+	// secrets provided by the user, e.g. through cert-manager, are not overwritten
+	assert.NoError(t, r.reconcileRedisOperatorTLSSecret(a))
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisServerTLSSecretName, Namespace: a.Namespace}, secret))
+	assert.Equal(t, []byte("cert"), secret.Data[corev1.TLSCertKey])
+}
+
+func Test_ReconcileArgoCD_ReconcileRedisAuthSecret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Redis.EnableAuth = true
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileRedisAuthSecret(a))
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisAuthSecretName, Namespace: a.Namespace}, secret))
+	password := secret.Data[common.ArgoCDKeyRedisAuth]
+	assert.NotEmpty(t, password)
+
+	// the generated password is kept on subsequent reconciliations
+	assert.NoError(t, r.reconcileRedisAuthSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisAuthSecretName, Namespace: a.Namespace}, secret))
+	assert.Equal(t, password, secret.Data[common.ArgoCDKeyRedisAuth])
+
+	// the generated secret is removed when auth is disabled
+	a.Spec.Redis.EnableAuth = false
+	assert.NoError(t, r.reconcileRedisAuthSecret(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisAuthSecretName, Namespace: a.Namespace}, secret)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func Test_ReconcileArgoCD_ReconcileRedisAuthSecret_userProvided(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Redis.EnableAuth = true
+	})
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.ArgoCDRedisAuthSecretName,
+			Namespace: a.Namespace,
+		},
+		Data: map[string][]byte{
+			common.ArgoCDKeyRedisAuth: []byte("password"),
+		},
+	}
+	r := makeTestReconciler(t, a, userSecret)
+
+	assert.NoError(t, r.reconcileRedisAuthSecret(a))
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisAuthSecretName, Namespace: a.Namespace}, secret))
+	assert.Equal(t, []byte("password"), secret.Data[common.ArgoCDKeyRedisAuth])
+
+	// secrets provided by the user are not removed
+	a.Spec.Redis.EnableAuth = false
+	assert.NoError(t, r.reconcileRedisAuthSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisAuthSecretName, Namespace: a.Namespace}, secret))
+}
+
 func Test_ReconcileArgoCD_ClusterPermissionsSecret(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
@@ -459,3 +616,24 @@ func Test_ReconcileArgoCD_ClusterPermissionsSecret(t *testing.T) {
 	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: testSecret.Name, Namespace: testSecret.Namespace}, testSecret))
 	assert.Nil(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: testSecret.Name, Namespace: testSecret.Namespace}, testSecret))
 }
+
+func Test_ReconcileArgoCD_ClusterPermissionsSecret_unchanged(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	secret := argoutil.NewSecretWithSuffix(a, "default-cluster-config")
+	secret.Labels[common.ArgoCDSecretTypeLabel] = "cluster"
+	secret.Data = map[string][]byte{"server": []byte(common.ArgoCDDefaultServer), "namespaces": []byte(a.Namespace)}
+	r := makeTestReconciler(t, a, secret)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	key := types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}
+	assert.NoError(t, r.reconcileClusterPermissionsSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+	assert.NotEmpty(t, secret.Annotations[common.AnnotationSpecHash])
+	resourceVersion := secret.ResourceVersion
+
+	// reconciling unchanged namespaces does not update the Secret
+	assert.NoError(t, r.reconcileClusterPermissionsSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+	assert.Equal(t, resourceVersion, secret.ResourceVersion)
+}
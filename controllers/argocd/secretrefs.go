@@ -0,0 +1,167 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// reservedArgoSecretKeys are the keys of argocd-secret managed by the operator, which cannot be sourced from a
+// secretKeyRef.
+var reservedArgoSecretKeys = map[string]bool{
+	common.ArgoCDKeyAdminPassword:      true,
+	common.ArgoCDKeyAdminPasswordMTime: true,
+	common.ArgoCDKeyServerSecretKey:    true,
+	common.ArgoCDKeyTLSCert:            true,
+	common.ArgoCDKeyTLSPrivateKey:      true,
+	common.ArgoCDDexSecretKey:          true,
+}
+
+// isSecretReferenced returns true if the Secret with the given name is referenced by the given ArgoCD as the source of
+// secret material that the operator copies into the Secrets it manages, or uses itself, e.g. the external Grafana API key.
+func isSecretReferenced(name string, cr *argoprojv1a1.ArgoCD) bool {
+	if cr.Spec.AdminPasswordSecretRef != nil && cr.Spec.AdminPasswordSecretRef.Name == name {
+		return true
+	}
+	if cr.Spec.Notifications.SecretName == name {
+		return true
+	}
+	for _, ref := range getNotificationsIntegrationsSecretRefs(cr) {
+		if ref.Name == name {
+			return true
+		}
+	}
+	if app := getApplicationSetGitHubApp(cr); app != nil && app.PrivateKeySecretRef.Name == name {
+		return true
+	}
+	if ref := getKeycloakAdminCredentialsSecretRef(cr); ref != nil && ref.Name == name {
+		return true
+	}
+	if cr.Spec.Monitoring.ExternalGrafana != nil && cr.Spec.Monitoring.ExternalGrafana.APIKeySecretRef.Name == name {
+		return true
+	}
+	for _, ref := range cr.Spec.SecretKeyRefs {
+		if ref.SecretRef.Name == name {
+			return true
+		}
+	}
+	for _, repo := range cr.Spec.InitialRepositorySecrets {
+		if repo.CredentialsSecret == name {
+			return true
+		}
+	}
+	for _, creds := range cr.Spec.InitialRepositoryCredentials {
+		if creds.CredentialsSecret == name {
+			return true
+		}
+		for _, ref := range getRepositoryCredentialsSecretRefs(creds) {
+			if ref.Name == name {
+				return true
+			}
+		}
+	}
+	for _, cluster := range cr.Spec.InitialClusters {
+		if cluster.CredentialsSecret == name {
+			return true
+		}
+	}
+	if agent := getAgentAgent(cr); agent.IsEnabled() && agent.ClientTLSSecretName == name {
+		return true
+	}
+	return false
+}
+
+// getManagedSecretKeyRefs returns the keys of the given argocd-secret that were sourced from secretKeyRefs.
+func getManagedSecretKeyRefs(secret *corev1.Secret) []string {
+	keys := secret.Annotations[common.AnnotationSecretKeyRefs]
+	if keys == "" {
+		return nil
+	}
+	return strings.Split(keys, ",")
+}
+
+// reconcileArgoSecretKeyRefs will set the keys of the given argocd-secret that are sourced from the secretKeyRefs of
+// the given ArgoCD, and remove the keys that were sourced from secretKeyRefs that are no longer listed. The returned
+// bool is true if the Secret was changed.
+func (r *ReconcileArgoCD) reconcileArgoSecretKeyRefs(cr *argoprojv1a1.ArgoCD, secret *corev1.Secret) bool {
+	changed := false
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+
+	desired := make(map[string]bool)
+	for _, ref := range cr.Spec.SecretKeyRefs {
+		if reservedArgoSecretKeys[ref.Key] {
+			err := fmt.Errorf("key %s of argocd-secret is managed by the operator and cannot be sourced from a secretKeyRef", ref.Key)
+			log.Error(err, fmt.Sprintf("invalid secretKeyRef for Argo CD instance %s in namespace %s", cr.Name, cr.Namespace))
+			if err := argoutil.CreateEvent(r.Client, "Warning", "Invalid", err.Error(), "InvalidSecretKeyRef", cr.ObjectMeta, cr.TypeMeta); err != nil {
+				log.Error(err, "failed to create event for invalid secretKeyRef")
+			}
+			continue
+		}
+		desired[ref.Key] = true
+
+		source := &corev1.Secret{}
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, ref.SecretRef.Name, source) {
+			log.Info(fmt.Sprintf("secret %s referenced by key %s of argocd-secret not found, keeping the current value", ref.SecretRef.Name, ref.Key))
+			continue
+		}
+		value, ok := source.Data[ref.SecretRef.Key]
+		if !ok {
+			log.Info(fmt.Sprintf("key %s of secret %s referenced by key %s of argocd-secret not found, keeping the current value", ref.SecretRef.Key, ref.SecretRef.Name, ref.Key))
+			continue
+		}
+
+		if current, ok := secret.Data[ref.Key]; !ok || !bytes.Equal(current, value) {
+			secret.Data[ref.Key] = value
+			changed = true
+		}
+	}
+
+	for _, key := range getManagedSecretKeyRefs(secret) {
+		if _, ok := secret.Data[key]; ok && !desired[key] {
+			delete(secret.Data, key)
+			changed = true
+		}
+	}
+
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if strings.Join(keys, ",") != secret.Annotations[common.AnnotationSecretKeyRefs] {
+		if len(keys) == 0 {
+			delete(secret.Annotations, common.AnnotationSecretKeyRefs)
+		} else {
+			if secret.Annotations == nil {
+				secret.Annotations = make(map[string]string)
+			}
+			secret.Annotations[common.AnnotationSecretKeyRefs] = strings.Join(keys, ",")
+		}
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,147 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func makeTestSecretKeyRef(key, name, secretKey string) argoprojv1alpha1.ArgoCDSecretKeyRef {
+	return argoprojv1alpha1.ArgoCDSecretKeyRef{
+		Key: key,
+		SecretRef: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  secretKey,
+		},
+	}
+}
+
+func TestReconcileArgoCD_reconcileArgoSecret_secretKeyRefs(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.SecretKeyRefs = []argoprojv1alpha1.ArgoCDSecretKeyRef{
+			makeTestSecretKeyRef("webhook.github.secret", "vault-secrets", "github-webhook"),
+			makeTestSecretKeyRef("dex.github.clientSecret", "vault-secrets", "github-client-secret"),
+		}
+	})
+	clusterSecret := argoutil.NewSecretWithSuffix(a, "cluster")
+	clusterSecret.Data = map[string][]byte{common.ArgoCDKeyAdminPassword: []byte("password")}
+	tlsSecret := argoutil.NewSecretWithSuffix(a, "tls")
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-secrets", Namespace: a.Namespace},
+		Data: map[string][]byte{
+			"github-webhook":       []byte("webhook"),
+			"github-client-secret": []byte("client"),
+		},
+	}
+	r := makeTestReconciler(t, a, clusterSecret, tlsSecret, source)
+
+	assert.NoError(t, r.reconcileArgoSecret(a))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, secret))
+	assert.Equal(t, "webhook", string(secret.Data["webhook.github.secret"]))
+	assert.Equal(t, "client", string(secret.Data["dex.github.clientSecret"]))
+	assert.Equal(t, "dex.github.clientSecret,webhook.github.secret", secret.Annotations[common.AnnotationSecretKeyRefs])
+
+	// changes to the referenced secret are picked up
+	source.Data["github-webhook"] = []byte("rotated")
+	assert.NoError(t, r.Client.Update(context.TODO(), source))
+	assert.NoError(t, r.reconcileArgoSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, secret))
+	assert.Equal(t, "rotated", string(secret.Data["webhook.github.secret"]))
+
+	// keys of removed references are removed, other keys are kept
+	secret.Data["webhook.gitlab.secret"] = []byte("set by hand")
+	assert.NoError(t, r.Client.Update(context.TODO(), secret))
+	a.Spec.SecretKeyRefs = a.Spec.SecretKeyRefs[:1]
+	assert.NoError(t, r.reconcileArgoSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, secret))
+	_, ok := secret.Data["dex.github.clientSecret"]
+	assert.False(t, ok)
+	assert.Equal(t, "set by hand", string(secret.Data["webhook.gitlab.secret"]))
+	assert.Equal(t, "webhook.github.secret", secret.Annotations[common.AnnotationSecretKeyRefs])
+}
+
+func TestReconcileArgoCD_reconcileArgoSecretKeyRefs_invalid(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.SecretKeyRefs = []argoprojv1alpha1.ArgoCDSecretKeyRef{
+			makeTestSecretKeyRef(common.ArgoCDKeyServerSecretKey, "vault-secrets", "key"),
+			makeTestSecretKeyRef("webhook.github.secret", "missing", "key"),
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	secret := argoutil.NewSecretWithName(a, common.ArgoCDSecretName)
+	secret.Data = map[string][]byte{
+		common.ArgoCDKeyServerSecretKey: []byte("session"),
+		"webhook.github.secret":         []byte("current"),
+	}
+	r.reconcileArgoSecretKeyRefs(a, secret)
+
+	// reserved keys are not overridden, values of missing secrets are kept
+	assert.Equal(t, "session", string(secret.Data[common.ArgoCDKeyServerSecretKey]))
+	assert.Equal(t, "current", string(secret.Data["webhook.github.secret"]))
+
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events))
+	assert.Len(t, events.Items, 1)
+	assert.Equal(t, "InvalidSecretKeyRef", events.Items[0].Reason)
+}
+
+func TestIsSecretReferenced(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.SecretKeyRefs = []argoprojv1alpha1.ArgoCDSecretKeyRef{
+			makeTestSecretKeyRef("webhook.github.secret", "vault-secrets", "key"),
+		}
+		a.Spec.Notifications.SecretName = "smtp-credentials"
+		a.Spec.InitialRepositorySecrets = []argoprojv1alpha1.ArgoCDRepositorySpec{
+			{Name: "private", URL: "https://github.com/example/private", CredentialsSecret: "repo-credentials"},
+		}
+		a.Spec.InitialRepositoryCredentials = []argoprojv1alpha1.ArgoCDRepositoryCredentialsSpec{
+			{Name: "github", URL: "https://github.com/example", GitHubApp: &argoprojv1alpha1.ArgoCDGitHubAppCredentialsSpec{
+				AppID:          1,
+				InstallationID: 1,
+				PrivateKeySecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "github-app"},
+					Key:                  "private-key",
+				},
+			}},
+		}
+		a.Spec.Monitoring.ExternalGrafana = &argoprojv1alpha1.ArgoCDExternalGrafanaSpec{
+			URL: "https://grafana.example.com",
+			APIKeySecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "grafana-api-key"},
+				Key:                  "token",
+			},
+		}
+		a.Spec.Notifications.Integrations.Slack = &argoprojv1alpha1.ArgoCDNotificationsSlackSpec{
+			TokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "slack-bot"},
+				Key:                  "token",
+			},
+		}
+		a.Spec.ArgoCDAgent = &argoprojv1alpha1.ArgoCDAgentSpec{
+			Agent: &argoprojv1alpha1.ArgoCDAgentAgentSpec{Enabled: true, PrincipalAddress: "principal.example.com", ClientTLSSecretName: "agent-client-tls"},
+		}
+	})
+
+	assert.True(t, isSecretReferenced("vault-secrets", a))
+	assert.True(t, isSecretReferenced("smtp-credentials", a))
+	assert.True(t, isSecretReferenced("repo-credentials", a))
+	assert.True(t, isSecretReferenced("github-app", a))
+	assert.True(t, isSecretReferenced("grafana-api-key", a))
+	assert.True(t, isSecretReferenced("slack-bot", a))
+	assert.True(t, isSecretReferenced("agent-client-tls", a))
+	assert.False(t, isSecretReferenced("other", a))
+}
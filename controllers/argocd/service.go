@@ -17,6 +17,7 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -65,6 +66,58 @@ func newServiceWithSuffix(suffix string, component string, cr *argoprojv1a1.Argo
 	return newServiceWithName(fmt.Sprintf("%s-%s", cr.Name, suffix), component, cr)
 }
 
+// setServiceNetworking sets the IP families, IP family policy and internal traffic policy of the given service spec
+// on the Service. Options that are not set in the spec are left to the cluster defaults.
+//
+// Returns true when the Service has been changed and needs to be updated on the cluster.
+func setServiceNetworking(svc *corev1.Service, spec argoprojv1a1.ArgoCDServiceSpec) bool {
+	changed := false
+	if len(spec.IPFamilies) > 0 && !reflect.DeepEqual(svc.Spec.IPFamilies, spec.IPFamilies) {
+		svc.Spec.IPFamilies = spec.IPFamilies
+		changed = true
+	}
+	if spec.IPFamilyPolicy != nil && !reflect.DeepEqual(svc.Spec.IPFamilyPolicy, spec.IPFamilyPolicy) {
+		svc.Spec.IPFamilyPolicy = spec.IPFamilyPolicy
+		changed = true
+	}
+	if spec.InternalTrafficPolicy != nil && !reflect.DeepEqual(svc.Spec.InternalTrafficPolicy, spec.InternalTrafficPolicy) {
+		svc.Spec.InternalTrafficPolicy = spec.InternalTrafficPolicy
+		changed = true
+	}
+	return changed
+}
+
+// deleteServiceIfIPFamilyChanged will delete the given Service if its primary IP family differs from the one of the
+// service spec, as the primary IP family of a Service cannot be changed. Returns true if the Service was deleted.
+func (r *ReconcileArgoCD) deleteServiceIfIPFamilyChanged(svc *corev1.Service, spec argoprojv1a1.ArgoCDServiceSpec) (bool, error) {
+	if len(spec.IPFamilies) == 0 || len(svc.Spec.IPFamilies) == 0 || svc.Spec.IPFamilies[0] == spec.IPFamilies[0] {
+		return false, nil
+	}
+
+	log.Info(fmt.Sprintf("primary IP family of service %s changed, recreating it", svc.Name))
+	if err := r.Client.Delete(context.TODO(), svc); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// updateServiceNetworking will bring the IP family and traffic policy options of the existing Service in line with
+// the given service spec. A Service whose primary IP family changed is deleted and gets recreated on the next
+// reconciliation.
+func (r *ReconcileArgoCD) updateServiceNetworking(svc *corev1.Service, spec argoprojv1a1.ArgoCDServiceSpec, changed bool) error {
+	deleted, err := r.deleteServiceIfIPFamilyChanged(svc, spec)
+	if err != nil || deleted {
+		return err
+	}
+	if setServiceNetworking(svc, spec) {
+		changed = true
+	}
+	if changed {
+		return r.Client.Update(context.TODO(), svc)
+	}
+	return nil
+}
+
 // reconcileGrafanaService will ensure that the Service for Grafana is present.
 func (r *ReconcileArgoCD) reconcileGrafanaService(cr *argoprojv1a1.ArgoCD) error {
 	svc := newServiceWithSuffix("grafana", "grafana", cr)
@@ -73,13 +126,15 @@ func (r *ReconcileArgoCD) reconcileGrafanaService(cr *argoprojv1a1.ArgoCD) error
 			// Service exists but enabled flag has been set to false, delete the Service
 			return r.Client.Delete(context.TODO(), svc)
 		}
-		return nil // Service found, do nothing
+		return r.updateServiceNetworking(svc, cr.Spec.Grafana.Service, false)
 	}
 
 	if !cr.Spec.Grafana.Enabled {
 		return nil // Grafana not enabled, do nothing.
 	}
 
+	setServiceNetworking(svc, cr.Spec.Grafana.Service)
+
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix("grafana", cr),
 	}
@@ -103,12 +158,25 @@ func (r *ReconcileArgoCD) reconcileGrafanaService(cr *argoprojv1a1.ArgoCD) error
 
 // reconcileMetricsService will ensure that the Service for the Argo CD application controller metrics is present.
 func (r *ReconcileArgoCD) reconcileMetricsService(cr *argoprojv1a1.ArgoCD) error {
+	port := getArgoControllerMetricsPort(cr)
 	svc := newServiceWithSuffix("metrics", "metrics", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-		// Service found, do nothing
-		return nil
+		if len(svc.Spec.Ports) == 1 && svc.Spec.Ports[0].Port == port && svc.Spec.Ports[0].TargetPort.IntVal == port {
+			return r.updateServiceNetworking(svc, cr.Spec.Controller.Service, false)
+		}
+		svc.Spec.Ports = []corev1.ServicePort{
+			{
+				Name:       "metrics",
+				Port:       port,
+				Protocol:   corev1.ProtocolTCP,
+				TargetPort: intstr.FromInt(int(port)),
+			},
+		}
+		return r.updateServiceNetworking(svc, cr.Spec.Controller.Service, true)
 	}
 
+	setServiceNetworking(svc, cr.Spec.Controller.Service)
+
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix("application-controller", cr),
 	}
@@ -116,9 +184,9 @@ func (r *ReconcileArgoCD) reconcileMetricsService(cr *argoprojv1a1.ArgoCD) error
 	svc.Spec.Ports = []corev1.ServicePort{
 		{
 			Name:       "metrics",
-			Port:       8082,
+			Port:       port,
 			Protocol:   corev1.ProtocolTCP,
-			TargetPort: intstr.FromInt(8082),
+			TargetPort: intstr.FromInt(int(port)),
 		},
 	}
 
@@ -130,19 +198,31 @@ func (r *ReconcileArgoCD) reconcileMetricsService(cr *argoprojv1a1.ArgoCD) error
 
 // reconcileRedisHAAnnounceServices will ensure that the announce Services are present for Redis when running in HA mode.
 func (r *ReconcileArgoCD) reconcileRedisHAAnnounceServices(cr *argoprojv1a1.ArgoCD) error {
-	for i := int32(0); i < common.ArgoCDDefaultRedisHAReplicas; i++ {
+	replicas := *getRedisHAReplicas(cr)
+	for i := int32(0); ; i++ {
 		svc := newServiceWithSuffix(fmt.Sprintf("redis-ha-announce-%d", i), "redis", cr)
-		if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-			if !cr.Spec.HA.Enabled {
-				return r.Client.Delete(context.TODO(), svc)
+		found := argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc)
+		if i >= replicas || !cr.Spec.HA.Enabled {
+			if !found {
+				// No further announce Services to clean up
+				return nil
+			}
+			// Service exists but HA has been disabled or scaled down, delete the Service
+			if err := r.Client.Delete(context.TODO(), svc); err != nil {
+				return err
 			}
-			return nil // Service found, do nothing
+			continue
 		}
 
-		if !cr.Spec.HA.Enabled {
-			return nil //return as Ha is not enabled do nothing
+		if found {
+			if err := r.updateServiceNetworking(svc, cr.Spec.Redis.Service, false); err != nil {
+				return err
+			}
+			continue
 		}
 
+		setServiceNetworking(svc, cr.Spec.Redis.Service)
+
 		svc.ObjectMeta.Annotations = map[string]string{
 			common.ArgoCDKeyTolerateUnreadyEndpounts: "true",
 		}
@@ -176,7 +256,6 @@ func (r *ReconcileArgoCD) reconcileRedisHAAnnounceServices(cr *argoprojv1a1.Argo
 			return err
 		}
 	}
-	return nil
 }
 
 // reconcileRedisHAMasterService will ensure that the "master" Service is present for Redis when running in HA mode.
@@ -186,13 +265,15 @@ func (r *ReconcileArgoCD) reconcileRedisHAMasterService(cr *argoprojv1a1.ArgoCD)
 		if !cr.Spec.HA.Enabled {
 			return r.Client.Delete(context.TODO(), svc)
 		}
-		return nil // Service found, do nothing
+		return r.updateServiceNetworking(svc, cr.Spec.Redis.Service, false)
 	}
 
 	if !cr.Spec.HA.Enabled {
 		return nil //return as Ha is not enabled do nothing
 	}
 
+	setServiceNetworking(svc, cr.Spec.Redis.Service)
+
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix("redis-ha", cr),
 	}
@@ -226,10 +307,8 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyService(cr *argoprojv1a1.ArgoCD)
 			return r.Client.Delete(context.TODO(), svc)
 		}
 
-		if ensureAutoTLSAnnotation(svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS()) {
-			return r.Client.Update(context.TODO(), svc)
-		}
-		return nil // Service found, do nothing
+		changed := ensureAutoTLSAnnotation(svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS())
+		return r.updateServiceNetworking(svc, cr.Spec.Redis.Service, changed)
 	}
 
 	if !cr.Spec.HA.Enabled {
@@ -237,6 +316,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyService(cr *argoprojv1a1.ArgoCD)
 	}
 
 	ensureAutoTLSAnnotation(svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS())
+	setServiceNetworking(svc, cr.Spec.Redis.Service)
 
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix("redis-ha-haproxy", cr),
@@ -282,17 +362,18 @@ func (r *ReconcileArgoCD) reconcileRedisService(cr *argoprojv1a1.ArgoCD) error {
 		if ensureAutoTLSAnnotation(svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS()) {
 			return r.Client.Update(context.TODO(), svc)
 		}
-		if cr.Spec.HA.Enabled {
+		if cr.Spec.HA.Enabled || cr.Spec.Redis.IsRemote() {
 			return r.Client.Delete(context.TODO(), svc)
 		}
-		return nil // Service found, do nothing
+		return r.updateServiceNetworking(svc, cr.Spec.Redis.Service, false)
 	}
 
-	if cr.Spec.HA.Enabled {
-		return nil //return as Ha is enabled do nothing
+	if cr.Spec.HA.Enabled || cr.Spec.Redis.IsRemote() {
+		return nil //return as Ha is enabled or remote Redis is used do nothing
 	}
 
 	ensureAutoTLSAnnotation(svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS())
+	setServiceNetworking(svc, cr.Spec.Redis.Service)
 
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix("redis", cr),
@@ -358,13 +439,12 @@ func (r *ReconcileArgoCD) reconcileRepoService(cr *argoprojv1a1.ArgoCD) error {
 	svc := newServiceWithSuffix("repo-server", "repo-server", cr)
 
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-		if ensureAutoTLSAnnotation(svc, common.ArgoCDRepoServerTLSSecretName, cr.Spec.Repo.WantsAutoTLS()) {
-			return r.Client.Update(context.TODO(), svc)
-		}
-		return nil // Service found, do nothing
+		changed := ensureAutoTLSAnnotation(svc, common.ArgoCDRepoServerTLSSecretName, cr.Spec.Repo.WantsAutoTLS())
+		return r.updateServiceNetworking(svc, cr.Spec.Repo.Service, changed)
 	}
 
 	ensureAutoTLSAnnotation(svc, common.ArgoCDRepoServerTLSSecretName, cr.Spec.Repo.WantsAutoTLS())
+	setServiceNetworking(svc, cr.Spec.Repo.Service)
 
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix("repo-server", cr),
@@ -394,9 +474,11 @@ func (r *ReconcileArgoCD) reconcileRepoService(cr *argoprojv1a1.ArgoCD) error {
 func (r *ReconcileArgoCD) reconcileServerMetricsService(cr *argoprojv1a1.ArgoCD) error {
 	svc := newServiceWithSuffix("server-metrics", "server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-		return nil // Service found, do nothing
+		return r.updateServiceNetworking(svc, cr.Spec.Server.Service.ArgoCDServiceSpec, false)
 	}
 
+	setServiceNetworking(svc, cr.Spec.Server.Service.ArgoCDServiceSpec)
+
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix("server", cr),
 	}
@@ -420,13 +502,12 @@ func (r *ReconcileArgoCD) reconcileServerMetricsService(cr *argoprojv1a1.ArgoCD)
 func (r *ReconcileArgoCD) reconcileServerService(cr *argoprojv1a1.ArgoCD) error {
 	svc := newServiceWithSuffix("server", "server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-		if ensureAutoTLSAnnotation(svc, common.ArgoCDServerTLSSecretName, cr.Spec.Server.WantsAutoTLS()) {
-			return r.Client.Update(context.TODO(), svc)
-		}
-		return nil // Service found, do nothing
+		changed := ensureAutoTLSAnnotation(svc, common.ArgoCDServerTLSSecretName, cr.Spec.Server.WantsAutoTLS())
+		return r.updateServiceNetworking(svc, cr.Spec.Server.Service.ArgoCDServiceSpec, changed)
 	}
 
 	ensureAutoTLSAnnotation(svc, common.ArgoCDServerTLSSecretName, cr.Spec.Server.WantsAutoTLS())
+	setServiceNetworking(svc, cr.Spec.Server.Service.ArgoCDServiceSpec)
 
 	svc.Spec.Ports = []corev1.ServicePort{
 		{
@@ -453,47 +534,3 @@ func (r *ReconcileArgoCD) reconcileServerService(cr *argoprojv1a1.ArgoCD) error
 	}
 	return r.Client.Create(context.TODO(), svc)
 }
-
-// reconcileServices will ensure that all Services are present for the given ArgoCD.
-func (r *ReconcileArgoCD) reconcileServices(cr *argoprojv1a1.ArgoCD) error {
-
-	if err := r.reconcileDexService(cr); err != nil {
-		log.Error(err, "error reconciling dex service")
-	}
-
-	err := r.reconcileGrafanaService(cr)
-	if err != nil {
-		return err
-	}
-
-	err = r.reconcileMetricsService(cr)
-	if err != nil {
-		return err
-	}
-
-	err = r.reconcileRedisHAServices(cr)
-	if err != nil {
-		return err
-	}
-
-	err = r.reconcileRedisService(cr)
-	if err != nil {
-		return err
-	}
-
-	err = r.reconcileRepoService(cr)
-	if err != nil {
-		return err
-	}
-
-	err = r.reconcileServerMetricsService(cr)
-	if err != nil {
-		return err
-	}
-
-	err = r.reconcileServerService(cr)
-	if err != nil {
-		return err
-	}
-	return nil
-}
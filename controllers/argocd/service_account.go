@@ -52,6 +52,19 @@ func newServiceAccountWithName(name string, cr *argoprojv1a1.ArgoCD) *corev1.Ser
 	return sa
 }
 
+// getServiceAccountAnnotations returns the user provided annotations for the ServiceAccount of the given component.
+func getServiceAccountAnnotations(name string, cr *argoprojv1a1.ArgoCD) map[string]string {
+	switch name {
+	case common.ArgoCDApplicationControllerComponent:
+		return cr.Spec.Controller.ServiceAccountAnnotations
+	case common.ArgoCDServerComponent:
+		return cr.Spec.Server.ServiceAccountAnnotations
+	case common.ArgoCDRepoServerComponent:
+		return cr.Spec.Repo.ServiceAccountAnnotations
+	}
+	return nil
+}
+
 func getServiceAccountName(crName, name string) string {
 	return fmt.Sprintf("%s-%s", crName, name)
 }
@@ -74,9 +87,45 @@ func (r *ReconcileArgoCD) reconcileServiceAccounts(cr *argoprojv1a1.ArgoCD) erro
 		}
 	}
 
+	return r.reconcileRepoServerServiceAccount(cr)
+}
+
+// reconcileRepoServerServiceAccount will ensure the operator managed ServiceAccount for the repo server is present
+// when annotations are requested for it, and removed otherwise. Without annotations or a user provided ServiceAccount,
+// the repo server keeps running with the default ServiceAccount of the namespace.
+func (r *ReconcileArgoCD) reconcileRepoServerServiceAccount(cr *argoprojv1a1.ArgoCD) error {
+	if useRepoServerServiceAccount(cr) {
+		_, err := r.reconcileServiceAccount(common.ArgoCDRepoServerComponent, cr)
+		return err
+	}
+
+	sa := newServiceAccountWithName(common.ArgoCDRepoServerComponent, cr)
+	if sa.Name == cr.Spec.Repo.ServiceAccount {
+		return nil
+	}
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, sa.Name, sa) && metav1.IsControlledBy(sa, cr) {
+		log.Info(fmt.Sprintf("deleting serviceaccount %s, it is no longer used by the repo server", sa.Name))
+		return r.Client.Delete(context.TODO(), sa)
+	}
 	return nil
 }
 
+// useRepoServerServiceAccount returns true if the operator should manage a ServiceAccount for the repo server, which
+// is the case when annotations are requested for it and no ServiceAccount is provided by the user.
+func useRepoServerServiceAccount(cr *argoprojv1a1.ArgoCD) bool {
+	return cr.Spec.Repo.ServiceAccount == "" && len(cr.Spec.Repo.ServiceAccountAnnotations) > 0
+}
+
+// getRepoServerServiceAccountName returns the name of the ServiceAccount used by the repo server. A user provided
+// ServiceAccount takes precedence over the one managed by the operator, and an empty name selects the default
+// ServiceAccount of the namespace.
+func getRepoServerServiceAccountName(cr *argoprojv1a1.ArgoCD) string {
+	if useRepoServerServiceAccount(cr) {
+		return getServiceAccountName(cr.Name, common.ArgoCDRepoServerComponent)
+	}
+	return cr.Spec.Repo.ServiceAccount
+}
+
 func (r *ReconcileArgoCD) reconcileServiceAccountClusterPermissions(name string, rules []v1.PolicyRule, cr *argoprojv1a1.ArgoCD) error {
 	var role *v1.ClusterRole
 	var err error
@@ -117,9 +166,31 @@ func (r *ReconcileArgoCD) reconcileServiceAccount(name string, cr *argoprojv1a1.
 			log.Info("deleting the existing Dex service account because dex uninstallation requested")
 			return sa, r.Client.Delete(context.TODO(), sa)
 		}
+
+		// Annotations added by other parties, e.g. the OpenShift token controller, are left untouched.
+		changed := false
+		for key, value := range getServiceAccountAnnotations(name, cr) {
+			if sa.Annotations[key] != value {
+				if sa.Annotations == nil {
+					sa.Annotations = map[string]string{}
+				}
+				sa.Annotations[key] = value
+				changed = true
+			}
+		}
+		if changed {
+			return sa, r.Client.Update(context.TODO(), sa)
+		}
 		return sa, nil
 	}
 
+	for key, value := range getServiceAccountAnnotations(name, cr) {
+		if sa.Annotations == nil {
+			sa.Annotations = map[string]string{}
+		}
+		sa.Annotations[key] = value
+	}
+
 	if err := controllerutil.SetControllerReference(cr, sa, r.Scheme); err != nil {
 		return nil, err
 	}
@@ -23,8 +23,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/argoproj-labs/argocd-operator/common"
 )
 
 func TestReconcileArgoCD_reconcileServiceAccountPermissions(t *testing.T) {
@@ -149,3 +153,77 @@ func testRules() []v1.PolicyRule {
 		},
 	}
 }
+
+func TestReconcileArgoCD_reconcileRepoServerServiceAccount(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	expectedName := fmt.Sprintf("%s-%s", a.Name, common.ArgoCDRepoServerComponent)
+
+	// the repo server uses the default ServiceAccount of the namespace by default
+	assert.NoError(t, r.reconcileRepoServerServiceAccount(a))
+	sa := &corev1.ServiceAccount{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: a.Namespace}, sa)
+	assert.True(t, errors.IsNotFound(err))
+	assert.Equal(t, "", getRepoServerServiceAccountName(a))
+
+	// the operator creates a ServiceAccount for the repo server when annotations are requested
+	a.Spec.Repo.ServiceAccountAnnotations = map[string]string{
+		"eks.amazonaws.com/role-arn": "arn:aws:iam::111122223333:role/argocd-repo-server",
+	}
+	assert.NoError(t, r.reconcileRepoServerServiceAccount(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: a.Namespace}, sa))
+	assert.True(t, metav1.IsControlledBy(sa, a))
+	assert.Equal(t, expectedName, getRepoServerServiceAccountName(a))
+
+	// a user provided ServiceAccount replaces the operator managed one
+	a.Spec.Repo.ServiceAccount = "repo-irsa"
+	assert.NoError(t, r.reconcileRepoServerServiceAccount(a))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: a.Namespace}, sa)
+	assert.True(t, errors.IsNotFound(err))
+	assert.Equal(t, "repo-irsa", getRepoServerServiceAccountName(a))
+
+	// a ServiceAccount not owned by the ArgoCD is left untouched
+	assert.NoError(t, r.Client.Create(context.TODO(), newServiceAccountWithName(common.ArgoCDRepoServerComponent, a)))
+	assert.NoError(t, r.reconcileRepoServerServiceAccount(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: a.Namespace}, sa))
+}
+
+func TestReconcileArgoCD_reconcileServiceAccount_annotations(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.Server.ServiceAccountAnnotations = map[string]string{
+		"eks.amazonaws.com/role-arn": "arn:aws:iam::111122223333:role/argocd-server",
+	}
+	r := makeTestReconciler(t, a)
+
+	expectedName := fmt.Sprintf("%s-%s", a.Name, common.ArgoCDServerComponent)
+	_, err := r.reconcileServiceAccount(common.ArgoCDServerComponent, a)
+	assert.NoError(t, err)
+
+	sa := &corev1.ServiceAccount{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: a.Namespace}, sa))
+	assert.Equal(t, "arn:aws:iam::111122223333:role/argocd-server", sa.Annotations["eks.amazonaws.com/role-arn"])
+
+	// annotations set by others are kept, while the requested annotations are restored
+	sa.Annotations = map[string]string{
+		"eks.amazonaws.com/role-arn":     "changed",
+		"openshift.io/internal-registry": "foo",
+	}
+	assert.NoError(t, r.Client.Update(context.TODO(), sa))
+
+	_, err = r.reconcileServiceAccount(common.ArgoCDServerComponent, a)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: a.Namespace}, sa))
+	assert.Equal(t, map[string]string{
+		"eks.amazonaws.com/role-arn":     "arn:aws:iam::111122223333:role/argocd-server",
+		"openshift.io/internal-registry": "foo",
+	}, sa.Annotations)
+
+	// other components are not annotated
+	_, err = r.reconcileServiceAccount(common.ArgoCDApplicationControllerComponent, a)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-%s", a.Name, common.ArgoCDApplicationControllerComponent), Namespace: a.Namespace}, sa))
+	assert.Empty(t, sa.Annotations)
+}
@@ -1,10 +1,16 @@
 package argocd
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
 	"github.com/argoproj-labs/argocd-operator/common"
 )
 
@@ -50,3 +56,84 @@ func TestEnsureAutoTLSAnnotation(t *testing.T) {
 		assert.Equal(t, ok, false)
 	})
 }
+
+func TestReconcileArgoCD_reconcileRedisHAAnnounceServices(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	replicas := int32(5)
+	a.Spec.HA.Replicas = &replicas
+	assert.NoError(t, r.reconcileRedisHAAnnounceServices(a))
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("argocd-redis-ha-announce-%d", i), Namespace: a.Namespace}, &corev1.Service{}))
+	}
+
+	// Scaling down removes the announce Services of the removed replicas
+	replicas = int32(3)
+	assert.NoError(t, r.reconcileRedisHAAnnounceServices(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-announce-2", Namespace: a.Namespace}, &corev1.Service{}))
+	for i := 3; i < 5; i++ {
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("argocd-redis-ha-announce-%d", i), Namespace: a.Namespace}, &corev1.Service{})
+		assert.True(t, apierrors.IsNotFound(err))
+	}
+}
+
+func TestReconcileArgoCD_reconcileRepoService_networking(t *testing.T) {
+	dualStack := corev1.IPFamilyPolicyRequireDualStack
+	local := corev1.ServiceInternalTrafficPolicyLocal
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Repo.Service = argoprojv1alpha1.ArgoCDServiceSpec{
+			IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+			IPFamilyPolicy: &dualStack,
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileRepoService(a))
+
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}, svc))
+	assert.Equal(t, []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}, svc.Spec.IPFamilies)
+	assert.Equal(t, &dualStack, svc.Spec.IPFamilyPolicy)
+	assert.Nil(t, svc.Spec.InternalTrafficPolicy)
+
+	// the internal traffic policy is updated in place
+	a.Spec.Repo.Service.InternalTrafficPolicy = &local
+	assert.NoError(t, r.reconcileRepoService(a))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}, svc))
+	assert.Equal(t, &local, svc.Spec.InternalTrafficPolicy)
+
+	// changing the primary IP family recreates the Service
+	a.Spec.Repo.Service.IPFamilies = []corev1.IPFamily{corev1.IPv4Protocol}
+	assert.NoError(t, r.reconcileRepoService(a))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}, svc))
+
+	assert.NoError(t, r.reconcileRepoService(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}, svc))
+	assert.Equal(t, []corev1.IPFamily{corev1.IPv4Protocol}, svc.Spec.IPFamilies)
+}
+
+func TestReconcileArgoCD_reconcileServerService_networking(t *testing.T) {
+	singleStack := corev1.IPFamilyPolicySingleStack
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileServerService(a))
+
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, svc))
+	assert.Empty(t, svc.Spec.IPFamilies)
+	assert.Nil(t, svc.Spec.IPFamilyPolicy)
+
+	a.Spec.Server.Service.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol}
+	a.Spec.Server.Service.IPFamilyPolicy = &singleStack
+	assert.NoError(t, r.reconcileServerService(a))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, svc))
+	assert.Equal(t, []corev1.IPFamily{corev1.IPv6Protocol}, svc.Spec.IPFamilies)
+	assert.Equal(t, &singleStack, svc.Spec.IPFamilyPolicy)
+	assert.Equal(t, corev1.ServiceTypeClusterIP, svc.Spec.Type)
+}
@@ -0,0 +1,164 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// isClusterShardAssignmentEnabled returns true if the operator assigns the cluster secrets to the Application
+// controller shards for the given ArgoCD.
+func isClusterShardAssignmentEnabled(cr *argoprojv1a1.ArgoCD) bool {
+	return cr.Spec.Controller.Sharding.ClusterAssignment != nil &&
+		(cr.Spec.Controller.Sharding.Enabled || isControllerDynamicScalingEnabled(cr))
+}
+
+// getClusterShardGroup returns the key of the group of clusters the given cluster secret is assigned to a shard with.
+// The returned bool is false if the cluster is assigned on its own.
+func getClusterShardGroup(secret *corev1.Secret, cr *argoprojv1a1.ArgoCD) (string, bool) {
+	assignment := cr.Spec.Controller.Sharding.ClusterAssignment
+	if assignment.Strategy != argoprojv1a1.ClusterShardAssignmentLabel || assignment.LabelKey == "" {
+		return "", false
+	}
+	value, ok := secret.Labels[assignment.LabelKey]
+	return value, ok
+}
+
+// getClusterShard returns the shard the given cluster secret is assigned to. The returned bool is false if the secret
+// holds no valid shard below the given number of replicas.
+func getClusterShard(secret *corev1.Secret, replicas int32) (int32, bool) {
+	value, ok := secret.Data[common.ArgoCDKeyClusterShard]
+	if !ok {
+		return 0, false
+	}
+	shard, err := strconv.ParseInt(string(value), 10, 32)
+	if err != nil || shard < 0 || int32(shard) >= replicas {
+		return 0, false
+	}
+	return int32(shard), true
+}
+
+// isClusterShardAssignedByOperator returns true if the shard of the given cluster secret was assigned by the operator.
+func isClusterShardAssignedByOperator(secret *corev1.Secret) bool {
+	return secret.Annotations[common.AnnotationShardAssigned] == "true"
+}
+
+// reconcileClusterShardAssignment will ensure that the cluster secrets of the given ArgoCD are assigned to the
+// Application controller shards using the configured strategy. Clusters keep the shard they are assigned to as long
+// as it exists, so that scaling the controller only moves the clusters of removed shards. Shards set by the user are
+// never changed. When the assignment is disabled, the shards assigned by the operator are removed again.
+func (r *ReconcileArgoCD) reconcileClusterShardAssignment(cr *argoprojv1a1.ArgoCD) error {
+	clusterSecrets, err := r.getClusterSecrets(cr)
+	if err != nil {
+		return err
+	}
+	secrets := clusterSecrets.Items
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+
+	if !isClusterShardAssignmentEnabled(cr) {
+		for i := range secrets {
+			secret := &secrets[i]
+			if !isClusterShardAssignedByOperator(secret) {
+				continue
+			}
+			delete(secret.Data, common.ArgoCDKeyClusterShard)
+			delete(secret.Annotations, common.AnnotationShardAssigned)
+			log.Info(fmt.Sprintf("removing shard assignment from cluster secret %s", secret.Name))
+			if err := r.Client.Update(context.TODO(), secret); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	replicas := r.getApplicationControllerReplicaCount(cr)
+	if replicas < 1 {
+		return nil
+	}
+	clusters := make([]int, replicas)
+	groupShards := make(map[string]int32)
+
+	// Keep the existing assignments, and collect the clusters that need to be (re)assigned.
+	var unassigned []*corev1.Secret
+	for i := range secrets {
+		secret := &secrets[i]
+		shard, valid := getClusterShard(secret, replicas)
+		if !isClusterShardAssignedByOperator(secret) {
+			if _, ok := secret.Data[common.ArgoCDKeyClusterShard]; ok {
+				// The shard was set by the user.
+				if valid {
+					clusters[shard]++
+				}
+				continue
+			}
+			unassigned = append(unassigned, secret)
+			continue
+		}
+
+		group, grouped := getClusterShardGroup(secret, cr)
+		if groupShard, ok := groupShards[group]; grouped && ok && groupShard != shard {
+			valid = false
+		}
+		if !valid {
+			unassigned = append(unassigned, secret)
+			continue
+		}
+		if grouped {
+			groupShards[group] = shard
+		}
+		clusters[shard]++
+	}
+
+	for _, secret := range unassigned {
+		group, grouped := getClusterShardGroup(secret, cr)
+		shard, ok := groupShards[group]
+		if !grouped || !ok {
+			shard = 0
+			for i := range clusters {
+				if clusters[i] < clusters[shard] {
+					shard = int32(i)
+				}
+			}
+			if grouped {
+				groupShards[group] = shard
+			}
+		}
+		clusters[shard]++
+
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		if secret.Annotations == nil {
+			secret.Annotations = make(map[string]string)
+		}
+		secret.Data[common.ArgoCDKeyClusterShard] = []byte(fmt.Sprint(shard))
+		secret.Annotations[common.AnnotationShardAssigned] = "true"
+		log.Info(fmt.Sprintf("assigning cluster secret %s to shard %d", secret.Name, shard))
+		if err := r.Client.Update(context.TODO(), secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
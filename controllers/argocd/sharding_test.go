@@ -0,0 +1,123 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestShardClusterSecret(name string, labels map[string]string, data map[string][]byte) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{common.ArgoCDSecretTypeLabel: "cluster"},
+		},
+		Data: map[string][]byte{"server": []byte("https://" + name)},
+	}
+	for k, v := range labels {
+		secret.Labels[k] = v
+	}
+	for k, v := range data {
+		secret.Data[k] = v
+	}
+	return secret
+}
+
+func getTestClusterShards(t *testing.T, c client.Client, names ...string) []string {
+	t.Helper()
+	var shards []string
+	for _, name := range names {
+		secret := &corev1.Secret{}
+		assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, secret))
+		shards = append(shards, string(secret.Data[common.ArgoCDKeyClusterShard]))
+	}
+	return shards
+}
+
+func TestReconcileArgoCD_reconcileClusterShardAssignment_roundRobin(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Controller.Sharding.Enabled = true
+		a.Spec.Controller.Sharding.Replicas = 3
+		a.Spec.Controller.Sharding.ClusterAssignment = &argoprojv1alpha1.ArgoCDClusterShardAssignmentSpec{}
+	})
+	var objs []runtime.Object
+	var names []string
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("cluster-%d", i)
+		names = append(names, name)
+		objs = append(objs, makeTestShardClusterSecret(name, nil, nil))
+	}
+	// a shard set by the user is kept and counted
+	objs = append(objs, makeTestShardClusterSecret("pinned", nil, map[string][]byte{common.ArgoCDKeyClusterShard: []byte("0")}))
+	r := makeTestReconciler(t, objs...)
+
+	assert.NoError(t, r.reconcileClusterShardAssignment(a))
+	assert.Equal(t, []string{"1", "2", "0", "1", "2"}, getTestClusterShards(t, r.Client, names...))
+	assert.Equal(t, []string{"0"}, getTestClusterShards(t, r.Client, "pinned"))
+
+	// scaling up keeps the existing assignments
+	a.Spec.Controller.Sharding.Replicas = 4
+	assert.NoError(t, r.reconcileClusterShardAssignment(a))
+	assert.Equal(t, []string{"1", "2", "0", "1", "2"}, getTestClusterShards(t, r.Client, names...))
+
+	// scaling down only moves the clusters of the removed shards
+	a.Spec.Controller.Sharding.Replicas = 2
+	assert.NoError(t, r.reconcileClusterShardAssignment(a))
+	assert.Equal(t, []string{"1", "0", "0", "1", "1"}, getTestClusterShards(t, r.Client, names...))
+
+	// disabling the assignment removes the shards assigned by the operator
+	a.Spec.Controller.Sharding.ClusterAssignment = nil
+	assert.NoError(t, r.reconcileClusterShardAssignment(a))
+	assert.Equal(t, []string{"", "", "", "", ""}, getTestClusterShards(t, r.Client, names...))
+	assert.Equal(t, []string{"0"}, getTestClusterShards(t, r.Client, "pinned"))
+}
+
+func TestReconcileArgoCD_reconcileClusterShardAssignment_label(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Controller.Sharding.Enabled = true
+		a.Spec.Controller.Sharding.Replicas = 2
+		a.Spec.Controller.Sharding.ClusterAssignment = &argoprojv1alpha1.ArgoCDClusterShardAssignmentSpec{
+			Strategy: argoprojv1alpha1.ClusterShardAssignmentLabel,
+			LabelKey: "region",
+		}
+	})
+	r := makeTestReconciler(t,
+		makeTestShardClusterSecret("eu-1", map[string]string{"region": "eu"}, nil),
+		makeTestShardClusterSecret("eu-2", map[string]string{"region": "eu"}, nil),
+		makeTestShardClusterSecret("other", nil, nil),
+		makeTestShardClusterSecret("us-1", map[string]string{"region": "us"}, nil),
+	)
+
+	assert.NoError(t, r.reconcileClusterShardAssignment(a))
+	assert.Equal(t, []string{"0", "0", "1", "1"}, getTestClusterShards(t, r.Client, "eu-1", "eu-2", "other", "us-1"))
+
+	// a cluster joining a group is assigned to the shard of the group
+	assert.NoError(t, r.Client.Create(context.TODO(), makeTestShardClusterSecret("us-2", map[string]string{"region": "us"}, nil)))
+	assert.NoError(t, r.reconcileClusterShardAssignment(a))
+	assert.Equal(t, []string{"1"}, getTestClusterShards(t, r.Client, "us-2"))
+}
+
+func TestReconcileArgoCD_reconcileClusterShardAssignment_shardingDisabled(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Controller.Sharding.ClusterAssignment = &argoprojv1alpha1.ArgoCDClusterShardAssignmentSpec{}
+	})
+	r := makeTestReconciler(t, makeTestShardClusterSecret("cluster", nil, nil))
+
+	assert.NoError(t, r.reconcileClusterShardAssignment(a))
+	assert.Equal(t, []string{""}, getTestClusterShards(t, r.Client, "cluster"))
+}
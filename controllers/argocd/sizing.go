@@ -0,0 +1,128 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const (
+	sizingProfileSmall  = "small"
+	sizingProfileMedium = "medium"
+	sizingProfileLarge  = "large"
+
+	// sizingApplicationSetComponent, sizingRedisHAProxyComponent and sizingKeycloakComponent are the names of the
+	// components without a component constant of their own.
+	sizingApplicationSetComponent = "argocd-" + common.ApplicationSetServiceNameSuffix
+	sizingRedisHAProxyComponent   = common.ArgoCDRedisHAComponent + "-haproxy"
+	sizingKeycloakComponent       = defaultKeycloakIdentifier
+)
+
+// componentSize is the CPU and memory requested by, and the limits of, the container of a component.
+type componentSize struct {
+	requestCPU, requestMemory, limitCPU, limitMemory string
+}
+
+// sizingProfiles are the resources of the containers of the components in each sizing profile, by component. The
+// Redis sentinels use the resources of Redis.
+var sizingProfiles = map[string]map[string]componentSize{
+	sizingProfileSmall: {
+		common.ArgoCDApplicationControllerComponent:   {"250m", "512Mi", "1", "1Gi"},
+		common.ArgoCDServerComponent:                  {"100m", "128Mi", "500m", "256Mi"},
+		common.ArgoCDRepoServerComponent:              {"100m", "256Mi", "1", "512Mi"},
+		common.ArgoCDRedisComponent:                   {"100m", "128Mi", "500m", "256Mi"},
+		sizingRedisHAProxyComponent:                   {"50m", "64Mi", "250m", "128Mi"},
+		common.ArgoCDDexServerComponent:               {"50m", "64Mi", "250m", "128Mi"},
+		sizingApplicationSetComponent:                 {"50m", "128Mi", "250m", "256Mi"},
+		common.ArgoCDNotificationsControllerComponent: {"50m", "64Mi", "250m", "128Mi"},
+		common.ArgoCDOperatorGrafanaComponent:         {"100m", "128Mi", "500m", "256Mi"},
+		common.ArgoCDAgentPrincipalComponent:          {"100m", "128Mi", "500m", "256Mi"},
+		common.ArgoCDAgentAgentComponent:              {"50m", "64Mi", "250m", "128Mi"},
+		sizingKeycloakComponent:                       {"250m", "512Mi", "500m", "1Gi"},
+	},
+	sizingProfileMedium: {
+		common.ArgoCDApplicationControllerComponent:   {"500m", "1Gi", "2", "2Gi"},
+		common.ArgoCDServerComponent:                  {"250m", "256Mi", "1", "512Mi"},
+		common.ArgoCDRepoServerComponent:              {"250m", "512Mi", "2", "1Gi"},
+		common.ArgoCDRedisComponent:                   {"250m", "256Mi", "1", "512Mi"},
+		sizingRedisHAProxyComponent:                   {"100m", "128Mi", "500m", "256Mi"},
+		common.ArgoCDDexServerComponent:               {"100m", "128Mi", "500m", "256Mi"},
+		sizingApplicationSetComponent:                 {"100m", "256Mi", "500m", "512Mi"},
+		common.ArgoCDNotificationsControllerComponent: {"100m", "128Mi", "500m", "256Mi"},
+		common.ArgoCDOperatorGrafanaComponent:         {"250m", "256Mi", "1", "512Mi"},
+		common.ArgoCDAgentPrincipalComponent:          {"250m", "256Mi", "1", "512Mi"},
+		common.ArgoCDAgentAgentComponent:              {"100m", "128Mi", "500m", "256Mi"},
+		sizingKeycloakComponent:                       {"500m", "1Gi", "1", "2Gi"},
+	},
+	sizingProfileLarge: {
+		common.ArgoCDApplicationControllerComponent:   {"1", "2Gi", "4", "4Gi"},
+		common.ArgoCDServerComponent:                  {"500m", "512Mi", "2", "1Gi"},
+		common.ArgoCDRepoServerComponent:              {"500m", "1Gi", "4", "2Gi"},
+		common.ArgoCDRedisComponent:                   {"500m", "512Mi", "2", "1Gi"},
+		sizingRedisHAProxyComponent:                   {"250m", "256Mi", "1", "512Mi"},
+		common.ArgoCDDexServerComponent:               {"250m", "256Mi", "1", "512Mi"},
+		sizingApplicationSetComponent:                 {"250m", "512Mi", "1", "1Gi"},
+		common.ArgoCDNotificationsControllerComponent: {"250m", "256Mi", "1", "512Mi"},
+		common.ArgoCDOperatorGrafanaComponent:         {"500m", "512Mi", "2", "1Gi"},
+		common.ArgoCDAgentPrincipalComponent:          {"500m", "512Mi", "2", "1Gi"},
+		common.ArgoCDAgentAgentComponent:              {"250m", "256Mi", "1", "512Mi"},
+		sizingKeycloakComponent:                       {"1", "2Gi", "2", "4Gi"},
+	},
+}
+
+// getSizingProfile returns the sizing profile of the operator, or an empty string if none is set.
+func getSizingProfile() string {
+	return strings.ToLower(strings.TrimSpace(os.Getenv(common.ArgoCDSizingProfileEnvName)))
+}
+
+// validateSizingProfile returns an error if the given sizing profile is set but unknown.
+func validateSizingProfile(profile string) error {
+	if _, ok := sizingProfiles[profile]; profile == "" || ok {
+		return nil
+	}
+	profiles := make([]string, 0, len(sizingProfiles))
+	for name := range sizingProfiles {
+		profiles = append(profiles, name)
+	}
+	sort.Strings(profiles)
+	return fmt.Errorf("invalid value %q of %s, must be one of %s", profile, common.ArgoCDSizingProfileEnvName, strings.Join(profiles, ", "))
+}
+
+// getDefaultResources returns the resources of the container of the given component in the sizing profile of the
+// operator, or no resources if no sizing profile is set.
+func getDefaultResources(component string) corev1.ResourceRequirements {
+	size, ok := sizingProfiles[getSizingProfile()][component]
+	if !ok {
+		return corev1.ResourceRequirements{}
+	}
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(size.requestCPU),
+			corev1.ResourceMemory: resource.MustParse(size.requestMemory),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(size.limitCPU),
+			corev1.ResourceMemory: resource.MustParse(size.limitMemory),
+		},
+	}
+}
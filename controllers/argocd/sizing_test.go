@@ -0,0 +1,75 @@
+package argocd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestValidateSizingProfile(t *testing.T) {
+	assert.NoError(t, validateSizingProfile(""))
+	for profile := range sizingProfiles {
+		assert.NoError(t, validateSizingProfile(profile))
+	}
+	assert.Error(t, validateSizingProfile("huge"))
+}
+
+func TestGetDefaultResources(t *testing.T) {
+	assert.Equal(t, corev1.ResourceRequirements{}, getDefaultResources(common.ArgoCDServerComponent))
+
+	t.Setenv(common.ArgoCDSizingProfileEnvName, "Small")
+	assert.Equal(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		},
+	}, getDefaultResources(common.ArgoCDServerComponent))
+
+	// every component of every profile has valid resources that are not exceeded by their requests
+	for profile, sizes := range sizingProfiles {
+		t.Setenv(common.ArgoCDSizingProfileEnvName, profile)
+		for component := range sizes {
+			resources := getDefaultResources(component)
+			for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+				request, limit := resources.Requests[name], resources.Limits[name]
+				assert.True(t, request.Cmp(limit) <= 0, "%s %s of %s", profile, name, component)
+			}
+		}
+	}
+}
+
+func TestGetComponentResources_sizingProfile(t *testing.T) {
+	t.Setenv(common.ArgoCDSizingProfileEnvName, sizingProfileMedium)
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Autoscale.Enabled = true
+		a.Spec.Repo.Resources = &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("2"),
+			},
+		}
+	})
+
+	// the profile applies to the components without resources, including an autoscaled server
+	assert.Equal(t, getDefaultResources(common.ArgoCDServerComponent), getArgoServerResources(a))
+	assert.Equal(t, getDefaultResources(common.ArgoCDApplicationControllerComponent), getArgoApplicationControllerResources(a))
+	assert.Equal(t, getDefaultResources(common.ArgoCDRedisComponent), getRedisSentinelResources(a))
+
+	// the resources of the ArgoCD take precedence over the profile
+	assert.Equal(t, *a.Spec.Repo.Resources, getArgoRepoResources(a))
+
+	// the profile applies to the argocd-agent and Keycloak workloads, and replaces the defaults of Keycloak
+	assert.Equal(t, getDefaultResources(common.ArgoCDAgentPrincipalComponent), getAgentResources(common.ArgoCDAgentPrincipalComponent, nil))
+	assert.Equal(t, getDefaultResources(common.ArgoCDAgentAgentComponent), getAgentResources(common.ArgoCDAgentAgentComponent, nil))
+	k := makeTestArgoCDForKeycloak()
+	assert.Equal(t, getDefaultResources(sizingKeycloakComponent), getKeycloakResources(k))
+	assert.Equal(t, getDefaultResources(sizingKeycloakComponent), newKeycloakDeployment(k).Spec.Template.Spec.Containers[0].Resources)
+}
@@ -21,10 +21,13 @@ import (
 	"reflect"
 
 	template "github.com/openshift/api/template/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
 	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
@@ -36,10 +39,7 @@ const (
 	multipleSSOConfiguration string = "multiple SSO configuration: "
 )
 
-var (
-	templateAPIFound     = false
-	ssoConfigLegalStatus string
-)
+var templateAPIFound = false
 
 // IsTemplateAPIAvailable returns true if the template API is present.
 func IsTemplateAPIAvailable() bool {
@@ -56,6 +56,13 @@ func verifyTemplateAPI() error {
 	return nil
 }
 
+// createSSOValidationFailedEvent records a warning Event on the given ArgoCD for the given SSO validation error.
+func (r *ReconcileArgoCD) createSSOValidationFailedEvent(cr *argoprojv1a1.ArgoCD, err error) {
+	if err := argoutil.CreateEvent(r.Client, "Warning", "Invalid", err.Error(), "InvalidSSOConfiguration", cr.ObjectMeta, cr.TypeMeta); err != nil {
+		log.Error(err, "failed to create SSO validation event")
+	}
+}
+
 // The purpose of reconcileSSO is to try and catch as many illegal configuration edge cases at the highest level (that can lead to conflicts)
 // as possible, that may arise from the operator supporting multiple SSO providers in a backwards-compatible way.
 // The operator must support both `.spec.dex` and `.spec.sso.dex` for dex, and `.spec.sso` fields and `.spec.sso.keycloak`
@@ -63,14 +70,14 @@ func verifyTemplateAPI() error {
 // active provider, contradicting configuration etc, and throw the appropriate errors.
 func (r *ReconcileArgoCD) reconcileSSO(cr *argoprojv1a1.ArgoCD) error {
 
-	// reset ssoConfigLegalStatus at the beginning of each SSO reconciliation round
-	ssoConfigLegalStatus = ssoLegalUnknown
+	// reset r.ssoConfigLegalStatus at the beginning of each SSO reconciliation round
+	r.ssoConfigLegalStatus = ssoLegalUnknown
 
 	// Emit events warning users about deprecation notice for soon-to-be-removed fields in the CR if being used
 
 	if env := os.Getenv("DISABLE_DEX"); env != "" {
 		// Emit event for each instance providing users with deprecation notice for `DISABLE_DEX` if not emitted already
-		if currentInstanceEventEmissionStatus, ok := DeprecationEventEmissionTracker[cr.Namespace]; !ok || !currentInstanceEventEmissionStatus.DisableDexDeprecationWarningEmitted {
+		if currentInstanceEventEmissionStatus, ok := getDeprecationEventEmissionStatus(cr.Namespace); !ok || !currentInstanceEventEmissionStatus.DisableDexDeprecationWarningEmitted {
 			err := argoutil.CreateEvent(r.Client, "Warning", "Deprecated", "`DISABLE_DEX` is deprecated, and support will be removed in Argo CD Operator v0.6.0/OpenShift GitOps v1.9.0. Dex can be enabled/disabled through `.spec.sso`", "DeprecationNotice", cr.ObjectMeta, cr.TypeMeta)
 			if err != nil {
 				return err
@@ -81,7 +88,7 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoprojv1a1.ArgoCD) error {
 			} else {
 				currentInstanceEventEmissionStatus.DisableDexDeprecationWarningEmitted = true
 			}
-			DeprecationEventEmissionTracker[cr.Namespace] = currentInstanceEventEmissionStatus
+			setDeprecationEventEmissionStatus(cr.Namespace, currentInstanceEventEmissionStatus)
 		}
 
 	}
@@ -89,7 +96,7 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoprojv1a1.ArgoCD) error {
 	if cr.Spec.Dex != nil && !reflect.DeepEqual(cr.Spec.Dex, &v1alpha1.ArgoCDDexSpec{}) {
 
 		// Emit event for each instance providing users with deprecation notice for `.spec.dex` if not emitted already
-		if currentInstanceEventEmissionStatus, ok := DeprecationEventEmissionTracker[cr.Namespace]; !ok || !currentInstanceEventEmissionStatus.DexSpecDeprecationWarningEmitted {
+		if currentInstanceEventEmissionStatus, ok := getDeprecationEventEmissionStatus(cr.Namespace); !ok || !currentInstanceEventEmissionStatus.DexSpecDeprecationWarningEmitted {
 			err := argoutil.CreateEvent(r.Client, "Warning", "Deprecated", "`.spec.dex` is deprecated, and support will be removed in Argo CD Operator v0.6.0/OpenShift GitOps v1.9.0. Dex configuration can be managed through `.spec.sso.dex`", "DeprecationNotice", cr.ObjectMeta, cr.TypeMeta)
 			if err != nil {
 				return err
@@ -100,7 +107,7 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoprojv1a1.ArgoCD) error {
 			} else {
 				currentInstanceEventEmissionStatus.DexSpecDeprecationWarningEmitted = true
 			}
-			DeprecationEventEmissionTracker[cr.Namespace] = currentInstanceEventEmissionStatus
+			setDeprecationEventEmissionStatus(cr.Namespace, currentInstanceEventEmissionStatus)
 		}
 
 	}
@@ -109,7 +116,7 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoprojv1a1.ArgoCD) error {
 		cr.Spec.SSO.VerifyTLS != nil || cr.Spec.SSO.Resources != nil) {
 
 		// Emit event for each instance providing users with deprecation notice for `.spec.SSO` subfields if not emitted already
-		if currentInstanceEventEmissionStatus, ok := DeprecationEventEmissionTracker[cr.Namespace]; !ok || !currentInstanceEventEmissionStatus.SSOSpecDeprecationWarningEmitted {
+		if currentInstanceEventEmissionStatus, ok := getDeprecationEventEmissionStatus(cr.Namespace); !ok || !currentInstanceEventEmissionStatus.SSOSpecDeprecationWarningEmitted {
 			err := argoutil.CreateEvent(r.Client, "Warning", "Deprecated", "`.spec.SSO.Image`, `.spec.SSO.Version`, `.spec.SSO.Resources` and `.spec.SSO.VerifyTLS` are deprecated, and support will be removed in Argo CD Operator v0.6.0/OpenShift GitOps v1.9.0. Keycloak configuration can be managed through `.spec.sso.keycloak`", "DeprecationNotice", cr.ObjectMeta, cr.TypeMeta)
 			if err != nil {
 				return err
@@ -120,7 +127,7 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoprojv1a1.ArgoCD) error {
 			} else {
 				currentInstanceEventEmissionStatus.SSOSpecDeprecationWarningEmitted = true
 			}
-			DeprecationEventEmissionTracker[cr.Namespace] = currentInstanceEventEmissionStatus
+			setDeprecationEventEmissionStatus(cr.Namespace, currentInstanceEventEmissionStatus)
 		}
 	}
 
@@ -141,7 +148,8 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoprojv1a1.ArgoCD) error {
 			errMsg = "must suppy valid dex configuration when dex is enabled"
 			err = errors.New(illegalSSOConfiguration + errMsg)
 			log.Error(err, fmt.Sprintf("Illegal expression of SSO configuration detetected for Argo CD %s in namespace %s. %s", cr.Name, cr.Namespace, errMsg))
-			ssoConfigLegalStatus = ssoLegalFailed // set global indicator that SSO config has gone wrong
+			r.ssoConfigLegalStatus = ssoLegalFailed // set indicator that SSO config has gone wrong
+			r.createSSOValidationFailedEvent(cr, err)
 			_ = r.reconcileStatusSSOConfig(cr)
 			return err
 		}
@@ -184,7 +192,8 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoprojv1a1.ArgoCD) error {
 			if isError {
 				err = errors.New(illegalSSOConfiguration + errMsg)
 				log.Error(err, fmt.Sprintf("Illegal expression of SSO configuration detetected for Argo CD %s in namespace %s. %s", cr.Name, cr.Namespace, errMsg))
-				ssoConfigLegalStatus = ssoLegalFailed // set global indicator that SSO config has gone wrong
+				r.ssoConfigLegalStatus = ssoLegalFailed // set indicator that SSO config has gone wrong
+				r.createSSOValidationFailedEvent(cr, err)
 				_ = r.reconcileStatusSSOConfig(cr)
 				return err
 			}
@@ -217,7 +226,8 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoprojv1a1.ArgoCD) error {
 
 			if isError {
 				log.Error(err, fmt.Sprintf("Illegal expression of SSO configuration deletected for Argo CD %s in namespace %s. %s", cr.Name, cr.Namespace, errMsg))
-				ssoConfigLegalStatus = ssoLegalFailed // set global indicator that SSO config has gone wrong
+				r.ssoConfigLegalStatus = ssoLegalFailed // set indicator that SSO config has gone wrong
+				r.createSSOValidationFailedEvent(cr, err)
 				_ = r.reconcileStatusSSOConfig(cr)
 				return err
 			}
@@ -234,7 +244,8 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoprojv1a1.ArgoCD) error {
 				errMsg = "Cannot specify SSO provider spec without specifying SSO provider type"
 				err = errors.New(illegalSSOConfiguration + errMsg)
 				log.Error(err, fmt.Sprintf("Cannot specify SSO provider spec without specifying SSO provider type for Argo CD %s in namespace %s.", cr.Name, cr.Namespace))
-				ssoConfigLegalStatus = ssoLegalFailed // set global indicator that SSO config has gone wrong
+				r.ssoConfigLegalStatus = ssoLegalFailed // set indicator that SSO config has gone wrong
+				r.createSSOValidationFailedEvent(cr, err)
 				_ = r.reconcileStatusSSOConfig(cr)
 				return err
 			}
@@ -242,8 +253,8 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoprojv1a1.ArgoCD) error {
 	}
 
 	// control reaching this point means that none of the illegal config combinations were detected. SSO is configured legally
-	// set global indicator that SSO config has been successful
-	ssoConfigLegalStatus = ssoLegalSuccess
+	// set indicator that SSO config has been successful
+	r.ssoConfigLegalStatus = ssoLegalSuccess
 
 	// reconcile resources based on enabled provider
 	// keycloak
@@ -296,3 +307,50 @@ func (r *ReconcileArgoCD) deleteSSOConfiguration(newCr *argoprojv1a1.ArgoCD, old
 	_ = r.reconcileStatusSSOConfig(newCr)
 	return nil
 }
+
+// setSSOTheme will mount the ConfigMap of the given theme into the first container of the given pod spec, in the
+// directory of the theme below the given themes directory, replacing any theme mounted before. The theme is removed
+// when nil. It returns true when the pod spec changed.
+func setSSOTheme(podSpec *corev1.PodSpec, theme *argoprojv1a1.ArgoCDSSOThemeSpec, themesDir string) bool {
+	volumes := make([]corev1.Volume, 0, len(podSpec.Volumes))
+	for _, volume := range podSpec.Volumes {
+		if volume.Name != common.ArgoCDSSOThemeVolumeName {
+			volumes = append(volumes, volume)
+		}
+	}
+	mounts := make([]corev1.VolumeMount, 0, len(podSpec.Containers[0].VolumeMounts))
+	for _, mount := range podSpec.Containers[0].VolumeMounts {
+		if mount.Name != common.ArgoCDSSOThemeVolumeName {
+			mounts = append(mounts, mount)
+		}
+	}
+
+	if theme != nil {
+		// Set the default mode of the API server, so the volume of an existing workload compares equal.
+		var defaultMode int32 = 420
+		volumes = append(volumes, corev1.Volume{
+			Name: common.ArgoCDSSOThemeVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: theme.ConfigMap,
+					},
+					Items:       theme.Items,
+					DefaultMode: &defaultMode,
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      common.ArgoCDSSOThemeVolumeName,
+			MountPath: fmt.Sprintf("%s/%s", themesDir, theme.Name),
+			ReadOnly:  true,
+		})
+	}
+
+	if equality.Semantic.DeepEqual(volumes, podSpec.Volumes) && equality.Semantic.DeepEqual(mounts, podSpec.Containers[0].VolumeMounts) {
+		return false
+	}
+	podSpec.Volumes = volumes
+	podSpec.Containers[0].VolumeMounts = mounts
+	return true
+}
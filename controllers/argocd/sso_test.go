@@ -49,7 +49,7 @@ func makeFakeReconciler(t *testing.T, acd *argov1alpha1.ArgoCD, objs ...runtime.
 	oappsv1.Install(s)
 	routev1.Install(s)
 
-	cl := fake.NewFakeClientWithScheme(s, objs...)
+	cl := newTestApplyClient(fake.NewFakeClientWithScheme(s, objs...))
 	return &ReconcileArgoCD{
 		Client: cl,
 		Scheme: s,
@@ -329,6 +329,7 @@ func TestReconcile_illegalSSOConfiguration(t *testing.T) {
 					t.Errorf("Got unexpected error")
 				} else {
 					assert.Equal(t, test.Err, err)
+					assertSSOValidationFailedEvent(t, r, err)
 				}
 			} else {
 				if test.wantErr {
@@ -340,6 +341,21 @@ func TestReconcile_illegalSSOConfiguration(t *testing.T) {
 
 }
 
+// assertSSOValidationFailedEvent asserts that a warning Event was recorded for the given SSO validation error.
+func assertSSOValidationFailedEvent(t *testing.T, r *ReconcileArgoCD, err error) {
+	t.Helper()
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events))
+	for _, event := range events.Items {
+		if event.Reason == "InvalidSSOConfiguration" {
+			assert.Equal(t, "Warning", event.Type)
+			assert.Equal(t, err.Error(), event.Message)
+			return
+		}
+	}
+	t.Errorf("expected an InvalidSSOConfiguration event")
+}
+
 func TestReconcile_emitEventOnDetectingDeprecatedFields(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 
@@ -16,28 +16,101 @@ package argocd
 
 import (
 	"context"
+	"crypto/sha1"
 	"fmt"
 	"reflect"
-	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
 	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
+// getRedisHALivenessProbe will return the liveness probe of the Redis container in HA mode.
+func getRedisHALivenessProbe(cr *argoprojv1a1.ArgoCD) *corev1.Probe {
+	return getRedisProbe(cr.Spec.Redis.LivenessProbe, getRedisHAHealthProbe("/health/redis_liveness.sh"))
+}
+
+// getRedisHAReadinessProbe will return the readiness probe of the Redis container in HA mode.
+func getRedisHAReadinessProbe(cr *argoprojv1a1.ArgoCD) *corev1.Probe {
+	return getRedisProbe(cr.Spec.Redis.ReadinessProbe, getRedisHAHealthProbe("/health/redis_readiness.sh"))
+}
+
+// getRedisHAHealthProbe will return the default probe of the Redis container in HA mode, running the given health script.
+func getRedisHAHealthProbe(script string) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{
+					"sh",
+					"-c",
+					script,
+				},
+			},
+		},
+		FailureThreshold:    int32(5),
+		InitialDelaySeconds: int32(30),
+		PeriodSeconds:       int32(15),
+		SuccessThreshold:    int32(1),
+		TimeoutSeconds:      int32(15),
+	}
+}
+
 func getRedisHAReplicas(cr *argoprojv1a1.ArgoCD) *int32 {
 	replicas := common.ArgoCDDefaultRedisHAReplicas
-	// TODO: Allow override of this value through CR?
+	// Allow override of replicas from CR
+	if cr.Spec.HA.Replicas != nil && *cr.Spec.HA.Replicas >= common.ArgoCDDefaultRedisHAReplicas {
+		replicas = *cr.Spec.HA.Replicas
+	}
 	return &replicas
 }
 
+// getRedisHAQuorum will return the number of Sentinels that need to agree about the failure of the Redis master.
+func getRedisHAQuorum(cr *argoprojv1a1.ArgoCD) int32 {
+	return *getRedisHAReplicas(cr)/2 + 1
+}
+
+// getRedisHAReplicaIndexes will return the ordinal indexes of the Redis HA StatefulSet pods.
+func getRedisHAReplicaIndexes(cr *argoprojv1a1.ArgoCD) []int32 {
+	indexes := make([]int32, 0)
+	for i := int32(0); i < *getRedisHAReplicas(cr); i++ {
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+// getRedisHASentinelIDEnv will return the environment variables holding the Sentinel ID of each Redis HA pod.
+func getRedisHASentinelIDEnv(cr *argoprojv1a1.ArgoCD) []corev1.EnvVar {
+	// TODO: Should these be hard-coded?
+	ids := []string{
+		"3c0d9c0320bb34888c2df5757c718ce6ca992ce6",
+		"40000915ab58c3fa8fd888fb8b24711944e6cbb4",
+		"2bbec7894d954a8af3bb54d13eaec53cb024e2ca",
+	}
+
+	env := make([]corev1.EnvVar, 0)
+	for _, i := range getRedisHAReplicaIndexes(cr) {
+		id := ""
+		if int(i) < len(ids) {
+			id = ids[i]
+		} else {
+			// Sentinel IDs must be unique 40 character hex strings, derive the additional ones from the pod name.
+			id = fmt.Sprintf("%x", sha1.Sum([]byte(nameWithSuffix(fmt.Sprintf("redis-ha-server-%d", i), cr))))
+		}
+		env = append(env, corev1.EnvVar{
+			Name:  fmt.Sprintf("SENTINEL_ID_%d", i),
+			Value: id,
+		})
+	}
+	return env
+}
+
 // newStatefulSet returns a new StatefulSet instance for the given ArgoCD instance.
 func newStatefulSet(cr *argoprojv1a1.ArgoCD) *appsv1.StatefulSet {
 	return &appsv1.StatefulSet{
@@ -81,6 +154,7 @@ func newStatefulSetWithName(name string, component string, cr *argoprojv1a1.Argo
 		ss.Spec.Template.Spec.Tolerations = cr.Spec.NodePlacement.Tolerations
 	}
 	ss.Spec.ServiceName = name
+	ss.Spec.RevisionHistoryLimit = getRevisionHistoryLimit(cr)
 
 	return ss
 }
@@ -94,32 +168,18 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoprojv1a1.ArgoCD) err
 	ss := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
 
 	existing := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		if !cr.Spec.HA.Enabled {
-			// StatefulSet exists but HA enabled flag has been set to false, delete the StatefulSet
-			return r.Client.Delete(context.TODO(), existing)
-		}
-
-		desiredImage := getRedisHAContainerImage(cr)
-		changed := false
-		updateNodePlacementStateful(existing, ss, &changed)
-		for i, container := range existing.Spec.Template.Spec.Containers {
-			if container.Image != desiredImage {
-				existing.Spec.Template.Spec.Containers[i].Image = getRedisHAContainerImage(cr)
-				existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
-				changed = true
-			}
-		}
-
-		if changed {
-			return r.Client.Update(context.TODO(), existing)
-		}
-
-		return nil // StatefulSet found, do nothing
+	if cr.Spec.HA.Enabled && argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) &&
+		volumeClaimTemplatesChanged(existing.Spec.VolumeClaimTemplates, getRedisHAVolumeClaimTemplates(cr)) {
+		return r.recreateStatefulSet(cr, existing)
 	}
 
 	if !cr.Spec.HA.Enabled {
-		return nil // HA not enabled, do nothing.
+		// HA is not enabled, delete the StatefulSet if it exists
+		existing = newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+			return r.Client.Delete(context.TODO(), existing)
+		}
+		return nil
 	}
 
 	ss.Spec.PodManagementPolicy = appsv1.OrderedReadyPodManagement
@@ -165,46 +225,17 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoprojv1a1.ArgoCD) err
 			Command: []string{
 				"redis-server",
 			},
+			Env:             getRedisAuthEnv(cr, "AUTH"),
 			Image:           getRedisHAContainerImage(cr),
 			ImagePullPolicy: corev1.PullIfNotPresent,
-			LivenessProbe: &corev1.Probe{
-				ProbeHandler: corev1.ProbeHandler{
-					Exec: &corev1.ExecAction{
-						Command: []string{
-							"sh",
-							"-c",
-							"/health/redis_liveness.sh",
-						},
-					},
-				},
-				FailureThreshold:    int32(5),
-				InitialDelaySeconds: int32(30),
-				PeriodSeconds:       int32(15),
-				SuccessThreshold:    int32(1),
-				TimeoutSeconds:      int32(15),
-			},
-			Name: "redis",
+			LivenessProbe:   getRedisHALivenessProbe(cr),
+			Name:            "redis",
 			Ports: []corev1.ContainerPort{{
 				ContainerPort: common.ArgoCDDefaultRedisPort,
 				Name:          "redis",
 			}},
-			ReadinessProbe: &corev1.Probe{
-				ProbeHandler: corev1.ProbeHandler{
-					Exec: &corev1.ExecAction{
-						Command: []string{
-							"sh",
-							"-c",
-							"/health/redis_readiness.sh",
-						},
-					},
-				},
-				FailureThreshold:    int32(5),
-				InitialDelaySeconds: int32(30),
-				PeriodSeconds:       int32(15),
-				SuccessThreshold:    int32(1),
-				TimeoutSeconds:      int32(15),
-			},
-			Resources: getRedisResources(cr),
+			ReadinessProbe: getRedisHAReadinessProbe(cr),
+			Resources:      getRedisResources(cr),
 			SecurityContext: &corev1.SecurityContext{
 				AllowPrivilegeEscalation: boolPtr(false),
 				Capabilities: &corev1.Capabilities{
@@ -275,7 +306,7 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoprojv1a1.ArgoCD) err
 				SuccessThreshold:    int32(1),
 				TimeoutSeconds:      int32(15),
 			},
-			Resources: getRedisResources(cr),
+			Resources: getRedisSentinelResources(cr),
 			SecurityContext: &corev1.SecurityContext{
 				AllowPrivilegeEscalation: boolPtr(false),
 				Capabilities: &corev1.Capabilities{
@@ -309,20 +340,7 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoprojv1a1.ArgoCD) err
 		Command: []string{
 			"sh",
 		},
-		Env: []corev1.EnvVar{
-			{
-				Name:  "SENTINEL_ID_0",
-				Value: "3c0d9c0320bb34888c2df5757c718ce6ca992ce6", // TODO: Should this be hard-coded?
-			},
-			{
-				Name:  "SENTINEL_ID_1",
-				Value: "40000915ab58c3fa8fd888fb8b24711944e6cbb4", // TODO: Should this be hard-coded?
-			},
-			{
-				Name:  "SENTINEL_ID_2",
-				Value: "2bbec7894d954a8af3bb54d13eaec53cb024e2ca", // TODO: Should this be hard-coded?
-			},
-		},
+		Env:             append(getRedisHASentinelIDEnv(cr), getRedisAuthEnv(cr, "AUTH")...),
 		Image:           getRedisHAContainerImage(cr),
 		ImagePullPolicy: corev1.PullIfNotPresent,
 		Name:            "config-init",
@@ -366,8 +384,7 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoprojv1a1.ArgoCD) err
 
 	ss.Spec.Template.Spec.ServiceAccountName = nameWithSuffix("argocd-redis-ha", cr)
 
-	var terminationGracePeriodSeconds int64 = 60
-	ss.Spec.Template.Spec.TerminationGracePeriodSeconds = &terminationGracePeriodSeconds
+	ss.Spec.Template.Spec.TerminationGracePeriodSeconds = getRedisTerminationGracePeriodSeconds(cr, 60)
 
 	var defaultMode int32 = 493
 	ss.Spec.Template.Spec.Volumes = []corev1.Volume{
@@ -392,12 +409,6 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoprojv1a1.ArgoCD) err
 				},
 			},
 		},
-		{
-			Name: "data",
-			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{},
-			},
-		},
 		{
 			Name: common.ArgoCDRedisServerTLSSecretName,
 			VolumeSource: corev1.VolumeSource{
@@ -409,6 +420,16 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoprojv1a1.ArgoCD) err
 		},
 	}
 
+	ss.Spec.VolumeClaimTemplates = getRedisHAVolumeClaimTemplates(cr)
+	if len(ss.Spec.VolumeClaimTemplates) == 0 {
+		ss.Spec.Template.Spec.Volumes = append(ss.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: common.ArgoCDRedisDataVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
 	ss.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
 		Type: appsv1.RollingUpdateStatefulSetStrategyType,
 	}
@@ -417,13 +438,10 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoprojv1a1.ArgoCD) err
 		return err
 	}
 
-	if err := controllerutil.SetControllerReference(cr, ss, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), ss)
+	return r.applyResource(cr, ss)
 }
 
-func getArgoControllerContainerEnv(cr *argoprojv1a1.ArgoCD) []corev1.EnvVar {
+func getArgoControllerContainerEnv(cr *argoprojv1a1.ArgoCD, replicas int32) []corev1.EnvVar {
 	env := make([]corev1.EnvVar, 0)
 
 	env = append(env, corev1.EnvVar{
@@ -431,47 +449,129 @@ func getArgoControllerContainerEnv(cr *argoprojv1a1.ArgoCD) []corev1.EnvVar {
 		Value: "/home/argocd",
 	})
 
-	if cr.Spec.Controller.Sharding.Enabled {
+	if cr.Spec.Controller.Sharding.Enabled || isControllerDynamicScalingEnabled(cr) {
 		env = append(env, corev1.EnvVar{
 			Name:  "ARGOCD_CONTROLLER_REPLICAS",
-			Value: fmt.Sprint(cr.Spec.Controller.Sharding.Replicas),
+			Value: fmt.Sprint(replicas),
 		})
 	}
 
+	// The settings are read from argocd-cm, where the operator validates them.
+	if cr.Spec.Controller.ReconciliationTimeout != "" {
+		env = append(env, getArgoConfigMapKeyEnv("ARGOCD_RECONCILIATION_TIMEOUT", common.ArgoCDKeyTimeoutReconciliation))
+	}
+	if cr.Spec.Controller.ServerSideDiff != nil {
+		env = append(env, getArgoConfigMapKeyEnv("ARGOCD_APPLICATION_CONTROLLER_SERVER_SIDE_DIFF", common.ArgoCDKeyControllerDiffServerSide))
+	}
+
 	return env
 }
 
-func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) error {
+// getArgoConfigMapKeyEnv returns an environment variable with the given name that refers to the given key of argocd-cm.
+func getArgoConfigMapKeyEnv(name string, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: common.ArgoCDConfigMapName,
+				},
+				Key:      key,
+				Optional: boolPtr(true),
+			},
+		},
+	}
+}
+
+// isControllerDynamicScalingEnabled returns true if dynamic scaling of the Application controller shards is enabled.
+func isControllerDynamicScalingEnabled(cr *argoprojv1a1.ArgoCD) bool {
+	return cr.Spec.Controller.Sharding.DynamicScalingEnabled != nil && *cr.Spec.Controller.Sharding.DynamicScalingEnabled
+}
+
+// getApplicationControllerReplicaCount will return the number of replicas for the Application controller.
+// When dynamic scaling is enabled, the number of replicas is derived from the number of managed cluster
+// secrets and the configured clusters per shard, bounded by the minimum and maximum number of shards.
+func (r *ReconcileArgoCD) getApplicationControllerReplicaCount(cr *argoprojv1a1.ArgoCD) int32 {
 	var replicas int32 = common.ArgocdApplicationControllerDefaultReplicas
 
+	if isControllerDynamicScalingEnabled(cr) {
+		minShards := cr.Spec.Controller.Sharding.MinShards
+		maxShards := cr.Spec.Controller.Sharding.MaxShards
+		clustersPerShard := cr.Spec.Controller.Sharding.ClustersPerShard
+
+		if minShards < 1 {
+			log.Info("minimum number of shards cannot be less than 1, defaulting to 1")
+			minShards = 1
+		}
+		if maxShards < minShards {
+			log.Info("maximum number of shards cannot be less than the minimum number of shards, defaulting to the minimum")
+			maxShards = minShards
+		}
+		if clustersPerShard < 1 {
+			log.Info("clusters per shard cannot be less than 1, defaulting to 1")
+			clustersPerShard = 1
+		}
+
+		clusterSecrets, err := r.getClusterSecrets(cr)
+		if err != nil {
+			log.Error(err, fmt.Sprintf("failed to list cluster secrets for ArgoCD %s in namespace %s, using %d replicas",
+				cr.Name, cr.Namespace, minShards))
+			return minShards
+		}
+
+		clusters := int32(len(clusterSecrets.Items))
+		replicas = (clusters + clustersPerShard - 1) / clustersPerShard
+		if replicas < minShards {
+			replicas = minShards
+		}
+		if replicas > maxShards {
+			replicas = maxShards
+		}
+		return replicas
+	}
+
 	if cr.Spec.Controller.Sharding.Replicas != 0 && cr.Spec.Controller.Sharding.Enabled {
 		replicas = cr.Spec.Controller.Sharding.Replicas
 	}
 
+	return replicas
+}
+
+func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) error {
+	replicas := r.getApplicationControllerReplicaCount(cr)
+
 	ss := newStatefulSetWithSuffix("application-controller", "application-controller", cr)
 	ss.Spec.Replicas = &replicas
 	controllerEnv := cr.Spec.Controller.Env
 	// Sharding setting explicitly overrides a value set in the env
-	controllerEnv = argoutil.EnvMerge(controllerEnv, getArgoControllerContainerEnv(cr), true)
+	controllerEnv = argoutil.EnvMerge(controllerEnv, getArgoControllerContainerEnv(cr, replicas), true)
 	// Let user specify their own environment first
 	controllerEnv = argoutil.EnvMerge(controllerEnv, proxyEnvVars(), false)
+	controllerEnv = argoutil.EnvMerge(controllerEnv, getRedisCredentialsEnv(cr), false)
+	controllerEnv = argoutil.EnvMerge(controllerEnv, getCmdParamsEnv(cmdParamsPrefixController, cr), false)
+	controllerCommand := getArgoApplicationControllerCommand(cr, useTLSForRedis)
+	if isRepoServerTLSVerificationRequested(cr) {
+		controllerCommand = append(controllerCommand, "--repo-server-strict-tls")
+	}
+	image := r.getArgoContainerImageForStage(cr, "application-controller")
+	setWorkloadArgoImage(ss, image)
 	podSpec := &ss.Spec.Template.Spec
 	podSpec.Containers = []corev1.Container{{
-		Command:         getArgoApplicationControllerCommand(cr, useTLSForRedis),
-		Image:           getArgoContainerImage(cr),
+		Command:         controllerCommand,
+		Image:           image,
 		ImagePullPolicy: corev1.PullAlways,
 		Name:            "argocd-application-controller",
 		Env:             controllerEnv,
 		Ports: []corev1.ContainerPort{
 			{
-				ContainerPort: 8082,
+				ContainerPort: getArgoControllerMetricsPort(cr),
 			},
 		},
 		ReadinessProbe: &corev1.Probe{
 			ProbeHandler: corev1.ProbeHandler{
 				HTTPGet: &corev1.HTTPGetAction{
 					Path: "/healthz",
-					Port: intstr.FromInt(8082),
+					Port: intstr.FromInt(int(getArgoControllerMetricsPort(cr))),
 				},
 			},
 			InitialDelaySeconds: 5,
@@ -498,6 +598,13 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 			},
 		},
 	}}
+	if cr.Spec.Controller.Storage != nil {
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      common.ArgoCDApplicationControllerWorkDirVolumeName,
+			MountPath: common.ArgoCDApplicationControllerWorkDirPath,
+		})
+	}
+	ss.Spec.VolumeClaimTemplates = getApplicationControllerVolumeClaimTemplates(cr)
 	AddSeccompProfileForOpenShift(r.Client, podSpec)
 	podSpec.ServiceAccountName = nameWithSuffix("argocd-application-controller", cr)
 	podSpec.Volumes = []corev1.Volume{
@@ -569,12 +676,14 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 				},
 				RunAsNonRoot: boolPtr(true),
 			},
-			VolumeMounts: getArgoImportVolumeMounts(),
+			VolumeMounts: getArgoImportVolumeMounts(export),
 		}}
 
 		podSpec.Volumes = getArgoImportVolumes(export)
 	}
 
+	addExecProviderTools(cr, &ss.Spec.Template, getArgoApplicationControllerResources(cr))
+
 	invalidImagePod := containsInvalidImage(cr, r)
 	if invalidImagePod {
 		if err := r.Client.Delete(context.TODO(), ss); err != nil {
@@ -583,52 +692,9 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 	}
 
 	existing := newStatefulSetWithSuffix("application-controller", "application-controller", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getArgoContainerImage(cr)
-		changed := false
-		if actualImage != desiredImage {
-			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
-			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
-			changed = true
-		}
-		desiredCommand := getArgoApplicationControllerCommand(cr, useTLSForRedis)
-		if isRepoServerTLSVerificationRequested(cr) {
-			desiredCommand = append(desiredCommand, "--repo-server-strict-tls")
-		}
-		updateNodePlacementStateful(existing, ss, &changed)
-		if !reflect.DeepEqual(desiredCommand, existing.Spec.Template.Spec.Containers[0].Command) {
-			existing.Spec.Template.Spec.Containers[0].Command = desiredCommand
-			changed = true
-		}
-
-		if !reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].Env,
-			ss.Spec.Template.Spec.Containers[0].Env) {
-			existing.Spec.Template.Spec.Containers[0].Env = ss.Spec.Template.Spec.Containers[0].Env
-			changed = true
-		}
-		if !reflect.DeepEqual(ss.Spec.Template.Spec.Volumes, existing.Spec.Template.Spec.Volumes) {
-			existing.Spec.Template.Spec.Volumes = ss.Spec.Template.Spec.Volumes
-			changed = true
-		}
-		if !reflect.DeepEqual(ss.Spec.Template.Spec.Containers[0].VolumeMounts,
-			existing.Spec.Template.Spec.Containers[0].VolumeMounts) {
-			existing.Spec.Template.Spec.Containers[0].VolumeMounts = ss.Spec.Template.Spec.Containers[0].VolumeMounts
-			changed = true
-		}
-		if !reflect.DeepEqual(ss.Spec.Template.Spec.Containers[0].Resources, existing.Spec.Template.Spec.Containers[0].Resources) {
-			existing.Spec.Template.Spec.Containers[0].Resources = ss.Spec.Template.Spec.Containers[0].Resources
-			changed = true
-		}
-		if !reflect.DeepEqual(ss.Spec.Replicas, existing.Spec.Replicas) {
-			existing.Spec.Replicas = ss.Spec.Replicas
-			changed = true
-		}
-
-		if changed {
-			return r.Client.Update(context.TODO(), existing)
-		}
-		return nil // StatefulSet found with nothing to do, move along...
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) &&
+		volumeClaimTemplatesChanged(existing.Spec.VolumeClaimTemplates, ss.Spec.VolumeClaimTemplates) {
+		return r.recreateStatefulSet(cr, existing)
 	}
 
 	// Delete existing deployment for Application Controller, if any ..
@@ -639,23 +705,98 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 		}
 	}
 
-	if err := controllerutil.SetControllerReference(cr, ss, r.Scheme); err != nil {
-		return err
+	return r.applyResource(cr, ss)
+}
+
+// getApplicationControllerVolumeClaimTemplates will return the volume claim templates for the Application Controller StatefulSet.
+func getApplicationControllerVolumeClaimTemplates(cr *argoprojv1a1.ArgoCD) []corev1.PersistentVolumeClaim {
+	storage := cr.Spec.Controller.Storage
+	if storage == nil {
+		return nil
 	}
-	return r.Client.Create(context.TODO(), ss)
+
+	size := resource.MustParse(common.ArgoCDApplicationControllerDefaultStorageSize)
+	if storage.Size != nil {
+		size = *storage.Size
+	}
+
+	return []corev1.PersistentVolumeClaim{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: common.ArgoCDApplicationControllerWorkDirVolumeName,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+			},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+			StorageClassName: storage.StorageClassName,
+		},
+	}}
 }
 
-// reconcileStatefulSets will ensure that all StatefulSets are present for the given ArgoCD.
-func (r *ReconcileArgoCD) reconcileStatefulSets(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) error {
-	if err := r.reconcileApplicationControllerStatefulSet(cr, useTLSForRedis); err != nil {
-		return err
+// getRedisHAVolumeClaimTemplates will return the volume claim templates for the Redis HA StatefulSet.
+func getRedisHAVolumeClaimTemplates(cr *argoprojv1a1.ArgoCD) []corev1.PersistentVolumeClaim {
+	if !cr.Spec.Redis.IsPersistent() {
+		return nil
 	}
-	if err := r.reconcileRedisStatefulSet(cr); err != nil {
+
+	return []corev1.PersistentVolumeClaim{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: common.ArgoCDRedisDataVolumeName,
+		},
+		Spec: getRedisPersistentVolumeClaimSpec(cr),
+	}}
+}
+
+// recreateStatefulSet will delete the given StatefulSet, whose volume claim templates are immutable, so that it is
+// created again with the desired volume claim templates once the deletion completes, which triggers another
+// reconciliation. The pods are orphaned and adopted by the new StatefulSet, so the component keeps running, but they
+// only use the new volume claims once they are restarted. A warning Event is recorded on the ArgoCD.
+func (r *ReconcileArgoCD) recreateStatefulSet(cr *argoprojv1a1.ArgoCD, existing *appsv1.StatefulSet) error {
+	if existing.DeletionTimestamp != nil {
+		return nil // StatefulSet is still being deleted, wait for it to be gone...
+	}
+
+	log.Info(fmt.Sprintf("volume claim templates of StatefulSet %s changed, recreating it", existing.Name))
+	if err := r.Client.Delete(context.TODO(), existing, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil {
 		return err
 	}
+
+	message := fmt.Sprintf("Recreating StatefulSet %s as its volume claim templates changed, its pods use the new volume claims once they are restarted", existing.Name)
+	if err := argoutil.CreateEvent(r.Client, "Warning", "Recreating", message, "StatefulSetRecreated", cr.ObjectMeta, cr.TypeMeta); err != nil {
+		log.Error(err, "failed to record event", "message", message)
+	}
 	return nil
 }
 
+// volumeClaimTemplatesChanged returns true if the name, size or storage class of the existing volume claim
+// templates differ from the desired ones. Other fields are ignored as they are defaulted by the API server.
+func volumeClaimTemplatesChanged(existing, desired []corev1.PersistentVolumeClaim) bool {
+	if len(existing) != len(desired) {
+		return true
+	}
+	for i := range desired {
+		if existing[i].Name != desired[i].Name {
+			return true
+		}
+		existingSize := existing[i].Spec.Resources.Requests[corev1.ResourceStorage]
+		desiredSize := desired[i].Spec.Resources.Requests[corev1.ResourceStorage]
+		if existingSize.Cmp(desiredSize) != 0 {
+			return true
+		}
+		// an empty storage class name is defaulted by the API server
+		if desired[i].Spec.StorageClassName != nil &&
+			!reflect.DeepEqual(existing[i].Spec.StorageClassName, desired[i].Spec.StorageClassName) {
+			return true
+		}
+	}
+	return false
+}
+
 // triggerStatefulSetRollout will update the label with the given key to trigger a new rollout of the StatefulSet.
 func (r *ReconcileArgoCD) triggerStatefulSetRollout(sts *appsv1.StatefulSet, key string) error {
 	if !argoutil.IsObjectFound(r.Client, sts.Namespace, sts.Name, sts) {
@@ -667,18 +808,6 @@ func (r *ReconcileArgoCD) triggerStatefulSetRollout(sts *appsv1.StatefulSet, key
 	return r.Client.Update(context.TODO(), sts)
 }
 
-// to update nodeSelector and tolerations in reconciler
-func updateNodePlacementStateful(existing *appsv1.StatefulSet, ss *appsv1.StatefulSet, changed *bool) {
-	if !reflect.DeepEqual(existing.Spec.Template.Spec.NodeSelector, ss.Spec.Template.Spec.NodeSelector) {
-		existing.Spec.Template.Spec.NodeSelector = ss.Spec.Template.Spec.NodeSelector
-		*changed = true
-	}
-	if !reflect.DeepEqual(existing.Spec.Template.Spec.Tolerations, ss.Spec.Template.Spec.Tolerations) {
-		existing.Spec.Template.Spec.Tolerations = ss.Spec.Template.Spec.Tolerations
-		*changed = true
-	}
-}
-
 // Returns true if a StatefulSet has pods in ErrImagePull or ImagePullBackoff state.
 // These pods cannot be restarted automatially due to known kubernetes issue https://github.com/kubernetes/kubernetes/issues/67250
 func containsInvalidImage(cr *argoprojv1a1.ArgoCD, r *ReconcileArgoCD) bool {
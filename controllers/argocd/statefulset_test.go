@@ -3,6 +3,7 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	resourcev1 "k8s.io/apimachinery/pkg/api/resource"
@@ -20,6 +21,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -103,6 +106,97 @@ func TestReconcileArgoCD_reconcileRedisStatefulSet_HA_enabled(t *testing.T) {
 	assert.Errorf(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s), "not found")
 }
 
+func TestReconcileArgoCD_reconcileRedisStatefulSet_HA_tuning(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+	s := newStatefulSetWithSuffix("redis-ha-server", "redis", a)
+
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+	assert.Equal(t, int32(3), *s.Spec.Replicas)
+	assert.Len(t, s.Spec.Template.Spec.InitContainers[0].Env, 3)
+
+	replicas := int32(5)
+	sentinelResources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resourcev1.MustParse("64Mi"),
+		},
+	}
+	a.Spec.HA.Replicas = &replicas
+	a.Spec.HA.SentinelResources = &sentinelResources
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+	assert.Equal(t, int32(5), *s.Spec.Replicas)
+	assert.Len(t, s.Spec.Template.Spec.InitContainers[0].Env, 5)
+	assert.Equal(t, "sentinel", s.Spec.Template.Spec.Containers[1].Name)
+	assert.Equal(t, sentinelResources, s.Spec.Template.Spec.Containers[1].Resources)
+	assert.Equal(t, corev1.ResourceRequirements{}, s.Spec.Template.Spec.Containers[0].Resources)
+}
+
+func TestReconcileArgoCD_reconcileRedisStatefulSet_HA_persistence(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+	s := newStatefulSetWithSuffix("redis-ha-server", "redis", a)
+
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+	assert.Empty(t, s.Spec.VolumeClaimTemplates)
+	assert.Len(t, s.Spec.Template.Spec.Volumes, 4)
+
+	// Enabling persistence recreates the StatefulSet with volume claim templates, once the existing one is deleted
+	a.Spec.Redis.Persistence = &argoprojv1alpha1.ArgoCDRedisPersistenceSpec{Enabled: true}
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assertNotFound(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	s = newStatefulSetWithSuffix("redis-ha-server", "redis", a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+	assert.Len(t, s.Spec.VolumeClaimTemplates, 1)
+	assert.Equal(t, "data", s.Spec.VolumeClaimTemplates[0].Name)
+	assert.Equal(t, resourcev1.MustParse("10Gi"), s.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage])
+	for _, v := range s.Spec.Template.Spec.Volumes {
+		assert.NotEqual(t, "data", v.Name)
+	}
+}
+
+func TestReconcileArgoCD_reconcileRedisStatefulSet_HA_probes(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+	s := newStatefulSetWithSuffix("redis-ha-server", "redis", a)
+
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+	assert.Equal(t, int64(60), *s.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	assert.Equal(t, int32(30), s.Spec.Template.Spec.Containers[0].LivenessProbe.InitialDelaySeconds)
+
+	gracePeriod := int64(120)
+	a.Spec.Redis.TerminationGracePeriodSeconds = &gracePeriod
+	a.Spec.Redis.LivenessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: []string{"sh", "-c", "/health/redis_liveness.sh"}},
+		},
+		InitialDelaySeconds: 60,
+	}
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+	assert.Equal(t, int64(120), *s.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	assert.Equal(t, int32(60), s.Spec.Template.Spec.Containers[0].LivenessProbe.InitialDelaySeconds)
+	assert.Equal(t, int32(3), s.Spec.Template.Spec.Containers[0].LivenessProbe.FailureThreshold)
+	// The sentinel keeps its default probes
+	assert.Equal(t, int32(30), s.Spec.Template.Spec.Containers[1].LivenessProbe.InitialDelaySeconds)
+}
+
 func TestReconcileArgoCD_reconcileApplicationController(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
@@ -219,6 +313,25 @@ func TestReconcileArgoCD_reconcileApplicationController_withUpgrade(t *testing.T
 	assert.Errorf(t, err, "not found")
 }
 
+func TestReconcileArgoCD_reconcileApplicationController_workloads(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, ss))
+	assert.Equal(t, int32(3), *ss.Spec.RevisionHistoryLimit)
+
+	a.Spec.Workloads = &argoprojv1alpha1.ArgoCDWorkloadSpec{
+		RevisionHistoryLimit: int32Ptr(5),
+	}
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, ss))
+	assert.Equal(t, int32(5), *ss.Spec.RevisionHistoryLimit)
+}
+
 func TestReconcileArgoCD_reconcileApplicationController_withResources(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCDWithResources(func(a *argoprojv1alpha1.ArgoCD) {
@@ -356,63 +469,350 @@ func TestReconcileArgoCD_reconcileApplicationController_withSharding(t *testing.
 	}
 }
 
-func Test_UpdateNodePlacementStateful(t *testing.T) {
+func TestReconcileArgoCD_reconcileApplicationController_withEnv(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
 
-	ss := &appsv1.StatefulSet{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "argocd-sample-server",
-			Namespace: testNamespace,
-		},
-		Spec: appsv1.StatefulSetSpec{
-			Template: corev1.PodTemplateSpec{
-				Spec: corev1.PodSpec{
-					NodeSelector: map[string]string{
-						"test_key1": "test_value1",
-						"test_key2": "test_value2",
-					},
-					Tolerations: []corev1.Toleration{
-						{
-							Key:    "test_key1",
-							Value:  "test_value1",
-							Effect: corev1.TaintEffectNoSchedule,
-						},
-					},
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Controller.Env = []corev1.EnvVar{
+			{Name: "ARGOCD_K8S_CLIENT_QPS", Value: "100"},
+			{Name: "ARGOCD_CLUSTER_CACHE_LIST_PAGE_SIZE", Value: "250"},
+			{Name: "ARGOCD_CONTROLLER_REPLICAS", Value: "5"},
+		}
+		a.Spec.Controller.Sharding = argoprojv1alpha1.ArgoCDApplicationControllerShardSpec{
+			Enabled:  true,
+			Replicas: 2,
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+
+	ss := &appsv1.StatefulSet{}
+	key := types.NamespacedName{
+		Name:      "argocd-application-controller",
+		Namespace: a.Namespace,
+	}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+
+	// sharding settings take precedence over the env specified in the CR
+	want := []corev1.EnvVar{
+		{Name: "ARGOCD_CLUSTER_CACHE_LIST_PAGE_SIZE", Value: "250"},
+		{Name: "ARGOCD_CONTROLLER_REPLICAS", Value: "2"},
+		{Name: "ARGOCD_K8S_CLIENT_QPS", Value: "100"},
+		{Name: "HOME", Value: "/home/argocd"},
+	}
+	assert.Equal(t, want, ss.Spec.Template.Spec.Containers[0].Env)
+
+	// changes to the env are applied to the existing StatefulSet
+	a.Spec.Controller.Env = []corev1.EnvVar{
+		{Name: "ARGOCD_K8S_CLIENT_QPS", Value: "50"},
+	}
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+
+	want = []corev1.EnvVar{
+		{Name: "ARGOCD_CONTROLLER_REPLICAS", Value: "2"},
+		{Name: "ARGOCD_K8S_CLIENT_QPS", Value: "50"},
+		{Name: "HOME", Value: "/home/argocd"},
+	}
+	assert.Equal(t, want, ss.Spec.Template.Spec.Containers[0].Env)
+}
+
+func TestReconcileArgoCD_reconcileApplicationController_withControllerSettings(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Controller.ReconciliationTimeout = "5m"
+		a.Spec.Controller.ServerSideDiff = boolPtr(true)
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, ss))
+
+	env := ss.Spec.Template.Spec.Containers[0].Env
+	assert.Contains(t, env, getArgoConfigMapKeyEnv("ARGOCD_RECONCILIATION_TIMEOUT", "timeout.reconciliation"))
+	assert.Contains(t, env, getArgoConfigMapKeyEnv("ARGOCD_APPLICATION_CONTROLLER_SERVER_SIDE_DIFF", "controller.diff.server.side"))
+}
+
+// deleteRecordingClient is a client.Client that records the propagation policy of the deletes made through it.
+type deleteRecordingClient struct {
+	client.Client
+	propagationPolicies []*metav1.DeletionPropagation
+}
+
+func (c *deleteRecordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	deleteOpts := &client.DeleteOptions{}
+	deleteOpts.ApplyOptions(opts)
+	c.propagationPolicies = append(c.propagationPolicies, deleteOpts.PropagationPolicy)
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func TestReconcileArgoCD_reconcileApplicationController_withStorage(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	deletes := &deleteRecordingClient{Client: r.Client}
+	r.Client = deletes
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+
+	ss := &appsv1.StatefulSet{}
+	key := types.NamespacedName{
+		Name:      "argocd-application-controller",
+		Namespace: a.Namespace,
+	}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+	assert.Empty(t, ss.Spec.VolumeClaimTemplates)
+
+	storageClassName := "fast"
+	size := resourcev1.MustParse("20Gi")
+	a.Spec.Controller.Storage = &argoprojv1alpha1.ArgoCDApplicationControllerStorageSpec{
+		Size:             &size,
+		StorageClassName: &storageClassName,
+	}
+
+	// the StatefulSet is deleted without its pods, and created again once it is gone
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assertNotFound(t, r.Client.Get(context.TODO(), key, ss))
+	orphan := metav1.DeletePropagationOrphan
+	assert.Equal(t, []*metav1.DeletionPropagation{&orphan}, deletes.propagationPolicies)
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events))
+	assert.Len(t, events.Items, 1)
+	assert.Equal(t, "StatefulSetRecreated", events.Items[0].Reason)
+	assert.Equal(t, "Warning", events.Items[0].Type)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+
+	assert.Len(t, ss.Spec.VolumeClaimTemplates, 1)
+	pvc := ss.Spec.VolumeClaimTemplates[0]
+	assert.Equal(t, "workdir", pvc.Name)
+	assert.Equal(t, &storageClassName, pvc.Spec.StorageClassName)
+	assert.True(t, size.Equal(pvc.Spec.Resources.Requests[corev1.ResourceStorage]))
+	assert.Contains(t, ss.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "workdir",
+		MountPath: "/tmp",
+	})
+
+	// the default size is used when no size is specified
+	a.Spec.Controller.Storage = &argoprojv1alpha1.ArgoCDApplicationControllerStorageSpec{}
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+	assert.Len(t, ss.Spec.VolumeClaimTemplates, 1)
+	assert.True(t, resourcev1.MustParse("10Gi").Equal(ss.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage]))
+
+	// removing the storage removes the volume claim templates and the mount
+	a.Spec.Controller.Storage = nil
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+	assert.Empty(t, ss.Spec.VolumeClaimTemplates)
+	assert.Equal(t, controllerDefaultVolumeMounts(), ss.Spec.Template.Spec.Containers[0].VolumeMounts)
+}
+
+func TestReconcileArgoCD_reconcileApplicationController_withMetricsPort(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Controller.Metrics.Port = 9082
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.reconcileMetricsService(a))
+
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-application-controller",
+		Namespace: a.Namespace,
+	}, ss))
+	container := ss.Spec.Template.Spec.Containers[0]
+	assert.Contains(t, strings.Join(container.Command, " "), "--metrics-port 9082")
+	assert.Equal(t, int32(9082), container.Ports[0].ContainerPort)
+	assert.Equal(t, intstr.FromInt(9082), container.ReadinessProbe.HTTPGet.Port)
+
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-metrics",
+		Namespace: a.Namespace,
+	}, svc))
+	assert.Equal(t, int32(9082), svc.Spec.Ports[0].Port)
+	assert.Equal(t, intstr.FromInt(9082), svc.Spec.Ports[0].TargetPort)
+
+	// reverting to the default port updates the existing resources
+	a.Spec.Controller.Metrics.Port = 0
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.reconcileMetricsService(a))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-application-controller",
+		Namespace: a.Namespace,
+	}, ss))
+	container = ss.Spec.Template.Spec.Containers[0]
+	assert.NotContains(t, container.Command, "--metrics-port")
+	assert.Equal(t, int32(8082), container.Ports[0].ContainerPort)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-metrics",
+		Namespace: a.Namespace,
+	}, svc))
+	assert.Equal(t, int32(8082), svc.Spec.Ports[0].Port)
+}
+
+func TestReconcileArgoCD_reconcileApplicationController_withDynamicSharding(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	makeClusterSecret := func(name string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+				Labels: map[string]string{
+					common.ArgoCDSecretTypeLabel: "cluster",
 				},
 			},
-		},
+		}
 	}
-	ss2 := &appsv1.StatefulSet{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "argocd-sample-server",
-			Namespace: testNamespace,
+
+	tests := []struct {
+		name     string
+		sharding argoprojv1alpha1.ArgoCDApplicationControllerShardSpec
+		clusters int
+		replicas int32
+	}{
+		{
+			name: "replicas derived from the number of clusters",
+			sharding: argoprojv1alpha1.ArgoCDApplicationControllerShardSpec{
+				DynamicScalingEnabled: boolPtr(true),
+				MinShards:             1,
+				MaxShards:             5,
+				ClustersPerShard:      2,
+			},
+			clusters: 5,
+			replicas: 3,
 		},
-		Spec: appsv1.StatefulSetSpec{
-			Template: corev1.PodTemplateSpec{
-				Spec: corev1.PodSpec{
-					NodeSelector: map[string]string{
-						"test_key1": "test_value1",
-					},
-					Tolerations: []corev1.Toleration{
-						{
-							Key:    "test_key1",
-							Value:  "test_value1",
-							Effect: corev1.TaintEffectNoExecute,
-						},
-					},
-				},
+		{
+			name: "replicas bounded by the maximum number of shards",
+			sharding: argoprojv1alpha1.ArgoCDApplicationControllerShardSpec{
+				DynamicScalingEnabled: boolPtr(true),
+				MinShards:             1,
+				MaxShards:             2,
+				ClustersPerShard:      1,
+			},
+			clusters: 5,
+			replicas: 2,
+		},
+		{
+			name: "replicas bounded by the minimum number of shards",
+			sharding: argoprojv1alpha1.ArgoCDApplicationControllerShardSpec{
+				DynamicScalingEnabled: boolPtr(true),
+				MinShards:             2,
+				MaxShards:             4,
+				ClustersPerShard:      3,
+			},
+			clusters: 1,
+			replicas: 2,
+		},
+		{
+			name: "invalid bounds fall back to defaults",
+			sharding: argoprojv1alpha1.ArgoCDApplicationControllerShardSpec{
+				DynamicScalingEnabled: boolPtr(true),
+			},
+			clusters: 4,
+			replicas: 1,
+		},
+		{
+			name: "static replicas ignored when dynamic scaling is enabled",
+			sharding: argoprojv1alpha1.ArgoCDApplicationControllerShardSpec{
+				Enabled:               true,
+				Replicas:              5,
+				DynamicScalingEnabled: boolPtr(true),
+				MinShards:             1,
+				MaxShards:             3,
 			},
+			clusters: 2,
+			replicas: 2,
 		},
 	}
-	expectedChange := false
-	actualChange := false
-	updateNodePlacementStateful(ss, ss, &actualChange)
-	if actualChange != expectedChange {
-		t.Fatalf("updateNodePlacement failed, value of changed: %t", actualChange)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+				a.Spec.Controller.Sharding = test.sharding
+			})
+			objs := []runtime.Object{a}
+			for i := 0; i < test.clusters; i++ {
+				objs = append(objs, makeClusterSecret(fmt.Sprintf("cluster-%d", i)))
+			}
+			r := makeTestReconciler(t, objs...)
+
+			assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+
+			ss := &appsv1.StatefulSet{}
+			assert.NoError(t, r.Client.Get(
+				context.TODO(),
+				types.NamespacedName{
+					Name:      "argocd-application-controller",
+					Namespace: a.Namespace,
+				},
+				ss))
+
+			assert.Equal(t, test.replicas, *ss.Spec.Replicas)
+			assert.Contains(t, ss.Spec.Template.Spec.Containers[0].Env,
+				corev1.EnvVar{Name: "ARGOCD_CONTROLLER_REPLICAS", Value: fmt.Sprint(test.replicas)})
+		})
 	}
-	updateNodePlacementStateful(ss, ss2, &actualChange)
-	if actualChange == expectedChange {
-		t.Fatalf("updateNodePlacement failed, value of changed: %t", actualChange)
+}
+
+func Test_UpdateNodePlacementStateful(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	ss := newStatefulSetWithSuffix("application-controller", "application-controller", a)
+	ss.Spec.Template.Spec.NodeSelector = map[string]string{
+		"test_key1": "test_value1",
+		"test_key2": "test_value2",
+	}
+	ss.Spec.Template.Spec.Tolerations = []corev1.Toleration{
+		{
+			Key:    "test_key1",
+			Value:  "test_value1",
+			Effect: corev1.TaintEffectNoSchedule,
+		},
+	}
+	assert.NoError(t, r.applyResource(a, ss.DeepCopy()))
+	existing := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ss.Name, Namespace: a.Namespace}, existing))
+	resourceVersion := existing.ResourceVersion
+
+	// an unchanged node placement is not applied again
+	assert.NoError(t, r.applyResource(a, ss.DeepCopy()))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ss.Name, Namespace: a.Namespace}, existing))
+	assert.Equal(t, resourceVersion, existing.ResourceVersion)
+
+	// a changed node placement is applied, and the node selectors no longer set are removed
+	ss.Spec.Template.Spec.NodeSelector = map[string]string{
+		"test_key1": "test_value1",
+	}
+	ss.Spec.Template.Spec.Tolerations = []corev1.Toleration{
+		{
+			Key:    "test_key1",
+			Value:  "test_value1",
+			Effect: corev1.TaintEffectNoExecute,
+		},
 	}
+	assert.NoError(t, r.applyResource(a, ss.DeepCopy()))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ss.Name, Namespace: a.Namespace}, existing))
+	assert.NotEqual(t, resourceVersion, existing.ResourceVersion)
+	assert.Equal(t, ss.Spec.Template.Spec.NodeSelector, existing.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, ss.Spec.Template.Spec.Tolerations, existing.Spec.Template.Spec.Tolerations)
 }
 
 func Test_ContainsValidImage(t *testing.T) {
@@ -435,3 +835,53 @@ func Test_ContainsValidImage(t *testing.T) {
 	}
 
 }
+
+func TestReconcileArgoCD_reconcileApplicationControllerStatefulSet_execProviderTools(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.ExecProviderTools = []argoprojv1alpha1.ArgoCDExecProviderToolSpec{{
+			Name:  "kubelogin",
+			Image: "quay.io/example/kubelogin:v0.1.0",
+			Path:  "/usr/local/bin/kubelogin",
+		}}
+		a.Spec.InitialClusters = []argoprojv1alpha1.ArgoCDClusterSpec{{
+			Name:   "aks",
+			Server: "https://aks.example.com",
+			ExecProvider: &argoprojv1alpha1.ArgoCDClusterExecProviderSpec{
+				Provider: "azure",
+				Command:  "kubelogin",
+				Args:     []string{"get-token", "--login", "workloadidentity"},
+			},
+		}}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, ss))
+	assert.Equal(t, "true", ss.Spec.Template.Labels[common.ArgoCDKeyAzureWorkloadIdentity])
+	initContainers := ss.Spec.Template.Spec.InitContainers
+	assert.Len(t, initContainers, 1)
+	assert.Equal(t, "exec-provider-kubelogin", initContainers[0].Name)
+	assert.Equal(t, []string{"cp", "/usr/local/bin/kubelogin", "/exec-provider-tools/kubelogin"}, initContainers[0].Command)
+	assert.Contains(t, ss.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "exec-provider-tools",
+		MountPath: "/usr/local/bin/kubelogin",
+		SubPath:   "kubelogin",
+	})
+	assert.Contains(t, ss.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "exec-provider-tools",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+
+	// removing the tools and the cluster should remove the init container and the workload identity label
+	a.Spec.ExecProviderTools = nil
+	a.Spec.InitialClusters = nil
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ss.Name, Namespace: a.Namespace}, ss))
+	assert.Empty(t, ss.Spec.Template.Spec.InitContainers)
+	assert.NotContains(t, ss.Spec.Template.Labels, common.ArgoCDKeyAzureWorkloadIdentity)
+}
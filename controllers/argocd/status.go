@@ -16,19 +16,42 @@ package argocd
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
+// deploymentProgressDeadlineExceeded is the reason of the Progressing condition of a Deployment whose rollout did not
+// make progress within its deadline.
+const deploymentProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+// patchStatus will persist the changes made to the Status of the given ArgoCD during a reconciliation, compared to the
+// given status it was read with, in a single patch. The patch is rejected with a conflict when the ArgoCD was changed in
+// the meantime, e.g. by another replica of the operator, so that the status is never computed from a stale object.
+func (r *ReconcileArgoCD) patchStatus(cr *argoprojv1a1.ArgoCD, original *argoprojv1a1.ArgoCDStatus) error {
+	if reflect.DeepEqual(*original, cr.Status) {
+		return nil
+	}
+	base := cr.DeepCopy()
+	base.Status = *original
+	return r.Client.Status().Patch(context.TODO(), cr, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{}))
+}
+
 // reconcileStatus will ensure that all of the Status properties are updated for the given ArgoCD.
+// The changes are only made in memory, and persisted by patchStatus at the end of the reconciliation.
 func (r *ReconcileArgoCD) reconcileStatus(cr *argoprojv1a1.ArgoCD) error {
 	if err := r.reconcileStatusApplicationController(cr); err != nil {
 		return err
@@ -38,14 +61,18 @@ func (r *ReconcileArgoCD) reconcileStatus(cr *argoprojv1a1.ArgoCD) error {
 		return err
 	}
 
-	if err := r.reconcileStatusDex(cr); err != nil {
-		log.Error(err, "error reconciling dex status")
+	if err := r.reconcileStatusResourceTrackingMethod(cr); err != nil {
+		return err
 	}
 
-	if err := r.reconcileStatusPhase(cr); err != nil {
+	if err := r.reconcileStatusManagedNamespaces(cr); err != nil {
 		return err
 	}
 
+	if err := r.reconcileStatusDex(cr); err != nil {
+		log.Error(err, "error reconciling dex status")
+	}
+
 	if err := r.reconcileStatusRedis(cr); err != nil {
 		return err
 	}
@@ -70,28 +97,74 @@ func (r *ReconcileArgoCD) reconcileStatus(cr *argoprojv1a1.ArgoCD) error {
 		return err
 	}
 
+	if err := r.reconcileStatusCertificates(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusReconcilePaused(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusUpgrade(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusVersionSkew(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusResourceQuota(cr); err != nil {
+		return err
+	}
+
+	// The phase aggregates the status of the components and the server host, so it is reconciled last.
+	if err := r.reconcileStatusPhase(cr); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// getReplicasStatus returns the status of a component from the desired and ready replicas of its workload.
+func getReplicasStatus(replicas *int32, readyReplicas int32) string {
+	if replicas != nil && readyReplicas == *replicas {
+		return "Running"
+	}
+	return "Pending"
+}
+
+// getDeploymentStatus returns the status of the component that runs in the given Deployment. The component has failed
+// if the rollout of the Deployment exceeded its progress deadline.
+func getDeploymentStatus(deploy *appsv1.Deployment) string {
+	for _, condition := range deploy.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Status == corev1.ConditionFalse &&
+			condition.Reason == deploymentProgressDeadlineExceeded {
+			return "Failed"
+		}
+	}
+	return getReplicasStatus(deploy.Spec.Replicas, deploy.Status.ReadyReplicas)
+}
+
+// getStatefulSetStatus returns the status of the component that runs in the given StatefulSet.
+func getStatefulSetStatus(ss *appsv1.StatefulSet) string {
+	return getReplicasStatus(ss.Spec.Replicas, ss.Status.ReadyReplicas)
+}
+
 // reconcileStatusApplicationController will ensure that the ApplicationController Status is updated for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatusApplicationController(cr *argoprojv1a1.ArgoCD) error {
 	status := "Unknown"
 
 	ss := newStatefulSetWithSuffix("application-controller", "application-controller", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, ss.Name, ss) {
-		status = "Pending"
-
-		if ss.Spec.Replicas != nil {
-			if ss.Status.ReadyReplicas == *ss.Spec.Replicas {
-				status = "Running"
-			}
-		}
+		status = getStatefulSetStatus(ss)
 	}
 
-	if cr.Status.ApplicationController != status {
-		cr.Status.ApplicationController = status
-		return r.Client.Status().Update(context.TODO(), cr)
+	// The status of a disabled component is not reported
+	if !cr.Spec.Controller.IsEnabled() {
+		status = ""
 	}
+
+	cr.Status.ApplicationController = status
 	return nil
 }
 
@@ -101,19 +174,10 @@ func (r *ReconcileArgoCD) reconcileStatusDex(cr *argoprojv1a1.ArgoCD) error {
 
 	deploy := newDeploymentWithSuffix("dex-server", "dex-server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
-		status = "Pending"
-
-		if deploy.Spec.Replicas != nil {
-			if deploy.Status.ReadyReplicas == *deploy.Spec.Replicas {
-				status = "Running"
-			}
-		}
+		status = getDeploymentStatus(deploy)
 	}
 
-	if cr.Status.Dex != status {
-		cr.Status.Dex = status
-		return r.Client.Status().Update(context.TODO(), cr)
-	}
+	cr.Status.Dex = status
 	return nil
 }
 
@@ -123,49 +187,234 @@ func (r *ReconcileArgoCD) reconcileStatusApplicationSetController(cr *argoprojv1
 
 	deploy := newDeploymentWithSuffix("applicationset-controller", "controller", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
-		status = "Pending"
+		status = getDeploymentStatus(deploy)
+	}
 
-		if deploy.Spec.Replicas != nil {
-			if deploy.Status.ReadyReplicas == *deploy.Spec.Replicas {
-				status = "Running"
-			}
+	cr.Status.ApplicationSetController = status
+	return nil
+}
+
+// reconcileStatusSSOConfig will ensure that the SSOConfig status is updated for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusSSOConfig(cr *argoprojv1a1.ArgoCD) error {
+
+	// set status to track ssoConfigLegalStatus so it is always up to date with latest ssoConfig situation, the status
+	// is kept if the SSO configuration was not validated in this reconciliation
+	if r.ssoConfigLegalStatus == "" {
+		return nil
+	}
+
+	cr.Status.SSOConfig = r.ssoConfigLegalStatus
+	return nil
+}
+
+// reconcileStatusRBACConfig will ensure that the RBACConfig status is updated for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusRBACConfig(cr *argoprojv1a1.ArgoCD, validPolicy bool) error {
+	status := "Success"
+	if !validPolicy {
+		status = "Failed"
+	}
+
+	cr.Status.RBACConfig = status
+	return nil
+}
+
+// setConditionWithEvent will set the given condition on the status of the given ArgoCD, unless it is already set. A
+// warning event with the given action is emitted when the condition becomes true or its message changes.
+func (r *ReconcileArgoCD) setConditionWithEvent(cr *argoprojv1a1.ArgoCD, condition metav1.Condition, action string) {
+	existing := meta.FindStatusCondition(cr.Status.Conditions, condition.Type)
+	if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason &&
+		existing.Message == condition.Message && existing.ObservedGeneration == condition.ObservedGeneration {
+		return
+	}
+
+	if condition.Status == metav1.ConditionTrue && (existing == nil || existing.Message != condition.Message) {
+		if err := argoutil.CreateEvent(r.Client, "Warning", action, condition.Message, condition.Type, cr.ObjectMeta, cr.TypeMeta); err != nil {
+			log.Error(err, fmt.Sprintf("failed to create event for condition %s", condition.Type))
 		}
 	}
 
-	if cr.Status.ApplicationSetController != status {
-		cr.Status.ApplicationSetController = status
-		return r.Client.Status().Update(context.TODO(), cr)
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+}
+
+// reconcileStatusExtraConfigConflicts will ensure that the ExtraConfigConflict condition of the given ArgoCD reports
+// the given ExtraConfig keys that override values managed through first-class fields. The condition is only maintained
+// once ExtraConfig has been used.
+func (r *ReconcileArgoCD) reconcileStatusExtraConfigConflicts(cr *argoprojv1a1.ArgoCD, conflicts []string) error {
+	if len(cr.Spec.ExtraConfig) == 0 && meta.FindStatusCondition(cr.Status.Conditions, argoprojv1a1.ArgoCDConditionTypeExtraConfigConflict) == nil {
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:    argoprojv1a1.ArgoCDConditionTypeExtraConfigConflict,
+		Status:  metav1.ConditionFalse,
+		Reason:  "NoConflicts",
+		Message: "extraConfig does not override any field managed by the ArgoCD spec",
+	}
+	if len(conflicts) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ConflictingKeys"
+		condition.Message = fmt.Sprintf("extraConfig overrides the values of keys managed by the ArgoCD spec: %s", strings.Join(conflicts, ", "))
+	}
+	condition.ObservedGeneration = cr.Generation
+
+	r.setConditionWithEvent(cr, condition, "Conflict")
+	return nil
+}
+
+// reconcileStatusReconcilePaused will ensure that the ReconcilePaused condition reports whether the reconciliation of
+// the resources of the given ArgoCD is paused. An event is emitted when the reconciliation is paused or resumed. The
+// condition is only maintained once the reconciliation has been paused.
+func (r *ReconcileArgoCD) reconcileStatusReconcilePaused(cr *argoprojv1a1.ArgoCD) error {
+	paused := isReconcilePaused(cr)
+	existing := meta.FindStatusCondition(cr.Status.Conditions, argoprojv1a1.ArgoCDConditionTypeReconcilePaused)
+	if !paused && existing == nil {
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:    argoprojv1a1.ArgoCDConditionTypeReconcilePaused,
+		Status:  metav1.ConditionFalse,
+		Reason:  "Reconciling",
+		Message: "the resources of the ArgoCD are reconciled",
+	}
+	eventType, reason := "Normal", "ReconcileResumed"
+	if paused {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Paused"
+		condition.Message = fmt.Sprintf("the reconciliation of the resources of the ArgoCD is paused through the %s annotation", common.AnnotationReconcile)
+		eventType, reason = "Warning", "ReconcilePaused"
 	}
+
+	if existing != nil && existing.Status == condition.Status {
+		return nil
+	}
+
+	if err := argoutil.CreateEvent(r.Client, eventType, condition.Reason, condition.Message, reason, cr.ObjectMeta, cr.TypeMeta); err != nil {
+		log.Error(err, "failed to create event for paused reconciliation")
+	}
+
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
 	return nil
 }
 
-// reconcileStatusSSOConfig will ensure that the SSOConfig status is updated for the given ArgoCD.
-func (r *ReconcileArgoCD) reconcileStatusSSOConfig(cr *argoprojv1a1.ArgoCD) error {
+// reconcileStatusManagedNamespaces will ensure that the ManagedNamespaces status lists the namespaces currently managed
+// by the given ArgoCD, and that the RejectedNamespaces status lists the labelled namespaces the ArgoCD is not allowed to
+// manage. An event is emitted for each namespace that starts or stops being managed, or is rejected.
+func (r *ReconcileArgoCD) reconcileStatusManagedNamespaces(cr *argoprojv1a1.ArgoCD) error {
+	var namespaces []string
+	if r.ManagedNamespaces != nil {
+		for _, namespace := range r.ManagedNamespaces.Items {
+			if namespace.Name != cr.Namespace {
+				namespaces = append(namespaces, namespace.Name)
+			}
+		}
+	}
+	sort.Strings(namespaces)
 
-	// set status to track ssoConfigLegalStatus so it is always up to date with latest ssoConfig situation
-	status := ssoConfigLegalStatus
+	labelled := &corev1.NamespaceList{}
+	if err := r.Client.List(context.TODO(), labelled, client.MatchingLabels{common.ArgoCDManagedByLabel: cr.Namespace}); err != nil {
+		return err
+	}
+	var rejected []string
+	for _, namespace := range labelled.Items {
+		if !isManagedNamespaceAllowed(cr.Namespace, namespace.Name) || !r.Options.isNamespaceWatched(namespace.Name) {
+			rejected = append(rejected, namespace.Name)
+		}
+	}
+	sort.Strings(rejected)
 
-	if cr.Status.SSOConfig != status {
-		cr.Status.SSOConfig = status
-		return r.Client.Status().Update(context.TODO(), cr)
+	if reflect.DeepEqual(cr.Status.ManagedNamespaces, namespaces) && reflect.DeepEqual(cr.Status.RejectedNamespaces, rejected) {
+		return nil
+	}
+
+	for _, ns := range rejected {
+		if !containsString(cr.Status.RejectedNamespaces, ns) {
+			message := fmt.Sprintf("namespace %s is labelled as managed by Argo CD instance %s, but is not allowed to be managed by it", ns, cr.Name)
+			if err := argoutil.CreateEvent(r.Client, "Warning", "Rejected", message, "ManagedNamespaceRejected", cr.ObjectMeta, cr.TypeMeta); err != nil {
+				log.Error(err, "failed to create event")
+			}
+		}
+	}
+
+	for _, ns := range namespaces {
+		if !containsString(cr.Status.ManagedNamespaces, ns) {
+			message := fmt.Sprintf("namespace %s is now managed by Argo CD instance %s", ns, cr.Name)
+			if err := argoutil.CreateEvent(r.Client, "Normal", "Added", message, "ManagedNamespaceAdded", cr.ObjectMeta, cr.TypeMeta); err != nil {
+				log.Error(err, "failed to create event")
+			}
+		}
 	}
+	for _, ns := range cr.Status.ManagedNamespaces {
+		if !containsString(namespaces, ns) {
+			message := fmt.Sprintf("namespace %s is no longer managed by Argo CD instance %s", ns, cr.Name)
+			if err := argoutil.CreateEvent(r.Client, "Normal", "Removed", message, "ManagedNamespaceRemoved", cr.ObjectMeta, cr.TypeMeta); err != nil {
+				log.Error(err, "failed to create event")
+			}
+		}
+	}
+
+	cr.Status.ManagedNamespaces = namespaces
+	cr.Status.RejectedNamespaces = rejected
+	return nil
+}
+
+// reconcileStatusResourceTrackingMethod will ensure that the ResourceTrackingMethod status is updated for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusResourceTrackingMethod(cr *argoprojv1a1.ArgoCD) error {
+	rtm := argoprojv1a1.ParseResourceTrackingMethod(cr.Spec.ResourceTrackingMethod)
+	status := rtm.String()
+
+	cr.Status.ResourceTrackingMethod = status
 	return nil
 }
 
-// reconcileStatusPhase will ensure that the Status Phase is updated for the given ArgoCD.
+// reconcileStatusPhase will ensure that the Status Phase is updated for the given ArgoCD. The phase is Available once
+// the required components are running and the server host is available, Failed if any deployed component has failed
+// and Pending otherwise.
 func (r *ReconcileArgoCD) reconcileStatusPhase(cr *argoprojv1a1.ArgoCD) error {
-	var phase string
+	_, hostAvailable, err := r.getServerHostStatus(cr)
+	if err != nil {
+		return err
+	}
 
-	if cr.Status.ApplicationController == "Running" && cr.Status.Redis == "Running" && cr.Status.Repo == "Running" && cr.Status.Server == "Running" {
-		phase = "Available"
-	} else {
+	phase := "Available"
+	if !hostAvailable {
 		phase = "Pending"
 	}
 
-	if cr.Status.Phase != phase {
-		cr.Status.Phase = phase
-		return r.Client.Status().Update(context.TODO(), cr)
+	// Disabled and remote components are not required.
+	required := []string{}
+	if cr.Spec.Controller.IsEnabled() {
+		required = append(required, cr.Status.ApplicationController)
+	}
+	if isRedisDeployed(cr) {
+		required = append(required, cr.Status.Redis)
+	}
+	if isRepoServerDeployed(cr) {
+		required = append(required, cr.Status.Repo)
 	}
+	if cr.Spec.Server.IsEnabled() {
+		required = append(required, cr.Status.Server)
+	}
+	for _, status := range required {
+		if status != "Running" {
+			phase = "Pending"
+		}
+	}
+
+	// Optional components are only taken into account once they are deployed.
+	optional := []string{cr.Status.ApplicationSetController, cr.Status.Dex, cr.Status.NotificationsController}
+	for _, status := range optional {
+		if status == "Pending" {
+			phase = "Pending"
+		}
+	}
+	for _, status := range append(required, optional...) {
+		if status == "Failed" {
+			phase = "Failed"
+		}
+	}
+
+	cr.Status.Phase = phase
 	return nil
 }
 
@@ -176,30 +425,27 @@ func (r *ReconcileArgoCD) reconcileStatusRedis(cr *argoprojv1a1.ArgoCD) error {
 	if !cr.Spec.HA.Enabled {
 		deploy := newDeploymentWithSuffix("redis", "redis", cr)
 		if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
-			status = "Pending"
-
-			if deploy.Spec.Replicas != nil {
-				if deploy.Status.ReadyReplicas == *deploy.Spec.Replicas {
-					status = "Running"
-				}
-			}
+			status = getDeploymentStatus(deploy)
 		}
 	} else {
 		ss := newStatefulSetWithSuffix("redis-ha-server", "redis-ha-server", cr)
 		if argoutil.IsObjectFound(r.Client, cr.Namespace, ss.Name, ss) {
-			status = "Pending"
+			status = getStatefulSetStatus(ss)
 
-			if ss.Status.ReadyReplicas == *ss.Spec.Replicas {
-				status = "Running"
+			// Redis is only available once the HA proxy in front of it is available as well
+			deploy := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
+			if status == "Running" && argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
+				status = getDeploymentStatus(deploy)
 			}
 		}
-		// TODO: Add check for HA proxy deployment here as well?
 	}
 
-	if cr.Status.Redis != status {
-		cr.Status.Redis = status
-		return r.Client.Status().Update(context.TODO(), cr)
+	// The status of a disabled or remote component is not reported
+	if !isRedisDeployed(cr) {
+		status = ""
 	}
+
+	cr.Status.Redis = status
 	return nil
 }
 
@@ -209,19 +455,15 @@ func (r *ReconcileArgoCD) reconcileStatusRepo(cr *argoprojv1a1.ArgoCD) error {
 
 	deploy := newDeploymentWithSuffix("repo-server", "repo-server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
-		status = "Pending"
-
-		if deploy.Spec.Replicas != nil {
-			if deploy.Status.ReadyReplicas == *deploy.Spec.Replicas {
-				status = "Running"
-			}
-		}
+		status = getDeploymentStatus(deploy)
 	}
 
-	if cr.Status.Repo != status {
-		cr.Status.Repo = status
-		return r.Client.Status().Update(context.TODO(), cr)
+	// The status of a disabled or remote component is not reported
+	if !isRepoServerDeployed(cr) {
+		status = ""
 	}
+
+	cr.Status.Repo = status
 	return nil
 }
 
@@ -231,20 +473,15 @@ func (r *ReconcileArgoCD) reconcileStatusServer(cr *argoprojv1a1.ArgoCD) error {
 
 	deploy := newDeploymentWithSuffix("server", "server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
-		status = "Pending"
-
-		// TODO: Refactor these checks.
-		if deploy.Spec.Replicas != nil {
-			if deploy.Status.ReadyReplicas == *deploy.Spec.Replicas {
-				status = "Running"
-			}
-		}
+		status = getDeploymentStatus(deploy)
 	}
 
-	if cr.Status.Server != status {
-		cr.Status.Server = status
-		return r.Client.Status().Update(context.TODO(), cr)
+	// The status of a disabled component is not reported
+	if !cr.Spec.Server.IsEnabled() {
+		status = ""
 	}
+
+	cr.Status.Server = status
 	return nil
 }
 
@@ -254,30 +491,32 @@ func (r *ReconcileArgoCD) reconcileStatusNotifications(cr *argoprojv1a1.ArgoCD)
 
 	deploy := newDeploymentWithSuffix("notifications-controller", "controller", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
-		status = "Pending"
-
-		if deploy.Spec.Replicas != nil {
-			if deploy.Status.ReadyReplicas == *deploy.Spec.Replicas {
-				status = "Running"
-			}
-		}
+		status = getDeploymentStatus(deploy)
 	}
 
-	if cr.Status.NotificationsController != status {
-		if !cr.Spec.Notifications.Enabled {
-			cr.Status.NotificationsController = ""
-		} else {
-			cr.Status.NotificationsController = status
-		}
-		return r.Client.Status().Update(context.TODO(), cr)
+	if !cr.Spec.Notifications.Enabled {
+		status = ""
 	}
+	cr.Status.NotificationsController = status
 	return nil
 }
 
 // reconcileStatusHost will ensure that the host status is updated for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatusHost(cr *argoprojv1a1.ArgoCD) error {
-	cr.Status.Host = ""
-	cr.Status.Phase = "Available"
+	host, _, err := r.getServerHostStatus(cr)
+	if err != nil {
+		return err
+	}
+
+	cr.Status.Host = host
+	return nil
+}
+
+// getServerHostStatus returns the host the server of the given ArgoCD is exposed at, and whether the host is
+// available. A host that was requested but not yet created is not waited for.
+func (r *ReconcileArgoCD) getServerHostStatus(cr *argoprojv1a1.ArgoCD) (string, bool, error) {
+	host := ""
+	available := true
 
 	if (cr.Spec.Server.Route.Enabled || cr.Spec.Server.Ingress.Enabled) && IsRouteAPIAvailable() {
 		route := newRouteWithSuffix("server", cr)
@@ -297,36 +536,36 @@ func (r *ReconcileArgoCD) reconcileStatusHost(cr *argoprojv1a1.ArgoCD) error {
 		}
 
 		if err := r.Client.List(context.TODO(), routeList, opts); err != nil {
-			return err
+			return "", false, err
 		}
 
 		if len(routeList.Items) == 0 {
 			log.Info("argocd-server route requested but not found on cluster")
-			return nil
+			return host, available, nil
 		} else {
 			route = &routeList.Items[0]
 			// status.ingress not available
 			if route.Status.Ingress == nil {
-				cr.Status.Host = ""
-				cr.Status.Phase = "Pending"
+				host = ""
+				available = false
 			} else {
 				// conditions exist and type is RouteAdmitted
 				if len(route.Status.Ingress[0].Conditions) > 0 && route.Status.Ingress[0].Conditions[0].Type == routev1.RouteAdmitted {
 					if route.Status.Ingress[0].Conditions[0].Status == corev1.ConditionTrue {
-						cr.Status.Host = route.Status.Ingress[0].Host
-						cr.Status.Phase = "Available"
+						host = route.Status.Ingress[0].Host
+						available = true
 					} else {
-						cr.Status.Host = ""
-						cr.Status.Phase = "Pending"
+						host = ""
+						available = false
 					}
 				} else {
 					// no conditions are available
 					if route.Status.Ingress[0].Host != "" {
-						cr.Status.Host = route.Status.Ingress[0].Host
-						cr.Status.Phase = "Available"
+						host = route.Status.Ingress[0].Host
+						available = true
 					} else {
-						cr.Status.Host = "Unavailable"
-						cr.Status.Phase = "Pending"
+						host = "Unavailable"
+						available = false
 					}
 				}
 			}
@@ -335,7 +574,7 @@ func (r *ReconcileArgoCD) reconcileStatusHost(cr *argoprojv1a1.ArgoCD) error {
 		ingress := newIngressWithSuffix("server", cr)
 		if !argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
 			log.Info("argocd-server ingress requested but not found on cluster")
-			return nil
+			return host, available, nil
 		} else {
 			if !reflect.DeepEqual(ingress.Status.LoadBalancer, corev1.LoadBalancerStatus{}) && len(ingress.Status.LoadBalancer.Ingress) > 0 {
 				var s []string
@@ -350,10 +589,10 @@ func (r *ReconcileArgoCD) reconcileStatusHost(cr *argoprojv1a1.ArgoCD) error {
 					}
 				}
 				hosts = strings.Join(s, ", ")
-				cr.Status.Host = hosts
-				cr.Status.Phase = "Available"
+				host = hosts
+				available = true
 			}
 		}
 	}
-	return r.Client.Status().Update(context.TODO(), cr)
+	return host, available, nil
 }
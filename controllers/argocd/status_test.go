@@ -10,11 +10,16 @@ import (
 
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func TestReconcileArgoCD_reconcileStatusSSOConfig(t *testing.T) {
@@ -113,7 +118,7 @@ func TestReconcileArgoCD_reconcileStatusHost(t *testing.T) {
 		expectedNil       bool
 		expectedHost      bool
 		host              string
-		phase             string
+		available         bool
 	}{
 		{
 			name:              "",
@@ -122,7 +127,7 @@ func TestReconcileArgoCD_reconcileStatusHost(t *testing.T) {
 			ingressEnabled:    false,
 			expectedNil:       false,
 			host:              "argocd",
-			phase:             "Available",
+			available:         true,
 		},
 		{
 			name:              "",
@@ -131,7 +136,7 @@ func TestReconcileArgoCD_reconcileStatusHost(t *testing.T) {
 			ingressEnabled:    true,
 			expectedNil:       false,
 			host:              "argocd, 12.0.0.5",
-			phase:             "Available",
+			available:         true,
 		},
 	}
 	for _, test := range tests {
@@ -211,7 +216,9 @@ func TestReconcileArgoCD_reconcileStatusHost(t *testing.T) {
 			assert.NoError(t, err)
 
 			assert.Equal(t, test.host, a.Status.Host)
-			assert.Equal(t, test.phase, a.Status.Phase)
+			_, available, err := r.getServerHostStatus(a)
+			assert.NoError(t, err)
+			assert.Equal(t, test.available, available)
 		})
 	}
 }
@@ -248,3 +255,171 @@ func TestReconcileArgoCD_reconcileStatusApplicationSetController(t *testing.T) {
 	assert.NoError(t, r.reconcileStatusApplicationSetController(a))
 	assert.Equal(t, "Pending", a.Status.ApplicationSetController)
 }
+
+func TestReconcileArgoCD_reconcileStatusResourceTrackingMethod(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileStatusResourceTrackingMethod(a))
+	assert.Equal(t, "label", a.Status.ResourceTrackingMethod)
+
+	a.Spec.ResourceTrackingMethod = "annotation+label"
+	assert.NoError(t, r.reconcileStatusResourceTrackingMethod(a))
+	assert.Equal(t, "annotation+label", a.Status.ResourceTrackingMethod)
+
+	// invalid tracking methods fall back to label
+	a.Spec.ResourceTrackingMethod = "invalid"
+	assert.NoError(t, r.reconcileStatusResourceTrackingMethod(a))
+	assert.Equal(t, "label", a.Status.ResourceTrackingMethod)
+}
+
+func TestReconcileArgoCD_reconcileStatusManagedNamespaces(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	assert.NoError(t, createNamespace(r, "managed-b", a.Namespace))
+	assert.NoError(t, createNamespace(r, "managed-a", a.Namespace))
+
+	assert.NoError(t, r.reconcileStatusManagedNamespaces(a))
+	assert.Equal(t, []string{"managed-a", "managed-b"}, a.Status.ManagedNamespaces)
+
+	// releasing a namespace removes it from the status
+	r.ManagedNamespaces.Items = r.ManagedNamespaces.Items[:2]
+	assert.NoError(t, r.reconcileStatusManagedNamespaces(a))
+	assert.Equal(t, []string{"managed-b"}, a.Status.ManagedNamespaces)
+
+	// an event is emitted for every namespace that was added or removed
+	events := &v1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events))
+	reasons := []string{}
+	for _, event := range events.Items {
+		reasons = append(reasons, event.Reason)
+	}
+	assert.ElementsMatch(t, []string{"ManagedNamespaceAdded", "ManagedNamespaceAdded", "ManagedNamespaceRemoved"}, reasons)
+}
+
+func TestReconcileArgoCD_reconcileStatusPhase(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	controller := newStatefulSetWithSuffix("application-controller", "application-controller", a)
+	controller.Spec.Replicas = int32Ptr(1)
+	var objs []runtime.Object
+	var deployments []*appsv1.Deployment
+	for _, suffix := range []string{"redis", "repo-server", "server"} {
+		deploy := newDeploymentWithSuffix(suffix, suffix, a)
+		deploy.Spec.Replicas = int32Ptr(1)
+		deployments = append(deployments, deploy)
+		objs = append(objs, deploy)
+	}
+	r := makeTestReconciler(t, append(objs, a, controller)...)
+
+	assert.NoError(t, r.reconcileStatus(a))
+	assert.Equal(t, "Pending", a.Status.Server)
+	assert.Equal(t, "Pending", a.Status.Phase)
+
+	// the phase becomes available once the workloads of all required components are ready
+	controller.Status.ReadyReplicas = 1
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), controller))
+	for _, deploy := range deployments {
+		deploy.Status.ReadyReplicas = 1
+		assert.NoError(t, r.Client.Status().Update(context.TODO(), deploy))
+	}
+	assert.NoError(t, r.reconcileStatus(a))
+	assert.Equal(t, "Running", a.Status.Server)
+	assert.Equal(t, "Available", a.Status.Phase)
+
+	// a rollout that exceeded its progress deadline fails the component
+	server := deployments[2]
+	server.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type:   appsv1.DeploymentProgressing,
+		Status: v1.ConditionFalse,
+		Reason: "ProgressDeadlineExceeded",
+	}}
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), server))
+	assert.NoError(t, r.reconcileStatus(a))
+	assert.Equal(t, "Failed", a.Status.Server)
+	assert.Equal(t, "Failed", a.Status.Phase)
+}
+
+// statusRecordingClient is a client.Client that counts the writes to the status of objects sent through it.
+type statusRecordingClient struct {
+	client.Client
+	updates int
+	patches int
+}
+
+func (c *statusRecordingClient) Status() client.StatusWriter {
+	return &statusRecordingWriter{StatusWriter: c.Client.Status(), client: c}
+}
+
+type statusRecordingWriter struct {
+	client.StatusWriter
+	client *statusRecordingClient
+}
+
+func (w *statusRecordingWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	w.client.updates++
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+func (w *statusRecordingWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	w.client.patches++
+	return w.StatusWriter.Patch(ctx, obj, patch, opts...)
+}
+
+func TestReconcileArgoCD_Reconcile_patchesStatusOnce(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	c := &statusRecordingClient{Client: r.Client}
+	r.Client = c
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0, c.updates)
+	assert.Equal(t, 1, c.patches)
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	assert.Equal(t, "Pending", a.Status.Phase)
+	assert.Equal(t, "Success", a.Status.RBACConfig)
+}
+
+func TestReconcileArgoCD_patchStatus(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	c := &statusRecordingClient{Client: r.Client}
+	r.Client = c
+
+	key := types.NamespacedName{Name: a.Name, Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, a))
+	original := a.Status.DeepCopy()
+
+	// an unchanged status is not patched
+	assert.NoError(t, r.patchStatus(a, original))
+	assert.Equal(t, 0, c.patches)
+
+	a.Status.Phase = "Available"
+	a.Status.Server = "Running"
+	assert.NoError(t, r.patchStatus(a, original))
+	assert.Equal(t, 1, c.patches)
+
+	persisted := &argoprojv1alpha1.ArgoCD{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, persisted))
+	assert.Equal(t, "Available", persisted.Status.Phase)
+	assert.Equal(t, "Running", persisted.Status.Server)
+
+	// a status computed from an outdated version of the ArgoCD is rejected
+	persisted.Labels = map[string]string{"changed": "true"}
+	assert.NoError(t, r.Client.Update(context.TODO(), persisted))
+	original = a.Status.DeepCopy()
+	a.Status.Phase = "Failed"
+	assert.True(t, apierrors.IsConflict(r.patchStatus(a, original)))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), key, persisted))
+	assert.Equal(t, "Available", persisted.Status.Phase)
+}
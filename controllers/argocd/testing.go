@@ -16,7 +16,10 @@ package argocd
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"sort"
+	"sync"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -27,10 +30,16 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	resourcev1 "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
@@ -52,14 +61,103 @@ func ZapLogger(development bool) logr.Logger {
 func makeTestReconciler(t *testing.T, objs ...runtime.Object) *ReconcileArgoCD {
 	s := scheme.Scheme
 	assert.NoError(t, argoprojv1alpha1.AddToScheme(s))
+	// The fake client registers unknown unstructured kinds in the scheme when listing them, which races with the
+	// components reconciled concurrently.
+	appProject := schema.GroupVersionKind{Group: appProjectGroup, Version: appProjectVersion, Kind: appProjectKind}
+	s.AddKnownTypeWithName(appProject, &unstructured.Unstructured{})
+	s.AddKnownTypeWithName(appProject.GroupVersion().WithKind(appProjectKind+"List"), &unstructured.UnstructuredList{})
 
 	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()
 	return &ReconcileArgoCD{
-		Client: cl,
-		Scheme: s,
+		Client:     newTestApplyClient(cl),
+		Scheme:     s,
+		reconciled: &reconciledObjects{},
 	}
 }
 
+// testApplyClient is a client.Client that emulates server-side apply on top of the fake client, which does not support
+// apply patches, with a three-way strategic merge of the last applied, the applied and the existing object. Fields set
+// by others, e.g. injected sidecars or user annotations, are kept, while fields that are no longer applied are removed.
+// Objects that were not applied yet are owned as a whole, apart from their labels and annotations.
+type testApplyClient struct {
+	client.Client
+
+	mu sync.Mutex
+	// applied holds the fields last applied to each object, by type and key.
+	applied map[string][]byte
+}
+
+// newTestApplyClient returns a new testApplyClient that wraps the given client.
+func newTestApplyClient(c client.Client) *testApplyClient {
+	return &testApplyClient{Client: c, applied: map[string][]byte{}}
+}
+
+// getAppliedFields returns the JSON of the fields of the given object that are owned by its field manager.
+func getAppliedFields(obj client.Object, metadataFields ...string) ([]byte, error) {
+	fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	metadata := map[string]interface{}{}
+	for _, field := range metadataFields {
+		if value, ok := fields["metadata"].(map[string]interface{})[field]; ok {
+			metadata[field] = value
+		}
+	}
+	fields["metadata"] = metadata
+	delete(fields, "status")
+	return json.Marshal(fields)
+}
+
+// Patch applies the given object if the patch is an apply patch, and patches it otherwise.
+func (c *testApplyClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	applied, err := getAppliedFields(obj, "labels", "annotations", "ownerReferences")
+	if err != nil {
+		return err
+	}
+	id := fmt.Sprintf("%T/%s", obj, client.ObjectKeyFromObject(obj))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	existing := newEmptyObject(obj)
+	if err := c.Client.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		c.applied[id] = applied
+		return c.Client.Create(ctx, obj)
+	}
+
+	lastApplied, ok := c.applied[id]
+	if !ok {
+		if lastApplied, err = getAppliedFields(existing, "ownerReferences"); err != nil {
+			return err
+		}
+	}
+	current, err := json.Marshal(existing)
+	if err != nil {
+		return err
+	}
+	schema, err := strategicpatch.NewPatchMetaFromStruct(obj)
+	if err != nil {
+		return err
+	}
+	diff, err := strategicpatch.CreateThreeWayMergePatch(lastApplied, applied, current, schema, true)
+	if err != nil {
+		return err
+	}
+	c.applied[id] = applied
+
+	// Applies that do not change the object leave it untouched, like the API server does.
+	if string(diff) == "{}" {
+		return c.Client.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+	}
+	return c.Client.Patch(ctx, obj, client.RawPatch(types.StrategicMergePatchType, diff), opts...)
+}
+
 type argoCDOpt func(*argoprojv1alpha1.ArgoCD)
 
 func makeTestArgoCD(opts ...argoCDOpt) *argoprojv1alpha1.ArgoCD {
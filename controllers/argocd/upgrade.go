@@ -0,0 +1,330 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// upgradePhaseProgressing is the phase of an ordered upgrade that is rolling out the components.
+	upgradePhaseProgressing = "Progressing"
+
+	// upgradePhaseCompleted is the phase of an ordered upgrade once all components run the Argo CD image.
+	upgradePhaseCompleted = "Completed"
+)
+
+// upgradeStage is a component that is rolled out on its own during an ordered upgrade.
+type upgradeStage struct {
+	// suffix is the name suffix of the workload of the component.
+	suffix string
+	// statefulSet is true when the workload of the component is a StatefulSet rather than a Deployment.
+	statefulSet bool
+	// image returns the Argo CD image the component runs once upgraded. It is nil when the component does not run the
+	// Argo CD image, and the upgrade only waits for it to be ready before rolling out the next stages.
+	image func(cr *argoprojv1a1.ArgoCD) string
+}
+
+// getApplicationControllerContainerImage returns the image of the Application Controller of the given ArgoCD.
+func getApplicationControllerContainerImage(cr *argoprojv1a1.ArgoCD) string {
+	return getArgoComponentContainerImage(cr, cr.Spec.Controller.Image, cr.Spec.Controller.Version)
+}
+
+// getRepoServerContainerImage returns the image of the repo server of the given ArgoCD.
+func getRepoServerContainerImage(cr *argoprojv1a1.ArgoCD) string {
+	return getArgoComponentContainerImage(cr, cr.Spec.Repo.Image, cr.Spec.Repo.Version)
+}
+
+// getArgoServerContainerImage returns the image of the Argo CD Server of the given ArgoCD.
+func getArgoServerContainerImage(cr *argoprojv1a1.ArgoCD) string {
+	return getArgoComponentContainerImage(cr, cr.Spec.Server.Image, cr.Spec.Server.Version)
+}
+
+// getNotificationsContainerImage returns the image of the notifications controller of the given ArgoCD.
+func getNotificationsContainerImage(cr *argoprojv1a1.ArgoCD) string {
+	return getArgoComponentContainerImage(cr, cr.Spec.Notifications.Image, cr.Spec.Notifications.Version)
+}
+
+// getUpgradeStages returns the components of the given ArgoCD in the order they are rolled out during an ordered
+// upgrade.
+func getUpgradeStages(cr *argoprojv1a1.ArgoCD) []upgradeStage {
+	redis := upgradeStage{suffix: "redis"}
+	if cr.Spec.HA.Enabled {
+		redis = upgradeStage{suffix: "redis-ha-server", statefulSet: true}
+	}
+	return []upgradeStage{
+		redis,
+		{suffix: "repo-server", image: getRepoServerContainerImage},
+		{suffix: "application-controller", statefulSet: true, image: getApplicationControllerContainerImage},
+		{suffix: "server", image: getArgoServerContainerImage},
+		{suffix: "dex-server", image: getArgoContainerImage},
+		{suffix: "notifications-controller", image: getNotificationsContainerImage},
+	}
+}
+
+// isOrderedUpgrade returns true when the components of the given ArgoCD are upgraded one after the other.
+func isOrderedUpgrade(cr *argoprojv1a1.ArgoCD) bool {
+	return cr.Spec.UpgradeStrategy == argoprojv1a1.ArgoCDUpgradeStrategyOrdered
+}
+
+// getUpgradeStageWorkload returns the workload of the given stage, or nil when the workload does not exist.
+func (r *ReconcileArgoCD) getUpgradeStageWorkload(cr *argoprojv1a1.ArgoCD, stage upgradeStage) client.Object {
+	var obj client.Object = newDeploymentWithSuffix(stage.suffix, stage.suffix, cr)
+	if stage.statefulSet {
+		obj = newStatefulSetWithSuffix(stage.suffix, stage.suffix, cr)
+	}
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, obj.GetName(), obj) {
+		return nil
+	}
+	return obj
+}
+
+// isWorkloadRolledOut returns true when the given Deployment or StatefulSet has rolled out its latest revision to
+// all of its replicas, and all of them are ready.
+func isWorkloadRolledOut(obj client.Object) bool {
+	switch w := obj.(type) {
+	case *appsv1.Deployment:
+		replicas := int32(1)
+		if w.Spec.Replicas != nil {
+			replicas = *w.Spec.Replicas
+		}
+		return w.Status.ObservedGeneration >= w.Generation && w.Status.Replicas == replicas &&
+			w.Status.UpdatedReplicas == replicas && w.Status.AvailableReplicas == replicas
+	case *appsv1.StatefulSet:
+		replicas := int32(1)
+		if w.Spec.Replicas != nil {
+			replicas = *w.Spec.Replicas
+		}
+		return w.Status.ObservedGeneration >= w.Generation && w.Status.UpdatedReplicas == replicas &&
+			w.Status.ReadyReplicas == replicas && w.Status.CurrentRevision == w.Status.UpdateRevision
+	}
+	return false
+}
+
+// getWorkloadArgoImage returns the Argo CD image the given workload was last applied with, as recorded in its
+// annotations.
+func getWorkloadArgoImage(obj client.Object) string {
+	return obj.GetAnnotations()[common.AnnotationArgoCDImage]
+}
+
+// setWorkloadArgoImage records the given Argo CD image in the annotations of the given workload.
+func setWorkloadArgoImage(obj client.Object, image string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[common.AnnotationArgoCDImage] = image
+	obj.SetAnnotations(annotations)
+}
+
+// getUpgradeStage returns the suffix of the component of the given ArgoCD that is being upgraded to its image, or an
+// empty string when no upgrade is in progress.
+//
+// An upgrade is in progress while a component was applied with another image, or, once the upgrade started, until
+// the last component is rolled out. The current stage is the first component, in the upgrade order, that was not
+// applied with its image or is not rolled out yet. Components that are not deployed are skipped.
+func (r *ReconcileArgoCD) getUpgradeStage(cr *argoprojv1a1.ArgoCD) string {
+	stages := getUpgradeStages(cr)
+
+	workloads := make([]client.Object, len(stages))
+	inProgress := cr.Status.Upgrade != nil && cr.Status.Upgrade.Image == getArgoContainerImage(cr) &&
+		cr.Status.Upgrade.Phase == upgradePhaseProgressing
+	for i, stage := range stages {
+		workloads[i] = r.getUpgradeStageWorkload(cr, stage)
+		if workloads[i] == nil || stage.image == nil {
+			continue
+		}
+		if current := getWorkloadArgoImage(workloads[i]); current != "" && current != stage.image(cr) {
+			inProgress = true
+		}
+	}
+	if !inProgress {
+		return ""
+	}
+
+	for i, stage := range stages {
+		if workloads[i] == nil {
+			continue
+		}
+		if stage.image != nil && getWorkloadArgoImage(workloads[i]) != stage.image(cr) {
+			return stage.suffix
+		}
+		if !isWorkloadRolledOut(workloads[i]) {
+			return stage.suffix
+		}
+	}
+	return ""
+}
+
+// getArgoContainerImageForStage returns the Argo CD image the component with the given suffix must run. When the
+// components of the given ArgoCD are upgraded in order, a component keeps the image it runs until the components
+// before it in the upgrade order are upgraded and rolled out.
+func (r *ReconcileArgoCD) getArgoContainerImageForStage(cr *argoprojv1a1.ArgoCD, suffix string) string {
+	current := ""
+	if isOrderedUpgrade(cr) {
+		current = r.getUpgradeStage(cr)
+	}
+
+	// The components up to the current stage are upgraded, the ones after it keep the image they run.
+	afterCurrent := false
+	for _, stage := range getUpgradeStages(cr) {
+		if stage.suffix == suffix {
+			if afterCurrent {
+				if obj := r.getUpgradeStageWorkload(cr, stage); obj != nil && getWorkloadArgoImage(obj) != "" {
+					return getWorkloadArgoImage(obj)
+				}
+			}
+			return stage.image(cr)
+		}
+		if current != "" && stage.suffix == current {
+			afterCurrent = true
+		}
+	}
+	return getArgoContainerImage(cr)
+}
+
+// reconcileStatusUpgrade will ensure that the progress of the ordered upgrade of the given ArgoCD is reported in its
+// status.
+func (r *ReconcileArgoCD) reconcileStatusUpgrade(cr *argoprojv1a1.ArgoCD) error {
+	var status *argoprojv1a1.ArgoCDUpgradeStatus
+	if isOrderedUpgrade(cr) {
+		status = &argoprojv1a1.ArgoCDUpgradeStatus{
+			Image: getArgoContainerImage(cr),
+			Phase: upgradePhaseCompleted,
+		}
+		if stage := r.getUpgradeStage(cr); stage != "" {
+			status.Phase = upgradePhaseProgressing
+			status.Stage = stage
+		}
+	}
+
+	if !reflect.DeepEqual(cr.Status.Upgrade, status) && status != nil && status.Stage != "" {
+		log.Info(fmt.Sprintf("upgrading %s to %s", nameWithSuffix(status.Stage, cr), status.Image))
+	}
+	cr.Status.Upgrade = status
+	return nil
+}
+
+// maxSupportedMinorVersionSkew is the number of minor versions a pinned component may be away from the version of the
+// ArgoCD.
+const maxSupportedMinorVersionSkew = 1
+
+// versionPattern matches the major and minor version of a semantic version tag, e.g. v2.6.1.
+var versionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)(\.|-|$)`)
+
+// parseMinorVersion returns the major and minor version of the given tag, and false when the tag is not a semantic
+// version, e.g. a digest.
+func parseMinorVersion(tag string) (int, int, bool) {
+	match := versionPattern.FindStringSubmatch(tag)
+	if match == nil {
+		return 0, 0, false
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	return major, minor, true
+}
+
+// getPinnedComponentVersions returns the versions of the components of the given ArgoCD that are pinned to a version,
+// by the name of the component.
+func getPinnedComponentVersions(cr *argoprojv1a1.ArgoCD) map[string]string {
+	versions := map[string]string{}
+	if cr.Spec.Controller.Version != "" {
+		versions["application-controller"] = cr.Spec.Controller.Version
+	}
+	if cr.Spec.Repo.Version != "" {
+		versions["repo-server"] = cr.Spec.Repo.Version
+	}
+	if cr.Spec.Server.Version != "" {
+		versions["server"] = cr.Spec.Server.Version
+	}
+	if cr.Spec.Notifications.Enabled && cr.Spec.Notifications.Version != "" {
+		versions["notifications-controller"] = cr.Spec.Notifications.Version
+	}
+	if cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.Version != "" {
+		versions["applicationset-controller"] = cr.Spec.ApplicationSet.Version
+	}
+	return versions
+}
+
+// getSkewedComponentVersions returns the components of the given ArgoCD pinned to a version that is more than the
+// supported number of minor versions away from the version of the ArgoCD, sorted by name. Versions that are not
+// semantic versions, e.g. digests, are not compared.
+func getSkewedComponentVersions(cr *argoprojv1a1.ArgoCD) []string {
+	version := cr.Spec.Version
+	if version == "" {
+		version = common.ArgoCDDefaultArgoVersion
+	}
+	major, minor, ok := parseMinorVersion(version)
+	if !ok {
+		return nil
+	}
+
+	skewed := []string{}
+	for name, pinned := range getPinnedComponentVersions(cr) {
+		pinnedMajor, pinnedMinor, ok := parseMinorVersion(pinned)
+		if !ok {
+			continue
+		}
+		skew := pinnedMinor - minor
+		if skew < 0 {
+			skew = -skew
+		}
+		if pinnedMajor != major || skew > maxSupportedMinorVersionSkew {
+			skewed = append(skewed, fmt.Sprintf("%s (%s)", name, pinned))
+		}
+	}
+	sort.Strings(skewed)
+	return skewed
+}
+
+// reconcileStatusVersionSkew will ensure that the VersionSkew condition of the given ArgoCD reports the components
+// pinned to a version outside of the supported skew from the version of the ArgoCD. The condition is only maintained
+// once a component has been pinned to a version.
+func (r *ReconcileArgoCD) reconcileStatusVersionSkew(cr *argoprojv1a1.ArgoCD) error {
+	if len(getPinnedComponentVersions(cr)) == 0 && meta.FindStatusCondition(cr.Status.Conditions, argoprojv1a1.ArgoCDConditionTypeVersionSkew) == nil {
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:    argoprojv1a1.ArgoCDConditionTypeVersionSkew,
+		Status:  metav1.ConditionFalse,
+		Reason:  "SupportedVersions",
+		Message: "the components run versions within the supported skew",
+	}
+	if skewed := getSkewedComponentVersions(cr); len(skewed) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "UnsupportedVersions"
+		condition.Message = fmt.Sprintf("components are pinned to versions more than %d minor version away from the version of the ArgoCD: %s",
+			maxSupportedMinorVersionSkew, strings.Join(skewed, ", "))
+	}
+	condition.ObservedGeneration = cr.Generation
+
+	r.setConditionWithEvent(cr, condition, "VersionSkew")
+	return nil
+}
@@ -0,0 +1,230 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+// makeTestUpgradeDeployment returns a rolled out Deployment with the given suffix, last applied with the given image.
+func makeTestUpgradeDeployment(suffix, image string, a *argoprojv1alpha1.ArgoCD) *appsv1.Deployment {
+	deploy := newDeploymentWithSuffix(suffix, suffix, a)
+	deploy.Spec.Replicas = int32Ptr(1)
+	deploy.Status = appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1}
+	if image != "" {
+		setWorkloadArgoImage(deploy, image)
+	}
+	return deploy
+}
+
+func TestReconcileArgoCD_getArgoContainerImageForStage(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Image = "quay.io/argoproj/argocd"
+		a.Spec.Version = "v2.6.0"
+		a.Spec.UpgradeStrategy = argoprojv1alpha1.ArgoCDUpgradeStrategyOrdered
+	})
+	oldImage := "quay.io/argoproj/argocd:v2.5.0"
+	newImage := "quay.io/argoproj/argocd:v2.6.0"
+
+	redis := makeTestUpgradeDeployment("redis", "", a)
+	repo := makeTestUpgradeDeployment("repo-server", oldImage, a)
+	server := makeTestUpgradeDeployment("server", oldImage, a)
+	controller := newStatefulSetWithSuffix("application-controller", "application-controller", a)
+	controller.Spec.Replicas = int32Ptr(1)
+	controller.Status = appsv1.StatefulSetStatus{UpdatedReplicas: 1, ReadyReplicas: 1}
+	setWorkloadArgoImage(controller, oldImage)
+	r := makeTestReconciler(t, a, redis, repo, server, controller)
+
+	// the repo server is upgraded first, the components after it keep their image
+	assert.Equal(t, "repo-server", r.getUpgradeStage(a))
+	assert.Equal(t, newImage, r.getArgoContainerImageForStage(a, "repo-server"))
+	assert.Equal(t, oldImage, r.getArgoContainerImageForStage(a, "application-controller"))
+	assert.Equal(t, oldImage, r.getArgoContainerImageForStage(a, "server"))
+	// components that are not deployed yet are not held back
+	assert.Equal(t, newImage, r.getArgoContainerImageForStage(a, "dex-server"))
+
+	assert.NoError(t, r.reconcileStatusUpgrade(a))
+	assert.Equal(t, &argoprojv1alpha1.ArgoCDUpgradeStatus{
+		Image: newImage,
+		Phase: upgradePhaseProgressing,
+		Stage: "repo-server",
+	}, a.Status.Upgrade)
+
+	// the next stage waits until the repo server is rolled out
+	setWorkloadArgoImage(repo, newImage)
+	repo.Generation = 2
+	repo.Status.ObservedGeneration = 1
+	assert.NoError(t, r.Client.Update(context.TODO(), repo))
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), repo))
+	assert.Equal(t, "repo-server", r.getUpgradeStage(a))
+	assert.Equal(t, oldImage, r.getArgoContainerImageForStage(a, "application-controller"))
+
+	repo.Status.ObservedGeneration = 2
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), repo))
+	assert.Equal(t, "application-controller", r.getUpgradeStage(a))
+	assert.Equal(t, newImage, r.getArgoContainerImageForStage(a, "repo-server"))
+	assert.Equal(t, newImage, r.getArgoContainerImageForStage(a, "application-controller"))
+	assert.Equal(t, oldImage, r.getArgoContainerImageForStage(a, "server"))
+
+	setWorkloadArgoImage(controller, newImage)
+	assert.NoError(t, r.Client.Update(context.TODO(), controller))
+	assert.Equal(t, "server", r.getUpgradeStage(a))
+	assert.Equal(t, newImage, r.getArgoContainerImageForStage(a, "server"))
+
+	// the upgrade is completed once the last component is rolled out
+	setWorkloadArgoImage(server, newImage)
+	server.Status.AvailableReplicas = 0
+	assert.NoError(t, r.Client.Update(context.TODO(), server))
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), server))
+	assert.Equal(t, "server", r.getUpgradeStage(a))
+
+	server.Status.AvailableReplicas = 1
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), server))
+	assert.NoError(t, r.reconcileStatusUpgrade(a))
+	assert.Equal(t, &argoprojv1alpha1.ArgoCDUpgradeStatus{
+		Image: newImage,
+		Phase: upgradePhaseCompleted,
+	}, a.Status.Upgrade)
+}
+
+func TestReconcileArgoCD_getArgoContainerImageForStage_redisNotReady(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Image = "quay.io/argoproj/argocd"
+		a.Spec.Version = "v2.6.0"
+		a.Spec.UpgradeStrategy = argoprojv1alpha1.ArgoCDUpgradeStrategyOrdered
+	})
+	oldImage := "quay.io/argoproj/argocd:v2.5.0"
+
+	redis := makeTestUpgradeDeployment("redis", "", a)
+	redis.Status.AvailableReplicas = 0
+	repo := makeTestUpgradeDeployment("repo-server", oldImage, a)
+	r := makeTestReconciler(t, a, redis, repo)
+
+	// no component is upgraded before Redis is ready
+	assert.Equal(t, "redis", r.getUpgradeStage(a))
+	assert.Equal(t, oldImage, r.getArgoContainerImageForStage(a, "repo-server"))
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_orderedUpgrade(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Image = "quay.io/argoproj/argocd"
+		a.Spec.Version = "v2.6.0"
+		a.Spec.Repo.Version = "v2.6.1"
+		a.Spec.UpgradeStrategy = argoprojv1alpha1.ArgoCDUpgradeStrategyOrdered
+	})
+	oldImage := "quay.io/argoproj/argocd:v2.5.0"
+
+	redis := makeTestUpgradeDeployment("redis", "", a)
+	redis.Status.AvailableReplicas = 0
+	repo := makeTestUpgradeDeployment("repo-server", oldImage, a)
+	r := makeTestReconciler(t, a, redis, repo)
+
+	// the repo server keeps its image, including the main container, while Redis is not rolled out
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(repo), repo))
+	assert.Equal(t, oldImage, repo.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, oldImage, repo.Spec.Template.Spec.InitContainers[0].Image)
+	assert.Equal(t, oldImage, getWorkloadArgoImage(repo))
+
+	// it is then upgraded to the version it is pinned to
+	redis.Status.AvailableReplicas = 1
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), redis))
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(repo), repo))
+	assert.Equal(t, "quay.io/argoproj/argocd:v2.6.1", repo.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "quay.io/argoproj/argocd:v2.6.1", repo.Spec.Template.Spec.InitContainers[0].Image)
+	assert.Equal(t, "quay.io/argoproj/argocd:v2.6.1", getWorkloadArgoImage(repo))
+}
+
+func TestReconcileArgoCD_getArgoContainerImageForStage_parallel(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Image = "quay.io/argoproj/argocd"
+		a.Spec.Version = "v2.6.0"
+	})
+	objs := []runtime.Object{a}
+	for _, suffix := range []string{"repo-server", "server"} {
+		objs = append(objs, makeTestUpgradeDeployment(suffix, "quay.io/argoproj/argocd:v2.5.0", a))
+	}
+	r := makeTestReconciler(t, objs...)
+
+	// all components are upgraded at once
+	for _, suffix := range []string{"repo-server", "server"} {
+		assert.Equal(t, "quay.io/argoproj/argocd:v2.6.0", r.getArgoContainerImageForStage(a, suffix))
+	}
+	assert.NoError(t, r.reconcileStatusUpgrade(a))
+	assert.Nil(t, a.Status.Upgrade)
+}
+
+func TestReconcileArgoCD_getArgoContainerImageForStage_pinned(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Image = "quay.io/argoproj/argocd"
+		a.Spec.Version = "v2.6.0"
+		a.Spec.Controller.Version = "v2.5.0"
+		a.Spec.Server.Image = "registry.example.com/argocd"
+		a.Spec.UpgradeStrategy = argoprojv1alpha1.ArgoCDUpgradeStrategyOrdered
+	})
+
+	// the pinned components are rolled out with their own image and count as upgraded
+	repo := makeTestUpgradeDeployment("repo-server", "quay.io/argoproj/argocd:v2.6.0", a)
+	server := makeTestUpgradeDeployment("server", "quay.io/argoproj/argocd:v2.5.0", a)
+	controller := newStatefulSetWithSuffix("application-controller", "application-controller", a)
+	controller.Spec.Replicas = int32Ptr(1)
+	controller.Status = appsv1.StatefulSetStatus{UpdatedReplicas: 1, ReadyReplicas: 1}
+	setWorkloadArgoImage(controller, "quay.io/argoproj/argocd:v2.5.0")
+	r := makeTestReconciler(t, a, repo, server, controller)
+
+	assert.Equal(t, "server", r.getUpgradeStage(a))
+	assert.Equal(t, "quay.io/argoproj/argocd:v2.5.0", r.getArgoContainerImageForStage(a, "application-controller"))
+	assert.Equal(t, "registry.example.com/argocd:v2.6.0", r.getArgoContainerImageForStage(a, "server"))
+	assert.Equal(t, "quay.io/argoproj/argocd:v2.6.0", r.getArgoContainerImageForStage(a, "repo-server"))
+}
+
+func TestReconcileArgoCD_reconcileStatusVersionSkew(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Version = "v2.6.0"
+	})
+	r := makeTestReconciler(t, a)
+
+	// the condition is not reported while no component is pinned
+	assert.NoError(t, r.reconcileStatusVersionSkew(a))
+	assert.Nil(t, meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionTypeVersionSkew))
+
+	// a component one minor version behind is supported
+	a.Spec.Controller.Version = "v2.5.3"
+	a.Spec.Repo.Version = "sha256:d1c2e1c5d6c5f2b1e9b2c7c7e2bd1a3a5f8f2e8f6f3a1d2c3b4a5f6e7d8c9b0a"
+	assert.NoError(t, r.reconcileStatusVersionSkew(a))
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionTypeVersionSkew)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+
+	// components further away are reported
+	a.Spec.Controller.Version = "v2.4.0"
+	a.Spec.Server.Version = "v3.0.0"
+	assert.NoError(t, r.reconcileStatusVersionSkew(a))
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionTypeVersionSkew)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "UnsupportedVersions", condition.Reason)
+	assert.Equal(t, "components are pinned to versions more than 1 minor version away from the version of the ArgoCD: application-controller (v2.4.0), server (v3.0.0)", condition.Message)
+
+	// the condition is cleared once the components are no longer pinned
+	a.Spec.Controller.Version = ""
+	a.Spec.Repo.Version = ""
+	a.Spec.Server.Version = ""
+	assert.NoError(t, r.reconcileStatusVersionSkew(a))
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionTypeVersionSkew)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+}
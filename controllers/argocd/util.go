@@ -21,10 +21,12 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
+	"path"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -46,6 +48,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	v1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -54,6 +57,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
@@ -106,7 +110,7 @@ func generateArgoServerSessionKey() ([]byte, error) {
 
 // getArgoApplicationControllerResources will return the ResourceRequirements for the Argo CD application controller container.
 func getArgoApplicationControllerResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
-	resources := corev1.ResourceRequirements{}
+	resources := getDefaultResources(common.ArgoCDApplicationControllerComponent)
 
 	// Allow override of resource requirements from CR
 	if cr.Spec.Controller.Resources != nil {
@@ -121,8 +125,8 @@ func getArgoApplicationControllerCommand(cr *argoprojv1a1.ArgoCD, useTLSForRedis
 	cmd := []string{
 		"argocd-application-controller",
 		"--operation-processors", fmt.Sprint(getArgoServerOperationProcessors(cr)),
-		"--redis", getRedisServerAddress(cr),
 	}
+	cmd = append(cmd, getRedisServerArgs(cr)...)
 
 	if useTLSForRedis {
 		cmd = append(cmd, "--redis-use-tls")
@@ -137,6 +141,10 @@ func getArgoApplicationControllerCommand(cr *argoprojv1a1.ArgoCD, useTLSForRedis
 	cmd = append(cmd, "--status-processors", fmt.Sprint(getArgoServerStatusProcessors(cr)))
 	cmd = append(cmd, "--kubectl-parallelism-limit", fmt.Sprint(getArgoControllerParellismLimit(cr)))
 
+	if port := getArgoControllerMetricsPort(cr); port != common.ArgoCDDefaultApplicationControllerMetricsPort {
+		cmd = append(cmd, "--metrics-port", fmt.Sprint(port))
+	}
+
 	if cr.Spec.SourceNamespaces != nil && len(cr.Spec.SourceNamespaces) > 0 {
 		cmd = append(cmd, "--application-namespaces", fmt.Sprint(strings.Join(cr.Spec.SourceNamespaces, ",")))
 	}
@@ -145,6 +153,10 @@ func getArgoApplicationControllerCommand(cr *argoprojv1a1.ArgoCD, useTLSForRedis
 		cmd = append(cmd, "--app-resync", strconv.FormatInt(int64(cr.Spec.Controller.AppSync.Seconds()), 10))
 	}
 
+	if cr.Spec.Controller.SelfHealTimeout != nil {
+		cmd = append(cmd, "--self-heal-timeout-seconds", strconv.FormatInt(int64(cr.Spec.Controller.SelfHealTimeout.Seconds()), 10))
+	}
+
 	cmd = append(cmd, "--loglevel")
 	cmd = append(cmd, getLogLevel(cr.Spec.Controller.LogLevel))
 
@@ -154,6 +166,15 @@ func getArgoApplicationControllerCommand(cr *argoprojv1a1.ArgoCD, useTLSForRedis
 	return cmd
 }
 
+// getArgoControllerMetricsPort will return the metrics port for the ArgoCD Application Controller component.
+func getArgoControllerMetricsPort(cr *argoprojv1a1.ArgoCD) int32 {
+	port := int32(common.ArgoCDDefaultApplicationControllerMetricsPort)
+	if cr.Spec.Controller.Metrics.Port > 0 {
+		port = cr.Spec.Controller.Metrics.Port
+	}
+	return port
+}
+
 // getArgoContainerImage will return the container image for ArgoCD.
 func getArgoContainerImage(cr *argoprojv1a1.ArgoCD) string {
 	defaultTag, defaultImg := false, false
@@ -175,39 +196,32 @@ func getArgoContainerImage(cr *argoprojv1a1.ArgoCD) string {
 	return argoutil.CombineImageTag(img, tag)
 }
 
-// getRepoServerContainerImage will return the container image for the Repo server.
-//
-// There are three possible options for configuring the image, and this is the
-// order of preference.
-//
-// 1. from the Spec, the spec.repo field has an image and version to use for
-// generating an image reference.
-// 2. from the Environment, this looks for the `ARGOCD_REPOSERVER_IMAGE` field and uses
-// that if the spec is not configured.
-// 3. the default is configured in common.ArgoCDDefaultRepoServerVersion and
-// common.ArgoCDDefaultRepoServerImage.
-func getRepoServerContainerImage(cr *argoprojv1a1.ArgoCD) string {
-	defaultImg, defaultTag := false, false
-	img := cr.Spec.Repo.Image
-	if img == "" {
-		img = common.ArgoCDDefaultArgoImage
-		defaultImg = true
+// getArgoComponentContainerImage will return the container image for an Argo CD component that can be pinned to
+// another image or version than the other components through the given image and tag. The image and tag that are not
+// set default to those of the given ArgoCD.
+func getArgoComponentContainerImage(cr *argoprojv1a1.ArgoCD, img, tag string) string {
+	if img == "" && tag == "" {
+		return getArgoContainerImage(cr)
 	}
 
-	tag := cr.Spec.Repo.Version
-	if tag == "" {
-		tag = common.ArgoCDDefaultArgoVersion
-		defaultTag = true
+	if img == "" {
+		img = cr.Spec.Image
+		if img == "" {
+			img = common.ArgoCDDefaultArgoImage
+		}
 	}
-	if e := os.Getenv(common.ArgoCDImageEnvName); e != "" && (defaultTag && defaultImg) {
-		return e
+	if tag == "" {
+		tag = cr.Spec.Version
+		if tag == "" {
+			tag = common.ArgoCDDefaultArgoVersion
+		}
 	}
 	return argoutil.CombineImageTag(img, tag)
 }
 
 // getArgoRepoResources will return the ResourceRequirements for the Argo CD Repo server container.
 func getArgoRepoResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
-	resources := corev1.ResourceRequirements{}
+	resources := getDefaultResources(common.ArgoCDRepoServerComponent)
 
 	// Allow override of resource requirements from CR
 	if cr.Spec.Repo.Resources != nil {
@@ -217,9 +231,81 @@ func getArgoRepoResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
 	return resources
 }
 
-// getArgoServerInsecure returns the insecure value for the ArgoCD Server component.
+// getArgoRepoLivenessProbe will return the liveness probe for the Argo CD Repo server container.
+func getArgoRepoLivenessProbe(cr *argoprojv1a1.ArgoCD) *corev1.Probe {
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(common.ArgoCDDefaultRepoServerPort),
+			},
+		},
+		InitialDelaySeconds: 5,
+		PeriodSeconds:       10,
+	}
+
+	// Allow override of the liveness probe from CR
+	if cr.Spec.Repo.LivenessProbe != nil {
+		probe = cr.Spec.Repo.LivenessProbe.DeepCopy()
+	}
+
+	setProbeDefaults(probe)
+	return probe
+}
+
+// getArgoRepoReadinessProbe will return the readiness probe for the Argo CD Repo server container.
+func getArgoRepoReadinessProbe(cr *argoprojv1a1.ArgoCD) *corev1.Probe {
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(common.ArgoCDDefaultRepoServerPort),
+			},
+		},
+		InitialDelaySeconds: 5,
+		PeriodSeconds:       10,
+	}
+
+	// Allow override of the readiness probe from CR
+	if cr.Spec.Repo.ReadinessProbe != nil {
+		probe = cr.Spec.Repo.ReadinessProbe.DeepCopy()
+	}
+
+	setProbeDefaults(probe)
+	return probe
+}
+
+// getArgoRepoTerminationGracePeriodSeconds will return the termination grace period for the Argo CD Repo server pods.
+func getArgoRepoTerminationGracePeriodSeconds(cr *argoprojv1a1.ArgoCD) *int64 {
+	var seconds int64 = corev1.DefaultTerminationGracePeriodSeconds
+	if cr.Spec.Repo.TerminationGracePeriodSeconds != nil {
+		seconds = *cr.Spec.Repo.TerminationGracePeriodSeconds
+	}
+	return &seconds
+}
+
+// setProbeDefaults will populate the unset fields of the given probe with the values defaulted by the API server,
+// so that the desired probe can be compared with the one found in the cluster.
+func setProbeDefaults(probe *corev1.Probe) {
+	if probe.TimeoutSeconds == 0 {
+		probe.TimeoutSeconds = 1
+	}
+	if probe.PeriodSeconds == 0 {
+		probe.PeriodSeconds = 10
+	}
+	if probe.SuccessThreshold == 0 {
+		probe.SuccessThreshold = 1
+	}
+	if probe.FailureThreshold == 0 {
+		probe.FailureThreshold = 3
+	}
+}
+
+// getArgoServerInsecure returns the insecure value for the ArgoCD Server component. The server always runs
+// insecure when exposed through Istio, as TLS is handled by the mesh.
 func getArgoServerInsecure(cr *argoprojv1a1.ArgoCD) bool {
-	return cr.Spec.Server.Insecure
+	if insecure := cr.Spec.CmdParams.Server.Insecure; insecure != nil {
+		return *insecure || cr.Spec.Server.Istio.Enabled
+	}
+	return cr.Spec.Server.Insecure || cr.Spec.Server.Istio.Enabled
 }
 
 func isRepoServerTLSVerificationRequested(cr *argoprojv1a1.ArgoCD) bool {
@@ -250,9 +336,10 @@ func getArgoServerHost(cr *argoprojv1a1.ArgoCD) string {
 
 // getArgoServerResources will return the ResourceRequirements for the Argo CD server container.
 func getArgoServerResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
-	resources := corev1.ResourceRequirements{}
+	resources := getDefaultResources(common.ArgoCDServerComponent)
 
-	if cr.Spec.Server.Autoscale.Enabled {
+	// The autoscaler of the server scales on its CPU requests, so they are set even without a sizing profile.
+	if cr.Spec.Server.Autoscale.Enabled && resources.Requests == nil {
 		resources = corev1.ResourceRequirements{
 			Limits: corev1.ResourceList{
 				corev1.ResourceCPU:    resource.MustParse(common.ArgoCDDefaultServerResourceLimitCPU),
@@ -299,11 +386,24 @@ func (r *ReconcileArgoCD) getArgoServerURI(cr *argoprojv1a1.ArgoCD) string {
 		}
 	}
 
-	return fmt.Sprintf("https://%s", host) // TODO: Safe to assume HTTPS here?
+	return getArgoServerExternalURL(cr, host)
+}
+
+// getArgoServerExternalURL will return the URL the Argo CD server is reached under, which is either the external URL
+// set for the server or an https URL for the given host and the root path of the server.
+func getArgoServerExternalURL(cr *argoprojv1a1.ArgoCD, host string) string {
+	if cr.Spec.Server.ExternalURL != "" {
+		return strings.TrimSuffix(cr.Spec.Server.ExternalURL, "/")
+	}
+	return fmt.Sprintf("https://%s%s", host, strings.TrimSuffix(cr.Spec.Server.RootPath, "/"))
 }
 
 // getArgoServerOperationProcessors will return the numeric Operation Processors value for the ArgoCD Server.
 func getArgoServerOperationProcessors(cr *argoprojv1a1.ArgoCD) int32 {
+	if processors := cr.Spec.CmdParams.Controller.OperationProcessors; processors != nil {
+		return *processors
+	}
+
 	op := common.ArgoCDDefaultServerOperationProcessors
 	if cr.Spec.Controller.Processors.Operation > op {
 		op = cr.Spec.Controller.Processors.Operation
@@ -313,6 +413,10 @@ func getArgoServerOperationProcessors(cr *argoprojv1a1.ArgoCD) int32 {
 
 // getArgoServerStatusProcessors will return the numeric Status Processors value for the ArgoCD Server.
 func getArgoServerStatusProcessors(cr *argoprojv1a1.ArgoCD) int32 {
+	if processors := cr.Spec.CmdParams.Controller.StatusProcessors; processors != nil {
+		return *processors
+	}
+
 	sp := common.ArgoCDDefaultServerStatusProcessors
 	if cr.Spec.Controller.Processors.Status > sp {
 		sp = cr.Spec.Controller.Processors.Status
@@ -351,7 +455,7 @@ func getGrafanaContainerImage(cr *argoprojv1a1.ArgoCD) string {
 
 // getGrafanaResources will return the ResourceRequirements for the Grafana container.
 func getGrafanaResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
-	resources := corev1.ResourceRequirements{}
+	resources := getDefaultResources(common.ArgoCDOperatorGrafanaComponent)
 
 	// Allow override of resource requirements from CR
 	if cr.Spec.Grafana.Resources != nil {
@@ -372,10 +476,12 @@ func getRedisConfigPath() string {
 
 // getRedisInitScript will load the redis configuration from a template on disk for the given ArgoCD.
 // If an error occurs, an empty string value will be returned.
-func getRedisConf(useTLSForRedis bool) string {
+func getRedisConf(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) string {
 	path := fmt.Sprintf("%s/redis.conf.tpl", getRedisConfigPath())
 	params := map[string]string{
-		"UseTLS": strconv.FormatBool(useTLSForRedis),
+		"UseTLS":      strconv.FormatBool(useTLSForRedis),
+		"UseAuth":     strconv.FormatBool(isRedisAuthEnabled(cr)),
+		"Persistence": strconv.FormatBool(cr.Spec.Redis.IsPersistent()),
 	}
 	conf, err := loadTemplateFile(path, params)
 	if err != nil {
@@ -405,14 +511,21 @@ func getRedisContainerImage(cr *argoprojv1a1.ArgoCD) string {
 }
 
 // getRedisHAContainerImage will return the container image for the Redis server in HA mode.
+// The HA specific image and version take precedence over the ones of the Redis server.
 func getRedisHAContainerImage(cr *argoprojv1a1.ArgoCD) string {
 	defaultImg, defaultTag := false, false
-	img := cr.Spec.Redis.Image
+	img := cr.Spec.HA.RedisImage
+	if img == "" {
+		img = cr.Spec.Redis.Image
+	}
 	if img == "" {
 		img = common.ArgoCDDefaultRedisImage
 		defaultImg = true
 	}
-	tag := cr.Spec.Redis.Version
+	tag := cr.Spec.HA.RedisVersion
+	if tag == "" {
+		tag = cr.Spec.Redis.Version
+	}
 	if tag == "" {
 		tag = common.ArgoCDDefaultRedisVersionHA
 		defaultTag = true
@@ -423,6 +536,47 @@ func getRedisHAContainerImage(cr *argoprojv1a1.ArgoCD) string {
 	return argoutil.CombineImageTag(img, tag)
 }
 
+// getRedisProbe will return the given probe override for the Redis container, or the default probe if the
+// override is not set.
+func getRedisProbe(override *corev1.Probe, defaultProbe *corev1.Probe) *corev1.Probe {
+	if override == nil {
+		return defaultProbe
+	}
+	probe := override.DeepCopy()
+	setProbeDefaults(probe)
+	return probe
+}
+
+// getRedisTerminationGracePeriodSeconds will return the termination grace period for the Redis pods.
+func getRedisTerminationGracePeriodSeconds(cr *argoprojv1a1.ArgoCD, defaultSeconds int64) *int64 {
+	seconds := defaultSeconds
+	if cr.Spec.Redis.TerminationGracePeriodSeconds != nil {
+		seconds = *cr.Spec.Redis.TerminationGracePeriodSeconds
+	}
+	return &seconds
+}
+
+// getRedisPersistentVolumeClaimSpec will return the spec of the persistent volume claims backing the Redis data for
+// the given ArgoCD.
+func getRedisPersistentVolumeClaimSpec(cr *argoprojv1a1.ArgoCD) corev1.PersistentVolumeClaimSpec {
+	size := resource.MustParse(common.ArgoCDRedisDefaultStorageSize)
+	if cr.Spec.Redis.Persistence.Size != nil {
+		size = *cr.Spec.Redis.Persistence.Size
+	}
+
+	return corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{
+			corev1.ReadWriteOnce,
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceStorage: size,
+			},
+		},
+		StorageClassName: cr.Spec.Redis.Persistence.StorageClassName,
+	}
+}
+
 // getRedisHAProxyAddress will return the Redis HA Proxy service address for the given ArgoCD.
 func getRedisHAProxyAddress(cr *argoprojv1a1.ArgoCD) string {
 	return fqdnServiceRef("redis-ha-haproxy", common.ArgoCDDefaultRedisPort, cr)
@@ -457,6 +611,8 @@ func getRedisInitScript(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) string {
 	vars := map[string]string{
 		"ServiceName": nameWithSuffix("redis-ha", cr),
 		"UseTLS":      strconv.FormatBool(useTLSForRedis),
+		"UseAuth":     strconv.FormatBool(isRedisAuthEnabled(cr)),
+		"Quorum":      fmt.Sprint(getRedisHAQuorum(cr)),
 	}
 
 	script, err := loadTemplateFile(path, vars)
@@ -471,9 +627,13 @@ func getRedisInitScript(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) string {
 // If an error occurs, an empty string value will be returned.
 func getRedisHAProxyConfig(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) string {
 	path := fmt.Sprintf("%s/haproxy.cfg.tpl", getRedisConfigPath())
-	vars := map[string]string{
+	vars := map[string]interface{}{
 		"ServiceName": nameWithSuffix("redis-ha", cr),
 		"UseTLS":      strconv.FormatBool(useTLSForRedis),
+		"UseAuth":     strconv.FormatBool(isRedisAuthEnabled(cr)),
+		"Quorum":      getRedisHAQuorum(cr),
+		"Replicas":    getRedisHAReplicaIndexes(cr),
+		"Defaults":    getRedisHAProxyDefaults(cr),
 	}
 
 	script, err := loadTemplateFile(path, vars)
@@ -488,8 +648,9 @@ func getRedisHAProxyConfig(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) string
 // If an error occurs, an empty string value will be returned.
 func getRedisHAProxyScript(cr *argoprojv1a1.ArgoCD) string {
 	path := fmt.Sprintf("%s/haproxy_init.sh.tpl", getRedisConfigPath())
-	vars := map[string]string{
+	vars := map[string]interface{}{
 		"ServiceName": nameWithSuffix("redis-ha", cr),
+		"Replicas":    getRedisHAReplicaIndexes(cr),
 	}
 
 	script, err := loadTemplateFile(path, vars)
@@ -502,7 +663,7 @@ func getRedisHAProxyScript(cr *argoprojv1a1.ArgoCD) string {
 
 // getRedisResources will return the ResourceRequirements for the Redis container.
 func getRedisResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
-	resources := corev1.ResourceRequirements{}
+	resources := getDefaultResources(common.ArgoCDRedisComponent)
 
 	// Allow override of resource requirements from CR
 	if cr.Spec.Redis.Resources != nil {
@@ -512,9 +673,24 @@ func getRedisResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
 	return resources
 }
 
+// getRedisHAProxyDefaults will return the settings of the defaults section of the Redis HA Proxy configuration,
+// including the overrides from the CR.
+func getRedisHAProxyDefaults(cr *argoprojv1a1.ArgoCD) map[string]string {
+	defaults := map[string]string{
+		"timeout connect": "4s",
+		"timeout server":  "6m",
+		"timeout client":  "6m",
+		"timeout check":   "2s",
+	}
+	for key, value := range cr.Spec.HA.RedisProxyConfig {
+		defaults[key] = value
+	}
+	return defaults
+}
+
 // getRedisHAProxyResources will return the ResourceRequirements for the Redis HA Proxy.
 func getRedisHAProxyResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
-	resources := corev1.ResourceRequirements{}
+	resources := getDefaultResources(sizingRedisHAProxyComponent)
 
 	// Allow override of resource requirements from CR
 	if cr.Spec.HA.Resources != nil {
@@ -526,10 +702,12 @@ func getRedisHAProxyResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequiremen
 
 // getRedisSentinelConf will load the redis sentinel configuration from a template on disk for the given ArgoCD.
 // If an error occurs, an empty string value will be returned.
-func getRedisSentinelConf(useTLSForRedis bool) string {
+func getRedisSentinelConf(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) string {
 	path := fmt.Sprintf("%s/sentinel.conf.tpl", getRedisConfigPath())
-	params := map[string]string{
-		"UseTLS": strconv.FormatBool(useTLSForRedis),
+	params := map[string]interface{}{
+		"UseTLS":         strconv.FormatBool(useTLSForRedis),
+		"UseAuth":        strconv.FormatBool(isRedisAuthEnabled(cr)),
+		"SentinelConfig": getRedisSentinelConfig(cr),
 	}
 	conf, err := loadTemplateFile(path, params)
 	if err != nil {
@@ -539,12 +717,36 @@ func getRedisSentinelConf(useTLSForRedis bool) string {
 	return conf
 }
 
+// getRedisSentinelConfig will return the settings of the master monitored by Redis Sentinel, including the
+// overrides from the CR.
+func getRedisSentinelConfig(cr *argoprojv1a1.ArgoCD) map[string]string {
+	config := map[string]string{
+		"down-after-milliseconds": "10000",
+		"failover-timeout":        "180000",
+		"parallel-syncs":          "5",
+	}
+	for key, value := range cr.Spec.HA.SentinelConfig {
+		config[key] = value
+	}
+	return config
+}
+
+// getRedisSentinelResources will return the ResourceRequirements for the Redis Sentinel container.
+func getRedisSentinelResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
+	// Allow override of resource requirements from CR
+	if cr.Spec.HA.SentinelResources != nil {
+		return *cr.Spec.HA.SentinelResources
+	}
+	return getRedisResources(cr)
+}
+
 // getRedisLivenessScript will load the redis liveness script from a template on disk for the given ArgoCD.
 // If an error occurs, an empty string value will be returned.
-func getRedisLivenessScript(useTLSForRedis bool) string {
+func getRedisLivenessScript(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) string {
 	path := fmt.Sprintf("%s/redis_liveness.sh.tpl", getRedisConfigPath())
 	params := map[string]string{
-		"UseTLS": strconv.FormatBool(useTLSForRedis),
+		"UseTLS":  strconv.FormatBool(useTLSForRedis),
+		"UseAuth": strconv.FormatBool(isRedisAuthEnabled(cr)),
 	}
 	conf, err := loadTemplateFile(path, params)
 	if err != nil {
@@ -556,10 +758,11 @@ func getRedisLivenessScript(useTLSForRedis bool) string {
 
 // getRedisReadinessScript will load the redis readiness script from a template on disk for the given ArgoCD.
 // If an error occurs, an empty string value will be returned.
-func getRedisReadinessScript(useTLSForRedis bool) string {
+func getRedisReadinessScript(cr *argoprojv1a1.ArgoCD, useTLSForRedis bool) string {
 	path := fmt.Sprintf("%s/redis_readiness.sh.tpl", getRedisConfigPath())
 	params := map[string]string{
-		"UseTLS": strconv.FormatBool(useTLSForRedis),
+		"UseTLS":  strconv.FormatBool(useTLSForRedis),
+		"UseAuth": strconv.FormatBool(isRedisAuthEnabled(cr)),
 	}
 	conf, err := loadTemplateFile(path, params)
 	if err != nil {
@@ -586,14 +789,98 @@ func getSentinelLivenessScript(useTLSForRedis bool) string {
 
 // getRedisServerAddress will return the Redis service address for the given ArgoCD.
 func getRedisServerAddress(cr *argoprojv1a1.ArgoCD) string {
+	if cr.Spec.Redis.IsRemote() {
+		return cr.Spec.Redis.Remote.Address
+	}
 	if cr.Spec.HA.Enabled {
 		return getRedisHAProxyAddress(cr)
 	}
 	return fqdnServiceRef(common.ArgoCDDefaultRedisSuffix, common.ArgoCDDefaultRedisPort, cr)
 }
 
+// getRedisServerArgs will return the command line arguments pointing an Argo CD component at the Redis server
+// for the given ArgoCD, using the Sentinels of a remote Redis server when configured.
+func getRedisServerArgs(cr *argoprojv1a1.ArgoCD) []string {
+	if cr.Spec.Redis.IsRemote() && len(cr.Spec.Redis.Remote.Sentinels) > 0 {
+		args := make([]string, 0)
+		for _, sentinel := range cr.Spec.Redis.Remote.Sentinels {
+			args = append(args, "--sentinel", sentinel)
+		}
+		if cr.Spec.Redis.Remote.SentinelMaster != "" {
+			args = append(args, "--sentinelmaster", cr.Spec.Redis.Remote.SentinelMaster)
+		}
+		return args
+	}
+	return []string{"--redis", getRedisServerAddress(cr)}
+}
+
+// isRedisAuthEnabled will return true if Redis AUTH is enabled for the Redis server deployed for the given ArgoCD.
+func isRedisAuthEnabled(cr *argoprojv1a1.ArgoCD) bool {
+	return cr.Spec.Redis.EnableAuth && !cr.Spec.Redis.IsRemote()
+}
+
+// getRedisAuthEnv will return the environment variable with the given name holding the password of the Redis server
+// deployed for the given ArgoCD, if Redis AUTH is enabled.
+func getRedisAuthEnv(cr *argoprojv1a1.ArgoCD, name string) []corev1.EnvVar {
+	if !isRedisAuthEnabled(cr) {
+		return nil
+	}
+	return []corev1.EnvVar{{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: common.ArgoCDRedisAuthSecretName,
+				},
+				Key: common.ArgoCDKeyRedisAuth,
+			},
+		},
+	}}
+}
+
+// getRedisCredentialsEnv will return the environment variables holding the credentials Argo CD components use to
+// connect to the Redis server for the given ArgoCD, if any.
+func getRedisCredentialsEnv(cr *argoprojv1a1.ArgoCD) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0)
+	if isRedisAuthEnabled(cr) {
+		return getRedisAuthEnv(cr, "REDIS_PASSWORD")
+	}
+	if !cr.Spec.Redis.IsRemote() || cr.Spec.Redis.Remote.CredentialsSecret == "" {
+		return env
+	}
+
+	secretKeyRef := func(key string, optional bool) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: cr.Spec.Redis.Remote.CredentialsSecret,
+				},
+				Key:      key,
+				Optional: boolPtr(optional),
+			},
+		}
+	}
+	env = append(env, corev1.EnvVar{Name: "REDIS_USERNAME", ValueFrom: secretKeyRef("username", true)})
+	env = append(env, corev1.EnvVar{Name: "REDIS_PASSWORD", ValueFrom: secretKeyRef("password", false)})
+	return env
+}
+
+// validateRedisConfiguration will return an error if the Redis configuration of the given ArgoCD is invalid.
+func validateRedisConfiguration(cr *argoprojv1a1.ArgoCD) error {
+	if !cr.Spec.Redis.IsRemote() {
+		return nil
+	}
+	if cr.Spec.HA.Enabled {
+		return fmt.Errorf("illegal Redis configuration: .spec.redis.remote cannot be set when .spec.ha.enabled is true")
+	}
+	if cr.Spec.Redis.Remote.Address == "" && len(cr.Spec.Redis.Remote.Sentinels) == 0 {
+		return fmt.Errorf("illegal Redis configuration: .spec.redis.remote requires an address or a list of sentinels")
+	}
+	return nil
+}
+
 // loadTemplateFile will parse a template with the given path and execute it with the given params.
-func loadTemplateFile(path string, params map[string]string) (string, error) {
+func loadTemplateFile(path string, params interface{}) (string, error) {
 	tmpl, err := template.ParseFiles(path)
 	if err != nil {
 		log.Error(err, "unable to parse template")
@@ -637,6 +924,14 @@ func InspectCluster() error {
 		return err
 	}
 
+	if err := verifyCertManagerAPI(); err != nil {
+		return err
+	}
+
+	if err := verifyIstioAPI(); err != nil {
+		return err
+	}
+
 	if err := verifyVersionAPI(); err != nil {
 		return err
 	}
@@ -658,6 +953,10 @@ func (r *ReconcileArgoCD) reconcileCertificateAuthority(cr *argoprojv1a1.ArgoCD)
 }
 
 func (r *ReconcileArgoCD) redisShouldUseTLS(cr *argoprojv1a1.ArgoCD) bool {
+	if cr.Spec.Redis.IsRemote() {
+		return cr.Spec.Redis.Remote.UseTLS
+	}
+
 	var tlsSecretObj corev1.Secret
 	tlsSecretName := types.NamespacedName{Namespace: cr.Namespace, Name: common.ArgoCDRedisServerTLSSecretName}
 	err := r.Client.Get(context.TODO(), tlsSecretName, &tlsSecretObj)
@@ -674,6 +973,10 @@ func (r *ReconcileArgoCD) redisShouldUseTLS(cr *argoprojv1a1.ArgoCD) bool {
 		// service, which in turn is owned by the controller. This method performs
 		// a lookup of the controller through the intermediate owning service.
 		for _, secretOwner := range secretOwnerRefs {
+			// The secret was issued by the operator itself.
+			if secretOwner.Kind == "ArgoCD" && secretOwner.Name == cr.Name {
+				return true
+			}
 			if isOwnerOfInterest(secretOwner) {
 				key := client.ObjectKey{Name: secretOwner.Name, Namespace: tlsSecretObj.GetNamespace()}
 				svc := &corev1.Service{}
@@ -706,9 +1009,30 @@ func (r *ReconcileArgoCD) redisShouldUseTLS(cr *argoprojv1a1.ArgoCD) bool {
 	return false
 }
 
+// isReconcilePaused returns true if the reconciliation of the resources of the given ArgoCD is paused through the
+// reconcile annotation.
+func isReconcilePaused(cr *argoprojv1a1.ArgoCD) bool {
+	return cr.Annotations[common.AnnotationReconcile] == common.ReconcilePaused
+}
+
+// reconcilePausedStatus will ensure that the Status properties of the given ArgoCD are updated while the
+// reconciliation of its resources is paused. No resources are created, changed or deleted.
+func (r *ReconcileArgoCD) reconcilePausedStatus(cr *argoprojv1a1.ArgoCD) error {
+	if err := r.setManagedNamespaces(cr); err != nil {
+		return err
+	}
+	return r.reconcileStatus(cr)
+}
+
 // reconcileResources will reconcile common ArgoCD resources.
 func (r *ReconcileArgoCD) reconcileResources(cr *argoprojv1a1.ArgoCD) error {
 
+	// Only the components whose sections of the spec changed are reconciled, when nothing else changed since the last
+	// successful reconciliation.
+	if components := r.reconciled.getDirtyComponents(cr); len(components) > 0 {
+		return r.reconcileComponents(cr, components)
+	}
+
 	// reconcile SSO first, because dex resources get reconciled through other function calls as well, not just through reconcileSSO (this is important
 	// so that dex resources can be appropriately cleaned up when DISABLE_DEX is set to true and the operator pod restarts but doesn't enter
 	// dex reconciliation again because dex is disabled, thus leaving hanging resources around if they are not also cleaned up in the main loop)
@@ -719,6 +1043,11 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoprojv1a1.ArgoCD) error {
 		return err
 	}
 
+	if err := validateRedisConfiguration(cr); err != nil {
+		log.Error(err, fmt.Sprintf("invalid Redis configuration for Argo CD %s in namespace %s", cr.Name, cr.Namespace))
+		return err
+	}
+
 	log.Info("reconciling status")
 	if err := r.reconcileStatus(cr); err != nil {
 		return err
@@ -759,81 +1088,36 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoprojv1a1.ArgoCD) error {
 		return err
 	}
 
-	log.Info("reconciling services")
-	if err := r.reconcileServices(cr); err != nil {
+	log.Info("reconciling disabled components")
+	if err := r.reconcileDisabledComponents(cr); err != nil {
 		return err
 	}
 
-	log.Info("reconciling deployments")
-	if err := r.reconcileDeployments(cr, useTLSForRedis); err != nil {
-		return err
-	}
-
-	log.Info("reconciling statefulsets")
-	if err := r.reconcileStatefulSets(cr, useTLSForRedis); err != nil {
-		return err
-	}
-
-	log.Info("reconciling autoscalers")
-	if err := r.reconcileAutoscalers(cr); err != nil {
-		return err
-	}
-
-	log.Info("reconciling ingresses")
-	if err := r.reconcileIngresses(cr); err != nil {
-		return err
-	}
-
-	if IsRouteAPIAvailable() {
-		log.Info("reconciling routes")
-		if err := r.reconcileRoutes(cr); err != nil {
-			return err
-		}
-	}
-
-	if IsPrometheusAPIAvailable() {
-		log.Info("reconciling prometheus")
-		if err := r.reconcilePrometheus(cr); err != nil {
-			return err
-		}
-
-		// Reconciles prometheusRule created to alert based on argo-cd workload status
-		if err := r.reconcilePrometheusRule(cr); err != nil {
-			return err
-		}
-
-		if err := r.reconcileMetricsServiceMonitor(cr); err != nil {
-			return err
-		}
-
-		if err := r.reconcileRepoServerServiceMonitor(cr); err != nil {
-			return err
-		}
-
-		if err := r.reconcileServerMetricsServiceMonitor(cr); err != nil {
-			return err
-		}
-	}
-
-	if cr.Spec.ApplicationSet != nil {
-		log.Info("reconciling ApplicationSet controller")
-		if err := r.reconcileApplicationSetController(cr); err != nil {
-			return err
-		}
-	}
-
-	if cr.Spec.Notifications.Enabled {
-		log.Info("reconciling Notifications controller")
-		if err := r.reconcileNotificationsController(cr); err != nil {
-			return err
+	// The components only depend on the secrets and config maps reconciled above, so they are reconciled concurrently.
+	// A failing component does not prevent the other components from being reconciled.
+	log.Info("reconciling components")
+	errs := r.reconcileComponentGroups(cr, useTLSForRedis)
+
+	for _, group := range []componentGroup{
+		{"certificates", func() error { return r.reconcileCertManagerCertificates(cr) }},
+		{"repo-server", func() error { return r.reconcileRepoServerTLSSecret(cr) }},
+		{"server", func() error { return r.reconcileServerTLSSecret(cr) }},
+		{"dex", func() error { return r.reconcileDexTLSSecret(cr) }},
+		{"redis", func() error { return r.reconcileRedisTLSSecret(cr, useTLSForRedis) }},
+		{"monitoring", func() error { return r.reconcileExternalGrafanaDashboards(cr) }},
+	} {
+		if err := group.reconcile(); err != nil {
+			errs = append(errs, &componentError{component: group.name, err: err})
 		}
 	}
 
-	if err := r.reconcileRepoServerTLSSecret(cr); err != nil {
-		return err
+	r.reconcileStatusComponentErrors(cr, errs)
+	if len(errs) > 0 {
+		return errs
 	}
 
-	if err := r.reconcileRedisTLSSecret(cr, useTLSForRedis); err != nil {
+	// The cleanup runs last, once the resources needed by the spec are reconciled.
+	if err := r.reconcileResourceCleanup(cr); err != nil {
 		return err
 	}
 
@@ -939,7 +1223,7 @@ func removeString(slice []string, s string) []string {
 }
 
 // setResourceWatches will register Watches for each of the supported Resources.
-func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretMapper, namespaceResourceMapper handler.MapFunc) *builder.Builder {
+func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretMapper, namespaceResourceMapper, clusterSecretResourceMapper, resourceQuotaMapper handler.MapFunc) *builder.Builder {
 
 	deploymentConfigPred := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
@@ -983,6 +1267,10 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 			if !ok {
 				return false
 			}
+			if isReconcilePaused(newCR) {
+				// Resources are not deleted while the reconciliation is paused
+				return true
+			}
 
 			// Handle deletion of SSO from Argo CD custom resource
 			if !reflect.DeepEqual(oldCR.Spec.SSO, newCR.Spec.SSO) && newCR.Spec.SSO == nil {
@@ -1027,6 +1315,10 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 			if !ok {
 				return false
 			}
+			if isReconcilePaused(newCR) {
+				// Resources are not deleted while the reconciliation is paused
+				return true
+			}
 			if oldCR.Spec.Notifications.Enabled && !newCR.Spec.Notifications.Enabled {
 				err := r.deleteNotificationsResources(newCR)
 				if err != nil {
@@ -1038,19 +1330,26 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 		},
 	}
 
+	// Instances that do not match the label selector of the operator are ignored, before any other predicate runs.
+	labelSelectorPred := predicate.NewPredicateFuncs(r.Options.matchesLabelSelector)
+
 	// Watch for changes to primary resource ArgoCD
-	bldr.For(&argoprojv1a1.ArgoCD{}, builder.WithPredicates(deleteSSOPred, deleteNotificationsPred))
+	bldr.For(&argoprojv1a1.ArgoCD{}, builder.WithPredicates(labelSelectorPred, deleteSSOPred, deleteNotificationsPred))
+
+	// Secrets and ConfigMaps change often in busy namespaces, only watch for changes to their content.
+	resourceContentPred := resourceContentFilterPredicate()
 
 	// Watch for changes to ConfigMap sub-resources owned by ArgoCD instances.
-	bldr.Owns(&corev1.ConfigMap{})
+	bldr.Owns(&corev1.ConfigMap{}, builder.WithPredicates(resourceContentPred))
 
 	// Watch for changes to Secret sub-resources owned by ArgoCD instances.
-	bldr.Owns(&corev1.Secret{})
+	bldr.Owns(&corev1.Secret{}, builder.WithPredicates(resourceContentPred))
 
 	// Watch for changes to Service sub-resources owned by ArgoCD instances.
 	bldr.Owns(&corev1.Service{})
 
-	// Watch for changes to Deployment sub-resources owned by ArgoCD instances.
+	// Watch for changes to Deployment sub-resources owned by ArgoCD instances. This includes changes to their status, so
+	// that the status of the components follows the availability of their workloads.
 	bldr.Owns(&appsv1.Deployment{})
 
 	// Watch for changes to Ingress sub-resources owned by ArgoCD instances.
@@ -1069,11 +1368,15 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 	bldr.Watches(&source.Kind{Type: &v1.ClusterRole{}}, clusterResourceHandler)
 
 	// Watch for secrets of type TLS that might be created by external processes
-	bldr.Watches(&source.Kind{Type: &corev1.Secret{Type: corev1.SecretTypeTLS}}, tlsSecretHandler)
+	bldr.Watches(&source.Kind{Type: &corev1.Secret{Type: corev1.SecretTypeTLS}}, tlsSecretHandler,
+		builder.WithPredicates(predicate.NewPredicateFuncs(isSecretOfInterest), resourceContentPred))
 
-	// Watch for changes to Secret sub-resources owned by ArgoCD instances.
+	// Watch for changes to StatefulSet sub-resources owned by ArgoCD instances, including changes to their status.
 	bldr.Owns(&appsv1.StatefulSet{})
 
+	// Watch for changes to PodDisruptionBudget sub-resources owned by ArgoCD instances.
+	bldr.Owns(&policyv1.PodDisruptionBudget{})
+
 	// Inspect cluster to verify availability of extra features
 	// This sets the flags that are used in subsequent checks
 	if err := InspectCluster(); err != nil {
@@ -1102,6 +1405,14 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 			builder.WithPredicates(deploymentConfigPred))
 	}
 
+	// Watch for cluster secrets, the number of managed clusters drives the Application controller
+	// replicas when dynamic scaling is enabled
+	clusterSecretHandler := handler.EnqueueRequestsFromMapFunc(clusterSecretResourceMapper)
+	bldr.Watches(&source.Kind{Type: &corev1.Secret{}}, clusterSecretHandler, builder.WithPredicates(resourceContentPred))
+
+	// Watch for resource quotas, the resources of the components are validated against them
+	bldr.Watches(&source.Kind{Type: &corev1.ResourceQuota{}}, handler.EnqueueRequestsFromMapFunc(resourceQuotaMapper))
+
 	namespaceHandler := handler.EnqueueRequestsFromMapFunc(namespaceResourceMapper)
 
 	bldr.Watches(&source.Kind{Type: &corev1.Namespace{}}, namespaceHandler, builder.WithPredicates(namespaceFilterPredicate()))
@@ -1148,6 +1459,33 @@ func allowedNamespace(current string, namespaces string) bool {
 	return false
 }
 
+// isManagedNamespaceAllowed returns true if the Argo CD instance in the given namespace is allowed to manage the given
+// namespace. The allow-list is a semicolon separated list of entries in the form <argocd-namespace>=<patterns>, where
+// patterns is a comma separated list of glob patterns of namespaces, and an argocd-namespace of '*' matches every
+// instance. All namespaces are allowed when no allow-list is set.
+func isManagedNamespaceAllowed(argocdNamespace, namespace string) bool {
+	allowList := strings.TrimSpace(os.Getenv(common.ArgoCDManagedNamespacesAllowListEnvName))
+	if allowList == "" || namespace == argocdNamespace {
+		return true
+	}
+
+	for _, entry := range strings.Split(allowList, ";") {
+		owner, patterns, found := strings.Cut(entry, "=")
+		if !found {
+			continue
+		}
+		if owner = strings.TrimSpace(owner); owner != "*" && owner != argocdNamespace {
+			continue
+		}
+		for _, pattern := range splitList(patterns) {
+			if matched, _ := path.Match(pattern, namespace); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func splitList(s string) []string {
 	elems := strings.Split(s, ",")
 	for i := range elems {
@@ -1172,6 +1510,7 @@ type DeprecationEventEmissionStatus struct {
 	DexSpecDeprecationWarningEmitted                bool
 	DisableDexDeprecationWarningEmitted             bool
 	ResourceCustomizationsDeprecationWarningEmitted bool
+	ResourceFiltersDeprecationWarningEmitted        bool
 }
 
 // DeprecationEventEmissionTracker map stores the namespace containing ArgoCD instance as key and DeprecationEventEmissionStatus as value,
@@ -1179,6 +1518,33 @@ type DeprecationEventEmissionStatus struct {
 // This is temporary and can be removed in v0.0.6 when we remove the deprecated fields.
 var DeprecationEventEmissionTracker = make(map[string]DeprecationEventEmissionStatus)
 
+// deprecationEventEmissionTrackerMutex guards DeprecationEventEmissionTracker, as ArgoCD instances can be reconciled
+// concurrently.
+var deprecationEventEmissionTrackerMutex sync.Mutex
+
+// getDeprecationEventEmissionStatus returns the deprecation events emitted for the instance in the given namespace. The
+// returned bool is false if no event was emitted for the instance yet.
+func getDeprecationEventEmissionStatus(namespace string) (DeprecationEventEmissionStatus, bool) {
+	deprecationEventEmissionTrackerMutex.Lock()
+	defer deprecationEventEmissionTrackerMutex.Unlock()
+	status, ok := DeprecationEventEmissionTracker[namespace]
+	return status, ok
+}
+
+// setDeprecationEventEmissionStatus records the deprecation events emitted for the instance in the given namespace.
+func setDeprecationEventEmissionStatus(namespace string, status DeprecationEventEmissionStatus) {
+	deprecationEventEmissionTrackerMutex.Lock()
+	defer deprecationEventEmissionTrackerMutex.Unlock()
+	DeprecationEventEmissionTracker[namespace] = status
+}
+
+// deleteDeprecationEventEmissionStatus forgets the deprecation events emitted for the instance in the given namespace.
+func deleteDeprecationEventEmissionStatus(namespace string) {
+	deprecationEventEmissionTrackerMutex.Lock()
+	defer deprecationEventEmissionTrackerMutex.Unlock()
+	delete(DeprecationEventEmissionTracker, namespace)
+}
+
 func namespaceFilterPredicate() predicate.Predicate {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
@@ -1249,15 +1615,77 @@ func namespaceFilterPredicate() predicate.Predicate {
 
 			// if a namespace is deleted, remove it from deprecationEventEmissionTracker (if exists) so that if a namespace with the same name
 			// is created in the future and contains an Argo CD instance, it will be tracked appropriately
-			if _, ok := DeprecationEventEmissionTracker[e.Object.GetName()]; ok {
-				delete(DeprecationEventEmissionTracker, e.Object.GetName())
-			}
+			deleteDeprecationEventEmissionStatus(e.Object.GetName())
 
 			return false
 		},
 	}
 }
 
+// helmReleaseSecretType is the type of the Secrets in which Helm stores its releases.
+const helmReleaseSecretType corev1.SecretType = "helm.sh/release.v1"
+
+// ignoredResourceLabels are the labels of Secrets and ConfigMaps that are never used by ArgoCD instances and that
+// change often, e.g. the release storage of Helm or the temporary private keys of cert-manager.
+var ignoredResourceLabels = map[string]string{
+	"owner":                            "helm",
+	"cert-manager.io/next-private-key": "true",
+}
+
+// isIgnoredResource returns true if the given Secret or ConfigMap is never used by ArgoCD instances.
+func isIgnoredResource(o client.Object) bool {
+	if secret, ok := o.(*corev1.Secret); ok && secret.Type == helmReleaseSecretType {
+		return true
+	}
+	for k, v := range ignoredResourceLabels {
+		if o.GetLabels()[k] == v {
+			return true
+		}
+	}
+	return false
+}
+
+// hasResourceContentChanged returns true if the content of the given Secret or ConfigMap, its labels, annotations or
+// owners changed. Updates that only change other metadata, e.g. the managed fields, and resyncs are not relevant.
+func hasResourceContentChanged(oldObj, newObj client.Object) bool {
+	if !reflect.DeepEqual(oldObj.GetLabels(), newObj.GetLabels()) ||
+		!reflect.DeepEqual(oldObj.GetAnnotations(), newObj.GetAnnotations()) ||
+		!reflect.DeepEqual(oldObj.GetOwnerReferences(), newObj.GetOwnerReferences()) ||
+		!reflect.DeepEqual(oldObj.GetDeletionTimestamp(), newObj.GetDeletionTimestamp()) {
+		return true
+	}
+	switch newRes := newObj.(type) {
+	case *corev1.Secret:
+		oldRes, ok := oldObj.(*corev1.Secret)
+		return !ok || oldRes.Type != newRes.Type || !reflect.DeepEqual(oldRes.Data, newRes.Data) ||
+			!reflect.DeepEqual(oldRes.StringData, newRes.StringData)
+	case *corev1.ConfigMap:
+		oldRes, ok := oldObj.(*corev1.ConfigMap)
+		return !ok || !reflect.DeepEqual(oldRes.Data, newRes.Data) || !reflect.DeepEqual(oldRes.BinaryData, newRes.BinaryData)
+	default:
+		return true
+	}
+}
+
+// resourceContentFilterPredicate filters the events of Secrets and ConfigMaps, so that changes to resources that are
+// never used by ArgoCD instances, or that do not change their content, do not trigger reconciliations.
+func resourceContentFilterPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return !isIgnoredResource(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !isIgnoredResource(e.ObjectNew) && hasResourceContentChanged(e.ObjectOld, e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return !isIgnoredResource(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return !isIgnoredResource(e.Object)
+		},
+	}
+}
+
 // deleteRBACsForNamespace deletes the RBACs when the label from the namespace is removed.
 func deleteRBACsForNamespace(sourceNS string, k8sClient kubernetes.Interface) error {
 	log.Info(fmt.Sprintf("Removing the RBACs created for the namespace: %s", sourceNS))
@@ -1296,6 +1724,23 @@ func deleteRBACsForNamespace(sourceNS string, k8sClient kubernetes.Interface) er
 	return nil
 }
 
+// getClusterSecrets will return the cluster secrets in the namespace of the given ArgoCD.
+func (r *ReconcileArgoCD) getClusterSecrets(cr *argoprojv1a1.ArgoCD) (*corev1.SecretList, error) {
+	clusterSecrets := &corev1.SecretList{}
+	opts := &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			common.ArgoCDSecretTypeLabel: "cluster",
+		}),
+		Namespace: cr.Namespace,
+	}
+
+	if err := r.Client.List(context.TODO(), clusterSecrets, opts); err != nil {
+		return nil, err
+	}
+
+	return clusterSecrets, nil
+}
+
 func deleteManagedNamespaceFromClusterSecret(ownerNS, sourceNS string, k8sClient kubernetes.Interface) error {
 
 	// Get the cluster secret used for configuring ArgoCD
@@ -1371,6 +1816,88 @@ func getLogFormat(logField string) string {
 	return common.ArgoCDDefaultLogFormat
 }
 
+// reconcileManagedNamespacesFromSpec will ensure that the namespaces listed in .spec.managedNamespaces are labelled
+// as managed by the given ArgoCD, and that namespaces previously labelled because of the list, but no longer listed,
+// are released again. Roles, RoleBindings and the cluster secret follow the label of the namespaces.
+func (r *ReconcileArgoCD) reconcileManagedNamespacesFromSpec(cr *argoproj.ArgoCD) error {
+	desired := make(map[string]bool)
+	if cr.GetDeletionTimestamp() == nil {
+		for _, ns := range cr.Spec.ManagedNamespaces {
+			desired[ns] = true
+		}
+	}
+
+	for ns := range desired {
+		namespace := &corev1.Namespace{}
+		if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ns}, namespace); err != nil {
+			if errors.IsNotFound(err) {
+				log.Info(fmt.Sprintf("managed namespace %s not found, skipping", ns))
+				continue
+			}
+			return err
+		}
+
+		if value, ok := namespace.Labels[common.ArgoCDManagedByLabel]; ok && value != cr.Namespace {
+			log.Info(fmt.Sprintf("namespace %s is already managed by argocd instance in namespace %s, skipping", ns, value))
+			continue
+		}
+
+		if !isManagedNamespaceAllowed(cr.Namespace, ns) {
+			log.Info(fmt.Sprintf("namespace %s is not allowed to be managed by argocd instance in namespace %s, skipping", ns, cr.Namespace))
+			continue
+		}
+
+		if !r.Options.isNamespaceWatched(ns) {
+			log.Info(fmt.Sprintf("namespace %s is not watched by the operator, skipping", ns))
+			continue
+		}
+
+		// the namespace is either labelled by the operator already, or by the user, in which case it is not
+		// annotated so that it is not released once removed from the spec
+		if namespace.Labels[common.ArgoCDManagedByLabel] == cr.Namespace {
+			continue
+		}
+
+		if namespace.Labels == nil {
+			namespace.Labels = make(map[string]string)
+		}
+		if namespace.Annotations == nil {
+			namespace.Annotations = make(map[string]string)
+		}
+		namespace.Labels[common.ArgoCDManagedByLabel] = cr.Namespace
+		namespace.Annotations[common.AnnotationManagedNamespace] = cr.Namespace
+		if err := r.Client.Update(context.TODO(), namespace); err != nil {
+			return err
+		}
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	listOption := client.MatchingLabels{
+		common.ArgoCDManagedByLabel: cr.Namespace,
+	}
+	if err := r.Client.List(context.TODO(), namespaces, listOption); err != nil {
+		return err
+	}
+
+	for i := range namespaces.Items {
+		namespace := &namespaces.Items[i]
+		if namespace.Annotations[common.AnnotationManagedNamespace] != cr.Namespace ||
+			(desired[namespace.Name] && isManagedNamespaceAllowed(cr.Namespace, namespace.Name)) {
+			continue
+		}
+
+		// the namespace was labelled by the operator and is no longer listed in the spec, or no longer allowed
+		delete(namespace.Labels, common.ArgoCDManagedByLabel)
+		delete(namespace.Annotations, common.AnnotationManagedNamespace)
+		if err := r.Client.Update(context.TODO(), namespace); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("namespace %s is no longer managed by argocd instance in namespace %s", namespace.Name, cr.Namespace))
+	}
+
+	return nil
+}
+
 func (r *ReconcileArgoCD) setManagedNamespaces(cr *argoproj.ArgoCD) error {
 	namespaces := &corev1.NamespaceList{}
 	listOption := client.MatchingLabels{
@@ -1382,6 +1909,21 @@ func (r *ReconcileArgoCD) setManagedNamespaces(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	// ignore the label on namespaces the instance is not allowed to manage
+	allowed := namespaces.Items[:0]
+	for _, namespace := range namespaces.Items {
+		if !isManagedNamespaceAllowed(cr.Namespace, namespace.Name) {
+			log.Info(fmt.Sprintf("ignoring namespace %s, which is not allowed to be managed by argocd instance in namespace %s", namespace.Name, cr.Namespace))
+			continue
+		}
+		if !r.Options.isNamespaceWatched(namespace.Name) {
+			log.Info(fmt.Sprintf("ignoring namespace %s, which is not watched by the operator", namespace.Name))
+			continue
+		}
+		allowed = append(allowed, namespace)
+	}
+	namespaces.Items = allowed
+
 	namespaces.Items = append(namespaces.Items, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cr.Namespace}})
 	r.ManagedNamespaces = namespaces
 	return nil
@@ -1432,6 +1974,96 @@ func (r *ReconcileArgoCD) removeUnmanagedSourceNamespaceResources(cr *argoproj.A
 	return nil
 }
 
+// removeStaleManagedNamespaceRBACs will remove the Roles and RoleBindings of the given ArgoCD from namespaces that are
+// no longer labelled as managed by any Argo CD instance, e.g. because the label was removed while the operator was not
+// running. An event is emitted for each removed resource.
+func (r *ReconcileArgoCD) removeStaleManagedNamespaceRBACs(cr *argoproj.ArgoCD) error {
+	names := make(map[string]bool)
+	for _, param := range getPolicyRuleList(r.Client) {
+		names[generateResourceName(param.name, cr)] = true
+	}
+
+	listOption := client.MatchingLabels{
+		common.ArgoCDKeyPartOf:    common.ArgoCDAppName,
+		common.ArgoCDKeyManagedBy: cr.Name,
+	}
+
+	roles := &v1.RoleList{}
+	if err := r.Client.List(context.TODO(), roles, listOption); err != nil {
+		return err
+	}
+	for i := range roles.Items {
+		if !names[roles.Items[i].Name] {
+			continue
+		}
+		if err := r.removeStaleManagedNamespaceRBAC("Role", &roles.Items[i], cr); err != nil {
+			return err
+		}
+	}
+
+	roleBindings := &v1.RoleBindingList{}
+	if err := r.Client.List(context.TODO(), roleBindings, listOption); err != nil {
+		return err
+	}
+	for i := range roleBindings.Items {
+		if !names[roleBindings.Items[i].Name] {
+			continue
+		}
+		if err := r.removeStaleManagedNamespaceRBAC("RoleBinding", &roleBindings.Items[i], cr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeStaleManagedNamespaceRBAC will delete the given Role or RoleBinding of the given ArgoCD if its namespace is not
+// managed by an Argo CD instance.
+func (r *ReconcileArgoCD) removeStaleManagedNamespaceRBAC(kind string, obj client.Object, cr *argoproj.ArgoCD) error {
+	if obj.GetNamespace() == cr.Namespace {
+		return nil
+	}
+	// RoleBindings record the namespace of the ArgoCD they were created for.
+	if ns, ok := obj.GetAnnotations()[common.AnnotationNamespace]; ok && ns != cr.Namespace {
+		return nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: obj.GetNamespace()}, namespace); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if namespace.DeletionTimestamp != nil {
+		return nil
+	}
+	// Namespaces labelled for the instance, but not allowed to be managed by it, are treated as unlabelled.
+	if value, ok := namespace.Labels[common.ArgoCDManagedByLabel]; ok &&
+		(value != cr.Namespace || isManagedNamespaceAllowed(cr.Namespace, namespace.Name)) {
+		return nil
+	}
+
+	// Argo CD instances with the same name in other namespaces own resources with the same name and labels.
+	list := &argoproj.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), list, &client.ListOptions{Namespace: namespace.Name}); err != nil {
+		return err
+	}
+	if len(list.Items) > 0 {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("deleting stale %s %s from namespace %s, which is no longer managed by Argo CD instance %s", kind, obj.GetName(), namespace.Name, cr.Name))
+	if err := r.Client.Delete(context.TODO(), obj); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	message := fmt.Sprintf("removed stale %s %s from namespace %s, which is no longer managed by Argo CD instance %s", kind, obj.GetName(), namespace.Name, cr.Name)
+	if err := argoutil.CreateEvent(r.Client, "Normal", "Deleted", message, "StaleRBACRemoved", cr.ObjectMeta, cr.TypeMeta); err != nil {
+		log.Error(err, "failed to create event")
+	}
+	return nil
+}
+
 func (r *ReconcileArgoCD) cleanupUnmanagedSourceNamespaceResources(cr *argoproj.ArgoCD, ns string) error {
 	namespace := corev1.Namespace{}
 	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ns}, &namespace); err != nil {
@@ -1472,6 +2104,21 @@ func (r *ReconcileArgoCD) cleanupUnmanagedSourceNamespaceResources(cr *argoproj.
 			return err
 		}
 	}
+
+	// Delete the notifications controller Role and RoleBinding for SourceNamespaces
+	notificationsRBACName := getNotificationsRoleNameForSourceNamespaces(cr)
+	notificationsRoleBinding := &v1.RoleBinding{}
+	if argoutil.IsObjectFound(r.Client, namespace.Name, notificationsRBACName, notificationsRoleBinding) {
+		if err := r.Client.Delete(context.TODO(), notificationsRoleBinding); err != nil {
+			return err
+		}
+	}
+	notificationsRole := &v1.Role{}
+	if argoutil.IsObjectFound(r.Client, namespace.Name, notificationsRBACName, notificationsRole) {
+		if err := r.Client.Delete(context.TODO(), notificationsRole); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
@@ -18,6 +19,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	testclient "k8s.io/client-go/kubernetes/fake"
 )
 
@@ -142,6 +144,17 @@ var imageTests = []struct {
 			a.Spec.Redis.Version = "latest-ha"
 		}},
 	},
+	{
+		name:      "redis ha specific spec configuration",
+		imageFunc: getRedisHAContainerImage,
+		want:      redisHATestImage,
+		opts: []argoCDOpt{func(a *argoprojv1alpha1.ArgoCD) {
+			a.Spec.Redis.Image = "testing/redis-standalone"
+			a.Spec.Redis.Version = "latest"
+			a.Spec.HA.RedisImage = "testing/redis"
+			a.Spec.HA.RedisVersion = "latest-ha"
+		}},
+	},
 	{
 		name:      "redis ha env configuration",
 		imageFunc: getRedisHAContainerImage,
@@ -210,6 +223,24 @@ var argoServerURITests = []struct {
 		}},
 		want: "https://test-host-name",
 	},
+	{
+		name:         "test with root path",
+		routeEnabled: false,
+		opts: []argoCDOpt{func(a *argoprojv1alpha1.ArgoCD) {
+			a.Spec.Server.Host = "test-host-name"
+			a.Spec.Server.RootPath = "/argocd/"
+		}},
+		want: "https://test-host-name/argocd",
+	},
+	{
+		name:         "test with external URL",
+		routeEnabled: false,
+		opts: []argoCDOpt{func(a *argoprojv1alpha1.ArgoCD) {
+			a.Spec.Server.Host = "test-host-name"
+			a.Spec.Server.ExternalURL = "https://argocd.example.com/"
+		}},
+		want: "https://argocd.example.com",
+	},
 }
 
 func setRouteAPIFound(t *testing.T, routeEnabled bool) {
@@ -381,6 +412,29 @@ func TestGetArgoApplicationControllerCommand(t *testing.T) {
 				"text",
 			},
 		},
+		{
+			"configured selfHealTimeout",
+			[]argoCDOpt{selfHealTimeout(time.Second * 30)},
+			[]string{
+				"argocd-application-controller",
+				"--operation-processors",
+				"10",
+				"--redis",
+				"argocd-redis.argocd.svc.cluster.local:6379",
+				"--repo-server",
+				"argocd-repo-server.argocd.svc.cluster.local:8081",
+				"--status-processors",
+				"20",
+				"--kubectl-parallelism-limit",
+				"10",
+				"--self-heal-timeout-seconds",
+				"30",
+				"--loglevel",
+				"info",
+				"--logformat",
+				"text",
+			},
+		},
 		{
 			"configured parallelism limit",
 			[]argoCDOpt{parallelismLimit(30)},
@@ -606,6 +660,179 @@ func TestSetManagedNamespaces(t *testing.T) {
 	}
 }
 
+func TestIsManagedNamespaceAllowed(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowList string
+		namespace string
+		want      bool
+	}{
+		{"no allow-list", "", "team-a-dev", true},
+		{"own namespace", "argocd=team-a-*", testNamespace, true},
+		{"matching pattern", "argocd=team-a-*", "team-a-dev", true},
+		{"one of several patterns", "argocd=team-a-*, shared", "shared", true},
+		{"not matching pattern", "argocd=team-a-*", "team-b-dev", false},
+		{"entry for another instance", "team-b=team-b-*", "team-b-dev", false},
+		{"entry for all instances", "team-b=team-b-*;*=shared-*", "shared-tools", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(common.ArgoCDManagedNamespacesAllowListEnvName, test.allowList)
+			assert.Equal(t, test.want, isManagedNamespaceAllowed(testNamespace, test.namespace))
+		})
+	}
+}
+
+func TestSetManagedNamespaces_watchNamespaces(t *testing.T) {
+	a := makeTestArgoCD()
+	nsList := &v1.NamespaceList{
+		Items: []v1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "tenant-a",
+					Labels: map[string]string{
+						common.ArgoCDManagedByLabel: testNamespace,
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "tenant-b",
+					Labels: map[string]string{
+						common.ArgoCDManagedByLabel: testNamespace,
+					},
+				},
+			},
+		},
+	}
+	r := makeTestReconciler(t, a, nsList)
+	r.Options.WatchNamespaces = ParseWatchNamespaces("argocd,tenant-a")
+
+	assert.NoError(t, r.setManagedNamespaces(a))
+
+	names := []string{}
+	for _, n := range r.ManagedNamespaces.Items {
+		names = append(names, n.Name)
+	}
+	assert.ElementsMatch(t, []string{"tenant-a", testNamespace}, names)
+
+	// namespaces that are not watched by the operator are reported as rejected
+	assert.NoError(t, r.reconcileStatusManagedNamespaces(a))
+	assert.Equal(t, []string{"tenant-a"}, a.Status.ManagedNamespaces)
+	assert.Equal(t, []string{"tenant-b"}, a.Status.RejectedNamespaces)
+}
+
+func TestSetManagedNamespaces_allowList(t *testing.T) {
+	t.Setenv(common.ArgoCDManagedNamespacesAllowListEnvName, "argocd=team-a-*")
+	a := makeTestArgoCD()
+	nsList := &v1.NamespaceList{
+		Items: []v1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "team-a-dev",
+					Labels: map[string]string{
+						common.ArgoCDManagedByLabel: testNamespace,
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "team-b-dev",
+					Labels: map[string]string{
+						common.ArgoCDManagedByLabel: testNamespace,
+					},
+				},
+			},
+		},
+	}
+	r := makeTestReconciler(t, a, nsList)
+
+	assert.NoError(t, r.setManagedNamespaces(a))
+
+	names := []string{}
+	for _, n := range r.ManagedNamespaces.Items {
+		names = append(names, n.Name)
+	}
+	assert.ElementsMatch(t, []string{"team-a-dev", testNamespace}, names)
+
+	// the rejected namespace is reported in the status
+	assert.NoError(t, r.reconcileStatusManagedNamespaces(a))
+	assert.Equal(t, []string{"team-a-dev"}, a.Status.ManagedNamespaces)
+	assert.Equal(t, []string{"team-b-dev"}, a.Status.RejectedNamespaces)
+
+	// namespaces listed in the spec are not labelled if they are not allowed
+	assert.NoError(t, r.Client.Create(context.TODO(), &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-c-dev"}}))
+	a.Spec.ManagedNamespaces = []string{"team-c-dev"}
+	assert.NoError(t, r.reconcileManagedNamespacesFromSpec(a))
+
+	ns := &v1.Namespace{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "team-c-dev"}, ns))
+	assert.NotContains(t, ns.Labels, common.ArgoCDManagedByLabel)
+}
+
+func TestReconcileManagedNamespacesFromSpec(t *testing.T) {
+	a := makeTestArgoCD()
+	a.Spec.ManagedNamespaces = []string{"test-namespace-1", "test-namespace-2", "test-namespace-3", "test-namespace-missing"}
+	nsList := &v1.NamespaceList{
+		Items: []v1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-namespace-1",
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-namespace-2",
+					Labels: map[string]string{
+						common.ArgoCDManagedByLabel: "random-namespace",
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-namespace-3",
+					Labels: map[string]string{
+						common.ArgoCDManagedByLabel: testNamespace,
+					},
+				},
+			},
+		},
+	}
+	r := makeTestReconciler(t, nsList)
+
+	assert.NoError(t, r.reconcileManagedNamespacesFromSpec(a))
+
+	ns := &v1.Namespace{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "test-namespace-1"}, ns))
+	assert.Equal(t, testNamespace, ns.Labels[common.ArgoCDManagedByLabel])
+	assert.Equal(t, testNamespace, ns.Annotations[common.AnnotationManagedNamespace])
+
+	// namespaces managed by another instance are left untouched
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "test-namespace-2"}, ns))
+	assert.Equal(t, "random-namespace", ns.Labels[common.ArgoCDManagedByLabel])
+	assert.Empty(t, ns.Annotations[common.AnnotationManagedNamespace])
+
+	// namespaces already labelled by the user are not annotated
+	ns = &v1.Namespace{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "test-namespace-3"}, ns))
+	assert.Equal(t, testNamespace, ns.Labels[common.ArgoCDManagedByLabel])
+	assert.NotContains(t, ns.Annotations, common.AnnotationManagedNamespace)
+
+	// removing a namespace from the spec releases it again
+	a.Spec.ManagedNamespaces = []string{}
+	assert.NoError(t, r.reconcileManagedNamespacesFromSpec(a))
+
+	ns = &v1.Namespace{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "test-namespace-1"}, ns))
+	assert.NotContains(t, ns.Labels, common.ArgoCDManagedByLabel)
+	assert.NotContains(t, ns.Annotations, common.AnnotationManagedNamespace)
+
+	// namespaces labelled by the user are not released
+	ns = &v1.Namespace{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "test-namespace-3"}, ns))
+	assert.Equal(t, testNamespace, ns.Labels[common.ArgoCDManagedByLabel])
+}
+
 func TestSetManagedSourceNamespaces(t *testing.T) {
 	a := makeTestArgoCD()
 	a.Spec = v1alpha1.ArgoCDSpec{
@@ -684,3 +911,145 @@ func TestReconcileArgoCD_reconcileDexOAuthClientSecret(t *testing.T) {
 	}
 	assert.True(t, tokenExists, "Dex is enabled but unable to create oauth client secret")
 }
+
+func TestGetRedisServerArgs(t *testing.T) {
+	cr := makeTestArgoCD()
+	assert.Equal(t, []string{"--redis", "argocd-redis.argocd.svc.cluster.local:6379"}, getRedisServerArgs(cr))
+
+	cr.Spec.Redis.Remote = &v1alpha1.ArgoCDRedisRemoteSpec{Address: "redis.example.com:6380"}
+	assert.Equal(t, []string{"--redis", "redis.example.com:6380"}, getRedisServerArgs(cr))
+
+	cr.Spec.Redis.Remote.Sentinels = []string{"sentinel-0:26379", "sentinel-1:26379"}
+	cr.Spec.Redis.Remote.SentinelMaster = "argocd"
+	assert.Equal(t, []string{
+		"--sentinel", "sentinel-0:26379",
+		"--sentinel", "sentinel-1:26379",
+		"--sentinelmaster", "argocd",
+	}, getRedisServerArgs(cr))
+}
+
+func TestValidateRedisConfiguration(t *testing.T) {
+	cr := makeTestArgoCD()
+	assert.NoError(t, validateRedisConfiguration(cr))
+
+	cr.Spec.Redis.Remote = &v1alpha1.ArgoCDRedisRemoteSpec{}
+	assert.Error(t, validateRedisConfiguration(cr))
+
+	cr.Spec.Redis.Remote.Address = "redis.example.com:6379"
+	assert.NoError(t, validateRedisConfiguration(cr))
+
+	cr.Spec.HA.Enabled = true
+	assert.Error(t, validateRedisConfiguration(cr))
+}
+
+func TestGetRedisHAConfiguration(t *testing.T) {
+	t.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+	cr := makeTestArgoCD()
+
+	haproxyCfg := getRedisHAProxyConfig(cr, false)
+	assert.Contains(t, haproxyCfg, "    timeout server 6m\n")
+	assert.Contains(t, haproxyCfg, "backend check_if_redis_is_master_2\n")
+	assert.NotContains(t, haproxyCfg, "check_if_redis_is_master_3")
+	assert.Contains(t, haproxyCfg, "{ nbsrv(check_if_redis_is_master_0) ge 2 }")
+	assert.Contains(t, getRedisInitScript(cr, false), `QUORUM="2"`)
+	assert.Contains(t, getRedisSentinelConf(cr, false), "    sentinel down-after-milliseconds argocd 10000\n")
+	assert.Len(t, getRedisHASentinelIDEnv(cr), 3)
+
+	replicas := int32(5)
+	cr.Spec.HA.Replicas = &replicas
+	cr.Spec.HA.RedisProxyConfig = map[string]string{"timeout server": "30m"}
+	cr.Spec.HA.SentinelConfig = map[string]string{"down-after-milliseconds": "5000"}
+
+	haproxyCfg = getRedisHAProxyConfig(cr, false)
+	assert.Contains(t, haproxyCfg, "    timeout server 30m\n")
+	assert.Contains(t, haproxyCfg, "backend check_if_redis_is_master_4\n")
+	assert.Contains(t, haproxyCfg, "    server R4 argocd-redis-ha-announce-4:6379 check inter 3s fall 1 rise 1\n")
+	assert.Contains(t, haproxyCfg, "{ nbsrv(check_if_redis_is_master_4) ge 3 }")
+	assert.Contains(t, getRedisHAProxyScript(cr), `sed -i "s/REPLACE_ANNOUNCE4$/$ANNOUNCE_IP4/" "$HAPROXY_CONF"`)
+	assert.Contains(t, getRedisInitScript(cr, false), `QUORUM="3"`)
+	assert.Contains(t, getRedisSentinelConf(cr, false), "    sentinel down-after-milliseconds argocd 5000\n")
+
+	env := getRedisHASentinelIDEnv(cr)
+	assert.Len(t, env, 5)
+	assert.Equal(t, "SENTINEL_ID_4", env[4].Name)
+	assert.Len(t, env[4].Value, 40)
+	assert.NotEqual(t, env[3].Value, env[4].Value)
+}
+
+func TestGetRedisConf_persistence(t *testing.T) {
+	t.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+	cr := makeTestArgoCD()
+
+	assert.Contains(t, getRedisConf(cr, false), "save \"\"\n")
+	assert.NotContains(t, getRedisConf(cr, false), "appendonly yes")
+
+	cr.Spec.Redis.Persistence = &v1alpha1.ArgoCDRedisPersistenceSpec{Enabled: true}
+	assert.Contains(t, getRedisConf(cr, false), "appendonly yes\n")
+	assert.NotContains(t, getRedisConf(cr, false), "save \"\"")
+}
+
+func TestGetRedisHAConfiguration_auth(t *testing.T) {
+	t.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+	cr := makeTestArgoCD()
+
+	assert.NotContains(t, getRedisConf(cr, false), "requirepass")
+	assert.NotContains(t, getRedisHAProxyConfig(cr, false), "REPLACE_AUTH_SECRET")
+
+	cr.Spec.Redis.EnableAuth = true
+	assert.Contains(t, getRedisConf(cr, false), "requirepass replace-default-auth\nmasterauth replace-default-auth\n")
+	assert.Contains(t, getRedisSentinelConf(cr, false), "    sentinel auth-pass argocd replace-default-auth\n")
+	assert.Contains(t, getRedisHAProxyConfig(cr, false), "    tcp-check send AUTH\\ REPLACE_AUTH_SECRET\\r\\n\n")
+	assert.Contains(t, getRedisInitScript(cr, false), `redis-cli -h "${MASTER}" -p "${REDIS_PORT}" -a "${AUTH}" --no-auth-warning ping`)
+	assert.Contains(t, getRedisLivenessScript(cr, false), `-a "${AUTH}"`)
+	assert.Contains(t, getRedisReadinessScript(cr, false), `-a "${AUTH}"`)
+
+	// Redis auth only applies to the Redis server deployed by the operator
+	cr.Spec.Redis.Remote = &v1alpha1.ArgoCDRedisRemoteSpec{Address: "redis.example.com:6379"}
+	assert.False(t, isRedisAuthEnabled(cr))
+}
+
+func TestResourceContentFilterPredicate(t *testing.T) {
+	pred := resourceContentFilterPredicate()
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-secret", Namespace: testNamespace, ResourceVersion: "1"},
+		Data:       map[string][]byte{"key": []byte("value")},
+	}
+
+	// updates that do not change the content are ignored
+	updated := secret.DeepCopy()
+	updated.ResourceVersion = "2"
+	updated.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+	assert.False(t, pred.Update(event.UpdateEvent{ObjectOld: secret, ObjectNew: updated}))
+
+	updated.Data["key"] = []byte("changed")
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: secret, ObjectNew: updated}))
+
+	updated = secret.DeepCopy()
+	updated.Labels = map[string]string{common.ArgoCDSecretTypeLabel: "cluster"}
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: secret, ObjectNew: updated}))
+
+	cm := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "argocd-cm", Namespace: testNamespace}}
+	updatedCM := cm.DeepCopy()
+	assert.False(t, pred.Update(event.UpdateEvent{ObjectOld: cm, ObjectNew: updatedCM}))
+	updatedCM.Data = map[string]string{"key": "value"}
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: cm, ObjectNew: updatedCM}))
+	assert.True(t, pred.Create(event.CreateEvent{Object: cm}))
+
+	// resources that are never used by ArgoCD instances are ignored
+	helmRelease := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sh.helm.release.v1.app.v1", Namespace: testNamespace},
+		Type:       helmReleaseSecretType,
+	}
+	assert.False(t, pred.Create(event.CreateEvent{Object: helmRelease}))
+	assert.False(t, pred.Delete(event.DeleteEvent{Object: helmRelease}))
+
+	nextKey := &v1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      "argocd-server-tls-abcde",
+		Namespace: testNamespace,
+		Labels:    map[string]string{"cert-manager.io/next-private-key": "true"},
+	}}
+	updatedKey := nextKey.DeepCopy()
+	updatedKey.Data = map[string][]byte{"tls.key": []byte("key")}
+	assert.False(t, pred.Create(event.CreateEvent{Object: nextKey}))
+	assert.False(t, pred.Update(event.UpdateEvent{ObjectOld: nextKey, ObjectNew: updatedKey}))
+}
@@ -16,8 +16,13 @@ package argocdexport
 
 import (
 	"context"
+	"strconv"
+	"strings"
 
 	"github.com/sethvargo/go-password/password"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
@@ -56,7 +61,84 @@ func (r *ReconcileArgoCDExport) reconcileExport(cr *argoprojv1a1.ArgoCDExport) e
 		}
 	}
 
-	return nil
+	log.Info("reconciling export status")
+	return r.reconcileExportStatus(cr)
+}
+
+// reconcileExportStatus will ensure that the status of the ArgoCDExport reports the most recent successful export.
+func (r *ReconcileArgoCDExport) reconcileExportStatus(cr *argoprojv1a1.ArgoCDExport) error {
+	jobs := &batchv1.JobList{}
+	if err := r.Client.List(context.TODO(), jobs, client.InNamespace(cr.Namespace), client.MatchingLabels(common.DefaultLabels(cr.Name))); err != nil {
+		return err
+	}
+
+	var last *batchv1.Job
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if job.Status.Succeeded <= 0 || job.Status.CompletionTime == nil {
+			continue
+		}
+		if last == nil || job.Status.CompletionTime.After(last.Status.CompletionTime.Time) {
+			last = job
+		}
+	}
+	if last == nil {
+		return nil // No successful export yet
+	}
+	if cr.Status.LastSuccessfulExportTime != nil && !last.Status.CompletionTime.After(cr.Status.LastSuccessfulExportTime.Time) {
+		return nil // Already reported
+	}
+
+	size, fingerprint, err := r.getExportReport(last)
+	if err != nil {
+		return err
+	}
+	cr.Status.LastSuccessfulExportTime = last.Status.CompletionTime.DeepCopy()
+	cr.Status.LastExportSize = size
+	cr.Status.LastExportKeyFingerprint = fingerprint
+	return r.Client.Status().Update(context.TODO(), cr)
+}
+
+// getExportReport will return the size of the export archive and the fingerprint of its encryption key, that the
+// export process of the given Job reports in the termination message of its container as "key=value" lines.
+func (r *ReconcileArgoCDExport) getExportReport(job *batchv1.Job) (*int64, string, error) {
+	pods := &corev1.PodList{}
+	if err := r.Client.List(context.TODO(), pods, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return nil, "", err
+	}
+
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			terminated := status.State.Terminated
+			if terminated == nil || terminated.ExitCode != 0 {
+				continue
+			}
+			size, fingerprint := parseExportReport(terminated.Message)
+			return size, fingerprint, nil
+		}
+	}
+	return nil, "", nil
+}
+
+// parseExportReport will return the size and key fingerprint from the given termination message of the export process.
+func parseExportReport(message string) (*int64, string) {
+	var size *int64
+	fingerprint := ""
+	for _, line := range strings.Split(message, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "size":
+			if val, err := strconv.ParseInt(value, 10, 64); err == nil {
+				size = &val
+			}
+		case "keyFingerprint":
+			fingerprint = value
+		}
+	}
+	return size, fingerprint
 }
 
 // reconcileExportSecret will ensure that the Secret used for the export process is present.
@@ -66,9 +148,11 @@ func (r *ReconcileArgoCDExport) reconcileExportSecret(cr *argoprojv1a1.ArgoCDExp
 	a := &argoprojv1a1.ArgoCD{}
 	a.ObjectMeta = cr.ObjectMeta
 	secret := argoutil.NewSecretWithName(a, name)
+	// The backup key is only generated when it is not held by a referenced Secret
+	generateKey := cr.Spec.Storage == nil || cr.Spec.Storage.EncryptionKeySecretRef == nil
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, name, secret) {
 		backupKey := secret.Data[common.ArgoCDKeyBackupKey]
-		if len(backupKey) <= 0 {
+		if len(backupKey) <= 0 && generateKey {
 			backupKey, err := generateBackupKey()
 			if err != nil {
 				return err
@@ -80,13 +164,13 @@ func (r *ReconcileArgoCDExport) reconcileExportSecret(cr *argoprojv1a1.ArgoCDExp
 		return nil // TODO: Handle case where backup key changes, should trigger a new export?
 	}
 
-	backupKey, err := generateBackupKey()
-	if err != nil {
-		return err
-	}
-
-	secret.Data = map[string][]byte{
-		common.ArgoCDKeyBackupKey: backupKey,
+	secret.Data = map[string][]byte{}
+	if generateKey {
+		backupKey, err := generateBackupKey()
+		if err != nil {
+			return err
+		}
+		secret.Data[common.ArgoCDKeyBackupKey] = backupKey
 	}
 
 	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
@@ -23,6 +23,7 @@ import (
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -72,6 +73,33 @@ func getArgoExportContainerEnv(cr *argoprojv1a1.ArgoCDExport) []corev1.EnvVar {
 		})
 	}
 
+	env = append(env, argoutil.FetchStorageLocationEnv(cr)...)
+	env = append(env, argoutil.FetchEncryptionKeyEnv(cr)...)
+	env = append(env, getArgoExportRetentionEnv(cr)...)
+
+	return env
+}
+
+// getArgoExportRetentionEnv will return the environment variables that configure the pruning of old exports for the
+// given scheduled ArgoCDExport.
+func getArgoExportRetentionEnv(cr *argoprojv1a1.ArgoCDExport) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0)
+	if cr.Spec.Schedule == nil || len(*cr.Spec.Schedule) <= 0 || cr.Spec.Retention == nil {
+		return env
+	}
+
+	if cr.Spec.Retention.KeepLast != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ArgoCDExportRetentionKeepLastEnvName,
+			Value: fmt.Sprint(*cr.Spec.Retention.KeepLast),
+		})
+	}
+	if cr.Spec.Retention.MaxAge != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ArgoCDExportRetentionMaxAgeEnvName,
+			Value: fmt.Sprint(int64(cr.Spec.Retention.MaxAge.Seconds())),
+		})
+	}
 	return env
 }
 
@@ -91,7 +119,7 @@ func getArgoExportContainerImage(cr *argoprojv1a1.ArgoCDExport) string {
 }
 
 // getArgoExportVolumeMounts will return the VolumneMounts for the given ArgoCDExport.
-func getArgoExportVolumeMounts() []corev1.VolumeMount {
+func getArgoExportVolumeMounts(cr *argoprojv1a1.ArgoCDExport) []corev1.VolumeMount {
 	mounts := make([]corev1.VolumeMount, 0)
 
 	mounts = append(mounts, corev1.VolumeMount{
@@ -104,6 +132,10 @@ func getArgoExportVolumeMounts() []corev1.VolumeMount {
 		MountPath: "/secrets",
 	})
 
+	if _, mount := argoutil.FetchEncryptionKeyVolume(cr); mount != nil {
+		mounts = append(mounts, *mount)
+	}
+
 	return mounts
 }
 
@@ -113,15 +145,30 @@ func getArgoSecretVolume(name string, cr *argoprojv1a1.ArgoCDExport) corev1.Volu
 		Name: name,
 	}
 
+	// Set the default mode explicitly, so that the volume can be compared with the one of an existing CronJob
+	defaultMode := corev1.SecretVolumeSourceDefaultMode
 	volume.VolumeSource = corev1.VolumeSource{
 		Secret: &corev1.SecretVolumeSource{
-			SecretName: argoutil.FetchStorageSecretName(cr),
+			DefaultMode: &defaultMode,
+			SecretName:  argoutil.FetchStorageSecretName(cr),
 		},
 	}
 
 	return volume
 }
 
+// getArgoExportVolumes will return the Volumes for the export process of the given ArgoCDExport.
+func getArgoExportVolumes(cr *argoprojv1a1.ArgoCDExport) []corev1.Volume {
+	volumes := []corev1.Volume{
+		getArgoStorageVolume("backup-storage", cr),
+		getArgoSecretVolume("secret-storage", cr),
+	}
+	if volume, _ := argoutil.FetchEncryptionKeyVolume(cr); volume != nil {
+		volumes = append(volumes, *volume)
+	}
+	return volumes
+}
+
 // getArgoStorageVolume will return the storage Volume for the export process.
 func getArgoStorageVolume(name string, cr *argoprojv1a1.ArgoCDExport) corev1.Volume {
 	volume := corev1.Volume{
@@ -187,15 +234,12 @@ func newExportPodSpec(cr *argoprojv1a1.ArgoCDExport, argocdName string, client c
 			},
 			RunAsNonRoot: boolPtr(true),
 		},
-		VolumeMounts: getArgoExportVolumeMounts(),
+		VolumeMounts: getArgoExportVolumeMounts(cr),
 	}}
 
 	pod.RestartPolicy = corev1.RestartPolicyOnFailure
 	pod.ServiceAccountName = fmt.Sprintf("%s-%s", argocdName, "argocd-application-controller")
-	pod.Volumes = []corev1.Volume{
-		getArgoStorageVolume("backup-storage", cr),
-		getArgoSecretVolume("secret-storage", cr),
-	}
+	pod.Volumes = getArgoExportVolumes(cr)
 
 	// Configure runAsUser, runAsGroup and fsGroup so that the job can write to the PV
 	// 999 is the uid/gid of the argocd user that the container runs as
@@ -229,8 +273,29 @@ func (r *ReconcileArgoCDExport) reconcileCronJob(cr *argoprojv1a1.ArgoCDExport)
 
 	cj := newCronJob(cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cj.Name, cj) {
+		changed := false
 		if *cr.Spec.Schedule != cj.Spec.Schedule {
 			cj.Spec.Schedule = *cr.Spec.Schedule
+			changed = true
+		}
+		// Update the storage location, encryption key and retention policy of the export process
+		podSpec := &cj.Spec.JobTemplate.Spec.Template.Spec
+		if len(podSpec.Containers) > 0 {
+			container := &podSpec.Containers[0]
+			if env := getArgoExportContainerEnv(cr); !equality.Semantic.DeepEqual(env, container.Env) {
+				container.Env = env
+				changed = true
+			}
+			if mounts := getArgoExportVolumeMounts(cr); !equality.Semantic.DeepEqual(mounts, container.VolumeMounts) {
+				container.VolumeMounts = mounts
+				changed = true
+			}
+		}
+		if volumes := getArgoExportVolumes(cr); !equality.Semantic.DeepEqual(volumes, podSpec.Volumes) {
+			podSpec.Volumes = volumes
+			changed = true
+		}
+		if changed {
 			return r.Client.Update(context.TODO(), cj)
 		}
 		return nil
@@ -248,6 +313,8 @@ func (r *ReconcileArgoCDExport) reconcileCronJob(cr *argoprojv1a1.ArgoCDExport)
 	job := newJob(cr)
 	job.Spec.Template = newPodTemplateSpec(cr, argocdName, r.Client)
 
+	// The labels of the Jobs are used to report the most recent successful export in the status
+	cj.Spec.JobTemplate.ObjectMeta.Labels = job.Labels
 	cj.Spec.JobTemplate.Spec = job.Spec
 
 	if err := controllerutil.SetControllerReference(cr, cj, r.Scheme); err != nil {
@@ -17,10 +17,16 @@ package argocdexport
 import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
 	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
 )
 
 // reconcileArgoCDExportResources will reconcile all ArgoCDExport resources for the give CR.
@@ -50,6 +56,9 @@ func setResourceWatches(bld *builder.Builder) *builder.Builder {
 	// Watch for changes to Job sub-resources owned by ArgoCD instances.
 	bld.Owns(&batchv1.Job{})
 
+	// Watch for changes to the Jobs created by the CronJobs of ArgoCDExport instances.
+	bld.Watches(&source.Kind{Type: &batchv1.Job{}}, handler.EnqueueRequestsFromMapFunc(cronJobExportMapper))
+
 	// Watch for changes to PersistentVolumeClaim sub-resources owned by ArgoCD instances.
 	bld.Owns(&corev1.PersistentVolumeClaim{})
 
@@ -58,3 +67,14 @@ func setResourceWatches(bld *builder.Builder) *builder.Builder {
 
 	return bld
 }
+
+// cronJobExportMapper maps a Job created by the CronJob of an ArgoCDExport to a reconcile request for the ArgoCDExport,
+// as the Job is owned by the CronJob, not the ArgoCDExport.
+func cronJobExportMapper(o client.Object) []reconcile.Request {
+	for _, ref := range o.GetOwnerReferences() {
+		if ref.Kind == "CronJob" && ref.Name == o.GetLabels()[common.ArgoCDKeyName] {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: o.GetNamespace(), Name: ref.Name}}}
+		}
+	}
+	return nil
+}
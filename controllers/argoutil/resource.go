@@ -87,6 +87,65 @@ func FetchStorageSecretName(export *argoprojv1a1.ArgoCDExport) string {
 	return name
 }
 
+// FetchStorageLocationEnv will return the environment variables that set the bucket and prefix of the export archive
+// for the cloud provider storage backends of the given ArgoCDExport.
+func FetchStorageLocationEnv(export *argoprojv1a1.ArgoCDExport) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0)
+	if export.Spec.Storage == nil || strings.ToLower(export.Spec.Storage.Backend) == common.ArgoCDExportStorageBackendLocal {
+		return env
+	}
+
+	if len(export.Spec.Storage.Bucket) > 0 {
+		env = append(env, corev1.EnvVar{Name: common.ArgoCDExportBucketEnvName, Value: export.Spec.Storage.Bucket})
+	}
+	if prefix := strings.Trim(export.Spec.Storage.Prefix, "/"); len(prefix) > 0 {
+		env = append(env, corev1.EnvVar{Name: common.ArgoCDExportPrefixEnvName, Value: prefix})
+	}
+	return env
+}
+
+// FetchEncryptionKeyEnv will return the environment variables that set the location of the encryption key for the
+// export and import processes of the given ArgoCDExport, when the key is held by a referenced Secret.
+func FetchEncryptionKeyEnv(export *argoprojv1a1.ArgoCDExport) []corev1.EnvVar {
+	if export.Spec.Storage == nil || export.Spec.Storage.EncryptionKeySecretRef == nil {
+		return []corev1.EnvVar{}
+	}
+	return []corev1.EnvVar{{
+		Name:  common.ArgoCDExportKeyLocationEnvName,
+		Value: fmt.Sprintf("%s/%s", common.ArgoCDExportEncryptionKeyMountPath, common.ArgoCDKeyBackupKey),
+	}}
+}
+
+// FetchEncryptionKeyVolume will return the Volume and VolumeMount of the Secret referenced for the encryption key of
+// the given ArgoCDExport, or nil if the key is held by the export Secret.
+func FetchEncryptionKeyVolume(export *argoprojv1a1.ArgoCDExport) (*corev1.Volume, *corev1.VolumeMount) {
+	if export.Spec.Storage == nil || export.Spec.Storage.EncryptionKeySecretRef == nil {
+		return nil, nil
+	}
+	ref := export.Spec.Storage.EncryptionKeySecretRef
+	defaultMode := corev1.SecretVolumeSourceDefaultMode
+	volume := &corev1.Volume{
+		Name: "encryption-key",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				DefaultMode: &defaultMode,
+				SecretName:  ref.Name,
+				Items: []corev1.KeyToPath{{
+					Key:  ref.Key,
+					Path: common.ArgoCDKeyBackupKey,
+				}},
+				Optional: ref.Optional,
+			},
+		},
+	}
+	mount := &corev1.VolumeMount{
+		Name:      volume.Name,
+		MountPath: common.ArgoCDExportEncryptionKeyMountPath,
+		ReadOnly:  true,
+	}
+	return volume, mount
+}
+
 // IsObjectFound will perform a basic check that the given object exists via the Kubernetes API.
 // If an error occurs as part of the check, the function will return false.
 func IsObjectFound(client client.Client, namespace string, name string, obj client.Object) bool {
@@ -18,6 +18,7 @@ import (
 	"reflect"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
@@ -57,3 +58,79 @@ func TestDefaultAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchStorageLocationEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage *argoprojv1a1.ArgoCDExportStorageSpec
+		want    []corev1.EnvVar
+	}{
+		{
+			name:    "no storage",
+			storage: nil,
+			want:    []corev1.EnvVar{},
+		},
+		{
+			name: "local backend ignores bucket and prefix",
+			storage: &argoprojv1a1.ArgoCDExportStorageSpec{
+				Backend: common.ArgoCDExportStorageBackendLocal,
+				Bucket:  "backups",
+				Prefix:  "argocd",
+			},
+			want: []corev1.EnvVar{},
+		},
+		{
+			name: "bucket and prefix",
+			storage: &argoprojv1a1.ArgoCDExportStorageSpec{
+				Backend: common.ArgoCDExportStorageBackendGCP,
+				Bucket:  "backups",
+				Prefix:  "/clusters/prod/",
+			},
+			want: []corev1.EnvVar{
+				{Name: common.ArgoCDExportBucketEnvName, Value: "backups"},
+				{Name: common.ArgoCDExportPrefixEnvName, Value: "clusters/prod"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			export := &argoprojv1a1.ArgoCDExport{}
+			export.Spec.Storage = tt.storage
+			if got := FetchStorageLocationEnv(export); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FetchStorageLocationEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchEncryptionKeyVolume(t *testing.T) {
+	export := &argoprojv1a1.ArgoCDExport{}
+	export.Spec.Storage = &argoprojv1a1.ArgoCDExportStorageSpec{}
+
+	volume, mount := FetchEncryptionKeyVolume(export)
+	if volume != nil || mount != nil {
+		t.Errorf("FetchEncryptionKeyVolume() = %v, %v, want nil", volume, mount)
+	}
+	if env := FetchEncryptionKeyEnv(export); len(env) != 0 {
+		t.Errorf("FetchEncryptionKeyEnv() = %v, want empty", env)
+	}
+
+	export.Spec.Storage.EncryptionKeySecretRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "backup-passphrase"},
+		Key:                  "passphrase",
+	}
+	volume, mount = FetchEncryptionKeyVolume(export)
+	if volume == nil || mount == nil {
+		t.Fatalf("FetchEncryptionKeyVolume() = nil, want volume")
+	}
+	if volume.Secret.SecretName != "backup-passphrase" || !reflect.DeepEqual(volume.Secret.Items, []corev1.KeyToPath{{Key: "passphrase", Path: common.ArgoCDKeyBackupKey}}) {
+		t.Errorf("FetchEncryptionKeyVolume() volume = %v", volume)
+	}
+	if mount.Name != volume.Name || mount.MountPath != common.ArgoCDExportEncryptionKeyMountPath {
+		t.Errorf("FetchEncryptionKeyVolume() mount = %v", mount)
+	}
+	want := []corev1.EnvVar{{Name: common.ArgoCDExportKeyLocationEnvName, Value: "/encryption-key/backup.key"}}
+	if env := FetchEncryptionKeyEnv(export); !reflect.DeepEqual(env, want) {
+		t.Errorf("FetchEncryptionKeyEnv() = %v, want %v", env, want)
+	}
+}
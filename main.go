@@ -50,6 +50,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	argoprojiov1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoprojiov1beta1 "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/version"
 	//+kubebuilder:scaffold:imports
 )
@@ -63,6 +64,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(argoprojiov1alpha1.AddToScheme(scheme))
+	utilruntime.Must(argoprojiov1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -75,13 +77,38 @@ func printVersion() {
 
 func main() {
 	var metricsAddr string
-	var enableLeaderElection bool
 	var probeAddr string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
-	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
+	var leaderElectionOptions argocd.LeaderElectionOptions
+	flag.BoolVar(&leaderElectionOptions.Enabled, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&leaderElectionOptions.LeaseDuration, "leader-elect-lease-duration", 0,
+		"The duration standby replicas wait before taking over the leadership of a leader that stopped renewing it. "+
+			"Defaults to the LEADER_ELECTION_LEASE_DURATION environment variable, or 15s.")
+	flag.DurationVar(&leaderElectionOptions.RenewDeadline, "leader-elect-renew-deadline", 0,
+		"The duration the leader retries renewing its leadership before giving it up. "+
+			"Defaults to the LEADER_ELECTION_RENEW_DEADLINE environment variable, or 10s.")
+	flag.DurationVar(&leaderElectionOptions.RetryPeriod, "leader-elect-retry-period", 0,
+		"The interval at which the replicas try to acquire or renew the leadership. "+
+			"Defaults to the LEADER_ELECTION_RETRY_PERIOD environment variable, or 2s.")
+	var reconcilerOptions argocd.ReconcilerOptions
+	flag.DurationVar(&reconcilerOptions.SyncPeriod, "sync-period", 0,
+		"The interval at which all ArgoCD instances are reconciled again. "+
+			"Defaults to the SYNC_PERIOD environment variable, or 10h.")
+	flag.IntVar(&reconcilerOptions.MaxConcurrentReconciles, "max-concurrent-reconciles", 0,
+		"The number of ArgoCD instances reconciled in parallel. "+
+			"Defaults to the MAX_CONCURRENT_RECONCILES environment variable, or 1.")
+	flag.DurationVar(&reconcilerOptions.ReconcileBaseDelay, "reconcile-base-delay", 0,
+		"The initial delay before a failed reconciliation is retried, doubled with every failure. "+
+			"Defaults to the RECONCILE_BASE_DELAY environment variable, or 5ms.")
+	flag.DurationVar(&reconcilerOptions.ReconcileMaxDelay, "reconcile-max-delay", 0,
+		"The maximum delay before a failed reconciliation is retried. "+
+			"Defaults to the RECONCILE_MAX_DELAY environment variable, or 1000s.")
+	flag.StringVar(&reconcilerOptions.LabelSelector, "label-selector", "",
+		"Only reconcile the ArgoCD instances whose labels match this selector. "+
+			"Defaults to the ARGOCD_LABEL_SELECTOR environment variable, or all instances.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -92,6 +119,28 @@ func main() {
 
 	printVersion()
 
+	if err := reconcilerOptions.Complete(); err != nil {
+		setupLog.Error(err, "invalid reconciler options")
+		os.Exit(1)
+	}
+	setupLog.Info(fmt.Sprintf("Reconciling with sync period %s, %d concurrent reconciles and backoff from %s to %s",
+		reconcilerOptions.SyncPeriod, reconcilerOptions.MaxConcurrentReconciles,
+		reconcilerOptions.ReconcileBaseDelay, reconcilerOptions.ReconcileMaxDelay))
+	if err := leaderElectionOptions.Complete(); err != nil {
+		setupLog.Error(err, "invalid leader election options")
+		os.Exit(1)
+	}
+	if leaderElectionOptions.Enabled {
+		setupLog.Info(fmt.Sprintf("Electing a leader with lease duration %s, renew deadline %s and retry period %s",
+			leaderElectionOptions.LeaseDuration, leaderElectionOptions.RenewDeadline, leaderElectionOptions.RetryPeriod))
+	}
+	if reconcilerOptions.LabelSelector != "" {
+		setupLog.Info(fmt.Sprintf("Only reconciling ArgoCD instances matching the label selector %q", reconcilerOptions.LabelSelector))
+	}
+	if profile := os.Getenv(common.ArgoCDSizingProfileEnvName); profile != "" {
+		setupLog.Info(fmt.Sprintf("Applying the %s sizing profile to the components without resources", profile))
+	}
+
 	// Inspect cluster to verify availability of extra features
 	if err := argocd.InspectCluster(); err != nil {
 		setupLog.Info("unable to inspect cluster")
@@ -101,26 +150,35 @@ func main() {
 	if err != nil {
 		setupLog.Error(err, "Failed to get watch namespace, defaulting to all namespace mode")
 	}
-	setupLog.Info(fmt.Sprintf("Watching namespace \"%s\"", namespace))
+	reconcilerOptions.WatchNamespaces = argocd.ParseWatchNamespaces(namespace)
+	if len(reconcilerOptions.WatchNamespaces) == 0 {
+		setupLog.Info("Watching all namespaces")
+	} else {
+		setupLog.Info(fmt.Sprintf("Watching namespaces \"%s\"", strings.Join(reconcilerOptions.WatchNamespaces, ",")))
+	}
 
 	// Set default manager options
 	options := manager.Options{
-		Namespace:              namespace,
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
 		Port:                   9443,
 		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "b674928d.argoproj.io",
+		SyncPeriod:             &reconcilerOptions.SyncPeriod,
 	}
+	leaderElectionOptions.Apply(&options)
 
-	// Add support for MultiNamespace set in WATCH_NAMESPACE (e.g ns1,ns2)
-	// Note that this is not intended to be used for excluding namespaces, this is better done via a Predicate
+	// Restrict the cache to the namespaces set in WATCH_NAMESPACE (e.g ns1,ns2), cluster-scoped resources are always
+	// watched. Note that this is not intended to be used for excluding namespaces, this is better done via a Predicate
 	// Also note that you may face performance issues when using this with a high number of namespaces.
 	// More Info: https://godoc.org/github.com/kubernetes-sigs/controller-runtime/pkg/cache#MultiNamespacedCacheBuilder
-	if strings.Contains(namespace, ",") {
-		options.Namespace = ""
-		options.NewCache = cache.MultiNamespacedCacheBuilder(strings.Split(namespace, ","))
+	switch len(reconcilerOptions.WatchNamespaces) {
+	case 0:
+		// all namespaces
+	case 1:
+		options.Namespace = reconcilerOptions.WatchNamespaces[0]
+	default:
+		options.NewCache = cache.MultiNamespacedCacheBuilder(reconcilerOptions.WatchNamespaces)
 	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
@@ -136,6 +194,10 @@ func main() {
 		setupLog.Error(err, "")
 		os.Exit(1)
 	}
+	if err := argoprojiov1beta1.AddToScheme(mgr.GetScheme()); err != nil {
+		setupLog.Error(err, "")
+		os.Exit(1)
+	}
 
 	// Setup Scheme for Prometheus if available.
 	if argocd.IsPrometheusAPIAvailable() {
@@ -178,8 +240,9 @@ func main() {
 	}
 
 	if err = (&argocd.ReconcileArgoCD{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Options: reconcilerOptions,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ArgoCD")
 		os.Exit(1)
@@ -191,6 +254,20 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "ArgoCDExport")
 		os.Exit(1)
 	}
+
+	// The conversion and defaulting webhooks require a serving certificate, and are only started when enabled.
+	if strings.EqualFold(os.Getenv(common.ArgoCDEnableConversionWebhookEnvName), "true") {
+		if err = (&argoprojiov1beta1.ArgoCD{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ArgoCD")
+			os.Exit(1)
+		}
+	}
+	if strings.EqualFold(os.Getenv(common.ArgoCDEnableDefaultingWebhookEnvName), "true") {
+		if err = (&argoprojiov1alpha1.ArgoCD{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ArgoCD")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -201,6 +278,12 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	// Standby replicas are not ready until they are elected as the leader. The probe of the operator Deployment
+	// excludes this check, so that standby replicas do not block its rollouts.
+	if err := mgr.AddReadyzCheck("leader", argocd.LeaderReadyCheck(mgr.Elected())); err != nil {
+		setupLog.Error(err, "unable to set up leader check")
+		os.Exit(1)
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {